@@ -0,0 +1,160 @@
+// Package backoff implements exponential backoff with jitter, shared by all
+// subsystems that retry operations or space out periodic attempts.
+package backoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Schedule describes a backoff schedule. The n-th delay (starting at n=0) is
+// drawn uniformly from [d, d*(1+Jitter)), where
+// d = min(Initial * Multiplier^n, Max).
+type Schedule struct {
+	// Delay before the first retry. Must be positive.
+	Initial time.Duration
+	// Upper bound on delays before applying jitter. Must be at least Initial.
+	Max time.Duration
+	// Factor by which the delay grows with each attempt. Must be at least 1.
+	// A Multiplier of 1 results in a constant delay.
+	Multiplier float64
+	// Fraction of the delay that is added as random jitter. Must be in [0, 1].
+	Jitter float64
+}
+
+// Constant returns a schedule with constant delay d plus up to jitter*d of
+// jitter.
+func Constant(d time.Duration, jitter float64) Schedule {
+	return Schedule{d, d, 1, jitter}
+}
+
+func (s Schedule) Validate() error {
+	if !(0 < s.Initial) {
+		return fmt.Errorf("backoff: Initial (%v) must be positive", s.Initial)
+	}
+	if !(s.Initial <= s.Max) {
+		return fmt.Errorf("backoff: Max (%v) must be at least Initial (%v)", s.Max, s.Initial)
+	}
+	if !(1 <= s.Multiplier) {
+		return fmt.Errorf("backoff: Multiplier (%v) must be at least 1", s.Multiplier)
+	}
+	if !(0 <= s.Jitter && s.Jitter <= 1) {
+		return fmt.Errorf("backoff: Jitter (%v) must be between 0 and 1", s.Jitter)
+	}
+	return nil
+}
+
+// String returns a compact description of the schedule, suitable for logs.
+func (s Schedule) String() string {
+	return fmt.Sprintf("initial=%v max=%v multiplier=%v jitter=%v", s.Initial, s.Max, s.Multiplier, s.Jitter)
+}
+
+// Delay returns the (jittered) delay to use before attempt+1, where the first
+// attempt is 0.
+func (s Schedule) Delay(attempt int) time.Duration {
+	d := float64(s.Initial) * math.Pow(s.Multiplier, float64(attempt))
+	if math.IsNaN(d) || d > float64(s.Max) {
+		d = float64(s.Max)
+	}
+	return time.Duration(d * (1 + s.Jitter*rand.Float64()))
+}
+
+// Backoff tracks the current position in a Schedule. It is not thread-safe.
+type Backoff struct {
+	schedule Schedule
+	attempt  int
+}
+
+func New(schedule Schedule) *Backoff {
+	return &Backoff{schedule, 0}
+}
+
+func (b *Backoff) Schedule() Schedule {
+	return b.schedule
+}
+
+// Next returns the next delay and advances the schedule.
+func (b *Backoff) Next() time.Duration {
+	d := b.schedule.Delay(b.attempt)
+	if b.attempt < math.MaxInt32 {
+		b.attempt++
+	}
+	return d
+}
+
+// Reset moves back to the start of the schedule, e.g. after a success.
+func (b *Backoff) Reset() {
+	b.attempt = 0
+}
+
+// Wait sleeps for the next delay or until ctx is done, whichever happens
+// first. It returns ctx.Err() in the latter case.
+func (b *Backoff) Wait(ctx context.Context) error {
+	select {
+	case <-time.After(b.Next()):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent wraps err to make Retry give up immediately and return err, e.g.
+// because retrying can't possibly help. Permanent(nil) is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// Retry calls fn until it succeeds, fails with an error wrapped by Permanent,
+// or ctx is done, backing off according to schedule between attempts. onError,
+// if not nil, is called after each failed attempt that is retried with the
+// error and the delay before the next attempt.
+func Retry[T any](
+	ctx context.Context,
+	schedule Schedule,
+	fn func(context.Context) (T, error),
+	onError func(err error, delay time.Duration),
+) (T, error) {
+	b := New(schedule)
+	for {
+		result, err := fn(ctx)
+		if err == nil {
+			return result, nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			var zero T
+			return zero, permanent.err
+		}
+
+		delay := b.Next()
+		if onError != nil {
+			onError(err, delay)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,311 @@
+// Command bootstrapnode runs a standalone ragep2p bootstrap node for a set of
+// protocol instances, without the rest of an oracle node.
+//
+// Unlike offchainreporting2plus.Bootstrapper, bootstrapnode doesn't follow
+// config changes onchain. Instead, the protocol instances to bootstrap are
+// listed in a JSON config file, which is re-read upon SIGHUP. Instances that
+// were removed from the file are shut down, new ones are started, and
+// unchanged ones keep running undisturbed. Example config:
+//
+//	{
+//	  "keyFile": "/etc/bootstrapnode/key",
+//	  "listenAddresses": ["0.0.0.0:6690"],
+//	  "announceAddresses": ["bootstrap.example.com:6690"],
+//	  "deltaReconcile": "1m",
+//	  "deltaDial": "15s",
+//	  "instances": [
+//	    {
+//	      "configDigest": "000e...",
+//	      "f": 1,
+//	      "peerIDs": ["12D3KooW...", "12D3KooW...", "12D3KooW...", "12D3KooW..."],
+//	      "bootstrappers": ["12D3KooW...@other-bootstrap.example.com:6690"]
+//	    }
+//	  ]
+//	}
+//
+// The key file contains the hex-encoded Ed25519 private key that determines
+// the node's peer ID. Use -generate-key to create one.
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/networking"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/ragep2p/loggers"
+	ragetypes "github.com/smartcontractkit/libocr/ragep2p/types"
+)
+
+const (
+	defaultDeltaReconcile = 1 * time.Minute
+	defaultDeltaDial      = 15 * time.Second
+)
+
+// duration is a time.Duration that is encoded as a string such as "15s" in
+// JSON.
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(parsed)
+	return nil
+}
+
+type instanceConfig struct {
+	ConfigDigest  string                            `json:"configDigest"`
+	F             int                               `json:"f"`
+	PeerIDs       []string                          `json:"peerIDs"`
+	Bootstrappers []commontypes.BootstrapperLocator `json:"bootstrappers"`
+}
+
+type config struct {
+	KeyFile           string           `json:"keyFile"`
+	ListenAddresses   []string         `json:"listenAddresses"`
+	AnnounceAddresses []string         `json:"announceAddresses"`
+	DeltaReconcile    duration         `json:"deltaReconcile"`
+	DeltaDial         duration         `json:"deltaDial"`
+	Instances         []instanceConfig `json:"instances"`
+}
+
+func loadConfig(path string) (config, map[types.ConfigDigest]instanceConfig, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return config{}, nil, err
+	}
+	c := config{"", nil, nil, duration(defaultDeltaReconcile), duration(defaultDeltaDial), nil}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&c); err != nil {
+		return config{}, nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	if c.KeyFile == "" {
+		return config{}, nil, fmt.Errorf("keyFile must be set")
+	}
+	if len(c.ListenAddresses) == 0 {
+		return config{}, nil, fmt.Errorf("listenAddresses must not be empty")
+	}
+	if c.DeltaReconcile <= 0 || c.DeltaDial <= 0 {
+		return config{}, nil, fmt.Errorf("deltaReconcile and deltaDial must be positive")
+	}
+
+	instances := map[types.ConfigDigest]instanceConfig{}
+	for i, instance := range c.Instances {
+		digestBytes, err := hex.DecodeString(strings.TrimPrefix(instance.ConfigDigest, "0x"))
+		if err != nil {
+			return config{}, nil, fmt.Errorf("instance %v: invalid configDigest: %w", i, err)
+		}
+		configDigest, err := types.BytesToConfigDigest(digestBytes)
+		if err != nil {
+			return config{}, nil, fmt.Errorf("instance %v: invalid configDigest: %w", i, err)
+		}
+		if _, ok := instances[configDigest]; ok {
+			return config{}, nil, fmt.Errorf("instance %v: duplicate configDigest %v", i, configDigest)
+		}
+		for _, peerID := range instance.PeerIDs {
+			var decoded ragetypes.PeerID
+			if err := decoded.UnmarshalText([]byte(peerID)); err != nil {
+				return config{}, nil, fmt.Errorf("instance %v: invalid peer ID %q: %w", i, peerID, err)
+			}
+		}
+		instances[configDigest] = instance
+	}
+	return c, instances, nil
+}
+
+func readKey(path string) (ed25519.PrivateKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("key file must contain a hex-encoded Ed25519 private key: %w", err)
+	}
+	switch len(key) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(key), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(key), nil
+	}
+	return nil, fmt.Errorf("key has length %v, expected %v or %v bytes", len(key), ed25519.SeedSize, ed25519.PrivateKeySize)
+}
+
+func generateKey(path string) error {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	// O_EXCL, so that we never overwrite an existing identity
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(hex.EncodeToString(key) + "\n"); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	peerID, err := ragetypes.PeerIDFromPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(peerID.String())
+	return nil
+}
+
+type runningInstance struct {
+	config       instanceConfig
+	bootstrapper commontypes.Bootstrapper
+}
+
+// reconcile starts and stops bootstrappers such that exactly the instances in
+// desired are running.
+func reconcile(
+	factory types.BootstrapperFactory,
+	running map[types.ConfigDigest]runningInstance,
+	desired map[types.ConfigDigest]instanceConfig,
+	logger commontypes.Logger,
+) {
+	for configDigest, instance := range running {
+		if config, ok := desired[configDigest]; ok && reflect.DeepEqual(config, instance.config) {
+			continue
+		}
+		if err := instance.bootstrapper.Close(); err != nil {
+			logger.Warn("bootstrapnode: error while closing bootstrapper", commontypes.LogFields{
+				"configDigest": configDigest,
+				"error":        err,
+			})
+		}
+		delete(running, configDigest)
+		logger.Info("bootstrapnode: stopped bootstrapper", commontypes.LogFields{"configDigest": configDigest})
+	}
+
+	for configDigest, config := range desired {
+		if _, ok := running[configDigest]; ok {
+			continue
+		}
+		bootstrapper, err := factory.NewBootstrapper(configDigest, config.PeerIDs, config.Bootstrappers, config.F)
+		if err != nil {
+			logger.Error("bootstrapnode: failed to create bootstrapper", commontypes.LogFields{
+				"configDigest": configDigest,
+				"error":        err,
+			})
+			continue
+		}
+		if err := bootstrapper.Start(); err != nil {
+			logger.Error("bootstrapnode: failed to start bootstrapper", commontypes.LogFields{
+				"configDigest": configDigest,
+				"error":        err,
+			})
+			continue
+		}
+		running[configDigest] = runningInstance{config, bootstrapper}
+		logger.Info("bootstrapnode: started bootstrapper", commontypes.LogFields{
+			"configDigest": configDigest,
+			"f":            config.F,
+			"peerIDs":      config.PeerIDs,
+		})
+	}
+}
+
+func run(configPath string) error {
+	logger := loggers.MakeLogrusLogger()
+
+	c, instances, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	key, err := readKey(c.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read key: %w", err)
+	}
+
+	peer, err := networking.NewPeer(networking.PeerConfig{
+		PrivKey:             key,
+		Logger:              logger,
+		V2ListenAddresses:   c.ListenAddresses,
+		V2AnnounceAddresses: c.AnnounceAddresses,
+		V2DeltaReconcile:    time.Duration(c.DeltaReconcile),
+		V2DeltaDial:         time.Duration(c.DeltaDial),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create peer: %w", err)
+	}
+	logger.Info("bootstrapnode: peer started", commontypes.LogFields{
+		"peerID":            peer.PeerID(),
+		"listenAddresses":   c.ListenAddresses,
+		"announceAddresses": c.AnnounceAddresses,
+	})
+
+	factory := peer.OCR2BootstrapperFactory()
+	running := map[types.ConfigDigest]runningInstance{}
+	reconcile(factory, running, instances, logger)
+
+	chSignal := make(chan os.Signal, 1)
+	signal.Notify(chSignal, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+	for sig := range chSignal {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		// Network settings can't change without restarting the peer, so we
+		// only pick up changes to the instances.
+		_, instances, err := loadConfig(configPath)
+		if err != nil {
+			logger.Error("bootstrapnode: failed to reload config, keeping previous instances", commontypes.LogFields{"error": err})
+			continue
+		}
+		logger.Info("bootstrapnode: reloading instances", commontypes.LogFields{"instances": len(instances)})
+		reconcile(factory, running, instances, logger)
+	}
+
+	logger.Info("bootstrapnode: shutting down", nil)
+	reconcile(factory, running, nil, logger)
+	return peer.Close()
+}
+
+func main() {
+	configPath := flag.String("config", "", "path to the JSON config file")
+	generateKeyPath := flag.String("generate-key", "", "generate a new key, write it to this path, print the resulting peer ID and exit")
+	flag.Parse()
+
+	if *generateKeyPath != "" {
+		if err := generateKey(*generateKeyPath); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to generate key: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *configPath == "" {
+		fmt.Fprintln(os.Stderr, "-config is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "bootstrapnode: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,104 @@
+// Command transmissionsim prints the simulated distribution of the time to the
+// first successful transmission for an OCR3 transmission schedule. See package
+// transmissionsim for the model. Example:
+//
+//	transmissionsim -s 1,1,2,3 -delta-stage 10s -f 2 -failure-probabilities 0.5,0.1,0.1,0.1,0.05,0.05,0.05
+//
+// Every oracle fails with the same probability if -failure-probability and -n
+// are given instead of -failure-probabilities.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/transmissionsim"
+)
+
+func main() {
+	s := flag.String("s", "", "comma-separated transmission schedule, e.g. 1,1,2")
+	deltaStage := flag.Duration("delta-stage", 10*time.Second, "delay between stages")
+	f := flag.Int("f", 1, "maximum number of faulty oracles")
+	failureProbabilities := flag.String("failure-probabilities", "", "comma-separated failure probability of each oracle")
+	failureProbability := flag.Float64("failure-probability", 0.1, "failure probability of every oracle, if -failure-probabilities isn't set")
+	n := flag.Int("n", 4, "number of oracles, if -failure-probabilities isn't set")
+	trials := flag.Int("trials", 100_000, "number of simulated reports")
+	seed := flag.Int64("seed", 1, "seed of the simulation")
+	flag.Parse()
+
+	config, err := makeConfig(*s, *deltaStage, *f, *failureProbabilities, *failureProbability, *n, *trials, *seed)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	result, err := transmissionsim.Simulate(config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	fmt.Printf("success probability: %.6f\n", result.SuccessProbability())
+	fmt.Printf("mean delay of successful transmissions: %v\n", result.MeanDelay())
+	for _, q := range []float64{0.5, 0.9, 0.99, 0.999} {
+		if delay, ok := result.Quantile(q); ok {
+			fmt.Printf("p%v: %v\n", q*100, delay)
+		} else {
+			fmt.Printf("p%v: never\n", q*100)
+		}
+	}
+	fmt.Println("first successful transmission by stage:")
+	for stage, count := range result.StageSuccesses {
+		fmt.Printf("  stage %v (%v): %.6f\n", stage, time.Duration(stage)*config.DeltaStage, float64(count)/float64(result.Trials))
+	}
+	if result.WorstCaseOK {
+		fmt.Printf("worst case with at most %v faulty oracles: %v\n", config.F, result.WorstCaseDelay)
+	} else {
+		fmt.Printf("worst case with at most %v faulty oracles: never, the schedule has at most %v oracles\n", config.F, config.F)
+	}
+}
+
+func makeConfig(s string, deltaStage time.Duration, f int, failureProbabilities string, failureProbability float64, n int, trials int, seed int64) (transmissionsim.Config, error) {
+	schedule, err := parseList(s, strconv.Atoi)
+	if err != nil {
+		return transmissionsim.Config{}, fmt.Errorf("invalid -s: %w", err)
+	}
+
+	var probabilities []float64
+	if failureProbabilities != "" {
+		probabilities, err = parseList(failureProbabilities, func(x string) (float64, error) {
+			return strconv.ParseFloat(x, 64)
+		})
+		if err != nil {
+			return transmissionsim.Config{}, fmt.Errorf("invalid -failure-probabilities: %w", err)
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			probabilities = append(probabilities, failureProbability)
+		}
+	}
+
+	return transmissionsim.Config{
+		schedule,
+		deltaStage,
+		f,
+		probabilities,
+		trials,
+		seed,
+	}, nil
+}
+
+func parseList[T any](s string, parse func(string) (T, error)) ([]T, error) {
+	var result []T
+	for _, x := range strings.Split(s, ",") {
+		v, err := parse(strings.TrimSpace(x))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
@@ -0,0 +1,356 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./OwnerIsCreator.sol";
+import "./interfaces/TypeAndVersionInterface.sol";
+
+/**
+ * @notice Onchain verification of reports from multiple OCR3 protocol
+ * instances ("plugins") sharing one contract, as used by capability-based
+ * deployments. Each instance is identified by an ocrPluginType and has its own
+ * config, signers, and transmitters.
+ * @dev Unlike OCR2Aggregator, this contract doesn't compute config digests.
+ * Whoever calls setOCR3Configs must pass the digest that the oracles compute
+ * for the config with their OffchainConfigDigester, otherwise the oracles will
+ * fail to match the contract config to their protocol instance.
+ */
+abstract contract MultiOCR3Base is TypeAndVersionInterface, OwnerIsCreator {
+  // Maximum number of oracles the offchain reporting protocol is designed for
+  uint256 constant internal maxNumOracles = 256;
+
+  enum Role {
+    Unset,
+    Signer,
+    Transmitter
+  }
+
+  struct Oracle {
+    uint8 index; // Index of oracle in signers/transmitters
+    Role role; // Role of the address which mapped to this struct
+  }
+
+  struct ConfigInfo {
+    bytes32 configDigest;
+    uint8 F; // maximum number of faulty/dishonest oracles the system can tolerate
+    uint8 n; // number of configured signers
+    bool isSignatureVerificationEnabled; // if false, reports are accepted from transmitters without signatures
+  }
+
+  struct OCRConfig {
+    ConfigInfo configInfo;
+    address[] signers; // ith element is address ith oracle uses to sign a report
+    address[] transmitters; // ith element is address ith oracle uses to transmit a report via the transmit method
+  }
+
+  struct OCRConfigArgs {
+    bytes32 configDigest;
+    uint8 ocrPluginType;
+    uint8 F;
+    bool isSignatureVerificationEnabled;
+    address[] signers;
+    address[] transmitters;
+  }
+
+  /**
+   * @notice triggers a new run of the offchain reporting protocol instance
+   * identified by ocrPluginType
+   * @param ocrPluginType protocol instance whose config changed
+   * @param configDigest configDigest of this configuration
+   * @param signers ith element is address ith oracle uses to sign a report
+   * @param transmitters ith element is address ith oracle uses to transmit a report via the transmit method
+   * @param F maximum number of faulty/dishonest oracles the protocol can tolerate while still working correctly
+   */
+  event ConfigSet(
+    uint8 ocrPluginType,
+    bytes32 configDigest,
+    address[] signers,
+    address[] transmitters,
+    uint8 F
+  );
+
+  /**
+   * @notice emitted for every report that was successfully transmitted
+   * @param ocrPluginType protocol instance that produced the report
+   * @param configDigest configDigest of the config the report was produced under
+   * @param sequenceNumber OCR3 sequence number of the report
+   */
+  event Transmitted(
+    uint8 indexed ocrPluginType,
+    bytes32 configDigest,
+    uint64 sequenceNumber
+  );
+
+  // ocrPluginType => config
+  mapping(uint8 => OCRConfig) internal s_ocrConfigs;
+
+  // ocrPluginType => signer or transmitter address => oracle
+  mapping(uint8 => mapping(address => Oracle)) internal s_oracles;
+
+  // The chain id the contract was deployed on, to detect chain forks, after
+  // which reports signed for one fork could be replayed on the other.
+  uint256 internal immutable i_chainID;
+
+  constructor() {
+    i_chainID = block.chainid;
+  }
+
+  /**
+   * @notice sets offchain reporting protocol configurations incl. participating oracles
+   * @dev The signature verification mode of a protocol instance can't change
+   * once it has been configured.
+   * @param ocrConfigArgs configs of the protocol instances to set
+   */
+  function setOCR3Configs(
+    OCRConfigArgs[] memory ocrConfigArgs
+  )
+    external
+    onlyOwner()
+  {
+    for (uint256 i = 0; i < ocrConfigArgs.length; i++) {
+      _setOCR3Config(ocrConfigArgs[i]);
+    }
+  }
+
+  function _setOCR3Config(
+    OCRConfigArgs memory args
+  )
+    internal
+  {
+    require(args.F != 0, "F must be positive");
+
+    OCRConfig storage ocrConfig = s_ocrConfigs[args.ocrPluginType];
+    ConfigInfo storage configInfo = ocrConfig.configInfo;
+
+    // If F is 0, then the config is not yet set
+    if (configInfo.F == 0) {
+      configInfo.isSignatureVerificationEnabled = args.isSignatureVerificationEnabled;
+    } else {
+      require(
+        configInfo.isSignatureVerificationEnabled == args.isSignatureVerificationEnabled,
+        "static config cannot be changed"
+      );
+    }
+
+    require(args.transmitters.length <= maxNumOracles, "too many transmitters");
+    require(args.transmitters.length != 0, "no transmitters");
+
+    _clearOracleRoles(args.ocrPluginType, ocrConfig.signers);
+    _clearOracleRoles(args.ocrPluginType, ocrConfig.transmitters);
+
+    if (args.isSignatureVerificationEnabled) {
+      require(args.signers.length <= maxNumOracles, "too many signers");
+      require(args.signers.length > 3 * uint256(args.F), "faulty-oracle F too high");
+      // Transmitters are a subset of the oracles, so each of them must have a signer
+      require(args.transmitters.length <= args.signers.length, "too many transmitters");
+
+      configInfo.n = uint8(args.signers.length);
+      ocrConfig.signers = args.signers;
+      _assignOracleRoles(args.ocrPluginType, args.signers, Role.Signer);
+    } else {
+      require(args.signers.length == 0, "signers without signature verification");
+      configInfo.n = 0;
+      delete ocrConfig.signers;
+    }
+
+    ocrConfig.transmitters = args.transmitters;
+    _assignOracleRoles(args.ocrPluginType, args.transmitters, Role.Transmitter);
+
+    configInfo.F = args.F;
+    configInfo.configDigest = args.configDigest;
+
+    emit ConfigSet(
+      args.ocrPluginType,
+      args.configDigest,
+      args.signers,
+      args.transmitters,
+      args.F
+    );
+    _afterOCR3ConfigSet(args.ocrPluginType);
+  }
+
+  /**
+   * @notice hook that is called after a plugin's OCR3 config changes
+   * @param ocrPluginType plugin type for which the config changed
+   */
+  function _afterOCR3ConfigSet(
+    uint8 ocrPluginType
+  )
+    internal
+    virtual;
+
+  function _clearOracleRoles(
+    uint8 ocrPluginType,
+    address[] memory oracleAddresses
+  )
+    internal
+  {
+    for (uint256 i = 0; i < oracleAddresses.length; i++) {
+      delete s_oracles[ocrPluginType][oracleAddresses[i]];
+    }
+  }
+
+  function _assignOracleRoles(
+    uint8 ocrPluginType,
+    address[] memory oracleAddresses,
+    Role role
+  )
+    internal
+  {
+    for (uint256 i = 0; i < oracleAddresses.length; i++) {
+      address oracle = oracleAddresses[i];
+      require(s_oracles[ocrPluginType][oracle].role == Role.Unset, "repeated oracle address");
+      require(oracle != address(0), "oracle address cannot be zero");
+      s_oracles[ocrPluginType][oracle] = Oracle(uint8(i), role);
+    }
+  }
+
+  // The constant-length components of the msg.data sent to transmit.
+  // See the "If we wanted to call sam" example on for example reasoning
+  // https://solidity.readthedocs.io/en/v0.7.2/abi-spec.html
+  uint256 private constant TRANSMIT_MSGDATA_CONSTANT_LENGTH_COMPONENT =
+    4 + // function selector
+    32 * 3 + // 3 words containing reportContext
+    32 + // word containing start location of abiencoded report value
+    32 + // word containing location start of abiencoded rs value
+    32 + // word containing start location of abiencoded ss value
+    32 + // rawVs value
+    32 + // word containing length of report
+    32 + // word containing length rs
+    32 + // word containing length of ss
+    0; // placeholder
+
+  // Make sure the calldata length matches the inputs. Otherwise, the
+  // transmitter could append an arbitrarily long (up to gas-block limit)
+  // string of 0 bytes.
+  function _requireExpectedMsgDataLength(
+    bytes calldata report,
+    bytes32[] memory rs,
+    bytes32[] memory ss
+  )
+    private
+    pure
+  {
+    // calldata will never be big enough to make this overflow
+    uint256 expected = TRANSMIT_MSGDATA_CONSTANT_LENGTH_COMPONENT +
+      // one byte pure entry in report, padded to a multiple of 32 bytes
+      ((report.length + 31) / 32) * 32 +
+      rs.length * 32 + // 32 bytes per entry in rs
+      ss.length * 32 + // 32 bytes per entry in ss
+      0; // placeholder
+    require(msg.data.length == expected, "calldata length mismatch");
+  }
+
+  /**
+   * @notice verifies and accepts a report on behalf of a contract that
+   * inherits from MultiOCR3Base
+   * @dev The inheriting contract's transmit function must have the signature
+   * transmit(bytes32[3],bytes,bytes32[],bytes32[],bytes32), or the same with
+   * the rs, ss, and rawVs arguments omitted if signature verification is
+   * disabled, since the calldata length check assumes that layout.
+   * @param ocrPluginType type of the plugin that produced the report
+   * @param reportContext reportContext[0]: ConfigDigest, reportContext[1]: 24
+   * byte padding and 8 byte sequence number, reportContext[2]: unused and
+   * not signed, see evmutil.OCR3RawReportContext
+   * @param report serialized report, which the signatures are signing
+   * @param rs ith element is the R components of the ith signature on report. Must have at most maxNumOracles entries
+   * @param ss ith element is the S components of the ith signature on report. Must have at most maxNumOracles entries
+   * @param rawVs ith element is the the V component of the ith signature
+   */
+  function _transmit(
+    uint8 ocrPluginType,
+    bytes32[3] calldata reportContext,
+    bytes calldata report,
+    bytes32[] memory rs,
+    bytes32[] memory ss,
+    bytes32 rawVs
+  )
+    internal
+  {
+    // reportContext consists of:
+    // reportContext[0]: ConfigDigest
+    // reportContext[1]: 24 byte padding, 8 byte sequence number
+    // reportContext[2]: unused
+    // NOTE: If the arguments to this function are changed, _requireExpectedMsgDataLength and/or
+    // TRANSMIT_MSGDATA_CONSTANT_LENGTH_COMPONENT need to be changed accordingly
+    ConfigInfo memory configInfo = s_ocrConfigs[ocrPluginType].configInfo;
+    bytes32 configDigest = reportContext[0];
+
+    require(configInfo.configDigest == configDigest, "configDigest mismatch");
+    require(block.chainid == i_chainID, "chain forked");
+
+    // If the signature verification is disabled, the transmit function has
+    // no rs, ss, and rawVs arguments, which accounts for 4 words
+    if (configInfo.isSignatureVerificationEnabled) {
+      _requireExpectedMsgDataLength(report, rs, ss);
+    } else {
+      uint256 expected = TRANSMIT_MSGDATA_CONSTANT_LENGTH_COMPONENT - 4 * 32 + ((report.length + 31) / 32) * 32;
+      require(msg.data.length == expected, "calldata length mismatch");
+    }
+
+    // Only transmitters of the current config may transmit
+    Oracle memory transmitter = s_oracles[ocrPluginType][msg.sender];
+    require(
+      transmitter.role == Role.Transmitter &&
+      msg.sender == s_ocrConfigs[ocrPluginType].transmitters[transmitter.index],
+      "unauthorized transmitter"
+    );
+
+    if (configInfo.isSignatureVerificationEnabled) {
+      require(rs.length == uint256(configInfo.F) + 1, "wrong number of signatures");
+      require(rs.length == ss.length, "signatures out of registration");
+
+      // reportContext[2] isn't signed, matching evmutil.OCR3ReportDigest
+      bytes32 h = keccak256(abi.encode(keccak256(report), reportContext[0], reportContext[1]));
+      _verifySignatures(ocrPluginType, h, rs, ss, rawVs);
+    }
+
+    emit Transmitted(ocrPluginType, configDigest, uint64(uint256(reportContext[1])));
+  }
+
+  /**
+   * @notice verifies the signatures of a hashed report value for one OCR plugin type
+   * @param ocrPluginType plugin type for which the signatures are verified
+   * @param hashedReport hashed encoded packing of report + reportContext
+   * @param rs ith element is the R components of the ith signature on report
+   * @param ss ith element is the S components of the ith signature on report
+   * @param rawVs ith element is the the V component of the ith signature
+   */
+  function _verifySignatures(
+    uint8 ocrPluginType,
+    bytes32 hashedReport,
+    bytes32[] memory rs,
+    bytes32[] memory ss,
+    bytes32 rawVs
+  )
+    internal
+    view
+  {
+    // bit i is set iff the ith signer has signed
+    uint256 signed = 0;
+
+    for (uint256 i = 0; i < rs.length; i++) {
+      address signerAddress = ecrecover(hashedReport, uint8(rawVs[i]) + 27, rs[i], ss[i]);
+      Oracle memory oracle = s_oracles[ocrPluginType][signerAddress];
+      require(oracle.role == Role.Signer, "unauthorized signer");
+      require(signed & (1 << oracle.index) == 0, "duplicate signer");
+      signed |= 1 << oracle.index;
+    }
+  }
+
+  /**
+   * @notice information about current offchain reporting protocol configuration
+   * @param ocrPluginType OCR plugin type to return config details for
+   * @return ocrConfig OCR config for the plugin type
+   */
+  function latestConfigDetails(
+    uint8 ocrPluginType
+  )
+    external
+    view
+    returns (
+      OCRConfig memory ocrConfig
+    )
+  {
+    return s_ocrConfigs[ocrPluginType];
+  }
+}
@@ -0,0 +1,23 @@
+#!/usr/bin/env bash
+# Regenerates a binding in gethwrappers2 from a contract in contract2/dev, e.g.
+#
+#   gethwrappers2/generate.sh MultiOCR3Base multiocr3base
+#
+# Like abigen, the binding covers the contract and everything it imports.
+# Requires solc (0.8.x) on the PATH. abigen is pinned in tools.go.
+set -euo pipefail
+
+if [ "$#" -ne 2 ]; then
+  echo "usage: $0 <contract> <package>" >&2
+  exit 1
+fi
+contract="$1"
+pkg="$2"
+
+cd "$(dirname "$0")"
+tmp="$(mktemp -d)"
+trap 'rm -rf "$tmp"' EXIT
+
+solc --optimize --combined-json abi,bin "../contract2/dev/$contract.sol" > "$tmp/combined.json"
+mkdir -p "$pkg"
+go run github.com/ethereum/go-ethereum/cmd/abigen --combined-json "$tmp/combined.json" --pkg "$pkg" --out "$pkg/$pkg.go"
@@ -1,6 +1,8 @@
 package loghelper
 
 import (
+	"context"
+	"fmt"
 	"io"
 
 	"github.com/smartcontractkit/libocr/commontypes"
@@ -15,3 +17,25 @@ func CloseLogError(closer io.Closer, logger commontypes.Logger, msg string) {
 		})
 	}
 }
+
+// Like CloseLogError, but stops waiting for closer.Close() to return once ctx
+// is done. In that case, Close is abandoned, i.e. left running in the
+// background, and logged at ERROR level together with msg.
+func CloseLogErrorCtx(ctx context.Context, closer io.Closer, logger commontypes.Logger, msg string) {
+	chErr := make(chan error, 1)
+	go func() {
+		chErr <- closer.Close()
+	}()
+	select {
+	case err := <-chErr:
+		if err != nil {
+			logger.Warn(msg, commontypes.LogFields{
+				"error": err,
+			})
+		}
+	case <-ctx.Done():
+		logger.Error(msg, commontypes.LogFields{
+			"error": fmt.Errorf("abandoned Close() that didn't return before teardown deadline: %w", ctx.Err()),
+		})
+	}
+}
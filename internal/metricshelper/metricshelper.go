@@ -0,0 +1,63 @@
+// Package metricshelper helps components register prometheus metrics with a
+// registerer supplied by the application.
+package metricshelper
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartcontractkit/libocr/commontypes"
+)
+
+// Registerer registers collectors with an underlying prometheus.Registerer
+// and unregisters all of them on Close, so that a component that is torn down
+// and recreated can register its metrics again. The underlying registerer may
+// be nil, in which case collectors are still usable but aren't exported.
+//
+// Registration errors, e.g. due to two components registering the same
+// metrics, are logged rather than returned: metrics shouldn't keep an oracle
+// from running. Components sharing a registerer should be distinguished by
+// wrapping it, e.g. with prometheus.WrapRegistererWith.
+type Registerer struct {
+	registerer prometheus.Registerer
+	logger     commontypes.Logger
+
+	mutex      sync.Mutex
+	registered []prometheus.Collector
+}
+
+func NewRegisterer(registerer prometheus.Registerer, logger commontypes.Logger) *Registerer {
+	return &Registerer{registerer, logger, sync.Mutex{}, nil}
+}
+
+// Register registers collectors, and logs at WARN level for each one that
+// fails to register.
+func (r *Registerer) Register(collectors ...prometheus.Collector) {
+	if r.registerer == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, c := range collectors {
+		if err := r.registerer.Register(c); err != nil {
+			r.logger.Warn("metricshelper: failed to register metrics, they won't be exported", commontypes.LogFields{
+				"error": err,
+			})
+			continue
+		}
+		r.registered = append(r.registered, c)
+	}
+}
+
+// Close unregisters all collectors that were registered successfully.
+func (r *Registerer) Close() {
+	if r.registerer == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, c := range r.registered {
+		r.registerer.Unregister(c)
+	}
+	r.registered = nil
+}
@@ -0,0 +1,189 @@
+package networking
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/ragep2p"
+	ragetypes "github.com/smartcontractkit/libocr/ragep2p/types"
+)
+
+// The inspection endpoint is a read-only HTTP endpoint that serves a
+// PeerStatus as JSON at /status, for operators that need to debug a node in
+// the field but don't have a metrics pipeline. It is only served if
+// PeerConfig.V2InspectionAddress is set, and only on loopback addresses,
+// since it performs no authentication and reveals the node's peers and
+// protocol instances.
+
+const (
+	InstanceKindEndpoint     = "endpoint"
+	InstanceKindBootstrapper = "bootstrapper"
+)
+
+// PeerStatus is a snapshot of the state of a peer. It can be serialized with
+// encoding/json.
+type PeerStatus struct {
+	// Time at which the snapshot was taken
+	Time   time.Time
+	PeerID ragetypes.PeerID
+	// True iff all Instances are healthy
+	Healthy bool
+	// Remote peers the peer currently has streams with, sorted by PeerID
+	Peers []RemotePeerStatus
+	// Endpoints and bootstrappers that are currently registered with the
+	// peer, sorted by ConfigDigest
+	Instances []InstanceStatus
+}
+
+type RemotePeerStatus struct {
+	PeerID ragetypes.PeerID
+	// Whether there currently is an authenticated connection with the peer
+	Connected bool
+	// Addresses of the peer, as known to discovery
+	Addresses                   []ragetypes.Address
+	ConnectionLimiterSaturation float64
+	// Stats of each open stream with the peer, by stream name
+	Streams map[string]ragep2p.StreamStats
+}
+
+type InstanceStatus struct {
+	ConfigDigest ocr2types.ConfigDigest
+	// InstanceKindEndpoint or InstanceKindBootstrapper
+	Kind    string
+	Oracles int
+	F       int
+	// Number of the instance's oracles other than ourselves that we are
+	// currently connected to
+	ConnectedOracles int
+	// True iff we can reach at least Oracles-F oracles of the instance
+	// (counting ourselves if we are one of them), the number needed for the
+	// protocol to make progress if the remaining F are faulty
+	Healthy bool
+	// Messages currently waiting in the buffers of the instance's streams,
+	// summed across all remote oracles
+	OutgoingQueueDepth int
+	IncomingQueueDepth int
+}
+
+type registeredInstance struct {
+	kind    string
+	oracles []ragetypes.PeerID
+	f       int
+}
+
+// validateInspectionAddress checks that address is a <host>:<port> address
+// with host being "localhost" or a loopback IP.
+func validateInspectionAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid inspection address %q: %w", address, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("inspection address %q must have host localhost or a loopback IP", address)
+	}
+	return nil
+}
+
+// Status returns a snapshot of the peer's state, as served by the inspection
+// endpoint.
+func (p2 *concretePeerV2) Status() PeerStatus {
+	peerStats := p2.host.PeerStats()
+
+	peers := make([]RemotePeerStatus, 0, len(peerStats))
+	for id, stats := range peerStats {
+		addresses, _ := p2.discoverer.FindPeer(id)
+		peers = append(peers, RemotePeerStatus{
+			id,
+			stats.Connected,
+			addresses,
+			stats.ConnectionLimiterSaturation,
+			stats.Streams,
+		})
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return bytes.Compare(peers[i].PeerID[:], peers[j].PeerID[:]) < 0
+	})
+
+	p2.instancesMu.Lock()
+	instances := make([]InstanceStatus, 0, len(p2.instances))
+	for configDigest, instance := range p2.instances {
+		instances = append(instances, instanceStatus(p2.peerID, configDigest, instance, peerStats))
+	}
+	p2.instancesMu.Unlock()
+	sort.Slice(instances, func(i, j int) bool {
+		return bytes.Compare(instances[i].ConfigDigest[:], instances[j].ConfigDigest[:]) < 0
+	})
+
+	healthy := true
+	for _, instance := range instances {
+		healthy = healthy && instance.Healthy
+	}
+
+	return PeerStatus{
+		time.Now(),
+		p2.peerID,
+		healthy,
+		peers,
+		instances,
+	}
+}
+
+func instanceStatus(self ragetypes.PeerID, configDigest ocr2types.ConfigDigest, instance registeredInstance, peerStats map[ragetypes.PeerID]ragep2p.PeerStats) InstanceStatus {
+	streamName := streamNameFromConfigDigest(configDigest)
+	reachable := 0
+	connected := 0
+	outgoingQueueDepth := 0
+	incomingQueueDepth := 0
+	for _, oracle := range instance.oracles {
+		if oracle == self {
+			reachable++
+			continue
+		}
+		stats, ok := peerStats[oracle]
+		if !ok {
+			continue
+		}
+		if stats.Connected {
+			reachable++
+			connected++
+		}
+		if streamStats, ok := stats.Streams[streamName]; ok {
+			outgoingQueueDepth += streamStats.OutgoingQueueDepth
+			incomingQueueDepth += streamStats.IncomingQueueDepth
+		}
+	}
+	return InstanceStatus{
+		configDigest,
+		instance.kind,
+		len(instance.oracles),
+		instance.f,
+		connected,
+		reachable >= len(instance.oracles)-instance.f,
+		outgoingQueueDepth,
+		incomingQueueDepth,
+	}
+}
+
+func (p2 *concretePeerV2) inspectionHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(p2.Status())
+	})
+	return mux
+}
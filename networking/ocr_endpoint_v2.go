@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"go.uber.org/multierr"
 
@@ -18,6 +19,7 @@ import (
 
 var (
 	_ commontypes.BinaryNetworkEndpoint = &ocrEndpointV2{}
+	_ ocr2types.DialBackoffEndpoint     = &ocrEndpointV2{}
 )
 
 type ocrEndpointState int
@@ -190,6 +192,8 @@ func (o *ocrEndpointV2) Start() error {
 		if err != nil {
 			return fmt.Errorf("failed to create stream for oracle %v (peer id: %q): %w", oid, pid, err)
 		}
+		// no-op unless V2Compression is set
+		stream.SetCompression(true)
 		o.streams[oid] = stream
 	}
 
@@ -202,6 +206,9 @@ func (o *ocrEndpointV2) Start() error {
 	o.subs.Go(func() {
 		o.runSendToSelf()
 	})
+	o.subs.Go(func() {
+		o.runReportLinkStats()
+	})
 
 	o.logger.Info("OCREndpointV2: Started listening", nil)
 	succeeded = true
@@ -248,6 +255,69 @@ func (o *ocrEndpointV2) runSendToSelf() {
 	}
 }
 
+// linkStatsReportInterval is how often we log message loss on links to other
+// oracles
+const linkStatsReportInterval = 5 * time.Minute
+
+// runReportLinkStats periodically logs estimated message loss for each link
+// that lost messages during the last interval. The counters distinguish
+// between loss caused by connectivity problems, slow peers, peers exceeding
+// rate limits, and ourselves not keeping up.
+func (o *ocrEndpointV2) runReportLinkStats() {
+	previous := make(map[commontypes.OracleID]ragep2p.StreamStats, len(o.streams))
+	ticker := time.NewTicker(linkStatsReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for oid, stream := range o.streams {
+				current := stream.Stats()
+				delta := subtractStreamStats(current, previous[oid])
+				previous[oid] = current
+				if delta.OutgoingLossRate() == 0 && delta.IncomingLossRate() == 0 {
+					continue
+				}
+				o.logger.Warn("OCREndpointV2: lost messages on link", commontypes.LogFields{
+					"remoteOracleID":              oid,
+					"remotePeerID":                o.peerMapping[oid],
+					"interval":                    linkStatsReportInterval.String(),
+					"outgoingLossRate":            delta.OutgoingLossRate(),
+					"incomingLossRate":            delta.IncomingLossRate(),
+					"messagesSent":                delta.MessagesSent,
+					"messagesDroppedDisconnected": delta.MessagesDroppedDisconnected,
+					"messagesDroppedBackpressure": delta.MessagesDroppedBackpressure,
+					"messagesReceived":            delta.MessagesReceived,
+					"messagesDroppedRateLimited":  delta.MessagesDroppedRateLimited,
+					"messagesDroppedOverflow":     delta.MessagesDroppedOverflow,
+					"bytesSent":                   delta.BytesSent,
+					"bytesReceived":               delta.BytesReceived,
+					"messagesLimiterSaturation":   delta.MessagesLimiterSaturation,
+					"bytesLimiterSaturation":      delta.BytesLimiterSaturation,
+				})
+			}
+		case <-o.chClose:
+			return
+		}
+	}
+}
+
+func subtractStreamStats(a, b ragep2p.StreamStats) ragep2p.StreamStats {
+	return ragep2p.StreamStats{
+		a.MessagesSent - b.MessagesSent,
+		a.MessagesDroppedDisconnected - b.MessagesDroppedDisconnected,
+		a.MessagesDroppedBackpressure - b.MessagesDroppedBackpressure,
+		a.MessagesReceived - b.MessagesReceived,
+		a.MessagesDroppedRateLimited - b.MessagesDroppedRateLimited,
+		a.MessagesDroppedOverflow - b.MessagesDroppedOverflow,
+		a.BytesSent - b.BytesSent,
+		a.BytesReceived - b.BytesReceived,
+		a.MessagesLimiterSaturation,
+		a.BytesLimiterSaturation,
+		a.OutgoingQueueDepth,
+		a.IncomingQueueDepth,
+	}
+}
+
 // Close should be called to clean up even if Start is never called.
 func (o *ocrEndpointV2) Close() error {
 	o.stateMu.Lock()
@@ -322,15 +392,42 @@ func (o *ocrEndpointV2) sendToSelf(payload []byte) {
 }
 
 // Broadcast sends a msg to all oracles in the peer mapping
+//
+// All streams share the same payload, so the per-peer work is limited to
+// queueing it and to what the connection does with it, i.e. encryption.
+// Stream.SendMessage only hands payload to the stream's send loop, which
+// buffers it, so we don't need a goroutine per peer.
 func (o *ocrEndpointV2) Broadcast(payload []byte) {
-	var subs subprocesses.Subprocesses
-	defer subs.Wait()
+	o.stateMu.RLock()
+	state := o.state
+	o.stateMu.RUnlock()
+	if state != ocrEndpointStarted {
+		o.logger.Error("Broadcast on non-started ocrEndpointV2", commontypes.LogFields{"state": state})
+		return
+	}
+
 	for oracleID := range o.peerMapping {
-		oracleID := oracleID
-		subs.Go(func() {
-			o.SendTo(payload, oracleID)
-		})
+		if oracleID == o.ownOracleID {
+			o.sendToSelf(payload)
+			continue
+		}
+		o.streams[oracleID].SendMessage(payload)
+	}
+}
+
+// SetDialBackoff enables or disables dial back-off on the streams to all
+// other oracles, see ragep2p.Stream.SetDialBackoff. Other endpoints sharing
+// the same peers may keep the host dialing at the regular rate.
+func (o *ocrEndpointV2) SetDialBackoff(enabled bool) {
+	o.stateMu.RLock()
+	defer o.stateMu.RUnlock()
+	if o.state != ocrEndpointStarted {
+		return
+	}
+	for _, stream := range o.streams {
+		stream.SetDialBackoff(enabled)
 	}
+	o.logger.Info("OCREndpointV2: Set dial back-off", commontypes.LogFields{"enabled": enabled})
 }
 
 // Receive gives the channel to receive messages
@@ -3,15 +3,19 @@ package networking
 import (
 	"crypto/ed25519"
 	"fmt"
+	"net"
+	"net/http"
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
 	"github.com/smartcontractkit/libocr/networking/ragedisco"
 	nettypes "github.com/smartcontractkit/libocr/networking/types"
 	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 	"github.com/smartcontractkit/libocr/ragep2p"
+	"github.com/smartcontractkit/libocr/ragep2p/proxydialer"
 	ragetypes "github.com/smartcontractkit/libocr/ragep2p/types"
 	"go.uber.org/multierr"
 )
@@ -37,6 +41,55 @@ type PeerConfig struct {
 
 	V2DiscovererDatabase nettypes.DiscovererDatabase
 
+	// V2Dialer is used to establish outgoing connections to other peers. May be
+	// left unspecified, in which case connections are established using
+	// net.Dialer.
+	V2Dialer ragep2p.Dialer
+
+	// V2Proxy routes outgoing connections to peers and bootstrappers through
+	// SOCKS5 or HTTP CONNECT proxies, see proxydialer. If V2Dialer is also
+	// specified, it is used to reach the proxies unless V2Proxy.Forward is set.
+	// Doesn't apply to QUIC connections. May be left unspecified, in which
+	// case peers are dialed directly.
+	V2Proxy *proxydialer.Config
+
+	// V2ListenConfig is used to listen for incoming connections on
+	// V2ListenAddresses. May be left unspecified, in which case
+	// net.ListenConfig is used.
+	V2ListenConfig ragep2p.ListenConfig
+
+	// V2HostSnapshot may be set to a snapshot obtained from HostSnapshot() of
+	// the previously active peer with the same PrivKey, so that a standby
+	// taking over in an active/passive setup doesn't start from a cold mesh.
+	// May be left unspecified.
+	V2HostSnapshot *ragep2p.HostSnapshot
+
+	// V2AddressOverrides contains static addresses for peers that take
+	// precedence over the addresses learned through discovery. The host may
+	// update them at runtime. May be left unspecified.
+	V2AddressOverrides *ragep2p.AddressOverrides
+
+	// V2MetricsRegisterer is used to register prometheus metrics about peer
+	// connectivity. May be left unspecified, in which case metrics aren't
+	// exported.
+	V2MetricsRegisterer prometheus.Registerer
+
+	// V2QUIC enables the QUIC transport for the peers it selects. May be left
+	// unspecified, in which case all peers are dialed over TCP.
+	V2QUIC *ragep2p.QUICConfig
+
+	// V2Compression enables zstd compression of the messages of OCR
+	// endpoints, with peers that have enabled it as well. May be left
+	// unspecified, in which case messages aren't compressed.
+	V2Compression *ragep2p.CompressionConfig
+
+	// V2InspectionAddress is the <host>:<port> address on which the peer
+	// serves a read-only JSON status endpoint at /status, see PeerStatus. The
+	// host must be localhost or a loopback IP, since the endpoint performs no
+	// authentication. May be left unspecified, in which case the endpoint
+	// isn't served.
+	V2InspectionAddress string
+
 	V2EndpointConfig EndpointConfigV2
 }
 
@@ -47,6 +100,12 @@ type concretePeerV2 struct {
 	discoverer     *ragedisco.Ragep2pDiscoverer
 	logger         loghelper.LoggerWithContext
 	endpointConfig EndpointConfigV2
+
+	instancesMu sync.Mutex
+	instances   map[ocr2types.ConfigDigest]registeredInstance
+
+	// nil unless PeerConfig.V2InspectionAddress is set
+	inspectionServer *http.Server
 }
 
 // Users are expected to create (using the OCR*Factory() methods) and close endpoints and bootstrappers before calling
@@ -66,16 +125,45 @@ func NewPeer(c PeerConfig) (*concretePeerV2, error) {
 		"peerID": peerID.String(),
 	})
 
+	if c.V2InspectionAddress != "" {
+		if err := validateInspectionAddress(c.V2InspectionAddress); err != nil {
+			return nil, err
+		}
+	}
+
 	announceAddresses := c.V2AnnounceAddresses
 	if len(c.V2AnnounceAddresses) == 0 {
 		announceAddresses = c.V2ListenAddresses
 	}
 	discoverer := ragedisco.NewRagep2pDiscoverer(c.V2DeltaReconcile, announceAddresses, c.V2DiscovererDatabase)
+	var hostDiscoverer ragep2p.Discoverer = discoverer
+	if c.V2HostSnapshot != nil {
+		hostDiscoverer = ragep2p.NewPrewarmedDiscoverer(discoverer, *c.V2HostSnapshot)
+		logger.Info("PeerV2: prewarming ragep2p host from snapshot", commontypes.LogFields{
+			"snapshotTime":  c.V2HostSnapshot.Time,
+			"snapshotPeers": len(c.V2HostSnapshot.Peers),
+		})
+	}
+	if c.V2AddressOverrides != nil {
+		hostDiscoverer = ragep2p.NewOverridingDiscoverer(hostDiscoverer, c.V2AddressOverrides)
+	}
+	dialer := c.V2Dialer
+	if c.V2Proxy != nil {
+		proxyConfig := *c.V2Proxy
+		if proxyConfig.Forward == nil {
+			proxyConfig.Forward = c.V2Dialer
+		}
+		proxyDialer, err := proxydialer.New(proxyConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid V2Proxy: %w", err)
+		}
+		dialer = proxyDialer
+	}
 	host, err := ragep2p.NewHost(
-		ragep2p.HostConfig{c.V2DeltaDial},
+		ragep2p.HostConfig{c.V2DeltaDial, dialer, c.V2ListenConfig, c.V2MetricsRegisterer, c.V2QUIC, c.V2Compression},
 		c.PrivKey,
 		c.V2ListenAddresses,
-		discoverer,
+		hostDiscoverer,
 		c.Logger,
 	)
 	if err != nil {
@@ -88,13 +176,40 @@ func NewPeer(c PeerConfig) (*concretePeerV2, error) {
 
 	logger.Info("PeerV2: ragep2p host booted", nil)
 
-	return &concretePeerV2{
+	p2 := &concretePeerV2{
 		peerID,
 		host,
 		discoverer,
 		logger,
 		c.V2EndpointConfig,
-	}, nil
+		sync.Mutex{},
+		map[ocr2types.ConfigDigest]registeredInstance{},
+		nil,
+	}
+
+	if c.V2InspectionAddress != "" {
+		ln, err := net.Listen("tcp", c.V2InspectionAddress)
+		if err != nil {
+			return nil, multierr.Combine(
+				fmt.Errorf("failed to listen on inspection address: %w", err),
+				host.Close(),
+			)
+		}
+		p2.inspectionServer = &http.Server{
+			Handler:           p2.inspectionHandler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := p2.inspectionServer.Serve(ln); err != nil && err != http.ErrServerClosed {
+				logger.Error("PeerV2: inspection endpoint failed", commontypes.LogFields{"error": err})
+			}
+		}()
+		logger.Info("PeerV2: serving inspection endpoint", commontypes.LogFields{
+			"address": ln.Addr().String(),
+		})
+	}
+
+	return p2, nil
 }
 
 // An endpointRegistration is held by an endpoint which services a particular configDigest. The invariant is that only
@@ -117,13 +232,21 @@ func (r *endpointRegistration) Close() (err error) {
 	return err
 }
 
-func (p2 *concretePeerV2) register(configDigest ocr2types.ConfigDigest, oracles []ragetypes.PeerID, bootstrappers []ragetypes.PeerInfo) (*endpointRegistration, error) {
+func (p2 *concretePeerV2) register(kind string, configDigest ocr2types.ConfigDigest, oracles []ragetypes.PeerID, bootstrappers []ragetypes.PeerInfo, f int) (*endpointRegistration, error) {
 	if err := p2.discoverer.AddGroup(configDigest, oracles, bootstrappers); err != nil {
 		p2.logger.Warn("PeerV2: Failed to register endpoint", commontypes.LogFields{"configDigest": configDigest})
 		return nil, err
 	}
 
+	p2.instancesMu.Lock()
+	p2.instances[configDigest] = registeredInstance{kind, oracles, f}
+	p2.instancesMu.Unlock()
+
 	return newEndpointRegistration(func() error {
+		p2.instancesMu.Lock()
+		delete(p2.instances, configDigest)
+		p2.instancesMu.Unlock()
+
 		// Discoverer will not be closed until concretePeerV2.Close() is called.
 		// By the time concretePeerV2.Close() is called all endpoints/bootstrappers should have already been closed.
 		// Even if this weren't true, RemoveGroup() is a no-op if the discoverer is closed.
@@ -135,8 +258,24 @@ func (p2 *concretePeerV2) PeerID() string {
 	return p2.peerID.String()
 }
 
+// HostSnapshot returns a snapshot of the peer's ragep2p host state, for
+// passing to a standby via PeerConfig.V2HostSnapshot.
+func (p2 *concretePeerV2) HostSnapshot() ragep2p.HostSnapshot {
+	return p2.host.Snapshot()
+}
+
+// PeerStats returns per-stream and per-peer traffic statistics of the peer's
+// ragep2p host, keyed by remote peer.
+func (p2 *concretePeerV2) PeerStats() map[ragetypes.PeerID]ragep2p.PeerStats {
+	return p2.host.PeerStats()
+}
+
 func (p2 *concretePeerV2) Close() error {
-	return p2.host.Close()
+	var err error
+	if p2.inspectionServer != nil {
+		err = p2.inspectionServer.Close()
+	}
+	return multierr.Combine(err, p2.host.Close())
 }
 func decodev2Bootstrappers(v2bootstrappers []commontypes.BootstrapperLocator) (infos []ragetypes.PeerInfo, err error) {
 	for _, b := range v2bootstrappers {
@@ -195,7 +334,7 @@ func (p2 *concretePeerV2) newEndpoint(
 		return nil, fmt.Errorf("could not decode v2 bootstrappers: %w", err)
 	}
 
-	registration, err := p2.register(configDigest, decodedv2PeerIDs, decodedv2Bootstrappers)
+	registration, err := p2.register(InstanceKindEndpoint, configDigest, decodedv2PeerIDs, decodedv2Bootstrappers, f)
 	if err != nil {
 		return nil, err
 	}
@@ -241,7 +380,7 @@ func (p2 *concretePeerV2) newBootstrapper(
 		return nil, fmt.Errorf("could not decode v2 bootstrappers: %w", err)
 	}
 
-	registration, err := p2.register(configDigest, decodedv2PeerIDs, decodedv2Bootstrappers)
+	registration, err := p2.register(InstanceKindBootstrapper, configDigest, decodedv2PeerIDs, decodedv2Bootstrappers, f)
 	if err != nil {
 		return nil, err
 	}
@@ -13,13 +13,12 @@ import (
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/onchainconfig"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/reportgate"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 	"github.com/smartcontractkit/libocr/subprocesses"
 )
 
-const onchainConfigVersion = 1
-const onchainConfigEncodedLength = 1 + byteWidth + byteWidth
-
 type OnchainConfig struct {
 	Min *big.Int
 	Max *big.Int
@@ -38,48 +37,19 @@ var _ OnchainConfigCodec = StandardOnchainConfigCodec{}
 // An encoded onchain config is expected to be in the format
 // <version><min><max>
 // where version is a uint8 and min and max are in the format
-// returned by EncodeValue.
+// returned by EncodeValue. See also onchainconfig.NumericBounds.
 type StandardOnchainConfigCodec struct{}
 
 func (StandardOnchainConfigCodec) Decode(b []byte) (OnchainConfig, error) {
-	if len(b) != onchainConfigEncodedLength {
-		return OnchainConfig{}, fmt.Errorf("unexpected length of OnchainConfig, expected %v, got %v", onchainConfigEncodedLength, len(b))
-	}
-
-	if b[0] != onchainConfigVersion {
-		return OnchainConfig{}, fmt.Errorf("unexpected version of OnchainConfig, expected %v, got %v", onchainConfigVersion, b[0])
-	}
-
-	min, err := DecodeValue(b[1 : 1+byteWidth])
+	numericBounds, err := onchainconfig.DecodeNumericBounds(b)
 	if err != nil {
 		return OnchainConfig{}, err
 	}
-	max, err := DecodeValue(b[1+byteWidth:])
-	if err != nil {
-		return OnchainConfig{}, err
-	}
-
-	if !(min.Cmp(max) <= 0) {
-		return OnchainConfig{}, fmt.Errorf("OnchainConfig min (%v) should not be greater than max(%v)", min, max)
-	}
-
-	return OnchainConfig{min, max}, nil
+	return OnchainConfig{numericBounds.Min, numericBounds.Max}, nil
 }
 
 func (StandardOnchainConfigCodec) Encode(c OnchainConfig) ([]byte, error) {
-	minBytes, err := EncodeValue(c.Min)
-	if err != nil {
-		return nil, err
-	}
-	maxBytes, err := EncodeValue(c.Max)
-	if err != nil {
-		return nil, err
-	}
-	result := make([]byte, 0, onchainConfigEncodedLength)
-	result = append(result, onchainConfigVersion)
-	result = append(result, minBytes...)
-	result = append(result, maxBytes...)
-	return result, nil
+	return onchainconfig.EncodeNumericBounds(onchainconfig.NumericBounds{c.Min, c.Max})
 }
 
 type OffchainConfig struct {
@@ -287,22 +257,7 @@ func (fac NumericalMedianFactory) NewReportingPlugin(configuration types.Reporti
 }
 
 func Deviates(thresholdPPB uint64, old *big.Int, new *big.Int) bool {
-	if old.Cmp(i(0)) == 0 {
-		if new.Cmp(i(0)) == 0 { //nolint:gosimple
-			return false // Both values are zero; no deviation
-		}
-		return true // Any deviation from 0 is significant
-	}
-	// ||new - old|| / ||old||, approximated by a float
-	change := &big.Rat{}
-	change.SetFrac(i(0).Sub(new, old), old)
-	change.Abs(change)
-	threshold := &big.Rat{}
-	threshold.SetFrac(
-		(&big.Int{}).SetUint64(thresholdPPB),
-		(&big.Int{}).SetUint64(1e9),
-	)
-	return change.Cmp(threshold) >= 0
+	return reportgate.Deviates(thresholdPPB, old, new)
 }
 
 var _ types.ReportingPlugin = (*numericalMedian)(nil)
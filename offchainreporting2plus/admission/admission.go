@@ -0,0 +1,208 @@
+// Package admission lets hosts cap the protocol instances that a node runs
+// based on the resources each instance is expected to use.
+//
+// When an oracle passed a Controller (see e.g.
+// OCR3OracleArgs.AdmissionController) receives a new config, it computes the
+// Resources the protocol instance will use at most from the config and the
+// limits declared by the reporting plugin, and asks the Controller to admit
+// the instance before starting it. If admitting the instance would exceed the
+// Capacity of the node, the Controller refuses with a *CapacityExceededError
+// and the oracle doesn't run the instance. The oracle doesn't retry on its
+// own; the instance is considered again once the config changes or the oracle
+// is restarted.
+//
+// Typically, a single Controller is shared among all oracles of a node.
+package admission
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// Resources quantifies the (expected) resource usage of a protocol instance
+// or a node. All values are non-negative.
+type Resources struct {
+	// Network bandwidth in bytes per second, inbound and outbound combined
+	BandwidthBytesPerSecond float64
+	// Inbound messages per second. The CPU usage of a protocol instance is
+	// dominated by processing messages (deserialization, signature checks,
+	// plugin calls), so this serves as the measure of CPU.
+	MessagesPerSecond float64
+	// Memory in bytes
+	MemoryBytes float64
+}
+
+func (r Resources) add(s Resources) Resources {
+	return Resources{
+		r.BandwidthBytesPerSecond + s.BandwidthBytesPerSecond,
+		r.MessagesPerSecond + s.MessagesPerSecond,
+		r.MemoryBytes + s.MemoryBytes,
+	}
+}
+
+func (r Resources) max(s Resources) Resources {
+	return Resources{
+		max(r.BandwidthBytesPerSecond, s.BandwidthBytesPerSecond),
+		max(r.MessagesPerSecond, s.MessagesPerSecond),
+		max(r.MemoryBytes, s.MemoryBytes),
+	}
+}
+
+// Capacity is the capacity of a node. Zero values mean unlimited.
+type Capacity struct {
+	// Maximum number of concurrently running protocol instances
+	MaxInstances int
+	Resources    Resources
+}
+
+// Resource identifies a quantity limited by Capacity.
+type Resource string
+
+const (
+	ResourceInstances Resource = "instances"
+	ResourceBandwidth Resource = "bandwidth"
+	ResourceMessages  Resource = "messages"
+	ResourceMemory    Resource = "memory"
+)
+
+// CapacityExceededError is returned by Controller.Admit if admitting a
+// protocol instance would exceed the node's capacity for Resource.
+type CapacityExceededError struct {
+	ConfigDigest types.ConfigDigest
+	Resource     Resource
+	// Amount of Resource requested by the instance
+	Requested float64
+	// Amount of Resource already in use
+	InUse float64
+	// Capacity for Resource
+	Capacity float64
+}
+
+func (e *CapacityExceededError) Error() string {
+	return fmt.Sprintf("admitting protocol instance %v would exceed %v capacity: requested %v, in use %v, capacity %v",
+		e.ConfigDigest, e.Resource, e.Requested, e.InUse, e.Capacity)
+}
+
+// Controller tracks the protocol instances admitted on a node. All its
+// functions are thread-safe.
+type Controller struct {
+	capacity     Capacity
+	measuredLoad func() Resources
+
+	mu       sync.Mutex
+	nextID   uint64
+	admitted map[uint64]Admission
+}
+
+// Admission describes an admitted protocol instance.
+type Admission struct {
+	ConfigDigest types.ConfigDigest
+	Demand       Resources
+}
+
+// NewController returns a Controller enforcing capacity. measuredLoad may be
+// nil. Otherwise, it must be thread-safe and return the resources currently
+// used by the node, e.g. as reported by the operating system. The resources
+// in use are then the maximum of the measured load and the sum of the demands
+// of all admitted instances, so that load the declared limits don't account
+// for (e.g. from other services on the node) is taken into account as well.
+func NewController(capacity Capacity, measuredLoad func() Resources) (*Controller, error) {
+	if capacity.MaxInstances < 0 {
+		return nil, fmt.Errorf("MaxInstances (%v) must not be negative", capacity.MaxInstances)
+	}
+	if err := validateResources(capacity.Resources); err != nil {
+		return nil, fmt.Errorf("invalid capacity: %w", err)
+	}
+	return &Controller{
+		capacity,
+		measuredLoad,
+
+		sync.Mutex{},
+		0,
+		map[uint64]Admission{},
+	}, nil
+}
+
+func validateResources(r Resources) error {
+	// !(x >= 0) also catches NaN
+	if !(r.BandwidthBytesPerSecond >= 0 && r.MessagesPerSecond >= 0 && r.MemoryBytes >= 0) {
+		return fmt.Errorf("resources must be non-negative, got %+v", r)
+	}
+	return nil
+}
+
+// Admit admits the protocol instance with configDigest, which is expected to
+// use demand, unless that would exceed the node's capacity, in which case it
+// returns a *CapacityExceededError. For an admitted instance, release must be
+// called once the instance has stopped. release may be called multiple times.
+func (c *Controller) Admit(configDigest types.ConfigDigest, demand Resources) (release func(), err error) {
+	if err := validateResources(demand); err != nil {
+		return nil, fmt.Errorf("invalid demand for protocol instance %v: %w", configDigest, err)
+	}
+
+	var measuredLoad Resources
+	if c.measuredLoad != nil {
+		measuredLoad = c.measuredLoad()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var admittedDemand Resources
+	for _, admission := range c.admitted {
+		admittedDemand = admittedDemand.add(admission.Demand)
+	}
+	inUse := admittedDemand.max(measuredLoad)
+
+	if c.capacity.MaxInstances != 0 && len(c.admitted)+1 > c.capacity.MaxInstances {
+		return nil, &CapacityExceededError{
+			configDigest,
+			ResourceInstances,
+			1,
+			float64(len(c.admitted)),
+			float64(c.capacity.MaxInstances),
+		}
+	}
+	for _, check := range []struct {
+		resource  Resource
+		requested float64
+		inUse     float64
+		capacity  float64
+	}{
+		{ResourceBandwidth, demand.BandwidthBytesPerSecond, inUse.BandwidthBytesPerSecond, c.capacity.Resources.BandwidthBytesPerSecond},
+		{ResourceMessages, demand.MessagesPerSecond, inUse.MessagesPerSecond, c.capacity.Resources.MessagesPerSecond},
+		{ResourceMemory, demand.MemoryBytes, inUse.MemoryBytes, c.capacity.Resources.MemoryBytes},
+	} {
+		if check.capacity != 0 && check.inUse+check.requested > check.capacity {
+			return nil, &CapacityExceededError{
+				configDigest,
+				check.resource,
+				check.requested,
+				check.inUse,
+				check.capacity,
+			}
+		}
+	}
+
+	id := c.nextID
+	c.nextID++
+	c.admitted[id] = Admission{configDigest, demand}
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.admitted, id)
+	}, nil
+}
+
+// Admitted returns the currently admitted protocol instances.
+func (c *Controller) Admitted() []Admission {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	admitted := make([]Admission, 0, len(c.admitted))
+	for _, admission := range c.admitted {
+		admitted = append(admitted, admission)
+	}
+	return admitted
+}
@@ -0,0 +1,259 @@
+// Package atrest implements envelope encryption of the data an oracle
+// persists in its Database, for operators that must encrypt all data at rest.
+//
+// Every value is encrypted with AES-256-GCM under a random data key. The data
+// key is in turn encrypted ("wrapped") by a KeyProvider supplied by the host,
+// typically backed by a KMS or HSM holding the key-encryption key, and stored
+// next to the ciphertext. Seal generates a fresh data key for every value,
+// whereas a Sealer reuses one per config digest, so that writing protocol
+// state doesn't require a round trip to the KMS every round. Seal and Open
+// bind each value to associated data, e.g. its config digest and key, so that
+// values can't be swapped between records without detection.
+//
+// An oracle passed a KeyProvider (see e.g. OCR3OracleArgs.AtRestKeyProvider)
+// encrypts the OCR3 protocol state and the reports and signatures of OCR2
+// pending transmissions before they reach the Database. Contract configs,
+// which are public onchain anyway, the timestamps of pending transmissions,
+// which the Database needs for pruning, and the OCR2 epoch counters in
+// types.PersistentState remain in plaintext.
+//
+// Values written before encryption was enabled are still read as plaintext,
+// so encryption can be enabled on an existing Database. Such values are
+// encrypted the next time they are written. Conversely, an oracle without a
+// KeyProvider refuses to read encrypted values.
+package atrest
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// KeyProvider wraps and unwraps data keys with the host's key-encryption key.
+// All its functions should be thread-safe.
+type KeyProvider interface {
+	// WrapKey encrypts dataKey. The result is stored in plaintext next to the
+	// data encrypted under dataKey, so it must not reveal dataKey. It should
+	// identify the key-encryption key used, so that UnwrapKey keeps working
+	// after the key-encryption key is rotated.
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey reverses WrapKey.
+	UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error)
+}
+
+// KeySize is the size of data keys and of StaticKeyProvider's key.
+const KeySize = 32
+
+// StaticKeyProvider is a KeyProvider that wraps data keys with AES-256-GCM
+// under a fixed key held in memory, for hosts without a KMS.
+type StaticKeyProvider struct {
+	aead cipher.AEAD
+}
+
+var _ KeyProvider = (*StaticKeyProvider)(nil)
+
+func NewStaticKeyProvider(key [KeySize]byte) (*StaticKeyProvider, error) {
+	aead, err := newAEAD(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return &StaticKeyProvider{aead}, nil
+}
+
+func (kp *StaticKeyProvider) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	return seal(kp.aead, dataKey, nil)
+}
+
+func (kp *StaticKeyProvider) UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	return open(kp.aead, wrappedKey, nil)
+}
+
+// Envelopes start with these bytes. As a protobuf message can't start with
+// 'O' (field 9 with the invalid wire type 7), they can be told apart from
+// protocol state written in plaintext.
+var magic = []byte("OCRE")
+
+const version = 1
+
+// IsEnvelope returns whether value looks like the result of Seal.
+func IsEnvelope(value []byte) bool {
+	return len(value) > len(magic) && bytes.HasPrefix(value, magic) && value[len(magic)] == version
+}
+
+// Seal encrypts plaintext under a fresh data key wrapped by kp and returns the
+// resulting envelope. associatedData is authenticated but not encrypted, and
+// must be passed to Open again.
+func Seal(ctx context.Context, kp KeyProvider, plaintext []byte, associatedData []byte) ([]byte, error) {
+	dk, err := newDataKey(ctx, kp)
+	if err != nil {
+		return nil, err
+	}
+	return dk.seal(plaintext, associatedData)
+}
+
+// dataKey is a data key ready for sealing, along with the envelope header
+// carrying its wrapped form.
+type dataKey struct {
+	aead   cipher.AEAD
+	header []byte
+	// number of values sealed under the key, only used by Sealer
+	uses uint64
+}
+
+func newDataKey(ctx context.Context, kp KeyProvider) (*dataKey, error) {
+	var key [KeySize]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	wrappedKey, err := kp.WrapKey(ctx, key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	if len(wrappedKey) > math.MaxUint16 {
+		return nil, fmt.Errorf("wrapped data key is too long (%v bytes)", len(wrappedKey))
+	}
+
+	header := append([]byte{}, magic...)
+	header = append(header, version)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(wrappedKey)))
+	header = append(header, wrappedKey...)
+
+	aead, err := newAEAD(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return &dataKey{aead, header, 0}, nil
+}
+
+func (dk *dataKey) seal(plaintext []byte, associatedData []byte) ([]byte, error) {
+	ciphertext, err := seal(dk.aead, plaintext, additionalData(dk.header, associatedData))
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, dk.header...), ciphertext...), nil
+}
+
+// A data key is replaced after sealing this many values, which keeps the
+// probability of a random GCM nonce repeating negligible.
+const maxSealerDataKeyUses = 1 << 24
+
+// Protocol instances typically only write under a single config digest, so a
+// handful of cached data keys suffices.
+const maxSealerDataKeys = 4
+
+// Sealer seals values like Seal, but reuses the data key, and hence the
+// wrapped data key, for all values sealed under the same config digest. It
+// only calls KeyProvider.WrapKey for the first value sealed under a config
+// digest, rather than for every value. Envelopes produced by a Sealer are
+// opened with Open just like those produced by Seal. Data keys are kept in
+// memory for the lifetime of the Sealer. Sealer is thread-safe.
+type Sealer struct {
+	keyProvider KeyProvider
+
+	mutex    sync.Mutex
+	dataKeys map[types.ConfigDigest]*dataKey
+}
+
+func NewSealer(keyProvider KeyProvider) *Sealer {
+	return &Sealer{keyProvider, sync.Mutex{}, map[types.ConfigDigest]*dataKey{}}
+}
+
+// Seal is like the Seal function, using the data key for configDigest.
+// associatedData should bind the value to configDigest, as with Seal.
+func (s *Sealer) Seal(ctx context.Context, configDigest types.ConfigDigest, plaintext []byte, associatedData []byte) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	dk, ok := s.dataKeys[configDigest]
+	if !ok || dk.uses >= maxSealerDataKeyUses {
+		var err error
+		dk, err = newDataKey(ctx, s.keyProvider)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.dataKeys[configDigest]; !ok && len(s.dataKeys) >= maxSealerDataKeys {
+			clear(s.dataKeys)
+		}
+		s.dataKeys[configDigest] = dk
+	}
+	dk.uses++
+	return dk.seal(plaintext, associatedData)
+}
+
+// Open is the same as the Open function with the Sealer's KeyProvider.
+func (s *Sealer) Open(ctx context.Context, envelope []byte, associatedData []byte) ([]byte, error) {
+	return Open(ctx, s.keyProvider, envelope, associatedData)
+}
+
+// Open decrypts an envelope produced by Seal with the same associatedData.
+func Open(ctx context.Context, kp KeyProvider, envelope []byte, associatedData []byte) ([]byte, error) {
+	if !IsEnvelope(envelope) {
+		return nil, fmt.Errorf("value isn't an envelope")
+	}
+	rest := envelope[len(magic)+1:]
+	if len(rest) < 2 {
+		return nil, fmt.Errorf("envelope is truncated")
+	}
+	wrappedKeyLength := int(binary.BigEndian.Uint16(rest))
+	rest = rest[2:]
+	if len(rest) < wrappedKeyLength {
+		return nil, fmt.Errorf("envelope is truncated")
+	}
+	wrappedKey := rest[:wrappedKeyLength]
+	header := envelope[:len(envelope)-len(rest)+wrappedKeyLength]
+	ciphertext := rest[wrappedKeyLength:]
+
+	dataKey, err := kp.UnwrapKey(ctx, wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, ciphertext, additionalData(header, associatedData))
+}
+
+func additionalData(header []byte, associatedData []byte) []byte {
+	result := append([]byte{}, header...)
+	return append(result, associatedData...)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key has length %v, expected %v", len(key), KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal returns nonce || ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is truncated")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
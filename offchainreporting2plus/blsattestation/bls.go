@@ -0,0 +1,255 @@
+// Package blsattestation implements an ocr3types.AggregatingOnchainKeyring
+// based on BLS signatures over the BN254 (alt_bn128) curve, whose pairing is
+// available to EVM contracts through the precompiles of EIP-196 and EIP-197,
+// together with helpers for integrating contracts that verify the resulting
+// aggregate signatures (see ocr3types.ProtocolFeatureFlagAggregateAttestation).
+//
+// Signatures are points in G1, and every oracle has a public key in G1 as well
+// as in G2. The OnchainPublicKey of an oracle is the concatenation of both
+// (see PublicKeyLength). To verify an aggregate signature, a contract sums the
+// G1 public keys of the signers in the SignerBitmap (cheap, using the ecAdd
+// precompile), receives the sum of their G2 public keys from the transmitter
+// (see AggregatePublicKeys), and checks both with a single call to the
+// pairing precompile; VerifyAggregate performs the very same computation.
+//
+// Aggregation over the same message is vulnerable to rogue key attacks, where
+// an oracle chooses its public key as a function of the other oracles' keys.
+// The public keys in a config must therefore be checked with
+// VerifyProofOfPossession before the config is set.
+package blsattestation
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+const (
+	g1PointLength = 64
+	g2PointLength = 128
+
+	// Length of an OnchainPublicKey: G1 point followed by G2 point, each in
+	// the encoding used by the EVM precompiles
+	PublicKeyLength = g1PointLength + g2PointLength
+	// Length of a (possibly aggregate) signature, a G1 point in the encoding
+	// used by the EVM precompiles
+	SignatureLength = g1PointLength
+	// Length of a proof of possession, a G1 point in the encoding used by the
+	// EVM precompiles
+	ProofOfPossessionLength = g1PointLength
+	// Length of an encoded PrivateKey
+	PrivateKeyLength = 32
+)
+
+// Domain separation tags for hashing to G1
+var (
+	reportDomain            = []byte("libocr blsattestation report")
+	proofOfPossessionDomain = []byte("libocr blsattestation proof of possession")
+)
+
+// PrivateKey is a BLS private key, i.e. a scalar in [1, bn256.Order).
+type PrivateKey struct {
+	scalar *big.Int
+}
+
+// GeneratePrivateKey returns a new PrivateKey using randomness from r, or
+// crypto/rand if r is nil.
+func GeneratePrivateKey(r io.Reader) (*PrivateKey, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	orderMinusOne := new(big.Int).Sub(bn256.Order, big.NewInt(1))
+	scalar, err := rand.Int(r, orderMinusOne)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+	scalar.Add(scalar, big.NewInt(1))
+	return &PrivateKey{scalar}, nil
+}
+
+// PrivateKeyFromBytes decodes a PrivateKey encoded with Bytes.
+func PrivateKeyFromBytes(b []byte) (*PrivateKey, error) {
+	if len(b) != PrivateKeyLength {
+		return nil, fmt.Errorf("private key has length %v, expected %v", len(b), PrivateKeyLength)
+	}
+	scalar := new(big.Int).SetBytes(b)
+	if scalar.Sign() == 0 || scalar.Cmp(bn256.Order) >= 0 {
+		return nil, fmt.Errorf("private key out of range")
+	}
+	return &PrivateKey{scalar}, nil
+}
+
+// Bytes encodes k as a big-endian scalar.
+func (k *PrivateKey) Bytes() []byte {
+	return k.scalar.FillBytes(make([]byte, PrivateKeyLength))
+}
+
+// PublicKey returns the OnchainPublicKey corresponding to k.
+func (k *PrivateKey) PublicKey() types.OnchainPublicKey {
+	publicKey := make(types.OnchainPublicKey, 0, PublicKeyLength)
+	publicKey = append(publicKey, new(bn256.G1).ScalarBaseMult(k.scalar).Marshal()...)
+	publicKey = append(publicKey, new(bn256.G2).ScalarBaseMult(k.scalar).Marshal()...)
+	return publicKey
+}
+
+// ProofOfPossession returns a signature over k's own public key, which
+// proves that whoever created the public key knows k.
+func (k *PrivateKey) ProofOfPossession() []byte {
+	return new(bn256.G1).ScalarMult(hashToG1(proofOfPossessionDomain, k.PublicKey()), k.scalar).Marshal()
+}
+
+func (k *PrivateKey) sign(digest []byte) []byte {
+	return new(bn256.G1).ScalarMult(hashToG1(reportDomain, digest), k.scalar).Marshal()
+}
+
+// VerifyProofOfPossession checks that the two halves of publicKey belong to
+// the same private key and that proofOfPossession was created with it. Deploy
+// tooling must check the public key of every oracle with this function before
+// including it in a config.
+func VerifyProofOfPossession(publicKey types.OnchainPublicKey, proofOfPossession []byte) bool {
+	pk1, pk2, err := parsePublicKey(publicKey)
+	if err != nil {
+		return false
+	}
+	pop, err := parseG1(proofOfPossession)
+	if err != nil {
+		return false
+	}
+	return consistent(pk1, pk2) && verify(pk2, hashToG1(proofOfPossessionDomain, publicKey), pop)
+}
+
+// ReportDigest returns the digest that oracles sign for a report, namely
+// keccak256(keccak256(report) || configDigest || uint256(seqNr)). The message
+// point that is actually signed is obtained from the digest with HashToG1.
+func ReportDigest(configDigest types.ConfigDigest, seqNr uint64, report types.Report) []byte {
+	var rawSeqNr [32]byte
+	new(big.Int).SetUint64(seqNr).FillBytes(rawSeqNr[:])
+	sigData := crypto.Keccak256(report)
+	sigData = append(sigData, configDigest[:]...)
+	sigData = append(sigData, rawSeqNr[:]...)
+	return crypto.Keccak256(sigData)
+}
+
+// HashToG1 maps a report digest to the G1 point that is signed, encoded as
+// for the EVM precompiles.
+//
+// We use try-and-increment, which contracts can replicate cheaply using the
+// modexp precompile: For counter = 0, 1, ..., let x = keccak256(domain ||
+// digest || uint8(counter)) mod P. The first x for which x³ + 3 is a square
+// modulo P yields the point (x, y), where y is the smaller of the two square
+// roots. Since G1 has cofactor 1, this is always a point of G1.
+func HashToG1(digest []byte) []byte {
+	return hashToG1(reportDomain, digest).Marshal()
+}
+
+func hashToG1(domain []byte, msg []byte) *bn256.G1 {
+	halfP := new(big.Int).Rsh(bn256.P, 1)
+	three := big.NewInt(3)
+	for counter := 0; counter < 256; counter++ {
+		h := crypto.Keccak256(domain, msg, []byte{byte(counter)})
+		x := new(big.Int).SetBytes(h)
+		x.Mod(x, bn256.P)
+
+		rhs := new(big.Int).Exp(x, three, bn256.P)
+		rhs.Add(rhs, three)
+		rhs.Mod(rhs, bn256.P)
+		y := new(big.Int).ModSqrt(rhs, bn256.P)
+		if y == nil {
+			continue
+		}
+		if y.Cmp(halfP) > 0 {
+			y.Sub(bn256.P, y)
+		}
+
+		encoded := make([]byte, g1PointLength)
+		x.FillBytes(encoded[:32])
+		y.FillBytes(encoded[32:])
+		point, err := parseG1(encoded)
+		if err != nil {
+			panic(fmt.Sprintf("point derived in hashToG1 is invalid: %v", err))
+		}
+		return point
+	}
+	// Each attempt succeeds with probability about 1/2.
+	panic("hashToG1 failed to find a point after 256 attempts")
+}
+
+func parseG1(b []byte) (*bn256.G1, error) {
+	if len(b) != g1PointLength {
+		return nil, fmt.Errorf("G1 point has length %v, expected %v", len(b), g1PointLength)
+	}
+	point := new(bn256.G1)
+	if _, err := point.Unmarshal(b); err != nil {
+		return nil, err
+	}
+	return point, nil
+}
+
+func parseG2(b []byte) (*bn256.G2, error) {
+	if len(b) != g2PointLength {
+		return nil, fmt.Errorf("G2 point has length %v, expected %v", len(b), g2PointLength)
+	}
+	point := new(bn256.G2)
+	if _, err := point.Unmarshal(b); err != nil {
+		return nil, err
+	}
+	return point, nil
+}
+
+func parsePublicKey(publicKey types.OnchainPublicKey) (*bn256.G1, *bn256.G2, error) {
+	if len(publicKey) != PublicKeyLength {
+		return nil, nil, fmt.Errorf("public key has length %v, expected %v", len(publicKey), PublicKeyLength)
+	}
+	pk1, err := parseG1(publicKey[:g1PointLength])
+	if err != nil {
+		return nil, nil, err
+	}
+	pk2, err := parseG2(publicKey[g1PointLength:])
+	if err != nil {
+		return nil, nil, err
+	}
+	// The point at infinity is encoded as zeros. It would make any signature
+	// equal to it valid.
+	if allZero(publicKey[:g1PointLength]) || allZero(publicKey[g1PointLength:]) {
+		return nil, nil, fmt.Errorf("public key is the point at infinity")
+	}
+	return pk1, pk2, nil
+}
+
+func allZero(b []byte) bool {
+	for _, x := range b {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func g1Generator() *bn256.G1 {
+	return new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+}
+
+func g2Generator() *bn256.G2 {
+	return new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+}
+
+// consistent checks e(pk1, g2) = e(g1, pk2).
+func consistent(pk1 *bn256.G1, pk2 *bn256.G2) bool {
+	return bn256.PairingCheck(
+		[]*bn256.G1{pk1, new(bn256.G1).Neg(g1Generator())},
+		[]*bn256.G2{g2Generator(), pk2},
+	)
+}
+
+// verify checks e(signature, g2) = e(msg, pk2).
+func verify(pk2 *bn256.G2, msg *bn256.G1, signature *bn256.G1) bool {
+	return bn256.PairingCheck(
+		[]*bn256.G1{signature, new(bn256.G1).Neg(msg)},
+		[]*bn256.G2{g2Generator(), pk2},
+	)
+}
@@ -0,0 +1,147 @@
+package blsattestation
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// OnchainKeyring is an ocr3types.AggregatingOnchainKeyring that signs reports
+// with a BLS PrivateKey. It signs the report bytes only, the report info isn't
+// signed. It is thread-safe.
+type OnchainKeyring[RI any] struct {
+	privateKey *PrivateKey
+	publicKey  types.OnchainPublicKey
+}
+
+var _ ocr3types.AggregatingOnchainKeyring[struct{}] = &OnchainKeyring[struct{}]{}
+
+func NewOnchainKeyring[RI any](privateKey *PrivateKey) *OnchainKeyring[RI] {
+	return &OnchainKeyring[RI]{privateKey, privateKey.PublicKey()}
+}
+
+func (k *OnchainKeyring[RI]) PublicKey() types.OnchainPublicKey {
+	return append(types.OnchainPublicKey{}, k.publicKey...)
+}
+
+func (k *OnchainKeyring[RI]) Sign(configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ocr3types.ReportWithInfo[RI]) ([]byte, error) {
+	return k.privateKey.sign(ReportDigest(configDigest, seqNr, reportWithInfo.Report)), nil
+}
+
+func (k *OnchainKeyring[RI]) Verify(publicKey types.OnchainPublicKey, configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ocr3types.ReportWithInfo[RI], signature []byte) bool {
+	_, pk2, err := parsePublicKey(publicKey)
+	if err != nil {
+		return false
+	}
+	sig, err := parseG1(signature)
+	if err != nil {
+		return false
+	}
+	return verify(pk2, hashToG1(reportDomain, ReportDigest(configDigest, seqNr, reportWithInfo.Report)), sig)
+}
+
+func (k *OnchainKeyring[RI]) MaxSignatureLength() int {
+	return SignatureLength
+}
+
+func (k *OnchainKeyring[RI]) AggregateSignatures(signatures [][]byte) ([]byte, error) {
+	if len(signatures) == 0 {
+		return nil, fmt.Errorf("cannot aggregate zero signatures")
+	}
+	var aggregate *bn256.G1
+	for i, signature := range signatures {
+		sig, err := parseG1(signature)
+		if err != nil {
+			return nil, fmt.Errorf("signature %v is invalid: %w", i, err)
+		}
+		if aggregate == nil {
+			aggregate = sig
+		} else {
+			aggregate = new(bn256.G1).Add(aggregate, sig)
+		}
+	}
+	return aggregate.Marshal(), nil
+}
+
+// AggregatePublicKeys returns the sums of the G1 and G2 public keys of the
+// signers, each encoded as for the EVM precompiles. publicKeys are the
+// OnchainPublicKeys of all oracles in the config, indexed by OracleID.
+//
+// A contract can cheaply compute the G1 sum itself, but not the G2 sum, which
+// the transmitter therefore has to supply along with the aggregate signature.
+func AggregatePublicKeys(publicKeys []types.OnchainPublicKey, signers ocr3types.SignerBitmap) (g1 []byte, g2 []byte, err error) {
+	apk1, apk2, err := aggregatePublicKeys(publicKeys, signers)
+	if err != nil {
+		return nil, nil, err
+	}
+	return apk1.Marshal(), apk2.Marshal(), nil
+}
+
+func aggregatePublicKeys(publicKeys []types.OnchainPublicKey, signers ocr3types.SignerBitmap) (*bn256.G1, *bn256.G2, error) {
+	if signers.Len() == 0 {
+		return nil, nil, fmt.Errorf("no signers")
+	}
+	var apk1 *bn256.G1
+	var apk2 *bn256.G2
+	for _, oracleID := range signers.OracleIDs() {
+		if int(oracleID) >= len(publicKeys) {
+			return nil, nil, fmt.Errorf("signer %v out of range, there are only %v public keys", oracleID, len(publicKeys))
+		}
+		pk1, pk2, err := parsePublicKey(publicKeys[oracleID])
+		if err != nil {
+			return nil, nil, fmt.Errorf("public key of signer %v is invalid: %w", oracleID, err)
+		}
+		if apk1 == nil {
+			apk1, apk2 = pk1, pk2
+		} else {
+			apk1 = new(bn256.G1).Add(apk1, pk1)
+			apk2 = new(bn256.G2).Add(apk2, pk2)
+		}
+	}
+	return apk1, apk2, nil
+}
+
+// VerifyAggregate verifies an aggregate signature on a report the way an
+// integrating contract would, and may serve as a reference for implementing
+// one. publicKeys are the OnchainPublicKeys of all oracles in the config,
+// indexed by OracleID; the signature must be by at least minSigners oracles
+// (f+1 in a DON with fault tolerance f).
+//
+// With apk1 and apk2 the sums of the signers' G1 and G2 public keys, H the
+// message point (see HashToG1) and σ the aggregate signature, the contract
+// needs to check that e(apk1, g2) = e(g1, apk2), i.e. that the apk2 supplied
+// by the transmitter matches the signers, and that e(σ, g2) = e(H, apk2). For
+// γ = keccak256(σ || apk1 || apk2 || H) mod r, both hold (up to negligible
+// probability) iff
+//
+//	e(-(σ + γ·apk1), g2) · e(H + γ·g1, apk2) = 1,
+//
+// which takes a single call to the pairing precompile.
+func VerifyAggregate(publicKeys []types.OnchainPublicKey, configDigest types.ConfigDigest, seqNr uint64, report types.Report, aggregate ocr3types.AggregateSignature, minSigners int) bool {
+	if aggregate.Signers.Len() < minSigners {
+		return false
+	}
+	apk1, apk2, err := aggregatePublicKeys(publicKeys, aggregate.Signers)
+	if err != nil {
+		return false
+	}
+	sig, err := parseG1(aggregate.Signature)
+	if err != nil {
+		return false
+	}
+	h := hashToG1(reportDomain, ReportDigest(configDigest, seqNr, report))
+
+	gamma := new(big.Int).SetBytes(crypto.Keccak256(sig.Marshal(), apk1.Marshal(), apk2.Marshal(), h.Marshal()))
+	gamma.Mod(gamma, bn256.Order)
+
+	lhs := new(bn256.G1).Add(sig, new(bn256.G1).ScalarMult(apk1, gamma))
+	rhs := new(bn256.G1).Add(h, new(bn256.G1).ScalarBaseMult(gamma))
+	return bn256.PairingCheck(
+		[]*bn256.G1{new(bn256.G1).Neg(lhs), rhs},
+		[]*bn256.G2{g2Generator(), apk2},
+	)
+}
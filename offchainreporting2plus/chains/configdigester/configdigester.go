@@ -0,0 +1,155 @@
+// Package configdigester helps integrators of non-EVM chains build
+// OffchainConfigDigesters. It provides
+//
+//   - a Registry of the OffchainConfigDigesters a node uses, which rejects
+//     digesters claiming a ConfigDigestPrefix that is unregistered (see the
+//     list in package types) or already claimed by another digester, and
+//     which can check any ContractConfig's ConfigDigest against the digester
+//     matching its prefix;
+//   - VerifyConfigDigest, which does the same for a single digester, e.g. to
+//     cross-check a digest computed by external tooling; and
+//   - SHA256ConfigDigest, a chain-agnostic way of hashing a ContractConfig
+//     into a ConfigDigest, so that new digesters don't need to invent (or
+//     copy and modify) their own encoding.
+package configdigester
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// Registry maps ConfigDigestPrefixes to the OffchainConfigDigesters that
+// claimed them. It is thread-safe.
+type Registry struct {
+	mu        sync.RWMutex
+	digesters map[types.ConfigDigestPrefix]types.OffchainConfigDigester
+}
+
+func NewRegistry() *Registry {
+	return &Registry{sync.RWMutex{}, map[types.ConfigDigestPrefix]types.OffchainConfigDigester{}}
+}
+
+// Register claims the digester's prefix for it. It fails if the prefix isn't
+// registered in package types or has been claimed by another digester.
+func (r *Registry) Register(digester types.OffchainConfigDigester) error {
+	prefix, err := digester.ConfigDigestPrefix()
+	if err != nil {
+		return fmt.Errorf("could not get ConfigDigestPrefix of digester: %w", err)
+	}
+	if !prefix.IsRegistered() {
+		return fmt.Errorf("ConfigDigestPrefix %v is not registered, add it to the list in package types first", prefix)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.digesters[prefix]; ok {
+		name, _ := prefix.Name()
+		return fmt.Errorf("ConfigDigestPrefix %v (%v) has already been claimed by another digester", prefix, name)
+	}
+	r.digesters[prefix] = digester
+	return nil
+}
+
+// Digester returns the digester that claimed prefix, if any.
+func (r *Registry) Digester(prefix types.ConfigDigestPrefix) (types.OffchainConfigDigester, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	digester, ok := r.digesters[prefix]
+	return digester, ok
+}
+
+// VerifyConfigDigest recomputes cc.ConfigDigest with the digester that
+// claimed its prefix and returns an error if there is no such digester or the
+// digests differ.
+func (r *Registry) VerifyConfigDigest(cc types.ContractConfig) error {
+	prefix := types.ConfigDigestPrefixFromConfigDigest(cc.ConfigDigest)
+	digester, ok := r.Digester(prefix)
+	if !ok {
+		return fmt.Errorf("no digester has claimed ConfigDigestPrefix %v of config digest %v", prefix, cc.ConfigDigest)
+	}
+	return VerifyConfigDigest(digester, cc)
+}
+
+// VerifyConfigDigest recomputes cc.ConfigDigest with digester and returns an
+// error if the digests differ. Deploy pipelines can use it to cross-check a
+// digest computed by external tooling (e.g. a contract's view function or a
+// script in another language) before submitting a config. See package
+// chains/configdigestvectors for test vectors of libocr's digesters.
+func VerifyConfigDigest(digester types.OffchainConfigDigester, cc types.ContractConfig) error {
+	prefix, err := digester.ConfigDigestPrefix()
+	if err != nil {
+		return fmt.Errorf("could not get ConfigDigestPrefix of digester: %w", err)
+	}
+	if !prefix.IsPrefixOf(cc.ConfigDigest) {
+		return fmt.Errorf("config digest %v doesn't start with ConfigDigestPrefix %v of digester", cc.ConfigDigest, prefix)
+	}
+	configDigest, err := digester.ConfigDigest(cc)
+	if err != nil {
+		return fmt.Errorf("could not compute config digest: %w", err)
+	}
+	if configDigest != cc.ConfigDigest {
+		return fmt.Errorf("config digest mismatch: got %v, computed %v", cc.ConfigDigest, configDigest)
+	}
+	return nil
+}
+
+// SHA256ConfigDigest computes a ConfigDigest for cc as
+//
+//	prefix || sha256(domain || configCount || signers || transmitters || f ||
+//	                 onchainConfig || offchainConfigVersion ||
+//	                 offchainConfig)[2:]
+//
+// The integers configCount, f and offchainConfigVersion are encoded as
+// big-endian uint64, uint8 and uint64 respectively. Every byte string,
+// including each element of domain, is prefixed by its length as a big-endian
+// uint64, and every list (domain, signers, transmitters) by its number of
+// elements, which makes the encoding injective. cc.ConfigDigest is ignored.
+//
+// domain must identify the contract whose config is digested, e.g. by chain
+// ID and contract address, so that digests of different contracts can't
+// collide. Each digester should document the exact domain it uses, so that
+// contracts can compute the same digest.
+func SHA256ConfigDigest(prefix types.ConfigDigestPrefix, domain [][]byte, cc types.ContractConfig) types.ConfigDigest {
+	h := sha256.New()
+	writeUint64(h, uint64(len(domain)))
+	for _, d := range domain {
+		writeBytes(h, d)
+	}
+	writeUint64(h, cc.ConfigCount)
+	writeUint64(h, uint64(len(cc.Signers)))
+	for _, signer := range cc.Signers {
+		writeBytes(h, signer)
+	}
+	writeUint64(h, uint64(len(cc.Transmitters)))
+	for _, transmitter := range cc.Transmitters {
+		writeBytes(h, []byte(transmitter))
+	}
+	_, _ = h.Write([]byte{cc.F})
+	writeBytes(h, cc.OnchainConfig)
+	writeUint64(h, cc.OffchainConfigVersion)
+	writeBytes(h, cc.OffchainConfig)
+
+	var configDigest types.ConfigDigest
+	if n := copy(configDigest[:], h.Sum(nil)); n != len(configDigest) {
+		// assertion
+		panic("copy too little data")
+	}
+	binary.BigEndian.PutUint16(configDigest[:2], uint16(prefix))
+	return configDigest
+}
+
+func writeUint64(h hash.Hash, v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	_, _ = h.Write(b[:])
+}
+
+func writeBytes(h hash.Hash, b []byte) {
+	writeUint64(h, uint64(len(b)))
+	_, _ = h.Write(b)
+}
@@ -0,0 +1,256 @@
+// Package configdigestvectors provides canonical test vectors for the
+// OffchainConfigDigesters that ship with libocr, for every chain family and
+// offchain config version (OCR2 and OCR3) they are used with.
+//
+// Tooling that computes config digests outside of libocr, e.g. a deploy
+// pipeline or a contract, can check its implementation against Vectors.
+// Integrators maintaining their own digester can run Check with vectors of
+// their own to catch accidental changes of their encoding.
+//
+// The expected digests were computed by an implementation independent of
+// libocr: a from-spec Keccak-256 and ABI encoder mirroring
+// _configDigestFromConfigData of the deployed OCR2 aggregator for EVM, the
+// deployed cosmwasm contracts' encoding for Cosmos, and a direct transcription
+// of the encoding documented on configdigester.SHA256ConfigDigest. A mismatch
+// thus indicates a regression in libocr, not in the vectors.
+//
+// The vectors never change once published. A digester whose output for a
+// published vector changes would compute different digests than the contracts
+// it is used with. New digesters should add vectors here.
+package configdigestvectors
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/chains/configdigester"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/chains/cosmosutil"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/chains/evmutil"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// Vector is a ContractConfig together with the digester that computes its
+// ConfigDigest.
+type Vector struct {
+	// Chain family and what the vector exercises, e.g. "EVM/OCR3"
+	Name     string
+	Digester types.OffchainConfigDigester
+	// ContractConfig.ConfigDigest is the expected digest.
+	ContractConfig types.ContractConfig
+}
+
+// Offchain config versions, see OffchainConfigVersion in ContractConfig. We
+// duplicate them here since the constants in package internal/config aren't
+// exported.
+const (
+	ocr2OffchainConfigVersion = 2
+	ocr3OffchainConfigVersion = 30
+)
+
+// Vectors returns the canonical test vectors. The result is freshly allocated
+// on every call, so callers may modify it.
+func Vectors() []Vector {
+	evmDigester := evmutil.EVMOffchainConfigDigester{
+		1,
+		common.HexToAddress("0x1234567890123456789012345678901234567890"),
+	}
+	evmSigners := func(n int) []types.OnchainPublicKey {
+		signers := make([]types.OnchainPublicKey, 0, n)
+		for i := 0; i < n; i++ {
+			signer := make([]byte, 20)
+			for j := range signer {
+				signer[j] = byte(0x10 + i)
+			}
+			signers = append(signers, signer)
+		}
+		return signers
+	}
+	evmTransmitters := func(n int) []types.Account {
+		transmitters := make([]types.Account, 0, n)
+		for i := 0; i < n; i++ {
+			var transmitter common.Address
+			for j := range transmitter {
+				transmitter[j] = byte(0xa0 + i)
+			}
+			transmitters = append(transmitters, types.Account(transmitter.Hex()))
+		}
+		return transmitters
+	}
+
+	cosmosDigester := cosmosutil.CosmosOffchainConfigDigester{
+		"cosmoshub-4",
+		"wasm1zqg3yyc5z5tpwxqergd3c8g7ruszzg3rysjjvfeg9y4zktpd9chs9spvuk",
+	}
+	cosmosSigners := make([]types.OnchainPublicKey, 0, 4)
+	for i := 0; i < 4; i++ {
+		// ed25519 public keys
+		signer := make([]byte, 32)
+		for j := range signer {
+			signer[j] = byte(0x30 + i)
+		}
+		cosmosSigners = append(cosmosSigners, signer)
+	}
+	cosmosTransmitters := []types.Account{
+		"wasm15zs2pg9q5zs2pg9q5zs2pg9q5zs2pg9qg7c5s9",
+		"wasm15xs6rgdp5xs6rgdp5xs6rgdp5xs6rgdpfwe46y",
+		"wasm152329g4z52329g4z52329g4z52329g4zc2ls3j",
+		"wasm15w368gar5w368gar5w368gar5w368gare673mn",
+	}
+
+	sha256Digester := sha256ConfigDigester{
+		0xFFFF,
+		[][]byte{[]byte("example-chain-1"), []byte("example-contract")},
+	}
+	sha256Signers := make([]types.OnchainPublicKey, 0, 4)
+	sha256Transmitters := make([]types.Account, 0, 4)
+	for i := 0; i < 4; i++ {
+		signer := make([]byte, 32)
+		for j := range signer {
+			signer[j] = byte(0x50 + i)
+		}
+		sha256Signers = append(sha256Signers, signer)
+		sha256Transmitters = append(sha256Transmitters, types.Account(fmt.Sprintf("transmitter-%d", i)))
+	}
+
+	return []Vector{
+		{
+			"EVM/OCR2",
+			evmDigester,
+			types.ContractConfig{
+				mustHexToConfigDigest("0x00010df277d742342d3ac5341cbfe5a0b34c14e038ba46dd23d09fd9b15ad70e"),
+				1,
+				evmSigners(4),
+				evmTransmitters(4),
+				1,
+				[]byte{0x01, 0x02, 0x03},
+				ocr2OffchainConfigVersion,
+				[]byte("ocr2 offchain config"),
+			},
+		},
+		{
+			"EVM/OCR3",
+			evmDigester,
+			types.ContractConfig{
+				mustHexToConfigDigest("0x0001f3c8738f32dd0ad347786d5bd5da3ff51aca799262e5c2fd6ac215951bd8"),
+				2,
+				evmSigners(4),
+				evmTransmitters(4),
+				1,
+				[]byte{0x01, 0x02, 0x03},
+				ocr3OffchainConfigVersion,
+				[]byte("ocr3 offchain config"),
+			},
+		},
+		{
+			"EVM/OCR3 with empty onchain and offchain config",
+			evmDigester,
+			types.ContractConfig{
+				mustHexToConfigDigest("0x00012a051917b48652ff7c017264215c923efc82577bf613c5f9fdbb5190ae16"),
+				3,
+				evmSigners(7),
+				evmTransmitters(7),
+				2,
+				nil,
+				ocr3OffchainConfigVersion,
+				nil,
+			},
+		},
+		{
+			"EVM/OCR3 with 31 oracles",
+			evmDigester,
+			types.ContractConfig{
+				mustHexToConfigDigest("0x0001d7f9574bc53c74aed2aeb7cca2d8576ca3c742eb647495d4daa3fe893703"),
+				4,
+				evmSigners(31),
+				evmTransmitters(31),
+				10,
+				[]byte{0x01, 0x02, 0x03},
+				ocr3OffchainConfigVersion,
+				[]byte("ocr3 offchain config"),
+			},
+		},
+		{
+			"Cosmos/OCR2",
+			cosmosDigester,
+			types.ContractConfig{
+				mustHexToConfigDigest("0x0002ae8a93a70a4b38aaace171d4461e97924514c652fc8a2a5d5941993a2fd6"),
+				1,
+				cosmosSigners,
+				cosmosTransmitters,
+				1,
+				[]byte{0x01, 0x02, 0x03},
+				ocr2OffchainConfigVersion,
+				[]byte("ocr2 offchain config"),
+			},
+		},
+		{
+			"Cosmos/OCR3",
+			cosmosDigester,
+			types.ContractConfig{
+				mustHexToConfigDigest("0x00023be30d00a29b22ce999f77cf01c5018eedacc8cc53b9de2a0a665d087528"),
+				2,
+				cosmosSigners,
+				cosmosTransmitters,
+				1,
+				[]byte{0x01, 0x02, 0x03},
+				ocr3OffchainConfigVersion,
+				[]byte("ocr3 offchain config"),
+			},
+		},
+		{
+			"SHA256ConfigDigest/OCR3",
+			sha256Digester,
+			types.ContractConfig{
+				mustHexToConfigDigest("0xffff937c44749f1bda2a13756df95da5b20990ab36359c049f5cd33017c5bed4"),
+				1,
+				sha256Signers,
+				sha256Transmitters,
+				1,
+				[]byte{0x01, 0x02, 0x03},
+				ocr3OffchainConfigVersion,
+				[]byte("ocr3 offchain config"),
+			},
+		},
+	}
+}
+
+// sha256ConfigDigester exercises configdigester.SHA256ConfigDigest. No chain
+// uses it, so its prefix is the unregistered 0xFFFF and its domain is made up.
+type sha256ConfigDigester struct {
+	prefix types.ConfigDigestPrefix
+	domain [][]byte
+}
+
+var _ types.OffchainConfigDigester = sha256ConfigDigester{}
+
+func (d sha256ConfigDigester) ConfigDigest(cc types.ContractConfig) (types.ConfigDigest, error) {
+	return configdigester.SHA256ConfigDigest(d.prefix, d.domain, cc), nil
+}
+
+func (d sha256ConfigDigester) ConfigDigestPrefix() (types.ConfigDigestPrefix, error) {
+	return d.prefix, nil
+}
+
+// Check recomputes the digest of every vector with its digester and returns
+// an error listing all vectors whose digest differs from the expected one.
+func Check(vectors []Vector) error {
+	var err error
+	for _, v := range vectors {
+		if verifyErr := configdigester.VerifyConfigDigest(v.Digester, v.ContractConfig); verifyErr != nil {
+			err = multierr.Append(err, fmt.Errorf("vector %q: %w", v.Name, verifyErr))
+		}
+	}
+	return err
+}
+
+func mustHexToConfigDigest(s string) types.ConfigDigest {
+	b := common.FromHex(s)
+	configDigest, err := types.BytesToConfigDigest(b)
+	if err != nil {
+		// assertion
+		panic(err)
+	}
+	return configDigest
+}
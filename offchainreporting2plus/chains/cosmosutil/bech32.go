@@ -0,0 +1,100 @@
+package cosmosutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cosmos-SDK addresses are bech32-encoded as specified by BIP-173, except
+// that they may be longer than 90 characters.
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Same limit as the Cosmos SDK
+const maxBech32Length = 1023
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	expanded := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// decodeBech32 returns the human-readable part and the decoded data of a
+// bech32 string.
+func decodeBech32(s string) (hrp string, data []byte, err error) {
+	if len(s) > maxBech32Length {
+		return "", nil, fmt.Errorf("bech32 string is longer than %v characters", maxBech32Length)
+	}
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, fmt.Errorf("bech32 string has mixed case")
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, fmt.Errorf("bech32 string has invalid separator position")
+	}
+	hrp = s[:sep]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, fmt.Errorf("bech32 human-readable part has invalid character %q", hrp[i])
+		}
+	}
+
+	values := make([]byte, 0, len(s)-sep-1)
+	for i := sep + 1; i < len(s); i++ {
+		v := strings.IndexByte(bech32Charset, s[i])
+		if v < 0 {
+			return "", nil, fmt.Errorf("bech32 data part has invalid character %q", s[i])
+		}
+		values = append(values, byte(v))
+	}
+	if bech32Polymod(append(bech32HRPExpand(hrp), values...)) != 1 {
+		return "", nil, fmt.Errorf("bech32 string has invalid checksum")
+	}
+
+	data, err = convertBits5To8(values[:len(values)-6])
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
+
+func convertBits5To8(values []byte) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	out := make([]byte, 0, len(values)*5/8)
+	for _, v := range values {
+		acc = acc<<5 | uint32(v)
+		bits += 5
+		for bits >= 8 {
+			bits -= 8
+			out = append(out, byte(acc>>bits))
+		}
+	}
+	if bits >= 5 || (acc<<(8-bits))&0xff != 0 {
+		return nil, fmt.Errorf("bech32 data part has invalid padding")
+	}
+	return out, nil
+}
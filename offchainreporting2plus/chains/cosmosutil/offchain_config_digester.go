@@ -0,0 +1,101 @@
+// Package cosmosutil contains helpers for running OCR against contracts on
+// Cosmos-SDK chains.
+package cosmosutil
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+var _ types.OffchainConfigDigester = CosmosOffchainConfigDigester{}
+
+// CosmosOffchainConfigDigester computes config digests the same way as the
+// deployed cosmwasm OCR2 contracts, using ConfigDigestPrefixTerra: the SHA-256
+// hash of
+//
+//	u32(len(chainID)) || chainID
+//	u32(len(contractAddress)) || contractAddress
+//	u32(configCount)
+//	u32(len(signers)) || signers[0] || signers[1] || ...
+//	u32(len(transmitters)) || u32(len(transmitters[0])) || transmitters[0] || ...
+//	u8(f)
+//	u32(len(onchainConfig)) || onchainConfig
+//	u64(offchainConfigVersion)
+//	u32(len(offchainConfig)) || offchainConfig
+//
+// with all integers big-endian and the first two bytes replaced by the prefix.
+// chainID (e.g. "cosmoshub-4"), contractAddress and the transmitters are hashed
+// as the bech32 strings given, signers as raw bytes. Transmitters must be
+// bech32 addresses with the same human-readable part as ContractAddress.
+type CosmosOffchainConfigDigester struct {
+	ChainID string
+	// bech32-encoded, e.g. "wasm1..."
+	ContractAddress string
+}
+
+func (d CosmosOffchainConfigDigester) ConfigDigest(cc types.ContractConfig) (types.ConfigDigest, error) {
+	if d.ChainID == "" {
+		return types.ConfigDigest{}, fmt.Errorf("cosmos ChainID must not be empty")
+	}
+	hrp, contractAddressData, err := decodeBech32(d.ContractAddress)
+	if err != nil {
+		return types.ConfigDigest{}, fmt.Errorf("invalid cosmos contract address '%v': %w", d.ContractAddress, err)
+	}
+	if len(contractAddressData) == 0 {
+		return types.ConfigDigest{}, fmt.Errorf("cosmos contract address '%v' is empty", d.ContractAddress)
+	}
+
+	for i, signer := range cc.Signers {
+		if len(signer) == 0 {
+			return types.ConfigDigest{}, fmt.Errorf("%v-th cosmos signer is empty", i)
+		}
+	}
+	for i, transmitter := range cc.Transmitters {
+		transmitterHRP, _, err := decodeBech32(string(transmitter))
+		if err != nil {
+			return types.ConfigDigest{}, fmt.Errorf("%v-th cosmos transmitter should be a bech32 address, but got '%v': %w", i, transmitter, err)
+		}
+		if transmitterHRP != hrp {
+			return types.ConfigDigest{}, fmt.Errorf("%v-th cosmos transmitter '%v' should have human-readable part '%v' like the contract address", i, transmitter, hrp)
+		}
+	}
+
+	if cc.ConfigCount > math.MaxUint32 {
+		return types.ConfigDigest{}, fmt.Errorf("cosmos ConfigCount must fit into a uint32, but got %v", cc.ConfigCount)
+	}
+
+	buf := sha256.New()
+	writeLengthPrefixed := func(b []byte) {
+		_ = binary.Write(buf, binary.BigEndian, uint32(len(b)))
+		buf.Write(b)
+	}
+
+	writeLengthPrefixed([]byte(d.ChainID))
+	writeLengthPrefixed([]byte(d.ContractAddress))
+	_ = binary.Write(buf, binary.BigEndian, uint32(cc.ConfigCount))
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(cc.Signers)))
+	for _, signer := range cc.Signers {
+		buf.Write(signer)
+	}
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(cc.Transmitters)))
+	for _, transmitter := range cc.Transmitters {
+		writeLengthPrefixed([]byte(transmitter))
+	}
+	buf.Write([]byte{cc.F})
+	writeLengthPrefixed(cc.OnchainConfig)
+	_ = binary.Write(buf, binary.BigEndian, cc.OffchainConfigVersion)
+	writeLengthPrefixed(cc.OffchainConfig)
+
+	var configDigest types.ConfigDigest
+	copy(configDigest[:], buf.Sum(nil))
+	binary.BigEndian.PutUint16(configDigest[:2], uint16(types.ConfigDigestPrefixTerra))
+	return configDigest, nil
+}
+
+func (d CosmosOffchainConfigDigester) ConfigDigestPrefix() (types.ConfigDigestPrefix, error) {
+	return types.ConfigDigestPrefixTerra, nil
+}
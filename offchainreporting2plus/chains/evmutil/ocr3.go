@@ -0,0 +1,124 @@
+package evmutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/smartcontractkit/libocr/gethwrappers2/ocr2aggregator"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// OCR3RawReportContext returns the report context of the OCR3 report with
+// seqNr as passed to the transmit function of OCR3 contracts such as
+// MultiOCR3Base:
+//
+//	[configDigest, 24 bytes of zero padding || big-endian seqNr, 32 bytes of zero padding]
+func OCR3RawReportContext(configDigest types.ConfigDigest, seqNr uint64) [3][32]byte {
+	rawRepctx := [3][32]byte{}
+	copy(rawRepctx[0][:], configDigest[:])
+	binary.BigEndian.PutUint64(rawRepctx[1][32-8:], seqNr)
+	return rawRepctx
+}
+
+// OCR3ReportDigest returns the digest that oracles sign for the OCR3 report
+// with seqNr:
+//
+//	keccak256(keccak256(report) || rawReportContext[0] || rawReportContext[1])
+//
+// where rawReportContext is OCR3RawReportContext(configDigest, seqNr). Unlike
+// for OCR2 reports, the last word of the report context isn't signed.
+func OCR3ReportDigest(configDigest types.ConfigDigest, seqNr uint64, report types.Report) []byte {
+	rawRepctx := OCR3RawReportContext(configDigest, seqNr)
+	sigData := crypto.Keccak256(report)
+	sigData = append(sigData, rawRepctx[0][:]...)
+	sigData = append(sigData, rawRepctx[1][:]...)
+	return crypto.Keccak256(sigData)
+}
+
+func makeTransmitMethod() abi.Method {
+	abi, err := abi.JSON(strings.NewReader(ocr2aggregator.OCR2AggregatorABI))
+	if err != nil {
+		// assertion
+		panic(fmt.Sprintf("could not parse aggregator ABI: %s", err.Error()))
+	}
+	return abi.Methods["transmit"]
+}
+
+// OCR3 contracts share the transmit function of OCR2Aggregator
+var transmitMethod = makeTransmitMethod()
+
+// OCR3TransmitCalldata returns the calldata for calling
+//
+//	transmit(bytes32[3] reportContext, bytes report, bytes32[] rs, bytes32[] ss, bytes32 rawVs)
+//
+// with the OCR3 report with seqNr and its signatures, which must be 65 bytes
+// long each (r || s || v with v ∈ {0, 1}). Contracts typically require
+// exactly f+1 signatures.
+func OCR3TransmitCalldata(configDigest types.ConfigDigest, seqNr uint64, report types.Report, signatures []types.AttributedOnchainSignature) ([]byte, error) {
+	if len(signatures) > 32 {
+		return nil, fmt.Errorf("too many signatures (%v), rawVs holds at most 32", len(signatures))
+	}
+	rs := make([][32]byte, 0, len(signatures))
+	ss := make([][32]byte, 0, len(signatures))
+	var rawVs [32]byte
+	for i, as := range signatures {
+		r, s, v, err := SplitSignature(as.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("signature of oracle %v: %w", as.Signer, err)
+		}
+		rs = append(rs, r)
+		ss = append(ss, s)
+		rawVs[i] = v
+	}
+
+	args, err := transmitMethod.Inputs.Pack(
+		OCR3RawReportContext(configDigest, seqNr),
+		[]byte(report),
+		rs,
+		ss,
+		rawVs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not ABI-encode transmit arguments: %w", err)
+	}
+	return append(append([]byte{}, transmitMethod.ID...), args...), nil
+}
+
+// VerifyOCR3Signatures checks offline, i.e. without calling the contract, that
+// signatures attest the OCR3 report with seqNr under the config cc, as the
+// contract would: every signature must recover to the onchain public key
+// (address) of its signer, no signer may sign twice, and there must be at
+// least cc.F+1 signatures.
+func VerifyOCR3Signatures(cc types.ContractConfig, seqNr uint64, report types.Report, signatures []types.AttributedOnchainSignature) error {
+	if len(signatures) < int(cc.F)+1 {
+		return fmt.Errorf("got %v signatures, need at least f+1 (%v)", len(signatures), int(cc.F)+1)
+	}
+	digest := OCR3ReportDigest(cc.ConfigDigest, seqNr, report)
+	seen := make(map[int]bool, len(signatures))
+	for _, as := range signatures {
+		signer := int(as.Signer)
+		if !(0 <= signer && signer < len(cc.Signers)) {
+			return fmt.Errorf("signer %v out of range, config has %v signers", as.Signer, len(cc.Signers))
+		}
+		if seen[signer] {
+			return fmt.Errorf("duplicate signature of oracle %v", as.Signer)
+		}
+		seen[signer] = true
+		if len(as.Signature) != 65 {
+			return fmt.Errorf("signature of oracle %v has length %v, expected 65", as.Signer, len(as.Signature))
+		}
+		publicKey, err := crypto.SigToPub(digest, as.Signature)
+		if err != nil {
+			return fmt.Errorf("could not recover public key from signature of oracle %v: %w", as.Signer, err)
+		}
+		address := crypto.PubkeyToAddress(*publicKey)
+		if !bytes.Equal(address[:], cc.Signers[signer]) {
+			return fmt.Errorf("signature of oracle %v recovers to %v, but its onchain public key is %x", as.Signer, address, []byte(cc.Signers[signer]))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Package chaos provides fault-injection hooks for OCR3 oracles, so operators
+// can run controlled game-day exercises against staging DONs using production
+// binaries.
+//
+// Fault injection is gated: an oracle only has hooks if a Controller is
+// passed in OCR3OracleArgs.ChaosController. Without one, nothing in this
+// package affects the oracle. Each injected fault is one-shot, i.e. it is
+// consumed by the next matching event and then cleared.
+//
+// The Controller can be driven directly from Go or through the HTTP handler
+// returned by Controller.Handler. The handler performs no authentication, so
+// it must only ever be served on a local/loopback interface.
+package chaos
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Controller holds the faults that have been requested for an oracle but not
+// yet injected. All its functions are thread-safe.
+type Controller struct {
+	mu               sync.Mutex
+	dropTransmission bool
+	observationDelay time.Duration
+
+	chEpochChange chan struct{}
+}
+
+func NewController() *Controller {
+	return &Controller{
+		chEpochChange: make(chan struct{}, 1),
+	}
+}
+
+// DropNextTransmission causes the oracle to silently drop the next report it
+// would otherwise pass to its ContractTransmitter.
+func (c *Controller) DropNextTransmission() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dropTransmission = true
+}
+
+// DelayNextObservation causes the oracle to delay the next call to the
+// ReportingPlugin's Observation function by delay. The delay counts towards
+// MaxDurationObservation, so delays at least that long cause the observation
+// to be missed altogether.
+func (c *Controller) DelayNextObservation(delay time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.observationDelay = delay
+}
+
+// ForceEpochChange causes the oracle to act as if the current leader had
+// failed to make progress, i.e. the oracle immediately broadcasts a wish to
+// move to the next epoch. Note that the epoch only changes if enough oracles
+// wish for it, so this needs to be triggered on at least f+1 oracles of the
+// DON.
+func (c *Controller) ForceEpochChange() {
+	select {
+	case c.chEpochChange <- struct{}{}:
+	default:
+		// an epoch change is already pending
+	}
+}
+
+// ShouldDropTransmission is called by the oracle for every transmission. It
+// returns true and clears the request if DropNextTransmission was called.
+func (c *Controller) ShouldDropTransmission() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	drop := c.dropTransmission
+	c.dropTransmission = false
+	return drop
+}
+
+// ObservationDelay is called by the oracle for every observation. It returns
+// and clears the delay requested with DelayNextObservation.
+func (c *Controller) ObservationDelay() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delay := c.observationDelay
+	c.observationDelay = 0
+	return delay
+}
+
+// EpochChanges returns a channel that receives a value for every call to
+// ForceEpochChange. It is consumed by the oracle.
+func (c *Controller) EpochChanges() <-chan struct{} {
+	return c.chEpochChange
+}
+
+// Handler returns an http.Handler exposing the controller as a local control
+// API. It accepts POST requests to the following paths:
+//
+//	/drop-next-transmission
+//	/delay-next-observation?delay=<duration>   (e.g. delay=5s)
+//	/force-epoch-change
+//
+// The handler performs no authentication.
+func (c *Controller) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/drop-next-transmission", c.post(func(r *http.Request) error {
+		c.DropNextTransmission()
+		return nil
+	}))
+	mux.HandleFunc("/delay-next-observation", c.post(func(r *http.Request) error {
+		delay, err := time.ParseDuration(r.URL.Query().Get("delay"))
+		if err != nil {
+			return fmt.Errorf("invalid delay: %w", err)
+		}
+		if delay < 0 {
+			return fmt.Errorf("delay must not be negative")
+		}
+		c.DelayNextObservation(delay)
+		return nil
+	}))
+	mux.HandleFunc("/force-epoch-change", c.post(func(r *http.Request) error {
+		c.ForceEpochChange()
+		return nil
+	}))
+	return mux
+}
+
+func (c *Controller) post(f func(*http.Request) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := f(r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -89,6 +89,32 @@ func PublicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 	}, nil
 }
 
+// ValidateOracleSet checks that oracles, the transmission schedule s, and f
+// form a valid oracle set for an OCR2 or OCR3 config. Deploy tooling should
+// call it before submitting a setConfig transaction: N, F, and the oracle
+// identities can't be changed within a protocol instance, and oracles refuse
+// to run instances with an invalid oracle set, so a faulty setConfig halts
+// the DON until it's superseded by a valid one.
+//
+// ValidateOracleSet is stricter than the checks oracles apply to configs read
+// from the contract. It also rejects an f that doesn't fit into 8 bits,
+// oracles lacking a signer, transmitter, or peer ID, and schedules s under
+// which no correct oracle might transmit. If validation fails, the returned
+// error is a *types.InvalidOracleSetError.
+func ValidateOracleSet(oracles []OracleIdentity, s []int, f int) error {
+	identities := make([]config.OracleIdentity, 0, len(oracles))
+	for _, oracle := range oracles {
+		identities = append(identities, config.OracleIdentity{
+			oracle.OffchainPublicKey,
+			oracle.OnchainPublicKey,
+			oracle.PeerID,
+			oracle.TransmitAccount,
+			types.OffchainPublicKey{},
+		})
+	}
+	return config.CheckOracleSetStrict(identities, s, f)
+}
+
 type OracleIdentityExtra struct {
 	OracleIdentity
 	ConfigEncryptionPublicKey types.ConfigEncryptionPublicKey
@@ -119,6 +145,7 @@ func ContractSetConfigArgsForEthereumIntegrationTest(
 			oracle.OnchainPublicKey,
 			oracle.PeerID,
 			oracle.TransmitAccount,
+			types.OffchainPublicKey{},
 		})
 		sharedSecretEncryptionPublicKeys = append(sharedSecretEncryptionPublicKeys, oracle.ConfigEncryptionPublicKey)
 	}
@@ -198,6 +225,7 @@ func ContractSetConfigArgsForTestsWithAuxiliaryArgs(
 			oracle.OnchainPublicKey,
 			oracle.PeerID,
 			oracle.TransmitAccount,
+			types.OffchainPublicKey{},
 		})
 		configEncryptionPublicKeys = append(configEncryptionPublicKeys, oracle.ConfigEncryptionPublicKey)
 	}
@@ -377,6 +405,7 @@ func ContractSetConfigArgsForTestsOCR3(
 			oracle.OnchainPublicKey,
 			oracle.PeerID,
 			oracle.TransmitAccount,
+			types.OffchainPublicKey{},
 		})
 		configEncryptionPublicKeys = append(configEncryptionPublicKeys, oracle.ConfigEncryptionPublicKey)
 	}
@@ -403,6 +432,12 @@ func ContractSetConfigArgsForTestsOCR3(
 			maxDurationObservation,
 			maxDurationShouldAcceptAttestedReport,
 			maxDurationShouldTransmitAcceptedReport,
+			0,
+			0,
+			0,
+			nil,
+			0,
+			0,
 			f,
 			onchainConfig,
 			types.ConfigDigest{},
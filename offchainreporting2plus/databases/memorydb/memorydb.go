@@ -0,0 +1,321 @@
+// Package memorydb provides an in-memory implementation of types.Database and
+// ocr3types.Database, intended for tests and local development. Its contents
+// are lost when the process exits, so it must not be used in production: an
+// oracle that forgets its protocol state across restarts may send messages that
+// conflict with ones it sent before.
+//
+// All values are copied on the way in and on the way out, so callers may
+// freely modify slices they have passed to or received from a DB.
+package memorydb
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// DB is safe for concurrent use. The zero value is not usable, use New.
+type DB struct {
+	latencyMu sync.RWMutex
+	latency   time.Duration
+
+	mutex                sync.Mutex
+	config               *types.ContractConfig
+	states               map[types.ConfigDigest]types.PersistentState
+	pendingTransmissions map[types.ReportTimestamp]types.PendingTransmission
+	protocolStates       map[types.ConfigDigest]map[string][]byte
+	quarantined          map[types.ConfigDigest]map[string][]byte
+}
+
+var (
+	_ types.Database                     = (*DB)(nil)
+	_ types.PendingTransmissionPruner    = (*DB)(nil)
+	_ ocr3types.Database                 = (*DB)(nil)
+	_ ocr3types.ProtocolStatePruner      = (*DB)(nil)
+	_ ocr3types.ProtocolStateQuarantiner = (*DB)(nil)
+)
+
+func New() *DB {
+	return &DB{
+		sync.RWMutex{},
+		0,
+
+		sync.Mutex{},
+		nil,
+		map[types.ConfigDigest]types.PersistentState{},
+		map[types.ReportTimestamp]types.PendingTransmission{},
+		map[types.ConfigDigest]map[string][]byte{},
+		map[types.ConfigDigest]map[string][]byte{},
+	}
+}
+
+// SetLatency makes every subsequent call wait for latency before accessing the
+// DB, e.g. to test how a plugin copes with a slow database. A call whose ctx
+// expires before then returns ctx.Err() without accessing the DB. Zero
+// disables the artificial latency.
+func (db *DB) SetLatency(latency time.Duration) {
+	db.latencyMu.Lock()
+	defer db.latencyMu.Unlock()
+
+	db.latency = latency
+}
+
+func (db *DB) wait(ctx context.Context) error {
+	db.latencyMu.RLock()
+	latency := db.latency
+	db.latencyMu.RUnlock()
+
+	if latency <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (db *DB) ReadConfig(ctx context.Context) (*types.ContractConfig, error) {
+	if err := db.wait(ctx); err != nil {
+		return nil, err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.config == nil {
+		return nil, nil
+	}
+	config := copyContractConfig(*db.config)
+	return &config, nil
+}
+
+func (db *DB) WriteConfig(ctx context.Context, config types.ContractConfig) error {
+	if err := db.wait(ctx); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	config = copyContractConfig(config)
+	db.config = &config
+	return nil
+}
+
+func (db *DB) ReadState(ctx context.Context, configDigest types.ConfigDigest) (*types.PersistentState, error) {
+	if err := db.wait(ctx); err != nil {
+		return nil, err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	state, ok := db.states[configDigest]
+	if !ok {
+		return nil, nil
+	}
+	state = copyPersistentState(state)
+	return &state, nil
+}
+
+func (db *DB) WriteState(ctx context.Context, configDigest types.ConfigDigest, state types.PersistentState) error {
+	if err := db.wait(ctx); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.states[configDigest] = copyPersistentState(state)
+	return nil
+}
+
+func (db *DB) StorePendingTransmission(ctx context.Context, timestamp types.ReportTimestamp, transmission types.PendingTransmission) error {
+	if err := db.wait(ctx); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.pendingTransmissions[timestamp] = copyPendingTransmission(transmission)
+	return nil
+}
+
+func (db *DB) PendingTransmissionsWithConfigDigest(ctx context.Context, configDigest types.ConfigDigest) (map[types.ReportTimestamp]types.PendingTransmission, error) {
+	if err := db.wait(ctx); err != nil {
+		return nil, err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	result := map[types.ReportTimestamp]types.PendingTransmission{}
+	for timestamp, transmission := range db.pendingTransmissions {
+		if timestamp.ConfigDigest == configDigest {
+			result[timestamp] = copyPendingTransmission(transmission)
+		}
+	}
+	return result, nil
+}
+
+func (db *DB) DeletePendingTransmission(ctx context.Context, timestamp types.ReportTimestamp) error {
+	if err := db.wait(ctx); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	delete(db.pendingTransmissions, timestamp)
+	return nil
+}
+
+func (db *DB) DeletePendingTransmissionsOlderThan(ctx context.Context, t time.Time) error {
+	if err := db.wait(ctx); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	for timestamp, transmission := range db.pendingTransmissions {
+		if transmission.Time.Before(t) {
+			delete(db.pendingTransmissions, timestamp)
+		}
+	}
+	return nil
+}
+
+func (db *DB) DeletePendingTransmissionsWithOtherConfigDigest(ctx context.Context, configDigest types.ConfigDigest) error {
+	if err := db.wait(ctx); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	for timestamp := range db.pendingTransmissions {
+		if timestamp.ConfigDigest != configDigest {
+			delete(db.pendingTransmissions, timestamp)
+		}
+	}
+	return nil
+}
+
+func (db *DB) ReadProtocolState(ctx context.Context, configDigest types.ConfigDigest, key string) ([]byte, error) {
+	if err := db.wait(ctx); err != nil {
+		return nil, err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	value, ok := db.protocolStates[configDigest][key]
+	if !ok {
+		return nil, nil
+	}
+	return bytes.Clone(value), nil
+}
+
+func (db *DB) WriteProtocolState(ctx context.Context, configDigest types.ConfigDigest, key string, value []byte) error {
+	if err := db.wait(ctx); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if value == nil {
+		delete(db.protocolStates[configDigest], key)
+		if len(db.protocolStates[configDigest]) == 0 {
+			delete(db.protocolStates, configDigest)
+		}
+		return nil
+	}
+	if db.protocolStates[configDigest] == nil {
+		db.protocolStates[configDigest] = map[string][]byte{}
+	}
+	db.protocolStates[configDigest][key] = bytes.Clone(value)
+	return nil
+}
+
+func (db *DB) DeleteProtocolStateWithOtherConfigDigest(ctx context.Context, configDigest types.ConfigDigest) error {
+	if err := db.wait(ctx); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	for digest := range db.protocolStates {
+		if digest != configDigest {
+			delete(db.protocolStates, digest)
+		}
+	}
+	return nil
+}
+
+// QuarantineProtocolState keeps value apart from the protocol state, where it
+// survives DeleteProtocolStateWithOtherConfigDigest. A later call for the same
+// configDigest and key replaces the value.
+func (db *DB) QuarantineProtocolState(ctx context.Context, configDigest types.ConfigDigest, key string, value []byte) error {
+	if err := db.wait(ctx); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.quarantined[configDigest] == nil {
+		db.quarantined[configDigest] = map[string][]byte{}
+	}
+	db.quarantined[configDigest][key] = append([]byte{}, value...)
+	return nil
+}
+
+// QuarantinedProtocolState returns the value last quarantined for
+// configDigest and key, or nil if there is none.
+func (db *DB) QuarantinedProtocolState(configDigest types.ConfigDigest, key string) []byte {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	value, ok := db.quarantined[configDigest][key]
+	if !ok {
+		return nil
+	}
+	return append([]byte{}, value...)
+}
+
+func copyContractConfig(c types.ContractConfig) types.ContractConfig {
+	signers := make([]types.OnchainPublicKey, len(c.Signers))
+	for i, signer := range c.Signers {
+		signers[i] = bytes.Clone(signer)
+	}
+	return types.ContractConfig{
+		c.ConfigDigest,
+		c.ConfigCount,
+		signers,
+		append([]types.Account(nil), c.Transmitters...),
+		c.F,
+		bytes.Clone(c.OnchainConfig),
+		c.OffchainConfigVersion,
+		bytes.Clone(c.OffchainConfig),
+	}
+}
+
+func copyPersistentState(s types.PersistentState) types.PersistentState {
+	return types.PersistentState{
+		s.Epoch,
+		s.HighestSentEpoch,
+		append([]uint32(nil), s.HighestReceivedEpoch...),
+	}
+}
+
+func copyPendingTransmission(t types.PendingTransmission) types.PendingTransmission {
+	signatures := make([]types.AttributedOnchainSignature, len(t.AttributedSignatures))
+	for i, signature := range t.AttributedSignatures {
+		signatures[i] = types.AttributedOnchainSignature{bytes.Clone(signature.Signature), signature.Signer}
+	}
+	return types.PendingTransmission{
+		t.Time,
+		t.ExtraHash,
+		bytes.Clone(t.Report),
+		signatures,
+	}
+}
@@ -0,0 +1,322 @@
+// Package delegatedtransmission implements an ocr3types.ContractTransmitter
+// that doesn't submit transactions itself, but hands attested reports to an
+// external transmission service, e.g. a tx-manager that centralizes onchain
+// submission for several oracles.
+//
+// libocr doesn't prescribe how to talk to the service. Typically, Service is
+// implemented by a thin wrapper around a gRPC client stub, with Transmission
+// mapped to a request message and the IdempotencyKey sent along as request
+// metadata.
+//
+// # Delivery contract
+//
+// Transmissions are delivered at least once: Every transmission is first
+// added to a PendingStore, and only removed once the Service has acknowledged
+// it. Unacknowledged transmissions are resubmitted with backoff until they are
+// acknowledged, including after restarts if the PendingStore is persistent.
+// Consequently, the Service may receive the same transmission several times
+// and must use the IdempotencyKey to deduplicate.
+//
+// The PendingStore holds at most maxPending transmissions (see
+// NewTransmitter). If the Service stays unavailable for long enough that more
+// transmissions pile up, the oldest ones are evicted and never delivered. By
+// then, they are typically stale anyway, since newer reports supersede them.
+package delegatedtransmission
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/backoff"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+// IdempotencyKey uniquely identifies a report of a protocol instance. All
+// oracles of a DON derive the same key for the same report, so the Service
+// may also use it to deduplicate transmissions across oracles.
+type IdempotencyKey [32]byte
+
+func (k IdempotencyKey) Hex() string {
+	return hex.EncodeToString(k[:])
+}
+
+const idempotencyKeyDomainSeparator = "ocr3 delegated transmission idempotency key"
+
+func MakeIdempotencyKey(configDigest types.ConfigDigest, seqNr uint64, report types.Report) IdempotencyKey {
+	h := sha256.New()
+	_, _ = h.Write([]byte(idempotencyKeyDomainSeparator))
+	_, _ = h.Write(configDigest[:])
+	_ = binary.Write(h, binary.BigEndian, seqNr)
+	_ = binary.Write(h, binary.BigEndian, uint64(len(report)))
+	_, _ = h.Write(report)
+	var result IdempotencyKey
+	h.Sum(result[:0])
+	return result
+}
+
+// Transmission bundles everything the protocol passes to
+// ContractTransmitter.Transmit.
+type Transmission[RI any] struct {
+	IdempotencyKey IdempotencyKey
+	ConfigDigest   types.ConfigDigest
+	SeqNr          uint64
+	ReportWithInfo ocr3types.ReportWithInfo[RI]
+	Signatures     []types.AttributedOnchainSignature
+}
+
+// Service is the external transmission service.
+type Service[RI any] interface {
+	// Submit hands the transmission to the service. A nil error acknowledges
+	// the transmission: the service has durably accepted it and is now
+	// responsible for getting it onchain. Submit may be called repeatedly
+	// with the same transmission, see the package documentation.
+	Submit(context.Context, Transmission[RI]) error
+}
+
+// PendingStore keeps track of transmissions that haven't been acknowledged by
+// the Service yet. Use a persistent implementation (e.g. backed by the same
+// database as ocr3types.Database) to retain at-least-once delivery across
+// restarts.
+type PendingStore[RI any] interface {
+	// AddPending stores the transmission. Adding a transmission whose
+	// IdempotencyKey is already stored must succeed and keep its position
+	// in the order returned by Pending.
+	AddPending(context.Context, Transmission[RI]) error
+	// RemovePending removes the transmission with the given key. Removing a
+	// key that isn't stored must succeed.
+	RemovePending(context.Context, IdempotencyKey) error
+	// Pending returns all stored transmissions, oldest first.
+	Pending(context.Context) ([]Transmission[RI], error)
+}
+
+// Transmitter is an ocr3types.ContractTransmitter that delegates to a
+// Service. Start must be called before the Transmitter is passed to the
+// oracle, and Close after the oracle has been closed.
+type Transmitter[RI any] struct {
+	service       Service[RI]
+	store         PendingStore[RI]
+	maxPending    int
+	fromAccount   types.Account
+	retrySchedule backoff.Schedule
+	logger        loghelper.LoggerWithContext
+
+	subprocesses subprocesses.Subprocesses
+	ctx          context.Context
+	cancel       context.CancelFunc
+	startOnce    sync.Once
+	chRetry      chan struct{}
+}
+
+var _ ocr3types.ContractTransmitter[struct{}] = &Transmitter[struct{}]{}
+
+// NewTransmitter creates a Transmitter. fromAccount is reported by
+// FromAccount and should be the account the Service transmits from.
+// retrySchedule governs resubmission of unacknowledged transmissions.
+// maxPending bounds the number of transmissions in store, beyond which the
+// oldest ones are evicted.
+func NewTransmitter[RI any](
+	service Service[RI],
+	store PendingStore[RI],
+	maxPending int,
+	fromAccount types.Account,
+	retrySchedule backoff.Schedule,
+	logger commontypes.Logger,
+) (*Transmitter[RI], error) {
+	if err := retrySchedule.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid retry schedule: %w", err)
+	}
+	if !(0 < maxPending) {
+		return nil, fmt.Errorf("maxPending (%v) must be positive", maxPending)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Transmitter[RI]{
+		service,
+		store,
+		maxPending,
+		fromAccount,
+		retrySchedule,
+		loghelper.MakeRootLoggerWithContext(logger).MakeChild(commontypes.LogFields{"id": "DelegatedTransmitter"}),
+
+		subprocesses.Subprocesses{},
+		ctx,
+		cancel,
+		sync.Once{},
+		make(chan struct{}, 1),
+	}, nil
+}
+
+// Start starts resubmitting pending transmissions in the background,
+// beginning with those left over from a previous run.
+func (t *Transmitter[RI]) Start() {
+	t.startOnce.Do(func() {
+		t.subprocesses.Go(t.retryLoop)
+	})
+}
+
+// Close stops resubmission. Transmissions that are still pending remain in
+// the PendingStore.
+func (t *Transmitter[RI]) Close() error {
+	t.cancel()
+	t.subprocesses.Wait()
+	return nil
+}
+
+func (t *Transmitter[RI]) Transmit(
+	ctx context.Context,
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	reportWithInfo ocr3types.ReportWithInfo[RI],
+	signatures []types.AttributedOnchainSignature,
+) error {
+	transmission := Transmission[RI]{
+		MakeIdempotencyKey(configDigest, seqNr, reportWithInfo.Report),
+		configDigest,
+		seqNr,
+		reportWithInfo,
+		signatures,
+	}
+
+	if err := t.store.AddPending(ctx, transmission); err != nil {
+		return fmt.Errorf("failed to add transmission to pending store: %w", err)
+	}
+	t.evictExcessPending(ctx)
+
+	if err := t.submit(ctx, transmission); err != nil {
+		t.logger.Warn("DelegatedTransmitter: Submit failed, will retry", commontypes.LogFields{
+			"error":          err,
+			"seqNr":          seqNr,
+			"idempotencyKey": transmission.IdempotencyKey.Hex(),
+		})
+		select {
+		case t.chRetry <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (t *Transmitter[RI]) FromAccount() (types.Account, error) {
+	return t.fromAccount, nil
+}
+
+// submit submits the transmission and removes it from the pending store once
+// acknowledged.
+func (t *Transmitter[RI]) submit(ctx context.Context, transmission Transmission[RI]) error {
+	if err := t.service.Submit(ctx, transmission); err != nil {
+		return err
+	}
+	if err := t.store.RemovePending(ctx, transmission.IdempotencyKey); err != nil {
+		// The transmission has been acknowledged, failing to remove it only
+		// results in a duplicate submission later on.
+		t.logger.Warn("DelegatedTransmitter: failed to remove acknowledged transmission from pending store", commontypes.LogFields{
+			"error":          err,
+			"seqNr":          transmission.SeqNr,
+			"idempotencyKey": transmission.IdempotencyKey.Hex(),
+		})
+	}
+	return nil
+}
+
+// evictExcessPending removes the oldest transmissions from the pending store
+// until at most maxPending remain.
+func (t *Transmitter[RI]) evictExcessPending(ctx context.Context) {
+	pending, err := t.store.Pending(ctx)
+	if err != nil {
+		t.logger.Warn("DelegatedTransmitter: failed to read pending transmissions for eviction", commontypes.LogFields{
+			"error": err,
+		})
+		return
+	}
+	for i := 0; i < len(pending)-t.maxPending; i++ {
+		evicted := pending[i]
+		if err := t.store.RemovePending(ctx, evicted.IdempotencyKey); err != nil {
+			t.logger.Warn("DelegatedTransmitter: failed to evict transmission from pending store", commontypes.LogFields{
+				"error":          err,
+				"seqNr":          evicted.SeqNr,
+				"idempotencyKey": evicted.IdempotencyKey.Hex(),
+			})
+			return
+		}
+		t.logger.Error("DelegatedTransmitter: pending store is full, evicted oldest unacknowledged transmission", commontypes.LogFields{
+			"maxPending":     t.maxPending,
+			"configDigest":   evicted.ConfigDigest,
+			"seqNr":          evicted.SeqNr,
+			"idempotencyKey": evicted.IdempotencyKey.Hex(),
+		})
+	}
+}
+
+// resubmitPending attempts to submit all pending transmissions. It returns
+// true iff all of them were acknowledged.
+func (t *Transmitter[RI]) resubmitPending() bool {
+	pending, err := t.store.Pending(t.ctx)
+	if err != nil {
+		t.logger.ErrorIfNotCanceled("DelegatedTransmitter: failed to read pending transmissions", t.ctx, commontypes.LogFields{
+			"error": err,
+		})
+		return false
+	}
+
+	acknowledgedAll := true
+	for _, transmission := range pending {
+		if err := t.submit(t.ctx, transmission); err != nil {
+			acknowledgedAll = false
+			t.logger.Warn("DelegatedTransmitter: resubmission failed", commontypes.LogFields{
+				"error":          err,
+				"seqNr":          transmission.SeqNr,
+				"idempotencyKey": transmission.IdempotencyKey.Hex(),
+			})
+		}
+		if t.ctx.Err() != nil {
+			return false
+		}
+	}
+	if len(pending) != 0 {
+		t.logger.Debug("DelegatedTransmitter: resubmitted pending transmissions", commontypes.LogFields{
+			"pending":         len(pending),
+			"acknowledgedAll": acknowledgedAll,
+		})
+	}
+	return acknowledgedAll
+}
+
+func (t *Transmitter[RI]) retryLoop() {
+	retryBackoff := backoff.New(t.retrySchedule)
+
+	// Check immediately after startup for transmissions left over from a
+	// previous run
+	tRetry := time.After(0)
+
+	for {
+		select {
+		case <-t.chRetry:
+			if tRetry == nil {
+				tRetry = time.After(retryBackoff.Next())
+			}
+		case <-tRetry:
+			if t.resubmitPending() {
+				retryBackoff.Reset()
+				tRetry = nil
+			} else {
+				tRetry = time.After(retryBackoff.Next())
+			}
+		case <-t.ctx.Done():
+		}
+
+		// ensure prompt exit
+		select {
+		case <-t.ctx.Done():
+			return
+		default:
+		}
+	}
+}
@@ -0,0 +1,295 @@
+// Package denylist lets hosts guarantee that a node never participates in
+// specific protocol instances, e.g. for compliance reasons or during incident
+// response.
+//
+// A Controller holds a set of denied config digests, persisted in a Store so
+// that it survives restarts. When an oracle passed a Controller (see e.g.
+// OCR3OracleArgs.Denylist) receives a new config, it checks the config digest
+// against the Controller before doing anything else with the config, and
+// refuses to run the protocol instance if the digest is denied. Denying the
+// config digest of a running instance stops the instance. Allowing a digest
+// again doesn't restart the instance on its own; the instance is considered
+// again once the config changes or the oracle is restarted.
+//
+// The Controller can be driven directly from Go or through the HTTP handler
+// returned by Controller.Handler. The handler performs no authentication, so
+// it must only ever be served on a local/loopback interface.
+//
+// Typically, a single Controller is shared among all oracles of a node.
+package denylist
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// Store persists the denylist.
+type Store interface {
+	// ReadDenylist returns the denylist most recently written with
+	// WriteDenylist, or an empty list if none has been written yet.
+	ReadDenylist(ctx context.Context) ([]types.ConfigDigest, error)
+	// WriteDenylist durably replaces the denylist. Once WriteDenylist has
+	// returned without error, subsequent calls to ReadDenylist must return
+	// configDigests, even after a crash.
+	WriteDenylist(ctx context.Context, configDigests []types.ConfigDigest) error
+}
+
+// FileStore is a Store keeping the denylist in a JSON file at Path, as a list
+// of hex-encoded config digests. Writes replace the file atomically.
+type FileStore struct {
+	Path string
+}
+
+var _ Store = FileStore{}
+
+func (s FileStore) ReadDenylist(ctx context.Context) ([]types.ConfigDigest, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read denylist file: %w", err)
+	}
+	var hexConfigDigests []string
+	if err := json.Unmarshal(data, &hexConfigDigests); err != nil {
+		return nil, fmt.Errorf("failed to decode denylist file %v: %w", s.Path, err)
+	}
+	configDigests := make([]types.ConfigDigest, 0, len(hexConfigDigests))
+	for _, hexConfigDigest := range hexConfigDigests {
+		configDigest, err := parseConfigDigest(hexConfigDigest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode denylist file %v: %w", s.Path, err)
+		}
+		configDigests = append(configDigests, configDigest)
+	}
+	return configDigests, nil
+}
+
+func (s FileStore) WriteDenylist(ctx context.Context, configDigests []types.ConfigDigest) error {
+	hexConfigDigests := make([]string, 0, len(configDigests))
+	for _, configDigest := range configDigests {
+		hexConfigDigests = append(hexConfigDigests, configDigest.Hex())
+	}
+	data, err := json.MarshalIndent(hexConfigDigests, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode denylist: %w", err)
+	}
+
+	// Write to a temporary file in the same directory and rename it over
+	// Path, so that a crash never leaves a partially written denylist behind.
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary denylist file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after successful rename
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary denylist file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync temporary denylist file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary denylist file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.Path); err != nil {
+		return fmt.Errorf("failed to replace denylist file: %w", err)
+	}
+	return nil
+}
+
+func parseConfigDigest(s string) (types.ConfigDigest, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return types.ConfigDigest{}, fmt.Errorf("invalid config digest %q: %w", s, err)
+	}
+	configDigest, err := types.BytesToConfigDigest(b)
+	if err != nil {
+		return types.ConfigDigest{}, fmt.Errorf("invalid config digest %q: %w", s, err)
+	}
+	return configDigest, nil
+}
+
+// Controller holds the denylist in memory and keeps it in sync with its
+// Store. All its functions are thread-safe.
+type Controller struct {
+	store Store
+
+	mu     sync.Mutex
+	denied map[types.ConfigDigest]struct{}
+	// the channels returned by Watch, closed once their config digest is
+	// denied
+	watchers map[types.ConfigDigest]map[chan struct{}]struct{}
+}
+
+// NewController returns a Controller initialized with the denylist read from
+// store.
+func NewController(ctx context.Context, store Store) (*Controller, error) {
+	configDigests, err := store.ReadDenylist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read denylist: %w", err)
+	}
+	denied := make(map[types.ConfigDigest]struct{}, len(configDigests))
+	for _, configDigest := range configDigests {
+		denied[configDigest] = struct{}{}
+	}
+	return &Controller{
+		store,
+
+		sync.Mutex{},
+		denied,
+		map[types.ConfigDigest]map[chan struct{}]struct{}{},
+	}, nil
+}
+
+// Deny adds configDigest to the denylist and stops any running protocol
+// instance with configDigest. The denylist is persisted before Deny returns.
+// If persisting fails, the denylist remains unchanged.
+func (c *Controller) Deny(ctx context.Context, configDigest types.ConfigDigest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.denied[configDigest]; ok {
+		return nil
+	}
+	if err := c.store.WriteDenylist(ctx, append(c.listLocked(), configDigest)); err != nil {
+		return fmt.Errorf("failed to persist denylist: %w", err)
+	}
+	c.denied[configDigest] = struct{}{}
+	for ch := range c.watchers[configDigest] {
+		close(ch)
+	}
+	delete(c.watchers, configDigest)
+	return nil
+}
+
+// Allow removes configDigest from the denylist. The denylist is persisted
+// before Allow returns. If persisting fails, the denylist remains unchanged.
+func (c *Controller) Allow(ctx context.Context, configDigest types.ConfigDigest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.denied[configDigest]; !ok {
+		return nil
+	}
+	delete(c.denied, configDigest)
+	if err := c.store.WriteDenylist(ctx, c.listLocked()); err != nil {
+		c.denied[configDigest] = struct{}{}
+		return fmt.Errorf("failed to persist denylist: %w", err)
+	}
+	return nil
+}
+
+// Denied returns whether configDigest is on the denylist.
+func (c *Controller) Denied(configDigest types.ConfigDigest) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.denied[configDigest]
+	return ok
+}
+
+// List returns the denylist, sorted.
+func (c *Controller) List() []types.ConfigDigest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.listLocked()
+}
+
+func (c *Controller) listLocked() []types.ConfigDigest {
+	configDigests := make([]types.ConfigDigest, 0, len(c.denied))
+	for configDigest := range c.denied {
+		configDigests = append(configDigests, configDigest)
+	}
+	sort.Slice(configDigests, func(i, j int) bool {
+		return bytes.Compare(configDigests[i][:], configDigests[j][:]) < 0
+	})
+	return configDigests
+}
+
+// Watch returns a channel that is closed once configDigest is denied. If
+// configDigest is already denied, the channel is closed right away. Call stop
+// once the channel is no longer needed.
+func (c *Controller) Watch(configDigest types.ConfigDigest) (denied <-chan struct{}, stop func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan struct{})
+	if _, ok := c.denied[configDigest]; ok {
+		close(ch)
+		return ch, func() {}
+	}
+	if c.watchers[configDigest] == nil {
+		c.watchers[configDigest] = map[chan struct{}]struct{}{}
+	}
+	c.watchers[configDigest][ch] = struct{}{}
+	return ch, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.watchers[configDigest], ch)
+		if len(c.watchers[configDigest]) == 0 {
+			delete(c.watchers, configDigest)
+		}
+	}
+}
+
+// Handler returns an http.Handler exposing the controller as a local control
+// API. It accepts GET requests to
+//
+//	/list
+//
+// which returns the denylist as a JSON list of hex-encoded config digests, and
+// POST requests to the following paths:
+//
+//	/deny?configDigest=<hex>
+//	/allow?configDigest=<hex>
+//
+// The handler performs no authentication.
+func (c *Controller) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		hexConfigDigests := []string{}
+		for _, configDigest := range c.List() {
+			hexConfigDigests = append(hexConfigDigests, configDigest.Hex())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(hexConfigDigests)
+	})
+	mux.HandleFunc("/deny", c.post(c.Deny))
+	mux.HandleFunc("/allow", c.post(c.Allow))
+	return mux
+}
+
+func (c *Controller) post(f func(context.Context, types.ConfigDigest) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		configDigest, err := parseConfigDigest(r.URL.Query().Get("configDigest"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := f(r.Context(), configDigest); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,357 @@
+package offchainreporting2plus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/admission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/atrest"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/denylist"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/managed"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/shim"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+// DualStackOracleArgs runs an OCR2 and an OCR3 protocol instance for the same
+// feed side by side, for cutting over from OCR2 to OCR3. Both stacks share
+// the peer (via BinaryNetworkEndpointFactory), the offchain keys, the
+// LocalConfig, and the MonitoringEndpoint.
+//
+// The stacks are isolated from each other by their config digests: network
+// endpoints, persisted protocol state, and offchain signatures are all
+// scoped to a config digest. To guarantee that the two stacks' config digests
+// can never collide, the OCR2 and OCR3 OffchainConfigDigesters must use
+// different ConfigDigestPrefixes; otherwise the oracle refuses to run.
+//
+// Which stacks run is controlled by Cutover and may be changed at runtime.
+type DualStackOracleArgs[RI any] struct {
+	// A factory for producing network endpoints. A network endpoints consists of
+	// networking methods a consumer must implement to allow a node to
+	// communicate with other participating nodes.
+	BinaryNetworkEndpointFactory types.BinaryNetworkEndpointFactory
+
+	// V2Bootstrappers is the list of bootstrap node addresses and IDs for the v2 stack.
+	V2Bootstrappers []commontypes.BootstrapperLocator
+
+	// LocalConfig contains oracle-specific configuration details which are not
+	// mandated by the on-chain configuration specification via OffchainAggregatoo.SetConfig.
+	LocalConfig types.LocalConfig
+
+	// Logger logs stuff.
+	Logger commontypes.Logger
+
+	// Used to send logs to a monitor.
+	MonitoringEndpoint commontypes.MonitoringEndpoint
+
+	// OffchainKeyring contains the secret keys needed for the OCR protocol, and methods
+	// which use those keys without exposing them to the rest of the application.
+	OffchainKeyring types.OffchainKeyring
+
+	// Cutover selects which stacks run. If nil, both stacks run.
+	Cutover *DualStackCutover
+
+	// AdmissionController decides whether the node has the capacity to run a
+	// protocol instance before either stack starts it. Optional, every
+	// instance is run if nil. See package admission for details.
+	AdmissionController *admission.Controller
+
+	// Denylist lists the config digests of protocol instances that neither
+	// stack may run. Optional, no instance is denied if nil. See package
+	// denylist for details.
+	Denylist *denylist.Controller
+
+	// AtRestKeyProvider enables envelope encryption of the data both stacks
+	// persist in their Databases. Optional, data is stored in plaintext if
+	// nil. See package atrest for details.
+	AtRestKeyProvider atrest.KeyProvider
+
+	// OCR2ContractConfigTracker tracks configuration changes of the OCR2
+	// contract.
+	OCR2ContractConfigTracker types.ContractConfigTracker
+
+	// OCR2ContractTransmitter transmits OCR2 reports.
+	OCR2ContractTransmitter types.ContractTransmitter
+
+	// OCR2Database provides persistent storage for the OCR2 stack. It may be
+	// backed by the same store as OCR3Database, but ReadConfig and
+	// WriteConfig must not be shared between the two.
+	OCR2Database types.Database
+
+	// Computes OCR2 config digests using purely offchain logic.
+	OCR2OffchainConfigDigester types.OffchainConfigDigester
+
+	// OCR2OnchainKeyring is used to sign OCR2 reports.
+	OCR2OnchainKeyring types.OnchainKeyring
+
+	// OCR2ReportingPluginFactory creates the OCR2 ReportingPlugins.
+	OCR2ReportingPluginFactory types.ReportingPluginFactory
+
+	// OCR3ContractConfigTracker tracks configuration changes of the OCR3
+	// contract.
+	OCR3ContractConfigTracker types.ContractConfigTracker
+
+	// OCR3ContractTransmitter transmits OCR3 reports.
+	OCR3ContractTransmitter ocr3types.ContractTransmitter[RI]
+
+	// OCR3Database provides persistent storage for the OCR3 stack. See
+	// OCR2Database.
+	OCR3Database ocr3types.Database
+
+	// Computes OCR3 config digests using purely offchain logic.
+	OCR3OffchainConfigDigester types.OffchainConfigDigester
+
+	// OCR3OnchainKeyring is used to sign OCR3 reports.
+	OCR3OnchainKeyring ocr3types.OnchainKeyring[RI]
+
+	// OCR3ReportingPluginFactory creates the OCR3 ReportingPlugins.
+	OCR3ReportingPluginFactory ocr3types.ReportingPluginFactory[RI]
+}
+
+func (DualStackOracleArgs[RI]) oracleArgsMarker() {}
+
+func (args DualStackOracleArgs[RI]) localConfig() types.LocalConfig { return args.LocalConfig }
+
+func (args DualStackOracleArgs[RI]) runManaged(ctx context.Context, teardownCtx context.Context, telemetryQueueStats *shim.TelemetryQueueStats, instances *managed.Instances) {
+	logger := loghelper.MakeRootLoggerWithContext(args.Logger)
+
+	if err := args.checkIsolation(); err != nil {
+		logger.Critical("DualStackOracle: stacks aren't isolated, refusing to run", commontypes.LogFields{
+			"error": err,
+		})
+		return
+	}
+
+	// Note that both stacks account for their telemetry in the same
+	// telemetryQueueStats.
+	stacks := [...]*dualStackInstance{
+		{
+			"ocr2",
+			func(ctx context.Context) {
+				managed.RunManagedOCR2Oracle(
+					ctx,
+					teardownCtx,
+
+					args.V2Bootstrappers,
+					args.AdmissionController,
+					args.AtRestKeyProvider,
+					args.OCR2ContractConfigTracker,
+					args.OCR2ContractTransmitter,
+					args.Denylist,
+					args.OCR2Database,
+					args.LocalConfig,
+					logger.MakeChild(commontypes.LogFields{"stack": "ocr2"}),
+					args.MonitoringEndpoint,
+					args.BinaryNetworkEndpointFactory,
+					args.OCR2OffchainConfigDigester,
+					args.OffchainKeyring,
+					args.OCR2OnchainKeyring,
+					args.OCR2ReportingPluginFactory,
+					telemetryQueueStats,
+				)
+			},
+			nil,
+			subprocesses.Subprocesses{},
+		},
+		{
+			"ocr3",
+			func(ctx context.Context) {
+				managed.RunManagedOCR3Oracle(
+					ctx,
+					teardownCtx,
+
+					args.V2Bootstrappers,
+					args.AdmissionController,
+					args.AtRestKeyProvider,
+					nil,
+					args.OCR3ContractConfigTracker,
+					args.OCR3ContractTransmitter,
+					args.Denylist,
+					args.OCR3Database,
+					nil,
+					instances,
+					nil,
+					args.LocalConfig,
+					logger.MakeChild(commontypes.LogFields{"stack": "ocr3"}),
+					nil,
+					nil,
+					args.MonitoringEndpoint,
+					args.BinaryNetworkEndpointFactory,
+					args.OCR3OffchainConfigDigester,
+					args.OffchainKeyring,
+					args.OCR3OnchainKeyring,
+					nil,
+					ocr3types.NewReportingPluginFactoryV2FromV1(args.OCR3ReportingPluginFactory),
+					nil,
+					nil,
+					telemetryQueueStats,
+					nil,
+					nil,
+					nil,
+					nil,
+				)
+			},
+			nil,
+			subprocesses.Subprocesses{},
+		},
+	}
+	defer func() {
+		for _, stack := range stacks {
+			stack.stop()
+		}
+	}()
+
+	for {
+		mode, chChanged := args.Cutover.get()
+		logger.Info("DualStackOracle: applying cutover mode", commontypes.LogFields{
+			"mode": mode,
+		})
+		// Start stacks before stopping any, so that there is no gap in
+		// service when switching directly from one stack to the other.
+		wanted := [...]bool{mode != DualStackModeOCR3Only, mode != DualStackModeOCR2Only}
+		for i, stack := range stacks {
+			if wanted[i] {
+				stack.start(ctx)
+			}
+		}
+		for i, stack := range stacks {
+			if !wanted[i] {
+				stack.stop()
+			}
+		}
+
+		select {
+		case <-chChanged:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (args DualStackOracleArgs[RI]) checkIsolation() error {
+	ocr2Prefix, err := args.OCR2OffchainConfigDigester.ConfigDigestPrefix()
+	if err != nil {
+		return fmt.Errorf("error getting OCR2 ConfigDigestPrefix: %w", err)
+	}
+	ocr3Prefix, err := args.OCR3OffchainConfigDigester.ConfigDigestPrefix()
+	if err != nil {
+		return fmt.Errorf("error getting OCR3 ConfigDigestPrefix: %w", err)
+	}
+	if ocr2Prefix == ocr3Prefix {
+		return fmt.Errorf("OCR2 and OCR3 OffchainConfigDigesters use the same ConfigDigestPrefix %v", ocr2Prefix)
+	}
+	return nil
+}
+
+// dualStackInstance is a stack of a DualStackOracle that can be started and
+// stopped repeatedly. Not thread-safe.
+type dualStackInstance struct {
+	name   string
+	run    func(ctx context.Context)
+	cancel context.CancelFunc
+	subs   subprocesses.Subprocesses
+}
+
+func (s *dualStackInstance) start(ctx context.Context) {
+	if s.cancel != nil {
+		return
+	}
+	stackCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.subs.Go(func() {
+		s.run(stackCtx)
+	})
+}
+
+func (s *dualStackInstance) stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.subs.Wait()
+	s.cancel = nil
+}
+
+type DualStackMode int
+
+const (
+	_ DualStackMode = iota
+	// Run only the OCR2 stack.
+	DualStackModeOCR2Only
+	// Run both stacks, typically while the OCR3 stack is being validated.
+	DualStackModeBoth
+	// Run only the OCR3 stack.
+	DualStackModeOCR3Only
+)
+
+func (m DualStackMode) String() string {
+	switch m {
+	case DualStackModeOCR2Only:
+		return "ocr2Only"
+	case DualStackModeBoth:
+		return "both"
+	case DualStackModeOCR3Only:
+		return "ocr3Only"
+	}
+	return fmt.Sprintf("DualStackMode(%d)", int(m))
+}
+
+// DualStackCutover is the switch that selects which stacks of a
+// DualStackOracle run. It is safe for concurrent use.
+type DualStackCutover struct {
+	mutex sync.Mutex
+	mode  DualStackMode
+	// closed and replaced whenever mode changes
+	chChanged chan struct{}
+}
+
+func NewDualStackCutover(mode DualStackMode) (*DualStackCutover, error) {
+	if err := checkDualStackMode(mode); err != nil {
+		return nil, err
+	}
+	return &DualStackCutover{sync.Mutex{}, mode, make(chan struct{})}, nil
+}
+
+// Set changes the mode. Stacks that are no longer selected are shut down;
+// stacks that are newly selected start from the state persisted in their
+// Database.
+func (c *DualStackCutover) Set(mode DualStackMode) error {
+	if err := checkDualStackMode(mode); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.mode == mode {
+		return nil
+	}
+	c.mode = mode
+	close(c.chChanged)
+	c.chChanged = make(chan struct{})
+	return nil
+}
+
+func (c *DualStackCutover) Mode() DualStackMode {
+	mode, _ := c.get()
+	return mode
+}
+
+func (c *DualStackCutover) get() (DualStackMode, <-chan struct{}) {
+	if c == nil {
+		return DualStackModeBoth, nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.mode, c.chChanged
+}
+
+func checkDualStackMode(mode DualStackMode) error {
+	switch mode {
+	case DualStackModeOCR2Only, DualStackModeBoth, DualStackModeOCR3Only:
+		return nil
+	}
+	return fmt.Errorf("invalid DualStackMode %v", mode)
+}
@@ -0,0 +1,271 @@
+// Package fanouttransmitter implements a types.ContractTransmitter that
+// delivers every attested OCR2 report to several destinations, e.g. a
+// contract on mainnet and a mirror of it on an L2.
+//
+// All destinations receive the same report and signatures, so their contracts
+// must accept reports signed with the oracles' OnchainKeyring under the same
+// config digest. (In OCR3, use an ocr3types.ContractTransmitterBundle
+// instead, which also supports destinations with different signature
+// schemes.)
+//
+// # Epoch tracking
+//
+// When an instance starts, the protocol looks up the latest epoch that was
+// transmitted for the instance's config digest, to avoid starting in an epoch
+// whose reports the contract would reject as stale. For a fan-out, the right
+// answer is the highest epoch that any destination has seen for that config
+// digest, not e.g. the epoch of the first destination. Destinations don't
+// necessarily agree on the config digest either: a destination that hasn't
+// received a report of the current config yet still reports the previous
+// config's digest. Hence, ContractTransmitter implements
+// types.ConfigDigestEpochContractTransmitter, which lets the protocol ask for
+// the epoch of a specific config digest, and queries all destinations
+// independently, skipping those whose call fails. Epochs that this
+// ContractTransmitter has successfully transmitted itself are taken into
+// account too, since destinations may only reflect them once the
+// corresponding transactions have been included onchain.
+package fanouttransmitter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+	"go.uber.org/multierr"
+)
+
+// Destination names a destination, e.g. "ethereum" or "arbitrum".
+type Destination string
+
+// DestinationStatus tracks the transmissions to a single destination.
+type DestinationStatus struct {
+	Destination Destination
+	// Report context of the most recent successful transmission, zero if
+	// there hasn't been one
+	LastSuccess     types.ReportContext
+	LastSuccessTime time.Time
+	// Error of the most recent failed transmission, nil if there hasn't been
+	// one
+	LastErr     error
+	LastErrTime time.Time
+	// Number of failed transmissions since the most recent successful one
+	ConsecutiveFailures int
+}
+
+// ContractTransmitter fans reports out to the transmitters of all its
+// destinations. It is thread-safe if the transmitters of its destinations are.
+type ContractTransmitter struct {
+	destinations []Destination // sorted
+	transmitters []types.ContractTransmitter
+
+	mu       sync.Mutex
+	statuses []DestinationStatus
+	// config digest of the most recent successful transmission to any
+	// destination, zero if there hasn't been one
+	transmittedConfigDigest types.ConfigDigest
+	// highest epoch successfully transmitted to any destination for
+	// transmittedConfigDigest
+	transmittedEpoch uint32
+}
+
+var _ types.ContractTransmitter = &ContractTransmitter{}
+var _ types.ConfigDigestEpochContractTransmitter = &ContractTransmitter{}
+
+func New(transmitters map[Destination]types.ContractTransmitter) (*ContractTransmitter, error) {
+	if len(transmitters) == 0 {
+		return nil, fmt.Errorf("fan-out ContractTransmitter needs at least one destination")
+	}
+	destinations := make([]Destination, 0, len(transmitters))
+	for destination, transmitter := range transmitters {
+		if transmitter == nil {
+			return nil, fmt.Errorf("ContractTransmitter for destination %q is nil", destination)
+		}
+		destinations = append(destinations, destination)
+	}
+	sort.Slice(destinations, func(i, j int) bool { return destinations[i] < destinations[j] })
+
+	t := &ContractTransmitter{destinations: destinations}
+	for _, destination := range destinations {
+		t.transmitters = append(t.transmitters, transmitters[destination])
+		t.statuses = append(t.statuses, DestinationStatus{Destination: destination})
+	}
+	return t, nil
+}
+
+// Destinations returns the destinations in sorted order.
+func (t *ContractTransmitter) Destinations() []Destination {
+	return append([]Destination{}, t.destinations...)
+}
+
+// Statuses returns the status of every destination, in the order of
+// Destinations.
+func (t *ContractTransmitter) Statuses() []DestinationStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]DestinationStatus{}, t.statuses...)
+}
+
+// Transmit invokes Transmit on the transmitters of all destinations
+// concurrently. A failure of one destination doesn't affect the others. The
+// returned error combines the errors of all failed destinations.
+func (t *ContractTransmitter) Transmit(
+	ctx context.Context,
+	repctx types.ReportContext,
+	report types.Report,
+	signatures []types.AttributedOnchainSignature,
+) error {
+	errs := make([]error, len(t.destinations))
+	var subs subprocesses.Subprocesses
+	for i := range t.destinations {
+		i := i
+		subs.Go(func() {
+			errs[i] = t.transmitters[i].Transmit(ctx, repctx, report, signatures)
+			t.recordTransmission(i, repctx, errs[i])
+		})
+	}
+	subs.Wait()
+
+	var err error
+	for i, destination := range t.destinations {
+		if errs[i] != nil {
+			err = multierr.Append(err, fmt.Errorf("error while transmitting to destination %q: %w", destination, errs[i]))
+		}
+	}
+	return err
+}
+
+func (t *ContractTransmitter) recordTransmission(i int, repctx types.ReportContext, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := &t.statuses[i]
+	if err != nil {
+		status.LastErr = err
+		status.LastErrTime = time.Now()
+		status.ConsecutiveFailures++
+		return
+	}
+	status.LastSuccess = repctx
+	status.LastSuccessTime = time.Now()
+	status.ConsecutiveFailures = 0
+
+	if repctx.ConfigDigest != t.transmittedConfigDigest {
+		t.transmittedConfigDigest = repctx.ConfigDigest
+		t.transmittedEpoch = repctx.Epoch
+	} else if repctx.Epoch > t.transmittedEpoch {
+		t.transmittedEpoch = repctx.Epoch
+	}
+}
+
+type configDigestAndEpoch struct {
+	configDigest types.ConfigDigest
+	epoch        uint32
+}
+
+// latestConfigDigestsAndEpochs queries the transmitters of all destinations
+// concurrently. It returns the results of the destinations that succeeded,
+// followed by the epoch transmitted by t itself, if any. It only returns an
+// error if all destinations fail.
+func (t *ContractTransmitter) latestConfigDigestsAndEpochs(ctx context.Context) ([]configDigestAndEpoch, error) {
+	results := make([]configDigestAndEpoch, len(t.destinations))
+	errs := make([]error, len(t.destinations))
+	var subs subprocesses.Subprocesses
+	for i := range t.destinations {
+		i := i
+		subs.Go(func() {
+			results[i].configDigest, results[i].epoch, errs[i] = t.transmitters[i].LatestConfigDigestAndEpoch(ctx)
+		})
+	}
+	subs.Wait()
+
+	var succeeded []configDigestAndEpoch
+	var err error
+	for i, destination := range t.destinations {
+		if errs[i] != nil {
+			err = multierr.Append(err, fmt.Errorf("error while getting latest config digest and epoch of destination %q: %w", destination, errs[i]))
+			continue
+		}
+		succeeded = append(succeeded, results[i])
+	}
+	if len(succeeded) == 0 {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.transmittedConfigDigest != (types.ConfigDigest{}) {
+		succeeded = append(succeeded, configDigestAndEpoch{t.transmittedConfigDigest, t.transmittedEpoch})
+	}
+	return succeeded, nil
+}
+
+// LatestEpochForConfigDigest returns the highest epoch that any destination
+// (or t itself) has transmitted for configDigest, see the package
+// documentation.
+func (t *ContractTransmitter) LatestEpochForConfigDigest(ctx context.Context, configDigest types.ConfigDigest) (uint32, error) {
+	results, err := t.latestConfigDigestsAndEpochs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return highestEpoch(results, configDigest), nil
+}
+
+// LatestConfigDigestAndEpoch returns the (non-zero) config digest reported
+// by most destinations, ties broken in favor of higher epochs, together with
+// the highest epoch for it. The protocol doesn't call it, since t implements
+// types.ConfigDigestEpochContractTransmitter.
+func (t *ContractTransmitter) LatestConfigDigestAndEpoch(ctx context.Context) (types.ConfigDigest, uint32, error) {
+	results, err := t.latestConfigDigestsAndEpochs(ctx)
+	if err != nil {
+		return types.ConfigDigest{}, 0, err
+	}
+	configDigest := mostReportedConfigDigest(results)
+	return configDigest, highestEpoch(results, configDigest), nil
+}
+
+func highestEpoch(results []configDigestAndEpoch, configDigest types.ConfigDigest) uint32 {
+	var epoch uint32
+	for _, result := range results {
+		if result.configDigest == configDigest && result.epoch > epoch {
+			epoch = result.epoch
+		}
+	}
+	return epoch
+}
+
+// mostReportedConfigDigest returns the non-zero config digest occurring in the
+// most results, ties broken in favor of higher epochs and then of smaller
+// digests. It returns the zero digest if all results have it.
+func mostReportedConfigDigest(results []configDigestAndEpoch) types.ConfigDigest {
+	counts := map[types.ConfigDigest]int{}
+	for _, result := range results {
+		if result.configDigest != (types.ConfigDigest{}) {
+			counts[result.configDigest]++
+		}
+	}
+	var best types.ConfigDigest
+	for configDigest, count := range counts {
+		if count != counts[best] {
+			if count > counts[best] {
+				best = configDigest
+			}
+			continue
+		}
+		epoch, bestEpoch := highestEpoch(results, configDigest), highestEpoch(results, best)
+		if epoch > bestEpoch || (epoch == bestEpoch && bytes.Compare(configDigest[:], best[:]) < 0) {
+			best = configDigest
+		}
+	}
+	return best
+}
+
+// FromAccount returns the account of the transmitter of the first destination
+// in sorted order.
+func (t *ContractTransmitter) FromAccount() (types.Account, error) {
+	return t.transmitters[0].FromAccount()
+}
@@ -0,0 +1,150 @@
+// Package heartbeat defines the signed heartbeats that OCR3 oracles emit over
+// their MonitoringEndpoint if configured to (see
+// OCR3OracleArgs.HeartbeatConfig), so that monitoring can authenticate which
+// operators are live without relying on inferences from the network layer.
+//
+// A heartbeat is emitted as the heartbeat field of the TelemetryWrapper
+// protobuf and consists of a serialized HeartbeatPayload and a signature over
+// it, made with the oracle's OffchainKeyring. Monitoring should pass both to
+// Verify and then check that the returned OffchainPublicKey belongs to the
+// config(s) the heartbeat claims to serve.
+package heartbeat
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/serialization"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// MinInterval is the smallest permitted Config.Interval.
+const MinInterval = 1 * time.Second
+
+// Config configures heartbeats.
+type Config struct {
+	// Time between heartbeats, at least MinInterval
+	Interval time.Duration
+	// Free-form version of the node software, included verbatim in every
+	// heartbeat
+	NodeVersion string
+}
+
+func (c Config) Validate() error {
+	if !(MinInterval <= c.Interval) {
+		return fmt.Errorf("heartbeat Interval (%v) must be at least %v", c.Interval, MinInterval)
+	}
+	return nil
+}
+
+// Heartbeat is the content of a heartbeat.
+type Heartbeat struct {
+	NodeVersion string
+	// Key of the OffchainKeyring that signed the heartbeat
+	OffchainPublicKey types.OffchainPublicKey
+	// Time at which the heartbeat was created, according to the oracle
+	Time time.Time
+	// Protocol instances run by the oracle, empty if it isn't running any
+	Instances []Instance
+}
+
+// Instance describes a protocol instance run by the oracle.
+type Instance struct {
+	ConfigDigest types.ConfigDigest
+	OracleID     commontypes.OracleID
+	// Zero if the oracle hasn't committed any sequence number since starting
+	// the instance
+	HighestCommittedSeqNr uint64
+	// Whether the instance is in degraded mode, i.e. the oracle hasn't been
+	// able to reach a quorum of oracles for a while, see
+	// types.DegradedModeConfig
+	Degraded bool
+	// Whether the oracle found the state it persisted for the instance to be
+	// corrupted when starting the instance, and started from a fresh state
+	// instead, see ocr3types.ProtocolStateQuarantiner
+	RecoveredFromCorruptedState bool
+}
+
+const domainSeparator = "ocr3 Heartbeat"
+
+func signedMsg(payload []byte) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(domainSeparator))
+	_, _ = h.Write(payload)
+	return h.Sum(nil)
+}
+
+// Sign serializes heartbeat and signs it with offchainKeyring. For
+// heartbeat.OffchainPublicKey, the key of offchainKeyring is used.
+func Sign(offchainKeyring types.OffchainKeyring, heartbeat Heartbeat) (payload []byte, signature []byte, err error) {
+	offchainPublicKey := offchainKeyring.OffchainPublicKey()
+	instances := make([]*serialization.HeartbeatInstance, 0, len(heartbeat.Instances))
+	for _, instance := range heartbeat.Instances {
+		instances = append(instances, &serialization.HeartbeatInstance{
+			ConfigDigest:                instance.ConfigDigest[:],
+			OracleId:                    uint32(instance.OracleID),
+			HighestCommittedSeqNr:       instance.HighestCommittedSeqNr,
+			Degraded:                    instance.Degraded,
+			RecoveredFromCorruptedState: instance.RecoveredFromCorruptedState,
+		})
+	}
+	payload, err = proto.Marshal(&serialization.HeartbeatPayload{
+		NodeVersion:         heartbeat.NodeVersion,
+		OffchainPublicKey:   offchainPublicKey[:],
+		UnixTimeNanoseconds: heartbeat.Time.UnixNano(),
+		Instances:           instances,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to serialize heartbeat: %w", err)
+	}
+	signature, err = offchainKeyring.OffchainSign(signedMsg(payload))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign heartbeat: %w", err)
+	}
+	return payload, signature, nil
+}
+
+// Verify checks that signature is valid for payload under the
+// OffchainPublicKey contained in payload, and returns the decoded heartbeat.
+// It doesn't check that the key belongs to any particular config.
+func Verify(payload []byte, signature []byte) (Heartbeat, error) {
+	var pb serialization.HeartbeatPayload
+	if err := proto.Unmarshal(payload, &pb); err != nil {
+		return Heartbeat{}, fmt.Errorf("failed to deserialize heartbeat: %w", err)
+	}
+	if len(pb.OffchainPublicKey) != ed25519.PublicKeySize {
+		return Heartbeat{}, fmt.Errorf("offchain public key has length %v, expected %v", len(pb.OffchainPublicKey), ed25519.PublicKeySize)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pb.OffchainPublicKey), signedMsg(payload), signature) {
+		return Heartbeat{}, fmt.Errorf("heartbeat has invalid signature")
+	}
+
+	instances := make([]Instance, 0, len(pb.Instances))
+	for i, instance := range pb.Instances {
+		configDigest, err := types.BytesToConfigDigest(instance.ConfigDigest)
+		if err != nil {
+			return Heartbeat{}, fmt.Errorf("instance %v has invalid config digest: %w", i, err)
+		}
+		if instance.OracleId > math.MaxUint8 {
+			return Heartbeat{}, fmt.Errorf("instance %v has oracle id %v out of range", i, instance.OracleId)
+		}
+		instances = append(instances, Instance{
+			configDigest,
+			commontypes.OracleID(instance.OracleId),
+			instance.HighestCommittedSeqNr,
+			instance.Degraded,
+			instance.RecoveredFromCorruptedState,
+		})
+	}
+	return Heartbeat{
+		pb.NodeVersion,
+		types.OffchainPublicKey(pb.OffchainPublicKey),
+		time.Unix(0, pb.UnixTimeNanoseconds),
+		instances,
+	}, nil
+}
@@ -1,13 +1,11 @@
 package ocr2config
 
 import (
-	"bytes"
 	"fmt"
 	"math"
 	"strconv"
 	"time"
 
-	"github.com/pkg/errors"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
@@ -92,7 +90,12 @@ func (c *PublicConfig) N() int {
 
 func (c *PublicConfig) CheckParameterBounds() error {
 	if c.F < 0 || c.F > math.MaxUint8 {
-		return errors.Errorf("number of potentially faulty oracles must fit in 8 bits.")
+		return &types.InvalidOracleSetError{
+			types.OracleSetViolationFaultTolerance,
+			c.N(),
+			c.F,
+			"number of potentially faulty oracles must fit in 8 bits.",
+		}
 	}
 	return nil
 }
@@ -129,6 +132,7 @@ func publicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 			types.OnchainPublicKey(change.Signers[i][:]),
 			oc.PeerIDs[i],
 			change.Transmitters[i],
+			types.OffchainPublicKey{},
 		})
 	}
 
@@ -167,50 +171,13 @@ func publicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 }
 
 func checkIdentityListsHaveNoDuplicates(change types.ContractConfig, oc offchainConfig) error {
-	// inefficient, but it doesn't matter
-	for i := range change.Signers {
-		for j := range change.Signers {
-			if i != j && bytes.Equal(change.Signers[i], change.Signers[j]) {
-				return fmt.Errorf("%v-th and %v-th signer are identical: %x", i, j, change.Signers[i])
-			}
-		}
-	}
-
-	{
-		uniquePeerIDs := map[string]struct{}{}
-		for _, peerID := range oc.PeerIDs {
-			if _, ok := uniquePeerIDs[peerID]; ok {
-				return fmt.Errorf("duplicate PeerID '%v'", peerID)
-			}
-			uniquePeerIDs[peerID] = struct{}{}
-		}
-	}
-
-	{
-		uniqueOffchainPublicKeys := map[types.OffchainPublicKey]struct{}{}
-		for _, ocpk := range oc.OffchainPublicKeys {
-			if _, ok := uniqueOffchainPublicKeys[ocpk]; ok {
-				return fmt.Errorf("duplicate OffchainPublicKey %x", ocpk)
-			}
-			uniqueOffchainPublicKeys[ocpk] = struct{}{}
-		}
-	}
-
-	{
-		// this isn't strictly necessary, but since we don't intend to run
-		// with duplicate transmitters at this time, we might as well check
-		uniqueTransmitters := map[types.Account]struct{}{}
-		for _, transmitter := range change.Transmitters {
-			if _, ok := uniqueTransmitters[transmitter]; ok {
-				return fmt.Errorf("duplicate transmitter '%v'", transmitter)
-			}
-			uniqueTransmitters[transmitter] = struct{}{}
-		}
-	}
-
-	// no point in checking SharedSecretEncryptions for uniqueness
-
-	return nil
+	return config.CheckIdentityListsHaveNoDuplicates(
+		int(change.F),
+		change.Signers,
+		change.Transmitters,
+		oc.PeerIDs,
+		oc.OffchainPublicKeys,
+	)
 }
 
 func checkIdentityListsHaveTheSameLength(
@@ -229,7 +196,12 @@ func checkIdentityListsHaveTheSameLength(
 		{len(oc.SharedSecretEncryptions.Encryptions), "shared-secret encryptions"},
 	} {
 		if identityList.length != expectedLength {
-			return errors.Errorf(errorMsg, identityList.name, identityList.length)
+			return &types.InvalidOracleSetError{
+				types.OracleSetViolationListLengthMismatch,
+				expectedLength,
+				int(change.F),
+				fmt.Sprintf(errorMsg, identityList.name, identityList.length),
+			}
 		}
 	}
 	return nil
@@ -262,14 +234,8 @@ func checkPublicConfigParameters(cfg PublicConfig) error {
 		return fmt.Errorf("DeltaResend (%v) must be non-negative", cfg.DeltaResend)
 	}
 
-	if !(0 <= cfg.F && cfg.F*3 < cfg.N()) {
-		return fmt.Errorf("F (%v) must be non-negative and less than N/3 (N = %v)",
-			cfg.F, cfg.N())
-	}
-
-	if !(cfg.N() <= types.MaxOracles) {
-		return fmt.Errorf("N (%v) must be less than or equal MaxOracles (%v)",
-			cfg.N(), types.MaxOracles)
+	if err := config.CheckOracleSet(cfg.N(), cfg.F); err != nil {
+		return err
 	}
 
 	if !(0 <= cfg.DeltaGrace) {
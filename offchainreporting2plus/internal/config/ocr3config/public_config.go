@@ -1,15 +1,14 @@
 package ocr3config
 
 import (
-	"bytes"
 	"fmt"
 	"math"
 	"strconv"
 	"time"
 
-	"github.com/pkg/errors"
 	"github.com/smartcontractkit/libocr/internal/byzquorum"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
 
@@ -82,6 +81,41 @@ type PublicConfig struct {
 	MaxDurationShouldAcceptAttestedReport   time.Duration
 	MaxDurationShouldTransmitAcceptedReport time.Duration
 
+	// FeatureFlags enable optional behaviors of the protocol and the
+	// ReportingPlugin. See ocr3types.FeatureFlags for details.
+	FeatureFlags ocr3types.FeatureFlags
+
+	// If DeltaGraceMin and DeltaGraceMax are non-zero, leaders auto-tune the
+	// grace period of their rounds within [DeltaGraceMin, DeltaGraceMax] based
+	// on how long it recently took them to collect a quorum of observations,
+	// instead of always waiting for DeltaGrace. DeltaGrace is used until a
+	// leader has collected enough samples. If both are zero, auto-tuning is
+	// disabled.
+	DeltaGraceMin time.Duration
+	DeltaGraceMax time.Duration
+
+	// LeaderWeights weights the selection of epoch leaders, e.g. to have
+	// oracles with high latency to the rest of the DON lead less often. If
+	// set, it has one entry per oracle, and each span of sum(LeaderWeights)
+	// consecutive epochs is led by every oracle i exactly LeaderWeights[i]
+	// times, in a pseudorandom order that every oracle derives from the
+	// config. Oracles with weight zero never lead. More than F oracles must
+	// have a positive weight, so that some correct oracle always leads
+	// eventually. If empty, all oracles lead equally often.
+	LeaderWeights []int
+
+	// If DeltaRoundMin and DeltaRoundMax are non-zero, oracles adapt the
+	// interval between rounds within [DeltaRoundMin, DeltaRoundMax] instead
+	// of always using DeltaRound: after a streak of rounds that committed well
+	// within the current interval, they shorten it, and after consecutive
+	// rounds that didn't commit at all, e.g. because of timeouts, they
+	// lengthen it. Every epoch resumes with the interval the oracle last
+	// used, starting at DeltaRound. Since rounds may then be as frequent as
+	// DeltaRoundMin, message rate limits are derived from DeltaRoundMin. If
+	// both are zero, adaptive round pacing is disabled.
+	DeltaRoundMin time.Duration
+	DeltaRoundMax time.Duration
+
 	// The maximum number of oracles that are assumed to be faulty while the
 	// protocol can retain liveness and safety. Unless you really know what
 	// you’re doing, be sure to set this to floor((n-1)/3) where n is the total
@@ -106,15 +140,53 @@ func (c *PublicConfig) ByzQuorumSize() int {
 }
 
 func (c *PublicConfig) MinRoundInterval() time.Duration {
-	if c.DeltaRound > c.DeltaGrace {
-		return c.DeltaRound
+	deltaGrace := c.DeltaGrace
+	if c.DeltaGraceAutoTuning() {
+		deltaGrace = c.DeltaGraceMin
+	}
+	if deltaRound := c.MinDeltaRound(); deltaRound > deltaGrace {
+		return deltaRound
+	}
+	return deltaGrace
+}
+
+// AdaptiveRoundPacing returns whether oracles adapt the interval between
+// rounds, see DeltaRoundMin and DeltaRoundMax.
+func (c *PublicConfig) AdaptiveRoundPacing() bool {
+	return c.DeltaRoundMin != 0 || c.DeltaRoundMax != 0
+}
+
+// MinDeltaRound returns the shortest interval between rounds that oracles may
+// use.
+func (c *PublicConfig) MinDeltaRound() time.Duration {
+	if c.AdaptiveRoundPacing() {
+		return c.DeltaRoundMin
+	}
+	return c.DeltaRound
+}
+
+// DeltaGraceAutoTuning returns whether leaders auto-tune the grace period,
+// see DeltaGraceMin and DeltaGraceMax.
+func (c *PublicConfig) DeltaGraceAutoTuning() bool {
+	return c.DeltaGraceMin != 0 || c.DeltaGraceMax != 0
+}
+
+// MaxDeltaGrace returns the longest grace period a leader may wait for.
+func (c *PublicConfig) MaxDeltaGrace() time.Duration {
+	if c.DeltaGraceAutoTuning() {
+		return c.DeltaGraceMax
 	}
 	return c.DeltaGrace
 }
 
 func (c *PublicConfig) CheckParameterBounds() error {
 	if c.F < 0 || c.F > math.MaxUint8 {
-		return errors.Errorf("number of potentially faulty oracles must fit in 8 bits.")
+		return &types.InvalidOracleSetError{
+			types.OracleSetViolationFaultTolerance,
+			c.N(),
+			c.F,
+			"number of potentially faulty oracles must fit in 8 bits.",
+		}
 	}
 	return nil
 }
@@ -146,11 +218,16 @@ func publicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 
 	identities := []config.OracleIdentity{}
 	for i := range change.Signers {
+		var nextOffchainPublicKey types.OffchainPublicKey
+		if len(oc.NextOffchainPublicKeys) != 0 {
+			nextOffchainPublicKey = oc.NextOffchainPublicKeys[i]
+		}
 		identities = append(identities, config.OracleIdentity{
 			oc.OffchainPublicKeys[i],
 			types.OnchainPublicKey(change.Signers[i][:]),
 			oc.PeerIDs[i],
 			change.Transmitters[i],
+			nextOffchainPublicKey,
 		})
 	}
 
@@ -170,6 +247,12 @@ func publicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 		oc.MaxDurationObservation,
 		oc.MaxDurationShouldAcceptAttestedReport,
 		oc.MaxDurationShouldTransmitAcceptedReport,
+		oc.FeatureFlags,
+		oc.DeltaGraceMin,
+		oc.DeltaGraceMax,
+		oc.LeaderWeights,
+		oc.DeltaRoundMin,
+		oc.DeltaRoundMax,
 
 		int(change.F),
 		change.OnchainConfig,
@@ -190,49 +273,37 @@ func publicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 }
 
 func checkIdentityListsHaveNoDuplicates(change types.ContractConfig, oc offchainConfig) error {
-	// inefficient, but it doesn't matter
-	for i := range change.Signers {
-		for j := range change.Signers {
-			if i != j && bytes.Equal(change.Signers[i], change.Signers[j]) {
-				return fmt.Errorf("%v-th and %v-th signer are identical: %x", i, j, change.Signers[i])
-			}
-		}
-	}
-
-	{
-		uniquePeerIDs := map[string]struct{}{}
-		for _, peerID := range oc.PeerIDs {
-			if _, ok := uniquePeerIDs[peerID]; ok {
-				return fmt.Errorf("duplicate PeerID '%v'", peerID)
-			}
-			uniquePeerIDs[peerID] = struct{}{}
-		}
-	}
-
-	{
-		uniqueOffchainPublicKeys := map[types.OffchainPublicKey]struct{}{}
-		for _, ocpk := range oc.OffchainPublicKeys {
-			if _, ok := uniqueOffchainPublicKeys[ocpk]; ok {
-				return fmt.Errorf("duplicate OffchainPublicKey %x", ocpk)
-			}
-			uniqueOffchainPublicKeys[ocpk] = struct{}{}
+	if err := config.CheckIdentityListsHaveNoDuplicates(
+		int(change.F),
+		change.Signers,
+		change.Transmitters,
+		oc.PeerIDs,
+		oc.OffchainPublicKeys,
+	); err != nil {
+		return err
+	}
+
+	// A next offchain public key may neither be shared with another oracle
+	// nor coincide with any current one, or signatures could be attributed
+	// to the wrong oracle.
+	offchainPublicKeys := map[types.OffchainPublicKey]struct{}{}
+	for _, ocpk := range oc.OffchainPublicKeys {
+		offchainPublicKeys[ocpk] = struct{}{}
+	}
+	for _, ocpk := range oc.NextOffchainPublicKeys {
+		if ocpk == (types.OffchainPublicKey{}) {
+			continue
 		}
-	}
-
-	{
-		// this isn't strictly necessary, but since we don't intend to run
-		// with duplicate transmitters at this time, we might as well check
-		uniqueTransmitters := map[types.Account]struct{}{}
-		for _, transmitter := range change.Transmitters {
-			if _, ok := uniqueTransmitters[transmitter]; ok {
-				return fmt.Errorf("duplicate transmitter '%v'", transmitter)
+		if _, ok := offchainPublicKeys[ocpk]; ok {
+			return &types.InvalidOracleSetError{
+				types.OracleSetViolationDuplicateIdentity,
+				len(change.Signers),
+				int(change.F),
+				fmt.Sprintf("duplicate next OffchainPublicKey %x", ocpk),
 			}
-			uniqueTransmitters[transmitter] = struct{}{}
 		}
+		offchainPublicKeys[ocpk] = struct{}{}
 	}
-
-	// no point in checking SharedSecretEncryptions for uniqueness
-
 	return nil
 }
 
@@ -252,7 +323,21 @@ func checkIdentityListsHaveTheSameLength(
 		{len(oc.SharedSecretEncryptions.Encryptions), "shared-secret encryptions"},
 	} {
 		if identityList.length != expectedLength {
-			return errors.Errorf(errorMsg, identityList.name, identityList.length)
+			return &types.InvalidOracleSetError{
+				types.OracleSetViolationListLengthMismatch,
+				expectedLength,
+				int(change.F),
+				fmt.Sprintf(errorMsg, identityList.name, identityList.length),
+			}
+		}
+	}
+	// next offchain public keys are optional
+	if len(oc.NextOffchainPublicKeys) != 0 && len(oc.NextOffchainPublicKeys) != expectedLength {
+		return &types.InvalidOracleSetError{
+			types.OracleSetViolationListLengthMismatch,
+			expectedLength,
+			int(change.F),
+			fmt.Sprintf(errorMsg, "next offchain public keys", len(oc.NextOffchainPublicKeys)),
 		}
 	}
 	return nil
@@ -269,14 +354,12 @@ func checkPublicConfigParameters(cfg PublicConfig) error {
 	// be made when you change this function!
 	/////////////////////////////////////////////////////////////////
 
-	if !(0 <= cfg.F && cfg.F*3 < cfg.N()) {
-		return fmt.Errorf("F (%v) must be non-negative and less than N/3 (N = %v)",
-			cfg.F, cfg.N())
+	if err := config.CheckOracleSet(cfg.N(), cfg.F); err != nil {
+		return err
 	}
 
-	if !(cfg.N() <= types.MaxOracles) {
-		return fmt.Errorf("N (%v) must be less than or equal MaxOracles (%v)",
-			cfg.N(), types.MaxOracles)
+	if err := cfg.FeatureFlags.Validate(); err != nil {
+		return fmt.Errorf("FeatureFlags are invalid: %w", err)
 	}
 
 	if !(0 <= cfg.DeltaProgress) {
@@ -295,11 +378,29 @@ func checkPublicConfigParameters(cfg PublicConfig) error {
 		return fmt.Errorf("DeltaRound (%v) must be non-negative", cfg.DeltaRound)
 	}
 
+	if cfg.AdaptiveRoundPacing() {
+		if !(0 < cfg.DeltaRoundMin && cfg.DeltaRoundMin <= cfg.DeltaRound && cfg.DeltaRound <= cfg.DeltaRoundMax) {
+			return fmt.Errorf("if set, DeltaRoundMin (%v), DeltaRound (%v), DeltaRoundMax (%v) must satisfy 0 < DeltaRoundMin <= DeltaRound <= DeltaRoundMax",
+				cfg.DeltaRoundMin, cfg.DeltaRound, cfg.DeltaRoundMax)
+		}
+	}
+
 	if !(0 <= cfg.DeltaGrace) {
 		return fmt.Errorf("DeltaGrace (%v) must be non-negative",
 			cfg.DeltaGrace)
 	}
 
+	if cfg.DeltaGraceAutoTuning() {
+		if !(0 < cfg.DeltaGraceMin && cfg.DeltaGraceMin <= cfg.DeltaGrace && cfg.DeltaGrace <= cfg.DeltaGraceMax) {
+			return fmt.Errorf("if set, DeltaGraceMin (%v), DeltaGrace (%v), DeltaGraceMax (%v) must satisfy 0 < DeltaGraceMin <= DeltaGrace <= DeltaGraceMax",
+				cfg.DeltaGraceMin, cfg.DeltaGrace, cfg.DeltaGraceMax)
+		}
+	}
+
+	if err := checkLeaderWeights(cfg); err != nil {
+		return err
+	}
+
 	if !(0 <= cfg.DeltaCertifiedCommitRequest) {
 		return fmt.Errorf("DeltaCertifiedCommitRequest (%v) must be non-negative", cfg.DeltaCertifiedCommitRequest)
 	}
@@ -329,9 +430,14 @@ func checkPublicConfigParameters(cfg PublicConfig) error {
 			cfg.DeltaRound, cfg.DeltaProgress)
 	}
 
-	sumMaxDurationsOutcomeGeneration := cfg.MaxDurationQuery + cfg.MaxDurationObservation + cfg.DeltaGrace
+	if !(cfg.DeltaRoundMax < cfg.DeltaProgress) {
+		return fmt.Errorf("DeltaRoundMax (%v) must be less than DeltaProgress (%v)",
+			cfg.DeltaRoundMax, cfg.DeltaProgress)
+	}
+
+	sumMaxDurationsOutcomeGeneration := cfg.MaxDurationQuery + cfg.MaxDurationObservation + cfg.MaxDeltaGrace()
 	if !(sumMaxDurationsOutcomeGeneration < cfg.DeltaProgress) {
-		return fmt.Errorf("sum of MaxDurationQuery/MaxDurationObservation/DeltaGrace (%v) must be less than DeltaProgress (%v)",
+		return fmt.Errorf("sum of MaxDurationQuery/MaxDurationObservation/DeltaGrace(Max) (%v) must be less than DeltaProgress (%v)",
 			sumMaxDurationsOutcomeGeneration, cfg.DeltaProgress)
 	}
 
@@ -361,6 +467,33 @@ func checkPublicConfigParameters(cfg PublicConfig) error {
 	return nil
 }
 
+// MaxLeaderWeight bounds the entries of PublicConfig.LeaderWeights, and thus
+// the length of the leader permutation that oracles compute on every epoch
+// change.
+const MaxLeaderWeight = 100
+
+func checkLeaderWeights(cfg PublicConfig) error {
+	if len(cfg.LeaderWeights) == 0 {
+		return nil
+	}
+	if len(cfg.LeaderWeights) != cfg.N() {
+		return fmt.Errorf("LeaderWeights must have one entry per oracle (%v), but has %v", cfg.N(), len(cfg.LeaderWeights))
+	}
+	positive := 0
+	for i, w := range cfg.LeaderWeights {
+		if !(0 <= w && w <= MaxLeaderWeight) {
+			return fmt.Errorf("LeaderWeights[%v] (%v) must be between 0 and %v", i, w, MaxLeaderWeight)
+		}
+		if w > 0 {
+			positive++
+		}
+	}
+	if !(positive > cfg.F) {
+		return fmt.Errorf("more than F (%v) oracles must have a positive LeaderWeight, but only %v do", cfg.F, positive)
+	}
+	return nil
+}
+
 func checkResourceExhaustion(cfg PublicConfig) error {
 	// Sending messages related to epoch changes and missing certified commits
 	// shouldn't be necessary in any realistic WAN deployment and could cause
@@ -2,12 +2,15 @@ package ocr3config
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 	"golang.org/x/crypto/curve25519"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/runtime/protoimpl"
 )
@@ -36,6 +39,152 @@ type offchainConfig struct {
 	MaxDurationShouldAcceptAttestedReport   time.Duration
 	MaxDurationShouldTransmitAcceptedReport time.Duration
 	SharedSecretEncryptions                 config.SharedSecretEncryptions
+	FeatureFlags                            ocr3types.FeatureFlags
+	DeltaGraceMin                           time.Duration
+	DeltaGraceMax                           time.Duration
+	LeaderWeights                           []int
+	DeltaRoundMin                           time.Duration
+	DeltaRoundMax                           time.Duration
+	NextOffchainPublicKeys                  []types.OffchainPublicKey
+}
+
+// Protobuf field numbers under which fields that were added after
+// OffchainConfigProto was generated are stored. We encode these fields by hand
+// as unknown varint fields (or packed repeated varint fields, or repeated bytes
+// fields), omitted if zero (or empty), so that configs that don't use them
+// serialize exactly as before.
+// Be sure to reserve these numbers in the .proto file when regenerating it.
+const (
+	featureFlagsFieldNumber             protowire.Number = 42
+	deltaGraceMinNanosecondsFieldNumber protowire.Number = 43
+	deltaGraceMaxNanosecondsFieldNumber protowire.Number = 44
+	leaderWeightsFieldNumber            protowire.Number = 45
+	deltaRoundMinNanosecondsFieldNumber protowire.Number = 46
+	deltaRoundMaxNanosecondsFieldNumber protowire.Number = 47
+	nextOffchainPublicKeysFieldNumber   protowire.Number = 48
+)
+
+func appendUnknownVarint(unknown []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return unknown
+	}
+	unknown = protowire.AppendTag(unknown, num, protowire.VarintType)
+	return protowire.AppendVarint(unknown, v)
+}
+
+func appendUnknownPackedVarints(unknown []byte, num protowire.Number, vs []int) []byte {
+	if len(vs) == 0 {
+		return unknown
+	}
+	var packed []byte
+	for _, v := range vs {
+		packed = protowire.AppendVarint(packed, uint64(v))
+	}
+	unknown = protowire.AppendTag(unknown, num, protowire.BytesType)
+	return protowire.AppendBytes(unknown, packed)
+}
+
+func appendUnknownRepeatedBytes(unknown []byte, num protowire.Number, bs [][]byte) []byte {
+	for _, b := range bs {
+		unknown = protowire.AppendTag(unknown, num, protowire.BytesType)
+		unknown = protowire.AppendBytes(unknown, b)
+	}
+	return unknown
+}
+
+// consumeUnknownRepeatedBytes returns the values of the repeated bytes field
+// num in unknown, or nil if unknown doesn't contain the field.
+func consumeUnknownRepeatedBytes(unknown []byte, num protowire.Number) ([][]byte, error) {
+	var result [][]byte
+	for len(unknown) > 0 {
+		fieldNum, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return nil, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+		if fieldNum == num && typ == protowire.BytesType {
+			b, n := protowire.ConsumeBytes(unknown)
+			if n < 0 {
+				return nil, fmt.Errorf("could not parse unknown field %v: %w", num, protowire.ParseError(n))
+			}
+			unknown = unknown[n:]
+			result = append(result, b)
+			continue
+		}
+		n = protowire.ConsumeFieldValue(fieldNum, typ, unknown)
+		if n < 0 {
+			return nil, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+	}
+	return result, nil
+}
+
+// consumeUnknownPackedVarints returns the values of the packed repeated varint
+// field num in unknown, or nil if unknown doesn't contain the field.
+func consumeUnknownPackedVarints(unknown []byte, num protowire.Number) ([]int, error) {
+	var result []int
+	for len(unknown) > 0 {
+		fieldNum, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return nil, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+		if fieldNum == num && typ == protowire.BytesType {
+			packed, n := protowire.ConsumeBytes(unknown)
+			if n < 0 {
+				return nil, fmt.Errorf("could not parse unknown field %v: %w", num, protowire.ParseError(n))
+			}
+			unknown = unknown[n:]
+			result = result[:0]
+			for len(packed) > 0 {
+				v, n := protowire.ConsumeVarint(packed)
+				if n < 0 {
+					return nil, fmt.Errorf("could not parse unknown field %v: %w", num, protowire.ParseError(n))
+				}
+				if v > math.MaxInt32 {
+					return nil, fmt.Errorf("unknown field %v contains out-of-range value %v", num, v)
+				}
+				result = append(result, int(v))
+				packed = packed[n:]
+			}
+			continue
+		}
+		n = protowire.ConsumeFieldValue(fieldNum, typ, unknown)
+		if n < 0 {
+			return nil, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+	}
+	return result, nil
+}
+
+// consumeUnknownVarints returns the values of all varint fields in unknown,
+// keyed by field number. Fields of other types are skipped.
+func consumeUnknownVarints(unknown []byte) (map[protowire.Number]uint64, error) {
+	varints := map[protowire.Number]uint64{}
+	for len(unknown) > 0 {
+		num, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return nil, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+		if typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(unknown)
+			if n < 0 {
+				return nil, fmt.Errorf("could not parse unknown field %v: %w", num, protowire.ParseError(n))
+			}
+			varints[num] = v
+			unknown = unknown[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, unknown)
+		if n < 0 {
+			return nil, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+	}
+	return varints, nil
 }
 
 func checkSize(serializedOffchainConfig []byte) error {
@@ -52,6 +201,20 @@ func checkSize(serializedOffchainConfig []byte) error {
 // serialize returns a binary serialization of o
 func (o offchainConfig) serialize() []byte {
 	offchainConfigProto := enprotoOffchainConfig(o)
+	var unknown []byte
+	unknown = appendUnknownVarint(unknown, featureFlagsFieldNumber, uint64(o.FeatureFlags))
+	unknown = appendUnknownVarint(unknown, deltaGraceMinNanosecondsFieldNumber, uint64(o.DeltaGraceMin))
+	unknown = appendUnknownVarint(unknown, deltaGraceMaxNanosecondsFieldNumber, uint64(o.DeltaGraceMax))
+	unknown = appendUnknownPackedVarints(unknown, leaderWeightsFieldNumber, o.LeaderWeights)
+	unknown = appendUnknownVarint(unknown, deltaRoundMinNanosecondsFieldNumber, uint64(o.DeltaRoundMin))
+	unknown = appendUnknownVarint(unknown, deltaRoundMaxNanosecondsFieldNumber, uint64(o.DeltaRoundMax))
+	nextOffchainPublicKeys := make([][]byte, 0, len(o.NextOffchainPublicKeys))
+	for _, k := range o.NextOffchainPublicKeys {
+		k := k // have to copy or we append the same key over and over
+		nextOffchainPublicKeys = append(nextOffchainPublicKeys, k[:])
+	}
+	unknown = appendUnknownRepeatedBytes(unknown, nextOffchainPublicKeysFieldNumber, nextOffchainPublicKeys)
+	offchainConfigProto.ProtoReflect().SetUnknown(unknown)
 	rv, err := proto.Marshal(&offchainConfigProto)
 	if err != nil {
 		panic(err)
@@ -100,6 +263,30 @@ func deprotoOffchainConfig(
 		return offchainConfig{}, fmt.Errorf("could not unmarshal shared protobuf: %w", err)
 	}
 
+	unknownVarints, err := consumeUnknownVarints(offchainConfigProto.ProtoReflect().GetUnknown())
+	if err != nil {
+		return offchainConfig{}, err
+	}
+
+	leaderWeights, err := consumeUnknownPackedVarints(offchainConfigProto.ProtoReflect().GetUnknown(), leaderWeightsFieldNumber)
+	if err != nil {
+		return offchainConfig{}, err
+	}
+
+	nextOffchainPublicKeysRaw, err := consumeUnknownRepeatedBytes(offchainConfigProto.ProtoReflect().GetUnknown(), nextOffchainPublicKeysFieldNumber)
+	if err != nil {
+		return offchainConfig{}, err
+	}
+	var nextOffchainPublicKeys []types.OffchainPublicKey
+	for _, ocpkRaw := range nextOffchainPublicKeysRaw {
+		var ocpk types.OffchainPublicKey
+		if len(ocpkRaw) != len(ocpk) {
+			return offchainConfig{}, fmt.Errorf("invalid next offchain public key: %x", ocpkRaw)
+		}
+		copy(ocpk[:], ocpkRaw)
+		nextOffchainPublicKeys = append(nextOffchainPublicKeys, ocpk)
+	}
+
 	return offchainConfig{
 		time.Duration(offchainConfigProto.GetDeltaProgressNanoseconds()),
 		time.Duration(offchainConfigProto.GetDeltaResendNanoseconds()),
@@ -118,6 +305,13 @@ func deprotoOffchainConfig(
 		time.Duration(offchainConfigProto.GetMaxDurationShouldAcceptAttestedReportNanoseconds()),
 		time.Duration(offchainConfigProto.GetMaxDurationShouldTransmitAcceptedReportNanoseconds()),
 		sharedSecretEncryptions,
+		ocr3types.FeatureFlags(unknownVarints[featureFlagsFieldNumber]),
+		time.Duration(unknownVarints[deltaGraceMinNanosecondsFieldNumber]),
+		time.Duration(unknownVarints[deltaGraceMaxNanosecondsFieldNumber]),
+		leaderWeights,
+		time.Duration(unknownVarints[deltaRoundMinNanosecondsFieldNumber]),
+		time.Duration(unknownVarints[deltaRoundMaxNanosecondsFieldNumber]),
+		nextOffchainPublicKeys,
 	}, nil
 }
 
@@ -43,6 +43,15 @@ func (c *SharedConfig) TransmissionOrderKey() [16]byte {
 	return result
 }
 
+func (c *SharedConfig) ObservationSampleKey() [16]byte {
+	var result [16]byte
+	mac := hmac.New(sha256.New, c.SharedSecret[:])
+	_, _ = mac.Write([]byte("chainlink offchain reporting v3 observation sample key"))
+	_, _ = mac.Write(c.ConfigDigest[:])
+	_ = copy(result[:], mac.Sum(nil))
+	return result
+}
+
 func SharedConfigFromContractConfig[RI any](
 	skipResourceExhaustionChecks bool,
 	change types.ContractConfig,
@@ -63,11 +72,11 @@ func SharedConfigFromContractConfig[RI any](
 			onchainPublicKey := onchainKeyring.PublicKey()
 			offchainPublicKey := offchainKeyring.OffchainPublicKey()
 			if bytes.Equal(identity.OnchainPublicKey, onchainPublicKey) {
-				if identity.OffchainPublicKey != offchainPublicKey {
+				if !identity.HasOffchainPublicKey(offchainPublicKey) {
 					return SharedConfig{}, 0, errors.Errorf(
 						"OnchainPublicKey %x in publicConfig matches "+
 							"mine, but OffchainPublicKey does not: %v (config) vs %v (mine)",
-						onchainPublicKey, identity.OffchainPublicKey, offchainPublicKey)
+						onchainPublicKey, identity.OffchainPublicKeys(), offchainPublicKey)
 				}
 				if identity.PeerID != peerID {
 					return SharedConfig{}, 0, errors.Errorf(
@@ -118,13 +127,23 @@ func XXXContractSetConfigArgsFromSharedConfig(
 	err error,
 ) {
 	offChainPublicKeys := []types.OffchainPublicKey{}
+	nextOffchainPublicKeys := []types.OffchainPublicKey{}
+	rotating := false
 	peerIDs := []string{}
 	for _, identity := range c.OracleIdentities {
 		signers = append(signers, identity.OnchainPublicKey)
 		transmitters = append(transmitters, identity.TransmitAccount)
 		offChainPublicKeys = append(offChainPublicKeys, identity.OffchainPublicKey)
+		nextOffchainPublicKeys = append(nextOffchainPublicKeys, identity.NextOffchainPublicKey)
+		if identity.NextOffchainPublicKey != (types.OffchainPublicKey{}) {
+			rotating = true
+		}
 		peerIDs = append(peerIDs, identity.PeerID)
 	}
+	if !rotating {
+		// serialize exactly like configs from before key rotation
+		nextOffchainPublicKeys = nil
+	}
 	f = uint8(c.F)
 	onchainConfig = c.OnchainConfig
 	offchainConfigVersion = config.OCR3OffchainConfigVersion
@@ -150,6 +169,13 @@ func XXXContractSetConfigArgsFromSharedConfig(
 			c.SharedSecret,
 			cryptorand.Reader,
 		),
+		c.FeatureFlags,
+		c.DeltaGraceMin,
+		c.DeltaGraceMax,
+		c.LeaderWeights,
+		c.DeltaRoundMin,
+		c.DeltaRoundMax,
+		nextOffchainPublicKeys,
 	}).serialize()
 	err = nil
 	return
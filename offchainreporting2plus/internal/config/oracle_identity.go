@@ -7,4 +7,43 @@ type OracleIdentity struct {
 	OnchainPublicKey  types.OnchainPublicKey
 	PeerID            string
 	TransmitAccount   types.Account
+	// The offchain public key the oracle is being rotated to, or zero if
+	// there is no rotation in progress. Only supported by OCR3.
+	NextOffchainPublicKey types.OffchainPublicKey
+}
+
+// OffchainPublicKeys returns the keys under which offchain signatures by the
+// oracle are accepted: OffchainPublicKey and, during a rotation,
+// NextOffchainPublicKey.
+func (id OracleIdentity) OffchainPublicKeys() []types.OffchainPublicKey {
+	if id.NextOffchainPublicKey == (types.OffchainPublicKey{}) {
+		return []types.OffchainPublicKey{id.OffchainPublicKey}
+	}
+	return []types.OffchainPublicKey{id.OffchainPublicKey, id.NextOffchainPublicKey}
+}
+
+// HasOffchainPublicKey returns whether publicKey is one of OffchainPublicKeys.
+func (id OracleIdentity) HasOffchainPublicKey(publicKey types.OffchainPublicKey) bool {
+	for _, k := range id.OffchainPublicKeys() {
+		if k == publicKey {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyOffchainSignature calls verify with each of OffchainPublicKeys until
+// one succeeds. If none does, it returns the error for OffchainPublicKey.
+func (id OracleIdentity) VerifyOffchainSignature(verify func(types.OffchainPublicKey) error) error {
+	var firstErr error
+	for _, k := range id.OffchainPublicKeys() {
+		err := verify(k)
+		if err == nil {
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
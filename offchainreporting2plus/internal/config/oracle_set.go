@@ -0,0 +1,184 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// CheckOracleSet checks the fundamental constraints relating n and f that
+// every config must satisfy.
+func CheckOracleSet(n int, f int) error {
+	if !(0 <= f && f*3 < n) {
+		return &types.InvalidOracleSetError{
+			types.OracleSetViolationFaultTolerance,
+			n,
+			f,
+			fmt.Sprintf("F (%v) must be non-negative and less than N/3 (N = %v)", f, n),
+		}
+	}
+
+	if !(n <= types.MaxOracles) {
+		return &types.InvalidOracleSetError{
+			types.OracleSetViolationTooManyOracles,
+			n,
+			f,
+			fmt.Sprintf("N (%v) must be less than or equal MaxOracles (%v)", n, types.MaxOracles),
+		}
+	}
+
+	return nil
+}
+
+// CheckIdentityListsHaveNoDuplicates checks that no two oracles share a
+// signer, transmitter, peer ID, or offchain public key. The lists needn't
+// have the same length.
+func CheckIdentityListsHaveNoDuplicates(
+	f int,
+	signers []types.OnchainPublicKey,
+	transmitters []types.Account,
+	peerIDs []string,
+	offchainPublicKeys []types.OffchainPublicKey,
+) error {
+	n := len(signers)
+	duplicate := func(detail string) error {
+		return &types.InvalidOracleSetError{types.OracleSetViolationDuplicateIdentity, n, f, detail}
+	}
+
+	// inefficient, but it doesn't matter
+	for i := range signers {
+		for j := range signers {
+			if i != j && bytes.Equal(signers[i], signers[j]) {
+				return duplicate(fmt.Sprintf("%v-th and %v-th signer are identical: %x", i, j, signers[i]))
+			}
+		}
+	}
+
+	{
+		uniquePeerIDs := map[string]struct{}{}
+		for _, peerID := range peerIDs {
+			if _, ok := uniquePeerIDs[peerID]; ok {
+				return duplicate(fmt.Sprintf("duplicate PeerID '%v'", peerID))
+			}
+			uniquePeerIDs[peerID] = struct{}{}
+		}
+	}
+
+	{
+		uniqueOffchainPublicKeys := map[types.OffchainPublicKey]struct{}{}
+		for _, ocpk := range offchainPublicKeys {
+			if _, ok := uniqueOffchainPublicKeys[ocpk]; ok {
+				return duplicate(fmt.Sprintf("duplicate OffchainPublicKey %x", ocpk))
+			}
+			uniqueOffchainPublicKeys[ocpk] = struct{}{}
+		}
+	}
+
+	{
+		// this isn't strictly necessary, but since we don't intend to run
+		// with duplicate transmitters at this time, we might as well check
+		uniqueTransmitters := map[types.Account]struct{}{}
+		for _, transmitter := range transmitters {
+			if _, ok := uniqueTransmitters[transmitter]; ok {
+				return duplicate(fmt.Sprintf("duplicate transmitter '%v'", transmitter))
+			}
+			uniqueTransmitters[transmitter] = struct{}{}
+		}
+	}
+
+	// no point in checking SharedSecretEncryptions for uniqueness
+
+	return nil
+}
+
+// CheckOracleSetStrict performs a superset of the checks that oracles perform
+// on the oracle set of a new config. It additionally rejects configs that
+// oracles would accept but that cannot make progress or are almost certainly
+// mistakes, e.g. an F that doesn't fit into the contract's uint8, missing
+// identities, or a transmission schedule S under which no correct oracle
+// transmits. Meant for deploy tooling, oracles must not use it for configs
+// read from the contract.
+func CheckOracleSetStrict(identities []OracleIdentity, s []int, f int) error {
+	n := len(identities)
+
+	if !(f <= math.MaxUint8) {
+		return &types.InvalidOracleSetError{
+			types.OracleSetViolationFaultTolerance,
+			n,
+			f,
+			fmt.Sprintf("F (%v) must fit into 8 bits", f),
+		}
+	}
+
+	if err := CheckOracleSet(n, f); err != nil {
+		return err
+	}
+
+	signers := make([]types.OnchainPublicKey, 0, n)
+	transmitters := make([]types.Account, 0, n)
+	peerIDs := make([]string, 0, n)
+	offchainPublicKeys := make([]types.OffchainPublicKey, 0, n)
+	for i, identity := range identities {
+		missing := ""
+		switch {
+		case len(identity.OnchainPublicKey) == 0:
+			missing = "signer"
+		case identity.TransmitAccount == "":
+			missing = "transmitter"
+		case identity.PeerID == "":
+			missing = "PeerID"
+		}
+		if missing != "" {
+			return &types.InvalidOracleSetError{
+				types.OracleSetViolationMissingIdentity,
+				n,
+				f,
+				fmt.Sprintf("%v-th oracle has no %v", i, missing),
+			}
+		}
+
+		signers = append(signers, identity.OnchainPublicKey)
+		transmitters = append(transmitters, identity.TransmitAccount)
+		peerIDs = append(peerIDs, identity.PeerID)
+		offchainPublicKeys = append(offchainPublicKeys, identity.OffchainPublicKey)
+	}
+
+	if err := CheckIdentityListsHaveNoDuplicates(f, signers, transmitters, peerIDs, offchainPublicKeys); err != nil {
+		return err
+	}
+
+	if !(len(s) < 1000) {
+		return &types.InvalidOracleSetError{
+			types.OracleSetViolationTransmissionSchedule,
+			n,
+			f,
+			fmt.Sprintf("len(S) (%v) must be less than 1000", len(s)),
+		}
+	}
+	sumS := 0
+	for i, si := range s {
+		if !(0 <= si && si <= n) {
+			return &types.InvalidOracleSetError{
+				types.OracleSetViolationTransmissionSchedule,
+				n,
+				f,
+				fmt.Sprintf("S[%v] (%v) must be between 0 and N", i, si),
+			}
+		}
+		sumS += si
+	}
+	// If the stages add up to F or fewer oracles, all oracles scheduled to
+	// transmit a report might be faulty.
+	if !(f < sumS) {
+		return &types.InvalidOracleSetError{
+			types.OracleSetViolationTransmissionSchedule,
+			n,
+			f,
+			fmt.Sprintf("sum of S (%v) must be greater than F, otherwise no correct oracle may transmit", sumS),
+		}
+	}
+
+	return nil
+}
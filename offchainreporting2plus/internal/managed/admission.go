@@ -0,0 +1,15 @@
+package managed
+
+import (
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/admission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// admitInstance asks admissionController to admit the protocol instance with
+// configDigest. It always succeeds if admissionController is nil.
+func admitInstance(admissionController *admission.Controller, configDigest types.ConfigDigest, demand admission.Resources) (release func(), err error) {
+	if admissionController == nil {
+		return func() {}, nil
+	}
+	return admissionController.Admit(configDigest, demand)
+}
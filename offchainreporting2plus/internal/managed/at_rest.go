@@ -0,0 +1,16 @@
+package managed
+
+import (
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/atrest"
+)
+
+// newAtRestSealer returns a Sealer for keyProvider, or nil if keyProvider is
+// nil, i.e. if data is persisted in plaintext. Since a protocol instance only
+// writes under its own config digest, every instance should use its own
+// Sealer.
+func newAtRestSealer(keyProvider atrest.KeyProvider) *atrest.Sealer {
+	if keyProvider == nil {
+		return nil
+	}
+	return atrest.NewSealer(keyProvider)
+}
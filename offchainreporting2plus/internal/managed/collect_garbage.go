@@ -7,11 +7,11 @@ import (
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
 
 const collectInterval = 10 * time.Minute
-const olderThan = 24 * time.Hour
 
 // collectGarbage periodically collects garbage left by old transmission protocol instances
 func collectGarbage(
@@ -20,6 +20,10 @@ func collectGarbage(
 	localConfig types.LocalConfig,
 	logger loghelper.LoggerWithContext,
 ) {
+	olderThan := localConfig.PendingTransmissionRetention
+	if olderThan == 0 {
+		olderThan = types.DefaultPendingTransmissionRetention
+	}
 	for {
 		wait := collectInterval + time.Duration(rand.Float64()*5.0*60.0)*time.Second
 		logger.Info("collectGarbage: going to sleep", commontypes.LogFields{
@@ -54,3 +58,39 @@ func collectGarbage(
 		}
 	}
 }
+
+// pruneSupersededState deletes whatever database keeps for config digests other
+// than configDigest, to the extent that database supports it. Call it once the
+// protocol instance for configDigest is about to run.
+func pruneSupersededState(
+	ctx context.Context,
+	database interface{},
+	configDigest types.ConfigDigest,
+	localConfig types.LocalConfig,
+	logger loghelper.LoggerWithContext,
+) {
+	prune := func(name string, f func(context.Context) error) {
+		childCtx, childCancel := context.WithTimeout(ctx, localConfig.DatabaseTimeout)
+		defer childCancel()
+		if err := f(childCtx); err != nil {
+			logger.ErrorIfNotCanceled("pruneSupersededState: error in "+name, childCtx, commontypes.LogFields{
+				"error": err,
+			})
+			return
+		}
+		logger.Info("pruneSupersededState: pruned state of superseded configs", commontypes.LogFields{
+			"method": name,
+		})
+	}
+
+	if pruner, ok := database.(types.PendingTransmissionPruner); ok {
+		prune("DeletePendingTransmissionsWithOtherConfigDigest", func(ctx context.Context) error {
+			return pruner.DeletePendingTransmissionsWithOtherConfigDigest(ctx, configDigest)
+		})
+	}
+	if pruner, ok := database.(ocr3types.ProtocolStatePruner); ok {
+		prune("DeleteProtocolStateWithOtherConfigDigest", func(ctx context.Context) error {
+			return pruner.DeleteProtocolStateWithOtherConfigDigest(ctx, configDigest)
+		})
+	}
+}
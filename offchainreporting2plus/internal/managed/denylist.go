@@ -0,0 +1,42 @@
+package managed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/denylist"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// enforceDenylist returns an error if configDigest is on the denylist of
+// denylistController. Otherwise, it returns a child of ctx that is canceled
+// once configDigest is denied. Call stop once the protocol instance has
+// stopped. It always succeeds if denylistController is nil.
+func enforceDenylist(ctx context.Context, denylistController *denylist.Controller, configDigest types.ConfigDigest) (enforcedCtx context.Context, stop func(), err error) {
+	if denylistController == nil {
+		return ctx, func() {}, nil
+	}
+	chDenied, stopWatching := denylistController.Watch(configDigest)
+	select {
+	case <-chDenied:
+		stopWatching()
+		return nil, nil, fmt.Errorf("config digest %v is on the denylist", configDigest)
+	default:
+	}
+
+	enforcedCtx, cancel := context.WithCancel(ctx)
+	chDone := make(chan struct{})
+	go func() {
+		defer close(chDone)
+		select {
+		case <-chDenied:
+			cancel()
+		case <-enforcedCtx.Done():
+		}
+	}()
+	return enforcedCtx, func() {
+		cancel()
+		<-chDone
+		stopWatching()
+	}, nil
+}
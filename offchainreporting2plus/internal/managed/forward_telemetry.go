@@ -5,26 +5,28 @@ import (
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/shim"
 	"google.golang.org/protobuf/proto"
 )
 
-// forwardTelemetry receives monitoring events on chTelemetry, serializes them, and forwards
-// them to monitoringEndpoint
+// forwardTelemetry receives monitoring events from telemetryQueue, serializes them, and forwards
+// them to monitoringEndpoint. It closes telemetryQueue when it exits.
 func forwardTelemetry[M proto.Message](
 	ctx context.Context,
 
 	logger loghelper.LoggerWithContext,
 	monitoringEndpoint commontypes.MonitoringEndpoint,
 
-	chTelemetry <-chan M,
+	telemetryQueue *shim.TelemetryQueue[M],
 ) {
+	defer telemetryQueue.Close()
 	for {
 		select {
-		case t, ok := <-chTelemetry:
+		case t, ok := <-telemetryQueue.Chan():
 			if !ok {
 				// This isn't supposed to happen, but we still handle this case gracefully,
 				// just in case...
-				logger.Error("forwardTelemetry: chTelemetry closed unexpectedly. exiting", nil)
+				logger.Error("forwardTelemetry: telemetryQueue closed unexpectedly. exiting", nil)
 				return
 			}
 			bin, err := proto.Marshal(t)
@@ -33,11 +35,13 @@ func forwardTelemetry[M proto.Message](
 					"proto": t,
 					"error": err,
 				})
+				telemetryQueue.Delivered()
 				break
 			}
 			if monitoringEndpoint != nil {
 				monitoringEndpoint.SendLog(bin)
 			}
+			telemetryQueue.Delivered()
 		case <-ctx.Done():
 			logger.Info("forwardTelemetry: exiting", nil)
 			return
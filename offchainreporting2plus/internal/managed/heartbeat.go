@@ -0,0 +1,119 @@
+package managed
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/heartbeat"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/shim"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// Instances tracks the OCR3 protocol instances an oracle runs, for inclusion
+// in its heartbeats and its status. All its functions are thread-safe.
+type Instances struct {
+	mutex     sync.Mutex
+	instances map[types.ConfigDigest]instance
+}
+
+type instance struct {
+	oid    commontypes.OracleID
+	status *protocol.InstanceStatus
+}
+
+func NewInstances() *Instances {
+	return &Instances{sync.Mutex{}, map[types.ConfigDigest]instance{}}
+}
+
+// add adds an instance. Call remove once it has stopped.
+func (hi *Instances) add(configDigest types.ConfigDigest, oid commontypes.OracleID, status *protocol.InstanceStatus) (remove func()) {
+	hi.mutex.Lock()
+	defer hi.mutex.Unlock()
+	hi.instances[configDigest] = instance{oid, status}
+	return func() {
+		hi.mutex.Lock()
+		defer hi.mutex.Unlock()
+		delete(hi.instances, configDigest)
+	}
+}
+
+// Status returns the status of the instances currently running, ordered by
+// config digest.
+func (hi *Instances) Status() []types.ProtocolInstanceStatus {
+	hi.mutex.Lock()
+	defer hi.mutex.Unlock()
+	statuses := make([]types.ProtocolInstanceStatus, 0, len(hi.instances))
+	for configDigest, instance := range hi.instances {
+		statuses = append(statuses, types.ProtocolInstanceStatus{
+			configDigest,
+			instance.status.HighestCommittedSeqNr(),
+			instance.status.Degraded(),
+			instance.status.RecoveredFromCorruptedState(),
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return bytes.Compare(statuses[i].ConfigDigest[:], statuses[j].ConfigDigest[:]) < 0
+	})
+	return statuses
+}
+
+func (hi *Instances) snapshot() []heartbeat.Instance {
+	hi.mutex.Lock()
+	defer hi.mutex.Unlock()
+	instances := make([]heartbeat.Instance, 0, len(hi.instances))
+	for configDigest, instance := range hi.instances {
+		instances = append(instances, heartbeat.Instance{
+			configDigest,
+			instance.oid,
+			instance.status.HighestCommittedSeqNr(),
+			instance.status.Degraded(),
+			instance.status.RecoveredFromCorruptedState(),
+		})
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return bytes.Compare(instances[i].ConfigDigest[:], instances[j].ConfigDigest[:]) < 0
+	})
+	return instances
+}
+
+// runHeartbeats emits a signed heartbeat through telemetrySender right away
+// and then every config.Interval, until ctx is done.
+func runHeartbeats(
+	ctx context.Context,
+
+	config heartbeat.Config,
+	instances *Instances,
+	logger loghelper.LoggerWithContext,
+	offchainKeyring types.OffchainKeyring,
+	telemetrySender shim.OCR3TelemetrySender,
+) {
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+	for {
+		payload, signature, err := heartbeat.Sign(offchainKeyring, heartbeat.Heartbeat{
+			config.NodeVersion,
+			offchainKeyring.OffchainPublicKey(),
+			time.Now(),
+			instances.snapshot(),
+		})
+		if err != nil {
+			logger.Error("runHeartbeats: failed to sign heartbeat", commontypes.LogFields{
+				"error": err,
+			})
+		} else {
+			telemetrySender.Heartbeat(payload, signature)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package managed
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/admission"
+)
+
+// instanceHandoff coordinates hot config swaps (see types.HotConfigSwapConfig)
+// between the protocol instances that a managed oracle runs one after another.
+// It does nothing if gracePeriod is zero. All its functions are thread-safe.
+type instanceHandoff struct {
+	ctx         context.Context
+	gracePeriod time.Duration
+
+	mutex sync.Mutex
+	// closed once the network endpoint of the next instance has started
+	chEndpointStarted chan struct{}
+	// closed once the most recent instance to ask for admission has released
+	// its resources, or wasn't admitted
+	chReleased chan struct{}
+}
+
+// newInstanceHandoff returns an instanceHandoff for a managed oracle that runs
+// until ctx is done.
+func newInstanceHandoff(ctx context.Context, gracePeriod time.Duration) *instanceHandoff {
+	chReleased := make(chan struct{})
+	close(chReleased)
+	return &instanceHandoff{ctx, gracePeriod, sync.Mutex{}, make(chan struct{}), chReleased}
+}
+
+// endpointStarted is called once the network endpoint of an instance has
+// started. It lets the endpoints of earlier instances that are winding down
+// close, and returns a channel that is closed once the endpoint of the next
+// instance has started in turn.
+func (h *instanceHandoff) endpointStarted() (chSuperseded <-chan struct{}) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	close(h.chEndpointStarted)
+	h.chEndpointStarted = make(chan struct{})
+	return h.chEndpointStarted
+}
+
+// lingerEndpoint returns a function that blocks until the network endpoint of
+// an instance running until instanceCtx is done may be closed. If the instance
+// stopped because of a config change, that is once chSuperseded is closed or
+// at most gracePeriod after instanceCtx is done, so that ragep2p keeps the
+// connections to peers that the next instance shares with this one. Otherwise,
+// e.g. if the instance failed or the oracle is shutting down, it doesn't
+// block.
+func (h *instanceHandoff) lingerEndpoint(instanceCtx context.Context, chSuperseded <-chan struct{}) (wait func()) {
+	if h.gracePeriod == 0 {
+		return func() {}
+	}
+	lingerCtx, lingerCancel := context.WithCancel(context.WithoutCancel(instanceCtx))
+	stop := context.AfterFunc(instanceCtx, func() {
+		time.AfterFunc(h.gracePeriod, lingerCancel)
+	})
+	return func() {
+		defer lingerCancel()
+		if stop() || h.ctx.Err() != nil {
+			// instance stopped on its own or oracle is shutting down
+			return
+		}
+		select {
+		case <-chSuperseded:
+		case <-lingerCtx.Done():
+		case <-h.ctx.Done():
+		}
+	}
+}
+
+// admit admits an instance running until instanceCtx is done by calling
+// admitInstance. An instance only releases its resources once it has fully
+// stopped, including after winding down. If admission fails for lack of
+// capacity while the previous instance is still winding down, admit waits for
+// the previous instance to release its resources and tries once more. The
+// wait ends at most gracePeriod after the previous instance's context is done.
+func (h *instanceHandoff) admit(instanceCtx context.Context, admitInstance func() (release func(), err error)) (release func(), err error) {
+	h.mutex.Lock()
+	chPreviousReleased := h.chReleased
+	chReleased := make(chan struct{})
+	h.chReleased = chReleased
+	h.mutex.Unlock()
+
+	release, err = admitInstance()
+	var capacityExceededErr *admission.CapacityExceededError
+	if err != nil && h.gracePeriod != 0 && errors.As(err, &capacityExceededErr) {
+		select {
+		case <-chPreviousReleased:
+			release, err = admitInstance()
+		case <-instanceCtx.Done():
+		}
+	}
+	if err != nil {
+		close(chReleased)
+		return nil, err
+	}
+	return func() {
+		release()
+		close(chReleased)
+	}, nil
+}
@@ -0,0 +1,79 @@
+package managed
+
+import (
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// offchainKeyringCandidates returns the keyrings the oracle may run a config
+// with: keyring itself, followed by the keyring it is being rotated to (if
+// any). See types.RotatingOffchainKeyring.
+func offchainKeyringCandidates(keyring types.OffchainKeyring) []types.OffchainKeyring {
+	candidates := []types.OffchainKeyring{keyring}
+	if rotating, ok := keyring.(types.RotatingOffchainKeyring); ok {
+		if next := rotating.NextOffchainKeyring(); next != nil {
+			candidates = append(candidates, next)
+		}
+	}
+	return candidates
+}
+
+// See offchainKeyringCandidates and types.RotatingOnchainKeyring.
+func ocr2OnchainKeyringCandidates(keyring types.OnchainKeyring) []types.OnchainKeyring {
+	candidates := []types.OnchainKeyring{keyring}
+	if rotating, ok := keyring.(types.RotatingOnchainKeyring); ok {
+		if next := rotating.NextOnchainKeyring(); next != nil {
+			candidates = append(candidates, next)
+		}
+	}
+	return candidates
+}
+
+// See offchainKeyringCandidates and ocr3types.RotatingOnchainKeyring.
+func ocr3OnchainKeyringCandidates[RI any](keyring ocr3types.OnchainKeyring[RI]) []ocr3types.OnchainKeyring[RI] {
+	candidates := []ocr3types.OnchainKeyring[RI]{keyring}
+	if rotating, ok := keyring.(ocr3types.RotatingOnchainKeyring[RI]); ok {
+		if next := rotating.NextOnchainKeyring(); next != nil {
+			candidates = append(candidates, next)
+		}
+	}
+	return candidates
+}
+
+// sharedConfigWithKeyrings tries all combinations of candidate keyrings with
+// sharedConfigFromContractConfig and returns the result of the first one that
+// succeeds, together with the keyrings used. Current keyrings are tried before
+// next keyrings, so that we keep using the current keys unless the config
+// lists only the next ones. If no combination succeeds, the error for the
+// current keyrings is returned.
+func sharedConfigWithKeyrings[SC any, ONK any](
+	logger loghelper.LoggerWithContext,
+	offchainKeyrings []types.OffchainKeyring,
+	onchainKeyrings []ONK,
+	sharedConfigFromContractConfig func(types.OffchainKeyring, ONK) (SC, commontypes.OracleID, error),
+) (SC, commontypes.OracleID, types.OffchainKeyring, ONK, error) {
+	var firstErr error
+	for i, onchainKeyring := range onchainKeyrings {
+		for j, offchainKeyring := range offchainKeyrings {
+			sharedConfig, oid, err := sharedConfigFromContractConfig(offchainKeyring, onchainKeyring)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			if i != 0 || j != 0 {
+				logger.Info("running config with next keyring(s) of rotation", commontypes.LogFields{
+					"nextOnchainKeyring":  i != 0,
+					"nextOffchainKeyring": j != 0,
+				})
+			}
+			return sharedConfig, oid, offchainKeyring, onchainKeyring, nil
+		}
+	}
+	var zeroSharedConfig SC
+	var zeroOnchainKeyring ONK
+	return zeroSharedConfig, 0, nil, zeroOnchainKeyring, firstErr
+}
@@ -2,6 +2,7 @@ package limits
 
 import (
 	"crypto/ed25519"
+	"encoding/binary"
 	"fmt"
 	"math"
 	"math/big"
@@ -24,9 +25,10 @@ type serializedLengthLimits struct {
 	maxLenMsgReportSignatures       int
 	maxLenMsgCertifiedCommitRequest int
 	maxLenMsgCertifiedCommit        int
+	maxLenMsgBlob                   int
 }
 
-func ocr3limits(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPluginLimits, maxSigLen int) (types.BinaryNetworkEndpointLimits, serializedLengthLimits, error) {
+func ocr3limits(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPluginLimits, chunkedTransfer ocr3types.ChunkedTransferConfig, maxSigLen int) (types.BinaryNetworkEndpointLimits, serializedLengthLimits, error) {
 	overflow := false
 
 	// These two helper functions add/multiply together a bunch of numbers and set overflow to true if the result
@@ -56,6 +58,12 @@ func ocr3limits(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPlu
 	const sigOverhead = 10
 	const overhead = 256
 
+	// ObservedAt and ReceivedAt, each encoded as a tag followed by a varint
+	observationTimestampsOverhead := 0
+	if cfg.FeatureFlags.Has(ocr3types.ProtocolFeatureFlagObservationTimestamps) {
+		observationTimestampsOverhead = 2 * (2 + binary.MaxVarintLen64)
+	}
+
 	maxLenCertifiedPrepareOrCommit := add(mul(ed25519.SignatureSize+sigOverhead, cfg.ByzQuorumSize()), pluginLimits.MaxOutcomeLength, overhead)
 
 	maxLenMsgNewEpoch := overhead
@@ -63,12 +71,16 @@ func ocr3limits(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPlu
 	maxLenMsgEpochStart := add(maxLenCertifiedPrepareOrCommit, mul(ed25519.SignatureSize+sigOverhead, cfg.ByzQuorumSize()), overhead)
 	maxLenMsgRoundStart := add(pluginLimits.MaxQueryLength, overhead)
 	maxLenMsgObservation := add(pluginLimits.MaxObservationLength, overhead)
-	maxLenMsgProposal := add(mul(add(pluginLimits.MaxObservationLength, ed25519.SignatureSize+sigOverhead), cfg.N()), overhead)
+	maxLenMsgProposal := add(mul(add(pluginLimits.MaxObservationLength, ed25519.SignatureSize+sigOverhead, observationTimestampsOverhead), cfg.N()), overhead)
 	maxLenMsgPrepare := overhead
 	maxLenMsgCommit := overhead
 	maxLenMsgReportSignatures := add(mul(add(maxSigLen, sigOverhead), pluginLimits.MaxReportCount), overhead)
 	maxLenMsgCertifiedCommitRequest := overhead
 	maxLenMsgCertifiedCommit := add(maxLenCertifiedPrepareOrCommit, overhead)
+	maxLenMsgBlob := 0 // MessageBlob and MessageBlobResponse
+	if pluginLimits.MaxBlobLength != 0 {
+		maxLenMsgBlob = add(pluginLimits.MaxBlobLength, overhead)
+	}
 
 	maxMessageSize := max(
 		maxLenMsgNewEpoch,
@@ -82,31 +94,54 @@ func ocr3limits(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPlu
 		maxLenMsgReportSignatures,
 		maxLenMsgCertifiedCommitRequest,
 		maxLenMsgCertifiedCommit,
+		maxLenMsgBlob,
 	)
 
-	minEpochInterval := math.Min(float64(cfg.DeltaProgress), math.Min(float64(cfg.DeltaInitial), float64(cfg.RMax)*float64(cfg.DeltaRound)))
+	// with adaptive round pacing, rounds may be as frequent as DeltaRoundMin
+	deltaRound := cfg.MinDeltaRound()
+	minEpochInterval := math.Min(float64(cfg.DeltaProgress), math.Min(float64(cfg.DeltaInitial), float64(cfg.RMax)*float64(deltaRound)))
 
 	messagesRate := (1.0*float64(time.Second)/float64(cfg.DeltaResend) +
 		3.0*float64(time.Second)/minEpochInterval +
-		8.0*float64(time.Second)/float64(cfg.DeltaRound)) * 1.2
+		8.0*float64(time.Second)/float64(deltaRound)) * 1.2
 
 	messagesCapacity := mul(12, 3)
 
 	bytesRate := float64(time.Second)/float64(cfg.DeltaResend)*float64(maxLenMsgNewEpoch) +
 		float64(time.Second)/float64(minEpochInterval)*float64(maxLenMsgNewEpoch) +
-		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenMsgPrepare) +
-		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenMsgCommit) +
-		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenMsgReportSignatures) +
+		float64(time.Second)/float64(deltaRound)*float64(maxLenMsgPrepare) +
+		float64(time.Second)/float64(deltaRound)*float64(maxLenMsgCommit) +
+		float64(time.Second)/float64(deltaRound)*float64(maxLenMsgReportSignatures) +
 		float64(time.Second)/float64(minEpochInterval)*float64(maxLenMsgEpochStart) +
-		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenMsgRoundStart) +
-		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenMsgProposal) +
+		float64(time.Second)/float64(deltaRound)*float64(maxLenMsgRoundStart) +
+		float64(time.Second)/float64(deltaRound)*float64(maxLenMsgProposal) +
 		float64(time.Second)/float64(minEpochInterval)*float64(maxLenMsgEpochStartRequest) +
-		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenMsgObservation) +
-		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenMsgCertifiedCommitRequest) +
-		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenMsgCertifiedCommit)
+		float64(time.Second)/float64(deltaRound)*float64(maxLenMsgObservation) +
+		float64(time.Second)/float64(deltaRound)*float64(maxLenMsgCertifiedCommitRequest) +
+		float64(time.Second)/float64(deltaRound)*float64(maxLenMsgCertifiedCommit)
 
 	// we don't multiply bytesRate by a safetyMargin since we already have a generous overhead on each message
 
+	if pluginLimits.MaxBlobsPerRound != 0 {
+		// Per DeltaRound, a remote oracle sends us up to MaxBlobsPerRound
+		// blobs and confirms storing up to as many of ours. It also requests
+		// and sends us up to all n*MaxBlobsPerRound blobs broadcast by
+		// anyone. We allow twice that to leave room for retries.
+		blobsPerSecond := 2 * float64(pluginLimits.MaxBlobsPerRound) * float64(time.Second) / float64(deltaRound)
+		messagesRate += blobsPerSecond * float64(2+2*cfg.N())
+		bytesRate += blobsPerSecond * (float64(1+cfg.N())*float64(maxLenMsgBlob) + float64(1+cfg.N())*overhead)
+		messagesCapacity = add(messagesCapacity, mul(2, pluginLimits.MaxBlobsPerRound, 2+2*cfg.N()))
+	}
+
+	if chunkedTransfer.ChunkSize != 0 {
+		// Messages longer than ChunkSize are split into chunks. The bytes of
+		// the chunks are already accounted for above, but every chunk counts
+		// as a message of its own.
+		maxMessageSize = min(maxMessageSize, add(chunkedTransfer.ChunkSize, overhead))
+		messagesRate += chunkedTransfer.MaxChunksPerSecond
+		bytesRate += chunkedTransfer.MaxChunksPerSecond * overhead
+	}
+
 	bytesCapacity := mul(add(
 		maxLenMsgNewEpoch,
 		maxLenMsgNewEpoch,
@@ -120,6 +155,7 @@ func ocr3limits(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPlu
 		maxLenMsgReportSignatures,
 		maxLenMsgCertifiedCommitRequest,
 		maxLenMsgCertifiedCommit,
+		mul(maxLenMsgBlob, pluginLimits.MaxBlobsPerRound),
 	), 3)
 
 	if overflow {
@@ -145,11 +181,19 @@ func ocr3limits(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPlu
 			maxLenMsgReportSignatures,
 			maxLenMsgCertifiedCommitRequest,
 			maxLenMsgCertifiedCommit,
+			maxLenMsgBlob,
 		},
 		nil
 }
 
-func OCR3Limits(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPluginLimits, maxSigLen int) (types.BinaryNetworkEndpointLimits, error) {
-	networkEndpointLimits, _, err := ocr3limits(cfg, pluginLimits, maxSigLen)
+func OCR3Limits(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPluginLimits, chunkedTransfer ocr3types.ChunkedTransferConfig, maxSigLen int) (types.BinaryNetworkEndpointLimits, error) {
+	networkEndpointLimits, _, err := ocr3limits(cfg, pluginLimits, chunkedTransfer, maxSigLen)
 	return networkEndpointLimits, err
 }
+
+// OCR3MaxSerializedMessageLength returns the maximum length of a serialized
+// message, before any chunking.
+func OCR3MaxSerializedMessageLength(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPluginLimits, maxSigLen int) (int, error) {
+	networkEndpointLimits, _, err := ocr3limits(cfg, pluginLimits, ocr3types.ChunkedTransferConfig{}, maxSigLen)
+	return networkEndpointLimits.MaxMessageLength, err
+}
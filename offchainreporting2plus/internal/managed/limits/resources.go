@@ -0,0 +1,48 @@
+package limits
+
+import (
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/admission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr2config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// networkResources returns the resources needed to handle traffic with n-1
+// peers at the rate permitted by networkEndpointLimits. Outbound traffic is
+// assumed to be the same as inbound traffic, since every peer enforces the
+// same limits on us.
+func networkResources(n int, networkEndpointLimits types.BinaryNetworkEndpointLimits) admission.Resources {
+	peers := float64(n - 1)
+	return admission.Resources{
+		2 * peers * networkEndpointLimits.BytesRatePerOracle,
+		peers * networkEndpointLimits.MessagesRatePerOracle,
+		// every peer may fill its token bucket before we process any message
+		peers * float64(networkEndpointLimits.BytesCapacityPerOracle),
+	}
+}
+
+// OCR2Resources estimates the resources used by an OCR2 protocol instance
+// at most, see admission.Resources.
+func OCR2Resources(cfg ocr2config.PublicConfig, reportingPluginLimits types.ReportingPluginLimits, networkEndpointLimits types.BinaryNetworkEndpointLimits) admission.Resources {
+	resources := networkResources(cfg.N(), networkEndpointLimits)
+	// the leader holds all observations of a round, and a report is held
+	// until transmission
+	resources.MemoryBytes += float64(cfg.N())*float64(reportingPluginLimits.MaxObservationLength) +
+		float64(reportingPluginLimits.MaxQueryLength+reportingPluginLimits.MaxReportLength)
+	return resources
+}
+
+// OCR3Resources estimates the resources used by an OCR3 protocol instance
+// at most, see admission.Resources.
+func OCR3Resources(cfg ocr3config.PublicConfig, pluginLimits ocr3types.ReportingPluginLimits, networkEndpointLimits types.BinaryNetworkEndpointLimits) admission.Resources {
+	resources := networkResources(cfg.N(), networkEndpointLimits)
+	// the leader holds all observations of a round, everybody holds the
+	// previous and the current outcome as well as the reports of the current
+	// round, and blobs are held until they expire
+	resources.MemoryBytes += float64(cfg.N())*float64(pluginLimits.MaxObservationLength) +
+		float64(pluginLimits.MaxQueryLength+2*pluginLimits.MaxOutcomeLength) +
+		float64(pluginLimits.MaxReportCount)*float64(pluginLimits.MaxReportLength) +
+		float64(cfg.N())*float64(pluginLimits.MaxBlobsPerRound)*float64(pluginLimits.MaxBlobLength)
+	return resources
+}
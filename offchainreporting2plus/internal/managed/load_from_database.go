@@ -22,5 +22,12 @@ func loadConfigFromDatabase(ctx context.Context, database types.ConfigDatabase,
 		return nil
 	}
 
+	if cc.ConfigDigest == (types.ConfigDigest{}) {
+		// written by runWithContractConfig once the config was removed from
+		// the contract
+		logger.Info("loadConfigFromDatabase: Database.ReadConfig returned config that was removed from the contract, no configuration to restore", nil)
+		return nil
+	}
+
 	return cc
 }
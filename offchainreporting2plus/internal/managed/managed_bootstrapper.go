@@ -60,6 +60,7 @@ func RunManagedBootstrapper(
 
 			<-ctx.Done()
 		},
+		false,
 		localConfig,
 		logger,
 		offchainConfigDigester,
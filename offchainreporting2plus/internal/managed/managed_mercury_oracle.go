@@ -6,6 +6,10 @@ import (
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/admission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/atrest"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/denylist"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/heartbeat"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/managed/limits"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/mercuryshim"
@@ -24,11 +28,17 @@ import (
 // creation/teardown of reporting plugins.
 func RunManagedMercuryOracle(
 	ctx context.Context,
+	teardownCtx context.Context,
 
 	v2bootstrappers []commontypes.BootstrapperLocator,
+	admissionController *admission.Controller,
+	atRestKeyProvider atrest.KeyProvider,
 	configTracker types.ContractConfigTracker,
 	contractTransmitter types.ContractTransmitter,
+	denylistController *denylist.Controller,
 	database ocr3types.Database,
+	heartbeatConfig *heartbeat.Config,
+	instances *Instances,
 	localConfig types.LocalConfig,
 	logger loghelper.LoggerWithContext,
 	monitoringEndpoint commontypes.MonitoringEndpoint,
@@ -37,25 +47,49 @@ func RunManagedMercuryOracle(
 	offchainKeyring types.OffchainKeyring,
 	onchainKeyring types.OnchainKeyring,
 	mercuryPluginFactory ocr3types.MercuryPluginFactory,
+	telemetryQueueStats *shim.TelemetryQueueStats,
 ) {
 	subs := subprocesses.Subprocesses{}
 	defer subs.Wait()
 
-	var chTelemetrySend chan<- *serialization.TelemetryWrapper
-	{
-		chTelemetry := make(chan *serialization.TelemetryWrapper, 100)
-		chTelemetrySend = chTelemetry
-		subs.Go(func() {
-			forwardTelemetry(ctx, logger, monitoringEndpoint, chTelemetry)
-		})
+	// mercury doesn't export metrics
+	metrics := protocol.NewMetrics(nil, logger)
+
+	telemetryQueue := shim.NewTelemetryQueue[*serialization.TelemetryWrapper](100, telemetryQueueStats)
+	subs.Go(func() {
+		forwardTelemetry(ctx, logger, monitoringEndpoint, telemetryQueue)
+	})
+
+	if heartbeatConfig != nil {
+		if err := heartbeatConfig.Validate(); err != nil {
+			logger.Error("ManagedMercuryOracle: invalid heartbeat config, not emitting heartbeats", commontypes.LogFields{
+				"error": err,
+			})
+		} else {
+			subs.Go(func() {
+				runHeartbeats(ctx, *heartbeatConfig, instances, logger, offchainKeyring, shim.MakeOCR3TelemetrySender(telemetryQueue, logger))
+			})
+		}
 	}
 
+	handoff := newInstanceHandoff(ctx, localConfig.HotConfigSwap.GracePeriod)
+
 	runWithContractConfig(
 		ctx,
 
 		configTracker,
 		database,
 		func(ctx context.Context, contractConfig types.ContractConfig, logger loghelper.LoggerWithContext) {
+			instanceCtx := ctx
+			ctx, stopEnforcingDenylist, err := enforceDenylist(ctx, denylistController, contractConfig.ConfigDigest)
+			if err != nil {
+				logger.Error("ManagedMercuryOracle: refusing to run protocol instance", commontypes.LogFields{
+					"error": err,
+				})
+				return
+			}
+			defer stopEnforcingDenylist()
+
 			skipResourceExhaustionChecks := localConfig.DevelopmentMode == types.EnableDangerousDevelopmentMode
 
 			fromAccount, err := contractTransmitter.FromAccount()
@@ -66,15 +100,25 @@ func RunManagedMercuryOracle(
 				return
 			}
 
-			ocr3OnchainKeyring := mercuryshim.NewMercuryOCR3OnchainKeyring(onchainKeyring)
+			ocr3OnchainKeyrings := []ocr3types.OnchainKeyring[mercuryshim.MercuryReportInfo]{}
+			for _, candidate := range ocr2OnchainKeyringCandidates(onchainKeyring) {
+				ocr3OnchainKeyrings = append(ocr3OnchainKeyrings, mercuryshim.NewMercuryOCR3OnchainKeyring(candidate))
+			}
 
-			sharedConfig, oid, err := ocr3config.SharedConfigFromContractConfig[mercuryshim.MercuryReportInfo](
-				skipResourceExhaustionChecks,
-				contractConfig,
-				offchainKeyring,
-				ocr3OnchainKeyring,
-				netEndpointFactory.PeerID(),
-				fromAccount,
+			sharedConfig, oid, offchainKeyring, ocr3OnchainKeyring, err := sharedConfigWithKeyrings(
+				logger,
+				offchainKeyringCandidates(offchainKeyring),
+				ocr3OnchainKeyrings,
+				func(offchainKeyring types.OffchainKeyring, onchainKeyring ocr3types.OnchainKeyring[mercuryshim.MercuryReportInfo]) (ocr3config.SharedConfig, commontypes.OracleID, error) {
+					return ocr3config.SharedConfigFromContractConfig(
+						skipResourceExhaustionChecks,
+						contractConfig,
+						offchainKeyring,
+						onchainKeyring,
+						netEndpointFactory.PeerID(),
+						fromAccount,
+					)
+				},
 			)
 			if err != nil {
 				logger.Error("ManagedMercuryOracle: error while updating config", commontypes.LogFields{
@@ -83,6 +127,17 @@ func RunManagedMercuryOracle(
 				return
 			}
 
+			if err := validateAggregateAttestation(sharedConfig.FeatureFlags, ocr3OnchainKeyring); err != nil {
+				logger.Error("ManagedMercuryOracle: error while updating config", commontypes.LogFields{
+					"error": err,
+				})
+				return
+			}
+
+			subs.Go(func() {
+				pruneSupersededState(ctx, database, sharedConfig.ConfigDigest, localConfig, logger)
+			})
+
 			// Run with new config
 			peerIDs := []string{}
 			for _, identity := range sharedConfig.OracleIdentities {
@@ -109,7 +164,8 @@ func RunManagedMercuryOracle(
 				})
 				return
 			}
-			defer loghelper.CloseLogError(
+			defer loghelper.CloseLogErrorCtx(
+				teardownCtx,
 				mercuryPlugin,
 				logger,
 				"ManagedMercuryOracle: error during reportingPlugin.Close()",
@@ -125,7 +181,7 @@ func RunManagedMercuryOracle(
 
 			reportingPluginLimits := mercuryshim.ReportingPluginLimits(mercuryPluginInfo.Limits)
 
-			lims, err := limits.OCR3Limits(sharedConfig.PublicConfig, reportingPluginLimits, ocr3OnchainKeyring.MaxSignatureLength())
+			lims, err := limits.OCR3Limits(sharedConfig.PublicConfig, reportingPluginLimits, ocr3types.ChunkedTransferConfig{}, ocr3OnchainKeyring.MaxSignatureLength())
 			if err != nil {
 				logger.Error("ManagedMercuryOracle: error during limits", commontypes.LogFields{
 					"error":                 err,
@@ -135,6 +191,19 @@ func RunManagedMercuryOracle(
 				})
 				return
 			}
+			demand := limits.OCR3Resources(sharedConfig.PublicConfig, reportingPluginLimits, lims)
+			release, err := handoff.admit(instanceCtx, func() (func(), error) {
+				return admitInstance(admissionController, sharedConfig.ConfigDigest, demand)
+			})
+			if err != nil {
+				logger.Error("ManagedMercuryOracle: protocol instance not admitted", commontypes.LogFields{
+					"error":  err,
+					"demand": demand,
+				})
+				return
+			}
+			defer release()
+
 			binNetEndpoint, err := netEndpointFactory.NewEndpoint(
 				sharedConfig.ConfigDigest,
 				peerIDs,
@@ -154,12 +223,18 @@ func RunManagedMercuryOracle(
 			// No need to binNetEndpoint.Start/Close since netEndpoint will handle that for us
 
 			netEndpoint := shim.NewOCR3SerializingEndpoint[mercuryshim.MercuryReportInfo](
-				chTelemetrySend,
+				telemetryQueue,
 				sharedConfig.ConfigDigest,
 				binNetEndpoint,
 				ocr3OnchainKeyring.MaxSignatureLength(),
 				childLogger,
+				metrics,
 				reportingPluginLimits,
+				ocr3types.ChunkedTransferConfig{}, // mercury doesn't need chunked transfer
+				localConfig.InboundMessageDeduplication,
+				nil, // mercury doesn't support message archiving
+				types.MessageArchivingConfig{},
+				0,
 				sharedConfig.N(),
 				sharedConfig.F,
 			)
@@ -170,11 +245,14 @@ func RunManagedMercuryOracle(
 				})
 				return
 			}
-			defer loghelper.CloseLogError(
+			defer loghelper.CloseLogErrorCtx(
+				teardownCtx,
 				netEndpoint,
 				logger,
 				"ManagedMercuryOracle: error during netEndpoint.Close()",
 			)
+			chSuperseded := handoff.endpointStarted()
+			defer handoff.lingerEndpoint(instanceCtx, chSuperseded)()
 
 			reportingPluginConfig := ocr3types.ReportingPluginConfig{
 				sharedConfig.ConfigDigest,
@@ -188,6 +266,7 @@ func RunManagedMercuryOracle(
 				sharedConfig.MaxDurationObservation,
 				sharedConfig.MaxDurationShouldAcceptAttestedReport,
 				sharedConfig.MaxDurationShouldTransmitAcceptedReport,
+				sharedConfig.FeatureFlags,
 			}
 			reportingPlugin := &mercuryshim.MercuryReportingPlugin{
 				reportingPluginConfig,
@@ -195,21 +274,36 @@ func RunManagedMercuryOracle(
 				mercuryPluginInfo.Limits,
 			}
 
+			instanceStatus := protocol.NewInstanceStatus(nil)
+			removeInstance := instances.add(sharedConfig.ConfigDigest, oid, instanceStatus)
+			defer removeInstance()
+
 			protocol.RunOracle[mercuryshim.MercuryReportInfo](
 				ctx,
+				nil, // no fault injection for mercury
+				nil, // no retransmission for mercury
 				sharedConfig,
 				mercuryshim.NewMercuryOCR3ContractTransmitter(contractTransmitter),
-				&shim.SerializingOCR3Database{database},
+				&shim.SerializingOCR3Database{database, newAtRestSealer(atRestKeyProvider)},
 				oid,
+				instanceStatus,
+				nil,
+				nil,
 				localConfig,
 				childLogger,
+				metrics,
 				netEndpoint,
 				offchainKeyring,
 				ocr3OnchainKeyring,
-				shim.LimitCheckOCR3ReportingPlugin[mercuryshim.MercuryReportInfo]{reportingPlugin, reportingPluginLimits},
-				shim.MakeOCR3TelemetrySender(chTelemetrySend, childLogger),
+				shim.LimitCheckOCR3ReportingPlugin[mercuryshim.MercuryReportInfo]{ocr3types.NewReportingPluginV2FromV1[mercuryshim.MercuryReportInfo](reportingPlugin), reportingPluginLimits, 0, metrics},
+				nil, // mercury plugins don't learn about abandoned rounds
+				shim.MakeOCR3TelemetrySender(telemetryQueue, childLogger),
+				nil, // mercury uses the local clock
+				nil, // mercury doesn't support tracing
+				nil, // mercury doesn't retry transmissions
 			)
 		},
+		localConfig.HotConfigSwap.GracePeriod != 0,
 		localConfig,
 		logger,
 		offchainConfigDigester,
@@ -6,6 +6,9 @@ import (
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/admission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/atrest"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/denylist"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr2config"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/managed/limits"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr2/protocol"
@@ -22,10 +25,14 @@ import (
 // creation/teardown of reporting plugins.
 func RunManagedOCR2Oracle(
 	ctx context.Context,
+	teardownCtx context.Context,
 
 	v2bootstrappers []commontypes.BootstrapperLocator,
+	admissionController *admission.Controller,
+	atRestKeyProvider atrest.KeyProvider,
 	configTracker types.ContractConfigTracker,
 	contractTransmitter types.ContractTransmitter,
+	denylistController *denylist.Controller,
 	database types.Database,
 	localConfig types.LocalConfig,
 	logger loghelper.LoggerWithContext,
@@ -35,18 +42,15 @@ func RunManagedOCR2Oracle(
 	offchainKeyring types.OffchainKeyring,
 	onchainKeyring types.OnchainKeyring,
 	reportingPluginFactory types.ReportingPluginFactory,
+	telemetryQueueStats *shim.TelemetryQueueStats,
 ) {
 	subs := subprocesses.Subprocesses{}
 	defer subs.Wait()
 
-	var chTelemetrySend chan<- *serialization.TelemetryWrapper
-	{
-		chTelemetry := make(chan *serialization.TelemetryWrapper, 100)
-		chTelemetrySend = chTelemetry
-		subs.Go(func() {
-			forwardTelemetry(ctx, logger, monitoringEndpoint, chTelemetry)
-		})
-	}
+	telemetryQueue := shim.NewTelemetryQueue[*serialization.TelemetryWrapper](100, telemetryQueueStats)
+	subs.Go(func() {
+		forwardTelemetry(ctx, logger, monitoringEndpoint, telemetryQueue)
+	})
 
 	subs.Go(func() {
 		collectGarbage(ctx, database, localConfig, logger)
@@ -58,6 +62,15 @@ func RunManagedOCR2Oracle(
 		configTracker,
 		database,
 		func(ctx context.Context, contractConfig types.ContractConfig, logger loghelper.LoggerWithContext) {
+			ctx, stopEnforcingDenylist, err := enforceDenylist(ctx, denylistController, contractConfig.ConfigDigest)
+			if err != nil {
+				logger.Error("ManagedOCR2Oracle: refusing to run protocol instance", commontypes.LogFields{
+					"error": err,
+				})
+				return
+			}
+			defer stopEnforcingDenylist()
+
 			skipResourceExhaustionChecks := localConfig.DevelopmentMode == types.EnableDangerousDevelopmentMode
 
 			fromAccount, err := contractTransmitter.FromAccount()
@@ -68,13 +81,20 @@ func RunManagedOCR2Oracle(
 				return
 			}
 
-			sharedConfig, oid, err := ocr2config.SharedConfigFromContractConfig(
-				skipResourceExhaustionChecks,
-				contractConfig,
-				offchainKeyring,
-				onchainKeyring,
-				netEndpointFactory.PeerID(),
-				fromAccount,
+			sharedConfig, oid, offchainKeyring, onchainKeyring, err := sharedConfigWithKeyrings(
+				logger,
+				offchainKeyringCandidates(offchainKeyring),
+				ocr2OnchainKeyringCandidates(onchainKeyring),
+				func(offchainKeyring types.OffchainKeyring, onchainKeyring types.OnchainKeyring) (ocr2config.SharedConfig, commontypes.OracleID, error) {
+					return ocr2config.SharedConfigFromContractConfig(
+						skipResourceExhaustionChecks,
+						contractConfig,
+						offchainKeyring,
+						onchainKeyring,
+						netEndpointFactory.PeerID(),
+						fromAccount,
+					)
+				},
 			)
 			if err != nil {
 				logger.Error("ManagedOCR2Oracle: error while updating config", commontypes.LogFields{
@@ -83,6 +103,10 @@ func RunManagedOCR2Oracle(
 				return
 			}
 
+			subs.Go(func() {
+				pruneSupersededState(ctx, database, sharedConfig.ConfigDigest, localConfig, logger)
+			})
+
 			// Run with new config
 			peerIDs := []string{}
 			for _, identity := range sharedConfig.OracleIdentities {
@@ -113,7 +137,8 @@ func RunManagedOCR2Oracle(
 				})
 				return
 			}
-			defer loghelper.CloseLogError(
+			defer loghelper.CloseLogErrorCtx(
+				teardownCtx,
 				reportingPlugin,
 				logger,
 				"ManagedOCR2Oracle: error during reportingPlugin.Close()",
@@ -136,6 +161,17 @@ func RunManagedOCR2Oracle(
 				})
 				return
 			}
+			demand := limits.OCR2Resources(sharedConfig.PublicConfig, reportingPluginInfo.Limits, lims)
+			release, err := admitInstance(admissionController, sharedConfig.ConfigDigest, demand)
+			if err != nil {
+				logger.Error("ManagedOCR2Oracle: protocol instance not admitted", commontypes.LogFields{
+					"error":  err,
+					"demand": demand,
+				})
+				return
+			}
+			defer release()
+
 			binNetEndpoint, err := netEndpointFactory.NewEndpoint(
 				sharedConfig.ConfigDigest,
 				peerIDs,
@@ -155,7 +191,7 @@ func RunManagedOCR2Oracle(
 			// No need to binNetEndpoint.Start/Close since netEndpoint will handle that for us
 
 			netEndpoint := shim.NewOCR2SerializingEndpoint(
-				chTelemetrySend,
+				telemetryQueue,
 				sharedConfig.ConfigDigest,
 				binNetEndpoint,
 				childLogger,
@@ -168,7 +204,8 @@ func RunManagedOCR2Oracle(
 				})
 				return
 			}
-			defer loghelper.CloseLogError(
+			defer loghelper.CloseLogErrorCtx(
+				teardownCtx,
 				netEndpoint,
 				logger,
 				"ManagedOCR2Oracle: error during netEndpoint.Close()",
@@ -193,11 +230,16 @@ func RunManagedOCR2Oracle(
 				reportQuorum = sharedConfig.F + 1
 			}
 
+			var protocolDatabase types.Database = database
+			if atRestKeyProvider != nil {
+				protocolDatabase = shim.EncryptingOCR2Database{database, newAtRestSealer(atRestKeyProvider)}
+			}
+
 			protocol.RunOracle(
 				ctx,
 				sharedConfig,
 				contractTransmitter,
-				database,
+				protocolDatabase,
 				oid,
 				localConfig,
 				childLogger,
@@ -206,9 +248,10 @@ func RunManagedOCR2Oracle(
 				onchainKeyring,
 				shim.LimitCheckReportingPlugin{reportingPlugin, reportingPluginInfo.Limits},
 				reportQuorum,
-				shim.MakeOCR2TelemetrySender(chTelemetrySend, childLogger),
+				shim.MakeOCR2TelemetrySender(telemetryQueue, childLogger),
 			)
 		},
+		false,
 		localConfig,
 		logger,
 		offchainConfigDigester,
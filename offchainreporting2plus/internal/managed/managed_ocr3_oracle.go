@@ -4,16 +4,29 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/internal/metricshelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/admission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/atrest"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/chaos"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/denylist"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/heartbeat"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/managed/limits"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/serialization"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/shim"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/latestreportcache"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3trace"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/pluginscheduler"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/retransmission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/transmissionretry"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 	"github.com/smartcontractkit/libocr/subprocesses"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
 )
 
@@ -23,38 +36,83 @@ import (
 // creation/teardown of reporting plugins.
 func RunManagedOCR3Oracle[RI any](
 	ctx context.Context,
+	teardownCtx context.Context,
 
 	v2bootstrappers []commontypes.BootstrapperLocator,
+	admissionController *admission.Controller,
+	atRestKeyProvider atrest.KeyProvider,
+	chaosController *chaos.Controller,
 	configTracker types.ContractConfigTracker,
 	contractTransmitter ocr3types.ContractTransmitter[RI],
+	denylistController *denylist.Controller,
 	database ocr3types.Database,
+	heartbeatConfig *heartbeat.Config,
+	instances *Instances,
+	latestReportCache *latestreportcache.Cache,
 	localConfig types.LocalConfig,
 	logger loghelper.LoggerWithContext,
+	messageArchiver types.MessageArchiver,
+	metricsRegisterer prometheus.Registerer,
 	monitoringEndpoint commontypes.MonitoringEndpoint,
 	netEndpointFactory types.BinaryNetworkEndpointFactory,
 	offchainConfigDigester types.OffchainConfigDigester,
 	offchainKeyring types.OffchainKeyring,
 	onchainKeyring ocr3types.OnchainKeyring[RI],
-	reportingPluginFactory ocr3types.ReportingPluginFactory[RI],
+	pluginScheduler *pluginscheduler.Scheduler,
+	reportingPluginFactory ocr3types.ReportingPluginFactoryV2[RI],
+	reportPostProcessors []ocr3types.ReportPostProcessor[RI],
+	retransmissionController *retransmission.Controller,
+	telemetryQueueStats *shim.TelemetryQueueStats,
+	timeSource ocr3types.TimeSource,
+	traceRecorder *ocr3trace.Recorder,
+	tracerProvider trace.TracerProvider,
+	transmissionRetryPolicy transmissionretry.Policy,
 ) {
 	subs := subprocesses.Subprocesses{}
 	defer subs.Wait()
 
-	var chTelemetrySend chan<- *serialization.TelemetryWrapper
-	{
-		chTelemetry := make(chan *serialization.TelemetryWrapper, 100)
-		chTelemetrySend = chTelemetry
-		subs.Go(func() {
-			forwardTelemetry(ctx, logger, monitoringEndpoint, chTelemetry)
-		})
+	metrics := protocol.NewMetrics(metricsRegisterer, logger)
+	defer metrics.Close()
+
+	telemetryMetrics := metricshelper.NewRegisterer(metricsRegisterer, logger)
+	defer telemetryMetrics.Close()
+	telemetryMetrics.Register(telemetryQueueStats.Collectors()...)
+
+	telemetryQueue := shim.NewTelemetryQueue[*serialization.TelemetryWrapper](100, telemetryQueueStats)
+	subs.Go(func() {
+		forwardTelemetry(ctx, logger, monitoringEndpoint, telemetryQueue)
+	})
+
+	if heartbeatConfig != nil {
+		if err := heartbeatConfig.Validate(); err != nil {
+			logger.Error("ManagedOCR3Oracle: invalid heartbeat config, not emitting heartbeats", commontypes.LogFields{
+				"error": err,
+			})
+		} else {
+			subs.Go(func() {
+				runHeartbeats(ctx, *heartbeatConfig, instances, logger, offchainKeyring, shim.MakeOCR3TelemetrySender(telemetryQueue, logger))
+			})
+		}
 	}
 
+	handoff := newInstanceHandoff(ctx, localConfig.HotConfigSwap.GracePeriod)
+
 	runWithContractConfig(
 		ctx,
 
 		configTracker,
 		database,
 		func(ctx context.Context, contractConfig types.ContractConfig, logger loghelper.LoggerWithContext) {
+			instanceCtx := ctx
+			ctx, stopEnforcingDenylist, err := enforceDenylist(ctx, denylistController, contractConfig.ConfigDigest)
+			if err != nil {
+				logger.Error("ManagedOCR3Oracle: refusing to run protocol instance", commontypes.LogFields{
+					"error": err,
+				})
+				return
+			}
+			defer stopEnforcingDenylist()
+
 			skipResourceExhaustionChecks := localConfig.DevelopmentMode == types.EnableDangerousDevelopmentMode
 
 			fromAccount, err := contractTransmitter.FromAccount()
@@ -65,13 +123,20 @@ func RunManagedOCR3Oracle[RI any](
 				return
 			}
 
-			sharedConfig, oid, err := ocr3config.SharedConfigFromContractConfig(
-				skipResourceExhaustionChecks,
-				contractConfig,
-				offchainKeyring,
-				onchainKeyring,
-				netEndpointFactory.PeerID(),
-				fromAccount,
+			sharedConfig, oid, offchainKeyring, onchainKeyring, err := sharedConfigWithKeyrings(
+				logger,
+				offchainKeyringCandidates(offchainKeyring),
+				ocr3OnchainKeyringCandidates(onchainKeyring),
+				func(offchainKeyring types.OffchainKeyring, onchainKeyring ocr3types.OnchainKeyring[RI]) (ocr3config.SharedConfig, commontypes.OracleID, error) {
+					return ocr3config.SharedConfigFromContractConfig(
+						skipResourceExhaustionChecks,
+						contractConfig,
+						offchainKeyring,
+						onchainKeyring,
+						netEndpointFactory.PeerID(),
+						fromAccount,
+					)
+				},
 			)
 			if err != nil {
 				logger.Error("ManagedOCR3Oracle: error while updating config", commontypes.LogFields{
@@ -80,6 +145,17 @@ func RunManagedOCR3Oracle[RI any](
 				return
 			}
 
+			if err := validateAggregateAttestation(sharedConfig.FeatureFlags, onchainKeyring); err != nil {
+				logger.Error("ManagedOCR3Oracle: error while updating config", commontypes.LogFields{
+					"error": err,
+				})
+				return
+			}
+
+			subs.Go(func() {
+				pruneSupersededState(ctx, database, sharedConfig.ConfigDigest, localConfig, logger)
+			})
+
 			// Run with new config
 			peerIDs := []string{}
 			for _, identity := range sharedConfig.OracleIdentities {
@@ -90,7 +166,7 @@ func RunManagedOCR3Oracle[RI any](
 				"oid": oid,
 			})
 
-			reportingPlugin, reportingPluginInfo, err := reportingPluginFactory.NewReportingPlugin(ocr3types.ReportingPluginConfig{
+			reportingPluginConfig := ocr3types.ReportingPluginConfig{
 				sharedConfig.ConfigDigest,
 				oid,
 				sharedConfig.N(),
@@ -102,21 +178,31 @@ func RunManagedOCR3Oracle[RI any](
 				sharedConfig.MaxDurationObservation,
 				sharedConfig.MaxDurationShouldAcceptAttestedReport,
 				sharedConfig.MaxDurationShouldTransmitAcceptedReport,
-			})
+				sharedConfig.FeatureFlags,
+			}
+			if traceRecorder != nil {
+				logger.Warn("ManagedOCR3Oracle: trace recording is enabled, all plugin inputs and outputs are written to the trace", nil)
+				traceRecorder.Record(ocr3trace.Entry{
+					Kind:   ocr3trace.EntryKindConfig,
+					Config: &reportingPluginConfig,
+				})
+			}
 
+			reportingPlugin, reportingPluginInfo, err := reportingPluginFactory.NewReportingPlugin(ctx, reportingPluginConfig)
 			if err != nil {
 				logger.Error("ManagedOCR3Oracle: error during NewReportingPlugin()", commontypes.LogFields{
 					"error": err,
 				})
 				return
 			}
-			defer loghelper.CloseLogError(
+			defer loghelper.CloseLogErrorCtx(
+				teardownCtx,
 				reportingPlugin,
 				logger,
 				"ManagedOCR3Oracle: error during reportingPlugin.Close()",
 			)
 
-			if err := validateOCR3ReportingPluginLimits(reportingPluginInfo.Limits); err != nil {
+			if err := ValidateOCR3ReportingPluginInfo(sharedConfig, reportingPlugin, reportingPluginInfo); err != nil {
 				logger.Error("ManagedOCR3Oracle: invalid ReportingPluginInfo", commontypes.LogFields{
 					"error":               err,
 					"reportingPluginInfo": reportingPluginInfo,
@@ -124,7 +210,17 @@ func RunManagedOCR3Oracle[RI any](
 				return
 			}
 
-			lims, err := limits.OCR3Limits(sharedConfig.PublicConfig, reportingPluginInfo.Limits, onchainKeyring.MaxSignatureLength())
+			lims, err := limits.OCR3Limits(sharedConfig.PublicConfig, reportingPluginInfo.Limits, reportingPluginInfo.ChunkedTransfer, onchainKeyring.MaxSignatureLength())
+			if err != nil {
+				logger.Error("ManagedOCR3Oracle: error during limits", commontypes.LogFields{
+					"error":                 err,
+					"publicConfig":          sharedConfig.PublicConfig,
+					"reportingPluginLimits": reportingPluginInfo.Limits,
+					"maxSigLen":             onchainKeyring.MaxSignatureLength(),
+				})
+				return
+			}
+			maxMessageLength, err := limits.OCR3MaxSerializedMessageLength(sharedConfig.PublicConfig, reportingPluginInfo.Limits, onchainKeyring.MaxSignatureLength())
 			if err != nil {
 				logger.Error("ManagedOCR3Oracle: error during limits", commontypes.LogFields{
 					"error":                 err,
@@ -134,6 +230,31 @@ func RunManagedOCR3Oracle[RI any](
 				})
 				return
 			}
+			demand := limits.OCR3Resources(sharedConfig.PublicConfig, reportingPluginInfo.Limits, lims)
+			release, err := handoff.admit(instanceCtx, func() (func(), error) {
+				return admitInstance(admissionController, sharedConfig.ConfigDigest, demand)
+			})
+			if err != nil {
+				logger.Error("ManagedOCR3Oracle: protocol instance not admitted", commontypes.LogFields{
+					"error":  err,
+					"demand": demand,
+				})
+				return
+			}
+			defer release()
+
+			pluginSchedulerInstance, err := registerWithPluginScheduler(pluginScheduler, sharedConfig.ConfigDigest, demand)
+			if err != nil {
+				logger.Error("ManagedOCR3Oracle: error while registering with plugin scheduler", commontypes.LogFields{
+					"error":  err,
+					"demand": demand,
+				})
+				return
+			}
+			if pluginSchedulerInstance != nil {
+				defer pluginSchedulerInstance.Close()
+			}
+
 			binNetEndpoint, err := netEndpointFactory.NewEndpoint(
 				sharedConfig.ConfigDigest,
 				peerIDs,
@@ -152,16 +273,30 @@ func RunManagedOCR3Oracle[RI any](
 
 			// No need to binNetEndpoint.Start/Close since netEndpoint will handle that for us
 
-			netEndpoint := shim.NewOCR3SerializingEndpoint[RI](
-				chTelemetrySend,
+			var netEndpoint protocol.NetworkEndpoint[RI] = shim.NewOCR3SerializingEndpoint[RI](
+				telemetryQueue,
 				sharedConfig.ConfigDigest,
 				binNetEndpoint,
 				onchainKeyring.MaxSignatureLength(),
 				childLogger,
+				metrics,
 				reportingPluginInfo.Limits,
+				reportingPluginInfo.ChunkedTransfer,
+				localConfig.InboundMessageDeduplication,
+				messageArchiver,
+				localConfig.MessageArchiving,
+				maxMessageLength,
 				sharedConfig.N(),
 				sharedConfig.F,
 			)
+			var blobExchange *protocol.BlobExchange[RI]
+			if reportingPluginInfo.Limits.MaxBlobLength != 0 {
+				blobExchange = protocol.NewBlobExchange[RI](netEndpoint, sharedConfig, reportingPluginInfo.Limits, oid, childLogger)
+				netEndpoint = blobExchange
+			}
+			if traceRecorder != nil {
+				netEndpoint = shim.NewRecordingOCR3NetworkEndpoint[RI](netEndpoint, traceRecorder, childLogger)
+			}
 			if err := netEndpoint.Start(); err != nil {
 				logger.Error("ManagedOCR3Oracle: error during netEndpoint.Start()", commontypes.LogFields{
 					"error":        err,
@@ -169,40 +304,227 @@ func RunManagedOCR3Oracle[RI any](
 				})
 				return
 			}
-			defer loghelper.CloseLogError(
+			defer loghelper.CloseLogErrorCtx(
+				teardownCtx,
 				netEndpoint,
 				logger,
 				"ManagedOCR3Oracle: error during netEndpoint.Close()",
 			)
+			chSuperseded := handoff.endpointStarted()
+			defer handoff.lingerEndpoint(instanceCtx, chSuperseded)()
+
+			var chForceEpochChange <-chan struct{}
+			var chRetransmissionRequests <-chan retransmission.Request
+			if retransmissionController != nil {
+				chRetransmissionRequests = retransmissionController.Requests()
+			}
+			var protocolContractTransmitter ocr3types.ContractTransmitter[RI] = contractTransmitter
+			var postProcessingPipeline *shim.OCR3ReportPostProcessingPipeline[RI]
+			if len(reportPostProcessors) != 0 {
+				pipeline, err := shim.NewOCR3ReportPostProcessingPipeline(reportPostProcessors, reportingPluginInfo.Limits.MaxReportLength)
+				if err != nil {
+					logger.Error("ManagedOCR3Oracle: invalid ReportPostProcessors", commontypes.LogFields{
+						"error":                 err,
+						"reportingPluginLimits": reportingPluginInfo.Limits,
+					})
+					return
+				}
+				postProcessingPipeline = pipeline
+				protocolContractTransmitter = shim.PostProcessingOCR3ContractTransmitter[RI]{protocolContractTransmitter, postProcessingPipeline}
+			}
+			if chaosController != nil {
+				logger.Warn("ManagedOCR3Oracle: fault injection is enabled, this oracle may misbehave on request", nil)
+				chForceEpochChange = chaosController.EpochChanges()
+				protocolContractTransmitter = shim.ChaosOCR3ContractTransmitter[RI]{protocolContractTransmitter, chaosController, childLogger}
+			}
+			protocolReportingPlugin := WrapOCR3ReportingPlugin[RI](
+				sharedConfig,
+				reportingPlugin,
+				reportingPluginInfo,
+				childLogger,
+				metrics,
+				traceRecorder,
+				pluginSchedulerInstance,
+				blobExchange,
+				chaosController,
+			)
+			if batchContractTransmitter, ok := contractTransmitter.(ocr3types.BatchContractTransmitter[RI]); ok {
+				if postProcessingPipeline != nil {
+					batchContractTransmitter = shim.PostProcessingOCR3BatchContractTransmitter[RI]{batchContractTransmitter, postProcessingPipeline}
+				}
+				if chaosController != nil {
+					batchContractTransmitter = shim.ChaosOCR3BatchContractTransmitter[RI]{batchContractTransmitter, chaosController, childLogger}
+				}
+				protocolContractTransmitter = shim.BatchingOCR3ContractTransmitter[RI]{
+					protocolContractTransmitter,
+					batchContractTransmitter,
+				}
+			}
+
+			// nil if the plugin doesn't implement RoundAbandonmentListener
+			roundAbandonmentListener, _ := reportingPlugin.(ocr3types.RoundAbandonmentListener)
+
+			var onDegradedChanged func(degraded bool)
+			if localConfig.DegradedMode.Behavior == types.DegradedModeBehaviorBackOff {
+				if dialBackoffEndpoint, ok := binNetEndpoint.(types.DialBackoffEndpoint); ok {
+					onDegradedChanged = dialBackoffEndpoint.SetDialBackoff
+				} else {
+					logger.Warn("ManagedOCR3Oracle: degraded mode behavior is BackOff, but network endpoint doesn't implement DialBackoffEndpoint, dialing won't back off", nil)
+				}
+			}
+			instanceStatus := protocol.NewInstanceStatus(onDegradedChanged)
+			removeInstance := instances.add(sharedConfig.ConfigDigest, oid, instanceStatus)
+			defer removeInstance()
+
+			var telemetrySender protocol.TelemetrySender = shim.MakeOCR3TelemetrySender(telemetryQueue, childLogger)
+			if traceRecorder != nil {
+				telemetrySender = shim.RecordingOCR3TelemetrySender{telemetrySender, traceRecorder}
+			}
 
 			protocol.RunOracle[RI](
 				ctx,
+				chForceEpochChange,
+				chRetransmissionRequests,
 				sharedConfig,
-				contractTransmitter,
-				&shim.SerializingOCR3Database{database},
+				protocolContractTransmitter,
+				&shim.SerializingOCR3Database{database, newAtRestSealer(atRestKeyProvider)},
 				oid,
+				instanceStatus,
+				latestReportCache,
+				latestreportcache.Destinations(contractTransmitter),
 				localConfig,
 				childLogger,
+				metrics,
 				netEndpoint,
 				offchainKeyring,
 				onchainKeyring,
-				shim.LimitCheckOCR3ReportingPlugin[RI]{reportingPlugin, reportingPluginInfo.Limits},
-				shim.MakeOCR3TelemetrySender(chTelemetrySend, childLogger),
+				protocolReportingPlugin,
+				roundAbandonmentListener,
+				telemetrySender,
+				timeSource,
+				tracerProvider,
+				transmissionRetryPolicy,
 			)
 		},
+		localConfig.HotConfigSwap.GracePeriod != 0,
 		localConfig,
 		logger,
 		offchainConfigDigester,
 	)
 }
 
-func validateOCR3ReportingPluginLimits(limits ocr3types.ReportingPluginLimits) error {
+// ValidateOCR3ReportingPluginInfo checks the ReportingPluginInfo that a
+// ReportingPluginFactory returned alongside reportingPlugin, both on its own and
+// against the feature flags of sharedConfig.
+func ValidateOCR3ReportingPluginInfo[RI any](sharedConfig ocr3config.SharedConfig, reportingPlugin ocr3types.ReportingPluginV2[RI], reportingPluginInfo ocr3types.ReportingPluginInfo) error {
+	return multierr.Combine(
+		validateOCR3ReportingPluginLimits(reportingPluginInfo.Limits, reportingPluginInfo.ChunkedTransfer),
+		validateChunkedTransferConfig(reportingPluginInfo.ChunkedTransfer),
+		validateObservationCacheConfig(reportingPluginInfo.ObservationCache),
+		validateQueryLess(sharedConfig.FeatureFlags, reportingPluginInfo.QueryLess),
+		validateMaxExactObservationQuorum(sharedConfig.N(), sharedConfig.F, reportingPluginInfo.MaxExactObservationQuorum),
+		validateObservationSampling(sharedConfig.FeatureFlags, reportingPluginInfo.ObservationSampling),
+		validateReportGenerators(reportingPlugin),
+	)
+}
+
+// WrapOCR3ReportingPlugin wraps reportingPlugin, including the optional
+// ocr3types interfaces it implements, in the shims that sit between it and the
+// protocol. traceRecorder, pluginSchedulerInstance, blobExchange, and
+// chaosController may be nil. reportingPlugin and reportingPluginInfo must have
+// passed ValidateOCR3ReportingPluginInfo.
+func WrapOCR3ReportingPlugin[RI any](
+	sharedConfig ocr3config.SharedConfig,
+	reportingPlugin ocr3types.ReportingPluginV2[RI],
+	reportingPluginInfo ocr3types.ReportingPluginInfo,
+	logger loghelper.LoggerWithContext,
+	metrics *protocol.Metrics,
+	traceRecorder *ocr3trace.Recorder,
+	pluginSchedulerInstance *pluginscheduler.Instance,
+	blobExchange *protocol.BlobExchange[RI],
+	chaosController *chaos.Controller,
+) ocr3types.ReportingPluginV2[RI] {
+	scheduledReportingPlugin := reportingPlugin
+	if traceRecorder != nil {
+		scheduledReportingPlugin = shim.RecordingOCR3ReportingPlugin[RI]{scheduledReportingPlugin, traceRecorder}
+	}
+	if pluginSchedulerInstance != nil {
+		scheduledReportingPlugin = shim.SchedulingOCR3ReportingPlugin[RI]{scheduledReportingPlugin, pluginSchedulerInstance}
+	}
+	var protocolReportingPlugin ocr3types.ReportingPluginV2[RI] = shim.LimitCheckOCR3ReportingPlugin[RI]{scheduledReportingPlugin, reportingPluginInfo.Limits, reportingPluginInfo.MaxExactObservationQuorum, metrics}
+	if reportingPluginInfo.PreviousOutcomeHashOnly {
+		protocolReportingPlugin = shim.PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI]{protocolReportingPlugin}
+	}
+	if blobExchange != nil {
+		protocolReportingPlugin = shim.BlobOCR3ReportingPlugin[RI]{protocolReportingPlugin, blobExchange}
+	}
+	if reportingPluginInfo.ObservationCache.MaxEntries != 0 {
+		protocolReportingPlugin = shim.NewObservationCachingOCR3ReportingPlugin[RI](protocolReportingPlugin, reportingPluginInfo.ObservationCache, logger)
+	}
+	if chaosController != nil {
+		protocolReportingPlugin = shim.ChaosOCR3ReportingPlugin[RI]{protocolReportingPlugin, chaosController, logger}
+	}
+	if reportBatcher, ok := reportingPlugin.(ocr3types.ReportBatcher[RI]); ok {
+		if traceRecorder != nil {
+			reportBatcher = shim.RecordingOCR3ReportBatcher[RI]{reportBatcher, traceRecorder}
+		}
+		if pluginSchedulerInstance != nil {
+			reportBatcher = shim.SchedulingOCR3ReportBatcher[RI]{reportBatcher, pluginSchedulerInstance}
+		}
+		if blobExchange != nil {
+			reportBatcher = shim.BlobOCR3ReportBatcher[RI]{reportBatcher, blobExchange}
+		}
+		protocolReportingPlugin = shim.ReportBatchingOCR3ReportingPlugin[RI]{
+			protocolReportingPlugin,
+			shim.LimitCheckOCR3ReportBatcher[RI]{reportBatcher, reportingPluginInfo.Limits, metrics},
+		}
+	} else if outcomeContextReporter, ok := reportingPlugin.(ocr3types.OutcomeContextReporter[RI]); ok {
+		// implementing both is rejected by validateReportGenerators
+		if traceRecorder != nil {
+			outcomeContextReporter = shim.RecordingOCR3OutcomeContextReporter[RI]{outcomeContextReporter, traceRecorder}
+		}
+		if pluginSchedulerInstance != nil {
+			outcomeContextReporter = shim.SchedulingOCR3OutcomeContextReporter[RI]{outcomeContextReporter, pluginSchedulerInstance}
+		}
+		outcomeContextReporter = shim.LimitCheckOCR3OutcomeContextReporter[RI]{outcomeContextReporter, reportingPluginInfo.Limits, metrics}
+		if reportingPluginInfo.PreviousOutcomeHashOnly {
+			outcomeContextReporter = shim.PreviousOutcomeHashOnlyOCR3OutcomeContextReporter[RI]{outcomeContextReporter}
+		}
+		if blobExchange != nil {
+			outcomeContextReporter = shim.BlobOCR3OutcomeContextReporter[RI]{outcomeContextReporter, blobExchange}
+		}
+		protocolReportingPlugin = shim.OutcomeContextReportingOCR3ReportingPlugin[RI]{
+			protocolReportingPlugin,
+			outcomeContextReporter,
+		}
+	}
+	if reportDecisionBatcher, ok := reportingPlugin.(ocr3types.ReportDecisionBatcher[RI]); ok {
+		if traceRecorder != nil {
+			reportDecisionBatcher = shim.RecordingOCR3ReportDecisionBatcher[RI]{reportDecisionBatcher, traceRecorder}
+		}
+		if pluginSchedulerInstance != nil {
+			reportDecisionBatcher = shim.SchedulingOCR3ReportDecisionBatcher[RI]{reportDecisionBatcher, pluginSchedulerInstance}
+		}
+		if blobExchange != nil {
+			reportDecisionBatcher = shim.BlobOCR3ReportDecisionBatcher[RI]{reportDecisionBatcher, blobExchange}
+		}
+		protocolReportingPlugin = shim.AttachOCR3ReportDecisionBatcher[RI](protocolReportingPlugin, reportDecisionBatcher)
+	}
+	return protocolReportingPlugin
+}
+
+func validateOCR3ReportingPluginLimits(limits ocr3types.ReportingPluginLimits, chunkedTransfer ocr3types.ChunkedTransferConfig) error {
+	maxMaxObservationLength := ocr3types.MaxMaxObservationLength
+	if chunkedTransfer.ChunkSize != 0 {
+		maxMaxObservationLength = ocr3types.MaxMaxChunkedObservationLength
+	}
+
 	var err error
 	if !(0 <= limits.MaxQueryLength && limits.MaxQueryLength <= ocr3types.MaxMaxQueryLength) {
 		err = multierr.Append(err, fmt.Errorf("MaxQueryLength (%v) out of range. Should be between 0 and %v", limits.MaxQueryLength, ocr3types.MaxMaxQueryLength))
 	}
-	if !(0 <= limits.MaxObservationLength && limits.MaxObservationLength <= ocr3types.MaxMaxObservationLength) {
-		err = multierr.Append(err, fmt.Errorf("MaxObservationLength (%v) out of range. Should be between 0 and %v", limits.MaxObservationLength, ocr3types.MaxMaxObservationLength))
+	if !(0 <= limits.MaxObservationLength && limits.MaxObservationLength <= maxMaxObservationLength) {
+		err = multierr.Append(err, fmt.Errorf("MaxObservationLength (%v) out of range. Should be between 0 and %v", limits.MaxObservationLength, maxMaxObservationLength))
 	}
 	if !(0 <= limits.MaxOutcomeLength && limits.MaxOutcomeLength <= ocr3types.MaxMaxOutcomeLength) {
 		err = multierr.Append(err, fmt.Errorf("MaxOutcomeLength (%v) out of range. Should be between 0 and %v", limits.MaxOutcomeLength, ocr3types.MaxMaxOutcomeLength))
@@ -213,5 +535,88 @@ func validateOCR3ReportingPluginLimits(limits ocr3types.ReportingPluginLimits) e
 	if !(0 <= limits.MaxReportCount && limits.MaxReportCount <= ocr3types.MaxMaxReportCount) {
 		err = multierr.Append(err, fmt.Errorf("MaxReportCount (%v) out of range. Should be between 0 and %v", limits.MaxReportCount, ocr3types.MaxMaxReportCount))
 	}
+	if !(0 <= limits.MaxObservationProvenanceLength && limits.MaxObservationProvenanceLength <= ocr3types.MaxMaxObservationProvenanceLength) {
+		err = multierr.Append(err, fmt.Errorf("MaxObservationProvenanceLength (%v) out of range. Should be between 0 and %v", limits.MaxObservationProvenanceLength, ocr3types.MaxMaxObservationProvenanceLength))
+	}
+	if !(0 <= limits.MaxBlobLength && limits.MaxBlobLength <= maxMaxObservationLength) {
+		err = multierr.Append(err, fmt.Errorf("MaxBlobLength (%v) out of range. Should be between 0 and %v", limits.MaxBlobLength, maxMaxObservationLength))
+	}
+	if !(0 <= limits.MaxBlobsPerRound && limits.MaxBlobsPerRound <= ocr3types.MaxMaxBlobsPerRound) {
+		err = multierr.Append(err, fmt.Errorf("MaxBlobsPerRound (%v) out of range. Should be between 0 and %v", limits.MaxBlobsPerRound, ocr3types.MaxMaxBlobsPerRound))
+	}
+	if (limits.MaxBlobLength == 0) != (limits.MaxBlobsPerRound == 0) {
+		err = multierr.Append(err, fmt.Errorf("MaxBlobLength (%v) and MaxBlobsPerRound (%v) must either both be zero or both be non-zero", limits.MaxBlobLength, limits.MaxBlobsPerRound))
+	}
+	return err
+}
+
+func validateChunkedTransferConfig(config ocr3types.ChunkedTransferConfig) error {
+	if config.ChunkSize == 0 {
+		return nil
+	}
+	var err error
+	if !(ocr3types.MinChunkSize <= config.ChunkSize && config.ChunkSize <= ocr3types.MaxMaxObservationLength) {
+		err = multierr.Append(err, fmt.Errorf("ChunkedTransfer.ChunkSize (%v) out of range. Should be between %v and %v", config.ChunkSize, ocr3types.MinChunkSize, ocr3types.MaxMaxObservationLength))
+	}
+	if !(0 < config.MaxChunksPerSecond && config.MaxChunksPerSecond <= ocr3types.MaxMaxChunksPerSecond) {
+		err = multierr.Append(err, fmt.Errorf("ChunkedTransfer.MaxChunksPerSecond (%v) out of range. Should be positive and at most %v", config.MaxChunksPerSecond, ocr3types.MaxMaxChunksPerSecond))
+	}
+	return err
+}
+
+func validateQueryLess(featureFlags ocr3types.FeatureFlags, queryLess bool) error {
+	if featureFlags.Has(ocr3types.ProtocolFeatureFlagQueryLessRounds) && !queryLess {
+		return fmt.Errorf("config enables query-less rounds, but ReportingPlugin doesn't declare QueryLess")
+	}
+	return nil
+}
+
+func validateMaxExactObservationQuorum(n, f int, maxExactObservationQuorum int) error {
+	if !(0 <= maxExactObservationQuorum && maxExactObservationQuorum <= types.MaxOracles) {
+		return fmt.Errorf("MaxExactObservationQuorum (%v) must be between 0 and %v", maxExactObservationQuorum, types.MaxOracles)
+	}
+	if !(maxExactObservationQuorum <= n-f) {
+		return fmt.Errorf("MaxExactObservationQuorum (%v) exceeds n-f (%v), so the plugin may never reach its observation quorum", maxExactObservationQuorum, n-f)
+	}
+	return nil
+}
+
+func validateReportGenerators[RI any](reportingPlugin ocr3types.ReportingPluginV2[RI]) error {
+	_, isReportBatcher := reportingPlugin.(ocr3types.ReportBatcher[RI])
+	_, isOutcomeContextReporter := reportingPlugin.(ocr3types.OutcomeContextReporter[RI])
+	if isReportBatcher && isOutcomeContextReporter {
+		return fmt.Errorf("ReportingPlugin implements both ReportBatcher and OutcomeContextReporter, but at most one of them is supported")
+	}
+	return nil
+}
+
+func validateObservationSampling(featureFlags ocr3types.FeatureFlags, observationSampling bool) error {
+	if featureFlags.Has(ocr3types.ProtocolFeatureFlagObservationSampling) && !observationSampling {
+		return fmt.Errorf("config enables observation sampling, but ReportingPlugin doesn't declare ObservationSampling")
+	}
+	return nil
+}
+
+func validateAggregateAttestation[RI any](featureFlags ocr3types.FeatureFlags, onchainKeyring ocr3types.OnchainKeyring[RI]) error {
+	if !featureFlags.Has(ocr3types.ProtocolFeatureFlagAggregateAttestation) {
+		return nil
+	}
+	if _, ok := onchainKeyring.(ocr3types.AggregatingOnchainKeyring[RI]); !ok {
+		return fmt.Errorf("config enables aggregate attestation, but OnchainKeyring doesn't implement AggregatingOnchainKeyring")
+	}
+	return nil
+}
+
+func validateObservationCacheConfig(config ocr3types.ObservationCacheConfig) error {
+	if config.MaxEntries == 0 {
+		return nil
+	}
+	var err error
+	if !(0 <= config.MaxEntries && config.MaxEntries <= ocr3types.MaxObservationCacheMaxEntries) {
+		err = multierr.Append(err, fmt.Errorf("ObservationCache.MaxEntries (%v) out of range. Should be between 0 and %v", config.MaxEntries, ocr3types.MaxObservationCacheMaxEntries))
+	}
+	if !(0 < config.TTL) {
+		err = multierr.Append(err, fmt.Errorf("ObservationCache.TTL (%v) must be positive if the cache is enabled", config.TTL))
+	}
 	return err
 }
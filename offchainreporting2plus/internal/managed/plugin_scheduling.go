@@ -0,0 +1,23 @@
+package managed
+
+import (
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/admission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/pluginscheduler"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// registerWithPluginScheduler registers the protocol instance with
+// configDigest with pluginScheduler. The instance is weighted by the messages
+// per second it may process at most, our measure of its CPU demand (see
+// admission.Resources). The result is nil if pluginScheduler is nil.
+func registerWithPluginScheduler(pluginScheduler *pluginscheduler.Scheduler, configDigest types.ConfigDigest, demand admission.Resources) (*pluginscheduler.Instance, error) {
+	if pluginScheduler == nil {
+		return nil, nil
+	}
+	weight := demand.MessagesPerSecond
+	if !(weight > 0) {
+		// e.g. with unlimited network endpoints
+		weight = 1
+	}
+	return pluginScheduler.Register(configDigest, weight)
+}
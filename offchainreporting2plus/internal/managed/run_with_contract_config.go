@@ -11,13 +11,16 @@ import (
 
 // runWithContractConfig runs fn with a contractConfig and manages its lifecycle
 // as contractConfigs change according to contractConfigTracker. It also saves
-// and restores contract configs using database.
+// and restores contract configs using database. If hotSwap is set, fn for a
+// new contractConfig is started as soon as fn for the old one has been
+// cancelled, without waiting for it to return.
 func runWithContractConfig(
 	ctx context.Context,
 
 	contractConfigTracker types.ContractConfigTracker,
 	database types.ConfigDatabase,
 	fn func(context.Context, types.ContractConfig, loghelper.LoggerWithContext),
+	hotSwap bool,
 	localConfig types.LocalConfig,
 	logger loghelper.LoggerWithContext,
 	offchainConfigDigester types.OffchainConfigDigester,
@@ -29,6 +32,7 @@ func runWithContractConfig(
 		contractConfigTracker,
 		database,
 		fn,
+		hotSwap,
 		localConfig,
 		logger,
 
@@ -47,6 +51,7 @@ type runWithContractConfigState struct {
 	contractConfigTracker types.ContractConfigTracker
 	database              types.ConfigDatabase
 	fn                    func(context.Context, types.ContractConfig, loghelper.LoggerWithContext)
+	hotSwap               bool
 	localConfig           types.LocalConfig
 	logger                loghelper.LoggerWithContext
 
@@ -63,8 +68,9 @@ func (rwcc *runWithContractConfigState) run() {
 
 	// Only start tracking config after we attempted to load config from db
 	chNewConfig := make(chan types.ContractConfig, 5)
+	chConfigRemoved := make(chan types.ConfigDigest, 5)
 	rwcc.otherSubs.Go(func() {
-		TrackConfig(rwcc.ctx, rwcc.configDigester, rwcc.contractConfigTracker, rwcc.configDigest, rwcc.localConfig, rwcc.logger, chNewConfig)
+		TrackConfig(rwcc.ctx, rwcc.configDigester, rwcc.contractConfigTracker, rwcc.configDigest, rwcc.localConfig, rwcc.logger, chNewConfig, chConfigRemoved)
 	})
 
 	for {
@@ -75,6 +81,8 @@ func (rwcc *runWithContractConfigState) run() {
 				"newConfigDigest": change.ConfigDigest.Hex(),
 			})
 			rwcc.configChanged(change)
+		case configDigest := <-chConfigRemoved:
+			rwcc.configRemoved(configDigest)
 		case <-rwcc.ctx.Done():
 			rwcc.logger.Info("runWithContractConfig: winding down", nil)
 			rwcc.fnSubs.Wait()
@@ -109,6 +117,42 @@ func (rwcc *runWithContractConfigState) restoreFromDatabase() {
 	rwcc.configChanged(*contractConfig)
 }
 
+func (rwcc *runWithContractConfigState) configRemoved(configDigest types.ConfigDigest) {
+	if configDigest != rwcc.configDigest {
+		rwcc.logger.Warn("runWithContractConfig: ignoring removal of config that isn't running", commontypes.LogFields{
+			"configDigest":        rwcc.configDigest,
+			"removedConfigDigest": configDigest,
+		})
+		return
+	}
+
+	rwcc.logger.Info("runWithContractConfig: config was removed from contract, winding down", commontypes.LogFields{
+		"configDigest": configDigest,
+	})
+	rwcc.fnCancel()
+	rwcc.fnSubs.Wait()
+	rwcc.fnCancel = func() {}
+	rwcc.configDigest = types.ConfigDigest{}
+	rwcc.logger.Info("runWithContractConfig: closed removed configuration", commontypes.LogFields{
+		"configDigest": configDigest,
+	})
+
+	// Overwrite the removed config in the database, so that we don't restart
+	// it when restoring from the database on the next boot.
+	writeCtx, writeCancel := context.WithTimeout(rwcc.ctx, rwcc.localConfig.DatabaseTimeout)
+	defer writeCancel()
+	if err := rwcc.database.WriteConfig(writeCtx, types.ContractConfig{}); err != nil {
+		rwcc.logger.ErrorIfNotCanceled("runWithContractConfig: error overwriting removed config in database", writeCtx, commontypes.LogFields{
+			"configDigest": configDigest,
+			"error":        err,
+		})
+	}
+
+	if subscriber, ok := rwcc.contractConfigTracker.(types.ContractConfigRemovalSubscriber); ok {
+		subscriber.ConfigRemoved(configDigest)
+	}
+}
+
 func (rwcc *runWithContractConfigState) configChanged(contractConfig types.ContractConfig) {
 	// Cease any operation from earlier configs
 	rwcc.logger.Info("runWithContractConfig: winding down old configuration", commontypes.LogFields{
@@ -116,11 +160,13 @@ func (rwcc *runWithContractConfigState) configChanged(contractConfig types.Contr
 		"newConfigDigest": contractConfig.ConfigDigest,
 	})
 	rwcc.fnCancel()
-	rwcc.fnSubs.Wait()
-	rwcc.logger.Info("runWithContractConfig: closed old configuration", commontypes.LogFields{
-		"oldConfigDigest": rwcc.configDigest,
-		"newConfigDigest": contractConfig.ConfigDigest,
-	})
+	if !rwcc.hotSwap {
+		rwcc.fnSubs.Wait()
+		rwcc.logger.Info("runWithContractConfig: closed old configuration", commontypes.LogFields{
+			"oldConfigDigest": rwcc.configDigest,
+			"newConfigDigest": contractConfig.ConfigDigest,
+		})
+	}
 
 	// note that there is an analogous check in TrackConfig, so this should never trigger.
 	if err := rwcc.configDigester.CheckContractConfig(contractConfig); err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/smartcontractkit/libocr/backoff"
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
@@ -18,16 +19,30 @@ type trackConfigState struct {
 	localConfig    types.LocalConfig
 	logger         loghelper.LoggerWithContext
 	// out
-	chChanges chan<- types.ContractConfig
+	chChanges  chan<- types.ContractConfig
+	chRemovals chan<- types.ConfigDigest
 	// local
 	subprocesses subprocesses.Subprocesses
 	configDigest types.ConfigDigest
+	// time at which we first saw that the config for configDigest was removed,
+	// or the zero time if it hasn't been removed
+	configRemovedSince time.Time
 }
 
 func (state *trackConfigState) run() {
 	// Check immediately after startup
 	tCheckLatestConfigDetails := time.After(0)
 
+	// Retry failed ContractConfigTracker calls more rapidly than we usually
+	// poll, but never less rapidly.
+	pollInterval := state.localConfig.ContractConfigTrackerPollInterval
+	initialRetryDelay := time.Second
+	if pollInterval < initialRetryDelay {
+		initialRetryDelay = pollInterval
+	}
+	retrySchedule := backoff.Schedule{initialRetryDelay, pollInterval, 2, 0.2}
+	retryBackoff := backoff.New(retrySchedule)
+
 	chNotify := state.configTracker.Notify()
 
 	for {
@@ -42,11 +57,28 @@ func (state *trackConfigState) run() {
 				state.logger.Error("TrackConfig: ContractConfigTracker.Notify() was closed, which should never happen. Will ignore ContractConfigTracker.Notify() from now", nil)
 			}
 		case <-tCheckLatestConfigDetails:
-			change, awaitingConfirmation := state.checkLatestConfigDetails()
+			change, awaitingConfirmation, removed, failed := state.checkLatestConfigDetails()
 			state.logger.Debug("TrackConfig: checking latestConfigDetails", nil)
 
-			// poll more rapidly if we're awaiting confirmation
-			if awaitingConfirmation {
+			if removed {
+				state.configRemoved()
+			} else {
+				state.configRemovedSince = time.Time{}
+			}
+
+			if failed {
+				wait := retryBackoff.Next()
+				if pollInterval < wait {
+					wait = pollInterval
+				}
+				tCheckLatestConfigDetails = time.After(wait)
+				state.logger.Info("TrackConfig: ContractConfigTracker call failed, retrying", commontypes.LogFields{
+					"wait":          wait,
+					"retrySchedule": retrySchedule.String(),
+				})
+			} else if awaitingConfirmation {
+				// poll more rapidly if we're awaiting confirmation
+				retryBackoff.Reset()
 				wait := 15 * time.Second
 				if state.localConfig.ContractConfigTrackerPollInterval < wait {
 					wait = state.localConfig.ContractConfigTrackerPollInterval
@@ -56,7 +88,8 @@ func (state *trackConfigState) run() {
 					"wait": wait,
 				})
 			} else {
-				tCheckLatestConfigDetails = time.After(state.localConfig.ContractConfigTrackerPollInterval)
+				retryBackoff.Reset()
+				tCheckLatestConfigDetails = time.After(pollInterval)
 			}
 
 			if change != nil {
@@ -84,9 +117,42 @@ func (state *trackConfigState) run() {
 	}
 }
 
+func (state *trackConfigState) configRemoved() {
+	if state.localConfig.ContractConfigRemovalGracePeriod == 0 || state.configDigest == (types.ConfigDigest{}) {
+		return
+	}
+
+	if state.configRemovedSince.IsZero() {
+		state.configRemovedSince = time.Now()
+		state.logger.Warn("TrackConfig: config appears to have been removed from contract, will tear down instance unless it reappears", commontypes.LogFields{
+			"configDigest": state.configDigest.Hex(),
+			"gracePeriod":  state.localConfig.ContractConfigRemovalGracePeriod.String(),
+		})
+		return
+	}
+
+	if time.Since(state.configRemovedSince) < state.localConfig.ContractConfigRemovalGracePeriod {
+		return
+	}
+
+	state.logger.Warn("TrackConfig: config was removed from contract for longer than grace period, tearing down instance", commontypes.LogFields{
+		"configDigest":       state.configDigest.Hex(),
+		"configRemovedSince": state.configRemovedSince,
+	})
+	removedConfigDigest := state.configDigest
+	state.configDigest = types.ConfigDigest{}
+	state.configRemovedSince = time.Time{}
+	select {
+	case state.chRemovals <- removedConfigDigest:
+	case <-state.ctx.Done():
+	}
+}
+
 func (state *trackConfigState) checkLatestConfigDetails() (
 	latestConfigDetails *types.ContractConfig,
 	awaitingConfirmation bool,
+	removed bool,
+	failed bool,
 ) {
 	bhCtx, bhCancel := context.WithTimeout(state.ctx, state.localConfig.BlockchainTimeout)
 	defer bhCancel()
@@ -95,7 +161,7 @@ func (state *trackConfigState) checkLatestConfigDetails() (
 		state.logger.ErrorIfNotCanceled("TrackConfig: error during LatestBlockHeight()", bhCtx, commontypes.LogFields{
 			"error": err,
 		})
-		return nil, false
+		return nil, false, false, true
 	}
 
 	detailsCtx, detailsCancel := context.WithTimeout(state.ctx, state.localConfig.BlockchainTimeout)
@@ -105,19 +171,19 @@ func (state *trackConfigState) checkLatestConfigDetails() (
 		state.logger.ErrorIfNotCanceled("TrackConfig: error during LatestConfigDetails()", detailsCtx, commontypes.LogFields{
 			"error": err,
 		})
-		return nil, false
+		return nil, false, false, true
 	}
 	if latestConfigDigest == (types.ConfigDigest{}) {
 		state.logger.Warn("TrackConfig: LatestConfigDetails() returned a zero configDigest. Looks like the contract has not been configured", commontypes.LogFields{
 			"configDigest": latestConfigDigest,
 		})
-		return nil, false
+		return nil, false, true, false
 	}
 	if state.configDigest == latestConfigDigest {
-		return nil, false
+		return nil, false, false, false
 	}
 	if !state.localConfig.SkipContractConfigConfirmations && blockheight < changedInBlock+uint64(state.localConfig.ContractConfigConfirmations)-1 {
-		return nil, true
+		return nil, true, false, false
 	}
 	configCtx, configCancel := context.WithTimeout(state.ctx, state.localConfig.BlockchainTimeout)
 	defer configCancel()
@@ -126,7 +192,7 @@ func (state *trackConfigState) checkLatestConfigDetails() (
 		state.logger.ErrorIfNotCanceled("TrackConfig: error during LatestConfigDetails()", configCtx, commontypes.LogFields{
 			"error": err,
 		})
-		return nil, true
+		return nil, true, false, true
 	}
 
 	if latestConfigDigest != contractConfig.ConfigDigest {
@@ -135,7 +201,7 @@ func (state *trackConfigState) checkLatestConfigDetails() (
 			"contractConfig":     contractConfig,
 			"latestConfigDigest": latestConfigDigest,
 		})
-		return nil, false
+		return nil, false, false, false
 	}
 
 	// Ignore configs where the configDigest doesn't match, they might have
@@ -145,10 +211,10 @@ func (state *trackConfigState) checkLatestConfigDetails() (
 			"error":          err,
 			"contractConfig": contractConfig,
 		})
-		return nil, false
+		return nil, false, false, false
 	}
 
-	return &contractConfig, false
+	return &contractConfig, false, false, false
 }
 
 func TrackConfig(
@@ -161,6 +227,7 @@ func TrackConfig(
 	logger loghelper.LoggerWithContext,
 
 	chChanges chan<- types.ContractConfig,
+	chRemovals chan<- types.ConfigDigest,
 ) {
 	state := trackConfigState{
 		ctx,
@@ -171,9 +238,11 @@ func TrackConfig(
 		logger,
 		//out
 		chChanges,
+		chRemovals,
 		// local
 		subprocesses.Subprocesses{},
 		initialConfigDigest,
+		time.Time{},
 	}
 	state.run()
 }
@@ -109,6 +109,9 @@ func ReportingPluginLimits(mercuryPluginLimits ocr3types.MercuryPluginLimits) oc
 		ocr3MaxOutcomeLength(mercuryPluginLimits.MaxReportLength),
 		mercuryPluginLimits.MaxReportLength,
 		1,
+		0,
+		0,
+		0,
 	}
 }
 
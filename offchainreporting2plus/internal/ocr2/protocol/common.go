@@ -1,6 +1,12 @@
 package protocol
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
 
 type EpochRound struct {
 	Epoch uint32
@@ -12,3 +18,14 @@ func (x EpochRound) Less(y EpochRound) bool {
 }
 
 const ReportingPluginTimeoutWarningGracePeriod = 100 * time.Millisecond
+
+// pluginErrorLogFields returns structured details about err if it was caused
+// by the ReportingPlugin exceeding its limits, so that log-based metrics can
+// pick up the offending size.
+func pluginErrorLogFields(err error) commontypes.LogFields {
+	var limitExceededError *types.LimitExceededError
+	if errors.As(err, &limitExceededError) {
+		return limitExceededError.LogFields()
+	}
+	return nil
+}
@@ -298,7 +298,12 @@ func (pace *pacemakerState) restoreNeFromTransmitter() {
 		pace.ctx,
 		pace.localConfig.BlockchainTimeout,
 		func(ctx context.Context) {
-			configDigest, epoch, err = pace.contractTransmitter.LatestConfigDigestAndEpoch(ctx)
+			if t, ok := pace.contractTransmitter.(types.ConfigDigestEpochContractTransmitter); ok {
+				configDigest = pace.config.ConfigDigest
+				epoch, err = t.LatestEpochForConfigDigest(ctx, configDigest)
+			} else {
+				configDigest, epoch, err = pace.contractTransmitter.LatestConfigDigestAndEpoch(ctx)
+			}
 		},
 	)
 
@@ -143,10 +143,10 @@ func (repgen *reportGenerationState) messageObserveReq(msg MessageObserveReq, se
 		ins.Stop()
 
 		if err != nil {
-			repgen.logger.ErrorIfNotCanceled("ReportGeneration: ReportingPlugin.Observation errored", repgen.ctx, commontypes.LogFields{
+			repgen.logger.ErrorIfNotCanceled("ReportGeneration: ReportingPlugin.Observation errored", repgen.ctx, loghelper.MergePreserve(commontypes.LogFields{
 				"round": repgen.followerState.r,
 				"error": err,
-			})
+			}, pluginErrorLogFields(err)))
 			// failed to get data, nothing to be done
 			return
 		}
@@ -257,11 +257,11 @@ func (repgen *reportGenerationState) messageReportReq(msg MessageReportReq, send
 		ins.Stop()
 
 		if err != nil {
-			repgen.logger.Error("messageReportReq: error in ReportingPlugin.Report", commontypes.LogFields{
+			repgen.logger.Error("messageReportReq: error in ReportingPlugin.Report", loghelper.MergePreserve(commontypes.LogFields{
 				"round": repgen.followerState.r,
 				"error": err,
 				"id":    repgen.id,
-			})
+			}, pluginErrorLogFields(err)))
 			return
 		}
 	}
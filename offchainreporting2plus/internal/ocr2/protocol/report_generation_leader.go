@@ -98,10 +98,10 @@ func (repgen *reportGenerationState) startRound() {
 		ins.Stop()
 
 		if err != nil {
-			repgen.logger.Error("ReportGeneration: error while calling ReportingPlugin.Query. cannot start new round", commontypes.LogFields{
+			repgen.logger.Error("ReportGeneration: error while calling ReportingPlugin.Query. cannot start new round", loghelper.MergePreserve(commontypes.LogFields{
 				"round": repgen.leaderState.r,
 				"error": err,
-			})
+			}, pluginErrorLogFields(err)))
 			return
 		}
 
@@ -0,0 +1,152 @@
+package modelcheck
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/simnet"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+)
+
+type epochAndRun struct {
+	epoch uint64
+	run   int
+}
+
+// checker collects observations of the oracles' behaviour and checks them
+// against protocol invariants. All its functions are thread-safe.
+type checker struct {
+	mu sync.Mutex
+
+	committedOutcomes     map[uint64]ocr3types.Outcome
+	highestCommittedSeqNr uint64
+	leaders               map[uint64]commontypes.OracleID
+	highestEpoch          uint64
+	lastEpochAndRun       []epochAndRun
+
+	violation    error
+	chViolations chan error
+}
+
+func newChecker(n int) *checker {
+	return &checker{
+		committedOutcomes: map[uint64]ocr3types.Outcome{},
+		leaders:           map[uint64]commontypes.OracleID{},
+		lastEpochAndRun:   make([]epochAndRun, n),
+		chViolations:      make(chan error, 1),
+	}
+}
+
+func (c *checker) violations() <-chan error {
+	return c.chViolations
+}
+
+func (c *checker) firstViolation() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.violation
+}
+
+func (c *checker) violate(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.violateLocked(err)
+}
+
+func (c *checker) violateLocked(err error) {
+	if c.violation != nil {
+		return
+	}
+	c.violation = err
+	c.chViolations <- err
+}
+
+// committed is called whenever an oracle learns of a committed outcome.
+func (c *checker) committed(id commontypes.OracleID, seqNr uint64, outcome ocr3types.Outcome) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, ok := c.committedOutcomes[seqNr]; ok {
+		if !bytes.Equal(existing, outcome) {
+			c.violateLocked(fmt.Errorf("agreement violated: oracle %v committed outcome %x for seqNr %v, but %x was committed before", id, outcome, seqNr, existing))
+		}
+		return
+	}
+	c.committedOutcomes[seqNr] = outcome
+	if c.highestCommittedSeqNr < seqNr {
+		c.highestCommittedSeqNr = seqNr
+	}
+}
+
+// previousOutcome is called whenever an oracle hands an OutcomeContext to the
+// plugin.
+func (c *checker) previousOutcome(id commontypes.OracleID, outctx ocr3types.OutcomeContext) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if outctx.SeqNr <= 1 {
+		if outctx.PreviousOutcome != nil {
+			c.violateLocked(fmt.Errorf("chaining violated: oracle %v passed non-nil PreviousOutcome for seqNr %v", id, outctx.SeqNr))
+		}
+		return
+	}
+
+	if existing, ok := c.committedOutcomes[outctx.SeqNr-1]; ok && !bytes.Equal(existing, outctx.PreviousOutcome) {
+		c.violateLocked(fmt.Errorf("chaining violated: oracle %v passed PreviousOutcome %x for seqNr %v, but %x was committed for seqNr %v", id, outctx.PreviousOutcome, outctx.SeqNr, existing, outctx.SeqNr-1))
+	}
+}
+
+// roundStarted is called whenever an oracle starts a round.
+func (c *checker) roundStarted(id commontypes.OracleID, run int, epoch uint64, seqNr uint64, leader commontypes.OracleID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, ok := c.leaders[epoch]; ok && existing != leader {
+		c.violateLocked(fmt.Errorf("leader consistency violated: oracle %v thinks leader of epoch %v is %v, but it was %v before", id, epoch, leader, existing))
+		return
+	}
+	c.leaders[epoch] = leader
+
+	last := c.lastEpochAndRun[id]
+	if last.run == run && epoch < last.epoch {
+		c.violateLocked(fmt.Errorf("pacemaker monotonicity violated: oracle %v started round in epoch %v after starting round in epoch %v", id, epoch, last.epoch))
+		return
+	}
+	c.lastEpochAndRun[id] = epochAndRun{epoch, run}
+
+	if c.highestEpoch < epoch {
+		c.highestEpoch = epoch
+	}
+}
+
+// pacemakerStateWritten is called whenever an oracle persists its pacemaker
+// state.
+func (c *checker) pacemakerStateWritten(id commontypes.OracleID, old protocol.PacemakerState, new protocol.PacemakerState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if new.Epoch < old.Epoch || new.HighestSentNewEpochWish < old.HighestSentNewEpochWish {
+		c.violateLocked(fmt.Errorf("pacemaker monotonicity violated: oracle %v overwrote persisted state %+v with %+v", id, old, new))
+		return
+	}
+	if new.HighestSentNewEpochWish < new.Epoch {
+		c.violateLocked(fmt.Errorf("pacemaker invariant violated: oracle %v persisted state %+v with HighestSentNewEpochWish < Epoch", id, new))
+	}
+}
+
+func (c *checker) result(net *simnet.Network[struct{}], crashes int) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := net.Stats()
+	return Result{
+		c.highestCommittedSeqNr,
+		c.highestEpoch,
+		stats.Delivered,
+		stats.Dropped,
+		stats.Duplicated,
+		crashes,
+	}
+}
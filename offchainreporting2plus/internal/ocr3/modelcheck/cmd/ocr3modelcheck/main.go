@@ -0,0 +1,73 @@
+// Command ocr3modelcheck runs the OCR3 model-checking harness for a range of
+// seeds and exits with a non-zero status if any invariant is violated or
+// package permutation deviates from its test vectors.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/modelcheck"
+	"github.com/smartcontractkit/libocr/permutation"
+	"github.com/smartcontractkit/libocr/permutation/permutationvectors"
+)
+
+func main() {
+	firstSeed := flag.Int64("seed", 1, "first seed to run")
+	seeds := flag.Int("seeds", 10, "number of consecutive seeds to run")
+	n := flag.Int("n", 4, "number of oracles")
+	f := flag.Int("f", 1, "maximum number of faulty oracles")
+	duration := flag.Duration("duration", 10*time.Second, "duration of each run")
+	queryLess := flag.Bool("queryless", false, "run query-less rounds")
+	observationTimestamps := flag.Bool("observation-timestamps", false, "attach timestamps to observations")
+	observationSampling := flag.Bool("observation-sampling", false, "collect observations from a sample of oracles")
+	deltaGraceAutoTuning := flag.Bool("delta-grace-auto-tuning", false, "auto-tune DeltaGrace")
+	weightedLeaderSelection := flag.Bool("weighted-leader-selection", false, "weight leader selection")
+	adaptiveRoundPacing := flag.Bool("adaptive-round-pacing", false, "adapt DeltaRound")
+	stateSyncPartitions := flag.Bool("state-sync-partitions", false, "repeatedly cut off an oracle so that it has to catch up via state sync")
+	flag.Parse()
+
+	failed := false
+
+	// Transmission order and observation samples depend on permutations that
+	// all oracles must agree on, whatever their library version.
+	if err := permutationvectors.Check(permutationvectors.Vectors(), permutation.Permutation); err != nil {
+		failed = true
+		fmt.Printf("permutation vectors: FAIL: %v\n", err)
+	}
+
+	for seed := *firstSeed; seed < *firstSeed+int64(*seeds); seed++ {
+		params := modelcheck.DefaultParams(seed)
+		params.N = *n
+		params.F = *f
+		params.Duration = *duration
+		params.QueryLessRounds = *queryLess
+		params.ObservationTimestamps = *observationTimestamps
+		params.ObservationSampling = *observationSampling
+		params.DeltaGraceAutoTuning = *deltaGraceAutoTuning
+		params.WeightedLeaderSelection = *weightedLeaderSelection
+		params.AdaptiveRoundPacing = *adaptiveRoundPacing
+		params.StateSyncPartitions = *stateSyncPartitions
+		if params.StateSyncPartitions {
+			// With random drops on top of the partitions, few rounds complete
+			// and the cut-off oracle rarely falls far enough behind to need
+			// state sync.
+			params.DropProbability = 0
+		}
+
+		result, err := modelcheck.Run(context.Background(), params)
+		if err != nil {
+			failed = true
+			fmt.Printf("seed %v: FAIL: %v (%+v)\n", seed, err, result)
+			continue
+		}
+		fmt.Printf("seed %v: ok (%+v)\n", seed, result)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,73 @@
+// Command ocr3soak runs many OCR3 protocol instances with the synthetic plugin
+// in one process and reports their throughput and resource usage. It exits
+// with a non-zero status if an invariant is violated, an instance makes no
+// progress, or goroutines leak.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/modelcheck"
+)
+
+func main() {
+	defaults := modelcheck.DefaultSoakParams(1)
+
+	seed := flag.Int64("seed", defaults.Seed, "seed for the network schedules")
+	instances := flag.Int("instances", defaults.Instances, "number of protocol instances")
+	n := flag.Int("n", defaults.N, "number of oracles per instance")
+	f := flag.Int("f", defaults.F, "maximum number of faulty oracles per instance")
+	duration := flag.Duration("duration", defaults.Duration, "duration of the run")
+	deltaRound := flag.Duration("delta-round", defaults.DeltaRound, "minimum duration between rounds")
+	maxDelay := flag.Duration("max-delay", defaults.MaxDelay, "maximum message delay")
+	dropProbability := flag.Float64("drop-probability", defaults.DropProbability, "probability that a message is dropped")
+	observationLength := flag.Int("observation-length", defaults.Plugin.ObservationLength, "length of each observation in bytes")
+	outcomeLength := flag.Int("outcome-length", defaults.Plugin.OutcomeLength, "length of each outcome in bytes")
+	reportCount := flag.Int("report-count", defaults.Plugin.ReportCount, "number of reports per round")
+	reportLength := flag.Int("report-length", defaults.Plugin.ReportLength, "length of each report in bytes")
+	queryFailureProbability := flag.Float64("query-failure-probability", 0, "probability that Query fails")
+	observationFailureProbability := flag.Float64("observation-failure-probability", 0, "probability that Observation fails")
+	shouldAcceptFailureProbability := flag.Float64("should-accept-failure-probability", 0, "probability that ShouldAcceptAttestedReport fails")
+	shouldTransmitFailureProbability := flag.Float64("should-transmit-failure-probability", 0, "probability that ShouldTransmitAcceptedReport fails")
+	sampleInterval := flag.Duration("sample-interval", defaults.SampleInterval, "how often to sample resource usage")
+	flag.Parse()
+
+	params := modelcheck.SoakParams{
+		*instances,
+		*n,
+		*f,
+		*seed,
+		*duration,
+		*deltaRound,
+		*maxDelay,
+		*dropProbability,
+		defaults.Plugin,
+		*sampleInterval,
+		true,
+	}
+	params.Plugin.ObservationLength = *observationLength
+	params.Plugin.OutcomeLength = *outcomeLength
+	params.Plugin.ReportCount = *reportCount
+	params.Plugin.ReportLength = *reportLength
+	params.Plugin.QueryFailureProbability = *queryFailureProbability
+	params.Plugin.ObservationFailureProbability = *observationFailureProbability
+	params.Plugin.ShouldAcceptAttestedReportFailureProbability = *shouldAcceptFailureProbability
+	params.Plugin.ShouldTransmitAcceptedReportFailureProbability = *shouldTransmitFailureProbability
+
+	start := time.Now()
+	result, err := modelcheck.RunSoak(context.Background(), params)
+	fmt.Printf("ran for %v: %+v\n", time.Since(start).Round(time.Millisecond), result)
+	if err != nil {
+		fmt.Printf("FAIL: %v\n", err)
+		os.Exit(1)
+	}
+	if result.GoroutinesAfter > result.GoroutinesBefore {
+		fmt.Printf("FAIL: %v goroutines leaked\n", result.GoroutinesAfter-result.GoroutinesBefore)
+		os.Exit(1)
+	}
+	fmt.Println("ok")
+}
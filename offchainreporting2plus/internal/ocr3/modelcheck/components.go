@@ -0,0 +1,204 @@
+package modelcheck
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// checkingPlugin is a simple ReportingPlugin whose outcomes form a hash chain
+// over all observations. It reports every outcome it sees to the checker.
+type checkingPlugin struct {
+	id                    commontypes.OracleID
+	checker               *checker
+	observationTimestamps bool
+}
+
+var _ ocr3types.ReportingPluginV2[struct{}] = checkingPlugin{}
+
+func newCheckingPlugin(id commontypes.OracleID, checker *checker, observationTimestamps bool) checkingPlugin {
+	return checkingPlugin{id, checker, observationTimestamps}
+}
+
+func (p checkingPlugin) Query(ctx context.Context, outctx ocr3types.OutcomeContext) (types.Query, error) {
+	return binary.BigEndian.AppendUint64(nil, outctx.SeqNr), nil
+}
+
+func (p checkingPlugin) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
+	p.checker.previousOutcome(p.id, outctx)
+	if !outctx.ObservationsTimestamp.IsZero() {
+		return nil, fmt.Errorf("unexpected ObservationsTimestamp %v outside of Outcome", outctx.ObservationsTimestamp)
+	}
+	observation := binary.BigEndian.AppendUint64(nil, outctx.SeqNr)
+	observation = append(observation, byte(p.id))
+	return observation, nil
+}
+
+func (p checkingPlugin) ValidateObservation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, ao types.AttributedObservation) error {
+	if len(ao.Observation) != 9 {
+		return fmt.Errorf("observation has wrong length")
+	}
+	if binary.BigEndian.Uint64(ao.Observation) != outctx.SeqNr {
+		return fmt.Errorf("observation has wrong seqNr")
+	}
+	if commontypes.OracleID(ao.Observation[8]) != ao.Observer {
+		return fmt.Errorf("observation has wrong observer")
+	}
+	ts, ok := ocr3types.ObservationTimestampsFromContext(ctx, ao.Observer)
+	if p.observationTimestamps != ok || p.observationTimestamps == ts.ObservedAt.IsZero() || p.observationTimestamps == ts.ReceivedAt.IsZero() {
+		return fmt.Errorf("observation has unexpected timestamps ok=%v ObservedAt=%v ReceivedAt=%v", ok, ts.ObservedAt, ts.ReceivedAt)
+	}
+	if !outctx.ObservationsTimestamp.IsZero() {
+		return fmt.Errorf("unexpected ObservationsTimestamp %v outside of Outcome", outctx.ObservationsTimestamp)
+	}
+	return nil
+}
+
+func (p checkingPlugin) ObservationQuorum(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (ocr3types.Quorum, error) {
+	return ocr3types.QuorumTwoFPlusOne, nil
+}
+
+func (p checkingPlugin) Outcome(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, aos []types.AttributedObservation) (ocr3types.Outcome, error) {
+	p.checker.previousOutcome(p.id, outctx)
+	if p.observationTimestamps == outctx.ObservationsTimestamp.IsZero() {
+		return nil, fmt.Errorf("unexpected ObservationsTimestamp %v", outctx.ObservationsTimestamp)
+	}
+
+	sorted := append([]types.AttributedObservation{}, aos...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Observer < sorted[j].Observer })
+
+	h := sha256.New()
+	_, _ = h.Write(outctx.PreviousOutcome)
+	_, _ = h.Write(query)
+	for _, ao := range sorted {
+		_, _ = h.Write(ao.Observation)
+		// all honest oracles must agree on the timestamps, too
+		ts, ok := ocr3types.ObservationTimestampsFromContext(ctx, ao.Observer)
+		if p.observationTimestamps != ok {
+			return nil, fmt.Errorf("unexpected presence of timestamps for observation of %v: %v", ao.Observer, ok)
+		}
+		_ = binary.Write(h, binary.BigEndian, ts.ObservedAt.UnixNano())
+		_ = binary.Write(h, binary.BigEndian, ts.ReceivedAt.UnixNano())
+	}
+	_ = binary.Write(h, binary.BigEndian, outctx.ObservationsTimestamp.UnixNano())
+	return binary.BigEndian.AppendUint64(h.Sum(nil), outctx.SeqNr), nil
+}
+
+func (p checkingPlugin) Reports(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[struct{}], error) {
+	p.checker.committed(p.id, seqNr, outcome)
+	return []ocr3types.ReportWithInfo[struct{}]{{types.Report(outcome), struct{}{}}}, nil
+}
+
+func (p checkingPlugin) ShouldAcceptAttestedReport(context.Context, uint64, ocr3types.ReportWithInfo[struct{}]) (bool, error) {
+	return true, nil
+}
+
+func (p checkingPlugin) ShouldTransmitAcceptedReport(context.Context, uint64, ocr3types.ReportWithInfo[struct{}]) (bool, error) {
+	return true, nil
+}
+
+func (p checkingPlugin) Close() error {
+	return nil
+}
+
+type transmitter struct{}
+
+var _ ocr3types.ContractTransmitter[struct{}] = transmitter{}
+
+func (transmitter) Transmit(context.Context, types.ConfigDigest, uint64, ocr3types.ReportWithInfo[struct{}], []types.AttributedOnchainSignature) error {
+	return nil
+}
+
+func (transmitter) FromAccount() (types.Account, error) {
+	return "", nil
+}
+
+// memoryDatabase is an in-memory protocol.Database that survives simulated
+// crashes and reports every pacemaker state write to the checker.
+type memoryDatabase struct {
+	id      commontypes.OracleID
+	checker *checker
+
+	mu             sync.Mutex
+	contractConfig *types.ContractConfig
+	pacemakerState protocol.PacemakerState
+	cert           protocol.CertifiedPrepareOrCommit
+}
+
+var _ protocol.Database = (*memoryDatabase)(nil)
+
+func newMemoryDatabase(id commontypes.OracleID, checker *checker) *memoryDatabase {
+	return &memoryDatabase{id: id, checker: checker}
+}
+
+func (db *memoryDatabase) ReadConfig(ctx context.Context) (*types.ContractConfig, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.contractConfig, nil
+}
+
+func (db *memoryDatabase) WriteConfig(ctx context.Context, config types.ContractConfig) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.contractConfig = &config
+	return nil
+}
+
+func (db *memoryDatabase) ReadPacemakerState(ctx context.Context, configDigest types.ConfigDigest) (protocol.PacemakerState, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.pacemakerState, nil
+}
+
+func (db *memoryDatabase) WritePacemakerState(ctx context.Context, configDigest types.ConfigDigest, state protocol.PacemakerState) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.checker.pacemakerStateWritten(db.id, db.pacemakerState, state)
+	db.pacemakerState = state
+	return nil
+}
+
+func (db *memoryDatabase) ReadCert(ctx context.Context, configDigest types.ConfigDigest) (protocol.CertifiedPrepareOrCommit, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.cert, nil
+}
+
+func (db *memoryDatabase) WriteCert(ctx context.Context, configDigest types.ConfigDigest, cert protocol.CertifiedPrepareOrCommit) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.cert = cert
+	return nil
+}
+
+// QuarantineCorruptedState is never called, since the Read functions never
+// fail.
+func (db *memoryDatabase) QuarantineCorruptedState(ctx context.Context, configDigest types.ConfigDigest, corrupted *protocol.CorruptedStateError) error {
+	return nil
+}
+
+// checkingLogger discards all log messages except Critical ones, which it
+// reports to the checker as invariant violations.
+type checkingLogger struct {
+	checker *checker
+	id      commontypes.OracleID
+}
+
+var _ commontypes.Logger = checkingLogger{}
+
+func (checkingLogger) Trace(msg string, fields commontypes.LogFields) {}
+func (checkingLogger) Debug(msg string, fields commontypes.LogFields) {}
+func (checkingLogger) Info(msg string, fields commontypes.LogFields)  {}
+func (checkingLogger) Warn(msg string, fields commontypes.LogFields)  {}
+func (checkingLogger) Error(msg string, fields commontypes.LogFields) {}
+func (l checkingLogger) Critical(msg string, fields commontypes.LogFields) {
+	l.checker.violate(fmt.Errorf("oracle %v logged critical message %q: %v", l.id, msg, fields))
+}
@@ -0,0 +1,473 @@
+// Package modelcheck contains a harness for exploring executions of the OCR3
+// pacemaker and outcome generation protocols under adversarial message
+// scheduling and checking protocol invariants along the way.
+//
+// The harness runs n oracles in-process, connected by a simulated network that
+// delays, reorders, duplicates, and drops messages according to a seeded
+// pseudorandom schedule. Oracles are also crashed and restarted (with their
+// database intact) at pseudorandom points in time. Since the protocol itself
+// is driven by wall-clock timers and goroutine scheduling, a given seed does
+// not reproduce an execution exactly, but it does reproduce the adversary's
+// strategy. Running many seeds explores many different interleavings.
+//
+// The following invariants are checked continuously:
+//
+// - Agreement: at most one outcome is committed for each seqNr across all
+// oracles.
+//
+// - Chaining: the PreviousOutcome that a plugin sees for seqNr is the outcome
+// committed for seqNr-1.
+//
+// - Pacemaker monotonicity: an oracle's persisted pacemaker state never moves
+// backwards, and the epoch in which an oracle starts rounds never decreases
+// while the oracle is running.
+//
+// - Leader consistency: all oracles agree on the leader of a given epoch.
+//
+// - No assumption violations: the protocol never logs at Critical level.
+//
+// Use cmd/ocr3modelcheck to run the harness from CI.
+//
+// RunSoak runs many instances with the synthetic plugin from package
+// syntheticplugin instead, for capacity planning and leak detection. Use
+// cmd/ocr3soak to run it.
+package modelcheck
+
+import (
+	"context"
+	"crypto/ed25519"
+	cryptorand "crypto/rand"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/simnet"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+// Params configures a single run of the harness.
+type Params struct {
+	// Number of oracles and upper bound on the number of faulty oracles
+	N int
+	F int
+
+	// Seed for the adversarial schedule
+	Seed int64
+
+	// How long to run for
+	Duration time.Duration
+
+	// Every message is delayed by a uniformly random duration in
+	// [0, MaxDelay).
+	MaxDelay time.Duration
+	// Probability that a message is dropped
+	DropProbability float64
+	// Probability that a message is delivered twice
+	DuplicateProbability float64
+
+	// Average time between crashes of a (random) oracle. Zero disables
+	// crashes.
+	MeanTimeBetweenCrashes time.Duration
+	// How long a crashed oracle stays down before it is restarted
+	CrashDowntime time.Duration
+
+	// If set, Run returns an error if no outcome was committed at all.
+	RequireProgress bool
+
+	// If set, oracles run query-less rounds, see
+	// ocr3types.ProtocolFeatureFlagQueryLessRounds.
+	QueryLessRounds bool
+	// If set, observations carry timestamps, see
+	// ocr3types.ProtocolFeatureFlagObservationTimestamps.
+	ObservationTimestamps bool
+	// If set, observations are collected from a sample of oracles, see
+	// ocr3types.ProtocolFeatureFlagObservationSampling.
+	ObservationSampling bool
+
+	// If set, leaders auto-tune DeltaGrace, see
+	// ocr3config.PublicConfig.DeltaGraceMin.
+	DeltaGraceAutoTuning bool
+	// If set, leader selection is weighted, see
+	// ocr3config.PublicConfig.LeaderWeights.
+	WeightedLeaderSelection bool
+	// If set, oracles adapt the round interval, see
+	// ocr3config.PublicConfig.DeltaRoundMin.
+	AdaptiveRoundPacing bool
+
+	// If set, a random oracle is repeatedly cut off from all others for a
+	// while, so that it falls behind and has to catch up via state sync
+	// once it rejoins. Epochs are also kept short, so that it regularly
+	// catches up to a round close to RMax. No other oracle crashes while
+	// one is cut off.
+	StateSyncPartitions bool
+}
+
+func (params Params) simnetParams() simnet.Params {
+	return simnet.Params{
+		0,
+		params.MaxDelay,
+		params.DropProbability,
+		params.DuplicateProbability,
+	}
+}
+
+// DefaultParams returns parameters suitable for a quick run in CI.
+func DefaultParams(seed int64) Params {
+	return Params{
+		4,
+		1,
+		seed,
+		10 * time.Second,
+		20 * time.Millisecond,
+		0.05,
+		0.05,
+		3 * time.Second,
+		500 * time.Millisecond,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	}
+}
+
+// Result summarizes a run of the harness.
+type Result struct {
+	// Highest seqNr for which an outcome was committed
+	HighestCommittedSeqNr uint64
+	// Highest epoch in which any oracle started a round
+	HighestEpoch uint64
+	// Number of messages that were delivered, dropped, and duplicated by the
+	// simulated network
+	Delivered, Dropped, Duplicated uint64
+	// Number of simulated crashes
+	Crashes int
+}
+
+// Run runs the harness with the given parameters. It returns an error
+// describing the first invariant violation it encounters, if any.
+func Run(ctx context.Context, params Params) (Result, error) {
+	if !(0 < params.F && 3*params.F < params.N && params.N <= types.MaxOracles) {
+		return Result{}, fmt.Errorf("invalid parameters: n=%v f=%v", params.N, params.F)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, params.Duration)
+	defer cancel()
+
+	rng := rand.New(rand.NewSource(params.Seed))
+
+	checker := newChecker(params.N)
+	net := simnet.NewNetwork[struct{}](params.N, params.simnetParams(), rand.New(rand.NewSource(rng.Int63())))
+	defer net.Close()
+
+	sharedConfig, oracles, err := makeSharedConfigAndOracles(params, checker)
+	if err != nil {
+		return Result{}, err
+	}
+
+	localConfig := types.LocalConfig{
+		DatabaseTimeout:                    time.Second,
+		ContractTransmitterTransmitTimeout: time.Second,
+	}
+
+	var subs subprocesses.Subprocesses
+	defer subs.Wait()
+
+	crashes := 0
+	for i := range oracles {
+		i := i
+		subs.Go(func() {
+			oracles[i].runWithCrashes(
+				ctx,
+				sharedConfig,
+				localConfig,
+				net.Endpoint(commontypes.OracleID(i)),
+				checker,
+				newCheckingPlugin(commontypes.OracleID(i), checker, params.ObservationTimestamps),
+				transmitter{},
+			)
+		})
+	}
+
+	// oracle that is currently cut off from all others, if any
+	partitioned := -1
+	var tPartition <-chan time.Time
+	schedulePartition := func() {
+		if params.StateSyncPartitions {
+			tPartition = time.After(time.Duration((0.5 + rng.Float64()) * float64(stateSyncPartitionInterval)))
+		}
+	}
+	schedulePartition()
+
+	var tCrash <-chan time.Time
+	scheduleCrash := func() {
+		if params.MeanTimeBetweenCrashes > 0 {
+			tCrash = time.After(time.Duration(rng.ExpFloat64() * float64(params.MeanTimeBetweenCrashes)))
+		}
+	}
+	scheduleCrash()
+
+	for {
+		select {
+		case <-tCrash:
+			// Never crash more than f oracles at once, otherwise we're
+			// outside the fault model and can't expect progress.
+			victim := rng.Intn(params.N)
+			if oracles[victim].crash(params.CrashDowntime, partitioned < 0 && countCrashed(oracles) < params.F) {
+				crashes++
+			}
+			scheduleCrash()
+		case <-tPartition:
+			if partitioned >= 0 {
+				net.Heal()
+				partitioned = -1
+			} else if countCrashed(oracles) == 0 {
+				partitioned = rng.Intn(params.N)
+				var others []commontypes.OracleID
+				for i := 0; i < params.N; i++ {
+					if i != partitioned {
+						others = append(others, commontypes.OracleID(i))
+					}
+				}
+				net.Partition(others)
+			}
+			schedulePartition()
+		case err := <-checker.violations():
+			cancel()
+			subs.Wait()
+			return checker.result(net, crashes), err
+		case <-ctx.Done():
+			subs.Wait()
+			result := checker.result(net, crashes)
+			if err := checker.firstViolation(); err != nil {
+				return result, err
+			}
+			if params.RequireProgress && result.HighestCommittedSeqNr == 0 {
+				return result, fmt.Errorf("no progress: no outcome was committed during %v", params.Duration)
+			}
+			return result, nil
+		}
+	}
+}
+
+// Average time between an oracle being cut off and it rejoining, and between
+// it rejoining and the next oracle being cut off, if Params.StateSyncPartitions
+// is set. Long enough for the cut-off oracle to miss many rounds, but shorter
+// than DeltaProgress, so that it doesn't give up on the epoch.
+const stateSyncPartitionInterval = 1500 * time.Millisecond
+
+func countCrashed(oracles []*simulatedOracle) int {
+	count := 0
+	for _, o := range oracles {
+		if o.isCrashed() {
+			count++
+		}
+	}
+	return count
+}
+
+func makeSharedConfigAndOracles(params Params, checker *checker) (ocr3config.SharedConfig, []*simulatedOracle, error) {
+	var sharedSecret [config.SharedSecretSize]byte
+	if _, err := cryptorand.Read(sharedSecret[:]); err != nil {
+		return ocr3config.SharedConfig{}, nil, err
+	}
+
+	var configDigest types.ConfigDigest
+	if _, err := cryptorand.Read(configDigest[:]); err != nil {
+		return ocr3config.SharedConfig{}, nil, err
+	}
+
+	identities := make([]config.OracleIdentity, 0, params.N)
+	oracles := make([]*simulatedOracle, 0, params.N)
+	for i := 0; i < params.N; i++ {
+		_, offchainPrivateKey, err := ed25519.GenerateKey(cryptorand.Reader)
+		if err != nil {
+			return ocr3config.SharedConfig{}, nil, err
+		}
+		_, onchainPrivateKey, err := ed25519.GenerateKey(cryptorand.Reader)
+		if err != nil {
+			return ocr3config.SharedConfig{}, nil, err
+		}
+
+		offchainKeyring := simnet.OffchainKeyring{offchainPrivateKey}
+		onchainKeyring := simnet.OnchainKeyring[struct{}]{onchainPrivateKey}
+
+		identities = append(identities, config.OracleIdentity{
+			offchainKeyring.OffchainPublicKey(),
+			onchainKeyring.PublicKey(),
+			fmt.Sprintf("oracle-%d", i),
+			types.Account(fmt.Sprintf("account-%d", i)),
+			types.OffchainPublicKey{},
+		})
+
+		oracles = append(oracles, newSimulatedOracle(
+			commontypes.OracleID(i),
+			offchainKeyring,
+			onchainKeyring,
+			newMemoryDatabase(commontypes.OracleID(i), checker),
+			loghelper.MakeRootLoggerWithContext(checkingLogger{checker, commontypes.OracleID(i)}),
+		))
+	}
+
+	s := make([]int, params.N)
+	for i := range s {
+		s[i] = 1
+	}
+
+	var featureFlags ocr3types.FeatureFlags
+	if params.QueryLessRounds {
+		featureFlags |= ocr3types.ProtocolFeatureFlagQueryLessRounds
+	}
+	if params.ObservationTimestamps {
+		featureFlags |= ocr3types.ProtocolFeatureFlagObservationTimestamps
+	}
+	if params.ObservationSampling {
+		featureFlags |= ocr3types.ProtocolFeatureFlagObservationSampling
+	}
+
+	var deltaGraceMin, deltaGraceMax time.Duration
+	if params.DeltaGraceAutoTuning {
+		deltaGraceMin, deltaGraceMax = 1*time.Millisecond, 50*time.Millisecond
+	}
+
+	var leaderWeights []int
+	if params.WeightedLeaderSelection {
+		// oracle 0 never leads, oracle i leads i times per span
+		for i := 0; i < params.N; i++ {
+			leaderWeights = append(leaderWeights, i)
+		}
+	}
+
+	rMax := uint64(20)
+	if params.StateSyncPartitions {
+		rMax = 8
+	}
+
+	var deltaRoundMin, deltaRoundMax time.Duration
+	if params.AdaptiveRoundPacing {
+		deltaRoundMin, deltaRoundMax = 10*time.Millisecond, 200*time.Millisecond
+	}
+
+	return ocr3config.SharedConfig{
+		ocr3config.PublicConfig{
+			2 * time.Second,        // DeltaProgress
+			200 * time.Millisecond, // DeltaResend
+			1 * time.Second,        // DeltaInitial
+			50 * time.Millisecond,  // DeltaRound
+			10 * time.Millisecond,  // DeltaGrace
+			100 * time.Millisecond, // DeltaCertifiedCommitRequest
+			50 * time.Millisecond,  // DeltaStage
+			rMax,                   // RMax
+			s,
+			identities,
+			nil,                    // ReportingPluginConfig
+			100 * time.Millisecond, // MaxDurationQuery
+			100 * time.Millisecond, // MaxDurationObservation
+			100 * time.Millisecond, // MaxDurationShouldAcceptAttestedReport
+			100 * time.Millisecond, // MaxDurationShouldTransmitAcceptedReport
+			featureFlags,
+			deltaGraceMin,
+			deltaGraceMax,
+			leaderWeights,
+			deltaRoundMin,
+			deltaRoundMax,
+			params.F,
+			nil, // OnchainConfig
+			configDigest,
+		},
+		&sharedSecret,
+	}, oracles, nil
+}
+
+var _ protocol.TelemetrySender = telemetrySender{}
+
+type telemetrySender struct {
+	checker *checker
+	id      commontypes.OracleID
+	run     int
+}
+
+func (t telemetrySender) RoundStarted(
+	_ types.ConfigDigest,
+	epoch uint64,
+	seqNr uint64,
+	_ uint64,
+	leader commontypes.OracleID,
+) {
+	t.checker.roundStarted(t.id, t.run, epoch, seqNr, leader)
+}
+
+func (t telemetrySender) ProtocolError(
+	types.ConfigDigest,
+	uint64,
+	uint64,
+	protocol.ProtocolErrorCode,
+) {
+}
+
+func (t telemetrySender) OutcomeComputed(
+	types.ConfigDigest,
+	uint64,
+	uint64,
+	uint64,
+	commontypes.OracleID,
+	[]commontypes.OracleID,
+	[]commontypes.OracleID,
+	protocol.OutcomeDigest,
+) {
+}
+
+func (t telemetrySender) OutcomeCommitted(
+	types.ConfigDigest,
+	uint64,
+	uint64,
+	protocol.OutcomeDigest,
+) {
+}
+
+func (t telemetrySender) ReportAttested(
+	types.ConfigDigest,
+	uint64,
+	int,
+	[]commontypes.OracleID,
+) {
+}
+
+func (t telemetrySender) TransmissionDecided(
+	types.ConfigDigest,
+	uint64,
+	int,
+	protocol.TransmissionDecision,
+) {
+}
+
+func (t telemetrySender) DegradedModeChanged(
+	types.ConfigDigest,
+	bool,
+	int,
+) {
+}
+
+func (t telemetrySender) CorruptedStateQuarantined(
+	types.ConfigDigest,
+	string,
+) {
+}
+
+func (t telemetrySender) TimerExpired(
+	types.ConfigDigest,
+	uint64,
+	uint64,
+	protocol.Timer,
+) {
+}
@@ -0,0 +1,143 @@
+package modelcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/simnet"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+// simulatedOracle runs protocol.RunOracle for a single oracle and restarts it
+// after simulated crashes.
+type simulatedOracle struct {
+	id              commontypes.OracleID
+	offchainKeyring simnet.OffchainKeyring
+	onchainKeyring  simnet.OnchainKeyring[struct{}]
+	database        *memoryDatabase
+	logger          loghelper.LoggerWithContext
+
+	chCrash chan time.Duration
+
+	crashedMu sync.Mutex
+	crashed   bool
+}
+
+func newSimulatedOracle(
+	id commontypes.OracleID,
+	offchainKeyring simnet.OffchainKeyring,
+	onchainKeyring simnet.OnchainKeyring[struct{}],
+	database *memoryDatabase,
+	logger loghelper.LoggerWithContext,
+) *simulatedOracle {
+	return &simulatedOracle{
+		id,
+		offchainKeyring,
+		onchainKeyring,
+		database,
+		logger.MakeChild(commontypes.LogFields{"oid": id}),
+
+		make(chan time.Duration, 1),
+
+		sync.Mutex{},
+		false,
+	}
+}
+
+func (o *simulatedOracle) isCrashed() bool {
+	o.crashedMu.Lock()
+	defer o.crashedMu.Unlock()
+	return o.crashed
+}
+
+// crash requests that the oracle be crashed for downtime. Returns false if the
+// crash wasn't allowed or the oracle is already crashed.
+func (o *simulatedOracle) crash(downtime time.Duration, allowed bool) bool {
+	o.crashedMu.Lock()
+	defer o.crashedMu.Unlock()
+	if !allowed || o.crashed {
+		return false
+	}
+	select {
+	case o.chCrash <- downtime:
+		o.crashed = true
+		return true
+	default:
+		return false
+	}
+}
+
+func (o *simulatedOracle) runWithCrashes(
+	ctx context.Context,
+	sharedConfig ocr3config.SharedConfig,
+	localConfig types.LocalConfig,
+	endpoint *simnet.Endpoint[struct{}],
+	checker *checker,
+	reportingPlugin ocr3types.ReportingPluginV2[struct{}],
+	contractTransmitter ocr3types.ContractTransmitter[struct{}],
+) {
+	for run := 0; ; run++ {
+		endpoint.Drain()
+
+		runCtx, runCancel := context.WithCancel(ctx)
+		var subs subprocesses.Subprocesses
+		subs.Go(func() {
+			protocol.RunOracle[struct{}](
+				runCtx,
+				nil,
+				nil,
+				sharedConfig,
+				contractTransmitter,
+				o.database,
+				o.id,
+				nil,
+				nil,
+				nil,
+				localConfig,
+				o.logger.MakeChild(commontypes.LogFields{"run": run}),
+				protocol.NewMetrics(nil, o.logger),
+				endpoint,
+				o.offchainKeyring,
+				o.onchainKeyring,
+				reportingPlugin,
+				nil,
+				telemetrySender{checker, o.id, run},
+				nil,
+				nil,
+				nil,
+			)
+		})
+
+		var downtime time.Duration
+		select {
+		case downtime = <-o.chCrash:
+			o.logger.Info("modelcheck: crashing oracle", commontypes.LogFields{
+				"downtime": downtime.String(),
+			})
+		case <-ctx.Done():
+		}
+		runCancel()
+		subs.Wait()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case <-time.After(downtime):
+		case <-ctx.Done():
+			return
+		}
+
+		o.crashedMu.Lock()
+		o.crashed = false
+		o.crashedMu.Unlock()
+	}
+}
@@ -0,0 +1,322 @@
+package modelcheck
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/simnet"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/syntheticplugin"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+// SoakParams configures a soak run: many protocol instances, each consisting of
+// N oracles running the synthetic plugin, all in one process. Unlike Run, a
+// soak run uses a benign network and doesn't crash oracles by default; its
+// purpose is to measure resource usage under load and to detect leaks rather
+// than to explore adversarial schedules. The invariants listed in the package
+// documentation are still checked, except for Agreement and Chaining which
+// rely on the checking plugin.
+type SoakParams struct {
+	// Number of protocol instances to run concurrently
+	Instances int
+
+	// Number of oracles per instance and upper bound on the number of faulty
+	// oracles
+	N int
+	F int
+
+	// Seed for the network schedules
+	Seed int64
+
+	// How long to run for
+	Duration time.Duration
+
+	// Minimum duration between rounds of each instance
+	DeltaRound time.Duration
+
+	// See Params. Zero messes with nothing.
+	MaxDelay        time.Duration
+	DropProbability float64
+
+	// Sizes of the synthetic plugin's outputs and rates of its failures
+	Plugin syntheticplugin.Config
+
+	// How often to sample resource usage
+	SampleInterval time.Duration
+
+	// If set, RunSoak returns an error if some instance didn't transmit any
+	// report.
+	RequireProgress bool
+}
+
+// DefaultSoakParams returns parameters for 100 instances of 4 oracles each.
+func DefaultSoakParams(seed int64) SoakParams {
+	return SoakParams{
+		100,
+		4,
+		1,
+		seed,
+		time.Minute,
+		500 * time.Millisecond,
+		0,
+		0,
+		syntheticplugin.DefaultConfig(),
+		time.Second,
+		true,
+	}
+}
+
+// SoakResult summarizes a soak run.
+type SoakResult struct {
+	Instances int
+	// Number of calls to ContractTransmitter.Transmit across all instances
+	Transmissions uint64
+	// Lowest and highest seqNr transmitted by any oracle, taken over all
+	// instances. A MinHighestTransmittedSeqNr far below
+	// MaxHighestTransmittedSeqNr means that some instances were starved.
+	MinHighestTransmittedSeqNr uint64
+	MaxHighestTransmittedSeqNr uint64
+	// Highest epoch in which any oracle started a round
+	HighestEpoch uint64
+	// Number of messages that were delivered and dropped by the simulated
+	// networks, taken over all instances
+	Delivered, Dropped uint64
+
+	// Number of goroutines before any instance was started, at the peak, and
+	// after all instances had shut down. GoroutinesAfter exceeding
+	// GoroutinesBefore indicates a goroutine leak.
+	GoroutinesBefore, GoroutinesPeak, GoroutinesAfter int
+	// Heap usage at the peak and after all instances had shut down (and a
+	// garbage collection)
+	HeapAllocPeak, HeapAllocAfter uint64
+}
+
+// countingTransmitter counts calls to Transmit and records the highest
+// transmitted seqNr.
+type countingTransmitter struct {
+	transmissions     atomic.Uint64
+	highestSeqNrMutex sync.Mutex
+	highestSeqNr      uint64
+}
+
+var _ ocr3types.ContractTransmitter[struct{}] = (*countingTransmitter)(nil)
+
+func (t *countingTransmitter) Transmit(_ context.Context, _ types.ConfigDigest, seqNr uint64, _ ocr3types.ReportWithInfo[struct{}], _ []types.AttributedOnchainSignature) error {
+	t.transmissions.Add(1)
+	t.highestSeqNrMutex.Lock()
+	defer t.highestSeqNrMutex.Unlock()
+	if t.highestSeqNr < seqNr {
+		t.highestSeqNr = seqNr
+	}
+	return nil
+}
+
+func (t *countingTransmitter) FromAccount() (types.Account, error) {
+	return "", nil
+}
+
+func (t *countingTransmitter) highestTransmittedSeqNr() uint64 {
+	t.highestSeqNrMutex.Lock()
+	defer t.highestSeqNrMutex.Unlock()
+	return t.highestSeqNr
+}
+
+type soakInstance struct {
+	checker     *checker
+	net         *simnet.Network[struct{}]
+	transmitter *countingTransmitter
+}
+
+// RunSoak runs the soak harness with the given parameters. It returns an error
+// describing the first invariant violation it encounters, if any.
+func RunSoak(ctx context.Context, params SoakParams) (SoakResult, error) {
+	if !(0 < params.Instances) {
+		return SoakResult{}, fmt.Errorf("invalid parameters: instances=%v", params.Instances)
+	}
+	if !(0 < params.F && 3*params.F < params.N && params.N <= types.MaxOracles) {
+		return SoakResult{}, fmt.Errorf("invalid parameters: n=%v f=%v", params.N, params.F)
+	}
+	if !(0 < params.SampleInterval) {
+		return SoakResult{}, fmt.Errorf("invalid parameters: sampleInterval=%v", params.SampleInterval)
+	}
+	if err := params.Plugin.Validate(); err != nil {
+		return SoakResult{}, fmt.Errorf("invalid plugin parameters: %w", err)
+	}
+
+	result := SoakResult{Instances: params.Instances}
+	result.GoroutinesBefore = runtime.NumGoroutine()
+
+	ctx, cancel := context.WithTimeout(ctx, params.Duration)
+	defer cancel()
+
+	rng := rand.New(rand.NewSource(params.Seed))
+
+	localConfig := types.LocalConfig{
+		DatabaseTimeout:                    time.Second,
+		ContractTransmitterTransmitTimeout: time.Second,
+	}
+
+	var subs subprocesses.Subprocesses
+	instances := make([]soakInstance, 0, params.Instances)
+	closeInstances := func() {
+		cancel()
+		subs.Wait()
+		for _, instance := range instances {
+			instance.net.Close()
+		}
+	}
+
+	for k := 0; k < params.Instances; k++ {
+		networkParams := Params{
+			params.N,
+			params.F,
+			rng.Int63(),
+			params.Duration,
+			params.MaxDelay,
+			params.DropProbability,
+			0,
+			0,
+			0,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+		}
+
+		checker := newChecker(params.N)
+		net := simnet.NewNetwork[struct{}](networkParams.N, networkParams.simnetParams(), rand.New(rand.NewSource(networkParams.Seed)))
+		transmitter := &countingTransmitter{}
+		instances = append(instances, soakInstance{checker, net, transmitter})
+
+		sharedConfig, oracles, err := makeSharedConfigAndOracles(networkParams, checker)
+		if err != nil {
+			closeInstances()
+			return result, err
+		}
+		sharedConfig.DeltaRound = params.DeltaRound
+
+		for i := range oracles {
+			plugin, _, err := syntheticplugin.Factory{params.Plugin}.NewReportingPlugin(ocr3types.ReportingPluginConfig{
+				ConfigDigest:                            sharedConfig.ConfigDigest,
+				OracleID:                                commontypes.OracleID(i),
+				N:                                       sharedConfig.N(),
+				F:                                       sharedConfig.F,
+				EstimatedRoundInterval:                  sharedConfig.DeltaRound,
+				MaxDurationQuery:                        sharedConfig.MaxDurationQuery,
+				MaxDurationObservation:                  sharedConfig.MaxDurationObservation,
+				MaxDurationShouldAcceptAttestedReport:   sharedConfig.MaxDurationShouldAcceptAttestedReport,
+				MaxDurationShouldTransmitAcceptedReport: sharedConfig.MaxDurationShouldTransmitAcceptedReport,
+			})
+			if err != nil {
+				closeInstances()
+				return result, err
+			}
+
+			o := oracles[i]
+			endpoint := net.Endpoint(commontypes.OracleID(i))
+			subs.Go(func() {
+				o.runWithCrashes(
+					ctx,
+					sharedConfig,
+					localConfig,
+					endpoint,
+					checker,
+					ocr3types.NewReportingPluginV2FromV1(plugin),
+					transmitter,
+				)
+			})
+		}
+	}
+
+	sample := func() {
+		if goroutines := runtime.NumGoroutine(); result.GoroutinesPeak < goroutines {
+			result.GoroutinesPeak = goroutines
+		}
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+		if result.HeapAllocPeak < memStats.HeapAlloc {
+			result.HeapAllocPeak = memStats.HeapAlloc
+		}
+	}
+
+	violations := make(chan error, 1)
+	for _, instance := range instances {
+		instance := instance
+		subs.Go(func() {
+			select {
+			case err := <-instance.checker.violations():
+				select {
+				case violations <- err:
+				default:
+				}
+			case <-ctx.Done():
+			}
+		})
+	}
+
+	tSample := time.NewTicker(params.SampleInterval)
+	defer tSample.Stop()
+
+	var violation error
+loop:
+	for {
+		select {
+		case <-tSample.C:
+			sample()
+		case violation = <-violations:
+			break loop
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	sample()
+	closeInstances()
+
+	for k, instance := range instances {
+		r := instance.checker.result(instance.net, 0)
+		if result.HighestEpoch < r.HighestEpoch {
+			result.HighestEpoch = r.HighestEpoch
+		}
+		result.Delivered += r.Delivered
+		result.Dropped += r.Dropped
+
+		result.Transmissions += instance.transmitter.transmissions.Load()
+		highest := instance.transmitter.highestTransmittedSeqNr()
+		if k == 0 || highest < result.MinHighestTransmittedSeqNr {
+			result.MinHighestTransmittedSeqNr = highest
+		}
+		if result.MaxHighestTransmittedSeqNr < highest {
+			result.MaxHighestTransmittedSeqNr = highest
+		}
+
+		if violation == nil {
+			violation = instance.checker.firstViolation()
+		}
+	}
+
+	runtime.GC()
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	result.HeapAllocAfter = memStats.HeapAlloc
+	result.GoroutinesAfter = runtime.NumGoroutine()
+
+	if violation != nil {
+		return result, violation
+	}
+	if params.RequireProgress && result.MinHighestTransmittedSeqNr == 0 {
+		return result, fmt.Errorf("no progress: some instance didn't transmit any report during %v", params.Duration)
+	}
+	return result, nil
+}
@@ -8,4 +8,7 @@ import (
 type AttestedReportMany[RI any] struct {
 	ReportWithInfo       ocr3types.ReportWithInfo[RI]
 	AttributedSignatures []types.AttributedOnchainSignature
+	// nil unless aggregate attestation is enabled, see
+	// ocr3types.ProtocolFeatureFlagAggregateAttestation
+	AggregateSignature *ocr3types.AggregateSignature
 }
@@ -0,0 +1,490 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol/ringbuffer"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+	"golang.org/x/time/rate"
+)
+
+// How often BlobExchange resends blobs to oracles that haven't confirmed
+// storing them yet, and how long it waits for a response to a blob request
+// before asking the next oracle
+const blobRetryInterval = time.Second
+
+// Maximum number of non-blob messages BlobExchange buffers while the oracle
+// is busy. Beyond that, the oldest messages are dropped, as if they had been
+// lost by the network.
+const blobForwardBufferSize = 100
+
+// BlobExchange implements ocr3types.BlobBroadcastFetcher. It wraps the
+// NetworkEndpoint that the oracle uses and handles the messages of the blob
+// exchange protocol itself, passing all other messages on to the oracle.
+// Intercepting the messages before they reach the oracle matters: the plugin
+// calls BroadcastBlob and FetchBlob from within the oracle's subprocesses,
+// which would otherwise deadlock waiting for messages that they themselves
+// hold up.
+//
+// All protocol state is owned by the run goroutine.
+type BlobExchange[RI any] struct {
+	endpoint NetworkEndpoint[RI]
+	config   ocr3config.SharedConfig
+	limits   ocr3types.ReportingPluginLimits
+	id       commontypes.OracleID
+	logger   loghelper.LoggerWithContext
+
+	broadcastLimiter *rate.Limiter
+	chBroadcast      chan blobBroadcastRequest
+	chFetch          chan blobFetchRequest
+
+	mutex        sync.Mutex
+	subprocesses subprocesses.Subprocesses
+	started      bool
+	closed       bool
+	closedChOut  bool
+	chCancel     chan struct{}
+	chOut        chan MessageWithSender[RI]
+
+	// run goroutine state
+	stored           map[ocr3types.BlobDigest]*storedBlob
+	storedBy         [][]ocr3types.BlobDigest // FIFO of digests per oracle
+	broadcasts       map[ocr3types.BlobDigest]*pendingBlobBroadcast
+	fetches          map[ocr3types.BlobDigest]*pendingBlobFetch
+	responseLimiters []*rate.Limiter
+}
+
+var _ NetworkEndpoint[struct{}] = (*BlobExchange[struct{}])(nil)
+var _ ocr3types.BlobBroadcastFetcher = (*BlobExchange[struct{}])(nil)
+
+type storedBlob struct {
+	payload []byte
+	// number of oracles whose FIFO in storedBy contains the blob
+	refs int
+}
+
+type blobBroadcastRequest struct {
+	ctx       context.Context
+	payload   []byte
+	chSuccess chan<- ocr3types.BlobDigest
+}
+
+type blobFetchRequest struct {
+	ctx       context.Context
+	digest    ocr3types.BlobDigest
+	chSuccess chan<- []byte
+}
+
+type pendingBlobBroadcast struct {
+	payload []byte
+	stored  []bool
+	waiters []blobBroadcastRequest
+}
+
+type pendingBlobFetch struct {
+	// oracle that we asked most recently
+	asked   commontypes.OracleID
+	waiters []blobFetchRequest
+}
+
+func NewBlobExchange[RI any](
+	endpoint NetworkEndpoint[RI],
+	config ocr3config.SharedConfig,
+	limits ocr3types.ReportingPluginLimits,
+	id commontypes.OracleID,
+	logger loghelper.LoggerWithContext,
+) *BlobExchange[RI] {
+	n := config.N()
+
+	// An oracle may request each of the blobs broadcast by any oracle from us
+	responseLimiters := make([]*rate.Limiter, 0, n)
+	for i := 0; i < n; i++ {
+		responseLimiters = append(responseLimiters, rate.NewLimiter(blobRate(config, n*limits.MaxBlobsPerRound), n*limits.MaxBlobsPerRound))
+	}
+
+	return &BlobExchange[RI]{
+		endpoint,
+		config,
+		limits,
+		id,
+		logger.MakeChild(commontypes.LogFields{"proto": "blobexchange"}),
+
+		rate.NewLimiter(blobRate(config, limits.MaxBlobsPerRound), limits.MaxBlobsPerRound),
+		make(chan blobBroadcastRequest),
+		make(chan blobFetchRequest),
+
+		sync.Mutex{},
+		subprocesses.Subprocesses{},
+		false,
+		false,
+		false,
+		make(chan struct{}),
+		make(chan MessageWithSender[RI]),
+
+		map[ocr3types.BlobDigest]*storedBlob{},
+		make([][]ocr3types.BlobDigest, n),
+		map[ocr3types.BlobDigest]*pendingBlobBroadcast{},
+		map[ocr3types.BlobDigest]*pendingBlobFetch{},
+		responseLimiters,
+	}
+}
+
+// blobRate returns the rate corresponding to count blobs per round, assuming
+// the shortest round interval allowed by the config.
+func blobRate(config ocr3config.SharedConfig, count int) rate.Limit {
+	deltaRound := config.MinDeltaRound()
+	if deltaRound == 0 {
+		return rate.Inf
+	}
+	return rate.Limit(float64(count) * float64(time.Second) / float64(deltaRound))
+}
+
+// Start starts the BlobExchange. It will also start the underlying endpoint.
+func (bex *BlobExchange[RI]) Start() error {
+	bex.mutex.Lock()
+	defer bex.mutex.Unlock()
+
+	if bex.started {
+		return fmt.Errorf("cannot start already started BlobExchange")
+	}
+	bex.started = true
+
+	if err := bex.endpoint.Start(); err != nil {
+		return fmt.Errorf("error while starting BlobExchange: %w", err)
+	}
+
+	bex.subprocesses.Go(func() {
+		bex.run()
+	})
+
+	return nil
+}
+
+// Close closes the BlobExchange. It will also close the underlying endpoint.
+func (bex *BlobExchange[RI]) Close() error {
+	bex.mutex.Lock()
+	defer bex.mutex.Unlock()
+
+	if bex.started && !bex.closed {
+		bex.closed = true
+		close(bex.chCancel)
+		bex.subprocesses.Wait()
+
+		if !bex.closedChOut {
+			bex.closedChOut = true
+			close(bex.chOut)
+		}
+
+		return bex.endpoint.Close()
+	}
+
+	return nil
+}
+
+func (bex *BlobExchange[RI]) SendTo(msg Message[RI], to commontypes.OracleID) {
+	bex.endpoint.SendTo(msg, to)
+}
+
+func (bex *BlobExchange[RI]) Broadcast(msg Message[RI]) {
+	bex.endpoint.Broadcast(msg)
+}
+
+func (bex *BlobExchange[RI]) Multicast(msg Message[RI], to []commontypes.OracleID) {
+	bex.endpoint.Multicast(msg, to)
+}
+
+func (bex *BlobExchange[RI]) Receive() <-chan MessageWithSender[RI] {
+	return bex.chOut
+}
+
+func (bex *BlobExchange[RI]) BroadcastBlob(ctx context.Context, payload []byte) (ocr3types.BlobDigest, error) {
+	if !(0 < len(payload) && len(payload) <= bex.limits.MaxBlobLength) {
+		return ocr3types.BlobDigest{}, fmt.Errorf("blob has length %v, should be between 1 and %v", len(payload), bex.limits.MaxBlobLength)
+	}
+	if err := bex.broadcastLimiter.Wait(ctx); err != nil {
+		return ocr3types.BlobDigest{}, fmt.Errorf("error while waiting for blob rate limit: %w", err)
+	}
+
+	chSuccess := make(chan ocr3types.BlobDigest, 1)
+	select {
+	case bex.chBroadcast <- blobBroadcastRequest{ctx, payload, chSuccess}:
+	case <-ctx.Done():
+		return ocr3types.BlobDigest{}, ctx.Err()
+	case <-bex.chCancel:
+		return ocr3types.BlobDigest{}, fmt.Errorf("BlobExchange is closed")
+	}
+
+	select {
+	case digest := <-chSuccess:
+		return digest, nil
+	case <-ctx.Done():
+		return ocr3types.BlobDigest{}, fmt.Errorf("blob not stored by a quorum of oracles: %w", ctx.Err())
+	case <-bex.chCancel:
+		return ocr3types.BlobDigest{}, fmt.Errorf("BlobExchange is closed")
+	}
+}
+
+func (bex *BlobExchange[RI]) FetchBlob(ctx context.Context, digest ocr3types.BlobDigest) ([]byte, error) {
+	chSuccess := make(chan []byte, 1)
+	select {
+	case bex.chFetch <- blobFetchRequest{ctx, digest, chSuccess}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-bex.chCancel:
+		return nil, fmt.Errorf("BlobExchange is closed")
+	}
+
+	select {
+	case payload := <-chSuccess:
+		return payload, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("could not fetch blob %v: %w", digest, ctx.Err())
+	case <-bex.chCancel:
+		return nil, fmt.Errorf("BlobExchange is closed")
+	}
+}
+
+func (bex *BlobExchange[RI]) run() {
+	chIn := bex.endpoint.Receive()
+	forwardBuffer := ringbuffer.NewRingBuffer[MessageWithSender[RI]](blobForwardBufferSize)
+
+	ticker := time.NewTicker(blobRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		// Only try to pass a message on to the oracle if we have one
+		var chOut chan<- MessageWithSender[RI]
+		var out MessageWithSender[RI]
+		if forwardBuffer.Length() > 0 {
+			chOut = bex.chOut
+			out = forwardBuffer.Peek()
+		}
+
+		select {
+		case msg, ok := <-chIn:
+			if !ok {
+				bex.mutex.Lock()
+				defer bex.mutex.Unlock()
+				bex.closedChOut = true
+				close(bex.chOut)
+				return
+			}
+			if 0 <= int(msg.Sender) && int(msg.Sender) < bex.config.N() {
+				if blobMsg, ok := msg.Msg.(MessageToBlobExchange[RI]); ok {
+					blobMsg.processBlobExchange(bex, msg.Sender)
+					break
+				}
+			}
+			forwardBuffer.Push(msg)
+		case chOut <- out:
+			forwardBuffer.Pop()
+		case req := <-bex.chBroadcast:
+			bex.broadcast(req)
+		case req := <-bex.chFetch:
+			bex.fetch(req)
+		case <-ticker.C:
+			bex.retry()
+		case <-bex.chCancel:
+		}
+
+		// ensure prompt exit
+		select {
+		case <-bex.chCancel:
+			return
+		default:
+		}
+	}
+}
+
+// store adds payload to the blobs stored on behalf of oracle, evicting the
+// oldest such blob if oracle has exhausted its quota.
+func (bex *BlobExchange[RI]) store(oracle commontypes.OracleID, digest ocr3types.BlobDigest, payload []byte) {
+	for _, d := range bex.storedBy[oracle] {
+		if d == digest {
+			return
+		}
+	}
+
+	if len(bex.storedBy[oracle]) == ocr3types.BlobRetentionRounds*bex.limits.MaxBlobsPerRound {
+		evicted := bex.storedBy[oracle][0]
+		bex.storedBy[oracle] = bex.storedBy[oracle][1:]
+		if blob := bex.stored[evicted]; blob.refs == 1 {
+			delete(bex.stored, evicted)
+		} else {
+			blob.refs--
+		}
+	}
+
+	bex.storedBy[oracle] = append(bex.storedBy[oracle], digest)
+	if blob, ok := bex.stored[digest]; ok {
+		blob.refs++
+	} else {
+		bex.stored[digest] = &storedBlob{payload, 1}
+	}
+}
+
+func (bex *BlobExchange[RI]) broadcast(req blobBroadcastRequest) {
+	digest := ocr3types.MakeBlobDigest(req.payload)
+	bex.store(bex.id, digest, req.payload)
+
+	if pending, ok := bex.broadcasts[digest]; ok {
+		pending.waiters = append(pending.waiters, req)
+		return
+	}
+
+	stored := make([]bool, bex.config.N())
+	stored[bex.id] = true
+	bex.broadcasts[digest] = &pendingBlobBroadcast{req.payload, stored, []blobBroadcastRequest{req}}
+	bex.sendBlob(req.payload, stored)
+}
+
+// sendBlob sends payload to all oracles that haven't stored it yet.
+func (bex *BlobExchange[RI]) sendBlob(payload []byte, stored []bool) {
+	to := make([]commontypes.OracleID, 0, len(stored))
+	for i, ok := range stored {
+		if !ok {
+			to = append(to, commontypes.OracleID(i))
+		}
+	}
+	if len(to) != 0 {
+		bex.endpoint.Multicast(MessageBlob[RI]{payload}, to)
+	}
+}
+
+func (bex *BlobExchange[RI]) fetch(req blobFetchRequest) {
+	if blob, ok := bex.stored[req.digest]; ok {
+		req.chSuccess <- blob.payload
+		return
+	}
+
+	if pending, ok := bex.fetches[req.digest]; ok {
+		pending.waiters = append(pending.waiters, req)
+		return
+	}
+
+	pending := &pendingBlobFetch{bex.id, []blobFetchRequest{req}}
+	bex.fetches[req.digest] = pending
+	bex.requestBlob(req.digest, pending)
+}
+
+// requestBlob asks the next oracle in line for the blob.
+func (bex *BlobExchange[RI]) requestBlob(digest ocr3types.BlobDigest, pending *pendingBlobFetch) {
+	pending.asked = commontypes.OracleID((int(pending.asked) + 1) % bex.config.N())
+	if pending.asked == bex.id {
+		pending.asked = commontypes.OracleID((int(pending.asked) + 1) % bex.config.N())
+	}
+	bex.endpoint.SendTo(MessageBlobRequest[RI]{digest}, pending.asked)
+}
+
+// retry drops requests whose callers have given up, resends pending
+// broadcasts, and asks the next oracle for pending fetches.
+func (bex *BlobExchange[RI]) retry() {
+	for digest, pending := range bex.broadcasts {
+		pending.waiters = pruneBlobBroadcastRequests(pending.waiters)
+		if len(pending.waiters) == 0 {
+			delete(bex.broadcasts, digest)
+			continue
+		}
+		bex.sendBlob(pending.payload, pending.stored)
+	}
+
+	for digest, pending := range bex.fetches {
+		pending.waiters = pruneBlobFetchRequests(pending.waiters)
+		if len(pending.waiters) == 0 {
+			delete(bex.fetches, digest)
+			continue
+		}
+		bex.requestBlob(digest, pending)
+	}
+}
+
+func pruneBlobBroadcastRequests(reqs []blobBroadcastRequest) []blobBroadcastRequest {
+	result := reqs[:0]
+	for _, req := range reqs {
+		if req.ctx.Err() == nil {
+			result = append(result, req)
+		}
+	}
+	return result
+}
+
+func pruneBlobFetchRequests(reqs []blobFetchRequest) []blobFetchRequest {
+	result := reqs[:0]
+	for _, req := range reqs {
+		if req.ctx.Err() == nil {
+			result = append(result, req)
+		}
+	}
+	return result
+}
+
+// completeFetch hands payload to everyone waiting for it, if anyone.
+func (bex *BlobExchange[RI]) completeFetch(digest ocr3types.BlobDigest, payload []byte) {
+	pending, ok := bex.fetches[digest]
+	if !ok {
+		return
+	}
+	delete(bex.fetches, digest)
+	for _, req := range pending.waiters {
+		req.chSuccess <- payload
+	}
+}
+
+func (bex *BlobExchange[RI]) messageBlob(msg MessageBlob[RI], sender commontypes.OracleID) {
+	digest := ocr3types.MakeBlobDigest(msg.Payload)
+	bex.store(sender, digest, msg.Payload)
+	bex.completeFetch(digest, msg.Payload)
+	bex.endpoint.SendTo(MessageBlobAvailable[RI]{digest}, sender)
+}
+
+func (bex *BlobExchange[RI]) messageBlobAvailable(msg MessageBlobAvailable[RI], sender commontypes.OracleID) {
+	pending, ok := bex.broadcasts[msg.Digest]
+	if !ok || pending.stored[sender] {
+		return
+	}
+	pending.stored[sender] = true
+
+	storedCount := 0
+	for _, ok := range pending.stored {
+		if ok {
+			storedCount++
+		}
+	}
+	if storedCount < bex.config.ByzQuorumSize() {
+		return
+	}
+
+	bex.logger.Debug("blob stored by quorum", commontypes.LogFields{
+		"digest": msg.Digest,
+	})
+	delete(bex.broadcasts, msg.Digest)
+	for _, req := range pending.waiters {
+		req.chSuccess <- msg.Digest
+	}
+}
+
+func (bex *BlobExchange[RI]) messageBlobRequest(msg MessageBlobRequest[RI], sender commontypes.OracleID) {
+	blob, ok := bex.stored[msg.Digest]
+	if !ok {
+		return
+	}
+	if !bex.responseLimiters[sender].Allow() {
+		bex.logger.Debug("dropping blob request, sender exceeded its quota", commontypes.LogFields{
+			"sender": sender,
+			"digest": msg.Digest,
+		})
+		return
+	}
+	bex.endpoint.SendTo(MessageBlobResponse[RI]{blob.payload}, sender)
+}
+
+func (bex *BlobExchange[RI]) messageBlobResponse(msg MessageBlobResponse[RI], sender commontypes.OracleID) {
+	bex.completeFetch(ocr3types.MakeBlobDigest(msg.Payload), msg.Payload)
+}
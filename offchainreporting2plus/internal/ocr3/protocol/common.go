@@ -2,24 +2,50 @@ package protocol
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
 
+// after is like time.After, but waits on timeSource if it implements
+// ocr3types.TimerSource. timeSource may be nil.
+func after(timeSource ocr3types.TimeSource, d time.Duration) <-chan time.Time {
+	if timerSource, ok := timeSource.(ocr3types.TimerSource); ok {
+		return timerSource.After(d)
+	}
+	return time.After(d)
+}
+
 const ReportingPluginTimeoutWarningGracePeriod = 100 * time.Millisecond
 
 func callPlugin[T any](
 	ctx context.Context,
 	logger loghelper.LoggerWithContext,
+	metrics *Metrics,
+	tracing *Tracing,
+	seqNr uint64,
 	logFields commontypes.LogFields,
 	name string,
 	maxDuration time.Duration,
 	f func(context.Context) (T, error),
 ) (T, bool) {
-	pluginCtx, cancel := context.WithTimeout(ctx, maxDuration)
+	ctx, span := tracing.start(ctx, "ReportingPlugin."+name, seqNr)
+	spanErrorDescription := ""
+	defer func() { endSpan(span, spanErrorDescription) }()
+
+	// Pure functions are called with a maxDuration of zero since they should
+	// finish "instantly". Give them until we'd warn about them taking too
+	// long, rather than a context that is done from the start.
+	ctxDuration := maxDuration
+	if ctxDuration == 0 {
+		ctxDuration = ReportingPluginTimeoutWarningGracePeriod
+	}
+	pluginCtx, cancel := context.WithTimeout(ctx, ctxDuration)
 	defer cancel()
 
 	ins := loghelper.NewIfNotStopped(
@@ -32,14 +58,17 @@ func callPlugin[T any](
 		},
 	)
 
+	start := time.Now()
 	result, err := f(pluginCtx)
+	metrics.ObservePluginCallDuration(name, time.Since(start))
 
 	ins.Stop()
 
 	if err != nil {
-		logger.MakeChild(logFields).ErrorIfNotCanceled(fmt.Sprintf("call to ReportingPlugin.%s errored", name), ctx, commontypes.LogFields{
+		logger.MakeChild(logFields).ErrorIfNotCanceled(fmt.Sprintf("call to ReportingPlugin.%s errored", name), ctx, loghelper.MergePreserve(commontypes.LogFields{
 			"error": err,
-		})
+		}, pluginErrorLogFields(err)))
+		spanErrorDescription = err.Error()
 		// failed to get data, nothing to be done
 		var zero T
 		return zero, false
@@ -47,3 +76,14 @@ func callPlugin[T any](
 
 	return result, true
 }
+
+// pluginErrorLogFields returns structured details about err if it was caused
+// by the ReportingPlugin exceeding its limits, so that log-based metrics can
+// pick up the offending size.
+func pluginErrorLogFields(err error) commontypes.LogFields {
+	var limitExceededError *types.LimitExceededError
+	if errors.As(err, &limitExceededError) {
+		return limitExceededError.LogFields()
+	}
+	return nil
+}
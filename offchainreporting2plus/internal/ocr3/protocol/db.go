@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
@@ -19,4 +20,28 @@ type Database interface {
 
 	ReadCert(ctx context.Context, configDigest types.ConfigDigest) (CertifiedPrepareOrCommit, error)
 	WriteCert(ctx context.Context, configDigest types.ConfigDigest, cert CertifiedPrepareOrCommit) error
+
+	// QuarantineCorruptedState moves the value described by corrupted, as
+	// returned by one of the Read functions, out of the way, keeping it for
+	// forensics. Subsequent reads of the value return the zero state.
+	QuarantineCorruptedState(ctx context.Context, configDigest types.ConfigDigest, corrupted *CorruptedStateError) error
+}
+
+// CorruptedStateError is returned by the Read functions of Database if the
+// persisted value is corrupted, e.g. because it fails its checksum or can't be
+// decoded. Retrying the read won't help.
+type CorruptedStateError struct {
+	// Key under which the value is persisted
+	Key string
+	// The corrupted value as persisted
+	Value []byte
+	Err   error
+}
+
+func (e *CorruptedStateError) Error() string {
+	return fmt.Sprintf("persisted state %q is corrupted: %v", e.Key, e.Err)
+}
+
+func (e *CorruptedStateError) Unwrap() error {
+	return e.Err
 }
@@ -0,0 +1,60 @@
+package protocol
+
+import (
+	"time"
+)
+
+// reachabilityMonitor decides whether a protocol instance is in degraded
+// mode, see types.DegradedModeConfig. An oracle counts as reachable if this
+// oracle received a message from it at most threshold ago. This oracle itself
+// is always reachable. The instance is degraded while fewer oracles than a
+// Byzantine quorum are reachable. Not thread-safe.
+type reachabilityMonitor struct {
+	threshold  time.Duration
+	quorumSize int
+	id         int
+
+	// lastHeard[i] is when a message from oracle i was last received,
+	// initialized to the time the monitor was created, so that a freshly
+	// started instance isn't considered degraded right away
+	lastHeard []time.Time
+	degraded  bool
+}
+
+func newReachabilityMonitor(threshold time.Duration, n int, quorumSize int, id int, now time.Time) *reachabilityMonitor {
+	lastHeard := make([]time.Time, n)
+	for i := range lastHeard {
+		lastHeard[i] = now
+	}
+	return &reachabilityMonitor{
+		threshold,
+		quorumSize,
+		id,
+
+		lastHeard,
+		false,
+	}
+}
+
+// checkInterval returns how often check should be called.
+func (m *reachabilityMonitor) checkInterval() time.Duration {
+	return m.threshold / 4
+}
+
+func (m *reachabilityMonitor) heard(sender int, now time.Time) {
+	m.lastHeard[sender] = now
+}
+
+// check returns the number of reachable oracles and whether the instance
+// entered or left degraded mode since the last call.
+func (m *reachabilityMonitor) check(now time.Time) (reachable int, changed bool) {
+	for i, lastHeard := range m.lastHeard {
+		if i == m.id || now.Sub(lastHeard) <= m.threshold {
+			reachable++
+		}
+	}
+	degraded := reachable < m.quorumSize
+	changed = degraded != m.degraded
+	m.degraded = degraded
+	return reachable, changed
+}
@@ -0,0 +1,104 @@
+package protocol
+
+import (
+	"maps"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+)
+
+// messageFreshness returns a short name for msg's type and the maximum age
+// after which a message of that type is no longer worth processing.
+//
+// Messages sitting in buffers during a partition are flushed once the
+// partition heals. By then, pacemaker and outcome generation messages are
+// useless: an epoch that hasn't made progress for DeltaProgress has been
+// abandoned, and NewEpochWish messages are resent every DeltaResend anyway.
+// Report attestation messages stay relevant for as long as their round may
+// still be around, i.e. until it expires.
+func messageFreshness[RI any](msg Message[RI], config ocr3config.SharedConfig) (string, time.Duration) {
+	reportAttestationWindow := expiryDuration
+	if reportAttestationWindow < config.DeltaProgress {
+		reportAttestationWindow = config.DeltaProgress
+	}
+
+	certifiedCommitRequestWindow := config.DeltaCertifiedCommitRequest
+	if certifiedCommitRequestWindow < config.DeltaProgress {
+		certifiedCommitRequestWindow = config.DeltaProgress
+	}
+
+	switch msg.(type) {
+	case MessageNewEpochWish[RI]:
+		return "NewEpochWish", config.DeltaProgress
+	case MessageEpochStartRequest[RI]:
+		return "EpochStartRequest", config.DeltaProgress
+	case MessageEpochStart[RI]:
+		return "EpochStart", config.DeltaProgress
+	case MessageRoundStart[RI]:
+		return "RoundStart", config.DeltaProgress
+	case MessageObservation[RI]:
+		return "Observation", config.DeltaProgress
+	case MessageProposal[RI]:
+		return "Proposal", config.DeltaProgress
+	case MessagePrepare[RI]:
+		return "Prepare", config.DeltaProgress
+	case MessageCommit[RI]:
+		return "Commit", config.DeltaProgress
+	case MessageReportSignatures[RI]:
+		return "ReportSignatures", reportAttestationWindow
+	case MessageCertifiedCommitRequest[RI]:
+		return "CertifiedCommitRequest", certifiedCommitRequestWindow
+	case MessageCertifiedCommit[RI]:
+		return "CertifiedCommit", reportAttestationWindow
+	case MessageStateSyncRequest[RI]:
+		return "StateSyncRequest", certifiedCommitRequestWindow
+	case MessageStateSyncResponse[RI]:
+		return "StateSyncResponse", certifiedCommitRequestWindow
+	}
+	// Unknown message types are never considered stale.
+	return "Unknown", 0
+}
+
+// staleMessageDrops counts messages dropped at ingress because they exceeded
+// their freshness window, by message type.
+type staleMessageDrops map[string]uint64
+
+// checkFreshness returns false if msg is stale and should be dropped, see
+// types.StaleMessageDroppingConfig. Messages without a sent time are always
+// considered fresh, as are all messages if dropping is disabled.
+func (o *oracleState[RI]) checkFreshness(msg MessageWithSender[RI]) bool {
+	maxClockSkew := o.localConfig.StaleMessageDropping.MaxClockSkew
+	if maxClockSkew == 0 || msg.SentTime.IsZero() {
+		return true
+	}
+
+	msgType, window := messageFreshness(msg.Msg, o.config)
+	if window == 0 {
+		return true
+	}
+
+	age := time.Since(msg.SentTime)
+	if age <= window+maxClockSkew {
+		o.staleMessageTaper.Reset(func(oldCount uint64) {
+			o.logger.Info("Oracle: stopped dropping stale messages", commontypes.LogFields{
+				"droppedCount": oldCount,
+				"dropsByType":  maps.Clone(o.staleMessageDrops),
+			})
+		})
+		return true
+	}
+
+	o.staleMessageDrops[msgType]++
+	o.staleMessageTaper.Trigger(func(newCount uint64) {
+		o.logger.Warn("Oracle: dropping stale messages", commontypes.LogFields{
+			"droppedCount": newCount,
+			"dropsByType":  maps.Clone(o.staleMessageDrops),
+			"type":         msgType,
+			"sender":       msg.Sender,
+			"age":          age.String(),
+			"window":       window.String(),
+		})
+	})
+	return false
+}
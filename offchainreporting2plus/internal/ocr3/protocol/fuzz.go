@@ -0,0 +1,180 @@
+package protocol
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// harnessEventBufferSize is big enough for the events a couple of messages
+// can cause outcome generation to emit
+const harnessEventBufferSize = 16
+
+// OutcomeGenerationHarness runs the message handlers of outcome generation
+// synchronously, without its event loop and timers, so that fuzz tests can
+// deliver the messages a byzantine peer could send and have them processed
+// exactly as a running oracle would. It isn't safe for concurrent use.
+//
+// Events that outcome generation emits for the pacemaker and report
+// attestation are discarded.
+type OutcomeGenerationHarness[RI any] struct {
+	config          ocr3config.SharedConfig
+	database        Database
+	localConfig     types.LocalConfig
+	logger          loghelper.LoggerWithContext
+	metrics         *Metrics
+	netSender       NetworkSender[RI]
+	offchainKeyring types.OffchainKeyring
+	reportingPlugin ocr3types.ReportingPluginV2[RI]
+	telemetrySender TelemetrySender
+	timeSource      ocr3types.TimeSource
+
+	chOutcomeGenerationToPacemaker         chan EventToPacemaker[RI]
+	chOutcomeGenerationToReportAttestation chan EventToReportAttestation[RI]
+
+	outgen *outcomeGenerationState[RI] // nil until StartRound is called
+}
+
+func NewOutcomeGenerationHarness[RI any](
+	config ocr3config.SharedConfig,
+	database Database,
+	localConfig types.LocalConfig,
+	logger loghelper.LoggerWithContext,
+	metrics *Metrics,
+	netSender NetworkSender[RI],
+	offchainKeyring types.OffchainKeyring,
+	reportingPlugin ocr3types.ReportingPluginV2[RI],
+	telemetrySender TelemetrySender,
+	timeSource ocr3types.TimeSource,
+) *OutcomeGenerationHarness[RI] {
+	return &OutcomeGenerationHarness[RI]{
+		config,
+		database,
+		localConfig,
+		logger,
+		metrics,
+		netSender,
+		offchainKeyring,
+		reportingPlugin,
+		telemetrySender,
+		timeSource,
+
+		make(chan EventToPacemaker[RI], harnessEventBufferSize),
+		make(chan EventToReportAttestation[RI], harnessEventBufferSize),
+
+		nil,
+	}
+}
+
+// StartRound discards all state and lets oracle id start epoch at the round
+// following committedSeqNr, whose outcome was committedOutcome, as if the
+// epoch had started from the genesis certificate. If id leads the epoch, it
+// calls Query and starts the round as leader. As follower, it observes right
+// away in query-less rounds, and otherwise waits for MessageRoundStart.
+//
+// Plugin calls are made with ctx.
+func (h *OutcomeGenerationHarness[RI]) StartRound(ctx context.Context, id commontypes.OracleID, epoch uint64, committedSeqNr uint64, committedOutcome ocr3types.Outcome) {
+	outgen := newOutcomeGenerationState[RI](
+		ctx,
+
+		nil,
+		nil,
+		nil,
+		h.chOutcomeGenerationToPacemaker,
+		h.chOutcomeGenerationToReportAttestation,
+		h.config,
+		h.database,
+		id,
+		NewInstanceStatus(nil),
+		h.localConfig,
+		h.logger,
+		h.metrics,
+		h.netSender,
+		h.offchainKeyring,
+		h.reportingPlugin,
+		nil,
+		h.telemetrySender,
+		h.timeSource,
+		newTracing(nil, h.config.ConfigDigest, id),
+	)
+	h.outgen = outgen
+
+	outgen.initialize(&CertifiedCommit{})
+	outgen.sharedState.committedSeqNr = committedSeqNr
+	outgen.sharedState.committedOutcome = committedOutcome
+	outgen.sharedState.committedOutcomeHash = ocr3types.MakeOutcomeHash(committedOutcome)
+	outgen.eventNewEpochStart(EventNewEpochStart[RI]{epoch, false})
+
+	// see the genesis cases of messageEpochStart and messageEpochStartRequest
+	outgen.followerState.tInitial = nil
+	// as if TRound had fired, so that query-less rounds start right away
+	outgen.followerState.tRound = nil
+	outgen.sharedState.firstSeqNrOfEpoch = committedSeqNr + 1
+	outgen.startSubsequentFollowerRound()
+	if outgen.id == outgen.sharedState.l {
+		outgen.leaderState.phase = outgenLeaderPhaseSentEpochStart
+		outgen.leaderState.readyToStartRound = true
+		outgen.startSubsequentLeaderRound()
+	}
+	h.drainEvents()
+}
+
+// Epoch returns the current epoch.
+func (h *OutcomeGenerationHarness[RI]) Epoch() uint64 {
+	return h.outgen.sharedState.e
+}
+
+// Leader returns the leader of the current epoch.
+func (h *OutcomeGenerationHarness[RI]) Leader() commontypes.OracleID {
+	return h.outgen.sharedState.l
+}
+
+// SeqNr returns the seqNr of the current round.
+func (h *OutcomeGenerationHarness[RI]) SeqNr() uint64 {
+	return h.outgen.sharedState.seqNr
+}
+
+// Query returns the query of the current round, if the oracle has started the
+// round as leader or observed in it as follower.
+func (h *OutcomeGenerationHarness[RI]) Query() (types.Query, bool) {
+	if h.outgen.followerState.query != nil {
+		return *h.outgen.followerState.query, true
+	}
+	if h.outgen.id == h.outgen.sharedState.l && h.outgen.leaderState.phase == outgenLeaderPhaseSentRoundStart {
+		return h.outgen.leaderState.query, true
+	}
+	return nil, false
+}
+
+// Outcome returns the outcome the oracle computed from the leader's proposal
+// in the current round, if it did.
+func (h *OutcomeGenerationHarness[RI]) Outcome() (ocr3types.Outcome, bool) {
+	switch h.outgen.followerState.phase {
+	case outgenFollowerPhaseSentPrepare, outgenFollowerPhaseSentCommit:
+		return h.outgen.followerState.outcome.Outcome, true
+	}
+	return nil, false
+}
+
+// Deliver processes msg from sender like the event loop would. Messages that
+// the SerializingEndpoint would have rejected, e.g. because they fail
+// Message.CheckSize, must not be delivered.
+func (h *OutcomeGenerationHarness[RI]) Deliver(msg MessageToOutcomeGeneration[RI], sender commontypes.OracleID) {
+	h.outgen.messageToOutcomeGeneration(MessageToOutcomeGenerationWithSender[RI]{msg, sender})
+	h.drainEvents()
+}
+
+func (h *OutcomeGenerationHarness[RI]) drainEvents() {
+	for {
+		select {
+		case <-h.chOutcomeGenerationToPacemaker:
+		case <-h.chOutcomeGenerationToReportAttestation:
+		default:
+			return
+		}
+	}
+}
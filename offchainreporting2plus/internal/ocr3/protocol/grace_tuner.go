@@ -0,0 +1,83 @@
+package protocol
+
+import (
+	"sort"
+	"time"
+)
+
+// Number of recent rounds whose quorum latency the leader remembers
+const graceTunerWindow = 32
+
+// Number of samples the leader needs before it starts auto-tuning. Until then,
+// it uses the static DeltaGrace.
+const graceTunerMinSamples = 8
+
+// The grace period is set to this percentile of the recent quorum latencies.
+const graceTunerPercentile = 90
+
+// graceTuner auto-tunes the grace period of the rounds led by this oracle,
+// see ocr3config.PublicConfig.DeltaGraceMin. The quorum latency of a round is
+// the time from the leader starting the round until it has received a quorum
+// of observations. Observations from slower oracles typically trail the
+// quorum by a similar amount of time, so we wait for a high percentile of the
+// recent quorum latencies, bounded by [DeltaGraceMin, DeltaGraceMax]: when the
+// network is fast, rounds don't waste time waiting for stragglers that arrive
+// quickly anyways; when it is slow, we wait longer instead of consistently
+// missing observations.
+//
+// Only the leader's local timing is used, followers are unaffected. The state
+// lives as long as the outcome generation protocol instance, i.e. it is
+// scoped to a config digest. Not thread-safe.
+type graceTuner struct {
+	enabled       bool
+	deltaGrace    time.Duration
+	deltaGraceMin time.Duration
+	deltaGraceMax time.Duration
+
+	// circular buffer of the most recent quorum latencies
+	samples []time.Duration
+	next    int
+}
+
+func newGraceTuner(enabled bool, deltaGrace, deltaGraceMin, deltaGraceMax time.Duration) *graceTuner {
+	return &graceTuner{
+		enabled,
+		deltaGrace,
+		deltaGraceMin,
+		deltaGraceMax,
+		make([]time.Duration, 0, graceTunerWindow),
+		0,
+	}
+}
+
+func (t *graceTuner) recordQuorumLatency(latency time.Duration) {
+	if !t.enabled {
+		return
+	}
+	if len(t.samples) < graceTunerWindow {
+		t.samples = append(t.samples, latency)
+		return
+	}
+	t.samples[t.next] = latency
+	t.next = (t.next + 1) % graceTunerWindow
+}
+
+// grace returns the grace period to use for the next round and whether it is
+// auto-tuned.
+func (t *graceTuner) grace() (time.Duration, bool) {
+	if !t.enabled || len(t.samples) < graceTunerMinSamples {
+		return t.deltaGrace, false
+	}
+
+	sorted := append([]time.Duration{}, t.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	grace := sorted[(len(sorted)-1)*graceTunerPercentile/100]
+
+	if grace < t.deltaGraceMin {
+		grace = t.deltaGraceMin
+	}
+	if grace > t.deltaGraceMax {
+		grace = t.deltaGraceMax
+	}
+	return grace, true
+}
@@ -0,0 +1,75 @@
+package protocol
+
+import "sync/atomic"
+
+// InstanceStatus exposes the progress of a protocol instance outside of the
+// protocol, e.g. for heartbeats. All its functions are thread-safe.
+type InstanceStatus struct {
+	highestCommittedSeqNr       atomic.Uint64
+	degraded                    atomic.Bool
+	recoveredFromCorruptedState atomic.Bool
+
+	onDegradedChanged func(degraded bool)
+}
+
+// NewInstanceStatus returns a new InstanceStatus. If onDegradedChanged isn't
+// nil, it is called from the protocol whenever the instance enters or leaves
+// degraded mode, and must return quickly.
+func NewInstanceStatus(onDegradedChanged func(degraded bool)) *InstanceStatus {
+	return &InstanceStatus{
+		atomic.Uint64{},
+		atomic.Bool{},
+		atomic.Bool{},
+
+		onDegradedChanged,
+	}
+}
+
+// HighestCommittedSeqNr returns the highest sequence number committed by the
+// instance, zero if none.
+func (s *InstanceStatus) HighestCommittedSeqNr() uint64 {
+	return s.highestCommittedSeqNr.Load()
+}
+
+// Degraded returns whether the instance is in degraded mode, see
+// types.DegradedModeConfig.
+func (s *InstanceStatus) Degraded() bool {
+	return s.degraded.Load()
+}
+
+// RecoveredFromCorruptedState returns whether the instance found corrupted
+// persisted state when it started, and quarantined it to start from a fresh
+// state instead, see ocr3types.ProtocolStateQuarantiner.
+func (s *InstanceStatus) RecoveredFromCorruptedState() bool {
+	return s.recoveredFromCorruptedState.Load()
+}
+
+// committed may be called on a nil InstanceStatus, in which case it does
+// nothing.
+func (s *InstanceStatus) committed(seqNr uint64) {
+	if s == nil {
+		return
+	}
+	s.highestCommittedSeqNr.Store(seqNr)
+}
+
+// setDegraded may be called on a nil InstanceStatus, in which case it does
+// nothing.
+func (s *InstanceStatus) setDegraded(degraded bool) {
+	if s == nil {
+		return
+	}
+	s.degraded.Store(degraded)
+	if s.onDegradedChanged != nil {
+		s.onDegradedChanged(degraded)
+	}
+}
+
+// setRecoveredFromCorruptedState may be called on a nil InstanceStatus, in
+// which case it does nothing.
+func (s *InstanceStatus) setRecoveredFromCorruptedState() {
+	if s == nil {
+		return
+	}
+	s.recoveredFromCorruptedState.Store(true)
+}
@@ -2,6 +2,7 @@ package protocol //
 
 import (
 	"crypto/ed25519"
+	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/byzquorum"
@@ -35,6 +36,9 @@ type EventToOutcomeGeneration[RI any] interface {
 
 type EventNewEpochStart[RI any] struct {
 	Epoch uint64
+	// Whether the oracle must stay passive during Epoch, because it may have
+	// signed messages in it before quarantining corrupted state.
+	Passive bool
 }
 
 var _ EventToOutcomeGeneration[struct{}] = EventNewEpochStart[struct{}]{}
@@ -67,6 +71,10 @@ type Message[RI any] interface {
 type MessageWithSender[RI any] struct {
 	Msg    Message[RI]
 	Sender commontypes.OracleID
+	// Time at which the sender claims to have sent msg, according to its own
+	// clock. Zero if unknown, e.g. because the sender runs an older library
+	// version.
+	SentTime time.Time
 }
 
 type MessageToPacemaker[RI any] interface {
@@ -93,6 +101,20 @@ type MessageToOutcomeGenerationWithSender[RI any] struct {
 	sender commontypes.OracleID
 }
 
+// MessageToStateSync is implemented by the messages of the state sync
+// subprotocol, see state_sync.go. They are handled by outcome generation, but
+// aren't tied to an epoch.
+type MessageToStateSync[RI any] interface {
+	Message[RI]
+
+	processStateSync(outgen *outcomeGenerationState[RI], sender commontypes.OracleID)
+}
+
+type MessageToStateSyncWithSender[RI any] struct {
+	msg    MessageToStateSync[RI]
+	sender commontypes.OracleID
+}
+
 type MessageToReportAttestation[RI any] interface {
 	Message[RI]
 
@@ -104,6 +126,15 @@ type MessageToReportAttestationWithSender[RI any] struct {
 	sender commontypes.OracleID
 }
 
+// MessageToBlobExchange is implemented by the messages of the blob exchange
+// protocol. BlobExchange intercepts these messages, so they never reach the
+// oracle.
+type MessageToBlobExchange[RI any] interface {
+	Message[RI]
+
+	processBlobExchange(bex *BlobExchange[RI], sender commontypes.OracleID)
+}
+
 type MessageNewEpochWish[RI any] struct {
 	Epoch uint64
 }
@@ -228,7 +259,9 @@ type MessageObservation[RI any] struct {
 var _ MessageToOutcomeGeneration[struct{}] = (*MessageObservation[struct{}])(nil)
 
 func (msg MessageObservation[RI]) CheckSize(n int, f int, limits ocr3types.ReportingPluginLimits, maxReportSigLen int) bool {
-	return len(msg.SignedObservation.Observation) <= limits.MaxObservationLength && len(msg.SignedObservation.Signature) == ed25519.SignatureSize
+	return len(msg.SignedObservation.Observation) <= limits.MaxObservationLength &&
+		len(msg.SignedObservation.Signature) == ed25519.SignatureSize &&
+		ocr3types.CheckObservationProvenance(msg.SignedObservation.Observation, limits) == nil
 }
 
 func (msg MessageObservation[RI]) process(o *oracleState[RI], sender commontypes.OracleID) {
@@ -239,7 +272,7 @@ func (msg MessageObservation[RI]) process(o *oracleState[RI], sender commontypes
 }
 
 func (msg MessageObservation[RI]) processOutcomeGeneration(outgen *outcomeGenerationState[RI], sender commontypes.OracleID) {
-	outgen.messageObservation(msg, sender)
+	outgen.messageObservation(msg, sender, outgen.now())
 }
 
 func (msg MessageObservation[RI]) epoch() uint64 {
@@ -265,6 +298,9 @@ func (msg MessageProposal[RI]) CheckSize(n int, f int, limits ocr3types.Reportin
 		if len(aso.SignedObservation.Signature) != ed25519.SignatureSize {
 			return false
 		}
+		if ocr3types.CheckObservationProvenance(aso.SignedObservation.Observation, limits) != nil {
+			return false
+		}
 	}
 	return true
 }
@@ -402,6 +438,114 @@ func (msg MessageCertifiedCommit[RI]) processReportAttestation(repatt *reportAtt
 	repatt.messageCertifiedCommit(msg, sender)
 }
 
+type MessageStateSyncRequest[RI any] struct {
+	HighestCommittedSeqNr uint64
+}
+
+var _ MessageToStateSync[struct{}] = MessageStateSyncRequest[struct{}]{}
+
+func (msg MessageStateSyncRequest[RI]) CheckSize(n int, f int, _ ocr3types.ReportingPluginLimits, _ int) bool {
+	return true
+}
+
+func (msg MessageStateSyncRequest[RI]) process(o *oracleState[RI], sender commontypes.OracleID) {
+	o.chNetToStateSync <- MessageToStateSyncWithSender[RI]{msg, sender}
+}
+
+func (msg MessageStateSyncRequest[RI]) processStateSync(outgen *outcomeGenerationState[RI], sender commontypes.OracleID) {
+	outgen.messageStateSyncRequest(msg, sender)
+}
+
+type MessageStateSyncResponse[RI any] struct {
+	CertifiedCommit CertifiedCommit
+}
+
+var _ MessageToStateSync[struct{}] = MessageStateSyncResponse[struct{}]{}
+
+func (msg MessageStateSyncResponse[RI]) CheckSize(n int, f int, limits ocr3types.ReportingPluginLimits, maxReportSigLen int) bool {
+	return msg.CertifiedCommit.CheckSize(n, f, limits, maxReportSigLen)
+}
+
+func (msg MessageStateSyncResponse[RI]) process(o *oracleState[RI], sender commontypes.OracleID) {
+	o.chNetToStateSync <- MessageToStateSyncWithSender[RI]{msg, sender}
+}
+
+func (msg MessageStateSyncResponse[RI]) processStateSync(outgen *outcomeGenerationState[RI], sender commontypes.OracleID) {
+	outgen.messageStateSyncResponse(msg, sender)
+}
+
+type MessageBlob[RI any] struct {
+	Payload []byte
+}
+
+var _ MessageToBlobExchange[struct{}] = MessageBlob[struct{}]{}
+
+func (msg MessageBlob[RI]) CheckSize(n int, f int, limits ocr3types.ReportingPluginLimits, _ int) bool {
+	return 0 < len(msg.Payload) && len(msg.Payload) <= limits.MaxBlobLength
+}
+
+func (msg MessageBlob[RI]) process(o *oracleState[RI], sender commontypes.OracleID) {
+	// intercepted by BlobExchange
+}
+
+func (msg MessageBlob[RI]) processBlobExchange(bex *BlobExchange[RI], sender commontypes.OracleID) {
+	bex.messageBlob(msg, sender)
+}
+
+type MessageBlobAvailable[RI any] struct {
+	Digest ocr3types.BlobDigest
+}
+
+var _ MessageToBlobExchange[struct{}] = MessageBlobAvailable[struct{}]{}
+
+func (msg MessageBlobAvailable[RI]) CheckSize(n int, f int, _ ocr3types.ReportingPluginLimits, _ int) bool {
+	return true
+}
+
+func (msg MessageBlobAvailable[RI]) process(o *oracleState[RI], sender commontypes.OracleID) {
+	// intercepted by BlobExchange
+}
+
+func (msg MessageBlobAvailable[RI]) processBlobExchange(bex *BlobExchange[RI], sender commontypes.OracleID) {
+	bex.messageBlobAvailable(msg, sender)
+}
+
+type MessageBlobRequest[RI any] struct {
+	Digest ocr3types.BlobDigest
+}
+
+var _ MessageToBlobExchange[struct{}] = MessageBlobRequest[struct{}]{}
+
+func (msg MessageBlobRequest[RI]) CheckSize(n int, f int, _ ocr3types.ReportingPluginLimits, _ int) bool {
+	return true
+}
+
+func (msg MessageBlobRequest[RI]) process(o *oracleState[RI], sender commontypes.OracleID) {
+	// intercepted by BlobExchange
+}
+
+func (msg MessageBlobRequest[RI]) processBlobExchange(bex *BlobExchange[RI], sender commontypes.OracleID) {
+	bex.messageBlobRequest(msg, sender)
+}
+
+type MessageBlobResponse[RI any] struct {
+	Payload []byte
+}
+
+var _ MessageToBlobExchange[struct{}] = MessageBlobResponse[struct{}]{}
+
+func (msg MessageBlobResponse[RI]) CheckSize(n int, f int, limits ocr3types.ReportingPluginLimits, _ int) bool {
+	return 0 < len(msg.Payload) && len(msg.Payload) <= limits.MaxBlobLength
+}
+
+func (msg MessageBlobResponse[RI]) process(o *oracleState[RI], sender commontypes.OracleID) {
+	// intercepted by BlobExchange
+}
+
+func (msg MessageBlobResponse[RI]) processBlobExchange(bex *BlobExchange[RI], sender commontypes.OracleID) {
+	bex.messageBlobResponse(msg, sender)
+}
+
 type EventMissingOutcome[RI any] struct {
 	SeqNr uint64
 }
@@ -414,6 +558,10 @@ func (ev EventMissingOutcome[RI]) processReportAttestation(repatt *reportAttesta
 
 type EventCommittedOutcome[RI any] struct {
 	CertifiedCommit CertifiedCommit
+	// the outcome with sequence number CertifiedCommit.SeqNr-1, only valid if
+	// PreviousOutcomeKnown
+	PreviousOutcome      ocr3types.Outcome
+	PreviousOutcomeKnown bool
 }
 
 var _ EventToReportAttestation[struct{}] = EventCommittedOutcome[struct{}]{} // implements EventToReportAttestation
@@ -423,9 +571,15 @@ func (ev EventCommittedOutcome[RI]) processReportAttestation(repatt *reportAttes
 }
 
 type EventAttestedReport[RI any] struct {
-	SeqNr          uint64
-	Index          int
+	SeqNr uint64
+	Index int
+	// Number of reports attested for SeqNr, i.e. the number of
+	// EventAttestedReports sent for SeqNr
+	ReportCount    int
 	AttestedReport AttestedReportMany[RI]
+	// Set iff AttestedReport is the root of a batch, see
+	// ocr3types.ReportBatcher.
+	ReportBatch *ocr3types.ReportBatch[RI]
 }
 
 var _ EventToTransmission[struct{}] = EventAttestedReport[struct{}]{} // implements EventToTransmission
@@ -0,0 +1,222 @@
+package protocol
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/metricshelper"
+)
+
+// Metrics holds the prometheus metrics of an OCR3 oracle. They outlive
+// individual protocol instances: a Metrics is created once per oracle and
+// shared by the protocol instances for successive configs.
+type Metrics struct {
+	registerer *metricshelper.Registerer
+
+	roundDuration          prometheus.Histogram
+	pluginCallDuration     *prometheus.HistogramVec
+	messagesSent           *prometheus.CounterVec
+	messagesReceived       *prometheus.CounterVec
+	messagesDropped        *prometheus.CounterVec
+	transmissionQueueDepth prometheus.Gauge
+	dedupCacheEntries      prometheus.Gauge
+	dedupCacheEvictions    *prometheus.CounterVec
+	messageArchiveDropped  prometheus.Counter
+	degraded               prometheus.Gauge
+	messageSize            *prometheus.HistogramVec
+	pluginOutputSize       *prometheus.HistogramVec
+	pluginOutputLimitRatio *prometheus.HistogramVec
+}
+
+// Reasons for dropping messages, used as values of the "reason" label of
+// ocr3_messages_dropped_total
+const (
+	MessageDropReasonSize          = "size"
+	MessageDropReasonSerialization = "serialization"
+	MessageDropReasonBacklog       = "backlog"
+	MessageDropReasonDuplicate     = "duplicate"
+)
+
+// Directions of messages, used as values of the "direction" label of
+// ocr3_message_size_bytes
+const (
+	MessageDirectionSent     = "sent"
+	MessageDirectionReceived = "received"
+)
+
+// Kinds of ReportingPlugin outputs, used as values of the "kind" label of
+// ocr3_plugin_output_size_bytes and ocr3_plugin_output_limit_ratio
+const (
+	PluginOutputKindQuery       = "query"
+	PluginOutputKindObservation = "observation"
+	PluginOutputKindOutcome     = "outcome"
+	PluginOutputKindReport      = "report"
+)
+
+// Reasons for evicting entries from the inbound message deduplication cache,
+// used as values of the "reason" label of ocr3_dedup_cache_evictions_total
+const (
+	DedupCacheEvictionReasonExpired  = "expired"
+	DedupCacheEvictionReasonCapacity = "capacity"
+)
+
+// NewMetrics creates the metrics and registers them with registerer, which
+// may be nil. Call Close to unregister them.
+func NewMetrics(registerer prometheus.Registerer, logger commontypes.Logger) *Metrics {
+	m := &Metrics{
+		metricshelper.NewRegisterer(registerer, logger),
+
+		prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "ocr3_round_duration_seconds",
+			Help:    "Time between the commits of consecutive sequence numbers",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+		}),
+		prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ocr3_plugin_call_duration_seconds",
+			Help:    "Duration of calls to the ReportingPlugin, by method",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15),
+		}, []string{"method"}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ocr3_messages_sent_total",
+			Help: "Number of protocol messages sent, by type. A broadcast counts once.",
+		}, []string{"type"}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ocr3_messages_received_total",
+			Help: "Number of protocol messages received, by type",
+		}, []string{"type"}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ocr3_messages_dropped_total",
+			Help: "Number of protocol messages dropped by this oracle, by type and reason",
+		}, []string{"type", "reason"}),
+		prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ocr3_transmission_queue_depth",
+			Help: "Number of accepted reports waiting for their turn to be transmitted",
+		}),
+		prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ocr3_dedup_cache_entries",
+			Help: "Number of inbound messages remembered for deduplication",
+		}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ocr3_dedup_cache_evictions_total",
+			Help: "Number of entries evicted from the inbound message deduplication cache, by reason. Evictions for reason \"capacity\" happen before the deduplication window has elapsed.",
+		}, []string{"reason"}),
+		prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "ocr3_message_archive_dropped_total",
+			Help: "Number of sampled protocol messages that weren't passed to the MessageArchiver because its queue was full",
+		}),
+		prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ocr3_degraded",
+			Help: "1 while the protocol instance is in degraded mode, i.e. no quorum of oracles has been reachable for the configured threshold, 0 otherwise",
+		}),
+		prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ocr3_message_size_bytes",
+			Help:    "Serialized size of protocol messages (before chunking), by message type and direction",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 10),
+		}, []string{"message_type", "direction"}),
+		prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ocr3_plugin_output_size_bytes",
+			Help:    "Size of queries, observations, outcomes and reports produced by the ReportingPlugin",
+			Buckets: prometheus.ExponentialBuckets(16, 4, 10),
+		}, []string{"kind"}),
+		prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ocr3_plugin_output_limit_ratio",
+			Help:    "Size of queries, observations, outcomes and reports produced by the ReportingPlugin relative to the corresponding limit in ReportingPluginLimits",
+			Buckets: prometheus.LinearBuckets(0.1, 0.1, 10),
+		}, []string{"kind"}),
+	}
+	m.registerer.Register(
+		m.roundDuration,
+		m.pluginCallDuration,
+		m.messagesSent,
+		m.messagesReceived,
+		m.messagesDropped,
+		m.transmissionQueueDepth,
+		m.dedupCacheEntries,
+		m.dedupCacheEvictions,
+		m.messageArchiveDropped,
+		m.degraded,
+		m.messageSize,
+		m.pluginOutputSize,
+		m.pluginOutputLimitRatio,
+	)
+	return m
+}
+
+// Close unregisters the metrics.
+func (m *Metrics) Close() {
+	m.registerer.Close()
+}
+
+func (m *Metrics) ObserveRoundDuration(duration time.Duration) {
+	m.roundDuration.Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObservePluginCallDuration(method string, duration time.Duration) {
+	m.pluginCallDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (m *Metrics) IncMessagesSent(messageType string) {
+	m.messagesSent.WithLabelValues(messageType).Inc()
+}
+
+func (m *Metrics) IncMessagesReceived(messageType string) {
+	m.messagesReceived.WithLabelValues(messageType).Inc()
+}
+
+func (m *Metrics) IncMessagesDropped(messageType string, reason string) {
+	m.messagesDropped.WithLabelValues(messageType, reason).Inc()
+}
+
+func (m *Metrics) SetTransmissionQueueDepth(depth int) {
+	m.transmissionQueueDepth.Set(float64(depth))
+}
+
+func (m *Metrics) AddDedupCacheEntries(delta int) {
+	m.dedupCacheEntries.Add(float64(delta))
+}
+
+func (m *Metrics) IncDedupCacheEvictions(reason string) {
+	m.dedupCacheEvictions.WithLabelValues(reason).Inc()
+}
+
+func (m *Metrics) IncMessageArchiveDropped() {
+	m.messageArchiveDropped.Inc()
+}
+
+func (m *Metrics) SetDegraded(degraded bool) {
+	if degraded {
+		m.degraded.Set(1)
+	} else {
+		m.degraded.Set(0)
+	}
+}
+
+func (m *Metrics) ObserveMessageSize(messageType string, direction string, size int) {
+	m.messageSize.WithLabelValues(messageType, direction).Observe(float64(size))
+}
+
+// ObservePluginOutputSize records the size of an output of the
+// ReportingPlugin whose limit is limit.
+func (m *Metrics) ObservePluginOutputSize(kind string, size int, limit int) {
+	m.pluginOutputSize.WithLabelValues(kind).Observe(float64(size))
+	if limit > 0 {
+		m.pluginOutputLimitRatio.WithLabelValues(kind).Observe(float64(size) / float64(limit))
+	}
+}
+
+// UnknownMessageType is used as message type for messages that couldn't be
+// deserialized.
+const UnknownMessageType = "unknown"
+
+// MessageType returns the name of msg's type without type parameters, e.g.
+// "MessageObservation".
+func MessageType[RI any](msg Message[RI]) string {
+	name := reflect.TypeOf(msg).Name()
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"log"
+	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 )
@@ -12,6 +13,9 @@ type NetworkSender[RI any] interface {
 	SendTo(msg Message[RI], to commontypes.OracleID)
 	// Broadcast(msg) sends msg to all oracles
 	Broadcast(msg Message[RI])
+	// Multicast(msg, to) sends msg to every oracle in "to". Unlike repeated
+	// calls to SendTo, msg is serialized only once.
+	Multicast(msg Message[RI], to []commontypes.OracleID)
 }
 
 // NetworkEndpoint sends & receives messages to/from other oracles
@@ -65,14 +69,21 @@ var _ NetworkEndpoint[struct{}] = (*SimpleNetworkEndpoint[struct{}])(nil)
 // SendTo sends msg to oracle "to"
 func (end SimpleNetworkEndpoint[RI]) SendTo(msg Message[RI], to commontypes.OracleID) {
 	log.Printf("[%v] sending to %v: %T\n", end.id, to, msg)
-	end.net.chs[to] <- MessageWithSender[RI]{msg, end.id}
+	end.net.chs[to] <- MessageWithSender[RI]{msg, end.id, time.Now()}
+}
+
+// Multicast sends msg to every oracle in "to"
+func (end SimpleNetworkEndpoint[RI]) Multicast(msg Message[RI], to []commontypes.OracleID) {
+	for _, oid := range to {
+		end.SendTo(msg, oid)
+	}
 }
 
 // Broadcast sends msg to all participating oracles
 func (end SimpleNetworkEndpoint[RI]) Broadcast(msg Message[RI]) {
 	log.Printf("[%v] broadcasting: %T\n", end.id, msg)
 	for _, ch := range end.net.chs {
-		ch <- MessageWithSender[RI]{msg, end.id}
+		ch <- MessageWithSender[RI]{msg, end.id, time.Now()}
 	}
 }
 
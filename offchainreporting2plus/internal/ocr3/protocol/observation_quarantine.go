@@ -0,0 +1,76 @@
+package protocol
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+)
+
+// Number of consecutive observations failing ValidateObservation after which
+// we quarantine their sender
+const observationQuarantineThreshold = 3
+
+// The first quarantine of a sender lasts minObservationQuarantine. Each
+// further quarantine without a valid observation in between lasts twice as
+// long as the previous one, up to maxObservationQuarantine.
+const (
+	minObservationQuarantine = 10 * time.Second
+	maxObservationQuarantine = 10 * time.Minute
+)
+
+// observationQuarantine tracks which senders persistently send observations
+// that fail ValidateObservation. While a sender is quarantined, the leader
+// drops its observations without verifying or validating them, so that a
+// faulty oracle can't make us waste CPU on it every round. Once the
+// quarantine ends, the sender's next observation is validated again: if it
+// is valid, the sender is rehabilitated; otherwise it is quarantined again
+// right away.
+//
+// The state lives as long as the outcome generation protocol instance, i.e.
+// it is scoped to a config digest. Not thread-safe.
+type observationQuarantine struct {
+	senders []senderObservationQuarantine
+}
+
+type senderObservationQuarantine struct {
+	consecutiveFailures int
+	// duration of the next quarantine
+	duration time.Duration
+	until    time.Time
+}
+
+func newObservationQuarantine(n int) *observationQuarantine {
+	senders := make([]senderObservationQuarantine, n)
+	for i := range senders {
+		senders[i].duration = minObservationQuarantine
+	}
+	return &observationQuarantine{senders}
+}
+
+func (q *observationQuarantine) quarantined(sender commontypes.OracleID, now time.Time) bool {
+	return now.Before(q.senders[sender].until)
+}
+
+// recordValid returns true iff sender was rehabilitated, i.e. it had been
+// quarantined before.
+func (q *observationQuarantine) recordValid(sender commontypes.OracleID) bool {
+	s := &q.senders[sender]
+	rehabilitated := s.consecutiveFailures >= observationQuarantineThreshold
+	s.consecutiveFailures = 0
+	s.duration = minObservationQuarantine
+	return rehabilitated
+}
+
+// recordInvalid returns the duration for which sender is quarantined as a
+// result, or zero if it isn't.
+func (q *observationQuarantine) recordInvalid(sender commontypes.OracleID, now time.Time) time.Duration {
+	s := &q.senders[sender]
+	s.consecutiveFailures++
+	if s.consecutiveFailures < observationQuarantineThreshold {
+		return 0
+	}
+	duration := s.duration
+	s.until = now.Add(duration)
+	s.duration = min(2*s.duration, maxObservationQuarantine)
+	return duration
+}
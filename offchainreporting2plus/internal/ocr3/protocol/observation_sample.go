@@ -0,0 +1,36 @@
+package protocol
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/permutation"
+)
+
+// observationSampled returns whether oracle belongs to the observation sample
+// of the current round, see ocr3types.ProtocolFeatureFlagObservationSampling.
+// Without the flag, every oracle belongs to it. The sample is a function of
+// the config, epoch, seqNr, and quorum only, so that the leader and all
+// followers agree on it.
+func (outgen *outcomeGenerationState[RI]) observationSampled(oracle commontypes.OracleID, quorum int) bool {
+	if !outgen.observationSampling {
+		return true
+	}
+	n := outgen.config.N()
+	size := quorum + outgen.config.F
+	if size >= n {
+		return true
+	}
+
+	observationSampleKey := outgen.config.ObservationSampleKey()
+	mac := hmac.New(sha256.New, observationSampleKey[:])
+	_ = binary.Write(mac, binary.BigEndian, uint64(outgen.sharedState.e))
+	_ = binary.Write(mac, binary.BigEndian, outgen.sharedState.seqNr)
+
+	var key [16]byte
+	_ = copy(key[:], mac.Sum(nil))
+	pi := permutation.Permutation(n, key)
+	return pi[oracle] < size
+}
@@ -2,51 +2,82 @@ package protocol
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/smartcontractkit/libocr/backoff"
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/latestreportcache"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/retransmission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/transmissionretry"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 	"github.com/smartcontractkit/libocr/subprocesses"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // RunOracle runs one oracle instance of the offchain reporting protocol and manages
 // the lifecycle of all underlying goroutines.
 //
 // RunOracle runs forever until ctx is cancelled. It will only shut down
-// after all its sub-goroutines have exited.
+// after all its sub-goroutines have exited. If
+// localConfig.HotConfigSwap.GracePeriod is non-zero, it keeps transmitting
+// already scheduled reports for up to that long after ctx is cancelled, see
+// types.HotConfigSwapConfig.
 func RunOracle[RI any](
 	ctx context.Context,
 
+	chForceEpochChange <-chan struct{},
+	chRetransmissionRequests <-chan retransmission.Request,
 	config ocr3config.SharedConfig,
 	contractTransmitter ocr3types.ContractTransmitter[RI],
 	database Database,
 	id commontypes.OracleID,
+	instanceStatus *InstanceStatus,
+	latestReportCache *latestreportcache.Cache,
+	latestReportCacheDestinations []ocr3types.ReportDestination,
 	localConfig types.LocalConfig,
 	logger loghelper.LoggerWithContext,
+	metrics *Metrics,
 	netEndpoint NetworkEndpoint[RI],
 	offchainKeyring types.OffchainKeyring,
 	onchainKeyring ocr3types.OnchainKeyring[RI],
-	reportingPlugin ocr3types.ReportingPlugin[RI],
+	reportingPlugin ocr3types.ReportingPluginV2[RI],
+	roundAbandonmentListener ocr3types.RoundAbandonmentListener,
 	telemetrySender TelemetrySender,
+	timeSource ocr3types.TimeSource,
+	tracerProvider trace.TracerProvider,
+	transmissionRetryPolicy transmissionretry.Policy,
 ) {
 	o := oracleState[RI]{
 		ctx: ctx,
 
-		config:              config,
-		contractTransmitter: contractTransmitter,
-		database:            database,
-		id:                  id,
-		localConfig:         localConfig,
-		logger:              logger,
-		netEndpoint:         netEndpoint,
-		offchainKeyring:     offchainKeyring,
-		onchainKeyring:      onchainKeyring,
-		reportingPlugin:     reportingPlugin,
-		telemetrySender:     telemetrySender,
+		chForceEpochChange:            chForceEpochChange,
+		chRetransmissionRequests:      chRetransmissionRequests,
+		config:                        config,
+		contractTransmitter:           contractTransmitter,
+		database:                      database,
+		id:                            id,
+		instanceStatus:                instanceStatus,
+		latestReportCache:             latestReportCache,
+		latestReportCacheDestinations: latestReportCacheDestinations,
+		localConfig:                   localConfig,
+		logger:                        logger,
+		metrics:                       metrics,
+		netEndpoint:                   netEndpoint,
+		offchainKeyring:               offchainKeyring,
+		onchainKeyring:                onchainKeyring,
+		reportingPlugin:               reportingPlugin,
+		roundAbandonmentListener:      roundAbandonmentListener,
+		telemetrySender:               telemetrySender,
+		timeSource:                    timeSource,
+		tracing:                       newTracing(tracerProvider, config.ConfigDigest, id),
+		transmissionRetryPolicy:       transmissionRetryPolicy,
+
+		staleMessageDrops: staleMessageDrops{},
 	}
 	o.run()
 }
@@ -54,20 +85,34 @@ func RunOracle[RI any](
 type oracleState[RI any] struct {
 	ctx context.Context
 
-	config              ocr3config.SharedConfig
-	contractTransmitter ocr3types.ContractTransmitter[RI]
-	database            Database
-	id                  commontypes.OracleID
-	localConfig         types.LocalConfig
-	logger              loghelper.LoggerWithContext
-	netEndpoint         NetworkEndpoint[RI]
-	offchainKeyring     types.OffchainKeyring
-	onchainKeyring      ocr3types.OnchainKeyring[RI]
-	reportingPlugin     ocr3types.ReportingPlugin[RI]
-	telemetrySender     TelemetrySender
-
+	chForceEpochChange            <-chan struct{}
+	chRetransmissionRequests      <-chan retransmission.Request
+	config                        ocr3config.SharedConfig
+	contractTransmitter           ocr3types.ContractTransmitter[RI]
+	database                      Database
+	id                            commontypes.OracleID
+	instanceStatus                *InstanceStatus
+	latestReportCache             *latestreportcache.Cache
+	latestReportCacheDestinations []ocr3types.ReportDestination
+	localConfig                   types.LocalConfig
+	logger                        loghelper.LoggerWithContext
+	metrics                       *Metrics
+	netEndpoint                   NetworkEndpoint[RI]
+	offchainKeyring               types.OffchainKeyring
+	onchainKeyring                ocr3types.OnchainKeyring[RI]
+	reportingPlugin               ocr3types.ReportingPluginV2[RI]
+	roundAbandonmentListener      ocr3types.RoundAbandonmentListener
+	telemetrySender               TelemetrySender
+	timeSource                    ocr3types.TimeSource
+	tracing                       *Tracing
+	transmissionRetryPolicy       transmissionretry.Policy
+
+	staleMessageDrops        staleMessageDrops
+	staleMessageTaper        loghelper.LogarithmicTaper
+	reachability             *reachabilityMonitor
 	chNetToPacemaker         chan<- MessageToPacemakerWithSender[RI]
 	chNetToOutcomeGeneration chan<- MessageToOutcomeGenerationWithSender[RI]
+	chNetToStateSync         chan<- MessageToStateSyncWithSender[RI]
 	chNetToReportAttestation chan<- MessageToReportAttestationWithSender[RI]
 	childCancel              context.CancelFunc
 	childCtx                 context.Context
@@ -124,6 +169,9 @@ func (o *oracleState[RI]) run() {
 	chNetToOutcomeGeneration := make(chan MessageToOutcomeGenerationWithSender[RI])
 	o.chNetToOutcomeGeneration = chNetToOutcomeGeneration
 
+	chNetToStateSync := make(chan MessageToStateSyncWithSender[RI])
+	o.chNetToStateSync = chNetToStateSync
+
 	chPacemakerToOutcomeGeneration := make(chan EventToOutcomeGeneration[RI])
 
 	chOutcomeGenerationToPacemaker := make(chan EventToPacemaker[RI])
@@ -138,7 +186,13 @@ func (o *oracleState[RI]) run() {
 	o.childCtx, o.childCancel = context.WithCancel(context.Background())
 	defer o.childCancel()
 
-	paceState, cert, err := o.restoreFromDatabase()
+	// Transmission gets its own context, so that it can outlive the rest of
+	// the protocol instance for up to HotConfigSwap.GracePeriod.
+	transmissionCtx, transmissionCancel := context.WithCancel(context.Background())
+	defer transmissionCancel()
+	chTransmissionDrain := make(chan struct{})
+
+	paceState, cert, restoredPassivity, err := o.restoreFromDatabase()
 	if err != nil {
 		o.logger.Info("restoreFromDatabase returned an error, exiting oracle", commontypes.LogFields{
 			"error": err,
@@ -153,6 +207,7 @@ func (o *oracleState[RI]) run() {
 			chNetToPacemaker,
 			chPacemakerToOutcomeGeneration,
 			chOutcomeGenerationToPacemaker,
+			o.chForceEpochChange,
 			o.config,
 			o.database,
 			o.id,
@@ -161,8 +216,10 @@ func (o *oracleState[RI]) run() {
 			o.netEndpoint,
 			o.offchainKeyring,
 			o.telemetrySender,
+			o.timeSource,
 
 			paceState,
+			restoredPassivity,
 		)
 	})
 	o.subprocesses.Go(func() {
@@ -170,18 +227,24 @@ func (o *oracleState[RI]) run() {
 			o.childCtx,
 
 			chNetToOutcomeGeneration,
+			chNetToStateSync,
 			chPacemakerToOutcomeGeneration,
 			chOutcomeGenerationToPacemaker,
 			chOutcomeGenerationToReportAttestation,
 			o.config,
 			o.database,
 			o.id,
+			o.instanceStatus,
 			o.localConfig,
 			o.logger,
+			o.metrics,
 			o.netEndpoint,
 			o.offchainKeyring,
 			o.reportingPlugin,
+			o.roundAbandonmentListener,
 			o.telemetrySender,
+			o.timeSource,
+			o.tracing,
 
 			cert,
 		)
@@ -197,26 +260,56 @@ func (o *oracleState[RI]) run() {
 			o.config,
 			o.contractTransmitter,
 			o.logger,
+			o.metrics,
 			o.netEndpoint,
 			o.onchainKeyring,
 			o.reportingPlugin,
+			o.telemetrySender,
+			o.tracing,
 		)
 	})
 	o.subprocesses.Go(func() {
 		RunTransmission(
-			o.childCtx,
+			transmissionCtx,
 			&o.subprocesses,
 
+			chTransmissionDrain,
 			chReportAttestationToTransmission,
+			o.chRetransmissionRequests,
 			o.config,
 			o.contractTransmitter,
 			o.id,
+			o.latestReportCache,
+			o.latestReportCacheDestinations,
 			o.localConfig,
 			o.logger,
+			o.metrics,
 			o.reportingPlugin,
+			o.telemetrySender,
+			o.tracing,
+			o.transmissionRetryPolicy,
 		)
 	})
 
+	var chReachabilityCheck <-chan time.Time
+	if o.localConfig.DegradedMode.Threshold != 0 {
+		o.reachability = newReachabilityMonitor(
+			o.localConfig.DegradedMode.Threshold,
+			o.config.N(),
+			o.config.ByzQuorumSize(),
+			int(o.id),
+			time.Now(),
+		)
+		ticker := time.NewTicker(o.reachability.checkInterval())
+		defer ticker.Stop()
+		chReachabilityCheck = ticker.C
+		defer func() {
+			if o.reachability.degraded {
+				o.setDegraded(false, 0)
+			}
+		}()
+	}
+
 	chNet := o.netEndpoint.Receive()
 
 	chDone := o.ctx.Done()
@@ -227,13 +320,23 @@ func (o *oracleState[RI]) run() {
 			// responsibility to only provide valid senders. We perform it for
 			// defense-in-depth.
 			if 0 <= int(msg.Sender) && int(msg.Sender) < o.config.N() {
-				msg.Msg.process(o, msg.Sender)
+				if o.reachability != nil {
+					o.reachability.heard(int(msg.Sender), time.Now())
+				}
+				if o.checkFreshness(msg) {
+					msg.Msg.process(o, msg.Sender)
+				}
 			} else {
 				o.logger.Critical("msg.Sender out of bounds. This should *never* happen.", commontypes.LogFields{
 					"sender": msg.Sender,
 					"n":      o.config.N(),
 				})
 			}
+		case <-chReachabilityCheck:
+			reachable, changed := o.reachability.check(time.Now())
+			if changed {
+				o.setDegraded(o.reachability.degraded, reachable)
+			}
 		case <-chDone:
 		}
 
@@ -242,6 +345,13 @@ func (o *oracleState[RI]) run() {
 		case <-chDone:
 			o.logger.Debug("Oracle: winding down", nil)
 			o.childCancel()
+			if gracePeriod := o.localConfig.HotConfigSwap.GracePeriod; gracePeriod > 0 {
+				close(chTransmissionDrain)
+				timer := time.AfterFunc(gracePeriod, transmissionCancel)
+				defer timer.Stop()
+			} else {
+				transmissionCancel()
+			}
 			o.subprocesses.Wait()
 			o.logger.Debug("Oracle: exiting", nil)
 			return
@@ -250,65 +360,97 @@ func (o *oracleState[RI]) run() {
 	}
 }
 
-func tryUntilSuccess[T any](ctx context.Context, logger commontypes.Logger, retryPeriod time.Duration, fnTimeout time.Duration, fnName string, fn func(context.Context) (T, error)) (T, error) {
-	for {
-		var result T
-		var err error
-		func() {
+func (o *oracleState[RI]) setDegraded(degraded bool, reachable int) {
+	logFields := commontypes.LogFields{
+		"reachableOracles": reachable,
+		"quorumSize":       o.config.ByzQuorumSize(),
+		"threshold":        o.localConfig.DegradedMode.Threshold.String(),
+		"behavior":         o.localConfig.DegradedMode.Behavior.String(),
+	}
+	if degraded {
+		o.logger.Warn("Oracle: entering degraded mode, no quorum of oracles is reachable", logFields)
+	} else {
+		o.logger.Info("Oracle: leaving degraded mode", logFields)
+	}
+	o.instanceStatus.setDegraded(degraded)
+	o.metrics.SetDegraded(degraded)
+	o.telemetrySender.DegradedModeChanged(o.config.ConfigDigest, degraded, reachable)
+}
+
+func tryUntilSuccess[T any](ctx context.Context, logger commontypes.Logger, retrySchedule backoff.Schedule, fnTimeout time.Duration, fnName string, fn func(context.Context) (T, error)) (T, error) {
+	return backoff.Retry(
+		ctx,
+		retrySchedule,
+		func(ctx context.Context) (T, error) {
 			fnCtx, cancel := context.WithTimeout(ctx, fnTimeout)
 			defer cancel()
-			result, err = fn(fnCtx)
-		}()
-		if err == nil {
-			return result, nil
-		}
-		logger.Error(fmt.Sprintf("error during %s, retrying", fnName), commontypes.LogFields{
-			"error":       err,
-			"retryPeriod": retryPeriod.String(),
-		})
-
-		select {
-		case <-time.After(retryPeriod):
-		case <-ctx.Done():
-			var zero T
-			return zero, ctx.Err()
-		}
-	}
+			return fn(fnCtx)
+		},
+		func(err error, delay time.Duration) {
+			logger.Error(fmt.Sprintf("error during %s, retrying", fnName), commontypes.LogFields{
+				"error":         err,
+				"retryDelay":    delay.String(),
+				"retrySchedule": retrySchedule.String(),
+			})
+		},
+	)
 }
 
-func (o *oracleState[RI]) restoreFromDatabase() (PacemakerState, CertifiedPrepareOrCommit, error) {
-	const retryPeriod = 5 * time.Second
+func (o *oracleState[RI]) restoreFromDatabase() (PacemakerState, CertifiedPrepareOrCommit, passivity, error) {
+	retrySchedule := backoff.Schedule{500 * time.Millisecond, 5 * time.Second, 2, 0.2}
 
 	paceState, err := tryUntilSuccess[PacemakerState](
 		o.ctx,
 		o.logger,
-		retryPeriod,
+		retrySchedule,
 		o.localConfig.DatabaseTimeout,
 		"Database.ReadPacemakerState",
 		func(ctx context.Context) (PacemakerState, error) {
-			return o.database.ReadPacemakerState(ctx, o.config.ConfigDigest)
+			state, err := o.database.ReadPacemakerState(ctx, o.config.ConfigDigest)
+			return state, o.permanentIfCorrupted(err)
 		},
 	)
-	if err != nil {
-		return PacemakerState{}, nil, err
+	restoredPassivity := passivity{}
+	var corrupted *CorruptedStateError
+	if errors.As(err, &corrupted) {
+		if err := o.quarantine(retrySchedule, corrupted); err != nil {
+			return PacemakerState{}, nil, passivity{}, err
+		}
+		paceState = PacemakerState{}
+		restoredPassivity.passive = true
+	} else if err != nil {
+		return PacemakerState{}, nil, passivity{}, err
+	} else {
+		o.logger.Info("restoreFromDatabase: successfully restored pacemaker state", commontypes.LogFields{
+			"state": paceState,
+		})
 	}
 
-	o.logger.Info("restoreFromDatabase: successfully restored pacemaker state", commontypes.LogFields{
-		"state": paceState,
-	})
-
 	cert, err := tryUntilSuccess[CertifiedPrepareOrCommit](
 		o.ctx,
 		o.logger,
-		retryPeriod,
+		retrySchedule,
 		o.localConfig.DatabaseTimeout,
 		"Database.ReadCert",
 		func(ctx context.Context) (CertifiedPrepareOrCommit, error) {
-			return o.database.ReadCert(ctx, o.config.ConfigDigest)
+			cert, err := o.database.ReadCert(ctx, o.config.ConfigDigest)
+			return cert, o.permanentIfCorrupted(err)
 		},
 	)
-	if err != nil {
-		return PacemakerState{}, nil, err
+	if errors.As(err, &corrupted) {
+		if err := o.quarantine(retrySchedule, corrupted); err != nil {
+			return PacemakerState{}, nil, passivity{}, err
+		}
+		cert = nil
+		if !restoredPassivity.passive {
+			// the pacemaker persists every epoch before we sign anything in it,
+			// so we can't have signed in later epochs. If it never persisted
+			// anything, the zero epoch makes the pacemaker derive the epoch
+			// from the network.
+			restoredPassivity = passivity{true, paceState.Epoch}
+		}
+	} else if err != nil {
+		return PacemakerState{}, nil, passivity{}, err
 	}
 
 	if cert != nil {
@@ -320,5 +462,43 @@ func (o *oracleState[RI]) restoreFromDatabase() (PacemakerState, CertifiedPrepar
 		cert = &CertifiedCommit{}
 	}
 
-	return paceState, cert, nil
+	return paceState, cert, restoredPassivity, nil
+}
+
+// permanentIfCorrupted stops the retries of a read that found corrupted
+// state if the state is to be quarantined. Otherwise, the read is retried
+// until an operator repairs the state.
+func (o *oracleState[RI]) permanentIfCorrupted(err error) error {
+	var corrupted *CorruptedStateError
+	if o.localConfig.QuarantineCorruptedProtocolState && errors.As(err, &corrupted) {
+		return backoff.Permanent(err)
+	}
+	return err
+}
+
+// quarantine moves corrupted state out of the way, so that the instance starts
+// from a fresh state instead of failing to start over and over again. Since
+// the oracle has forgotten what it signed, the instance stays passive until it
+// has moved past every epoch it may have signed in, see passivity.
+func (o *oracleState[RI]) quarantine(retrySchedule backoff.Schedule, corrupted *CorruptedStateError) error {
+	o.logger.Error("restoreFromDatabase: persisted state is corrupted, quarantining it and starting from a fresh state", commontypes.LogFields{
+		"key":   corrupted.Key,
+		"error": corrupted.Err,
+	})
+	_, err := tryUntilSuccess[struct{}](
+		o.ctx,
+		o.logger,
+		retrySchedule,
+		o.localConfig.DatabaseTimeout,
+		"Database.QuarantineCorruptedState",
+		func(ctx context.Context) (struct{}, error) {
+			return struct{}{}, o.database.QuarantineCorruptedState(ctx, o.config.ConfigDigest, corrupted)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	o.instanceStatus.setRecoveredFromCorruptedState()
+	o.telemetrySender.CorruptedStateQuarantined(o.config.ConfigDigest, corrupted.Key)
+	return nil
 }
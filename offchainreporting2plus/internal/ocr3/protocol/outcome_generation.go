@@ -2,6 +2,8 @@ package protocol
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
@@ -10,6 +12,7 @@ import (
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol/pool"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Identifies an instance of the outcome generation protocol
@@ -25,57 +28,155 @@ func RunOutcomeGeneration[RI any](
 	ctx context.Context,
 
 	chNetToOutcomeGeneration <-chan MessageToOutcomeGenerationWithSender[RI],
+	chNetToStateSync <-chan MessageToStateSyncWithSender[RI],
 	chPacemakerToOutcomeGeneration <-chan EventToOutcomeGeneration[RI],
 	chOutcomeGenerationToPacemaker chan<- EventToPacemaker[RI],
 	chOutcomeGenerationToReportAttestation chan<- EventToReportAttestation[RI],
 	config ocr3config.SharedConfig,
 	database Database,
 	id commontypes.OracleID,
+	instanceStatus *InstanceStatus,
 	localConfig types.LocalConfig,
 	logger loghelper.LoggerWithContext,
+	metrics *Metrics,
 	netSender NetworkSender[RI],
 	offchainKeyring types.OffchainKeyring,
-	reportingPlugin ocr3types.ReportingPlugin[RI],
+	reportingPlugin ocr3types.ReportingPluginV2[RI],
+	roundAbandonmentListener ocr3types.RoundAbandonmentListener,
 	telemetrySender TelemetrySender,
+	timeSource ocr3types.TimeSource,
+	tracing *Tracing,
 
 	restoredCert CertifiedPrepareOrCommit,
 ) {
-	outgen := outcomeGenerationState[RI]{
+	outgen := newOutcomeGenerationState[RI](
+		ctx,
+
+		chNetToOutcomeGeneration,
+		chNetToStateSync,
+		chPacemakerToOutcomeGeneration,
+		chOutcomeGenerationToPacemaker,
+		chOutcomeGenerationToReportAttestation,
+		config,
+		database,
+		id,
+		instanceStatus,
+		localConfig,
+		logger,
+		metrics,
+		netSender,
+		offchainKeyring,
+		reportingPlugin,
+		roundAbandonmentListener,
+		telemetrySender,
+		timeSource,
+		tracing,
+	)
+	outgen.run(restoredCert)
+}
+
+func newOutcomeGenerationState[RI any](
+	ctx context.Context,
+
+	chNetToOutcomeGeneration <-chan MessageToOutcomeGenerationWithSender[RI],
+	chNetToStateSync <-chan MessageToStateSyncWithSender[RI],
+	chPacemakerToOutcomeGeneration <-chan EventToOutcomeGeneration[RI],
+	chOutcomeGenerationToPacemaker chan<- EventToPacemaker[RI],
+	chOutcomeGenerationToReportAttestation chan<- EventToReportAttestation[RI],
+	config ocr3config.SharedConfig,
+	database Database,
+	id commontypes.OracleID,
+	instanceStatus *InstanceStatus,
+	localConfig types.LocalConfig,
+	logger loghelper.LoggerWithContext,
+	metrics *Metrics,
+	netSender NetworkSender[RI],
+	offchainKeyring types.OffchainKeyring,
+	reportingPlugin ocr3types.ReportingPluginV2[RI],
+	roundAbandonmentListener ocr3types.RoundAbandonmentListener,
+	telemetrySender TelemetrySender,
+	timeSource ocr3types.TimeSource,
+	tracing *Tracing,
+) *outcomeGenerationState[RI] {
+	return &outcomeGenerationState[RI]{
 		ctx: ctx,
 
 		chNetToOutcomeGeneration:               chNetToOutcomeGeneration,
+		chNetToStateSync:                       chNetToStateSync,
 		chPacemakerToOutcomeGeneration:         chPacemakerToOutcomeGeneration,
 		chOutcomeGenerationToPacemaker:         chOutcomeGenerationToPacemaker,
 		chOutcomeGenerationToReportAttestation: chOutcomeGenerationToReportAttestation,
 		config:                                 config,
 		database:                               database,
 		id:                                     id,
+		instanceStatus:                         instanceStatus,
 		localConfig:                            localConfig,
 		logger:                                 logger.MakeUpdated(commontypes.LogFields{"proto": "outgen"}),
+		metrics:                                metrics,
 		netSender:                              netSender,
 		offchainKeyring:                        offchainKeyring,
 		reportingPlugin:                        reportingPlugin,
+		roundAbandonmentListener:               roundAbandonmentListener,
 		telemetrySender:                        telemetrySender,
+		timeSource:                             timeSource,
+		tracing:                                tracing,
+
+		queryLessRounds:       config.FeatureFlags.Has(ocr3types.ProtocolFeatureFlagQueryLessRounds),
+		observationTimestamps: config.FeatureFlags.Has(ocr3types.ProtocolFeatureFlagObservationTimestamps),
+		observationSampling:   config.FeatureFlags.Has(ocr3types.ProtocolFeatureFlagObservationSampling),
+
+		observationQuarantine: newObservationQuarantine(config.N()),
+		graceTuner: newGraceTuner(
+			config.DeltaGraceAutoTuning(),
+			config.DeltaGrace,
+			config.DeltaGraceMin,
+			config.DeltaGraceMax,
+		),
+		roundPacer: newRoundPacer(
+			config.AdaptiveRoundPacing(),
+			config.DeltaRound,
+			config.DeltaRoundMin,
+			config.DeltaRoundMax,
+		),
+		stateSync: newStateSyncState(config.N()),
 	}
-	outgen.run(restoredCert)
 }
 
 type outcomeGenerationState[RI any] struct {
 	ctx context.Context
 
 	chNetToOutcomeGeneration               <-chan MessageToOutcomeGenerationWithSender[RI]
+	chNetToStateSync                       <-chan MessageToStateSyncWithSender[RI]
 	chPacemakerToOutcomeGeneration         <-chan EventToOutcomeGeneration[RI]
 	chOutcomeGenerationToPacemaker         chan<- EventToPacemaker[RI]
 	chOutcomeGenerationToReportAttestation chan<- EventToReportAttestation[RI]
 	config                                 ocr3config.SharedConfig
 	database                               Database
 	id                                     commontypes.OracleID
+	instanceStatus                         *InstanceStatus
 	localConfig                            types.LocalConfig
 	logger                                 loghelper.LoggerWithContext
+	metrics                                *Metrics
 	netSender                              NetworkSender[RI]
 	offchainKeyring                        types.OffchainKeyring
-	reportingPlugin                        ocr3types.ReportingPlugin[RI]
-	telemetrySender                        TelemetrySender
+	reportingPlugin                        ocr3types.ReportingPluginV2[RI]
+	// nil if the plugin doesn't implement it
+	roundAbandonmentListener ocr3types.RoundAbandonmentListener
+	telemetrySender          TelemetrySender
+	timeSource               ocr3types.TimeSource
+	tracing                  *Tracing
+
+	// See ocr3types.ProtocolFeatureFlagQueryLessRounds
+	queryLessRounds bool
+	// See ocr3types.ProtocolFeatureFlagObservationTimestamps
+	observationTimestamps bool
+	// See ocr3types.ProtocolFeatureFlagObservationSampling
+	observationSampling bool
+
+	observationQuarantine *observationQuarantine
+	graceTuner            *graceTuner
+	roundPacer            *roundPacer
+	stateSync             *stateSyncState
 
 	bufferedMessages []*MessageBuffer[RI]
 	leaderState      leaderState[RI]
@@ -90,10 +191,28 @@ type leaderState[RI any] struct {
 
 	readyToStartRound bool // TODO: explain meaning of this vs design doc
 	tRound            <-chan time.Time
+	// time at which the current round was started, used to measure its
+	// quorum latency
+	roundStartedAt time.Time
 
 	query        types.Query
-	observations map[commontypes.OracleID]*SignedObservation
+	observations map[commontypes.OracleID]*AttributedSignedObservation
 	tGrace       <-chan time.Time
+	// grace period of the current round, see graceTuner
+	deltaGrace time.Duration
+
+	// spans the time from starting a round until broadcasting its
+	// MessageProposal, nil if there is no such round
+	observationCollectionSpan trace.Span
+
+	// Only used in query-less rounds: observations that arrived before the
+	// leader started the round they belong to, at most one per sender
+	earlyObservations map[commontypes.OracleID]earlyObservation[RI]
+}
+
+type earlyObservation[RI any] struct {
+	message    MessageObservation[RI]
+	receivedAt time.Time
 }
 
 type epochStartRequest[RI any] struct {
@@ -106,6 +225,10 @@ type followerState[RI any] struct {
 
 	tInitial <-chan time.Time
 
+	// Only used in query-less rounds: fires DeltaRound after the follower
+	// started its previous round. Set to nil once fired.
+	tRound <-chan time.Time
+
 	roundStartPool *pool.Pool[MessageRoundStart[RI]]
 
 	query *types.Query
@@ -136,12 +259,67 @@ type sharedState struct {
 	observationQuorum *int
 	committedSeqNr    uint64
 	committedOutcome  ocr3types.Outcome
+	// hash of committedOutcome
+	committedOutcomeHash ocr3types.OutcomeHash
+	// time at which we committed committedSeqNr, zero if we haven't
+	// committed anything since starting
+	committedTime time.Time
+	// whether we must not sign anything or lead in the current epoch, see
+	// EventNewEpochStart
+	passive bool
+}
+
+func (outgen *outcomeGenerationState[RI]) timerExpired(timer Timer) {
+	outgen.telemetrySender.TimerExpired(outgen.config.ConfigDigest, outgen.sharedState.e, outgen.sharedState.seqNr, timer)
 }
 
 // Run starts the event loop for the report-generation protocol
 func (outgen *outcomeGenerationState[RI]) run(restoredCert CertifiedPrepareOrCommit) {
 	outgen.logger.Info("OutcomeGeneration: running", nil)
 
+	outgen.initialize(restoredCert)
+
+	// Event Loop
+	chDone := outgen.ctx.Done()
+	for {
+		select {
+		case msg := <-outgen.chNetToOutcomeGeneration:
+			outgen.messageToOutcomeGeneration(msg)
+		case msg := <-outgen.chNetToStateSync:
+			msg.msg.processStateSync(outgen, msg.sender)
+		case ev := <-outgen.chPacemakerToOutcomeGeneration:
+			ev.processOutcomeGeneration(outgen)
+		case <-outgen.followerState.tInitial:
+			outgen.timerExpired(TimerInitial)
+			outgen.eventTInitialTimeout()
+		case <-outgen.followerState.tRound:
+			outgen.timerExpired(TimerFollowerRound)
+			outgen.eventFollowerTRoundTimeout()
+		case <-outgen.leaderState.tGrace:
+			outgen.timerExpired(TimerGrace)
+			outgen.eventTGraceTimeout()
+		case <-outgen.leaderState.tRound:
+			outgen.timerExpired(TimerLeaderRound)
+			outgen.eventTRoundTimeout()
+		case <-chDone:
+		}
+
+		// ensure prompt exit
+		select {
+		case <-chDone:
+			outgen.endObservationCollectionSpan("oracle shut down")
+			outgen.logger.Info("OutcomeGeneration: exiting", commontypes.LogFields{
+				"e": outgen.sharedState.e,
+				"l": outgen.sharedState.l,
+			})
+			return
+		default:
+		}
+	}
+}
+
+// initialize sets up the state of outgen before the first epoch starts.
+func (outgen *outcomeGenerationState[RI]) initialize(restoredCert CertifiedPrepareOrCommit) {
 	for i := 0; i < outgen.config.N(); i++ {
 		outgen.bufferedMessages = append(outgen.bufferedMessages, NewMessageBuffer[RI](futureMessageBufferSize))
 	}
@@ -152,9 +330,13 @@ func (outgen *outcomeGenerationState[RI]) run(restoredCert CertifiedPrepareOrCom
 		map[commontypes.OracleID]*epochStartRequest[RI]{},
 		false,
 		nil,
+		time.Time{},
 		nil,
 		nil,
 		nil,
+		0,
+		nil,
+		map[commontypes.OracleID]earlyObservation[RI]{},
 	}
 
 	outgen.followerState = followerState[RI]{
@@ -163,6 +345,7 @@ func (outgen *outcomeGenerationState[RI]) run(restoredCert CertifiedPrepareOrCom
 		nil,
 		nil,
 		nil,
+		nil,
 		outcomeAndDigests{},
 		restoredCert,
 		nil,
@@ -178,39 +361,22 @@ func (outgen *outcomeGenerationState[RI]) run(restoredCert CertifiedPrepareOrCom
 		nil,
 		0,
 		nil,
+		ocr3types.MakeOutcomeHash(nil),
+		time.Time{},
+		false,
 	}
 
-	// Event Loop
-	chDone := outgen.ctx.Done()
-	for {
-		select {
-		case msg := <-outgen.chNetToOutcomeGeneration:
-			outgen.messageToOutcomeGeneration(msg)
-		case ev := <-outgen.chPacemakerToOutcomeGeneration:
-			ev.processOutcomeGeneration(outgen)
-		case <-outgen.followerState.tInitial:
-			outgen.eventTInitialTimeout()
-		case <-outgen.leaderState.tGrace:
-			outgen.eventTGraceTimeout()
-		case <-outgen.leaderState.tRound:
-			outgen.eventTRoundTimeout()
-		case <-chDone:
-		}
-
-		// ensure prompt exit
-		select {
-		case <-chDone:
-			outgen.logger.Info("OutcomeGeneration: exiting", commontypes.LogFields{
-				"e": outgen.sharedState.e,
-				"l": outgen.sharedState.l,
-			})
-			return
-		default:
-		}
+	if commitQC, ok := restoredCert.(*CertifiedCommit); ok && !commitQC.IsGenesis() {
+		outgen.stateSync.latestCertifiedCommit = commitQC
 	}
 }
 
 func (outgen *outcomeGenerationState[RI]) messageToOutcomeGeneration(msg MessageToOutcomeGenerationWithSender[RI]) {
+	if commitMsg, ok := msg.msg.(MessageCommit[RI]); ok {
+		// regardless of epoch, see state_sync.go
+		outgen.observeCommitSeqNr(commitMsg.SeqNr, msg.sender)
+	}
+
 	msgEpoch := msg.msg.epoch()
 	if msgEpoch < outgen.sharedState.e {
 		// drop
@@ -260,14 +426,72 @@ func (outgen *outcomeGenerationState[RI]) unbufferMessages() {
 	outgen.logger.Trace("done unbuffering messages for new epoch", nil)
 }
 
+// abandonedRoundReason returns why the current round of the ending epoch
+// didn't commit, if this oracle took part in it, see
+// ocr3types.RoundAbandonmentListener.
+func (outgen *outcomeGenerationState[RI]) abandonedRoundReason() (ocr3types.RoundAbandonmentReason, bool) {
+	if outgen.sharedState.seqNr <= outgen.sharedState.committedSeqNr {
+		return 0, false
+	}
+	if outgen.id == outgen.sharedState.l && outgen.leaderState.phase == outgenLeaderPhaseSentRoundStart {
+		return ocr3types.RoundAbandonmentReasonInsufficientObservations, true
+	}
+	switch outgen.followerState.phase {
+	case outgenFollowerPhaseSentObservation:
+		return ocr3types.RoundAbandonmentReasonNoProposal, true
+	case outgenFollowerPhaseSentPrepare:
+		return ocr3types.RoundAbandonmentReasonInsufficientPrepares, true
+	case outgenFollowerPhaseSentCommit:
+		return ocr3types.RoundAbandonmentReasonInsufficientCommits, true
+	}
+	return 0, false
+}
+
 func (outgen *outcomeGenerationState[RI]) eventNewEpochStart(ev EventNewEpochStart[RI]) {
+	if outgen.id == outgen.sharedState.l && outgen.leaderState.phase == outgenLeaderPhaseSentRoundStart {
+		// We were leader of the previous epoch and still waiting for a quorum
+		// of observations
+		outgen.telemetrySender.ProtocolError(
+			outgen.config.ConfigDigest,
+			outgen.sharedState.e,
+			outgen.sharedState.seqNr,
+			ProtocolErrorCodeInsufficientObservations,
+		)
+	}
+	if reason, ok := outgen.abandonedRoundReason(); ok {
+		outgen.logger.Debug("abandoning uncommitted round of ending epoch", commontypes.LogFields{
+			"seqNr":  outgen.sharedState.seqNr,
+			"reason": reason.String(),
+		})
+		if outgen.roundAbandonmentListener != nil {
+			seqNr := outgen.sharedState.seqNr
+			callPlugin[struct{}](
+				outgen.ctx,
+				outgen.logger,
+				outgen.metrics,
+				outgen.tracing,
+				seqNr,
+				commontypes.LogFields{
+					"seqNr":  seqNr,
+					"reason": reason.String(),
+				},
+				"RoundAbandoned",
+				0, // RoundAbandoned should finish "instantly"
+				func(ctx context.Context) (struct{}, error) {
+					return struct{}{}, outgen.roundAbandonmentListener.RoundAbandoned(ctx, seqNr, reason)
+				},
+			)
+		}
+	}
+
 	// Initialization
 	outgen.logger.Info("starting new epoch", commontypes.LogFields{
 		"epoch": ev.Epoch,
 	})
 
 	outgen.sharedState.e = ev.Epoch
-	outgen.sharedState.l = Leader(outgen.sharedState.e, outgen.config.N(), outgen.config.LeaderSelectionKey())
+	outgen.sharedState.passive = ev.Passive
+	outgen.sharedState.l = Leader(outgen.sharedState.e, outgen.config.N(), outgen.config.LeaderWeights, outgen.config.LeaderSelectionKey())
 
 	outgen.logger = outgen.logger.MakeUpdated(commontypes.LogFields{
 		"e": outgen.sharedState.e,
@@ -277,8 +501,18 @@ func (outgen *outcomeGenerationState[RI]) eventNewEpochStart(ev EventNewEpochSta
 	outgen.sharedState.firstSeqNrOfEpoch = 0
 	outgen.sharedState.seqNr = 0
 
+	if outgen.roundPacer.epochEnded() {
+		outgen.logger.Info("lengthened round interval after rounds failed to commit", commontypes.LogFields{
+			"deltaRound": outgen.roundPacer.interval().String(),
+		})
+	}
+
 	outgen.followerState.phase = outgenFollowerPhaseNewEpoch
-	outgen.followerState.tInitial = time.After(outgen.config.DeltaInitial)
+	outgen.followerState.tInitial = after(outgen.timeSource, outgen.config.DeltaInitial)
+	outgen.followerState.tRound = nil
+	if outgen.queryLessRounds {
+		outgen.followerState.tRound = after(outgen.timeSource, outgen.roundPacer.interval())
+	}
 	outgen.followerState.outcome = outcomeAndDigests{}
 
 	outgen.followerState.roundStartPool = pool.NewPool[MessageRoundStart[RI]](poolSize)
@@ -286,10 +520,20 @@ func (outgen *outcomeGenerationState[RI]) eventNewEpochStart(ev EventNewEpochSta
 	outgen.followerState.preparePool = pool.NewPool[PrepareSignature](poolSize)
 	outgen.followerState.commitPool = pool.NewPool[CommitSignature](poolSize)
 
+	outgen.endObservationCollectionSpan("epoch ended")
 	outgen.leaderState.phase = outgenLeaderPhaseNewEpoch
 	outgen.leaderState.epochStartRequests = map[commontypes.OracleID]*epochStartRequest[RI]{}
 	outgen.leaderState.readyToStartRound = false
 	outgen.leaderState.tGrace = nil
+	outgen.leaderState.earlyObservations = map[commontypes.OracleID]earlyObservation[RI]{}
+
+	if outgen.sharedState.passive {
+		// our cert may be older than one we signed before quarantining
+		// corrupted state, so we must not vouch for it
+		outgen.logger.Warn("staying passive in epoch, since we may have signed messages in it before quarantining corrupted state", nil)
+		outgen.unbufferMessages()
+		return
+	}
 
 	var highestCertified CertifiedPrepareOrCommit
 	var highestCertifiedTimestamp HighestCertifiedTimestamp
@@ -318,7 +562,7 @@ func (outgen *outcomeGenerationState[RI]) eventNewEpochStart(ev EventNewEpochSta
 	}, outgen.sharedState.l)
 
 	if outgen.id == outgen.sharedState.l {
-		outgen.leaderState.tRound = time.After(outgen.config.DeltaRound)
+		outgen.leaderState.tRound = after(outgen.timeSource, outgen.roundPacer.interval())
 	}
 
 	outgen.unbufferMessages()
@@ -341,7 +585,34 @@ func (outgen *outcomeGenerationState[RI]) OutcomeCtx(seqNr uint64) ocr3types.Out
 		outgen.sharedState.committedOutcome,
 		uint64(outgen.sharedState.e),
 		seqNr - outgen.sharedState.firstSeqNrOfEpoch + 1,
+		outgen.sharedState.l,
+		outgen.id == outgen.sharedState.l,
+		outgen.sharedState.committedOutcomeHash,
+		time.Time{},
+	}
+}
+
+// now returns the time for timestamps attached to observations, see
+// ocr3types.TimeSource.
+func (outgen *outcomeGenerationState[RI]) now() time.Time {
+	if outgen.timeSource != nil {
+		return outgen.timeSource.Now()
+	}
+	return time.Now()
+}
+
+// medianObservedAt returns the upper median of the ObservedAt of
+// observationTimestamps, or the zero time if observationTimestamps is empty.
+func medianObservedAt(observationTimestamps map[commontypes.OracleID]ocr3types.ObservationTimestamps) time.Time {
+	if len(observationTimestamps) == 0 {
+		return time.Time{}
+	}
+	observedAts := make([]time.Time, 0, len(observationTimestamps))
+	for _, ts := range observationTimestamps {
+		observedAts = append(observedAts, ts.ObservedAt)
 	}
+	sort.Slice(observedAts, func(i, j int) bool { return observedAts[i].Before(observedAts[j]) })
+	return observedAts[len(observedAts)/2]
 }
 
 func (outgen *outcomeGenerationState[RI]) ObservationQuorum(query types.Query) (quorum int, ok bool) {
@@ -355,7 +626,7 @@ func (outgen *outcomeGenerationState[RI]) ObservationQuorum(query types.Query) (
 		0, // pure function
 		outgen.OutcomeCtx(outgen.sharedState.seqNr),
 		func(ctx context.Context, outctx ocr3types.OutcomeContext) (ocr3types.Quorum, error) {
-			return outgen.reportingPlugin.ObservationQuorum(outctx, query)
+			return outgen.reportingPlugin.ObservationQuorum(ctx, outctx, query)
 		},
 	)
 
@@ -363,27 +634,14 @@ func (outgen *outcomeGenerationState[RI]) ObservationQuorum(query types.Query) (
 		return 0, false
 	}
 
-	nMinusF := outgen.config.N() - outgen.config.F
-
-	switch observationQuorum {
-	case ocr3types.QuorumFPlusOne:
-		quorum = outgen.config.F + 1
-	case ocr3types.QuorumTwoFPlusOne:
-		quorum = 2*outgen.config.F + 1
-	case ocr3types.QuorumByzQuorum:
-		quorum = outgen.config.ByzQuorumSize()
-	case ocr3types.QuorumNMinusF:
-		quorum = nMinusF
-	default:
-		quorum = int(observationQuorum)
-	}
-
-	if !(0 < quorum && int(quorum) <= nMinusF) {
+	quorum, err := observationQuorum.Count(outgen.config.N(), outgen.config.F)
+	if err != nil {
 		outgen.logger.Error("invalid observation quorum", commontypes.LogFields{
-			"quorum":  quorum,
+			"error":   err,
+			"quorum":  int(observationQuorum),
 			"n":       outgen.config.N(),
 			"f":       outgen.config.F,
-			"nMinusF": nMinusF,
+			"nMinusF": outgen.config.N() - outgen.config.F,
 		})
 		return 0, false
 	}
@@ -403,6 +661,9 @@ func callPluginFromOutcomeGeneration[T any, RI any](
 	return callPlugin[T](
 		outgen.ctx,
 		outgen.logger,
+		outgen.metrics,
+		outgen.tracing,
+		outctx.SeqNr,
 		commontypes.LogFields{
 			"seqNr": outctx.SeqNr,
 			"round": outctx.Round, // nolint: staticcheck
@@ -414,3 +675,16 @@ func callPluginFromOutcomeGeneration[T any, RI any](
 		},
 	)
 }
+
+// checkObservedAt checks that so carries an ObservedAt iff observation
+// timestamps are enabled. The leader checks this, too, so that a faulty
+// observer cannot get the leader's proposal rejected by honest followers.
+func (outgen *outcomeGenerationState[RI]) checkObservedAt(so SignedObservation) error {
+	if outgen.observationTimestamps && so.ObservedAt.IsZero() {
+		return fmt.Errorf("missing ObservedAt even though observation timestamps are enabled")
+	}
+	if !outgen.observationTimestamps && !so.ObservedAt.IsZero() {
+		return fmt.Errorf("unexpected ObservedAt %v even though observation timestamps are disabled", so.ObservedAt)
+	}
+	return nil
+}
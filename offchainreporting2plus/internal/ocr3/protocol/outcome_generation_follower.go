@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"context"
+	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol/pool"
@@ -83,6 +84,13 @@ func (outgen *outcomeGenerationState[RI]) messageEpochStart(msg MessageEpochStar
 
 		prepareQc := msg.EpochStartProof.HighestCertified.(*CertifiedPrepare)
 
+		if outgen.sharedState.passive {
+			outgen.logger.Debug("not signing Prepare (reproposal) while passive", commontypes.LogFields{
+				"seqNr": prepareQc.SeqNr,
+			})
+			return
+		}
+
 		// We don't know the actual inputs, so we always use the empty OutcomeInputsDigest
 		// in case of a re-proposal.
 		outcomeInputsDigest := OutcomeInputsDigest{}
@@ -133,7 +141,41 @@ func (outgen *outcomeGenerationState[RI]) startSubsequentFollowerRound() {
 	outgen.followerState.query = nil
 	outgen.followerState.outcome = outcomeAndDigests{}
 
-	outgen.tryProcessRoundStartPool()
+	if outgen.queryLessRounds {
+		outgen.tryStartQueryLessFollowerRound()
+	} else {
+		outgen.tryProcessRoundStartPool()
+	}
+}
+
+func (outgen *outcomeGenerationState[RI]) eventFollowerTRoundTimeout() {
+	outgen.followerState.tRound = nil
+	outgen.tryStartQueryLessFollowerRound()
+}
+
+// In query-less rounds, there is no MessageRoundStart. Instead, followers
+// observe once the previous round has been committed and DeltaRound has passed
+// since they started the previous round, mirroring the conditions under which
+// the leader starts a round.
+func (outgen *outcomeGenerationState[RI]) tryStartQueryLessFollowerRound() {
+	if outgen.followerState.phase != outgenFollowerPhaseNewRound {
+		outgen.logger.Debug("cannot start query-less round, wrong phase", commontypes.LogFields{
+			"seqNr": outgen.sharedState.seqNr,
+			"phase": outgen.followerState.phase,
+		})
+		return
+	}
+
+	if outgen.followerState.tRound != nil {
+		outgen.logger.Debug("cannot start query-less round, TRound hasn't fired yet", commontypes.LogFields{
+			"seqNr": outgen.sharedState.seqNr,
+		})
+		return
+	}
+
+	outgen.followerState.tRound = after(outgen.timeSource, outgen.roundPacer.interval())
+	outgen.roundPacer.roundStarted(time.Now())
+	outgen.observe(types.Query{})
 }
 
 func (outgen *outcomeGenerationState[RI]) messageRoundStart(msg MessageRoundStart[RI], sender commontypes.OracleID) {
@@ -156,6 +198,15 @@ func (outgen *outcomeGenerationState[RI]) messageRoundStart(msg MessageRoundStar
 		return
 	}
 
+	if outgen.queryLessRounds {
+		outgen.logger.Warn("dropping MessageRoundStart, rounds are query-less", commontypes.LogFields{
+			"sender":   sender,
+			"seqNr":    outgen.sharedState.seqNr,
+			"msgSeqNr": msg.SeqNr,
+		})
+		return
+	}
+
 	if putResult := outgen.followerState.roundStartPool.Put(msg.SeqNr, sender, msg); putResult != pool.PutResultOK {
 		outgen.logger.Debug("dropping MessageRoundStart", commontypes.LogFields{
 			"seqNr":    outgen.sharedState.seqNr,
@@ -173,6 +224,11 @@ func (outgen *outcomeGenerationState[RI]) messageRoundStart(msg MessageRoundStar
 }
 
 func (outgen *outcomeGenerationState[RI]) tryProcessRoundStartPool() {
+	if outgen.queryLessRounds {
+		// the pool is unused
+		return
+	}
+
 	if outgen.followerState.phase != outgenFollowerPhaseNewRound {
 		outgen.logger.Debug("cannot process RoundStartPool, wrong phase", commontypes.LogFields{
 			"seqNr": outgen.sharedState.seqNr,
@@ -200,7 +256,11 @@ func (outgen *outcomeGenerationState[RI]) tryProcessRoundStartPool() {
 
 	msg := poolEntries[outgen.sharedState.l].Item
 
-	outgen.followerState.query = &msg.Query
+	outgen.observe(msg.Query)
+}
+
+func (outgen *outcomeGenerationState[RI]) observe(query types.Query) {
+	outgen.followerState.query = &query
 
 	outctx := outgen.OutcomeCtx(outgen.sharedState.seqNr)
 
@@ -212,6 +272,26 @@ func (outgen *outcomeGenerationState[RI]) tryProcessRoundStartPool() {
 		outgen.sharedState.l,
 	)
 
+	if outgen.observationSampling {
+		quorum, ok := outgen.ObservationQuorum(query)
+		if !ok {
+			return
+		}
+		if !outgen.observationSampled(outgen.id, quorum) {
+			outgen.followerState.phase = outgenFollowerPhaseSentObservation
+			outgen.logger.Debug("not in observation sample, skipping observation", commontypes.LogFields{
+				"seqNr": outgen.sharedState.seqNr,
+			})
+			outgen.tryProcessProposalPool()
+			return
+		}
+	}
+
+	var observedAt time.Time
+	if outgen.observationTimestamps {
+		observedAt = outgen.now()
+	}
+
 	o, ok := callPluginFromOutcomeGeneration[types.Observation](
 		outgen,
 		"Observation",
@@ -225,7 +305,7 @@ func (outgen *outcomeGenerationState[RI]) tryProcessRoundStartPool() {
 		return
 	}
 
-	so, err := MakeSignedObservation(outgen.ID(), outgen.sharedState.seqNr, msg.Query, o, outgen.offchainKeyring.OffchainSign)
+	so, err := MakeSignedObservation(outgen.ID(), outgen.sharedState.seqNr, query, o, observedAt, outgen.offchainKeyring.OffchainSign)
 	if err != nil {
 		outgen.logger.Error("MakeSignedObservation returned error", commontypes.LogFields{
 			"seqNr": outgen.sharedState.seqNr,
@@ -234,7 +314,7 @@ func (outgen *outcomeGenerationState[RI]) tryProcessRoundStartPool() {
 		return
 	}
 
-	if err := so.Verify(outgen.ID(), outgen.sharedState.seqNr, msg.Query, outgen.offchainKeyring.OffchainPublicKey()); err != nil {
+	if err := so.Verify(outgen.ID(), outgen.sharedState.seqNr, query, outgen.offchainKeyring.OffchainPublicKey()); err != nil {
 		outgen.logger.Error("MakeSignedObservation produced invalid signature", commontypes.LogFields{
 			"seqNr": outgen.sharedState.seqNr,
 			"error": err,
@@ -318,6 +398,10 @@ func (outgen *outcomeGenerationState[RI]) tryProcessProposalPool() {
 	}
 
 	attributedObservations := []types.AttributedObservation{}
+	var observationTimestamps map[commontypes.OracleID]ocr3types.ObservationTimestamps
+	if outgen.observationTimestamps {
+		observationTimestamps = map[commontypes.OracleID]ocr3types.ObservationTimestamps{}
+	}
 	{
 		quorum, ok := outgen.ObservationQuorum(*outgen.followerState.query)
 		if !ok {
@@ -351,7 +435,17 @@ func (outgen *outcomeGenerationState[RI]) tryProcessProposalPool() {
 
 			seen[aso.Observer] = true
 
-			if err := aso.SignedObservation.Verify(outgen.ID(), outgen.sharedState.seqNr, *outgen.followerState.query, outgen.config.OracleIdentities[aso.Observer].OffchainPublicKey); err != nil {
+			if !outgen.observationSampled(aso.Observer, quorum) {
+				outgen.logger.Warn("dropping MessageProposal that contains signed observation from oracle outside of observation sample", commontypes.LogFields{
+					"seqNr":    outgen.sharedState.seqNr,
+					"observer": aso.Observer,
+				})
+				return
+			}
+
+			if err := outgen.config.OracleIdentities[aso.Observer].VerifyOffchainSignature(func(publicKey types.OffchainPublicKey) error {
+				return aso.SignedObservation.Verify(outgen.ID(), outgen.sharedState.seqNr, *outgen.followerState.query, publicKey)
+			}); err != nil {
 				outgen.logger.Warn("dropping MessageProposal that contains signed observation with invalid signature", commontypes.LogFields{
 					"seqNr": outgen.sharedState.seqNr,
 					"error": err,
@@ -359,16 +453,42 @@ func (outgen *outcomeGenerationState[RI]) tryProcessProposalPool() {
 				return
 			}
 
+			if err := outgen.checkObservedAt(aso.SignedObservation); err != nil {
+				outgen.logger.Warn("dropping MessageProposal that contains signed observation with invalid ObservedAt", commontypes.LogFields{
+					"seqNr": outgen.sharedState.seqNr,
+					"error": err,
+				})
+				return
+			}
+
+			if outgen.observationTimestamps == aso.ReceivedAt.IsZero() {
+				outgen.logger.Warn("dropping MessageProposal that contains signed observation with invalid ReceivedAt", commontypes.LogFields{
+					"seqNr":                 outgen.sharedState.seqNr,
+					"observationTimestamps": outgen.observationTimestamps,
+					"receivedAt":            aso.ReceivedAt,
+				})
+				return
+			}
+
+			ao := types.AttributedObservation{aso.SignedObservation.Observation, aso.Observer}
+			ts := ocr3types.ObservationTimestamps{aso.SignedObservation.ObservedAt, aso.ReceivedAt}
+
 			err, ok := callPluginFromOutcomeGeneration[error](
 				outgen,
 				"ValidateObservation",
 				0, // ValidateObservation is a pure function and should finish "instantly"
 				outgen.OutcomeCtx(outgen.sharedState.seqNr),
 				func(ctx context.Context, outctx ocr3types.OutcomeContext) (error, error) {
+					if outgen.observationTimestamps {
+						ctx = ocr3types.ContextWithObservationTimestamps(ctx, map[commontypes.OracleID]ocr3types.ObservationTimestamps{
+							aso.Observer: ts,
+						})
+					}
 					return outgen.reportingPlugin.ValidateObservation(
+						ctx,
 						outctx,
 						*outgen.followerState.query,
-						types.AttributedObservation{aso.SignedObservation.Observation, aso.Observer},
+						ao,
 					), nil
 				},
 			)
@@ -379,10 +499,10 @@ func (outgen *outcomeGenerationState[RI]) tryProcessProposalPool() {
 				})
 			}
 
-			attributedObservations = append(attributedObservations, types.AttributedObservation{
-				aso.SignedObservation.Observation,
-				aso.Observer,
-			})
+			attributedObservations = append(attributedObservations, ao)
+			if outgen.observationTimestamps {
+				observationTimestamps[aso.Observer] = ts
+			}
 		}
 	}
 
@@ -392,15 +512,23 @@ func (outgen *outcomeGenerationState[RI]) tryProcessProposalPool() {
 		outgen.sharedState.seqNr,
 		*outgen.followerState.query,
 		attributedObservations,
+		observationTimestamps,
 	)
 
+	outcomeCtx := outgen.OutcomeCtx(outgen.sharedState.seqNr)
+	if outgen.observationTimestamps {
+		outcomeCtx.ObservationsTimestamp = medianObservedAt(observationTimestamps)
+	}
 	outcome, ok := callPluginFromOutcomeGeneration[ocr3types.Outcome](
 		outgen,
 		"Outcome",
 		0, // Outcome is a pure function and should finish "instantly"
-		outgen.OutcomeCtx(outgen.sharedState.seqNr),
-		func(_ context.Context, outctx ocr3types.OutcomeContext) (ocr3types.Outcome, error) {
-			return outgen.reportingPlugin.Outcome(outctx, *outgen.followerState.query, attributedObservations)
+		outcomeCtx,
+		func(ctx context.Context, outctx ocr3types.OutcomeContext) (ocr3types.Outcome, error) {
+			if outgen.observationTimestamps {
+				ctx = ocr3types.ContextWithObservationTimestamps(ctx, observationTimestamps)
+			}
+			return outgen.reportingPlugin.Outcome(ctx, outctx, *outgen.followerState.query, attributedObservations)
 		},
 	)
 	if !ok {
@@ -409,6 +537,40 @@ func (outgen *outcomeGenerationState[RI]) tryProcessProposalPool() {
 
 	outcomeDigest := MakeOutcomeDigest(outcome)
 
+	{
+		observed := make([]bool, outgen.config.N())
+		for _, ao := range attributedObservations {
+			observed[ao.Observer] = true
+		}
+		included := make([]commontypes.OracleID, 0, len(attributedObservations))
+		excluded := make([]commontypes.OracleID, 0, outgen.config.N()-len(attributedObservations))
+		for i := 0; i < outgen.config.N(); i++ {
+			if observed[i] {
+				included = append(included, commontypes.OracleID(i))
+			} else {
+				excluded = append(excluded, commontypes.OracleID(i))
+			}
+		}
+		outctx := outgen.OutcomeCtx(outgen.sharedState.seqNr)
+		outgen.telemetrySender.OutcomeComputed(
+			outgen.config.ConfigDigest,
+			outctx.Epoch,
+			outctx.SeqNr,
+			outctx.Round,
+			outgen.sharedState.l,
+			included,
+			excluded,
+			outcomeDigest,
+		)
+	}
+
+	if outgen.sharedState.passive {
+		outgen.logger.Debug("not signing Prepare while passive", commontypes.LogFields{
+			"seqNr": msg.SeqNr,
+		})
+		return
+	}
+
 	prepareSignature, err := MakePrepareSignature(
 		outgen.ID(),
 		msg.SeqNr,
@@ -490,13 +652,15 @@ func (outgen *outcomeGenerationState[RI]) tryProcessPreparePool() {
 		if preparePoolEntry.Verified != nil {
 			continue
 		}
-		err := preparePoolEntry.Item.Verify(
-			outgen.ID(),
-			outgen.sharedState.seqNr,
-			outgen.followerState.outcome.InputsDigest,
-			outgen.followerState.outcome.Digest,
-			outgen.config.OracleIdentities[sender].OffchainPublicKey,
-		)
+		err := outgen.config.OracleIdentities[sender].VerifyOffchainSignature(func(publicKey types.OffchainPublicKey) error {
+			return preparePoolEntry.Item.Verify(
+				outgen.ID(),
+				outgen.sharedState.seqNr,
+				outgen.followerState.outcome.InputsDigest,
+				outgen.followerState.outcome.Digest,
+				publicKey,
+			)
+		})
 		ok := err == nil
 		outgen.followerState.preparePool.StoreVerified(outgen.sharedState.seqNr, sender, ok)
 		if !ok {
@@ -525,6 +689,13 @@ func (outgen *outcomeGenerationState[RI]) tryProcessPreparePool() {
 		return
 	}
 
+	if outgen.sharedState.passive {
+		outgen.logger.Debug("not signing Commit while passive", commontypes.LogFields{
+			"seqNr": outgen.sharedState.seqNr,
+		})
+		return
+	}
+
 	commitSignature, err := MakeCommitSignature(
 		outgen.ID(),
 		outgen.sharedState.seqNr,
@@ -611,12 +782,14 @@ func (outgen *outcomeGenerationState[RI]) tryProcessCommitPool() {
 		if commitPoolEntry.Verified != nil {
 			continue
 		}
-		err := commitPoolEntry.Item.Verify(
-			outgen.ID(),
-			outgen.sharedState.seqNr,
-			outgen.followerState.outcome.Digest,
-			outgen.config.OracleIdentities[sender].OffchainPublicKey,
-		)
+		err := outgen.config.OracleIdentities[sender].VerifyOffchainSignature(func(publicKey types.OffchainPublicKey) error {
+			return commitPoolEntry.Item.Verify(
+				outgen.ID(),
+				outgen.sharedState.seqNr,
+				outgen.followerState.outcome.Digest,
+				publicKey,
+			)
+		})
 		ok := err == nil
 		commitPoolEntry.Verified = &ok
 		if !ok {
@@ -704,15 +877,40 @@ func (outgen *outcomeGenerationState[RI]) commit(commit CertifiedCommit) {
 			return
 		}
 
+		// the previous outcome is only known if we committed our way here,
+		// rather than skipping ahead, e.g. after a restart
+		previousOutcome := outgen.sharedState.committedOutcome
+		previousOutcomeKnown := commit.SeqNr == outgen.sharedState.committedSeqNr+1
+
+		now := time.Now()
+		if commit.SeqNr == outgen.sharedState.committedSeqNr+1 && !outgen.sharedState.committedTime.IsZero() {
+			outgen.metrics.ObserveRoundDuration(now.Sub(outgen.sharedState.committedTime))
+		}
+
 		outgen.sharedState.committedSeqNr = commit.SeqNr
+		outgen.instanceStatus.committed(commit.SeqNr)
 		outgen.sharedState.committedOutcome = commit.Outcome
+		outgen.sharedState.committedOutcomeHash = ocr3types.MakeOutcomeHash(commit.Outcome)
+		outgen.sharedState.committedTime = now
+		outgen.stateSync.latestCertifiedCommit = &commit
 
 		outgen.logger.Debug("✅ committed outcome", commontypes.LogFields{
 			"seqNr": commit.SeqNr,
 		})
+		if outgen.roundPacer.roundCommitted(now) {
+			outgen.logger.Info("shortened round interval after fast rounds", commontypes.LogFields{
+				"deltaRound": outgen.roundPacer.interval().String(),
+			})
+		}
+		outgen.telemetrySender.OutcomeCommitted(
+			outgen.config.ConfigDigest,
+			commit.CommitEpoch,
+			commit.SeqNr,
+			MakeOutcomeDigest(commit.Outcome),
+		)
 
 		select {
-		case outgen.chOutcomeGenerationToReportAttestation <- EventCommittedOutcome[RI]{commit}:
+		case outgen.chOutcomeGenerationToReportAttestation <- EventCommittedOutcome[RI]{commit, previousOutcome, previousOutcomeKnown}:
 		case <-outgen.ctx.Done():
 			return
 		}
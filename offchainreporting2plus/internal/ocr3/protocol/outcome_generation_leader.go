@@ -7,6 +7,7 @@ import (
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type outgenLeaderPhase string
@@ -36,6 +37,13 @@ func (outgen *outcomeGenerationState[RI]) messageEpochStartRequest(msg MessageEp
 		return
 	}
 
+	if outgen.sharedState.passive {
+		outgen.logger.Debug("dropping MessageEpochStartRequest while passive", commontypes.LogFields{
+			"sender": sender,
+		})
+		return
+	}
+
 	if outgen.leaderState.phase != outgenLeaderPhaseNewEpoch {
 		outgen.logger.Debug("dropping MessageEpochStartRequest for wrong phase", commontypes.LogFields{
 			"sender": sender,
@@ -53,10 +61,9 @@ func (outgen *outcomeGenerationState[RI]) messageEpochStartRequest(msg MessageEp
 
 	outgen.leaderState.epochStartRequests[sender] = &epochStartRequest[RI]{}
 
-	if err := msg.SignedHighestCertifiedTimestamp.Verify(
-		outgen.ID(),
-		outgen.config.OracleIdentities[sender].OffchainPublicKey,
-	); err != nil {
+	if err := outgen.config.OracleIdentities[sender].VerifyOffchainSignature(func(publicKey types.OffchainPublicKey) error {
+		return msg.SignedHighestCertifiedTimestamp.Verify(outgen.ID(), publicKey)
+	}); err != nil {
 		outgen.leaderState.epochStartRequests[sender].bad = true
 		outgen.logger.Warn("MessageEpochStartRequest.SignedHighestCertifiedTimestamp is invalid", commontypes.LogFields{
 			"sender": sender,
@@ -182,7 +189,7 @@ func (outgen *outcomeGenerationState[RI]) eventTRoundTimeout() {
 	outgen.logger.Debug("TRound fired", commontypes.LogFields{
 		"seqNr":          outgen.sharedState.seqNr,
 		"committedSeqNr": outgen.sharedState.committedSeqNr,
-		"deltaRound":     outgen.config.DeltaRound.String(),
+		"deltaRound":     outgen.roundPacer.interval().String(),
 	})
 	outgen.startSubsequentLeaderRound()
 }
@@ -194,6 +201,11 @@ func (outgen *outcomeGenerationState[RI]) startSubsequentLeaderRound() {
 	}
 	outgen.leaderState.readyToStartRound = false
 
+	if outgen.queryLessRounds {
+		outgen.startQueryLessLeaderRound()
+		return
+	}
+
 	query, ok := callPluginFromOutcomeGeneration[types.Query](
 		outgen,
 		"Query",
@@ -209,9 +221,12 @@ func (outgen *outcomeGenerationState[RI]) startSubsequentLeaderRound() {
 
 	outgen.leaderState.query = query
 
-	outgen.leaderState.observations = map[commontypes.OracleID]*SignedObservation{}
+	outgen.leaderState.observations = map[commontypes.OracleID]*AttributedSignedObservation{}
+	outgen.startObservationCollectionSpan()
 
-	outgen.leaderState.tRound = time.After(outgen.config.DeltaRound)
+	outgen.leaderState.tRound = after(outgen.timeSource, outgen.roundPacer.interval())
+	outgen.leaderState.roundStartedAt = time.Now()
+	outgen.roundPacer.roundStarted(outgen.leaderState.roundStartedAt)
 
 	outgen.leaderState.phase = outgenLeaderPhaseSentRoundStart
 	outgen.logger.Debug("broadcasting MessageRoundStart", commontypes.LogFields{
@@ -224,7 +239,33 @@ func (outgen *outcomeGenerationState[RI]) startSubsequentLeaderRound() {
 	})
 }
 
-func (outgen *outcomeGenerationState[RI]) messageObservation(msg MessageObservation[RI], sender commontypes.OracleID) {
+// In query-less rounds, the leader doesn't broadcast MessageRoundStart and
+// merely starts accepting observations. We nevertheless use
+// outgenLeaderPhaseSentRoundStart for this phase.
+func (outgen *outcomeGenerationState[RI]) startQueryLessLeaderRound() {
+	outgen.leaderState.query = nil
+
+	outgen.leaderState.observations = map[commontypes.OracleID]*AttributedSignedObservation{}
+	outgen.startObservationCollectionSpan()
+
+	outgen.leaderState.tRound = after(outgen.timeSource, outgen.roundPacer.interval())
+	outgen.leaderState.roundStartedAt = time.Now()
+	outgen.roundPacer.roundStarted(outgen.leaderState.roundStartedAt)
+
+	outgen.leaderState.phase = outgenLeaderPhaseSentRoundStart
+	outgen.logger.Debug("started query-less round", commontypes.LogFields{
+		"seqNr":             outgen.sharedState.committedSeqNr + 1,
+		"earlyObservations": len(outgen.leaderState.earlyObservations),
+	})
+
+	earlyObservations := outgen.leaderState.earlyObservations
+	outgen.leaderState.earlyObservations = map[commontypes.OracleID]earlyObservation[RI]{}
+	for sender, early := range earlyObservations {
+		outgen.messageObservation(early.message, sender, early.receivedAt)
+	}
+}
+
+func (outgen *outcomeGenerationState[RI]) messageObservation(msg MessageObservation[RI], sender commontypes.OracleID, receivedAt time.Time) {
 
 	if msg.Epoch != outgen.sharedState.e {
 		outgen.logger.Debug("dropping MessageObservation for wrong epoch", commontypes.LogFields{
@@ -246,6 +287,18 @@ func (outgen *outcomeGenerationState[RI]) messageObservation(msg MessageObservat
 	}
 
 	if outgen.leaderState.phase != outgenLeaderPhaseSentRoundStart && outgen.leaderState.phase != outgenLeaderPhaseGrace {
+		// In query-less rounds, followers don't wait for us to start the
+		// round, so their observations may arrive early.
+		if outgen.queryLessRounds && outgen.sharedState.committedSeqNr < msg.SeqNr && msg.SeqNr <= outgen.sharedState.committedSeqNr+2 {
+			outgen.logger.Debug("buffering early MessageObservation", commontypes.LogFields{
+				"sender":   sender,
+				"seqNr":    outgen.sharedState.seqNr,
+				"msgSeqNr": msg.SeqNr,
+				"phase":    outgen.leaderState.phase,
+			})
+			outgen.leaderState.earlyObservations[sender] = earlyObservation[RI]{msg, receivedAt}
+			return
+		}
 		outgen.logger.Debug("dropping MessageObservation for wrong phase", commontypes.LogFields{
 			"sender":   sender,
 			"seqNr":    outgen.sharedState.seqNr,
@@ -272,7 +325,30 @@ func (outgen *outcomeGenerationState[RI]) messageObservation(msg MessageObservat
 		return
 	}
 
-	if err := msg.SignedObservation.Verify(outgen.ID(), outgen.sharedState.seqNr, outgen.leaderState.query, outgen.config.OracleIdentities[sender].OffchainPublicKey); err != nil {
+	quorum, ok := outgen.ObservationQuorum(outgen.leaderState.query)
+	if !ok {
+		return
+	}
+
+	if !outgen.observationSampled(sender, quorum) {
+		outgen.logger.Warn("dropping MessageObservation from oracle outside of observation sample", commontypes.LogFields{
+			"sender": sender,
+			"seqNr":  outgen.sharedState.seqNr,
+		})
+		return
+	}
+
+	if outgen.observationQuarantine.quarantined(sender, time.Now()) {
+		outgen.logger.Debug("dropping MessageObservation from quarantined sender", commontypes.LogFields{
+			"sender": sender,
+			"seqNr":  outgen.sharedState.seqNr,
+		})
+		return
+	}
+
+	if err := outgen.config.OracleIdentities[sender].VerifyOffchainSignature(func(publicKey types.OffchainPublicKey) error {
+		return msg.SignedObservation.Verify(outgen.ID(), outgen.sharedState.seqNr, outgen.leaderState.query, publicKey)
+	}); err != nil {
 		outgen.logger.Warn("dropping MessageObservation carrying invalid SignedObservation", commontypes.LogFields{
 			"sender": sender,
 			"seqNr":  outgen.sharedState.seqNr,
@@ -281,13 +357,32 @@ func (outgen *outcomeGenerationState[RI]) messageObservation(msg MessageObservat
 		return
 	}
 
+	if err := outgen.checkObservedAt(msg.SignedObservation); err != nil {
+		outgen.logger.Warn("dropping MessageObservation carrying SignedObservation with invalid ObservedAt", commontypes.LogFields{
+			"sender": sender,
+			"seqNr":  outgen.sharedState.seqNr,
+			"error":  err,
+		})
+		return
+	}
+
+	if !outgen.observationTimestamps {
+		receivedAt = time.Time{}
+	}
+
 	err, ok := callPluginFromOutcomeGeneration[error](
 		outgen,
 		"ValidateObservation",
 		0, // ValidateObservation is a pure function and should finish "instantly"
 		outgen.OutcomeCtx(outgen.sharedState.seqNr),
 		func(ctx context.Context, outctx ocr3types.OutcomeContext) (error, error) {
+			if outgen.observationTimestamps {
+				ctx = ocr3types.ContextWithObservationTimestamps(ctx, map[commontypes.OracleID]ocr3types.ObservationTimestamps{
+					sender: {msg.SignedObservation.ObservedAt, receivedAt},
+				})
+			}
 			return outgen.reportingPlugin.ValidateObservation(
+				ctx,
 				outctx,
 				outgen.leaderState.query,
 				types.AttributedObservation{msg.SignedObservation.Observation, sender},
@@ -300,34 +395,59 @@ func (outgen *outcomeGenerationState[RI]) messageObservation(msg MessageObservat
 			"seqNr":  outgen.sharedState.seqNr,
 			"error":  err,
 		})
-	}
-
-	quorum, ok := outgen.ObservationQuorum(outgen.leaderState.query)
-	if !ok {
+		// If the call failed (!ok), the fault may well be ours
+		if ok {
+			if duration := outgen.observationQuarantine.recordInvalid(sender, time.Now()); duration != 0 {
+				outgen.logger.Warn("quarantining sender of persistently invalid observations", commontypes.LogFields{
+					"sender":   sender,
+					"seqNr":    outgen.sharedState.seqNr,
+					"duration": duration.String(),
+				})
+			}
+		}
 		return
 	}
+	if outgen.observationQuarantine.recordValid(sender) {
+		outgen.logger.Info("sender of previously invalid observations sent a valid one, lifting quarantine", commontypes.LogFields{
+			"sender": sender,
+			"seqNr":  outgen.sharedState.seqNr,
+		})
+	}
 
 	outgen.logger.Debug("got valid MessageObservation", commontypes.LogFields{
 		"sender": sender,
 		"seqNr":  outgen.sharedState.seqNr,
 	})
 
-	outgen.leaderState.observations[sender] = &msg.SignedObservation
+	outgen.leaderState.observations[sender] = &AttributedSignedObservation{
+		msg.SignedObservation,
+		sender,
+		receivedAt,
+	}
 
 	observationCount := 0
-	for _, so := range outgen.leaderState.observations {
-		if so != nil {
+	for _, aso := range outgen.leaderState.observations {
+		if aso != nil {
 			observationCount++
 		}
 	}
 	if observationCount == quorum {
+		// Pick the grace period before recording this round's latency, so
+		// that it only depends on previous rounds.
+		deltaGrace, autoTuned := outgen.graceTuner.grace()
+		quorumLatency := time.Since(outgen.leaderState.roundStartedAt)
+		outgen.graceTuner.recordQuorumLatency(quorumLatency)
+
 		outgen.logger.Debug("reached observation quorum, starting observation grace period", commontypes.LogFields{
 			"seqNr":             outgen.sharedState.seqNr,
-			"deltaGrace":        outgen.config.DeltaGrace.String(),
+			"deltaGrace":        deltaGrace.String(),
+			"deltaGraceTuned":   autoTuned,
+			"quorumLatency":     quorumLatency.String(),
 			"observationQuorum": quorum,
 		})
 		outgen.leaderState.phase = outgenLeaderPhaseGrace
-		outgen.leaderState.tGrace = time.After(outgen.config.DeltaGrace)
+		outgen.leaderState.deltaGrace = deltaGrace
+		outgen.leaderState.tGrace = after(outgen.timeSource, deltaGrace)
 	}
 }
 
@@ -341,12 +461,9 @@ func (outgen *outcomeGenerationState[RI]) eventTGraceTimeout() {
 	}
 	asos := make([]AttributedSignedObservation, 0, outgen.config.N())
 	contributors := make([]commontypes.OracleID, 0, outgen.config.N())
-	for oid, so := range outgen.leaderState.observations {
-		if so != nil {
-			asos = append(asos, AttributedSignedObservation{
-				*so,
-				commontypes.OracleID(oid),
-			})
+	for oid, aso := range outgen.leaderState.observations {
+		if aso != nil {
+			asos = append(asos, *aso)
 			contributors = append(contributors, commontypes.OracleID(oid))
 		}
 	}
@@ -356,11 +473,32 @@ func (outgen *outcomeGenerationState[RI]) eventTGraceTimeout() {
 	outgen.logger.Debug("broadcasting MessageProposal after TGrace fired", commontypes.LogFields{
 		"seqNr":        outgen.sharedState.seqNr,
 		"contributors": contributors,
-		"deltaGrace":   outgen.config.DeltaGrace.String(),
+		"deltaGrace":   outgen.leaderState.deltaGrace.String(),
 	})
 	outgen.netSender.Broadcast(MessageProposal[RI]{
 		outgen.sharedState.e,
 		outgen.sharedState.seqNr,
 		asos,
 	})
+	if outgen.leaderState.observationCollectionSpan != nil {
+		outgen.leaderState.observationCollectionSpan.SetAttributes(attribute.Int("ocr3.observation_count", len(asos)))
+	}
+	outgen.endObservationCollectionSpan("")
+}
+
+// startObservationCollectionSpan starts the span covering the collection of
+// observations for the round with seqNr committedSeqNr+1, which the leader
+// is about to start.
+func (outgen *outcomeGenerationState[RI]) startObservationCollectionSpan() {
+	outgen.endObservationCollectionSpan("round abandoned")
+	_, outgen.leaderState.observationCollectionSpan = outgen.tracing.start(
+		outgen.ctx,
+		"ObservationCollection",
+		outgen.sharedState.committedSeqNr+1,
+	)
+}
+
+func (outgen *outcomeGenerationState[RI]) endObservationCollectionSpan(errorDescription string) {
+	endSpan(outgen.leaderState.observationCollectionSpan, errorDescription)
+	outgen.leaderState.observationCollectionSpan = nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 	"github.com/smartcontractkit/libocr/permutation"
 )
@@ -22,6 +23,7 @@ func RunPacemaker[RI any](
 	chNetToPacemaker <-chan MessageToPacemakerWithSender[RI],
 	chPacemakerToOutcomeGeneration chan<- EventToOutcomeGeneration[RI],
 	chOutcomeGenerationToPacemaker <-chan EventToPacemaker[RI],
+	chForceEpochChange <-chan struct{},
 	config ocr3config.SharedConfig,
 	database Database,
 	id commontypes.OracleID,
@@ -30,17 +32,19 @@ func RunPacemaker[RI any](
 	netSender NetworkSender[RI],
 	offchainKeyring types.OffchainKeyring,
 	telemetrySender TelemetrySender,
+	timeSource ocr3types.TimeSource,
 
 	restoredState PacemakerState,
+	restoredPassivity passivity,
 ) {
 	pace := makePacemakerState[RI](
 		ctx, chNetToPacemaker,
 		chPacemakerToOutcomeGeneration, chOutcomeGenerationToPacemaker,
-		config, database,
+		chForceEpochChange, config, database,
 		id, localConfig, logger, netSender, offchainKeyring,
-		telemetrySender,
+		telemetrySender, timeSource,
 	)
-	pace.run(restoredState)
+	pace.run(restoredState, restoredPassivity)
 }
 
 func makePacemakerState[RI any](
@@ -48,6 +52,7 @@ func makePacemakerState[RI any](
 	chNetToPacemaker <-chan MessageToPacemakerWithSender[RI],
 	chPacemakerToOutcomeGeneration chan<- EventToOutcomeGeneration[RI],
 	chOutcomeGenerationToPacemaker <-chan EventToPacemaker[RI],
+	chForceEpochChange <-chan struct{},
 	config ocr3config.SharedConfig,
 	database Database, id commontypes.OracleID,
 	localConfig types.LocalConfig,
@@ -55,6 +60,7 @@ func makePacemakerState[RI any](
 	netSender NetworkSender[RI],
 	offchainKeyring types.OffchainKeyring,
 	telemetrySender TelemetrySender,
+	timeSource ocr3types.TimeSource,
 ) pacemakerState[RI] {
 	return pacemakerState[RI]{
 		ctx: ctx,
@@ -62,6 +68,7 @@ func makePacemakerState[RI any](
 		chNetToPacemaker:               chNetToPacemaker,
 		chPacemakerToOutcomeGeneration: chPacemakerToOutcomeGeneration,
 		chOutcomeGenerationToPacemaker: chOutcomeGenerationToPacemaker,
+		chForceEpochChange:             chForceEpochChange,
 		config:                         config,
 		database:                       database,
 		id:                             id,
@@ -70,6 +77,7 @@ func makePacemakerState[RI any](
 		netSender:                      netSender,
 		offchainKeyring:                offchainKeyring,
 		telemetrySender:                telemetrySender,
+		timeSource:                     timeSource,
 
 		newEpochWishes: make([]uint64, config.N()),
 	}
@@ -81,6 +89,7 @@ type pacemakerState[RI any] struct {
 	chNetToPacemaker               <-chan MessageToPacemakerWithSender[RI]
 	chPacemakerToOutcomeGeneration chan<- EventToOutcomeGeneration[RI]
 	chOutcomeGenerationToPacemaker <-chan EventToPacemaker[RI]
+	chForceEpochChange             <-chan struct{}
 	config                         ocr3config.SharedConfig
 	database                       Database
 	id                             commontypes.OracleID
@@ -89,6 +98,7 @@ type pacemakerState[RI any] struct {
 	netSender                      NetworkSender[RI]
 	offchainKeyring                types.OffchainKeyring
 	telemetrySender                TelemetrySender
+	timeSource                     ocr3types.TimeSource
 	// Test use only: send testBlocker an event to halt the pacemaker event loop,
 	// send testUnblocker an event to resume it.
 	testBlocker   chan eventTestBlock
@@ -117,13 +127,55 @@ type pacemakerState[RI any] struct {
 	tProgress <-chan time.Time
 
 	notifyOutcomeGenerationOfNewEpoch bool
+
+	// passivity is set while the oracle is passive after quarantining
+	// corrupted state, see types.LocalConfig.QuarantineCorruptedProtocolState
+	passivity passivity
+}
+
+// passivity describes in which epochs an oracle that quarantined corrupted
+// state stays passive, see ocr3types.ProtocolStateQuarantiner.
+type passivity struct {
+	passive bool
+	// If passive, the oracle stays passive up to and including this epoch.
+	// Zero if the oracle lost its pacemaker state, in which case it is derived
+	// from the NewEpochWishes received.
+	throughEpoch uint64
+}
+
+// passiveIn returns whether the oracle must stay passive in epoch.
+func (pace *pacemakerState[RI]) passiveIn(epoch uint64) bool {
+	if !pace.passivity.passive {
+		return false
+	}
+	if pace.passivity.throughEpoch != 0 {
+		return epoch <= pace.passivity.throughEpoch
+	}
+
+	var wishes []uint64
+	for j, wish := range pace.newEpochWishes {
+		if commontypes.OracleID(j) != pace.id && wish != 0 {
+			wishes = append(wishes, wish)
+		}
+	}
+	if len(wishes)+1 < pace.config.ByzQuorumSize() {
+		// we haven't heard from enough oracles to bound the epochs we may
+		// have entered before
+		return true
+	}
+	sort.Slice(wishes, func(i, j int) bool { return wishes[i] > wishes[j] })
+	// at least f+1 oracles wished for any epoch we entered, and no more than
+	// f faulty oracles can wish for epochs beyond this
+	return epoch <= wishes[pace.config.F]
 }
 
-func (pace *pacemakerState[RI]) run(restoredState PacemakerState) {
+func (pace *pacemakerState[RI]) run(restoredState PacemakerState, restoredPassivity passivity) {
 	pace.logger.Info("Pacemaker: running", nil)
 
 	// Initialization
 
+	pace.passivity = restoredPassivity
+
 	if restoredState == (PacemakerState{}) {
 		// seqNrs start with 1, so let's make epochs also start with 1
 		pace.ne = 1
@@ -132,9 +184,9 @@ func (pace *pacemakerState[RI]) run(restoredState PacemakerState) {
 		pace.ne = restoredState.HighestSentNewEpochWish
 		pace.e = restoredState.Epoch
 	}
-	pace.l = Leader(pace.e, pace.config.N(), pace.config.LeaderSelectionKey())
+	pace.l = Leader(pace.e, pace.config.N(), pace.config.LeaderWeights, pace.config.LeaderSelectionKey())
 
-	pace.tProgress = time.After(pace.config.DeltaProgress)
+	pace.tProgress = after(pace.timeSource, pace.config.DeltaProgress)
 
 	pace.sendNewEpochWish()
 
@@ -149,23 +201,35 @@ func (pace *pacemakerState[RI]) run(restoredState PacemakerState) {
 	// Event Loop
 	for {
 		var nilOrChPacemakerToOutcomeGeneration chan<- EventToOutcomeGeneration[RI]
+		var passive bool
 		if pace.notifyOutcomeGenerationOfNewEpoch {
 			nilOrChPacemakerToOutcomeGeneration = pace.chPacemakerToOutcomeGeneration
+			passive = pace.passiveIn(pace.e)
 		} else {
 			nilOrChPacemakerToOutcomeGeneration = nil
 		}
 
 		select {
-		case nilOrChPacemakerToOutcomeGeneration <- EventNewEpochStart[RI]{pace.e}:
+		case nilOrChPacemakerToOutcomeGeneration <- EventNewEpochStart[RI]{pace.e, passive}:
 			pace.notifyOutcomeGenerationOfNewEpoch = false
+			if pace.passivity.passive && !passive {
+				pace.logger.Info("Pacemaker: moved past all epochs the oracle may have signed in before quarantining corrupted state, leaving passive mode", commontypes.LogFields{
+					"epoch": pace.e,
+				})
+				pace.passivity = passivity{}
+			}
 		case msg := <-pace.chNetToPacemaker:
 			msg.msg.processPacemaker(pace, msg.sender)
 		case ev := <-pace.chOutcomeGenerationToPacemaker:
 			ev.processPacemaker(pace)
 		case <-pace.tResend:
+			pace.telemetrySender.TimerExpired(pace.config.ConfigDigest, pace.e, 0, TimerResend)
 			pace.eventTResendTimeout()
 		case <-pace.tProgress:
+			pace.telemetrySender.TimerExpired(pace.config.ConfigDigest, pace.e, 0, TimerProgress)
 			pace.eventTProgressTimeout()
+		case <-pace.chForceEpochChange: // nil unless fault injection is enabled
+			pace.eventForceEpochChange()
 		case <-pace.testBlocker:
 			<-pace.testUnblocker
 		case <-chDone:
@@ -182,12 +246,12 @@ func (pace *pacemakerState[RI]) run(restoredState PacemakerState) {
 }
 
 func (pace *pacemakerState[RI]) eventProgress() {
-	pace.tProgress = time.After(pace.config.DeltaProgress)
+	pace.tProgress = after(pace.timeSource, pace.config.DeltaProgress)
 }
 
 func (pace *pacemakerState[RI]) sendNewEpochWish() {
 	pace.netSender.Broadcast(MessageNewEpochWish[RI]{pace.ne})
-	pace.tResend = time.After(pace.config.DeltaResend)
+	pace.tResend = after(pace.timeSource, pace.config.DeltaResend)
 }
 
 func (pace *pacemakerState[RI]) eventTResendTimeout() {
@@ -198,6 +262,14 @@ func (pace *pacemakerState[RI]) eventTProgressTimeout() {
 	pace.logger.Debug("TProgress fired", commontypes.LogFields{
 		"deltaProgress": pace.config.DeltaProgress.String(),
 	})
+	pace.telemetrySender.ProtocolError(pace.config.ConfigDigest, pace.e, 0, ProtocolErrorCodeProgressTimeout)
+	pace.eventNewEpochRequest()
+}
+
+func (pace *pacemakerState[RI]) eventForceEpochChange() {
+	pace.logger.Warn("epoch change forced by fault injection", commontypes.LogFields{
+		"epoch": pace.e,
+	})
 	pace.eventNewEpochRequest()
 }
 
@@ -295,13 +367,13 @@ func (pace *pacemakerState[RI]) messageNewEpochWish(msg MessageNewEpochWish[RI],
 		pace.logger.Debug("moving to new epoch", commontypes.LogFields{
 			"newEpoch": switchToEpoch,
 		})
-		l := Leader(switchToEpoch, pace.config.N(), pace.config.LeaderSelectionKey())
+		l := Leader(switchToEpoch, pace.config.N(), pace.config.LeaderWeights, pace.config.LeaderSelectionKey())
 		pace.e, pace.l = switchToEpoch, l // (e, l) ← (ē, leader(ē))
 		if pace.ne < pace.e {             // ne ← max{ne, e}
 			pace.ne = pace.e
 		}
 
-		pace.tProgress = time.After(pace.config.DeltaProgress) // restart timer T_{progress}
+		pace.tProgress = after(pace.timeSource, pace.config.DeltaProgress) // restart timer T_{progress}
 
 		pace.notifyOutcomeGenerationOfNewEpoch = true // invoke event newEpochStart(e, l)
 	}
@@ -332,18 +404,37 @@ func sortedGreaterThan(xs []uint64, y uint64) (rv []uint64) {
 	return rv
 }
 
-// Leader will produce an oracle id for the given epoch.
-func Leader(epoch uint64, n int, key [16]byte) (leader commontypes.OracleID) {
-	span := epoch / uint64(n)
-	epochInSpan := epoch % uint64(n)
+// Leader returns the leader of epoch. Without weights, every span of n
+// consecutive epochs is led by each oracle once. With weights (see
+// ocr3config.PublicConfig.LeaderWeights), every span of sum(weights)
+// consecutive epochs is led by each oracle i weights[i] times.
+func Leader(epoch uint64, n int, weights []int, key [16]byte) (leader commontypes.OracleID) {
+	// slots[j] is the oracle that leads the epoch at position j of the
+	// permutation
+	var slots []commontypes.OracleID
+	if len(weights) == 0 {
+		slots = make([]commontypes.OracleID, 0, n)
+		for i := 0; i < n; i++ {
+			slots = append(slots, commontypes.OracleID(i))
+		}
+	} else {
+		for i, w := range weights {
+			for j := 0; j < w; j++ {
+				slots = append(slots, commontypes.OracleID(i))
+			}
+		}
+	}
+
+	span := epoch / uint64(len(slots))
+	epochInSpan := epoch % uint64(len(slots))
 
 	mac := hmac.New(sha256.New, key[:])
 	_ = binary.Write(mac, binary.BigEndian, span)
 
 	var permutationKey [16]byte
 	copy(permutationKey[:], mac.Sum(nil))
-	pi := permutation.Permutation(n, permutationKey)
-	return commontypes.OracleID(pi[epochInSpan])
+	pi := permutation.Permutation(len(slots), permutationKey)
+	return slots[pi[epochInSpan]]
 }
 
 type eventTestBlock struct{}
@@ -16,6 +16,7 @@ import (
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/scheduler"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func RunReportAttestation[RI any](
@@ -27,16 +28,19 @@ func RunReportAttestation[RI any](
 	config ocr3config.SharedConfig,
 	contractTransmitter ocr3types.ContractTransmitter[RI],
 	logger loghelper.LoggerWithContext,
+	metrics *Metrics,
 	netSender NetworkSender[RI],
 	onchainKeyring ocr3types.OnchainKeyring[RI],
-	reportingPlugin ocr3types.ReportingPlugin[RI],
+	reportingPlugin ocr3types.ReportingPluginV2[RI],
+	telemetrySender TelemetrySender,
+	tracing *Tracing,
 ) {
 	sched := scheduler.NewScheduler[EventMissingOutcome[RI]]()
 	defer sched.Close()
 
 	newReportAttestationState(ctx, chNetToReportAttestation,
 		chOutcomeGenerationToReportAttestation, chReportAttestationToTransmission,
-		config, contractTransmitter, logger, netSender, onchainKeyring, reportingPlugin, sched).run()
+		config, contractTransmitter, logger, metrics, netSender, onchainKeyring, reportingPlugin, telemetrySender, tracing, sched).run()
 }
 
 const expiryMinRounds int = 10
@@ -56,9 +60,20 @@ type reportAttestationState[RI any] struct {
 	config                                 ocr3config.SharedConfig
 	contractTransmitter                    ocr3types.ContractTransmitter[RI]
 	logger                                 loghelper.LoggerWithContext
+	metrics                                *Metrics
 	netSender                              NetworkSender[RI]
 	onchainKeyring                         ocr3types.OnchainKeyring[RI]
-	reportingPlugin                        ocr3types.ReportingPlugin[RI]
+	reportingPlugin                        ocr3types.ReportingPluginV2[RI]
+	telemetrySender                        TelemetrySender
+	tracing                                *Tracing
+
+	// nil unless reportingPlugin implements ocr3types.ReportBatcher
+	reportBatcher ocr3types.ReportBatcher[RI]
+	// nil unless reportingPlugin implements ocr3types.OutcomeContextReporter
+	// but not ocr3types.ReportBatcher
+	outcomeContextReporter ocr3types.OutcomeContextReporter[RI]
+	// nil unless onchainKeyring implements ocr3types.AggregatingOnchainKeyring
+	aggregatingOnchainKeyring ocr3types.AggregatingOnchainKeyring[RI]
 
 	scheduler *scheduler.Scheduler[EventMissingOutcome[RI]]
 	// reap() is used to prevent unbounded state growth of rounds
@@ -68,14 +83,24 @@ type reportAttestationState[RI any] struct {
 	// highest sequence number for which we have received report signatures
 	// from each oracle
 	highestReportSignaturesSeqNr []uint64
+	// committed outcomes by seqNr, from which the previous outcomes passed to
+	// outcomeContextReporter are taken. Only tracked if outcomeContextReporter
+	// is set. reapOutcomes() is used to prevent unbounded state growth.
+	outcomes map[uint64]ocr3types.Outcome
 }
 
 type round[RI any] struct {
 	certifiedCommit *CertifiedCommit
+	// the reports that are signed. If the plugin batches reports, these are
+	// the roots of reportBatches.
 	reportsWithInfo []ocr3types.ReportWithInfo[RI]
+	reportBatches   []ocr3types.ReportBatch[RI]
 	oracles         []oracle // always initialized to be of length n
 	startedFetch    bool
 	complete        bool
+	// spans the time from signing reportsWithInfo until they are attested,
+	// nil if we haven't signed them or they have been attested
+	attestationSpan trace.Span
 }
 
 // oracle contains information about interactions with oracles (self & others)
@@ -104,6 +129,9 @@ func (repatt *reportAttestationState[RI]) run() {
 		// ensure prompt exit
 		select {
 		case <-repatt.ctx.Done():
+			for _, round := range repatt.rounds {
+				endSpan(round.attestationSpan, "oracle shut down")
+			}
 			repatt.logger.Info("ReportAttestation: exiting", nil)
 			repatt.scheduler.Close()
 			return
@@ -138,11 +166,13 @@ func (repatt *reportAttestationState[RI]) messageReportSignatures(
 
 	if _, ok := repatt.rounds[msg.SeqNr]; !ok {
 		repatt.rounds[msg.SeqNr] = &round[RI]{
+			nil,
 			nil,
 			nil,
 			make([]oracle, repatt.config.N()),
 			false,
 			false,
+			nil,
 		}
 	}
 
@@ -349,26 +379,54 @@ func (repatt *reportAttestationState[RI]) tryComplete(seqNr uint64) {
 		return
 	}
 
+	var aggregateSignatures []*ocr3types.AggregateSignature
+	if repatt.config.FeatureFlags.Has(ocr3types.ProtocolFeatureFlagAggregateAttestation) {
+		var ok bool
+		aggregateSignatures, ok = repatt.aggregateSignatures(seqNr, aossPerReport)
+		if !ok {
+			return
+		}
+	}
+
 	if repatt.highestAttestedSeqNr < seqNr {
 		repatt.highestAttestedSeqNr = seqNr
 	}
 
 	repatt.rounds[seqNr].complete = true
+	endSpan(repatt.rounds[seqNr].attestationSpan, "")
+	repatt.rounds[seqNr].attestationSpan = nil
 
 	repatt.logger.Debug("sending attested reports to transmission protocol", commontypes.LogFields{
 		"seqNr":   seqNr,
 		"reports": len(reportsWithInfo),
 	})
 
+	reportBatches := repatt.rounds[seqNr].reportBatches
 	for i := range reportsWithInfo {
+		var reportBatch *ocr3types.ReportBatch[RI]
+		if reportBatches != nil {
+			reportBatch = &reportBatches[i]
+		}
+		var aggregateSignature *ocr3types.AggregateSignature
+		if aggregateSignatures != nil {
+			aggregateSignature = aggregateSignatures[i]
+		}
+		signers := make([]commontypes.OracleID, 0, len(aossPerReport[i]))
+		for _, aos := range aossPerReport[i] {
+			signers = append(signers, aos.Signer)
+		}
+		repatt.telemetrySender.ReportAttested(repatt.config.ConfigDigest, seqNr, i, signers)
 		select {
 		case repatt.chReportAttestationToTransmission <- EventAttestedReport[RI]{
 			seqNr,
 			i,
+			len(reportsWithInfo),
 			AttestedReportMany[RI]{
 				reportsWithInfo[i],
 				aossPerReport[i],
+				aggregateSignature,
 			},
+			reportBatch,
 		}:
 		case <-repatt.ctx.Done():
 		}
@@ -377,6 +435,39 @@ func (repatt *reportAttestationState[RI]) tryComplete(seqNr uint64) {
 	repatt.reap()
 }
 
+// aggregateSignatures aggregates the (valid) signatures of each report, see
+// ocr3types.ProtocolFeatureFlagAggregateAttestation.
+func (repatt *reportAttestationState[RI]) aggregateSignatures(seqNr uint64, aossPerReport [][]types.AttributedOnchainSignature) ([]*ocr3types.AggregateSignature, bool) {
+	if repatt.aggregatingOnchainKeyring == nil {
+		// managed checks this before starting the protocol
+		repatt.logger.Critical("config enables aggregate attestation, but OnchainKeyring doesn't implement AggregatingOnchainKeyring", commontypes.LogFields{
+			"seqNr": seqNr,
+		})
+		return nil, false
+	}
+
+	aggregateSignatures := make([]*ocr3types.AggregateSignature, 0, len(aossPerReport))
+	for i, aoss := range aossPerReport {
+		signatures := make([][]byte, 0, len(aoss))
+		var signers ocr3types.SignerBitmap
+		for _, aos := range aoss {
+			signatures = append(signatures, aos.Signature)
+			signers |= 1 << aos.Signer
+		}
+		aggregate, err := repatt.aggregatingOnchainKeyring.AggregateSignatures(signatures)
+		if err != nil {
+			repatt.logger.Error("failed to aggregate signatures", commontypes.LogFields{
+				"seqNr": seqNr,
+				"index": i,
+				"error": err,
+			})
+			return nil, false
+		}
+		aggregateSignatures = append(aggregateSignatures, &ocr3types.AggregateSignature{aggregate, signers})
+	}
+	return aggregateSignatures, true
+}
+
 func (repatt *reportAttestationState[RI]) verifySignatures(publicKey types.OnchainPublicKey, seqNr uint64, reportsWithInfo []ocr3types.ReportWithInfo[RI], signatures [][]byte) bool {
 	if len(reportsWithInfo) != len(signatures) {
 		return false
@@ -427,30 +518,138 @@ func (repatt *reportAttestationState[RI]) verifySignatures(publicKey types.Oncha
 }
 
 func (repatt *reportAttestationState[RI]) eventCommittedOutcome(ev EventCommittedOutcome[RI]) {
+	if repatt.outcomeContextReporter != nil && ev.PreviousOutcomeKnown && ev.CertifiedCommit.SeqNr > 1 {
+		repatt.outcomes[ev.CertifiedCommit.SeqNr-1] = ev.PreviousOutcome
+	}
 	repatt.receivedCertifiedCommit(ev.CertifiedCommit)
 }
 
-func (repatt *reportAttestationState[RI]) receivedCertifiedCommit(certifiedCommit CertifiedCommit) {
-	if repatt.rounds[certifiedCommit.SeqNr] != nil && repatt.rounds[certifiedCommit.SeqNr].reportsWithInfo != nil {
-		repatt.logger.Debug("dropping CertifiedCommit for which we already have reports", commontypes.LogFields{
-			"seqNr": certifiedCommit.SeqNr,
-		})
-		return
+// previousOutcome returns the outcome with sequence number seqNr-1, if we know
+// it.
+func (repatt *reportAttestationState[RI]) previousOutcome(seqNr uint64) (ocr3types.Outcome, bool) {
+	if seqNr <= 1 {
+		return nil, true
 	}
+	previousOutcome, ok := repatt.outcomes[seqNr-1]
+	return previousOutcome, ok
+}
 
-	reportsWithInfo, ok := callPlugin[[]ocr3types.ReportWithInfo[RI]](
+// reports calls the ReportingPlugin to obtain the reports to be signed for
+// certifiedCommit. If the plugin batches reports, it also returns the batches
+// whose roots are the reports to be signed.
+func (repatt *reportAttestationState[RI]) reports(certifiedCommit CertifiedCommit) ([]ocr3types.ReportWithInfo[RI], []ocr3types.ReportBatch[RI], bool) {
+	if repatt.outcomeContextReporter != nil {
+		previousOutcome, ok := repatt.previousOutcome(certifiedCommit.SeqNr)
+		if !ok {
+			repatt.logger.Info("previous outcome is unknown, leaving report generation to other oracles", commontypes.LogFields{
+				"seqNr": certifiedCommit.SeqNr,
+			})
+			return nil, nil, false
+		}
+		outctx := ocr3types.OutcomeContext{
+			certifiedCommit.SeqNr,
+			previousOutcome,
+			0,
+			0,
+			0,
+			false,
+			ocr3types.MakeOutcomeHash(previousOutcome),
+			time.Time{},
+		}
+		reportsWithInfo, ok := callPlugin[[]ocr3types.ReportWithInfo[RI]](
+			repatt.ctx,
+			repatt.logger,
+			repatt.metrics,
+			repatt.tracing,
+			certifiedCommit.SeqNr,
+			commontypes.LogFields{"seqNr": certifiedCommit.SeqNr},
+			"ReportsWithOutcomeContext",
+			0, // ReportsWithOutcomeContext is a pure function and should finish "instantly"
+			func(ctx context.Context) ([]ocr3types.ReportWithInfo[RI], error) {
+				return repatt.outcomeContextReporter.ReportsWithOutcomeContext(
+					ctx,
+					outctx,
+					certifiedCommit.Outcome,
+				)
+			},
+		)
+		return reportsWithInfo, nil, ok
+	}
+
+	if repatt.reportBatcher == nil {
+		reportsWithInfo, ok := callPlugin[[]ocr3types.ReportWithInfo[RI]](
+			repatt.ctx,
+			repatt.logger,
+			repatt.metrics,
+			repatt.tracing,
+			certifiedCommit.SeqNr,
+			commontypes.LogFields{"seqNr": certifiedCommit.SeqNr},
+			"Reports",
+			0, // Reports is a pure function and should finish "instantly"
+			func(ctx context.Context) ([]ocr3types.ReportWithInfo[RI], error) {
+				return repatt.reportingPlugin.Reports(
+					ctx,
+					certifiedCommit.SeqNr,
+					certifiedCommit.Outcome,
+				)
+			},
+		)
+		return reportsWithInfo, nil, ok
+	}
+
+	batches, ok := callPlugin[[][]ocr3types.ReportWithInfo[RI]](
 		repatt.ctx,
 		repatt.logger,
+		repatt.metrics,
+		repatt.tracing,
+		certifiedCommit.SeqNr,
 		commontypes.LogFields{"seqNr": certifiedCommit.SeqNr},
-		"Reports",
-		0, // Reports is a pure function and should finish "instantly"
-		func(context.Context) ([]ocr3types.ReportWithInfo[RI], error) {
-			return repatt.reportingPlugin.Reports(
+		"ReportBatches",
+		0, // ReportBatches is a pure function and should finish "instantly"
+		func(ctx context.Context) ([][]ocr3types.ReportWithInfo[RI], error) {
+			return repatt.reportBatcher.ReportBatches(
+				ctx,
 				certifiedCommit.SeqNr,
 				certifiedCommit.Outcome,
 			)
 		},
 	)
+	if !ok || batches == nil {
+		return nil, nil, ok
+	}
+
+	reportsWithInfo := make([]ocr3types.ReportWithInfo[RI], 0, len(batches))
+	reportBatches := make([]ocr3types.ReportBatch[RI], 0, len(batches))
+	for i, batch := range batches {
+		reportBatch, err := ocr3types.MakeReportBatch(batch)
+		if err != nil {
+			repatt.logger.Error("ReportingPlugin.ReportBatches returned invalid batch", commontypes.LogFields{
+				"seqNr": certifiedCommit.SeqNr,
+				"index": i,
+				"error": err,
+			})
+			return nil, nil, false
+		}
+		reportsWithInfo = append(reportsWithInfo, reportBatch.RootReportWithInfo())
+		reportBatches = append(reportBatches, reportBatch)
+	}
+	return reportsWithInfo, reportBatches, true
+}
+
+func (repatt *reportAttestationState[RI]) receivedCertifiedCommit(certifiedCommit CertifiedCommit) {
+	if repatt.rounds[certifiedCommit.SeqNr] != nil && repatt.rounds[certifiedCommit.SeqNr].reportsWithInfo != nil {
+		repatt.logger.Debug("dropping CertifiedCommit for which we already have reports", commontypes.LogFields{
+			"seqNr": certifiedCommit.SeqNr,
+		})
+		return
+	}
+
+	if repatt.outcomeContextReporter != nil {
+		repatt.outcomes[certifiedCommit.SeqNr] = certifiedCommit.Outcome
+		repatt.reapOutcomes()
+	}
+
+	reportsWithInfo, reportBatches, ok := repatt.reports(certifiedCommit)
 	if !ok {
 		return
 	}
@@ -478,15 +677,19 @@ func (repatt *reportAttestationState[RI]) receivedCertifiedCommit(certifiedCommi
 
 	if _, ok := repatt.rounds[certifiedCommit.SeqNr]; !ok {
 		repatt.rounds[certifiedCommit.SeqNr] = &round[RI]{
+			nil,
 			nil,
 			nil,
 			make([]oracle, repatt.config.N()),
 			false,
 			false,
+			nil,
 		}
 	}
 	repatt.rounds[certifiedCommit.SeqNr].certifiedCommit = &certifiedCommit
 	repatt.rounds[certifiedCommit.SeqNr].reportsWithInfo = reportsWithInfo
+	repatt.rounds[certifiedCommit.SeqNr].reportBatches = reportBatches
+	_, repatt.rounds[certifiedCommit.SeqNr].attestationSpan = repatt.tracing.start(repatt.ctx, "ReportAttestation", certifiedCommit.SeqNr)
 
 	repatt.logger.Debug("broadcasting MessageReportSignatures", commontypes.LogFields{
 		"seqNr": certifiedCommit.SeqNr,
@@ -533,13 +736,46 @@ func (repatt *reportAttestationState[RI]) reap() {
 	// repeatedly adding and deleting from the same map without ever exceeding
 	// some maximum length. Fortunately, this is no longer the case
 	// https://go-review.googlesource.com/c/go/+/25049/
-	for seqNr := range repatt.rounds {
+	for seqNr, round := range repatt.rounds {
 		if repatt.isBeyondExpiry(seqNr) {
+			if len(round.reportsWithInfo) != 0 && !round.complete {
+				repatt.logger.Debug("reaping round whose reports never attained enough signatures", commontypes.LogFields{
+					"seqNr": seqNr,
+				})
+				repatt.telemetrySender.ProtocolError(
+					repatt.config.ConfigDigest,
+					round.certifiedCommit.CommitEpoch,
+					seqNr,
+					ProtocolErrorCodeAttestationShortfall,
+				)
+			}
+			endSpan(round.attestationSpan, "expired before attaining enough signatures")
 			delete(repatt.rounds, seqNr)
 		}
 	}
 }
 
+// reap outcomes that are too old to serve as the previous outcome of a round
+// we might still generate reports for, to prevent unbounded state growth
+func (repatt *reportAttestationState[RI]) reapOutcomes() {
+	maxActiveRoundCount := repatt.expiryRounds() + repatt.lookaheadRounds()
+	// only reap if more than ~ a third of the outcomes can be discarded
+	if 3*len(repatt.outcomes) <= 4*maxActiveRoundCount {
+		return
+	}
+	highest := uint64(0)
+	for seqNr := range repatt.outcomes {
+		if highest < seqNr {
+			highest = seqNr
+		}
+	}
+	for seqNr := range repatt.outcomes {
+		if seqNr+uint64(maxActiveRoundCount) < highest {
+			delete(repatt.outcomes, seqNr)
+		}
+	}
+}
+
 // The age (denoted in rounds) after which a report is considered expired and
 // will automatically be dropped
 func (repatt *reportAttestationState[RI]) expiryRounds() int {
@@ -575,11 +811,20 @@ func newReportAttestationState[RI any](
 	config ocr3config.SharedConfig,
 	contractTransmitter ocr3types.ContractTransmitter[RI],
 	logger loghelper.LoggerWithContext,
+	metrics *Metrics,
 	netSender NetworkSender[RI],
 	onchainKeyring ocr3types.OnchainKeyring[RI],
-	reportingPlugin ocr3types.ReportingPlugin[RI],
+	reportingPlugin ocr3types.ReportingPluginV2[RI],
+	telemetrySender TelemetrySender,
+	tracing *Tracing,
 	sched *scheduler.Scheduler[EventMissingOutcome[RI]],
 ) *reportAttestationState[RI] {
+	reportBatcher, _ := reportingPlugin.(ocr3types.ReportBatcher[RI])
+	var outcomeContextReporter ocr3types.OutcomeContextReporter[RI]
+	if reportBatcher == nil {
+		outcomeContextReporter, _ = reportingPlugin.(ocr3types.OutcomeContextReporter[RI])
+	}
+	aggregatingOnchainKeyring, _ := onchainKeyring.(ocr3types.AggregatingOnchainKeyring[RI])
 	return &reportAttestationState[RI]{
 		ctx,
 
@@ -589,13 +834,21 @@ func newReportAttestationState[RI any](
 		config,
 		contractTransmitter,
 		logger.MakeUpdated(commontypes.LogFields{"proto": "repatt"}),
+		metrics,
 		netSender,
 		onchainKeyring,
 		reportingPlugin,
+		telemetrySender,
+		tracing,
+
+		reportBatcher,
+		outcomeContextReporter,
+		aggregatingOnchainKeyring,
 
 		sched,
 		map[uint64]*round[RI]{},
 		0,
 		make([]uint64, config.N()),
+		map[uint64]ocr3types.Outcome{},
 	}
 }
@@ -0,0 +1,118 @@
+package protocol
+
+import (
+	"time"
+)
+
+// A round counts as fast if it committed within this percentage of the
+// current round interval.
+const roundPacerFastPercentage = 50
+
+// Number of consecutive fast rounds after which the round interval is
+// shortened.
+const roundPacerSpeedUpStreak = 4
+
+// Number of consecutive rounds that didn't commit after which the round
+// interval is lengthened.
+const roundPacerBackOffStreak = 2
+
+// roundPacer adapts the interval between the rounds this oracle starts, see
+// ocr3config.PublicConfig.DeltaRoundMin. The latency of a round is the time
+// from this oracle starting it, as leader or (in query-less rounds) as
+// follower, until it commits. After roundPacerSpeedUpStreak consecutive rounds
+// whose latency was at most roundPacerFastPercentage of the current interval,
+// the interval is shortened by a quarter; the protocol then has headroom to
+// run faster, e.g. to follow volatile markets more closely. After
+// roundPacerBackOffStreak consecutive rounds that were started but never
+// committed, e.g. because the epoch timed out, the interval is doubled. The
+// interval always stays within [DeltaRoundMin, DeltaRoundMax].
+//
+// Every oracle paces itself based on its local timing only. The state lives
+// as long as the outcome generation protocol instance, i.e. it is scoped to a
+// config digest. Not thread-safe.
+type roundPacer struct {
+	enabled       bool
+	deltaRoundMin time.Duration
+	deltaRoundMax time.Duration
+
+	deltaRound time.Duration
+	// time at which the current round was started, zero if there is no
+	// uncommitted round
+	roundStartedAt time.Time
+	fastStreak     int
+	failedStreak   int
+}
+
+func newRoundPacer(enabled bool, deltaRound, deltaRoundMin, deltaRoundMax time.Duration) *roundPacer {
+	return &roundPacer{
+		enabled,
+		deltaRoundMin,
+		deltaRoundMax,
+
+		deltaRound,
+		time.Time{},
+		0,
+		0,
+	}
+}
+
+// interval returns the round interval to use for the next round.
+func (p *roundPacer) interval() time.Duration {
+	return p.deltaRound
+}
+
+func (p *roundPacer) roundStarted(now time.Time) {
+	if !p.enabled {
+		return
+	}
+	p.roundStartedAt = now
+}
+
+// roundCommitted returns whether the round interval changed.
+func (p *roundPacer) roundCommitted(now time.Time) bool {
+	if !p.enabled || p.roundStartedAt.IsZero() {
+		return false
+	}
+	latency := now.Sub(p.roundStartedAt)
+	p.roundStartedAt = time.Time{}
+	p.failedStreak = 0
+
+	if latency*100 > p.deltaRound*roundPacerFastPercentage {
+		p.fastStreak = 0
+		return false
+	}
+	p.fastStreak++
+	if p.fastStreak < roundPacerSpeedUpStreak {
+		return false
+	}
+	p.fastStreak = 0
+	return p.setInterval(p.deltaRound * 3 / 4)
+}
+
+// epochEnded returns whether the round interval changed.
+func (p *roundPacer) epochEnded() bool {
+	if !p.enabled || p.roundStartedAt.IsZero() {
+		return false
+	}
+	p.roundStartedAt = time.Time{}
+	p.fastStreak = 0
+
+	p.failedStreak++
+	if p.failedStreak < roundPacerBackOffStreak {
+		return false
+	}
+	p.failedStreak = 0
+	return p.setInterval(p.deltaRound * 2)
+}
+
+func (p *roundPacer) setInterval(deltaRound time.Duration) bool {
+	if deltaRound < p.deltaRoundMin {
+		deltaRound = p.deltaRoundMin
+	}
+	if deltaRound > p.deltaRoundMax {
+		deltaRound = p.deltaRoundMax
+	}
+	changed := deltaRound != p.deltaRound
+	p.deltaRound = deltaRound
+	return changed
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"hash"
+	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/byzquorum"
@@ -30,6 +31,8 @@ const signedObservationDomainSeparator = "ocr3 SignedObservation"
 type SignedObservation struct {
 	Observation types.Observation
 	Signature   []byte
+	// Zero unless ocr3types.ProtocolFeatureFlagObservationTimestamps is set
+	ObservedAt time.Time
 }
 
 func MakeSignedObservation(
@@ -37,17 +40,18 @@ func MakeSignedObservation(
 	seqNr uint64,
 	query types.Query,
 	observation types.Observation,
+	observedAt time.Time,
 	signer func(msg []byte) (sig []byte, err error),
 ) (
 	SignedObservation,
 	error,
 ) {
-	payload := signedObservationMsg(ogid, seqNr, query, observation)
+	payload := signedObservationMsg(ogid, seqNr, query, observation, observedAt)
 	sig, err := signer(payload)
 	if err != nil {
 		return SignedObservation{}, err
 	}
-	return SignedObservation{observation, sig}, nil
+	return SignedObservation{observation, sig, observedAt}, nil
 }
 
 func (so SignedObservation) Verify(ogid OutcomeGenerationID, seqNr uint64, query types.Query, publicKey types.OffchainPublicKey) error {
@@ -57,7 +61,7 @@ func (so SignedObservation) Verify(ogid OutcomeGenerationID, seqNr uint64, query
 		return fmt.Errorf("ed25519 public key size mismatch, expected %v but got %v", ed25519.PublicKeySize, len(pk))
 	}
 
-	ok := ed25519.Verify(pk, signedObservationMsg(ogid, seqNr, query, so.Observation), so.Signature)
+	ok := ed25519.Verify(pk, signedObservationMsg(ogid, seqNr, query, so.Observation, so.ObservedAt), so.Signature)
 	if !ok {
 		return fmt.Errorf("SignedObservation has invalid signature")
 	}
@@ -65,7 +69,7 @@ func (so SignedObservation) Verify(ogid OutcomeGenerationID, seqNr uint64, query
 	return nil
 }
 
-func signedObservationMsg(ogid OutcomeGenerationID, seqNr uint64, query types.Query, observation types.Observation) []byte {
+func signedObservationMsg(ogid OutcomeGenerationID, seqNr uint64, query types.Query, observation types.Observation, observedAt time.Time) []byte {
 	h := sha256.New()
 
 	_, _ = h.Write([]byte(signedObservationDomainSeparator))
@@ -86,12 +90,21 @@ func signedObservationMsg(ogid OutcomeGenerationID, seqNr uint64, query types.Qu
 	_ = binary.Write(h, binary.BigEndian, uint64(len(observation)))
 	_, _ = h.Write(observation)
 
+	// observedAt is omitted if zero, so that signatures stay the same as in
+	// library versions that predate it
+	if !observedAt.IsZero() {
+		_ = binary.Write(h, binary.BigEndian, observedAt.UnixNano())
+	}
+
 	return ocr3DomainSeparatedSum(h)
 }
 
 type AttributedSignedObservation struct {
 	SignedObservation SignedObservation
 	Observer          commontypes.OracleID
+	// Time at which the leader received the observation. Zero unless
+	// ocr3types.ProtocolFeatureFlagObservationTimestamps is set.
+	ReceivedAt time.Time
 }
 
 type OutcomeInputsDigest [32]byte
@@ -102,6 +115,7 @@ func MakeOutcomeInputsDigest(
 	seqNr uint64,
 	query types.Query,
 	attributedObservations []types.AttributedObservation,
+	observationTimestamps map[commontypes.OracleID]ocr3types.ObservationTimestamps,
 ) OutcomeInputsDigest {
 	h := sha256.New()
 
@@ -123,6 +137,13 @@ func MakeOutcomeInputsDigest(
 		_, _ = h.Write(ao.Observation)
 
 		_ = binary.Write(h, binary.BigEndian, uint64(ao.Observer))
+
+		// omitted if absent, so that digests stay the same as in library
+		// versions that predate them
+		if ts, ok := observationTimestamps[ao.Observer]; ok {
+			_ = binary.Write(h, binary.BigEndian, ts.ObservedAt.UnixNano())
+			_ = binary.Write(h, binary.BigEndian, ts.ReceivedAt.UnixNano())
+		}
 	}
 
 	var result OutcomeInputsDigest
@@ -362,8 +383,10 @@ func (qc *EpochStartProof) Verify(
 		if !(0 <= int(ashct.Signer) && int(ashct.Signer) < len(oracleIdentities)) {
 			return fmt.Errorf("signer out of bounds: %v", ashct.Signer)
 		}
-		if err := ashct.SignedHighestCertifiedTimestamp.Verify(ogid, oracleIdentities[ashct.Signer].OffchainPublicKey); err != nil {
-			return fmt.Errorf("%v-th signature by %v-th oracle with pubkey %x does not verify: %w", i, ashct.Signer, oracleIdentities[ashct.Signer].OffchainPublicKey, err)
+		if err := oracleIdentities[ashct.Signer].VerifyOffchainSignature(func(publicKey types.OffchainPublicKey) error {
+			return ashct.SignedHighestCertifiedTimestamp.Verify(ogid, publicKey)
+		}); err != nil {
+			return fmt.Errorf("%v-th signature by %v-th oracle with pubkeys %x does not verify: %w", i, ashct.Signer, oracleIdentities[ashct.Signer].OffchainPublicKeys(), err)
 		}
 
 		if maximumTimestamp.Less(ashct.SignedHighestCertifiedTimestamp.HighestCertifiedTimestamp) {
@@ -445,8 +468,10 @@ func (hc *CertifiedPrepare) Verify(
 		if !(0 <= int(aps.Signer) && int(aps.Signer) < len(oracleIdentities)) {
 			return fmt.Errorf("signer out of bounds: %v", aps.Signer)
 		}
-		if err := aps.Signature.Verify(ogid, hc.SeqNr, hc.OutcomeInputsDigest, MakeOutcomeDigest(hc.Outcome), oracleIdentities[aps.Signer].OffchainPublicKey); err != nil {
-			return fmt.Errorf("%v-th signature by %v-th oracle with pubkey %x does not verify: %w", i, aps.Signer, oracleIdentities[aps.Signer].OffchainPublicKey, err)
+		if err := oracleIdentities[aps.Signer].VerifyOffchainSignature(func(publicKey types.OffchainPublicKey) error {
+			return aps.Signature.Verify(ogid, hc.SeqNr, hc.OutcomeInputsDigest, MakeOutcomeDigest(hc.Outcome), publicKey)
+		}); err != nil {
+			return fmt.Errorf("%v-th signature by %v-th oracle with pubkeys %x does not verify: %w", i, aps.Signer, oracleIdentities[aps.Signer].OffchainPublicKeys(), err)
 		}
 	}
 	return nil
@@ -523,8 +548,10 @@ func (hc *CertifiedCommit) Verify(
 		if !(0 <= int(acs.Signer) && int(acs.Signer) < len(oracleIdentities)) {
 			return fmt.Errorf("signer out of bounds: %v", acs.Signer)
 		}
-		if err := acs.Signature.Verify(ogid, hc.SeqNr, MakeOutcomeDigest(hc.Outcome), oracleIdentities[acs.Signer].OffchainPublicKey); err != nil {
-			return fmt.Errorf("%v-th signature by %v-th oracle with pubkey %x does not verify: %w", i, acs.Signer, oracleIdentities[acs.Signer].OffchainPublicKey, err)
+		if err := oracleIdentities[acs.Signer].VerifyOffchainSignature(func(publicKey types.OffchainPublicKey) error {
+			return acs.Signature.Verify(ogid, hc.SeqNr, MakeOutcomeDigest(hc.Outcome), publicKey)
+		}); err != nil {
+			return fmt.Errorf("%v-th signature by %v-th oracle with pubkeys %x does not verify: %w", i, acs.Signer, oracleIdentities[acs.Signer].OffchainPublicKeys(), err)
 		}
 	}
 	return nil
@@ -0,0 +1,259 @@
+package protocol
+
+import (
+	"sort"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+)
+
+// We consider ourselves lagging once f+1 oracles have sent a MessageCommit
+// for a seqNr more than this far ahead of our committedSeqNr. Commits for
+// committedSeqNr+1 are part of the normal flow of a round, so we allow some
+// slack.
+const stateSyncLagThreshold = 2
+
+// stateSyncState implements a small subprotocol that lets an oracle that has
+// fallen behind catch up to the certified outcome chain without waiting for
+// the next epoch change.
+//
+// Without it, an oracle only learns about outcomes committed in its absence
+// from the MessageEpochStart that kicks off the next epoch. After a restart,
+// or after being partitioned for a while, an oracle may therefore be stuck
+// observing commits for seqNrs it cannot participate in, until the current
+// leader is replaced, which may take an entire RMax rounds on a long-lived
+// instance.
+//
+// Instead, every oracle tracks the highest seqNr each of its peers has sent a
+// MessageCommit for, regardless of epoch. Once f+1 of them, and hence at
+// least one honest oracle, are more than stateSyncLagThreshold seqNrs ahead
+// of us, we send a MessageStateSyncRequest to these f+1 oracles, at most once
+// per DeltaCertifiedCommitRequest. They respond with the latest
+// CertifiedCommit they hold, which serves as a checkpoint: since it carries a
+// quorum certificate, we can verify it and commit it directly, without
+// having to replay the outcomes leading up to it. If the checkpoint was
+// committed in the current epoch, we also jump straight to the following
+// round (see messageStateSyncResponse).
+//
+// State sync is best-effort, e.g. requests and responses may be lost. The
+// regular protocol for catching up at epoch changes is unaffected.
+type stateSyncState struct {
+	// highest seqNr each oracle has sent a MessageCommit for
+	highestCommitSeqNrs []uint64
+
+	// the latest CertifiedCommit we hold, nil if there is none. Used to
+	// respond to requests.
+	latestCertifiedCommit *CertifiedCommit
+
+	// time at which we sent our latest request
+	lastRequest time.Time
+	// oracles that we sent our latest request to and that haven't responded
+	// yet
+	pendingResponses []bool
+
+	// time at which we last responded to each oracle
+	lastResponses []time.Time
+}
+
+func newStateSyncState(n int) *stateSyncState {
+	return &stateSyncState{
+		make([]uint64, n),
+		nil,
+		time.Time{},
+		make([]bool, n),
+		make([]time.Time, n),
+	}
+}
+
+func (outgen *outcomeGenerationState[RI]) observeCommitSeqNr(seqNr uint64, sender commontypes.OracleID) {
+	stateSync := outgen.stateSync
+	if seqNr <= stateSync.highestCommitSeqNrs[sender] {
+		return
+	}
+	stateSync.highestCommitSeqNrs[sender] = seqNr
+
+	outgen.tryRequestStateSync()
+}
+
+func (outgen *outcomeGenerationState[RI]) tryRequestStateSync() {
+	stateSync := outgen.stateSync
+
+	if time.Since(stateSync.lastRequest) < outgen.config.DeltaCertifiedCommitRequest {
+		return
+	}
+
+	// the f+1 oracles with the highest seqNrs
+	oracles := make([]commontypes.OracleID, 0, len(stateSync.highestCommitSeqNrs))
+	for i := range stateSync.highestCommitSeqNrs {
+		if commontypes.OracleID(i) != outgen.id {
+			oracles = append(oracles, commontypes.OracleID(i))
+		}
+	}
+	sort.SliceStable(oracles, func(i, j int) bool {
+		return stateSync.highestCommitSeqNrs[oracles[i]] > stateSync.highestCommitSeqNrs[oracles[j]]
+	})
+	if len(oracles) < outgen.config.F+1 {
+		return
+	}
+	oracles = oracles[:outgen.config.F+1]
+
+	// at least one honest oracle has made it this far
+	honestSeqNr := stateSync.highestCommitSeqNrs[oracles[len(oracles)-1]]
+	if honestSeqNr <= outgen.sharedState.committedSeqNr+stateSyncLagThreshold {
+		return
+	}
+
+	outgen.logger.Info("lagging behind other oracles, sending MessageStateSyncRequest", commontypes.LogFields{
+		"committedSeqNr": outgen.sharedState.committedSeqNr,
+		"honestSeqNr":    honestSeqNr,
+		"to":             oracles,
+	})
+
+	stateSync.lastRequest = time.Now()
+	for i := range stateSync.pendingResponses {
+		stateSync.pendingResponses[i] = false
+	}
+	for _, oracle := range oracles {
+		stateSync.pendingResponses[oracle] = true
+	}
+	outgen.netSender.Multicast(MessageStateSyncRequest[RI]{outgen.sharedState.committedSeqNr}, oracles)
+}
+
+func (outgen *outcomeGenerationState[RI]) messageStateSyncRequest(msg MessageStateSyncRequest[RI], sender commontypes.OracleID) {
+	stateSync := outgen.stateSync
+
+	if stateSync.latestCertifiedCommit == nil || stateSync.latestCertifiedCommit.SeqNr <= msg.HighestCommittedSeqNr {
+		outgen.logger.Debug("dropping MessageStateSyncRequest, we don't have a newer CertifiedCommit", commontypes.LogFields{
+			"sender":                sender,
+			"highestCommittedSeqNr": msg.HighestCommittedSeqNr,
+		})
+		return
+	}
+
+	// Responses are much larger than requests. Don't let a byzantine oracle
+	// use us to amplify its traffic. We allow two responses per
+	// DeltaCertifiedCommitRequest to tolerate jitter in the timing of honest
+	// requests.
+	if time.Since(stateSync.lastResponses[sender]) < outgen.config.DeltaCertifiedCommitRequest/2 {
+		outgen.logger.Debug("dropping MessageStateSyncRequest, sender is sending requests too frequently", commontypes.LogFields{
+			"sender": sender,
+		})
+		return
+	}
+	stateSync.lastResponses[sender] = time.Now()
+
+	outgen.logger.Debug("sending MessageStateSyncResponse", commontypes.LogFields{
+		"sender":                sender,
+		"highestCommittedSeqNr": msg.HighestCommittedSeqNr,
+		"seqNr":                 stateSync.latestCertifiedCommit.SeqNr,
+	})
+	outgen.netSender.SendTo(MessageStateSyncResponse[RI]{*stateSync.latestCertifiedCommit}, sender)
+}
+
+func (outgen *outcomeGenerationState[RI]) messageStateSyncResponse(msg MessageStateSyncResponse[RI], sender commontypes.OracleID) {
+	stateSync := outgen.stateSync
+
+	if !stateSync.pendingResponses[sender] {
+		outgen.logger.Debug("dropping unsolicited MessageStateSyncResponse", commontypes.LogFields{
+			"sender": sender,
+		})
+		return
+	}
+	stateSync.pendingResponses[sender] = false
+
+	cert := msg.CertifiedCommit
+	if cert.IsGenesis() || cert.SeqNr <= outgen.sharedState.committedSeqNr {
+		outgen.logger.Debug("dropping MessageStateSyncResponse, CertifiedCommit isn't newer than committed outcome", commontypes.LogFields{
+			"sender":         sender,
+			"seqNr":          cert.SeqNr,
+			"committedSeqNr": outgen.sharedState.committedSeqNr,
+		})
+		return
+	}
+
+	if outgen.followerState.phase == outgenFollowerPhaseUnknown {
+		// We haven't started the first epoch yet
+		outgen.logger.Debug("dropping MessageStateSyncResponse, no epoch has started yet", commontypes.LogFields{
+			"sender": sender,
+		})
+		return
+	}
+
+	if cert.Timestamp().Less(outgen.followerState.cert.Timestamp()) {
+		// The response is newer than our committed outcome, but older than our
+		// lock, e.g. because we restored the lock from the database. Committing
+		// it would not be unsafe, but replacing the lock with an older cert would
+		// be.
+		outgen.logger.Debug("dropping MessageStateSyncResponse, CertifiedCommit is older than our lock", commontypes.LogFields{
+			"sender":        sender,
+			"seqNr":         cert.SeqNr,
+			"certTimestamp": cert.Timestamp(),
+			"lockTimestamp": outgen.followerState.cert.Timestamp(),
+		})
+		return
+	}
+
+	if err := cert.Verify(outgen.config.ConfigDigest, outgen.config.OracleIdentities, outgen.config.ByzQuorumSize()); err != nil {
+		outgen.logger.Warn("dropping MessageStateSyncResponse containing invalid CertifiedCommit", commontypes.LogFields{
+			"sender": sender,
+			"seqNr":  cert.SeqNr,
+			"error":  err,
+		})
+		return
+	}
+
+	outgen.logger.Info("catching up via state sync", commontypes.LogFields{
+		"sender":         sender,
+		"seqNr":          cert.SeqNr,
+		"commitEpoch":    cert.CommitEpoch,
+		"committedSeqNr": outgen.sharedState.committedSeqNr,
+	})
+
+	outgen.commit(cert)
+	if outgen.sharedState.committedSeqNr != cert.SeqNr {
+		// commit failed, e.g. because we couldn't persist the cert
+		return
+	}
+
+	if cert.CommitEpoch != outgen.sharedState.e || outgen.id == outgen.sharedState.l {
+		// We'll pick up from the committed outcome at the next epoch change,
+		// or, if we are the leader, when our own round completes.
+		return
+	}
+
+	// The current epoch has progressed past cert.SeqNr without us. Since any
+	// round of the current epoch that follows cert.SeqNr must build on it, we
+	// can safely join the next round, unless the epoch has run out of rounds.
+	if outgen.followerState.phase == outgenFollowerPhaseNewEpoch {
+		// We missed MessageEpochStart
+		outgen.followerState.tInitial = nil
+		// The cert doesn't tell us the actual first seqNr of the epoch, only
+		// that the epoch can't have started after cert.SeqNr+1, which is the
+		// case if cert.SeqNr is a reproposal. Using that bound means we may
+		// undercount the rounds of the epoch, but never cut the epoch short:
+		// the oracles that saw MessageEpochStart still enforce RMax exactly.
+		outgen.sharedState.firstSeqNrOfEpoch = cert.SeqNr + 1
+	}
+
+	// Same as after committing in messageCommit
+	if cert.SeqNr+1 >= outgen.sharedState.firstSeqNrOfEpoch && uint64(outgen.config.RMax) <= cert.SeqNr-outgen.sharedState.firstSeqNrOfEpoch+1 {
+		outgen.logger.Debug("epoch has been going on for too long after state sync, sending EventChangeLeader to Pacemaker", commontypes.LogFields{
+			"firstSeqNrOfEpoch": outgen.sharedState.firstSeqNrOfEpoch,
+			"seqNr":             cert.SeqNr,
+			"rMax":              outgen.config.RMax,
+		})
+		select {
+		case outgen.chOutcomeGenerationToPacemaker <- EventNewEpochRequest[RI]{}:
+		case <-outgen.ctx.Done():
+		}
+		return
+	}
+
+	select {
+	case outgen.chOutcomeGenerationToPacemaker <- EventProgress[RI]{}:
+	case <-outgen.ctx.Done():
+		return
+	}
+
+	outgen.startSubsequentFollowerRound()
+}
@@ -1,6 +1,8 @@
 package protocol
 
 import (
+	"fmt"
+
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
@@ -13,4 +15,159 @@ type TelemetrySender interface {
 		round uint64,
 		leader commontypes.OracleID,
 	)
+
+	// ProtocolError reports a failure of the protocol. epoch and seqNr are
+	// zero if the failure isn't tied to a particular epoch or round,
+	// respectively.
+	ProtocolError(
+		configDigest types.ConfigDigest,
+		epoch uint64,
+		seqNr uint64,
+		code ProtocolErrorCode,
+	)
+
+	// OutcomeComputed reports that this oracle computed the outcome of a
+	// round from the leader's proposal. includedObservers are the oracles
+	// whose observations the proposal contained, excludedObservers all other
+	// oracles.
+	OutcomeComputed(
+		configDigest types.ConfigDigest,
+		epoch uint64,
+		seqNr uint64,
+		round uint64,
+		leader commontypes.OracleID,
+		includedObservers []commontypes.OracleID,
+		excludedObservers []commontypes.OracleID,
+		outcomeDigest OutcomeDigest,
+	)
+
+	// OutcomeCommitted reports that this oracle committed the outcome of
+	// seqNr, certified in commitEpoch.
+	OutcomeCommitted(
+		configDigest types.ConfigDigest,
+		commitEpoch uint64,
+		seqNr uint64,
+		outcomeDigest OutcomeDigest,
+	)
+
+	// ReportAttested reports that this oracle collected valid signatures from
+	// f+1 signers for the report of seqNr with the given index.
+	ReportAttested(
+		configDigest types.ConfigDigest,
+		seqNr uint64,
+		index int,
+		signers []commontypes.OracleID,
+	)
+
+	// TransmissionDecided reports what this oracle decided to do with an
+	// attested report.
+	TransmissionDecided(
+		configDigest types.ConfigDigest,
+		seqNr uint64,
+		index int,
+		decision TransmissionDecision,
+	)
+
+	// DegradedModeChanged reports that this oracle's protocol instance
+	// entered or left degraded mode. reachableOracles is the number of
+	// oracles, including this one, that this oracle has recently received
+	// messages from.
+	DegradedModeChanged(
+		configDigest types.ConfigDigest,
+		degraded bool,
+		reachableOracles int,
+	)
+
+	// CorruptedStateQuarantined reports that this oracle found the state it
+	// persisted under key to be corrupted when starting the protocol
+	// instance, and quarantined it to start from a fresh state instead.
+	CorruptedStateQuarantined(
+		configDigest types.ConfigDigest,
+		key string,
+	)
+
+	// TimerExpired reports that one of the protocol's timers fired while
+	// this oracle was in the given epoch and round. It isn't sent as
+	// telemetry, but lets traces (see package ocr3trace) capture when the
+	// protocol acted on its own rather than in response to a message.
+	TimerExpired(
+		configDigest types.ConfigDigest,
+		epoch uint64,
+		seqNr uint64,
+		timer Timer,
+	)
+}
+
+// Timer names one of the protocol's timers
+type Timer string
+
+const (
+	TimerProgress      Timer = "TProgress"
+	TimerResend        Timer = "TResend"
+	TimerInitial       Timer = "TInitial"
+	TimerFollowerRound Timer = "TRound (follower)"
+	TimerLeaderRound   Timer = "TRound (leader)"
+	TimerGrace         Timer = "TGrace"
+)
+
+// ProtocolErrorCode identifies the cause of a protocol failure. Codes are
+// stable across versions, so that dashboards can break down failures
+// consistently across DONs. Never reuse or renumber codes, and keep them in
+// sync with ProtocolErrorCode in offchainreporting3_telemetry.proto.
+type ProtocolErrorCode int
+
+const (
+	_ ProtocolErrorCode = iota
+	// The pacemaker's progress timer expired, i.e. no round was committed
+	// within DeltaProgress, and this oracle asked for a new epoch.
+	ProtocolErrorCodeProgressTimeout
+	// As leader, this oracle didn't receive a quorum of valid observations
+	// before the round was aborted by an epoch change.
+	ProtocolErrorCodeInsufficientObservations
+	// A round's reports expired before this oracle received signatures from
+	// f+1 oracles.
+	ProtocolErrorCodeAttestationShortfall
+	// ContractTransmitter.Transmit returned an error.
+	ProtocolErrorCodeTransmitFailure
+)
+
+func (c ProtocolErrorCode) String() string {
+	switch c {
+	case ProtocolErrorCodeProgressTimeout:
+		return "progressTimeout"
+	case ProtocolErrorCodeInsufficientObservations:
+		return "insufficientObservations"
+	case ProtocolErrorCodeAttestationShortfall:
+		return "attestationShortfall"
+	case ProtocolErrorCodeTransmitFailure:
+		return "transmitFailure"
+	}
+	return fmt.Sprintf("ProtocolErrorCode(%d)", int(c))
+}
+
+// TransmissionDecision is the fate of an attested report on this oracle. Keep
+// in sync with TransmissionDecision in offchainreporting3_telemetry.proto.
+type TransmissionDecision int
+
+const (
+	_ TransmissionDecision = iota
+	// ShouldAcceptAttestedReport returned false.
+	TransmissionDecisionNotAccepted
+	// ShouldTransmitAcceptedReport returned false.
+	TransmissionDecisionNotTransmitted
+	// The report was passed to the ContractTransmitter, which returned
+	// without error.
+	TransmissionDecisionTransmitted
+)
+
+func (d TransmissionDecision) String() string {
+	switch d {
+	case TransmissionDecisionNotAccepted:
+		return "notAccepted"
+	case TransmissionDecisionNotTransmitted:
+		return "notTransmitted"
+	case TransmissionDecisionTransmitted:
+		return "transmitted"
+	}
+	return fmt.Sprintf("TransmissionDecision(%d)", int(d))
 }
@@ -0,0 +1,92 @@
+package protocol
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const tracerName = "github.com/smartcontractkit/libocr/offchainreporting2plus"
+
+// Tracing creates OpenTelemetry spans for the phases of the rounds of a
+// protocol instance.
+//
+// Every (configDigest, seqNr) has its own trace. Its trace ID is derived from
+// configDigest and seqNr, so that the spans of all oracles end up in the same
+// trace without the protocol having to propagate trace context over the
+// network. The spans of each oracle are children of a virtual root span whose
+// ID is derived in the same way and which is never recorded itself.
+type Tracing struct {
+	tracer       trace.Tracer
+	configDigest types.ConfigDigest
+	id           commontypes.OracleID
+}
+
+// newTracing creates a Tracing that creates spans with tracerProvider, which
+// may be nil, in which case no spans are recorded.
+func newTracing(tracerProvider trace.TracerProvider, configDigest types.ConfigDigest, id commontypes.OracleID) *Tracing {
+	if tracerProvider == nil {
+		tracerProvider = noop.NewTracerProvider()
+	}
+	return &Tracing{tracerProvider.Tracer(tracerName), configDigest, id}
+}
+
+// rootSpanContext returns the span context of the virtual root span of the
+// trace for seqNr.
+func (t *Tracing) rootSpanContext(seqNr uint64) trace.SpanContext {
+	h := sha256.New()
+	_, _ = h.Write([]byte("ocr3 round trace"))
+	_, _ = h.Write(t.configDigest[:])
+	_ = binary.Write(h, binary.BigEndian, seqNr)
+	var digest [sha256.Size]byte
+	h.Sum(digest[:0])
+
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	copy(traceID[:], digest[:len(traceID)])
+	copy(spanID[:], digest[len(traceID):])
+
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
+
+// start starts a span named name in the trace for seqNr. The span is a child
+// of the span carried by ctx if that span belongs to the same trace, e.g.
+// because ctx was returned by a previous call to start, and a child of the
+// trace's virtual root span otherwise. The returned context carries the new
+// span and should be passed to plugin callbacks, so that they can create
+// spans of their own.
+func (t *Tracing) start(ctx context.Context, name string, seqNr uint64) (context.Context, trace.Span) {
+	root := t.rootSpanContext(seqNr)
+	if trace.SpanContextFromContext(ctx).TraceID() != root.TraceID() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, root)
+	}
+	return t.tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.String("ocr3.config_digest", t.configDigest.Hex()),
+		attribute.Int("ocr3.oracle_id", int(t.id)),
+		attribute.Int64("ocr3.seq_nr", int64(seqNr)),
+	))
+}
+
+// endSpan ends span, if it isn't nil. A non-empty errorDescription marks the
+// span as failed.
+func endSpan(span trace.Span, errorDescription string) {
+	if span == nil {
+		return
+	}
+	if errorDescription != "" {
+		span.SetStatus(codes.Error, errorDescription)
+	}
+	span.End()
+}
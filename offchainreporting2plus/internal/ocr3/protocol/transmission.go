@@ -5,16 +5,22 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/binary"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/scheduler"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/latestreportcache"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/retransmission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/transmissionretry"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 	"github.com/smartcontractkit/libocr/permutation"
 	"github.com/smartcontractkit/libocr/subprocesses"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const ContractTransmitterTimeoutWarningGracePeriod = 50 * time.Millisecond
@@ -23,31 +29,65 @@ func RunTransmission[RI any](
 	ctx context.Context,
 	subprocesses *subprocesses.Subprocesses,
 
+	chDrain <-chan struct{},
 	chReportAttestationToTransmission <-chan EventToTransmission[RI],
+	chRetransmissionRequests <-chan retransmission.Request,
 	config ocr3config.SharedConfig,
 	contractTransmitter ocr3types.ContractTransmitter[RI],
 	id commontypes.OracleID,
+	latestReportCache *latestreportcache.Cache,
+	latestReportCacheDestinations []ocr3types.ReportDestination,
 	localConfig types.LocalConfig,
 	logger loghelper.LoggerWithContext,
-	reportingPlugin ocr3types.ReportingPlugin[RI],
+	metrics *Metrics,
+	reportingPlugin ocr3types.ReportingPluginV2[RI],
+	telemetrySender TelemetrySender,
+	tracing *Tracing,
+	transmissionRetryPolicy transmissionretry.Policy,
 ) {
-	sched := scheduler.NewScheduler[EventAttestedReport[RI]]()
+	sched := scheduler.NewScheduler[scheduledReport[RI]]()
 	defer sched.Close()
+	batchSched := scheduler.NewScheduler[scheduledBatch[RI]]()
+	defer batchSched.Close()
+
+	batchContractTransmitter, _ := contractTransmitter.(ocr3types.BatchContractTransmitter[RI])
+	reportDecisionBatcher, _ := reportingPlugin.(ocr3types.ReportDecisionBatcher[RI])
+
+	if latestReportCache != nil {
+		ctx = latestreportcache.ContextWithCache(ctx, latestReportCache)
+	}
 
 	t := transmissionState[RI]{
 		ctx,
 		subprocesses,
 
+		chDrain,
 		chReportAttestationToTransmission,
+		chRetransmissionRequests,
 		config,
 		contractTransmitter,
 		id,
+		latestReportCache,
+		latestReportCacheDestinations,
 		localConfig,
 		logger.MakeUpdated(commontypes.LogFields{"proto": "transmission"}),
+		metrics,
 		reportingPlugin,
+		telemetrySender,
+		tracing,
+		transmissionRetryPolicy,
+
+		batchContractTransmitter,
+		reportDecisionBatcher,
 
 		sched,
+		batchSched,
+		0,
+		map[uint64]*pendingReports[RI]{},
+		nil,
+		false,
 	}
+	metrics.SetTransmissionQueueDepth(0)
 	t.run()
 }
 
@@ -56,15 +96,65 @@ type transmissionState[RI any] struct {
 
 	subprocesses *subprocesses.Subprocesses
 
+	// closed once the rest of the protocol instance has stopped, see
+	// types.HotConfigSwapConfig
+	chDrain                           <-chan struct{}
 	chReportAttestationToTransmission <-chan EventToTransmission[RI]
+	chRetransmissionRequests          <-chan retransmission.Request
 	config                            ocr3config.SharedConfig
 	contractTransmitter               ocr3types.ContractTransmitter[RI]
 	id                                commontypes.OracleID
-	localConfig                       types.LocalConfig
-	logger                            loghelper.LoggerWithContext
-	reportingPlugin                   ocr3types.ReportingPlugin[RI]
+	// nil if there is none
+	latestReportCache *latestreportcache.Cache
+	// the destinations under which reports are looked up in
+	// latestReportCache
+	latestReportCacheDestinations []ocr3types.ReportDestination
+	localConfig                   types.LocalConfig
+	logger                        loghelper.LoggerWithContext
+	metrics                       *Metrics
+	reportingPlugin               ocr3types.ReportingPluginV2[RI]
+	telemetrySender               TelemetrySender
+	tracing                       *Tracing
+	// nil if failed transmissions aren't retried
+	transmissionRetryPolicy transmissionretry.Policy
+
+	// nil unless contractTransmitter implements
+	// ocr3types.BatchContractTransmitter
+	batchContractTransmitter ocr3types.BatchContractTransmitter[RI]
+	// nil unless reportingPlugin implements ocr3types.ReportDecisionBatcher
+	reportDecisionBatcher ocr3types.ReportDecisionBatcher[RI]
+
+	scheduler *scheduler.Scheduler[scheduledReport[RI]]
+	// only used if batchContractTransmitter is set
+	batchScheduler *scheduler.Scheduler[scheduledBatch[RI]]
+	// number of reports in scheduler and batchScheduler
+	scheduledCount int
+	// only used if batchContractTransmitter or reportDecisionBatcher is set:
+	// reports of seqNrs whose reports haven't all arrived yet, keyed by seqNr
+	pendingReports map[uint64]*pendingReports[RI]
+	// attested reports of the highest seqNr received so far, retained for
+	// retransmission
+	latestAttestedReports []EventAttestedReport[RI]
+	// whether chDrain has been closed
+	draining bool
+}
+
+// retryState tracks the attempts to transmit a report or batch
+type retryState struct {
+	// when the report (or the last report of the batch) was received
+	receivedAt     time.Time
+	failedAttempts int
+	lastErr        error
+}
+
+type scheduledReport[RI any] struct {
+	ev    EventAttestedReport[RI]
+	retry retryState
+}
 
-	scheduler *scheduler.Scheduler[EventAttestedReport[RI]]
+type scheduledBatch[RI any] struct {
+	evs   []EventAttestedReport[RI]
+	retry retryState
 }
 
 // run runs the event loop for the local transmission protocol
@@ -78,6 +168,12 @@ func (t *transmissionState[RI]) run() {
 			ev.processTransmission(t)
 		case ev := <-t.scheduler.Scheduled():
 			t.scheduled(ev)
+		case evs := <-t.batchScheduler.Scheduled():
+			t.batchScheduled(evs)
+		case req := <-t.chRetransmissionRequests: // nil unless retransmission is enabled
+			t.retransmissionRequest(req)
+		case <-t.chDrain:
+			t.drain()
 		case <-chDone:
 		}
 
@@ -88,42 +184,94 @@ func (t *transmissionState[RI]) run() {
 			return
 		default:
 		}
+
+		if t.draining && t.scheduledCount == 0 {
+			t.logger.Info("Transmission: drained, exiting", nil)
+			return
+		}
 	}
 }
 
+// drain makes the event loop exit once all scheduled reports have been
+// transmitted (or given up on), instead of when ctx is done. Reports whose
+// seqNr is still waiting for more reports to arrive are dropped, since the
+// rest of the protocol instance has stopped and won't send any.
+func (t *transmissionState[RI]) drain() {
+	t.logger.Info("Transmission: draining", commontypes.LogFields{
+		"scheduledReports": t.scheduledCount,
+	})
+	t.chDrain = nil
+	// retransmission requests are for the protocol instance that replaces
+	// ours, leave them to it
+	t.chRetransmissionRequests = nil
+	t.draining = true
+}
+
 func (t *transmissionState[RI]) eventAttestedReport(ev EventAttestedReport[RI]) {
-	now := time.Now()
+	t.retainForRetransmission(ev)
+	t.accept(ev)
+}
 
-	shouldAccept, ok := callPlugin[bool](
-		t.ctx,
-		t.logger,
-		commontypes.LogFields{
-			"seqNr": ev.SeqNr,
-			"index": ev.Index,
-		},
-		"ShouldAcceptAttestedReport",
-		t.config.MaxDurationShouldAcceptAttestedReport,
-		func(ctx context.Context) (bool, error) {
-			return t.reportingPlugin.ShouldAcceptAttestedReport(
-				ctx,
-				ev.SeqNr,
-				ev.AttestedReport.ReportWithInfo,
-			)
-		},
-	)
-	if !ok {
+// retainForRetransmission remembers ev if it is one of the reports of the
+// highest seqNr received so far.
+func (t *transmissionState[RI]) retainForRetransmission(ev EventAttestedReport[RI]) {
+	if len(t.latestAttestedReports) != 0 {
+		latestSeqNr := t.latestAttestedReports[0].SeqNr
+		if ev.SeqNr < latestSeqNr {
+			return
+		}
+		if ev.SeqNr > latestSeqNr {
+			t.latestAttestedReports = nil
+		}
+	}
+	t.latestAttestedReports = append(t.latestAttestedReports, ev)
+}
+
+func (t *transmissionState[RI]) retransmissionRequest(req retransmission.Request) {
+	if req.ConfigDigest != t.config.ConfigDigest {
+		req.Respond(fmt.Errorf("oracle is running config digest %v, not %v", t.config.ConfigDigest, req.ConfigDigest))
+		return
+	}
+	if len(t.latestAttestedReports) == 0 {
+		req.Respond(fmt.Errorf("no reports have been attested for config digest %v yet", req.ConfigDigest))
 		return
 	}
 
-	if !shouldAccept {
-		t.logger.Debug("ReportingPlugin.ShouldAcceptAttestedReport returned false", commontypes.LogFields{
-			"seqNr": ev.SeqNr,
-			"index": ev.Index,
-		})
+	t.logger.Info("Transmission: retransmission of latest attested reports requested", commontypes.LogFields{
+		"seqNr":   t.latestAttestedReports[0].SeqNr,
+		"reports": len(t.latestAttestedReports),
+	})
+	// respond before calling the plugin, so the requester isn't held up by
+	// slow calls to ShouldAcceptAttestedReport
+	req.Respond(nil)
+	for _, ev := range t.latestAttestedReports {
+		t.accept(ev)
+	}
+}
+
+// accept schedules ev for transmission if the plugin accepts it and we're
+// part of its transmission schedule. If we transmit in batches or the plugin
+// decides on all reports of a seqNr at once, ev is held back until all reports
+// of its seqNr have arrived.
+func (t *transmissionState[RI]) accept(ev EventAttestedReport[RI]) {
+	if t.batchContractTransmitter != nil || t.reportDecisionBatcher != nil {
+		t.acceptAll(ev)
+		return
+	}
+
+	now := time.Now()
+
+	if !t.shouldAccept(ev) {
 		return
 	}
 
-	delayMaybe := t.transmitDelay(ev.SeqNr, ev.Index)
+	t.schedule(ev, now)
+}
+
+// schedule schedules the accepted report ev for transmission, unless we're not
+// part of its transmission schedule.
+func (t *transmissionState[RI]) schedule(ev EventAttestedReport[RI], receivedAt time.Time) {
+	delayMaybe := t.transmitDelay(ev.SeqNr, ev.Index, t.transmissionHint(ev))
 	if delayMaybe == nil {
 		t.logger.Debug("dropping EventAttestedReport because we're not included in transmission schedule", commontypes.LogFields{
 			"seqNr": ev.SeqNr,
@@ -138,21 +286,175 @@ func (t *transmissionState[RI]) eventAttestedReport(ev EventAttestedReport[RI])
 		"index": ev.Index,
 		"delay": delay.String(),
 	})
-	t.scheduler.ScheduleDeadline(ev, now.Add(delay))
+	t.scheduler.ScheduleDeadline(scheduledReport[RI]{ev, retryState{receivedAt, 0, nil}}, receivedAt.Add(delay))
+	t.scheduledCount++
+	t.metrics.SetTransmissionQueueDepth(t.scheduledCount)
+}
+
+type pendingReports[RI any] struct {
+	considered map[int]bool // keyed by report index
+	evs        []EventAttestedReport[RI]
+}
+
+// acceptAll holds back ev until all reports of its seqNr have arrived. It then
+// asks the plugin which of them to accept and schedules the accepted ones for
+// transmission, as a single batch if we transmit in batches.
+func (t *transmissionState[RI]) acceptAll(ev EventAttestedReport[RI]) {
+	pending, ok := t.pendingReports[ev.SeqNr]
+	if !ok {
+		pending = &pendingReports[RI]{map[int]bool{}, nil}
+		t.pendingReports[ev.SeqNr] = pending
+	}
+	if pending.considered[ev.Index] {
+		// This happens if retransmission is requested while the reports of
+		// ev.SeqNr are still arriving.
+		return
+	}
+	pending.considered[ev.Index] = true
+	pending.evs = append(pending.evs, ev)
+	if len(pending.considered) < ev.ReportCount {
+		return
+	}
+	delete(t.pendingReports, ev.SeqNr)
+
+	sort.Slice(pending.evs, func(i, j int) bool {
+		return pending.evs[i].Index < pending.evs[j].Index
+	})
+	accepted := t.shouldAcceptAll(ev.SeqNr, pending.evs)
+
+	now := time.Now()
+	if t.batchContractTransmitter == nil {
+		for _, ev := range accepted {
+			t.schedule(ev, now)
+		}
+		return
+	}
+
+	if len(accepted) == 0 {
+		t.logger.Debug("dropping batch because no AttestedReport was accepted", commontypes.LogFields{
+			"seqNr": ev.SeqNr,
+		})
+		return
+	}
+
+	// The whole batch is scheduled like the first report of the seqNr, at
+	// the earliest time permitted by the hints of its reports.
+	var delayMaybe *time.Duration
+	for _, acceptedEv := range accepted {
+		d := t.transmitDelay(ev.SeqNr, 0, t.transmissionHint(acceptedEv))
+		if d == nil {
+			break
+		}
+		if delayMaybe == nil || *d < *delayMaybe {
+			delayMaybe = d
+		}
+	}
+	if delayMaybe == nil {
+		t.logger.Debug("dropping batch because we're not included in transmission schedule", commontypes.LogFields{
+			"seqNr": ev.SeqNr,
+		})
+		return
+	}
+	delay := *delayMaybe
+
+	t.logger.Debug("accepted batch for transmission", commontypes.LogFields{
+		"seqNr":   ev.SeqNr,
+		"reports": len(accepted),
+		"delay":   delay.String(),
+	})
+	t.batchScheduler.ScheduleDeadline(scheduledBatch[RI]{accepted, retryState{now, 0, nil}}, now.Add(delay))
+	t.scheduledCount += len(accepted)
+	t.metrics.SetTransmissionQueueDepth(t.scheduledCount)
 }
 
-func (t *transmissionState[RI]) scheduled(ev EventAttestedReport[RI]) {
-	shouldTransmit, ok := callPlugin[bool](
+// shouldAcceptAll returns the reports among evs (all of seqNr, ordered by
+// index) that the plugin accepts.
+func (t *transmissionState[RI]) shouldAcceptAll(seqNr uint64, evs []EventAttestedReport[RI]) []EventAttestedReport[RI] {
+	var accepted []EventAttestedReport[RI]
+	if t.reportDecisionBatcher == nil {
+		for _, ev := range evs {
+			if t.shouldAccept(ev) {
+				accepted = append(accepted, ev)
+			}
+		}
+		return accepted
+	}
+
+	decisions, ok := callPlugin[[]bool](
 		t.ctx,
 		t.logger,
+		t.metrics,
+		t.tracing,
+		seqNr,
+		commontypes.LogFields{
+			"seqNr":   seqNr,
+			"reports": len(evs),
+		},
+		"ShouldAcceptAttestedReports",
+		t.config.MaxDurationShouldAcceptAttestedReport,
+		func(ctx context.Context) ([]bool, error) {
+			return t.reportDecisionBatcher.ShouldAcceptAttestedReports(
+				ctx,
+				seqNr,
+				reportsWithInfo(evs),
+			)
+		},
+	)
+	if !ok || !t.checkDecisions("ShouldAcceptAttestedReports", seqNr, evs, decisions) {
+		return nil
+	}
+
+	for i, ev := range evs {
+		if !decisions[i] {
+			t.logger.Debug("ReportingPlugin.ShouldAcceptAttestedReports returned false", commontypes.LogFields{
+				"seqNr": ev.SeqNr,
+				"index": ev.Index,
+			})
+			t.telemetrySender.TransmissionDecided(t.config.ConfigDigest, ev.SeqNr, ev.Index, TransmissionDecisionNotAccepted)
+			continue
+		}
+		accepted = append(accepted, ev)
+	}
+	return accepted
+}
+
+func reportsWithInfo[RI any](evs []EventAttestedReport[RI]) []ocr3types.ReportWithInfo[RI] {
+	reports := make([]ocr3types.ReportWithInfo[RI], 0, len(evs))
+	for _, ev := range evs {
+		reports = append(reports, ev.AttestedReport.ReportWithInfo)
+	}
+	return reports
+}
+
+// checkDecisions returns false if the plugin's function name didn't return
+// exactly one decision per report.
+func (t *transmissionState[RI]) checkDecisions(name string, seqNr uint64, evs []EventAttestedReport[RI], decisions []bool) bool {
+	if len(decisions) != len(evs) {
+		t.logger.Error(fmt.Sprintf("ReportingPlugin.%s returned wrong number of decisions", name), commontypes.LogFields{
+			"seqNr":     seqNr,
+			"reports":   len(evs),
+			"decisions": len(decisions),
+		})
+		return false
+	}
+	return true
+}
+
+func (t *transmissionState[RI]) shouldAccept(ev EventAttestedReport[RI]) bool {
+	shouldAccept, ok := callPlugin[bool](
+		t.attestedReportContext(t.ctx, ev),
+		t.logger,
+		t.metrics,
+		t.tracing,
+		ev.SeqNr,
 		commontypes.LogFields{
 			"seqNr": ev.SeqNr,
 			"index": ev.Index,
 		},
-		"ShouldTransmitAcceptedReport",
-		t.config.MaxDurationShouldTransmitAcceptedReport,
+		"ShouldAcceptAttestedReport",
+		t.config.MaxDurationShouldAcceptAttestedReport,
 		func(ctx context.Context) (bool, error) {
-			return t.reportingPlugin.ShouldTransmitAcceptedReport(
+			return t.reportingPlugin.ShouldAcceptAttestedReport(
 				ctx,
 				ev.SeqNr,
 				ev.AttestedReport.ReportWithInfo,
@@ -160,25 +462,57 @@ func (t *transmissionState[RI]) scheduled(ev EventAttestedReport[RI]) {
 		},
 	)
 	if !ok {
-		return
+		return false
 	}
 
-	if !shouldTransmit {
-		t.logger.Info("ReportingPlugin.ShouldTransmitAcceptedReport returned false", commontypes.LogFields{
+	if !shouldAccept {
+		t.logger.Debug("ReportingPlugin.ShouldAcceptAttestedReport returned false", commontypes.LogFields{
 			"seqNr": ev.SeqNr,
 			"index": ev.Index,
 		})
+		t.telemetrySender.TransmissionDecided(t.config.ConfigDigest, ev.SeqNr, ev.Index, TransmissionDecisionNotAccepted)
+		return false
+	}
+	return true
+}
+
+func (t *transmissionState[RI]) scheduled(sr scheduledReport[RI]) {
+	t.scheduledCount--
+	t.metrics.SetTransmissionQueueDepth(t.scheduledCount)
+
+	ev := sr.ev
+	if !t.shouldStillRetry(ev.SeqNr, sr.retry) {
+		return
+	}
+	if t.alreadyReported(ev.SeqNr) {
+		t.telemetrySender.TransmissionDecided(t.config.ConfigDigest, ev.SeqNr, ev.Index, TransmissionDecisionNotTransmitted)
+		return
+	}
+
+	attestedReportCtx, span := t.tracing.start(t.attestedReportContext(t.ctx, ev), "Transmission", ev.SeqNr)
+	span.SetAttributes(attribute.Int("ocr3.report_index", ev.Index))
+	spanErrorDescription := ""
+	defer func() { endSpan(span, spanErrorDescription) }()
+
+	shouldTransmit, ok := t.shouldTransmit(attestedReportCtx, ev)
+	if !ok {
+		spanErrorDescription = "ShouldTransmitAcceptedReport failed"
+		return
+	}
+	if !shouldTransmit {
 		return
 	}
 
 	t.logger.Debug("transmitting report", commontypes.LogFields{
-		"seqNr": ev.SeqNr,
-		"index": ev.Index,
+		"seqNr":          ev.SeqNr,
+		"index":          ev.Index,
+		"failedAttempts": sr.retry.failedAttempts,
 	})
 
 	{
+		ctx, transmitSpan := t.tracing.start(attestedReportCtx, "ContractTransmitter.Transmit", ev.SeqNr)
 		ctx, cancel := context.WithTimeout(
-			t.ctx,
+			ctx,
 			t.localConfig.ContractTransmitterTransmitTimeout,
 		)
 		defer cancel()
@@ -205,19 +539,335 @@ func (t *transmissionState[RI]) scheduled(ev EventAttestedReport[RI]) {
 		ins.Stop()
 
 		if err != nil {
+			endSpan(transmitSpan, err.Error())
+			spanErrorDescription = "ContractTransmitter.Transmit failed"
 			t.logger.Error("ContractTransmitter.Transmit error", commontypes.LogFields{"error": err})
+			t.telemetrySender.ProtocolError(t.config.ConfigDigest, 0, ev.SeqNr, ProtocolErrorCodeTransmitFailure)
+			if delay, ok := t.retryDelay(ev.SeqNr, &sr.retry, err); ok {
+				t.scheduler.ScheduleDelay(sr, delay)
+				t.scheduledCount++
+				t.metrics.SetTransmissionQueueDepth(t.scheduledCount)
+			}
 			return
 		}
-
+		endSpan(transmitSpan, "")
 	}
 
 	t.logger.Info("🚀 successfully invoked ContractTransmitter.Transmit", commontypes.LogFields{
 		"seqNr": ev.SeqNr,
 		"index": ev.Index,
 	})
+	t.telemetrySender.TransmissionDecided(t.config.ConfigDigest, ev.SeqNr, ev.Index, TransmissionDecisionTransmitted)
+}
+
+func (t *transmissionState[RI]) batchScheduled(sb scheduledBatch[RI]) {
+	evs := sb.evs
+	t.scheduledCount -= len(evs)
+	t.metrics.SetTransmissionQueueDepth(t.scheduledCount)
+
+	seqNr := evs[0].SeqNr
+	if !t.shouldStillRetry(seqNr, sb.retry) {
+		return
+	}
+	if t.alreadyReported(seqNr) {
+		for _, ev := range evs {
+			t.telemetrySender.TransmissionDecided(t.config.ConfigDigest, seqNr, ev.Index, TransmissionDecisionNotTransmitted)
+		}
+		return
+	}
+	batchCtx, span := t.tracing.start(t.ctx, "Transmission", seqNr)
+	span.SetAttributes(attribute.Int("ocr3.report_count", len(evs)))
+	spanErrorDescription := ""
+	defer func() { endSpan(span, spanErrorDescription) }()
+
+	reports := make([]ocr3types.BatchedAttestedReport[RI], 0, len(evs))
+	for _, ev := range t.shouldTransmitAll(batchCtx, seqNr, evs) {
+		reports = append(reports, ocr3types.BatchedAttestedReport[RI]{
+			ev.Index,
+			ev.AttestedReport.ReportWithInfo,
+			ev.AttestedReport.AttributedSignatures,
+			ev.ReportBatch,
+			ev.AttestedReport.AggregateSignature,
+		})
+	}
+	if len(reports) == 0 {
+		t.logger.Info("no report of batch is to be transmitted", commontypes.LogFields{
+			"seqNr": seqNr,
+		})
+		return
+	}
+
+	t.logger.Debug("transmitting batch", commontypes.LogFields{
+		"seqNr":          seqNr,
+		"reports":        len(reports),
+		"failedAttempts": sb.retry.failedAttempts,
+	})
+
+	{
+		ctx, transmitSpan := t.tracing.start(batchCtx, "ContractTransmitter.TransmitBatch", seqNr)
+		ctx, cancel := context.WithTimeout(
+			ctx,
+			t.localConfig.ContractTransmitterTransmitTimeout,
+		)
+		defer cancel()
+
+		ins := loghelper.NewIfNotStopped(
+			t.localConfig.ContractTransmitterTransmitTimeout+ContractTransmitterTimeoutWarningGracePeriod,
+			func() {
+				t.logger.Error("ContractTransmitter.TransmitBatch is taking too long", commontypes.LogFields{
+					"maxDuration": t.localConfig.ContractTransmitterTransmitTimeout,
+					"seqNr":       seqNr,
+					"reports":     len(reports),
+				})
+			},
+		)
+
+		err := t.batchContractTransmitter.TransmitBatch(
+			ctx,
+			t.config.ConfigDigest,
+			seqNr,
+			reports,
+		)
+
+		ins.Stop()
+
+		if err != nil {
+			endSpan(transmitSpan, err.Error())
+			spanErrorDescription = "ContractTransmitter.TransmitBatch failed"
+			t.logger.Error("ContractTransmitter.TransmitBatch error", commontypes.LogFields{"error": err})
+			t.telemetrySender.ProtocolError(t.config.ConfigDigest, 0, seqNr, ProtocolErrorCodeTransmitFailure)
+			if delay, ok := t.retryDelay(seqNr, &sb.retry, err); ok {
+				t.batchScheduler.ScheduleDelay(sb, delay)
+				t.scheduledCount += len(evs)
+				t.metrics.SetTransmissionQueueDepth(t.scheduledCount)
+			}
+			return
+		}
+		endSpan(transmitSpan, "")
+	}
+
+	t.logger.Info("🚀 successfully invoked ContractTransmitter.TransmitBatch", commontypes.LogFields{
+		"seqNr":   seqNr,
+		"reports": len(reports),
+	})
+	for _, report := range reports {
+		t.telemetrySender.TransmissionDecided(t.config.ConfigDigest, seqNr, report.Index, TransmissionDecisionTransmitted)
+	}
+}
+
+func (t *transmissionState[RI]) retryStatus(seqNr uint64, r retryState) transmissionretry.Status {
+	highestAttestedSeqNr := seqNr
+	if len(t.latestAttestedReports) != 0 && t.latestAttestedReports[0].SeqNr > seqNr {
+		highestAttestedSeqNr = t.latestAttestedReports[0].SeqNr
+	}
+	return transmissionretry.Status{
+		seqNr,
+		r.failedAttempts,
+		time.Since(r.receivedAt),
+		highestAttestedSeqNr,
+		r.lastErr,
+	}
+}
+
+// retryDelay records the failed attempt err in r and returns how long to wait
+// before retrying, or ok == false if the report(s) of seqNr are to be dropped.
+func (t *transmissionState[RI]) retryDelay(seqNr uint64, r *retryState, err error) (delay time.Duration, ok bool) {
+	if t.transmissionRetryPolicy == nil {
+		return 0, false
+	}
+	r.failedAttempts++
+	r.lastErr = err
+	status := t.retryStatus(seqNr, *r)
+	if !t.transmissionRetryPolicy.ShouldRetry(status) {
+		t.logger.Warn("Transmission: giving up on transmission according to TransmissionRetryPolicy", commontypes.LogFields{
+			"seqNr":          seqNr,
+			"failedAttempts": r.failedAttempts,
+			"age":            status.Age.String(),
+		})
+		return 0, false
+	}
+	delay = t.transmissionRetryPolicy.RetryDelay(status)
+	t.logger.Info("Transmission: retrying failed transmission", commontypes.LogFields{
+		"seqNr":          seqNr,
+		"failedAttempts": r.failedAttempts,
+		"delay":          delay.String(),
+	})
+	return delay, true
+}
+
+// shouldStillRetry returns false if the retry with state r of the report(s) of
+// seqNr is to be dropped because the policy changed its mind, e.g. because a
+// report with a higher seqNr has arrived in the meantime. First attempts are
+// always made.
+func (t *transmissionState[RI]) shouldStillRetry(seqNr uint64, r retryState) bool {
+	if r.failedAttempts == 0 {
+		return true
+	}
+	status := t.retryStatus(seqNr, r)
+	if !t.transmissionRetryPolicy.ShouldRetry(status) {
+		t.logger.Info("Transmission: dropping pending retry according to TransmissionRetryPolicy", commontypes.LogFields{
+			"seqNr":                seqNr,
+			"failedAttempts":       r.failedAttempts,
+			"age":                  status.Age.String(),
+			"highestAttestedSeqNr": status.HighestAttestedSeqNr,
+		})
+		return false
+	}
+	return true
+}
+
+// alreadyReported returns true iff latestReportCache holds a confirmation of
+// the reports of seqNr (or a later one) on all destinations, in which case
+// they needn't be transmitted again.
+func (t *transmissionState[RI]) alreadyReported(seqNr uint64) bool {
+	if t.latestReportCache == nil {
+		return false
+	}
+	for _, destination := range t.latestReportCacheDestinations {
+		if !t.latestReportCache.Reported(destination, t.config.ConfigDigest, seqNr) {
+			return false
+		}
+	}
+	t.logger.Debug("Transmission: not transmitting reports that LatestReportCache holds as confirmed", commontypes.LogFields{
+		"seqNr": seqNr,
+	})
+	return true
+}
+
+// shouldTransmit returns ok == false if the call to the plugin failed.
+func (t *transmissionState[RI]) shouldTransmit(attestedReportCtx context.Context, ev EventAttestedReport[RI]) (shouldTransmit bool, ok bool) {
+	shouldTransmit, ok = callPlugin[bool](
+		attestedReportCtx,
+		t.logger,
+		t.metrics,
+		t.tracing,
+		ev.SeqNr,
+		commontypes.LogFields{
+			"seqNr": ev.SeqNr,
+			"index": ev.Index,
+		},
+		"ShouldTransmitAcceptedReport",
+		t.config.MaxDurationShouldTransmitAcceptedReport,
+		func(ctx context.Context) (bool, error) {
+			return t.reportingPlugin.ShouldTransmitAcceptedReport(
+				ctx,
+				ev.SeqNr,
+				ev.AttestedReport.ReportWithInfo,
+			)
+		},
+	)
+	if !ok {
+		return false, false
+	}
+
+	if !shouldTransmit {
+		t.logger.Info("ReportingPlugin.ShouldTransmitAcceptedReport returned false", commontypes.LogFields{
+			"seqNr": ev.SeqNr,
+			"index": ev.Index,
+		})
+		t.telemetrySender.TransmissionDecided(t.config.ConfigDigest, ev.SeqNr, ev.Index, TransmissionDecisionNotTransmitted)
+	}
+	return shouldTransmit, true
+}
+
+// shouldTransmitAll returns the reports among evs (all of seqNr, ordered by
+// index) that the plugin wants transmitted. If a call to the plugin fails, we
+// just leave out the affected reports.
+func (t *transmissionState[RI]) shouldTransmitAll(batchCtx context.Context, seqNr uint64, evs []EventAttestedReport[RI]) []EventAttestedReport[RI] {
+	var transmitted []EventAttestedReport[RI]
+	if t.reportDecisionBatcher == nil {
+		for _, ev := range evs {
+			if shouldTransmit, ok := t.shouldTransmit(t.attestedReportContext(batchCtx, ev), ev); ok && shouldTransmit {
+				transmitted = append(transmitted, ev)
+			}
+		}
+		return transmitted
+	}
+
+	decisions, ok := callPlugin[[]bool](
+		batchCtx,
+		t.logger,
+		t.metrics,
+		t.tracing,
+		seqNr,
+		commontypes.LogFields{
+			"seqNr":   seqNr,
+			"reports": len(evs),
+		},
+		"ShouldTransmitAcceptedReports",
+		t.config.MaxDurationShouldTransmitAcceptedReport,
+		func(ctx context.Context) ([]bool, error) {
+			return t.reportDecisionBatcher.ShouldTransmitAcceptedReports(
+				ctx,
+				seqNr,
+				reportsWithInfo(evs),
+			)
+		},
+	)
+	if !ok || !t.checkDecisions("ShouldTransmitAcceptedReports", seqNr, evs, decisions) {
+		return nil
+	}
+
+	for i, ev := range evs {
+		if !decisions[i] {
+			t.logger.Info("ReportingPlugin.ShouldTransmitAcceptedReports returned false", commontypes.LogFields{
+				"seqNr": ev.SeqNr,
+				"index": ev.Index,
+			})
+			t.telemetrySender.TransmissionDecided(t.config.ConfigDigest, ev.SeqNr, ev.Index, TransmissionDecisionNotTransmitted)
+			continue
+		}
+		transmitted = append(transmitted, ev)
+	}
+	return transmitted
+}
+
+// attestedReportContext returns a child of parent that carries the attested
+// report of ev, see ocr3types.ContextWithAttestedReport, its index, see
+// ocr3types.ContextWithReportIndex, and its batch if it has one, see
+// ocr3types.ContextWithReportBatch.
+func (t *transmissionState[RI]) attestedReportContext(parent context.Context, ev EventAttestedReport[RI]) context.Context {
+	ctx := ocr3types.ContextWithAttestedReport(parent, ocr3types.AttestedReport[RI]{
+		t.config.ConfigDigest,
+		ev.SeqNr,
+		ev.AttestedReport.ReportWithInfo,
+		ev.AttestedReport.AttributedSignatures,
+		ev.AttestedReport.AggregateSignature,
+	})
+	ctx = ocr3types.ContextWithReportIndex(ctx, ev.Index)
+	if ev.ReportBatch != nil {
+		ctx = ocr3types.ContextWithReportBatch(ctx, *ev.ReportBatch)
+	}
+	return ctx
+}
+
+// transmissionHint returns the hint carried by the Info of ev's report, see
+// ocr3types.TransmissionHinter.
+func (t *transmissionState[RI]) transmissionHint(ev EventAttestedReport[RI]) ocr3types.TransmissionHint {
+	hinter, ok := any(ev.AttestedReport.ReportWithInfo.Info).(ocr3types.TransmissionHinter)
+	if !ok {
+		return ocr3types.TransmissionHint{}
+	}
+	hint := hinter.TransmissionHint()
+	if hint.Delay < 0 || hint.DeltaStage < 0 {
+		t.logger.Warn("ignoring TransmissionHint with negative fields", commontypes.LogFields{
+			"seqNr":      ev.SeqNr,
+			"index":      ev.Index,
+			"delay":      hint.Delay.String(),
+			"deltaStage": hint.DeltaStage.String(),
+		})
+		return ocr3types.TransmissionHint{}
+	}
+	return hint
 }
 
-func (t *transmissionState[RI]) transmitDelay(seqNr uint64, index int) *time.Duration {
+// transmitDelay returns how long we should wait before transmitting the report
+// at index of the Reports returned for seqNr, adjusted by hint, or nil if we're
+// not part of the transmission schedule for it. The transmitter order is
+// permuted independently for every (seqNr, index), so that the reports of a
+// single seqNr are spread across the DON instead of all being transmitted by
+// the same oracle.
+func (t *transmissionState[RI]) transmitDelay(seqNr uint64, index int, hint ocr3types.TransmissionHint) *time.Duration {
 	transmissionOrderKey := t.config.TransmissionOrderKey()
 	mac := hmac.New(sha256.New, transmissionOrderKey[:])
 	_ = binary.Write(mac, binary.BigEndian, seqNr)
@@ -231,7 +881,13 @@ func (t *transmissionState[RI]) transmitDelay(seqNr uint64, index int) *time.Dur
 	for i, s := range t.config.S {
 		sum += s
 		if pi[t.id] < sum {
-			result := time.Duration(i) * t.config.DeltaStage
+			// Hints may only stretch the schedule. Otherwise, a plugin could
+			// make all stages transmit at once.
+			deltaStage := t.config.DeltaStage
+			if hint.DeltaStage > deltaStage {
+				deltaStage = hint.DeltaStage
+			}
+			result := hint.Delay + time.Duration(i)*deltaStage
 			return &result
 		}
 	}
@@ -0,0 +1,87 @@
+package serialization
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The protobuf field numbers under which the messages of the blob exchange
+// protocol are stored in an otherwise empty MessageWrapper. Like chunks, we
+// encode the fields by hand as unknown fields. Receivers running older
+// library versions fail to deserialize these messages and drop them. Be sure
+// to reserve these numbers in the .proto file when regenerating it.
+const (
+	blobFieldNumber          protowire.Number = 102
+	blobAvailableFieldNumber protowire.Number = 103
+	blobRequestFieldNumber   protowire.Number = 104
+	blobResponseFieldNumber  protowire.Number = 105
+)
+
+// appendBlobMessage appends m to unknown if m is a message of the blob
+// exchange protocol, and returns unknown unchanged otherwise.
+func appendBlobMessage[RI any](unknown []byte, m protocol.Message[RI]) []byte {
+	var num protowire.Number
+	var value []byte
+	switch v := m.(type) {
+	case protocol.MessageBlob[RI]:
+		num, value = blobFieldNumber, v.Payload
+	case protocol.MessageBlobAvailable[RI]:
+		num, value = blobAvailableFieldNumber, v.Digest[:]
+	case protocol.MessageBlobRequest[RI]:
+		num, value = blobRequestFieldNumber, v.Digest[:]
+	case protocol.MessageBlobResponse[RI]:
+		num, value = blobResponseFieldNumber, v.Payload
+	default:
+		return unknown
+	}
+	unknown = protowire.AppendTag(unknown, num, protowire.BytesType)
+	return protowire.AppendBytes(unknown, value)
+}
+
+// consumeBlobMessage returns ok=false if unknown doesn't contain a message
+// of the blob exchange protocol.
+func consumeBlobMessage[RI any](unknown []byte) (m protocol.Message[RI], ok bool, err error) {
+	for len(unknown) > 0 {
+		num, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return nil, false, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+		if blobFieldNumber <= num && num <= blobResponseFieldNumber && typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(unknown)
+			if n < 0 {
+				return nil, true, fmt.Errorf("could not parse blob message: %w", protowire.ParseError(n))
+			}
+			m, err := blobMessage[RI](num, v)
+			return m, true, err
+		}
+		n = protowire.ConsumeFieldValue(num, typ, unknown)
+		if n < 0 {
+			return nil, false, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+	}
+	return nil, false, nil
+}
+
+func blobMessage[RI any](num protowire.Number, value []byte) (protocol.Message[RI], error) {
+	switch num {
+	case blobFieldNumber:
+		return protocol.MessageBlob[RI]{value}, nil
+	case blobResponseFieldNumber:
+		return protocol.MessageBlobResponse[RI]{value}, nil
+	}
+
+	var digest ocr3types.BlobDigest
+	if len(value) != len(digest) {
+		return nil, fmt.Errorf("blob digest has length %v, should be %v", len(value), len(digest))
+	}
+	copy(digest[:], value)
+	if num == blobAvailableFieldNumber {
+		return protocol.MessageBlobAvailable[RI]{digest}, nil
+	}
+	return protocol.MessageBlobRequest[RI]{digest}, nil
+}
@@ -0,0 +1,113 @@
+package serialization
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// chunkFieldNumber is the protobuf field number under which a Chunk is stored
+// in an otherwise empty MessageWrapper. Like the sent time, we encode the
+// field by hand as an unknown field. Receivers running older library versions
+// fail to deserialize chunks since the MessageWrapper carries no message, and
+// drop them. Be sure to reserve this number in the .proto file when
+// regenerating it.
+const chunkFieldNumber protowire.Number = 101
+
+const (
+	chunkMessageIDFieldNumber protowire.Number = 1
+	chunkIndexFieldNumber     protowire.Number = 2
+	chunkCountFieldNumber     protowire.Number = 3
+	chunkDataFieldNumber      protowire.Number = 4
+)
+
+// Chunk is a piece of a serialized message that is too long to be sent at
+// once. A message is split into Count chunks with indices 0 through Count-1,
+// all of which carry the same MessageID chosen by the sender.
+type Chunk struct {
+	MessageID uint64
+	Index     uint32
+	Count     uint32
+	Data      []byte
+}
+
+// ChunkOverhead is an upper bound on the number of bytes SerializeChunk adds
+// to a chunk's data.
+const ChunkOverhead = 64
+
+func SerializeChunk(c Chunk) []byte {
+	var inner []byte
+	inner = protowire.AppendTag(inner, chunkMessageIDFieldNumber, protowire.VarintType)
+	inner = protowire.AppendVarint(inner, c.MessageID)
+	inner = protowire.AppendTag(inner, chunkIndexFieldNumber, protowire.VarintType)
+	inner = protowire.AppendVarint(inner, uint64(c.Index))
+	inner = protowire.AppendTag(inner, chunkCountFieldNumber, protowire.VarintType)
+	inner = protowire.AppendVarint(inner, uint64(c.Count))
+	inner = protowire.AppendTag(inner, chunkDataFieldNumber, protowire.BytesType)
+	inner = protowire.AppendBytes(inner, c.Data)
+
+	b := protowire.AppendTag(nil, chunkFieldNumber, protowire.BytesType)
+	return protowire.AppendBytes(b, inner)
+}
+
+// DeserializeChunk returns ok=false if b doesn't hold a chunk, in which case
+// b should be passed to Deserialize. The returned chunk's Data aliases b.
+func DeserializeChunk(b []byte) (chunk Chunk, ok bool, err error) {
+	num, typ, n := protowire.ConsumeTag(b)
+	if n < 0 || num != chunkFieldNumber {
+		return Chunk{}, false, nil
+	}
+	if typ != protowire.BytesType {
+		return Chunk{}, true, fmt.Errorf("chunk has wrong wire type %v", typ)
+	}
+	inner, n2 := protowire.ConsumeBytes(b[n:])
+	if n2 < 0 {
+		return Chunk{}, true, fmt.Errorf("could not parse chunk: %w", protowire.ParseError(n2))
+	}
+	if n+n2 != len(b) {
+		return Chunk{}, true, fmt.Errorf("chunk has trailing data")
+	}
+
+	for len(inner) > 0 {
+		num, typ, n := protowire.ConsumeTag(inner)
+		if n < 0 {
+			return Chunk{}, true, fmt.Errorf("could not parse chunk: %w", protowire.ParseError(n))
+		}
+		inner = inner[n:]
+		switch {
+		case num == chunkDataFieldNumber && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(inner)
+			if n < 0 {
+				return Chunk{}, true, fmt.Errorf("could not parse chunk data: %w", protowire.ParseError(n))
+			}
+			chunk.Data = v
+			inner = inner[n:]
+		case typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(inner)
+			if n < 0 {
+				return Chunk{}, true, fmt.Errorf("could not parse chunk: %w", protowire.ParseError(n))
+			}
+			switch num {
+			case chunkMessageIDFieldNumber:
+				chunk.MessageID = v
+			case chunkIndexFieldNumber, chunkCountFieldNumber:
+				if v > uint64(^uint32(0)) {
+					return Chunk{}, true, fmt.Errorf("chunk field %v out of range", num)
+				}
+				if num == chunkIndexFieldNumber {
+					chunk.Index = uint32(v)
+				} else {
+					chunk.Count = uint32(v)
+				}
+			}
+			inner = inner[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, inner)
+			if n < 0 {
+				return Chunk{}, true, fmt.Errorf("could not parse chunk: %w", protowire.ParseError(n))
+			}
+			inner = inner[n:]
+		}
+	}
+	return chunk, true, nil
+}
@@ -20,6 +20,113 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ProtocolErrorCode int32
+
+const (
+	ProtocolErrorCode_PROTOCOL_ERROR_CODE_UNSPECIFIED               ProtocolErrorCode = 0
+	ProtocolErrorCode_PROTOCOL_ERROR_CODE_PROGRESS_TIMEOUT          ProtocolErrorCode = 1
+	ProtocolErrorCode_PROTOCOL_ERROR_CODE_INSUFFICIENT_OBSERVATIONS ProtocolErrorCode = 2
+	ProtocolErrorCode_PROTOCOL_ERROR_CODE_ATTESTATION_SHORTFALL     ProtocolErrorCode = 3
+	ProtocolErrorCode_PROTOCOL_ERROR_CODE_TRANSMIT_FAILURE          ProtocolErrorCode = 4
+)
+
+// Enum value maps for ProtocolErrorCode.
+var (
+	ProtocolErrorCode_name = map[int32]string{
+		0: "PROTOCOL_ERROR_CODE_UNSPECIFIED",
+		1: "PROTOCOL_ERROR_CODE_PROGRESS_TIMEOUT",
+		2: "PROTOCOL_ERROR_CODE_INSUFFICIENT_OBSERVATIONS",
+		3: "PROTOCOL_ERROR_CODE_ATTESTATION_SHORTFALL",
+		4: "PROTOCOL_ERROR_CODE_TRANSMIT_FAILURE",
+	}
+	ProtocolErrorCode_value = map[string]int32{
+		"PROTOCOL_ERROR_CODE_UNSPECIFIED":               0,
+		"PROTOCOL_ERROR_CODE_PROGRESS_TIMEOUT":          1,
+		"PROTOCOL_ERROR_CODE_INSUFFICIENT_OBSERVATIONS": 2,
+		"PROTOCOL_ERROR_CODE_ATTESTATION_SHORTFALL":     3,
+		"PROTOCOL_ERROR_CODE_TRANSMIT_FAILURE":          4,
+	}
+)
+
+func (x ProtocolErrorCode) Enum() *ProtocolErrorCode {
+	p := new(ProtocolErrorCode)
+	*p = x
+	return p
+}
+
+func (x ProtocolErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProtocolErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_offchainreporting3_telemetry_proto_enumTypes[0].Descriptor()
+}
+
+func (ProtocolErrorCode) Type() protoreflect.EnumType {
+	return &file_offchainreporting3_telemetry_proto_enumTypes[0]
+}
+
+func (x ProtocolErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProtocolErrorCode.Descriptor instead.
+func (ProtocolErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{0}
+}
+
+type TransmissionDecision int32
+
+const (
+	TransmissionDecision_TRANSMISSION_DECISION_UNSPECIFIED     TransmissionDecision = 0
+	TransmissionDecision_TRANSMISSION_DECISION_NOT_ACCEPTED    TransmissionDecision = 1
+	TransmissionDecision_TRANSMISSION_DECISION_NOT_TRANSMITTED TransmissionDecision = 2
+	TransmissionDecision_TRANSMISSION_DECISION_TRANSMITTED     TransmissionDecision = 3
+)
+
+// Enum value maps for TransmissionDecision.
+var (
+	TransmissionDecision_name = map[int32]string{
+		0: "TRANSMISSION_DECISION_UNSPECIFIED",
+		1: "TRANSMISSION_DECISION_NOT_ACCEPTED",
+		2: "TRANSMISSION_DECISION_NOT_TRANSMITTED",
+		3: "TRANSMISSION_DECISION_TRANSMITTED",
+	}
+	TransmissionDecision_value = map[string]int32{
+		"TRANSMISSION_DECISION_UNSPECIFIED":     0,
+		"TRANSMISSION_DECISION_NOT_ACCEPTED":    1,
+		"TRANSMISSION_DECISION_NOT_TRANSMITTED": 2,
+		"TRANSMISSION_DECISION_TRANSMITTED":     3,
+	}
+)
+
+func (x TransmissionDecision) Enum() *TransmissionDecision {
+	p := new(TransmissionDecision)
+	*p = x
+	return p
+}
+
+func (x TransmissionDecision) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransmissionDecision) Descriptor() protoreflect.EnumDescriptor {
+	return file_offchainreporting3_telemetry_proto_enumTypes[1].Descriptor()
+}
+
+func (TransmissionDecision) Type() protoreflect.EnumType {
+	return &file_offchainreporting3_telemetry_proto_enumTypes[1]
+}
+
+func (x TransmissionDecision) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransmissionDecision.Descriptor instead.
+func (TransmissionDecision) EnumDescriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{1}
+}
+
 type TelemetryWrapper struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -32,6 +139,14 @@ type TelemetryWrapper struct {
 	//	*TelemetryWrapper_MessageSent
 	//	*TelemetryWrapper_AssertionViolation
 	//	*TelemetryWrapper_RoundStarted
+	//	*TelemetryWrapper_ProtocolError
+	//	*TelemetryWrapper_Heartbeat
+	//	*TelemetryWrapper_OutcomeComputed
+	//	*TelemetryWrapper_OutcomeCommitted
+	//	*TelemetryWrapper_ReportAttested
+	//	*TelemetryWrapper_TransmissionDecision
+	//	*TelemetryWrapper_DegradedModeChanged
+	//	*TelemetryWrapper_CorruptedStateQuarantined
 	Wrapped             isTelemetryWrapper_Wrapped `protobuf_oneof:"wrapped"`
 	UnixTimeNanoseconds int64                      `protobuf:"varint,6,opt,name=unix_time_nanoseconds,json=unixTimeNanoseconds,proto3" json:"unix_time_nanoseconds,omitempty"`
 }
@@ -110,6 +225,62 @@ func (x *TelemetryWrapper) GetRoundStarted() *TelemetryRoundStarted {
 	return nil
 }
 
+func (x *TelemetryWrapper) GetProtocolError() *TelemetryProtocolError {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_ProtocolError); ok {
+		return x.ProtocolError
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetHeartbeat() *TelemetryHeartbeat {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_Heartbeat); ok {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetOutcomeComputed() *TelemetryOutcomeComputed {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_OutcomeComputed); ok {
+		return x.OutcomeComputed
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetOutcomeCommitted() *TelemetryOutcomeCommitted {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_OutcomeCommitted); ok {
+		return x.OutcomeCommitted
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetReportAttested() *TelemetryReportAttested {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_ReportAttested); ok {
+		return x.ReportAttested
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetTransmissionDecision() *TelemetryTransmissionDecision {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_TransmissionDecision); ok {
+		return x.TransmissionDecision
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetDegradedModeChanged() *TelemetryDegradedModeChanged {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_DegradedModeChanged); ok {
+		return x.DegradedModeChanged
+	}
+	return nil
+}
+
+func (x *TelemetryWrapper) GetCorruptedStateQuarantined() *TelemetryCorruptedStateQuarantined {
+	if x, ok := x.GetWrapped().(*TelemetryWrapper_CorruptedStateQuarantined); ok {
+		return x.CorruptedStateQuarantined
+	}
+	return nil
+}
+
 func (x *TelemetryWrapper) GetUnixTimeNanoseconds() int64 {
 	if x != nil {
 		return x.UnixTimeNanoseconds
@@ -141,6 +312,38 @@ type TelemetryWrapper_RoundStarted struct {
 	RoundStarted *TelemetryRoundStarted `protobuf:"bytes,5,opt,name=round_started,json=roundStarted,proto3,oneof"`
 }
 
+type TelemetryWrapper_ProtocolError struct {
+	ProtocolError *TelemetryProtocolError `protobuf:"bytes,7,opt,name=protocol_error,json=protocolError,proto3,oneof"`
+}
+
+type TelemetryWrapper_Heartbeat struct {
+	Heartbeat *TelemetryHeartbeat `protobuf:"bytes,8,opt,name=heartbeat,proto3,oneof"`
+}
+
+type TelemetryWrapper_OutcomeComputed struct {
+	OutcomeComputed *TelemetryOutcomeComputed `protobuf:"bytes,9,opt,name=outcome_computed,json=outcomeComputed,proto3,oneof"`
+}
+
+type TelemetryWrapper_OutcomeCommitted struct {
+	OutcomeCommitted *TelemetryOutcomeCommitted `protobuf:"bytes,10,opt,name=outcome_committed,json=outcomeCommitted,proto3,oneof"`
+}
+
+type TelemetryWrapper_ReportAttested struct {
+	ReportAttested *TelemetryReportAttested `protobuf:"bytes,11,opt,name=report_attested,json=reportAttested,proto3,oneof"`
+}
+
+type TelemetryWrapper_TransmissionDecision struct {
+	TransmissionDecision *TelemetryTransmissionDecision `protobuf:"bytes,12,opt,name=transmission_decision,json=transmissionDecision,proto3,oneof"`
+}
+
+type TelemetryWrapper_DegradedModeChanged struct {
+	DegradedModeChanged *TelemetryDegradedModeChanged `protobuf:"bytes,13,opt,name=degraded_mode_changed,json=degradedModeChanged,proto3,oneof"`
+}
+
+type TelemetryWrapper_CorruptedStateQuarantined struct {
+	CorruptedStateQuarantined *TelemetryCorruptedStateQuarantined `protobuf:"bytes,14,opt,name=corrupted_state_quarantined,json=corruptedStateQuarantined,proto3,oneof"`
+}
+
 func (*TelemetryWrapper_MessageReceived) isTelemetryWrapper_Wrapped() {}
 
 func (*TelemetryWrapper_MessageBroadcast) isTelemetryWrapper_Wrapped() {}
@@ -151,6 +354,22 @@ func (*TelemetryWrapper_AssertionViolation) isTelemetryWrapper_Wrapped() {}
 
 func (*TelemetryWrapper_RoundStarted) isTelemetryWrapper_Wrapped() {}
 
+func (*TelemetryWrapper_ProtocolError) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_Heartbeat) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_OutcomeComputed) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_OutcomeCommitted) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_ReportAttested) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_TransmissionDecision) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_DegradedModeChanged) isTelemetryWrapper_Wrapped() {}
+
+func (*TelemetryWrapper_CorruptedStateQuarantined) isTelemetryWrapper_Wrapped() {}
+
 type TelemetryMessageReceived struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -565,202 +784,1119 @@ func (x *TelemetryRoundStarted) GetSeqNr() uint64 {
 	return 0
 }
 
-var File_offchainreporting3_telemetry_proto protoreflect.FileDescriptor
+type TelemetryProtocolError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_offchainreporting3_telemetry_proto_rawDesc = []byte{
-	0x0a, 0x22, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x69, 0x6e, 0x67, 0x33, 0x5f, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x1a, 0x21, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
-	0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x5f, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8f, 0x04, 0x0a, 0x10,
-	0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
-	0x12, 0x59, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x65,
-	0x69, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6f, 0x66, 0x66,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e,
-	0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x5c, 0x0a, 0x11, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65,
-	0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x72, 0x6f, 0x61,
-	0x64, 0x63, 0x61, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x0c, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x28, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x62, 0x0a, 0x13, 0x61, 0x73, 0x73, 0x65,
-	0x72, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d,
-	0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f,
-	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x12, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74,
-	0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x50, 0x0a, 0x0d,
-	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74,
-	0x72, 0x79, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x48, 0x00,
-	0x52, 0x0c, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x32,
-	0x0a, 0x15, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6e, 0x6f,
-	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x75,
-	0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e,
-	0x64, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x22, 0x8d, 0x01,
-	0x0a, 0x18, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12,
-	0x34, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f,
-	0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67,
-	0x33, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
-	0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x9d, 0x01,
-	0x0a, 0x19, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
-	0x12, 0x34, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
-	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
-	0x67, 0x33, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65,
-	0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
-	0x69, 0x7a, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d,
-	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x22, 0xb4, 0x01,
-	0x0a, 0x14, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x03, 0x6d,
-	0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
-	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73,
-	0x67, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f,
-	0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61,
-	0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65,
-	0x69, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65,
-	0x69, 0x76, 0x65, 0x72, 0x22, 0xac, 0x01, 0x0a, 0x1b, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74,
-	0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x7a, 0x0a, 0x15, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f,
-	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74,
-	0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69, 0x61,
-	0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x14, 0x69, 0x6e, 0x76, 0x61,
-	0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x42, 0x0b, 0x0a, 0x09, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x04, 0x08,
-	0x01, 0x10, 0x02, 0x22, 0x95, 0x01, 0x0a, 0x2f, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72,
-	0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e,
-	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64,
-	0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0xab, 0x01, 0x0a, 0x15,
-	0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x74,
-	0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f,
-	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70,
-	0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
-	0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12,
-	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69,
-	0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x72, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x72, 0x42, 0x11, 0x5a, 0x0f, 0x2e, 0x3b, 0x73,
-	0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	ConfigDigest []byte            `protobuf:"bytes,1,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+	Epoch        uint64            `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	SeqNr        uint64            `protobuf:"varint,3,opt,name=seq_nr,json=seqNr,proto3" json:"seq_nr,omitempty"`
+	Code         ProtocolErrorCode `protobuf:"varint,4,opt,name=code,proto3,enum=offchainreporting3.ProtocolErrorCode" json:"code,omitempty"`
 }
 
-var (
-	file_offchainreporting3_telemetry_proto_rawDescOnce sync.Once
-	file_offchainreporting3_telemetry_proto_rawDescData = file_offchainreporting3_telemetry_proto_rawDesc
-)
+func (x *TelemetryProtocolError) Reset() {
+	*x = TelemetryProtocolError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting3_telemetry_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_offchainreporting3_telemetry_proto_rawDescGZIP() []byte {
-	file_offchainreporting3_telemetry_proto_rawDescOnce.Do(func() {
-		file_offchainreporting3_telemetry_proto_rawDescData = protoimpl.X.CompressGZIP(file_offchainreporting3_telemetry_proto_rawDescData)
-	})
-	return file_offchainreporting3_telemetry_proto_rawDescData
+func (x *TelemetryProtocolError) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_offchainreporting3_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_offchainreporting3_telemetry_proto_goTypes = []interface{}{
-	(*TelemetryWrapper)(nil),                                // 0: offchainreporting3.TelemetryWrapper
-	(*TelemetryMessageReceived)(nil),                        // 1: offchainreporting3.TelemetryMessageReceived
-	(*TelemetryMessageBroadcast)(nil),                       // 2: offchainreporting3.TelemetryMessageBroadcast
-	(*TelemetryMessageSent)(nil),                            // 3: offchainreporting3.TelemetryMessageSent
-	(*TelemetryAssertionViolation)(nil),                     // 4: offchainreporting3.TelemetryAssertionViolation
-	(*TelemetryAssertionViolationInvalidSerialization)(nil), // 5: offchainreporting3.TelemetryAssertionViolationInvalidSerialization
-	(*TelemetryRoundStarted)(nil),                           // 6: offchainreporting3.TelemetryRoundStarted
-	(*MessageWrapper)(nil),                                  // 7: offchainreporting3.MessageWrapper
+func (*TelemetryProtocolError) ProtoMessage() {}
+
+func (x *TelemetryProtocolError) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting3_telemetry_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_offchainreporting3_telemetry_proto_depIdxs = []int32{
-	1, // 0: offchainreporting3.TelemetryWrapper.message_received:type_name -> offchainreporting3.TelemetryMessageReceived
-	2, // 1: offchainreporting3.TelemetryWrapper.message_broadcast:type_name -> offchainreporting3.TelemetryMessageBroadcast
-	3, // 2: offchainreporting3.TelemetryWrapper.message_sent:type_name -> offchainreporting3.TelemetryMessageSent
-	4, // 3: offchainreporting3.TelemetryWrapper.assertion_violation:type_name -> offchainreporting3.TelemetryAssertionViolation
-	6, // 4: offchainreporting3.TelemetryWrapper.round_started:type_name -> offchainreporting3.TelemetryRoundStarted
-	7, // 5: offchainreporting3.TelemetryMessageReceived.msg:type_name -> offchainreporting3.MessageWrapper
-	7, // 6: offchainreporting3.TelemetryMessageBroadcast.msg:type_name -> offchainreporting3.MessageWrapper
-	7, // 7: offchainreporting3.TelemetryMessageSent.msg:type_name -> offchainreporting3.MessageWrapper
-	5, // 8: offchainreporting3.TelemetryAssertionViolation.invalid_serialization:type_name -> offchainreporting3.TelemetryAssertionViolationInvalidSerialization
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+
+// Deprecated: Use TelemetryProtocolError.ProtoReflect.Descriptor instead.
+func (*TelemetryProtocolError) Descriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{7}
 }
 
-func init() { file_offchainreporting3_telemetry_proto_init() }
-func file_offchainreporting3_telemetry_proto_init() {
-	if File_offchainreporting3_telemetry_proto != nil {
-		return
+func (x *TelemetryProtocolError) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
 	}
-	file_offchainreporting3_messages_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_offchainreporting3_telemetry_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryWrapper); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_offchainreporting3_telemetry_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryMessageReceived); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_offchainreporting3_telemetry_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryMessageBroadcast); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+func (x *TelemetryProtocolError) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryProtocolError) GetSeqNr() uint64 {
+	if x != nil {
+		return x.SeqNr
+	}
+	return 0
+}
+
+func (x *TelemetryProtocolError) GetCode() ProtocolErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ProtocolErrorCode_PROTOCOL_ERROR_CODE_UNSPECIFIED
+}
+
+type TelemetryHeartbeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Payload   []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *TelemetryHeartbeat) Reset() {
+	*x = TelemetryHeartbeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting3_telemetry_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryHeartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryHeartbeat) ProtoMessage() {}
+
+func (x *TelemetryHeartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting3_telemetry_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_offchainreporting3_telemetry_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TelemetryMessageSent); i {
-			case 0:
-				return &v.state
-			case 1:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryHeartbeat.ProtoReflect.Descriptor instead.
+func (*TelemetryHeartbeat) Descriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TelemetryHeartbeat) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *TelemetryHeartbeat) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type TelemetryOutcomeComputed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest      []byte   `protobuf:"bytes,1,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+	Epoch             uint64   `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round             uint64   `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	SeqNr             uint64   `protobuf:"varint,4,opt,name=seq_nr,json=seqNr,proto3" json:"seq_nr,omitempty"`
+	Leader            uint32   `protobuf:"varint,5,opt,name=leader,proto3" json:"leader,omitempty"`
+	IncludedObservers []uint32 `protobuf:"varint,6,rep,packed,name=included_observers,json=includedObservers,proto3" json:"included_observers,omitempty"`
+	ExcludedObservers []uint32 `protobuf:"varint,7,rep,packed,name=excluded_observers,json=excludedObservers,proto3" json:"excluded_observers,omitempty"`
+	OutcomeDigest     []byte   `protobuf:"bytes,8,opt,name=outcome_digest,json=outcomeDigest,proto3" json:"outcome_digest,omitempty"`
+}
+
+func (x *TelemetryOutcomeComputed) Reset() {
+	*x = TelemetryOutcomeComputed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting3_telemetry_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryOutcomeComputed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryOutcomeComputed) ProtoMessage() {}
+
+func (x *TelemetryOutcomeComputed) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting3_telemetry_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryOutcomeComputed.ProtoReflect.Descriptor instead.
+func (*TelemetryOutcomeComputed) Descriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TelemetryOutcomeComputed) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryOutcomeComputed) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryOutcomeComputed) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TelemetryOutcomeComputed) GetSeqNr() uint64 {
+	if x != nil {
+		return x.SeqNr
+	}
+	return 0
+}
+
+func (x *TelemetryOutcomeComputed) GetLeader() uint32 {
+	if x != nil {
+		return x.Leader
+	}
+	return 0
+}
+
+func (x *TelemetryOutcomeComputed) GetIncludedObservers() []uint32 {
+	if x != nil {
+		return x.IncludedObservers
+	}
+	return nil
+}
+
+func (x *TelemetryOutcomeComputed) GetExcludedObservers() []uint32 {
+	if x != nil {
+		return x.ExcludedObservers
+	}
+	return nil
+}
+
+func (x *TelemetryOutcomeComputed) GetOutcomeDigest() []byte {
+	if x != nil {
+		return x.OutcomeDigest
+	}
+	return nil
+}
+
+type TelemetryOutcomeCommitted struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest  []byte `protobuf:"bytes,1,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+	Epoch         uint64 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	SeqNr         uint64 `protobuf:"varint,3,opt,name=seq_nr,json=seqNr,proto3" json:"seq_nr,omitempty"`
+	OutcomeDigest []byte `protobuf:"bytes,4,opt,name=outcome_digest,json=outcomeDigest,proto3" json:"outcome_digest,omitempty"`
+}
+
+func (x *TelemetryOutcomeCommitted) Reset() {
+	*x = TelemetryOutcomeCommitted{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting3_telemetry_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryOutcomeCommitted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryOutcomeCommitted) ProtoMessage() {}
+
+func (x *TelemetryOutcomeCommitted) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting3_telemetry_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryOutcomeCommitted.ProtoReflect.Descriptor instead.
+func (*TelemetryOutcomeCommitted) Descriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TelemetryOutcomeCommitted) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryOutcomeCommitted) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TelemetryOutcomeCommitted) GetSeqNr() uint64 {
+	if x != nil {
+		return x.SeqNr
+	}
+	return 0
+}
+
+func (x *TelemetryOutcomeCommitted) GetOutcomeDigest() []byte {
+	if x != nil {
+		return x.OutcomeDigest
+	}
+	return nil
+}
+
+type TelemetryReportAttested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte   `protobuf:"bytes,1,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+	SeqNr        uint64   `protobuf:"varint,2,opt,name=seq_nr,json=seqNr,proto3" json:"seq_nr,omitempty"`
+	ReportIndex  uint64   `protobuf:"varint,3,opt,name=report_index,json=reportIndex,proto3" json:"report_index,omitempty"`
+	Signers      []uint32 `protobuf:"varint,4,rep,packed,name=signers,proto3" json:"signers,omitempty"`
+}
+
+func (x *TelemetryReportAttested) Reset() {
+	*x = TelemetryReportAttested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting3_telemetry_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryReportAttested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryReportAttested) ProtoMessage() {}
+
+func (x *TelemetryReportAttested) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting3_telemetry_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryReportAttested.ProtoReflect.Descriptor instead.
+func (*TelemetryReportAttested) Descriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TelemetryReportAttested) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryReportAttested) GetSeqNr() uint64 {
+	if x != nil {
+		return x.SeqNr
+	}
+	return 0
+}
+
+func (x *TelemetryReportAttested) GetReportIndex() uint64 {
+	if x != nil {
+		return x.ReportIndex
+	}
+	return 0
+}
+
+func (x *TelemetryReportAttested) GetSigners() []uint32 {
+	if x != nil {
+		return x.Signers
+	}
+	return nil
+}
+
+type TelemetryTransmissionDecision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte               `protobuf:"bytes,1,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+	SeqNr        uint64               `protobuf:"varint,2,opt,name=seq_nr,json=seqNr,proto3" json:"seq_nr,omitempty"`
+	ReportIndex  uint64               `protobuf:"varint,3,opt,name=report_index,json=reportIndex,proto3" json:"report_index,omitempty"`
+	Decision     TransmissionDecision `protobuf:"varint,4,opt,name=decision,proto3,enum=offchainreporting3.TransmissionDecision" json:"decision,omitempty"`
+}
+
+func (x *TelemetryTransmissionDecision) Reset() {
+	*x = TelemetryTransmissionDecision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting3_telemetry_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryTransmissionDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryTransmissionDecision) ProtoMessage() {}
+
+func (x *TelemetryTransmissionDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting3_telemetry_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryTransmissionDecision.ProtoReflect.Descriptor instead.
+func (*TelemetryTransmissionDecision) Descriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TelemetryTransmissionDecision) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryTransmissionDecision) GetSeqNr() uint64 {
+	if x != nil {
+		return x.SeqNr
+	}
+	return 0
+}
+
+func (x *TelemetryTransmissionDecision) GetReportIndex() uint64 {
+	if x != nil {
+		return x.ReportIndex
+	}
+	return 0
+}
+
+func (x *TelemetryTransmissionDecision) GetDecision() TransmissionDecision {
+	if x != nil {
+		return x.Decision
+	}
+	return TransmissionDecision_TRANSMISSION_DECISION_UNSPECIFIED
+}
+
+type HeartbeatPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeVersion         string               `protobuf:"bytes,1,opt,name=node_version,json=nodeVersion,proto3" json:"node_version,omitempty"`
+	OffchainPublicKey   []byte               `protobuf:"bytes,2,opt,name=offchain_public_key,json=offchainPublicKey,proto3" json:"offchain_public_key,omitempty"`
+	UnixTimeNanoseconds int64                `protobuf:"varint,3,opt,name=unix_time_nanoseconds,json=unixTimeNanoseconds,proto3" json:"unix_time_nanoseconds,omitempty"`
+	Instances           []*HeartbeatInstance `protobuf:"bytes,4,rep,name=instances,proto3" json:"instances,omitempty"`
+}
+
+func (x *HeartbeatPayload) Reset() {
+	*x = HeartbeatPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting3_telemetry_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeartbeatPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatPayload) ProtoMessage() {}
+
+func (x *HeartbeatPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting3_telemetry_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatPayload.ProtoReflect.Descriptor instead.
+func (*HeartbeatPayload) Descriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HeartbeatPayload) GetNodeVersion() string {
+	if x != nil {
+		return x.NodeVersion
+	}
+	return ""
+}
+
+func (x *HeartbeatPayload) GetOffchainPublicKey() []byte {
+	if x != nil {
+		return x.OffchainPublicKey
+	}
+	return nil
+}
+
+func (x *HeartbeatPayload) GetUnixTimeNanoseconds() int64 {
+	if x != nil {
+		return x.UnixTimeNanoseconds
+	}
+	return 0
+}
+
+func (x *HeartbeatPayload) GetInstances() []*HeartbeatInstance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type HeartbeatInstance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest                []byte `protobuf:"bytes,1,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+	OracleId                    uint32 `protobuf:"varint,2,opt,name=oracle_id,json=oracleId,proto3" json:"oracle_id,omitempty"`
+	HighestCommittedSeqNr       uint64 `protobuf:"varint,3,opt,name=highest_committed_seq_nr,json=highestCommittedSeqNr,proto3" json:"highest_committed_seq_nr,omitempty"`
+	Degraded                    bool   `protobuf:"varint,4,opt,name=degraded,proto3" json:"degraded,omitempty"`
+	RecoveredFromCorruptedState bool   `protobuf:"varint,5,opt,name=recovered_from_corrupted_state,json=recoveredFromCorruptedState,proto3" json:"recovered_from_corrupted_state,omitempty"`
+}
+
+func (x *HeartbeatInstance) Reset() {
+	*x = HeartbeatInstance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting3_telemetry_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeartbeatInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatInstance) ProtoMessage() {}
+
+func (x *HeartbeatInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting3_telemetry_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatInstance.ProtoReflect.Descriptor instead.
+func (*HeartbeatInstance) Descriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HeartbeatInstance) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *HeartbeatInstance) GetOracleId() uint32 {
+	if x != nil {
+		return x.OracleId
+	}
+	return 0
+}
+
+func (x *HeartbeatInstance) GetHighestCommittedSeqNr() uint64 {
+	if x != nil {
+		return x.HighestCommittedSeqNr
+	}
+	return 0
+}
+
+func (x *HeartbeatInstance) GetDegraded() bool {
+	if x != nil {
+		return x.Degraded
+	}
+	return false
+}
+
+func (x *HeartbeatInstance) GetRecoveredFromCorruptedState() bool {
+	if x != nil {
+		return x.RecoveredFromCorruptedState
+	}
+	return false
+}
+
+type TelemetryDegradedModeChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest     []byte `protobuf:"bytes,1,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+	Degraded         bool   `protobuf:"varint,2,opt,name=degraded,proto3" json:"degraded,omitempty"`
+	ReachableOracles uint32 `protobuf:"varint,3,opt,name=reachable_oracles,json=reachableOracles,proto3" json:"reachable_oracles,omitempty"`
+}
+
+func (x *TelemetryDegradedModeChanged) Reset() {
+	*x = TelemetryDegradedModeChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting3_telemetry_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryDegradedModeChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryDegradedModeChanged) ProtoMessage() {}
+
+func (x *TelemetryDegradedModeChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting3_telemetry_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryDegradedModeChanged.ProtoReflect.Descriptor instead.
+func (*TelemetryDegradedModeChanged) Descriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TelemetryDegradedModeChanged) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryDegradedModeChanged) GetDegraded() bool {
+	if x != nil {
+		return x.Degraded
+	}
+	return false
+}
+
+func (x *TelemetryDegradedModeChanged) GetReachableOracles() uint32 {
+	if x != nil {
+		return x.ReachableOracles
+	}
+	return 0
+}
+
+type TelemetryCorruptedStateQuarantined struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte `protobuf:"bytes,1,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+	Key          string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *TelemetryCorruptedStateQuarantined) Reset() {
+	*x = TelemetryCorruptedStateQuarantined{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting3_telemetry_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TelemetryCorruptedStateQuarantined) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryCorruptedStateQuarantined) ProtoMessage() {}
+
+func (x *TelemetryCorruptedStateQuarantined) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting3_telemetry_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryCorruptedStateQuarantined.ProtoReflect.Descriptor instead.
+func (*TelemetryCorruptedStateQuarantined) Descriptor() ([]byte, []int) {
+	return file_offchainreporting3_telemetry_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TelemetryCorruptedStateQuarantined) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *TelemetryCorruptedStateQuarantined) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+var File_offchainreporting3_telemetry_proto protoreflect.FileDescriptor
+
+var file_offchainreporting3_telemetry_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x33, 0x5f, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x1a, 0x21, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x89, 0x0a, 0x0a, 0x10,
+	0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
+	0x12, 0x59, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6f, 0x66, 0x66,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e,
+	0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x5c, 0x0a, 0x11, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65,
+	0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x72, 0x6f, 0x61,
+	0x64, 0x63, 0x61, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x0c, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x62, 0x0a, 0x13, 0x61, 0x73, 0x73, 0x65,
+	0x72, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d,
+	0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x12, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74,
+	0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x50, 0x0a, 0x0d,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74,
+	0x72, 0x79, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x48, 0x00,
+	0x52, 0x0c, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x53,
+	0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65,
+	0x6d, 0x65, 0x74, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x48, 0x00, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x46, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65,
+	0x6d, 0x65, 0x74, 0x72, 0x79, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x48, 0x00,
+	0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x59, 0x0a, 0x10, 0x6f,
+	0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d,
+	0x65, 0x74, 0x72, 0x79, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x43, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x12, 0x5c, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d,
+	0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2d, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79,
+	0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64,
+	0x48, 0x00, 0x52, 0x10, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x74, 0x65, 0x64, 0x12, 0x56, 0x0a, 0x0f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x61,
+	0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
+	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x68, 0x0a, 0x15,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6f, 0x66,
+	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33,
+	0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00,
+	0x52, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x66, 0x0a, 0x15, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x64, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d,
+	0x65, 0x74, 0x72, 0x79, 0x44, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x4d, 0x6f, 0x64, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x13, 0x64, 0x65, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x78,
+	0x0a, 0x1b, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x5f, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74,
+	0x72, 0x79, 0x43, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x51, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x48, 0x00, 0x52, 0x19, 0x63,
+	0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x51, 0x75, 0x61,
+	0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x15, 0x75, 0x6e, 0x69, 0x78,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d,
+	0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x42, 0x09, 0x0a, 0x07,
+	0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x22, 0x8d, 0x01, 0x0a, 0x18, 0x54, 0x65, 0x6c, 0x65,
+	0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x03, 0x6d, 0x73, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x9d, 0x01, 0x0a, 0x19, 0x54, 0x65, 0x6c, 0x65,
+	0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x72, 0x6f, 0x61,
+	0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x03, 0x6d, 0x73,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67,
+	0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x6d,
+	0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x22, 0xb4, 0x01, 0x0a, 0x14, 0x54, 0x65, 0x6c, 0x65,
+	0x6d, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57,
+	0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d,
+	0x73, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x22, 0xac,
+	0x01, 0x0a, 0x1b, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65,
+	0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x7a,
+	0x0a, 0x15, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e,
+	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x33, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65,
+	0x72, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x14, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0b, 0x0a, 0x09, 0x76, 0x69,
+	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x95, 0x01,
+	0x0a, 0x2f, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x41, 0x73, 0x73, 0x65, 0x72,
+	0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0xab, 0x01, 0x0a, 0x15, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65,
+	0x74, 0x72, 0x79, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06,
+	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
+	0x71, 0x4e, 0x72, 0x22, 0xa5, 0x01, 0x0a, 0x16, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72,
+	0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71,
+	0x5f, 0x6e, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x72,
+	0x12, 0x39, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25,
+	0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69,
+	0x6e, 0x67, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x4c, 0x0a, 0x12, 0x54,
+	0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x9f, 0x02, 0x0a, 0x18, 0x54, 0x65,
+	0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x43, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x64, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0d, 0x52, 0x11, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x64, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0d, 0x52, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x5f,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x6f, 0x75,
+	0x74, 0x63, 0x6f, 0x6d, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x94, 0x01, 0x0a, 0x19,
+	0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x6f,
+	0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x22, 0x92, 0x01, 0x0a, 0x17, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x22, 0xc4, 0x01, 0x0a, 0x1d, 0x54, 0x65, 0x6c, 0x65,
+	0x6d, 0x65, 0x74, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x73, 0x65, 0x71, 0x4e, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x44, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x6f, 0x66, 0x66,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x33, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xde,
+	0x01, 0x0a, 0x10, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x11, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x4e,
+	0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x43, 0x0a, 0x09, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x33, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22,
+	0xef, 0x01, 0x0a, 0x11, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x72,
+	0x61, 0x63, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6f,
+	0x72, 0x61, 0x63, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x37, 0x0a, 0x18, 0x68, 0x69, 0x67, 0x68, 0x65,
+	0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x71,
+	0x5f, 0x6e, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x68, 0x69, 0x67, 0x68, 0x65,
+	0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x53, 0x65, 0x71, 0x4e, 0x72,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x12, 0x43, 0x0a, 0x1e,
+	0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63,
+	0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x1b, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x46,
+	0x72, 0x6f, 0x6d, 0x43, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x22, 0x8c, 0x01, 0x0a, 0x1c, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x44,
+	0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65,
+	0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10,
+	0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x73,
+	0x22, 0x5b, 0x0a, 0x22, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x72,
+	0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x51, 0x75, 0x61, 0x72, 0x61,
+	0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x2a, 0xee, 0x01,
+	0x0a, 0x11, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x1f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x28, 0x0a, 0x24, 0x50, 0x52, 0x4f, 0x54,
+	0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f,
+	0x50, 0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54,
+	0x10, 0x01, 0x12, 0x31, 0x0a, 0x2d, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x53, 0x55, 0x46, 0x46,
+	0x49, 0x43, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x4f, 0x42, 0x53, 0x45, 0x52, 0x56, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x53, 0x10, 0x02, 0x12, 0x2d, 0x0a, 0x29, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f,
+	0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x41, 0x54, 0x54,
+	0x45, 0x53, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x4f, 0x52, 0x54, 0x46, 0x41,
+	0x4c, 0x4c, 0x10, 0x03, 0x12, 0x28, 0x0a, 0x24, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c,
+	0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x54, 0x52, 0x41, 0x4e,
+	0x53, 0x4d, 0x49, 0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x04, 0x2a, 0xb7,
+	0x01, 0x0a, 0x14, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44,
+	0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x21, 0x54, 0x52, 0x41, 0x4e, 0x53,
+	0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x26,
+	0x0a, 0x22, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x44,
+	0x45, 0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x41, 0x43, 0x43, 0x45,
+	0x50, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x29, 0x0a, 0x25, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x4d,
+	0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x5f,
+	0x4e, 0x4f, 0x54, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x4d, 0x49, 0x54, 0x54, 0x45, 0x44, 0x10,
+	0x02, 0x12, 0x25, 0x0a, 0x21, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f,
+	0x4e, 0x5f, 0x44, 0x45, 0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53,
+	0x4d, 0x49, 0x54, 0x54, 0x45, 0x44, 0x10, 0x03, 0x42, 0x11, 0x5a, 0x0f, 0x2e, 0x3b, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_offchainreporting3_telemetry_proto_rawDescOnce sync.Once
+	file_offchainreporting3_telemetry_proto_rawDescData = file_offchainreporting3_telemetry_proto_rawDesc
+)
+
+func file_offchainreporting3_telemetry_proto_rawDescGZIP() []byte {
+	file_offchainreporting3_telemetry_proto_rawDescOnce.Do(func() {
+		file_offchainreporting3_telemetry_proto_rawDescData = protoimpl.X.CompressGZIP(file_offchainreporting3_telemetry_proto_rawDescData)
+	})
+	return file_offchainreporting3_telemetry_proto_rawDescData
+}
+
+var file_offchainreporting3_telemetry_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_offchainreporting3_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_offchainreporting3_telemetry_proto_goTypes = []interface{}{
+	(ProtocolErrorCode)(0),                                  // 0: offchainreporting3.ProtocolErrorCode
+	(TransmissionDecision)(0),                               // 1: offchainreporting3.TransmissionDecision
+	(*TelemetryWrapper)(nil),                                // 2: offchainreporting3.TelemetryWrapper
+	(*TelemetryMessageReceived)(nil),                        // 3: offchainreporting3.TelemetryMessageReceived
+	(*TelemetryMessageBroadcast)(nil),                       // 4: offchainreporting3.TelemetryMessageBroadcast
+	(*TelemetryMessageSent)(nil),                            // 5: offchainreporting3.TelemetryMessageSent
+	(*TelemetryAssertionViolation)(nil),                     // 6: offchainreporting3.TelemetryAssertionViolation
+	(*TelemetryAssertionViolationInvalidSerialization)(nil), // 7: offchainreporting3.TelemetryAssertionViolationInvalidSerialization
+	(*TelemetryRoundStarted)(nil),                           // 8: offchainreporting3.TelemetryRoundStarted
+	(*TelemetryProtocolError)(nil),                          // 9: offchainreporting3.TelemetryProtocolError
+	(*TelemetryHeartbeat)(nil),                              // 10: offchainreporting3.TelemetryHeartbeat
+	(*TelemetryOutcomeComputed)(nil),                        // 11: offchainreporting3.TelemetryOutcomeComputed
+	(*TelemetryOutcomeCommitted)(nil),                       // 12: offchainreporting3.TelemetryOutcomeCommitted
+	(*TelemetryReportAttested)(nil),                         // 13: offchainreporting3.TelemetryReportAttested
+	(*TelemetryTransmissionDecision)(nil),                   // 14: offchainreporting3.TelemetryTransmissionDecision
+	(*HeartbeatPayload)(nil),                                // 15: offchainreporting3.HeartbeatPayload
+	(*HeartbeatInstance)(nil),                               // 16: offchainreporting3.HeartbeatInstance
+	(*TelemetryDegradedModeChanged)(nil),                    // 17: offchainreporting3.TelemetryDegradedModeChanged
+	(*TelemetryCorruptedStateQuarantined)(nil),              // 18: offchainreporting3.TelemetryCorruptedStateQuarantined
+	(*MessageWrapper)(nil),                                  // 19: offchainreporting3.MessageWrapper
+}
+var file_offchainreporting3_telemetry_proto_depIdxs = []int32{
+	3,  // 0: offchainreporting3.TelemetryWrapper.message_received:type_name -> offchainreporting3.TelemetryMessageReceived
+	4,  // 1: offchainreporting3.TelemetryWrapper.message_broadcast:type_name -> offchainreporting3.TelemetryMessageBroadcast
+	5,  // 2: offchainreporting3.TelemetryWrapper.message_sent:type_name -> offchainreporting3.TelemetryMessageSent
+	6,  // 3: offchainreporting3.TelemetryWrapper.assertion_violation:type_name -> offchainreporting3.TelemetryAssertionViolation
+	8,  // 4: offchainreporting3.TelemetryWrapper.round_started:type_name -> offchainreporting3.TelemetryRoundStarted
+	9,  // 5: offchainreporting3.TelemetryWrapper.protocol_error:type_name -> offchainreporting3.TelemetryProtocolError
+	10, // 6: offchainreporting3.TelemetryWrapper.heartbeat:type_name -> offchainreporting3.TelemetryHeartbeat
+	11, // 7: offchainreporting3.TelemetryWrapper.outcome_computed:type_name -> offchainreporting3.TelemetryOutcomeComputed
+	12, // 8: offchainreporting3.TelemetryWrapper.outcome_committed:type_name -> offchainreporting3.TelemetryOutcomeCommitted
+	13, // 9: offchainreporting3.TelemetryWrapper.report_attested:type_name -> offchainreporting3.TelemetryReportAttested
+	14, // 10: offchainreporting3.TelemetryWrapper.transmission_decision:type_name -> offchainreporting3.TelemetryTransmissionDecision
+	17, // 11: offchainreporting3.TelemetryWrapper.degraded_mode_changed:type_name -> offchainreporting3.TelemetryDegradedModeChanged
+	18, // 12: offchainreporting3.TelemetryWrapper.corrupted_state_quarantined:type_name -> offchainreporting3.TelemetryCorruptedStateQuarantined
+	19, // 13: offchainreporting3.TelemetryMessageReceived.msg:type_name -> offchainreporting3.MessageWrapper
+	19, // 14: offchainreporting3.TelemetryMessageBroadcast.msg:type_name -> offchainreporting3.MessageWrapper
+	19, // 15: offchainreporting3.TelemetryMessageSent.msg:type_name -> offchainreporting3.MessageWrapper
+	7,  // 16: offchainreporting3.TelemetryAssertionViolation.invalid_serialization:type_name -> offchainreporting3.TelemetryAssertionViolationInvalidSerialization
+	0,  // 17: offchainreporting3.TelemetryProtocolError.code:type_name -> offchainreporting3.ProtocolErrorCode
+	1,  // 18: offchainreporting3.TelemetryTransmissionDecision.decision:type_name -> offchainreporting3.TransmissionDecision
+	16, // 19: offchainreporting3.HeartbeatPayload.instances:type_name -> offchainreporting3.HeartbeatInstance
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_offchainreporting3_telemetry_proto_init() }
+func file_offchainreporting3_telemetry_proto_init() {
+	if File_offchainreporting3_telemetry_proto != nil {
+		return
+	}
+	file_offchainreporting3_messages_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_offchainreporting3_telemetry_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryWrapper); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryMessageReceived); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryMessageBroadcast); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryMessageSent); i {
+			case 0:
+				return &v.state
+			case 1:
 				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
@@ -804,6 +1940,126 @@ func file_offchainreporting3_telemetry_proto_init() {
 				return nil
 			}
 		}
+		file_offchainreporting3_telemetry_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryProtocolError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryHeartbeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryOutcomeComputed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryOutcomeCommitted); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryReportAttested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryTransmissionDecision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatInstance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryDegradedModeChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting3_telemetry_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TelemetryCorruptedStateQuarantined); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_offchainreporting3_telemetry_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*TelemetryWrapper_MessageReceived)(nil),
@@ -811,6 +2067,14 @@ func file_offchainreporting3_telemetry_proto_init() {
 		(*TelemetryWrapper_MessageSent)(nil),
 		(*TelemetryWrapper_AssertionViolation)(nil),
 		(*TelemetryWrapper_RoundStarted)(nil),
+		(*TelemetryWrapper_ProtocolError)(nil),
+		(*TelemetryWrapper_Heartbeat)(nil),
+		(*TelemetryWrapper_OutcomeComputed)(nil),
+		(*TelemetryWrapper_OutcomeCommitted)(nil),
+		(*TelemetryWrapper_ReportAttested)(nil),
+		(*TelemetryWrapper_TransmissionDecision)(nil),
+		(*TelemetryWrapper_DegradedModeChanged)(nil),
+		(*TelemetryWrapper_CorruptedStateQuarantined)(nil),
 	}
 	file_offchainreporting3_telemetry_proto_msgTypes[4].OneofWrappers = []interface{}{
 		(*TelemetryAssertionViolation_InvalidSerialization)(nil),
@@ -820,13 +2084,14 @@ func file_offchainreporting3_telemetry_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_offchainreporting3_telemetry_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_offchainreporting3_telemetry_proto_goTypes,
 		DependencyIndexes: file_offchainreporting3_telemetry_proto_depIdxs,
+		EnumInfos:         file_offchainreporting3_telemetry_proto_enumTypes,
 		MessageInfos:      file_offchainreporting3_telemetry_proto_msgTypes,
 	}.Build()
 	File_offchainreporting3_telemetry_proto = out.File
@@ -2,20 +2,84 @@ package serialization
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
 
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 )
 
-// Serialize encodes a protocol.Message into a binary payload
-func Serialize[RI any](m protocol.Message[RI]) (b []byte, pbm *MessageWrapper, err error) {
+// sentTimeFieldNumber is the protobuf field number under which the sender's
+// wall clock time is stored in MessageWrapper. We encode the field by hand as
+// an unknown field, so that receivers running older library versions simply
+// ignore it. Be sure to reserve this number in the .proto file when
+// regenerating it.
+const sentTimeFieldNumber protowire.Number = 100
+
+// observedAtFieldNumber and receivedAtFieldNumber are the field numbers under
+// which protocol.SignedObservation.ObservedAt and
+// protocol.AttributedSignedObservation.ReceivedAt are stored in SignedObservation
+// and AttributedSignedObservation, respectively. Like the sent time, they are
+// encoded by hand as unknown fields.
+const (
+	observedAtFieldNumber protowire.Number = 106
+	receivedAtFieldNumber protowire.Number = 107
+)
+
+// appendTimestamp appends t as a varint field with number num to unknown,
+// unless t is zero.
+func appendTimestamp(unknown []byte, num protowire.Number, t time.Time) []byte {
+	if t.IsZero() {
+		return unknown
+	}
+	unknown = protowire.AppendTag(unknown, num, protowire.VarintType)
+	return protowire.AppendVarint(unknown, uint64(t.UnixNano()))
+}
+
+// consumeTimestamp returns the zero time if unknown doesn't contain a
+// timestamp with field number num, e.g. because the sender runs an older
+// library version.
+func consumeTimestamp(unknown []byte, num protowire.Number) (time.Time, error) {
+	var t time.Time
+	for len(unknown) > 0 {
+		fieldNum, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return time.Time{}, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+		if fieldNum == num && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(unknown)
+			if n < 0 {
+				return time.Time{}, fmt.Errorf("could not parse timestamp in field %v: %w", num, protowire.ParseError(n))
+			}
+			t = time.Unix(0, int64(v))
+			unknown = unknown[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(fieldNum, typ, unknown)
+		if n < 0 {
+			return time.Time{}, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+	}
+	return t, nil
+}
+
+// Serialize encodes a protocol.Message into a binary payload. Unless sentTime
+// is zero, it is included in the payload.
+func Serialize[RI any](m protocol.Message[RI], sentTime time.Time) (b []byte, pbm *MessageWrapper, err error) {
 	pbm, err = toProtoMessage(m)
 	if err != nil {
 		return nil, nil, err
 	}
+	unknown, err := appendStateSyncMessage(appendBlobMessage(nil, m), m)
+	if err != nil {
+		return nil, nil, err
+	}
+	pbm.ProtoReflect().SetUnknown(appendTimestamp(unknown, sentTimeFieldNumber, sentTime))
 	b, err = proto.Marshal(pbm)
 	if err != nil {
 		return nil, nil, err
@@ -23,18 +87,66 @@ func Serialize[RI any](m protocol.Message[RI]) (b []byte, pbm *MessageWrapper, e
 	return b, pbm, nil
 }
 
-// Deserialize decodes a binary payload into a protocol.Message
-func Deserialize[RI any](b []byte) (protocol.Message[RI], *MessageWrapper, error) {
+// WithoutSentTime returns the payload b produced by Serialize without the
+// sent time, so that payloads of the same message sent at different times
+// compare equal. b is returned as is if it doesn't contain a sent time or
+// doesn't parse.
+func WithoutSentTime(b []byte) []byte {
+	var stripped []byte
+	for rest := b; len(rest) > 0; {
+		fieldNum, typ, n := protowire.ConsumeTag(rest)
+		if n < 0 {
+			return b
+		}
+		m := protowire.ConsumeFieldValue(fieldNum, typ, rest[n:])
+		if m < 0 {
+			return b
+		}
+		if fieldNum == sentTimeFieldNumber {
+			if stripped == nil {
+				stripped = make([]byte, 0, len(b))
+				stripped = append(stripped, b[:len(b)-len(rest)]...)
+			}
+		} else if stripped != nil {
+			stripped = append(stripped, rest[:n+m]...)
+		}
+		rest = rest[n+m:]
+	}
+	if stripped == nil {
+		return b
+	}
+	return stripped
+}
+
+// Deserialize decodes a binary payload into a protocol.Message. The returned
+// sent time is zero if the payload doesn't contain one.
+func Deserialize[RI any](b []byte) (protocol.Message[RI], *MessageWrapper, time.Time, error) {
 	pbm := &MessageWrapper{}
 	err := proto.Unmarshal(b, pbm)
 	if err != nil {
-		return nil, nil, fmt.Errorf("could not unmarshal protobuf: %w", err)
+		return nil, nil, time.Time{}, fmt.Errorf("could not unmarshal protobuf: %w", err)
+	}
+	var m protocol.Message[RI]
+	if pbm.Msg == nil {
+		var ok bool
+		m, ok, err = consumeBlobMessage[RI](pbm.ProtoReflect().GetUnknown())
+		if err == nil && !ok {
+			m, ok, err = consumeStateSyncMessage[RI](pbm.ProtoReflect().GetUnknown())
+		}
+		if err == nil && !ok {
+			err = fmt.Errorf("message is empty")
+		}
+	} else {
+		m, err = messageWrapperFromProtoMessage[RI](pbm)
+	}
+	if err != nil {
+		return nil, nil, time.Time{}, fmt.Errorf("could not translate protobuf to protocol.Message: %w", err)
 	}
-	m, err := messageWrapperFromProtoMessage[RI](pbm)
+	sentTime, err := consumeTimestamp(pbm.ProtoReflect().GetUnknown(), sentTimeFieldNumber)
 	if err != nil {
-		return nil, nil, fmt.Errorf("could not translate protobuf to protocol.Message: %w", err)
+		return nil, nil, time.Time{}, err
 	}
-	return m, pbm, nil
+	return m, pbm, sentTime, nil
 }
 
 //
@@ -173,6 +285,10 @@ func toProtoMessage[RI any](m protocol.Message[RI]) (*MessageWrapper, error) {
 			CertifiedCommitToProtoMessage(v.CertifiedCommit),
 		}
 		msgWrapper.Msg = &MessageWrapper_MessageCertifiedCommit{pm}
+	case protocol.MessageBlob[RI], protocol.MessageBlobAvailable[RI], protocol.MessageBlobRequest[RI], protocol.MessageBlobResponse[RI]:
+		// encoded as unknown fields by Serialize, see appendBlobMessage
+	case protocol.MessageStateSyncRequest[RI], protocol.MessageStateSyncResponse[RI]:
+		// encoded as unknown fields by Serialize, see appendStateSyncMessage
 
 	default:
 		return nil, fmt.Errorf("unable to serialize message of type %T", m)
@@ -310,7 +426,7 @@ func epochStartProofToProtoMessage(srqc protocol.EpochStartProof) *EpochStartPro
 }
 
 func signedObservationToProtoMessage(o protocol.SignedObservation) *SignedObservation {
-	return &SignedObservation{
+	pbo := &SignedObservation{
 		// zero-initialize protobuf built-ins
 		protoimpl.MessageState{},
 		0,
@@ -319,10 +435,14 @@ func signedObservationToProtoMessage(o protocol.SignedObservation) *SignedObserv
 		o.Observation,
 		o.Signature,
 	}
+	if unknown := appendTimestamp(nil, observedAtFieldNumber, o.ObservedAt); len(unknown) > 0 {
+		pbo.ProtoReflect().SetUnknown(unknown)
+	}
+	return pbo
 }
 
 func attributedSignedObservationToProtoMessage(aso protocol.AttributedSignedObservation) *AttributedSignedObservation {
-	return &AttributedSignedObservation{
+	pbaso := &AttributedSignedObservation{
 		// zero-initialize protobuf built-ins
 		protoimpl.MessageState{},
 		0,
@@ -331,6 +451,10 @@ func attributedSignedObservationToProtoMessage(aso protocol.AttributedSignedObse
 		signedObservationToProtoMessage(aso.SignedObservation),
 		uint32(aso.Observer),
 	}
+	if unknown := appendTimestamp(nil, receivedAtFieldNumber, aso.ReceivedAt); len(unknown) > 0 {
+		pbaso.ProtoReflect().SetUnknown(unknown)
+	}
+	return pbaso
 }
 
 func PacemakerStateToProtoMessage(ps protocol.PacemakerState) *PacemakerState {
@@ -653,9 +777,15 @@ func attributedSignedObservationFromProtoMessage(m *AttributedSignedObservation)
 		return protocol.AttributedSignedObservation{}, err
 	}
 
+	receivedAt, err := consumeTimestamp(m.ProtoReflect().GetUnknown(), receivedAtFieldNumber)
+	if err != nil {
+		return protocol.AttributedSignedObservation{}, err
+	}
+
 	return protocol.AttributedSignedObservation{
 		signedObservation,
 		commontypes.OracleID(m.Observer),
+		receivedAt,
 	}, nil
 }
 
@@ -664,9 +794,15 @@ func signedObservationFromProtoMessage(m *SignedObservation) (protocol.SignedObs
 		return protocol.SignedObservation{}, fmt.Errorf("unable to extract a SignedObservation value")
 	}
 
+	observedAt, err := consumeTimestamp(m.ProtoReflect().GetUnknown(), observedAtFieldNumber)
+	if err != nil {
+		return protocol.SignedObservation{}, err
+	}
+
 	return protocol.SignedObservation{
 		m.Observation,
 		m.Signature,
+		observedAt,
 	}, nil
 }
 
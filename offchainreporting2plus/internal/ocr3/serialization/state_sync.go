@@ -0,0 +1,79 @@
+package serialization
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// The protobuf field numbers under which the messages of the state sync
+// subprotocol are stored in an otherwise empty MessageWrapper. Like blob
+// messages, we encode the fields by hand as unknown fields, and receivers
+// running older library versions drop these messages. A request is stored as
+// a varint, a response as the bytes of a serialized CertifiedCommit. Be sure
+// to reserve these numbers in the .proto file when regenerating it.
+const (
+	stateSyncRequestFieldNumber  protowire.Number = 108
+	stateSyncResponseFieldNumber protowire.Number = 109
+)
+
+// appendStateSyncMessage appends m to unknown if m is a message of the state
+// sync subprotocol, and returns unknown unchanged otherwise.
+func appendStateSyncMessage[RI any](unknown []byte, m protocol.Message[RI]) ([]byte, error) {
+	switch v := m.(type) {
+	case protocol.MessageStateSyncRequest[RI]:
+		unknown = protowire.AppendTag(unknown, stateSyncRequestFieldNumber, protowire.VarintType)
+		return protowire.AppendVarint(unknown, v.HighestCommittedSeqNr), nil
+	case protocol.MessageStateSyncResponse[RI]:
+		b, err := proto.Marshal(CertifiedCommitToProtoMessage(v.CertifiedCommit))
+		if err != nil {
+			return nil, err
+		}
+		unknown = protowire.AppendTag(unknown, stateSyncResponseFieldNumber, protowire.BytesType)
+		return protowire.AppendBytes(unknown, b), nil
+	default:
+		return unknown, nil
+	}
+}
+
+// consumeStateSyncMessage returns ok=false if unknown doesn't contain a
+// message of the state sync subprotocol.
+func consumeStateSyncMessage[RI any](unknown []byte) (m protocol.Message[RI], ok bool, err error) {
+	for len(unknown) > 0 {
+		num, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return nil, false, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+		switch {
+		case num == stateSyncRequestFieldNumber && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(unknown)
+			if n < 0 {
+				return nil, true, fmt.Errorf("could not parse state sync request: %w", protowire.ParseError(n))
+			}
+			return protocol.MessageStateSyncRequest[RI]{v}, true, nil
+		case num == stateSyncResponseFieldNumber && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(unknown)
+			if n < 0 {
+				return nil, true, fmt.Errorf("could not parse state sync response: %w", protowire.ParseError(n))
+			}
+			pbcc := &CertifiedCommit{}
+			if err := proto.Unmarshal(v, pbcc); err != nil {
+				return nil, true, fmt.Errorf("could not unmarshal CertifiedCommit of state sync response: %w", err)
+			}
+			cc, err := certifiedCommitFromProtoMessage(pbcc)
+			if err != nil {
+				return nil, true, err
+			}
+			return protocol.MessageStateSyncResponse[RI]{cc}, true, nil
+		}
+		n = protowire.ConsumeFieldValue(num, typ, unknown)
+		if n < 0 {
+			return nil, false, fmt.Errorf("could not parse unknown fields: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,71 @@
+package simnet
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// OffchainKeyring is an ed25519 types.OffchainKeyring. It doesn't support
+// ConfigDiffieHellman, so it can't decrypt the shared secret of a real
+// contract config.
+type OffchainKeyring struct {
+	PrivateKey ed25519.PrivateKey
+}
+
+var _ types.OffchainKeyring = OffchainKeyring{}
+
+func (k OffchainKeyring) OffchainSign(msg []byte) ([]byte, error) {
+	return ed25519.Sign(k.PrivateKey, msg), nil
+}
+
+func (k OffchainKeyring) ConfigDiffieHellman(point [curve25519.PointSize]byte) ([curve25519.PointSize]byte, error) {
+	return [curve25519.PointSize]byte{}, fmt.Errorf("not supported")
+}
+
+func (k OffchainKeyring) OffchainPublicKey() types.OffchainPublicKey {
+	var pk types.OffchainPublicKey
+	copy(pk[:], k.PrivateKey.Public().(ed25519.PublicKey))
+	return pk
+}
+
+func (k OffchainKeyring) ConfigEncryptionPublicKey() types.ConfigEncryptionPublicKey {
+	return types.ConfigEncryptionPublicKey{}
+}
+
+// OnchainKeyring is an ed25519 ocr3types.OnchainKeyring that signs
+// configDigest || big-endian seqNr || report and ignores the report's info.
+type OnchainKeyring[RI any] struct {
+	PrivateKey ed25519.PrivateKey
+}
+
+var _ ocr3types.OnchainKeyring[struct{}] = OnchainKeyring[struct{}]{}
+
+func onchainSignatureMessage(configDigest types.ConfigDigest, seqNr uint64, report types.Report) []byte {
+	msg := append([]byte{}, configDigest[:]...)
+	msg = binary.BigEndian.AppendUint64(msg, seqNr)
+	return append(msg, report...)
+}
+
+func (k OnchainKeyring[RI]) PublicKey() types.OnchainPublicKey {
+	return types.OnchainPublicKey(k.PrivateKey.Public().(ed25519.PublicKey))
+}
+
+func (k OnchainKeyring[RI]) Sign(configDigest types.ConfigDigest, seqNr uint64, rwi ocr3types.ReportWithInfo[RI]) ([]byte, error) {
+	return ed25519.Sign(k.PrivateKey, onchainSignatureMessage(configDigest, seqNr, rwi.Report)), nil
+}
+
+func (k OnchainKeyring[RI]) Verify(pk types.OnchainPublicKey, configDigest types.ConfigDigest, seqNr uint64, rwi ocr3types.ReportWithInfo[RI], signature []byte) bool {
+	if len(pk) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(pk), onchainSignatureMessage(configDigest, seqNr, rwi.Report), signature)
+}
+
+func (k OnchainKeyring[RI]) MaxSignatureLength() int {
+	return ed25519.SignatureSize
+}
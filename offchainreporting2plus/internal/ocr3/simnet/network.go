@@ -0,0 +1,251 @@
+// Package simnet provides an in-process network and keyrings for running
+// several OCR3 oracles, i.e. instances of protocol.RunOracle, against each
+// other in a single process. It backs both the model checker in package
+// modelcheck and the public test harness in package ocr3testing.
+package simnet
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/scheduler"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+const endpointBufferSize = 1000
+
+// Params controls how a Network treats the messages sent through it.
+type Params struct {
+	// Every message is delayed by a duration drawn uniformly at random from
+	// [MinDelay, MaxDelay). If MaxDelay <= MinDelay, messages are delayed by
+	// exactly MinDelay.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// Probability that a message is dropped
+	DropProbability float64
+	// Probability that a message is delivered twice
+	DuplicateProbability float64
+}
+
+// Stats counts the messages that a Network has delivered, dropped, and
+// duplicated.
+type Stats struct {
+	Delivered  uint64
+	Dropped    uint64
+	Duplicated uint64
+}
+
+type delivery[RI any] struct {
+	msg  protocol.MessageWithSender[RI]
+	to   commontypes.OracleID
+	copy bool
+}
+
+// Network is a simulated network that delays, reorders, duplicates and drops
+// messages according to a seeded pseudorandom schedule, and that can be
+// partitioned. All its functions are thread-safe.
+type Network[RI any] struct {
+	mu sync.Mutex
+	// protected by mu
+	params Params
+	rng    *rand.Rand
+	// nil if the network isn't partitioned, otherwise the group of every
+	// oracle. Oracles only reach oracles in the same group.
+	groups []int
+
+	scheduler *scheduler.Scheduler[delivery[RI]]
+	subs      subprocesses.Subprocesses
+	chDone    chan struct{}
+
+	endpoints []*Endpoint[RI]
+
+	delivered, dropped, duplicated atomic.Uint64
+}
+
+// NewNetwork returns a Network connecting n oracles. Call Close once you're
+// done with it.
+func NewNetwork[RI any](n int, params Params, rng *rand.Rand) *Network[RI] {
+	net := &Network[RI]{
+		params: params,
+		rng:    rng,
+
+		scheduler: scheduler.NewScheduler[delivery[RI]](),
+		chDone:    make(chan struct{}),
+	}
+	for i := 0; i < n; i++ {
+		net.endpoints = append(net.endpoints, &Endpoint[RI]{
+			net,
+			commontypes.OracleID(i),
+			make(chan protocol.MessageWithSender[RI], endpointBufferSize),
+		})
+	}
+	net.subs.Go(func() {
+		for {
+			select {
+			case d := <-net.scheduler.Scheduled():
+				net.deliver(d)
+			case <-net.chDone:
+				return
+			}
+		}
+	})
+	return net
+}
+
+// Endpoint returns the endpoint of oracle id.
+func (net *Network[RI]) Endpoint(id commontypes.OracleID) *Endpoint[RI] {
+	return net.endpoints[id]
+}
+
+// SetParams replaces the Params for all messages sent from now on.
+func (net *Network[RI]) SetParams(params Params) {
+	net.mu.Lock()
+	defer net.mu.Unlock()
+	net.params = params
+}
+
+// Partition splits the network into groups, so that messages are only
+// delivered between oracles in the same group. Every oracle not listed in any
+// group ends up isolated in a group of its own. Messages that are in flight
+// across the new partition boundaries are dropped. Partition replaces any
+// earlier partition.
+func (net *Network[RI]) Partition(groups ...[]commontypes.OracleID) {
+	net.mu.Lock()
+	defer net.mu.Unlock()
+	net.groups = make([]int, len(net.endpoints))
+	for i := range net.groups {
+		net.groups[i] = len(groups) + i
+	}
+	for g, group := range groups {
+		for _, id := range group {
+			if int(id) < len(net.groups) {
+				net.groups[id] = g
+			}
+		}
+	}
+}
+
+// Heal undoes Partition.
+func (net *Network[RI]) Heal() {
+	net.mu.Lock()
+	defer net.mu.Unlock()
+	net.groups = nil
+}
+
+// Stats returns the messages counted so far.
+func (net *Network[RI]) Stats() Stats {
+	return Stats{
+		net.delivered.Load(),
+		net.dropped.Load(),
+		net.duplicated.Load(),
+	}
+}
+
+// Close stops delivering messages. Messages still in flight are discarded.
+func (net *Network[RI]) Close() {
+	close(net.chDone)
+	net.subs.Wait()
+	net.scheduler.Close()
+}
+
+func (net *Network[RI]) reachableLocked(from commontypes.OracleID, to commontypes.OracleID) bool {
+	return net.groups == nil || net.groups[from] == net.groups[to]
+}
+
+func (net *Network[RI]) randomDelayLocked() time.Duration {
+	if net.params.MaxDelay <= net.params.MinDelay {
+		return net.params.MinDelay
+	}
+	return net.params.MinDelay + time.Duration(net.rng.Int63n(int64(net.params.MaxDelay-net.params.MinDelay)))
+}
+
+func (net *Network[RI]) send(msg protocol.Message[RI], from commontypes.OracleID, to commontypes.OracleID) {
+	net.mu.Lock()
+	reachable := net.reachableLocked(from, to)
+	drop := net.rng.Float64() < net.params.DropProbability
+	duplicate := net.rng.Float64() < net.params.DuplicateProbability
+	delay := net.randomDelayLocked()
+	duplicateDelay := net.randomDelayLocked()
+	net.mu.Unlock()
+
+	if !reachable || drop {
+		net.dropped.Add(1)
+		return
+	}
+
+	mws := protocol.MessageWithSender[RI]{msg, from, time.Time{}} // no sent time, delays are simulated
+	net.scheduler.ScheduleDelay(delivery[RI]{mws, to, false}, delay)
+	if duplicate {
+		net.scheduler.ScheduleDelay(delivery[RI]{mws, to, true}, duplicateDelay)
+	}
+}
+
+func (net *Network[RI]) deliver(d delivery[RI]) {
+	net.mu.Lock()
+	reachable := net.reachableLocked(d.msg.Sender, d.to)
+	net.mu.Unlock()
+	if !reachable {
+		net.dropped.Add(1)
+		return
+	}
+
+	select {
+	case net.endpoints[d.to].chReceive <- d.msg:
+		if d.copy {
+			net.duplicated.Add(1)
+		} else {
+			net.delivered.Add(1)
+		}
+	default:
+		// Receiver is overwhelmed, as with a real network we drop the message.
+		net.dropped.Add(1)
+	}
+}
+
+// Endpoint is the protocol.NetworkEndpoint of a single oracle on a Network.
+type Endpoint[RI any] struct {
+	net       *Network[RI]
+	id        commontypes.OracleID
+	chReceive chan protocol.MessageWithSender[RI]
+}
+
+var _ protocol.NetworkEndpoint[struct{}] = (*Endpoint[struct{}])(nil)
+
+func (end *Endpoint[RI]) SendTo(msg protocol.Message[RI], to commontypes.OracleID) {
+	end.net.send(msg, end.id, to)
+}
+
+func (end *Endpoint[RI]) Multicast(msg protocol.Message[RI], to []commontypes.OracleID) {
+	for _, oid := range to {
+		end.net.send(msg, end.id, oid)
+	}
+}
+
+func (end *Endpoint[RI]) Broadcast(msg protocol.Message[RI]) {
+	for i := range end.net.endpoints {
+		end.net.send(msg, end.id, commontypes.OracleID(i))
+	}
+}
+
+func (end *Endpoint[RI]) Receive() <-chan protocol.MessageWithSender[RI] {
+	return end.chReceive
+}
+
+// Drain discards all messages that arrived while the oracle was down
+func (end *Endpoint[RI]) Drain() {
+	for {
+		select {
+		case <-end.chReceive:
+		default:
+			return
+		}
+	}
+}
+
+func (end *Endpoint[RI]) Start() error { return nil }
+
+func (end *Endpoint[RI]) Close() error { return nil }
@@ -0,0 +1,82 @@
+package shim
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/atrest"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// EncryptingOCR2Database encrypts the reports and signatures of pending
+// transmissions before they reach the wrapped Database, see package atrest.
+// The encrypted fields are stored as an envelope in Report; ExtraHash and
+// AttributedSignatures are stored empty. Everything else is passed through.
+type EncryptingOCR2Database struct {
+	types.Database
+	Sealer *atrest.Sealer
+}
+
+var _ types.Database = EncryptingOCR2Database{}
+
+type pendingTransmissionSecrets struct {
+	ExtraHash            [32]byte
+	Report               types.Report
+	AttributedSignatures []types.AttributedOnchainSignature
+}
+
+func pendingTransmissionAssociatedData(ts types.ReportTimestamp) []byte {
+	ad := append([]byte{}, ts.ConfigDigest[:]...)
+	ad = binary.BigEndian.AppendUint32(ad, ts.Epoch)
+	return append(ad, ts.Round)
+}
+
+func (db EncryptingOCR2Database) StorePendingTransmission(ctx context.Context, ts types.ReportTimestamp, pt types.PendingTransmission) error {
+	plaintext, err := json.Marshal(pendingTransmissionSecrets{pt.ExtraHash, pt.Report, pt.AttributedSignatures})
+	if err != nil {
+		return fmt.Errorf("failed to encode pending transmission: %w", err)
+	}
+	envelope, err := db.Sealer.Seal(ctx, ts.ConfigDigest, plaintext, pendingTransmissionAssociatedData(ts))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt pending transmission: %w", err)
+	}
+	return db.Database.StorePendingTransmission(ctx, ts, types.PendingTransmission{
+		pt.Time,
+		[32]byte{},
+		envelope,
+		nil,
+	})
+}
+
+func (db EncryptingOCR2Database) PendingTransmissionsWithConfigDigest(ctx context.Context, configDigest types.ConfigDigest) (map[types.ReportTimestamp]types.PendingTransmission, error) {
+	pts, err := db.Database.PendingTransmissionsWithConfigDigest(ctx, configDigest)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[types.ReportTimestamp]types.PendingTransmission, len(pts))
+	for ts, pt := range pts {
+		// pending transmissions stored before encryption was enabled are
+		// passed through as is
+		if len(pt.AttributedSignatures) != 0 || !atrest.IsEnvelope(pt.Report) {
+			result[ts] = pt
+			continue
+		}
+		plaintext, err := db.Sealer.Open(ctx, pt.Report, pendingTransmissionAssociatedData(ts))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt pending transmission for %v: %w", ts, err)
+		}
+		var secrets pendingTransmissionSecrets
+		if err := json.Unmarshal(plaintext, &secrets); err != nil {
+			return nil, fmt.Errorf("failed to decode pending transmission for %v: %w", ts, err)
+		}
+		result[ts] = types.PendingTransmission{
+			pt.Time,
+			secrets.ExtraHash,
+			secrets.Report,
+			secrets.AttributedSignatures,
+		}
+	}
+	return result, nil
+}
@@ -15,7 +15,7 @@ import (
 )
 
 type OCR2SerializingEndpoint struct {
-	chTelemetry           chan<- *serialization.TelemetryWrapper
+	telemetryQueue        *TelemetryQueue[*serialization.TelemetryWrapper]
 	configDigest          types.ConfigDigest
 	endpoint              commontypes.BinaryNetworkEndpoint
 	logger                commontypes.Logger
@@ -34,14 +34,14 @@ type OCR2SerializingEndpoint struct {
 var _ protocol.NetworkEndpoint = (*OCR2SerializingEndpoint)(nil)
 
 func NewOCR2SerializingEndpoint(
-	chTelemetry chan<- *serialization.TelemetryWrapper,
+	telemetryQueue *TelemetryQueue[*serialization.TelemetryWrapper],
 	configDigest types.ConfigDigest,
 	endpoint commontypes.BinaryNetworkEndpoint,
 	logger commontypes.Logger,
 	reportingPluginLimits types.ReportingPluginLimits,
 ) *OCR2SerializingEndpoint {
 	return &OCR2SerializingEndpoint{
-		chTelemetry,
+		telemetryQueue,
 		configDigest,
 		endpoint,
 		logger,
@@ -59,14 +59,13 @@ func NewOCR2SerializingEndpoint(
 }
 
 func (n *OCR2SerializingEndpoint) sendTelemetry(t *serialization.TelemetryWrapper) {
-	select {
-	case n.chTelemetry <- t:
+	if n.telemetryQueue.TrySend(t) {
 		n.taper.Reset(func(oldCount uint64) {
 			n.logger.Info("OCR2SerializingEndpoint: stopped dropping telemetry", commontypes.LogFields{
 				"droppedCount": oldCount,
 			})
 		})
-	default:
+	} else {
 		n.taper.Trigger(func(newCount uint64) {
 			n.logger.Warn("OCR2SerializingEndpoint: dropping telemetry", commontypes.LogFields{
 				"droppedCount": newCount,
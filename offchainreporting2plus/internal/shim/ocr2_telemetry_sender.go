@@ -10,24 +10,23 @@ import (
 )
 
 type OCR2TelemetrySender struct {
-	chTelemetry chan<- *serialization.TelemetryWrapper
-	logger      commontypes.Logger
-	taper       loghelper.LogarithmicTaper
+	telemetryQueue *TelemetryQueue[*serialization.TelemetryWrapper]
+	logger         commontypes.Logger
+	taper          loghelper.LogarithmicTaper
 }
 
-func MakeOCR2TelemetrySender(chTelemetry chan<- *serialization.TelemetryWrapper, logger commontypes.Logger) OCR2TelemetrySender {
-	return OCR2TelemetrySender{chTelemetry, logger, loghelper.LogarithmicTaper{}}
+func MakeOCR2TelemetrySender(telemetryQueue *TelemetryQueue[*serialization.TelemetryWrapper], logger commontypes.Logger) OCR2TelemetrySender {
+	return OCR2TelemetrySender{telemetryQueue, logger, loghelper.LogarithmicTaper{}}
 }
 
 func (ts OCR2TelemetrySender) send(t *serialization.TelemetryWrapper) {
-	select {
-	case ts.chTelemetry <- t:
+	if ts.telemetryQueue.TrySend(t) {
 		ts.taper.Reset(func(oldCount uint64) {
 			ts.logger.Info("OCR2TelemetrySender: stopped dropping telemetry", commontypes.LogFields{
 				"droppedCount": oldCount,
 			})
 		})
-	default:
+	} else {
 		ts.taper.Trigger(func(newCount uint64) {
 			ts.logger.Warn("OCR2TelemetrySender: dropping telemetry", commontypes.LogFields{
 				"droppedCount": newCount,
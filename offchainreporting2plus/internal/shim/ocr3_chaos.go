@@ -0,0 +1,89 @@
+package shim
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/chaos"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// ChaosOCR3ReportingPlugin wraps another plugin and delays observations as
+// requested by the chaos.Controller.
+type ChaosOCR3ReportingPlugin[RI any] struct {
+	ocr3types.ReportingPluginV2[RI]
+	Controller *chaos.Controller
+	Logger     loghelper.LoggerWithContext
+}
+
+var _ ocr3types.ReportingPluginV2[struct{}] = ChaosOCR3ReportingPlugin[struct{}]{}
+
+func (rp ChaosOCR3ReportingPlugin[RI]) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
+	if delay := rp.Controller.ObservationDelay(); delay > 0 {
+		rp.Logger.Warn("ChaosOCR3ReportingPlugin: delaying observation due to fault injection", commontypes.LogFields{
+			"seqNr": outctx.SeqNr,
+			"delay": delay.String(),
+		})
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return rp.ReportingPluginV2.Observation(ctx, outctx, query)
+}
+
+// ChaosOCR3ContractTransmitter wraps another transmitter and drops
+// transmissions as requested by the chaos.Controller.
+type ChaosOCR3ContractTransmitter[RI any] struct {
+	ocr3types.ContractTransmitter[RI]
+	Controller *chaos.Controller
+	Logger     loghelper.LoggerWithContext
+}
+
+var _ ocr3types.ContractTransmitter[struct{}] = ChaosOCR3ContractTransmitter[struct{}]{}
+
+func (t ChaosOCR3ContractTransmitter[RI]) Transmit(
+	ctx context.Context,
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	reportWithInfo ocr3types.ReportWithInfo[RI],
+	aoss []types.AttributedOnchainSignature,
+) error {
+	if t.Controller.ShouldDropTransmission() {
+		t.Logger.Warn("ChaosOCR3ContractTransmitter: dropping transmission due to fault injection", commontypes.LogFields{
+			"seqNr": seqNr,
+		})
+		return nil
+	}
+	return t.ContractTransmitter.Transmit(ctx, configDigest, seqNr, reportWithInfo, aoss)
+}
+
+// ChaosOCR3BatchContractTransmitter is the ocr3types.BatchContractTransmitter
+// counterpart of ChaosOCR3ContractTransmitter. It drops entire batches.
+type ChaosOCR3BatchContractTransmitter[RI any] struct {
+	ocr3types.BatchContractTransmitter[RI]
+	Controller *chaos.Controller
+	Logger     loghelper.LoggerWithContext
+}
+
+var _ ocr3types.BatchContractTransmitter[struct{}] = ChaosOCR3BatchContractTransmitter[struct{}]{}
+
+func (t ChaosOCR3BatchContractTransmitter[RI]) TransmitBatch(
+	ctx context.Context,
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	reports []ocr3types.BatchedAttestedReport[RI],
+) error {
+	if t.Controller.ShouldDropTransmission() {
+		t.Logger.Warn("ChaosOCR3BatchContractTransmitter: dropping batch transmission due to fault injection", commontypes.LogFields{
+			"seqNr":   seqNr,
+			"reports": len(reports),
+		})
+		return nil
+	}
+	return t.BatchContractTransmitter.TransmitBatch(ctx, configDigest, seqNr, reports)
+}
@@ -0,0 +1,203 @@
+package shim
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/serialization"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"golang.org/x/time/rate"
+)
+
+// Maximum number of chunked messages per remote oracle that may be queued for
+// sending or be partially reassembled at any time
+const chunkedTransferBacklog = 4
+
+// splitIntoChunks splits sMsg into serialized chunks of at most chunkSize
+// bytes of data each.
+func splitIntoChunks(sMsg []byte, chunkSize int, messageID uint64) [][]byte {
+	count := (len(sMsg) + chunkSize - 1) / chunkSize
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		data := sMsg[i*chunkSize : min((i+1)*chunkSize, len(sMsg))]
+		chunks = append(chunks, serialization.SerializeChunk(serialization.Chunk{
+			messageID,
+			uint32(i),
+			uint32(count),
+			data,
+		}))
+	}
+	return chunks
+}
+
+// SplitIntoOCR3Chunks splits sMsg the way an OCR3SerializingEndpoint with
+// chunked transfer configured by config sends it, i.e. into chunks if it is
+// longer than config.ChunkSize, and as is otherwise.
+func SplitIntoOCR3Chunks(config ocr3types.ChunkedTransferConfig, sMsg []byte, messageID uint64) [][]byte {
+	if config.ChunkSize == 0 || len(sMsg) <= config.ChunkSize {
+		return [][]byte{sMsg}
+	}
+	return splitIntoChunks(sMsg, config.ChunkSize, messageID)
+}
+
+// chunkSender paces the chunks sent to a single remote oracle.
+type chunkSender struct {
+	chChunks chan []byte
+	limiter  *rate.Limiter
+}
+
+func newChunkSender(config ocr3types.ChunkedTransferConfig, maxChunkCount int) *chunkSender {
+	return &chunkSender{
+		make(chan []byte, chunkedTransferBacklog*maxChunkCount),
+		rate.NewLimiter(rate.Limit(config.MaxChunksPerSecond), 1),
+	}
+}
+
+// tryEnqueue enqueues either all of chunks or none of them. Not thread-safe.
+func (s *chunkSender) tryEnqueue(chunks [][]byte) bool {
+	if cap(s.chChunks)-len(s.chChunks) < len(chunks) {
+		return false
+	}
+	for _, chunk := range chunks {
+		s.chChunks <- chunk
+	}
+	return true
+}
+
+func (s *chunkSender) run(chCancel <-chan struct{}, send func([]byte)) {
+	for {
+		select {
+		case chunk := <-s.chChunks:
+			if delay := s.limiter.Reserve().Delay(); delay > 0 {
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-chCancel:
+					timer.Stop()
+					return
+				}
+			}
+			send(chunk)
+		case <-chCancel:
+			return
+		}
+	}
+}
+
+type partialMessage struct {
+	messageID uint64
+	count     uint32
+	data      []byte
+}
+
+// chunkReassembler reassembles the chunked messages received from a single
+// remote oracle. Since streams deliver messages in order, a message's chunks
+// must arrive in order of their indices. If a chunk is lost, the partially
+// reassembled message is dropped. Not thread-safe.
+type chunkReassembler struct {
+	chunkSize     int
+	maxChunkCount int
+	partials      []*partialMessage
+}
+
+func newChunkReassembler(chunkSize int, maxChunkCount int) *chunkReassembler {
+	return &chunkReassembler{chunkSize, maxChunkCount, nil}
+}
+
+// add returns the reassembled message once chunk completes it, and nil
+// otherwise.
+func (r *chunkReassembler) add(chunk serialization.Chunk) ([]byte, error) {
+	if !(0 < chunk.Count && int(chunk.Count) <= r.maxChunkCount) {
+		return nil, fmt.Errorf("chunk count %v out of range, should be between 1 and %v", chunk.Count, r.maxChunkCount)
+	}
+	if !(chunk.Index < chunk.Count) {
+		return nil, fmt.Errorf("chunk index %v out of range for count %v", chunk.Index, chunk.Count)
+	}
+	if chunk.Index+1 < chunk.Count && len(chunk.Data) != r.chunkSize {
+		return nil, fmt.Errorf("non-final chunk has length %v, should be %v", len(chunk.Data), r.chunkSize)
+	}
+	if !(0 < len(chunk.Data) && len(chunk.Data) <= r.chunkSize) {
+		return nil, fmt.Errorf("chunk has length %v, should be between 1 and %v", len(chunk.Data), r.chunkSize)
+	}
+
+	i := 0
+	for i < len(r.partials) && r.partials[i].messageID != chunk.MessageID {
+		i++
+	}
+
+	if i == len(r.partials) {
+		if chunk.Index != 0 {
+			return nil, fmt.Errorf("first received chunk of message has index %v", chunk.Index)
+		}
+		if len(r.partials) == chunkedTransferBacklog {
+			// evict the oldest partial message
+			r.partials = r.partials[1:]
+		}
+		// We don't preallocate data based on chunk.Count, since the sender
+		// might never send the remaining chunks.
+		r.partials = append(r.partials, &partialMessage{
+			chunk.MessageID,
+			chunk.Count,
+			nil,
+		})
+		i = len(r.partials) - 1
+	}
+
+	partial := r.partials[i]
+	if chunk.Count != partial.count || int(chunk.Index)*r.chunkSize != len(partial.data) {
+		r.partials = append(r.partials[:i], r.partials[i+1:]...)
+		return nil, fmt.Errorf("chunk %v/%v doesn't match partial message, a chunk must have been lost", chunk.Index, chunk.Count)
+	}
+
+	partial.data = append(partial.data, chunk.Data...)
+	if chunk.Index+1 < chunk.Count {
+		return nil, nil
+	}
+	r.partials = append(r.partials[:i], r.partials[i+1:]...)
+	return partial.data, nil
+}
+
+// chunkedTransfer holds the state of chunked transfer for an
+// OCR3SerializingEndpoint.
+// Chunks are reassembled by an OCR3InboundFilter.
+type chunkedTransfer struct {
+	config        ocr3types.ChunkedTransferConfig
+	nextMessageID uint64
+	senders       []*chunkSender
+}
+
+func newChunkedTransfer(config ocr3types.ChunkedTransferConfig, maxMessageLength int, n int) *chunkedTransfer {
+	if config.ChunkSize == 0 {
+		return nil
+	}
+	senders := make([]*chunkSender, 0, n)
+	for i := 0; i < n; i++ {
+		senders = append(senders, newChunkSender(config, maxChunkCount(config, maxMessageLength)))
+	}
+	return &chunkedTransfer{
+		config,
+		// message IDs only need to be unique per sender, starting from the
+		// current time avoids collisions across restarts
+		uint64(time.Now().UnixNano()),
+		senders,
+	}
+}
+
+func maxChunkCount(config ocr3types.ChunkedTransferConfig, maxMessageLength int) int {
+	return (maxMessageLength + config.ChunkSize - 1) / config.ChunkSize
+}
+
+// Not thread-safe.
+func (t *chunkedTransfer) trySend(sMsg []byte, to []commontypes.OracleID) bool {
+	messageID := t.nextMessageID
+	t.nextMessageID++
+	chunks := splitIntoChunks(sMsg, t.config.ChunkSize, messageID)
+	ok := true
+	for _, oid := range to {
+		if !t.senders[oid].tryEnqueue(chunks) {
+			ok = false
+		}
+	}
+	return ok
+}
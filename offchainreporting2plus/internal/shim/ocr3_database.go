@@ -1,8 +1,13 @@
 package shim
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
 
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/atrest"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/serialization"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
@@ -12,6 +17,8 @@ import (
 
 type SerializingOCR3Database struct {
 	BinaryDb ocr3types.Database
+	// nil if protocol state is stored in plaintext, see package atrest
+	Sealer *atrest.Sealer
 }
 
 var _ protocol.Database = (*SerializingOCR3Database)(nil)
@@ -20,6 +27,54 @@ const pacemakerKey = "pacemaker"
 
 const certKey = "cert"
 
+// Protocol state is written as checksumMagic | crc32c(payload) | payload, so
+// that corruption is detected when it is read back. As a protobuf message
+// can't start with 'O', checksummed values can be told apart from ones
+// written without a checksum by earlier versions. If a Sealer is set,
+// the checksummed value is encrypted in turn.
+var checksumMagic = []byte("OCRS")
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+func addChecksum(payload []byte) []byte {
+	result := append([]byte{}, checksumMagic...)
+	result = binary.BigEndian.AppendUint32(result, crc32.Checksum(payload, crc32cTable))
+	return append(result, payload...)
+}
+
+func verifyChecksum(value []byte) ([]byte, error) {
+	if !hasChecksum(value) {
+		// written without checksum
+		return value, nil
+	}
+	rest := value[len(checksumMagic):]
+	if len(rest) < 4 {
+		return nil, fmt.Errorf("checksum is truncated")
+	}
+	checksum, payload := binary.BigEndian.Uint32(rest), rest[4:]
+	if crc32.Checksum(payload, crc32cTable) != checksum {
+		return nil, fmt.Errorf("checksum mismatch")
+	}
+	return payload, nil
+}
+
+func hasChecksum(value []byte) bool {
+	return bytes.HasPrefix(value, checksumMagic)
+}
+
+// decodeError wraps an error decoding the value stored under key. A value that
+// passed its checksum was written intact, so failing to decode it means that
+// it was written by an incompatible version rather than that it is corrupted.
+// Only values written without checksum are reported as corrupted. A payload
+// that differs from the stored value was checksummed, since values are only
+// encrypted after being checksummed.
+func decodeError(key string, stored []byte, payload []byte, err error) error {
+	if len(payload) != len(stored) || hasChecksum(stored) {
+		return fmt.Errorf("could not decode persisted state %q, which passed its checksum: %w", key, err)
+	}
+	return &protocol.CorruptedStateError{key, stored, err}
+}
+
 func (db *SerializingOCR3Database) ReadConfig(ctx context.Context) (*types.ContractConfig, error) {
 	return db.BinaryDb.ReadConfig(ctx)
 }
@@ -28,8 +83,63 @@ func (db *SerializingOCR3Database) WriteConfig(ctx context.Context, config types
 	return db.BinaryDb.WriteConfig(ctx, config)
 }
 
+// readProtocolState returns the payload stored under key, and the value as
+// stored for use in a protocol.CorruptedStateError.
+func (db *SerializingOCR3Database) readProtocolState(ctx context.Context, configDigest types.ConfigDigest, key string) (payload []byte, stored []byte, err error) {
+	stored, err = db.BinaryDb.ReadProtocolState(ctx, configDigest, key)
+	if err != nil || stored == nil {
+		return nil, stored, err
+	}
+	raw := stored
+	if atrest.IsEnvelope(raw) {
+		if db.Sealer == nil {
+			return nil, stored, fmt.Errorf("protocol state %q is encrypted, but no KeyProvider is configured", key)
+		}
+		// A failure to decrypt may also be due to the wrong KeyProvider or an
+		// unavailable KMS, so we don't treat it as corruption.
+		raw, err = db.Sealer.Open(ctx, raw, associatedData(configDigest, key))
+		if err != nil {
+			return nil, stored, err
+		}
+	}
+	payload, err = verifyChecksum(raw)
+	if err != nil {
+		return nil, stored, &protocol.CorruptedStateError{key, stored, err}
+	}
+	return payload, stored, nil
+}
+
+func (db *SerializingOCR3Database) writeProtocolState(ctx context.Context, configDigest types.ConfigDigest, key string, payload []byte) error {
+	raw := addChecksum(payload)
+	if db.Sealer != nil {
+		var err error
+		raw, err = db.Sealer.Seal(ctx, configDigest, raw, associatedData(configDigest, key))
+		if err != nil {
+			return err
+		}
+	}
+	return db.BinaryDb.WriteProtocolState(ctx, configDigest, key, raw)
+}
+
+func (db *SerializingOCR3Database) QuarantineCorruptedState(ctx context.Context, configDigest types.ConfigDigest, corrupted *protocol.CorruptedStateError) error {
+	var err error
+	if quarantiner, ok := db.BinaryDb.(ocr3types.ProtocolStateQuarantiner); ok {
+		err = quarantiner.QuarantineProtocolState(ctx, configDigest, corrupted.Key, corrupted.Value)
+	} else {
+		err = db.BinaryDb.WriteProtocolState(ctx, configDigest, ocr3types.QuarantineKeyPrefix+corrupted.Key, corrupted.Value)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to quarantine protocol state %q: %w", corrupted.Key, err)
+	}
+	return db.BinaryDb.WriteProtocolState(ctx, configDigest, corrupted.Key, nil)
+}
+
+func associatedData(configDigest types.ConfigDigest, key string) []byte {
+	return append(append([]byte{}, configDigest[:]...), key...)
+}
+
 func (db *SerializingOCR3Database) ReadPacemakerState(ctx context.Context, configDigest types.ConfigDigest) (protocol.PacemakerState, error) {
-	raw, err := db.BinaryDb.ReadProtocolState(ctx, configDigest, pacemakerKey)
+	raw, stored, err := db.readProtocolState(ctx, configDigest, pacemakerKey)
 	if err != nil {
 		return protocol.PacemakerState{}, err
 	}
@@ -40,10 +150,14 @@ func (db *SerializingOCR3Database) ReadPacemakerState(ctx context.Context, confi
 
 	p := serialization.PacemakerState{}
 	if err := proto.Unmarshal(raw, &p); err != nil {
-		return protocol.PacemakerState{}, err
+		return protocol.PacemakerState{}, decodeError(pacemakerKey, stored, raw, err)
 	}
 
-	return serialization.PacemakerStateFromProtoMessage(&p)
+	state, err := serialization.PacemakerStateFromProtoMessage(&p)
+	if err != nil {
+		return protocol.PacemakerState{}, decodeError(pacemakerKey, stored, raw, err)
+	}
+	return state, nil
 }
 
 func (db *SerializingOCR3Database) WritePacemakerState(ctx context.Context, configDigest types.ConfigDigest, state protocol.PacemakerState) error {
@@ -54,11 +168,11 @@ func (db *SerializingOCR3Database) WritePacemakerState(ctx context.Context, conf
 		return err
 	}
 
-	return db.BinaryDb.WriteProtocolState(ctx, configDigest, pacemakerKey, raw)
+	return db.writeProtocolState(ctx, configDigest, pacemakerKey, raw)
 }
 
 func (db *SerializingOCR3Database) ReadCert(ctx context.Context, configDigest types.ConfigDigest) (protocol.CertifiedPrepareOrCommit, error) {
-	raw, err := db.BinaryDb.ReadProtocolState(ctx, configDigest, certKey)
+	raw, stored, err := db.readProtocolState(ctx, configDigest, certKey)
 	if err != nil {
 		return nil, err
 	}
@@ -69,10 +183,14 @@ func (db *SerializingOCR3Database) ReadCert(ctx context.Context, configDigest ty
 
 	p := serialization.CertifiedPrepareOrCommit{}
 	if err := proto.Unmarshal(raw, &p); err != nil {
-		return nil, err
+		return nil, decodeError(certKey, stored, raw, err)
 	}
 
-	return serialization.CertifiedPrepareOrCommitFromProtoMessage(&p)
+	cert, err := serialization.CertifiedPrepareOrCommitFromProtoMessage(&p)
+	if err != nil {
+		return nil, decodeError(certKey, stored, raw, err)
+	}
+	return cert, nil
 }
 
 // Writing with an empty value is the same as deleting.
@@ -88,5 +206,5 @@ func (db *SerializingOCR3Database) WriteCert(ctx context.Context, configDigest t
 		return err
 	}
 
-	return db.BinaryDb.WriteProtocolState(ctx, configDigest, certKey, raw)
+	return db.writeProtocolState(ctx, configDigest, certKey, raw)
 }
@@ -0,0 +1,115 @@
+package shim
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/serialization"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// messageDeduplicator recognizes serialized messages that have already been
+// received from the same sender, as configured by a
+// types.MessageDeduplicationConfig. Messages are compared without the sent
+// time that the sender includes in every payload, so that retransmissions of
+// the same message are recognized. Each sender has its own bounded cache, so
+// that a chatty sender cannot evict the entries of others.
+//
+// NOT thread-safe
+type messageDeduplicator struct {
+	config  types.MessageDeduplicationConfig
+	logger  commontypes.Logger
+	metrics *protocol.Metrics
+	senders []senderDedupCache
+	taper   loghelper.LogarithmicTaper
+}
+
+type senderDedupCache struct {
+	// entries in the order they were received, which is also the order in
+	// which they expire
+	order   *list.List
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+type dedupEntry struct {
+	key      [sha256.Size]byte
+	received time.Time
+}
+
+// newMessageDeduplicator returns nil if config disables deduplication.
+func newMessageDeduplicator(config types.MessageDeduplicationConfig, n int, logger commontypes.Logger, metrics *protocol.Metrics) *messageDeduplicator {
+	if config.MaxEntries <= 0 || config.Window <= 0 {
+		return nil
+	}
+	senders := make([]senderDedupCache, n)
+	for i := range senders {
+		senders[i] = senderDedupCache{list.New(), map[[sha256.Size]byte]*list.Element{}}
+	}
+	return &messageDeduplicator{
+		config,
+		logger,
+		metrics,
+		senders,
+		loghelper.LogarithmicTaper{},
+	}
+}
+
+// isDuplicate returns true iff serializedMsg, up to its sent time, has been
+// received from sender within the window. Otherwise, it remembers
+// serializedMsg.
+func (d *messageDeduplicator) isDuplicate(sender commontypes.OracleID, serializedMsg []byte) bool {
+	if int(sender) < 0 || int(sender) >= len(d.senders) {
+		return false
+	}
+	cache := &d.senders[sender]
+	now := time.Now()
+
+	for front := cache.order.Front(); front != nil; front = cache.order.Front() {
+		entry := front.Value.(dedupEntry)
+		if now.Sub(entry.received) < d.config.Window {
+			break
+		}
+		d.evict(cache, front, protocol.DedupCacheEvictionReasonExpired)
+	}
+
+	key := sha256.Sum256(serialization.WithoutSentTime(serializedMsg))
+	if _, ok := cache.entries[key]; ok {
+		return true
+	}
+
+	if cache.order.Len() >= d.config.MaxEntries {
+		d.evict(cache, cache.order.Front(), protocol.DedupCacheEvictionReasonCapacity)
+		d.taper.Trigger(func(count uint64) {
+			d.logger.Warn("OCR3SerializingEndpoint: inbound message deduplication cache is full, evicting entries before their window elapsed. Consider raising MaxEntries", commontypes.LogFields{
+				"sender":             sender,
+				"maxEntries":         d.config.MaxEntries,
+				"window":             d.config.Window.String(),
+				"prematureEvictions": count,
+			})
+		})
+	}
+
+	cache.entries[key] = cache.order.PushBack(dedupEntry{key, now})
+	d.metrics.AddDedupCacheEntries(1)
+	return false
+}
+
+func (d *messageDeduplicator) evict(cache *senderDedupCache, element *list.Element, reason string) {
+	entry := cache.order.Remove(element).(dedupEntry)
+	delete(cache.entries, entry.key)
+	d.metrics.AddDedupCacheEntries(-1)
+	d.metrics.IncDedupCacheEvictions(reason)
+}
+
+// close forgets all entries. Metrics are shared across protocol instances, so
+// the entries must not linger in the gauge once the endpoint is gone.
+func (d *messageDeduplicator) close() {
+	for i := range d.senders {
+		d.metrics.AddDedupCacheEntries(-d.senders[i].order.Len())
+		d.senders[i] = senderDedupCache{list.New(), map[[sha256.Size]byte]*list.Element{}}
+	}
+}
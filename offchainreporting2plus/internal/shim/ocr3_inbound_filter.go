@@ -0,0 +1,90 @@
+package shim
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/serialization"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// OCR3InboundFilter is the part of the receive path of an
+// OCR3SerializingEndpoint that runs before messages are deserialized: it
+// reassembles chunked messages and drops duplicates. ocr3testing.Fuzzer uses
+// it to process fuzzed messages the way a running oracle does.
+//
+// NOT thread-safe
+type OCR3InboundFilter struct {
+	logger       commontypes.Logger
+	metrics      *protocol.Metrics
+	reassemblers []*chunkReassembler  // nil if chunked transfer is disabled
+	dedup        *messageDeduplicator // nil if deduplication is disabled
+}
+
+func NewOCR3InboundFilter(
+	logger commontypes.Logger,
+	metrics *protocol.Metrics,
+	chunkedTransferConfig ocr3types.ChunkedTransferConfig,
+	deduplicationConfig types.MessageDeduplicationConfig,
+	maxMessageLength int,
+	n int,
+) *OCR3InboundFilter {
+	var reassemblers []*chunkReassembler
+	if chunkedTransferConfig.ChunkSize != 0 {
+		reassemblers = make([]*chunkReassembler, 0, n)
+		for i := 0; i < n; i++ {
+			reassemblers = append(reassemblers, newChunkReassembler(chunkedTransferConfig.ChunkSize, maxChunkCount(chunkedTransferConfig, maxMessageLength)))
+		}
+	}
+	return &OCR3InboundFilter{
+		logger,
+		metrics,
+		reassemblers,
+		newMessageDeduplicator(deduplicationConfig, n, logger, metrics),
+	}
+}
+
+// Filter returns the serialized message that raw, as received from sender,
+// completes. It returns nil if raw is a chunk of a message that is still
+// incomplete, and an error if raw is dropped, i.e. it is an invalid chunk or
+// duplicates an earlier message. Drops are logged and counted in the metrics.
+func (f *OCR3InboundFilter) Filter(sender commontypes.OracleID, raw []byte) ([]byte, error) {
+	serializedMsg := raw
+	if f.reassemblers != nil {
+		chunk, ok, err := serialization.DeserializeChunk(raw)
+		if ok {
+			if err == nil {
+				serializedMsg, err = f.reassemblers[sender].add(chunk)
+			}
+			if err != nil {
+				f.logger.Warn("OCR3SerializingEndpoint: Dropping invalid chunk", commontypes.LogFields{
+					"sender": sender,
+					"error":  err,
+				})
+				f.metrics.IncMessagesDropped(protocol.UnknownMessageType, protocol.MessageDropReasonSerialization)
+				return nil, fmt.Errorf("invalid chunk: %w", err)
+			}
+			if serializedMsg == nil {
+				// message is incomplete
+				return nil, nil
+			}
+		}
+	}
+
+	if f.dedup != nil && f.dedup.isDuplicate(sender, serializedMsg) {
+		// the message hasn't been deserialized, so its type is unknown
+		f.metrics.IncMessagesDropped(protocol.UnknownMessageType, protocol.MessageDropReasonDuplicate)
+		return nil, fmt.Errorf("duplicate message")
+	}
+
+	return serializedMsg, nil
+}
+
+// Close forgets all state.
+func (f *OCR3InboundFilter) Close() {
+	if f.dedup != nil {
+		f.dedup.close()
+	}
+}
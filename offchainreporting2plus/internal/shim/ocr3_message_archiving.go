@@ -0,0 +1,146 @@
+package shim
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// messageArchive samples and truncates protocol messages according to a
+// types.MessageArchivingConfig and queues them for a types.MessageArchiver.
+// Enqueueing never blocks; messages are dropped if the queue is full.
+type messageArchive struct {
+	archiver     types.MessageArchiver
+	config       types.MessageArchivingConfig
+	configDigest types.ConfigDigest
+	logger       commontypes.Logger
+	metrics      *protocol.Metrics
+
+	ch chan types.ArchivedMessage
+
+	// messages are enqueued concurrently by all senders
+	dropTaperMutex sync.Mutex
+	dropTaper      loghelper.LogarithmicTaper
+
+	// only accessed by run
+	errorTaper loghelper.LogarithmicTaper
+}
+
+// newMessageArchive returns nil if archiver is nil. All methods of a nil
+// messageArchive are no-ops.
+func newMessageArchive(archiver types.MessageArchiver, config types.MessageArchivingConfig, configDigest types.ConfigDigest, logger commontypes.Logger, metrics *protocol.Metrics) *messageArchive {
+	if archiver == nil {
+		return nil
+	}
+	queueCapacity := config.QueueCapacity
+	if queueCapacity == 0 {
+		queueCapacity = types.DefaultMessageArchivingQueueCapacity
+	}
+	return &messageArchive{
+		archiver,
+		config,
+		configDigest,
+		logger,
+		metrics,
+
+		make(chan types.ArchivedMessage, queueCapacity),
+
+		sync.Mutex{},
+		loghelper.LogarithmicTaper{},
+
+		loghelper.LogarithmicTaper{},
+	}
+}
+
+func (a *messageArchive) archiveInbound(serializedMsg []byte, sender commontypes.OracleID) {
+	if a == nil {
+		return
+	}
+	a.enqueue(types.MessageDirectionInbound, sender, nil, serializedMsg)
+}
+
+func (a *messageArchive) archiveOutbound(serializedMsg []byte, receivers []commontypes.OracleID) {
+	if a == nil {
+		return
+	}
+	a.enqueue(types.MessageDirectionOutbound, 0, receivers, serializedMsg)
+}
+
+func (a *messageArchive) enqueue(direction types.MessageDirection, sender commontypes.OracleID, receivers []commontypes.OracleID, serializedMsg []byte) {
+	if a.config.SamplingRate != 0 && rand.Float64() >= a.config.SamplingRate {
+		return
+	}
+	length := len(serializedMsg)
+	if a.config.MaxMessageLength != 0 && length > a.config.MaxMessageLength {
+		serializedMsg = serializedMsg[:a.config.MaxMessageLength]
+	}
+	msg := types.ArchivedMessage{
+		a.configDigest,
+		direction,
+		sender,
+		receivers,
+		time.Now(),
+		// copy, since the MessageArchiver runs concurrently with the
+		// protocol
+		append([]byte(nil), serializedMsg...),
+		length,
+	}
+	a.dropTaperMutex.Lock()
+	defer a.dropTaperMutex.Unlock()
+	select {
+	case a.ch <- msg:
+		a.dropTaper.Reset(func(oldCount uint64) {
+			a.logger.Info("OCR3SerializingEndpoint: stopped dropping messages from the archive", commontypes.LogFields{
+				"droppedCount": oldCount,
+			})
+		})
+	default:
+		a.metrics.IncMessageArchiveDropped()
+		a.dropTaper.Trigger(func(newCount uint64) {
+			a.logger.Warn("OCR3SerializingEndpoint: dropping messages from the archive because the MessageArchiver can't keep up", commontypes.LogFields{
+				"droppedCount": newCount,
+			})
+		})
+	}
+}
+
+// run passes queued messages to the MessageArchiver until chCancel is closed.
+func (a *messageArchive) run(chCancel <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-chCancel:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-a.ch:
+			if err := a.archiver.ArchiveMessage(ctx, msg); err != nil {
+				a.errorTaper.Trigger(func(newCount uint64) {
+					a.logger.Warn("OCR3SerializingEndpoint: MessageArchiver returned error", commontypes.LogFields{
+						"error":      err,
+						"errorCount": newCount,
+					})
+				})
+			} else {
+				a.errorTaper.Reset(func(oldCount uint64) {
+					a.logger.Info("OCR3SerializingEndpoint: MessageArchiver stopped returning errors", commontypes.LogFields{
+						"errorCount": oldCount,
+					})
+				})
+			}
+		case <-chCancel:
+			return
+		}
+	}
+}
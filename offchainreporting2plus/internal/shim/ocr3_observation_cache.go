@@ -0,0 +1,119 @@
+package shim
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// ObservationCachingOCR3ReportingPlugin wraps another plugin and reuses its
+// observations for repeated identical queries, as configured by an
+// ocr3types.ObservationCacheConfig.
+type ObservationCachingOCR3ReportingPlugin[RI any] struct {
+	ocr3types.ReportingPluginV2[RI]
+	config ocr3types.ObservationCacheConfig
+	logger loghelper.LoggerWithContext
+
+	mutex   sync.Mutex
+	entries map[[sha256.Size]byte]observationCacheEntry
+}
+
+type observationCacheEntry struct {
+	seqNr       uint64
+	time        time.Time
+	observation types.Observation
+}
+
+var _ ocr3types.ReportingPluginV2[struct{}] = &ObservationCachingOCR3ReportingPlugin[struct{}]{}
+
+func NewObservationCachingOCR3ReportingPlugin[RI any](
+	plugin ocr3types.ReportingPluginV2[RI],
+	config ocr3types.ObservationCacheConfig,
+	logger loghelper.LoggerWithContext,
+) *ObservationCachingOCR3ReportingPlugin[RI] {
+	return &ObservationCachingOCR3ReportingPlugin[RI]{
+		plugin,
+		config,
+		logger,
+		sync.Mutex{},
+		make(map[[sha256.Size]byte]observationCacheEntry, config.MaxEntries),
+	}
+}
+
+func (rp *ObservationCachingOCR3ReportingPlugin[RI]) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
+	key := sha256.Sum256(query)
+
+	if observation, ok := rp.lookup(key, outctx.SeqNr); ok {
+		rp.logger.Trace("ObservationCachingOCR3ReportingPlugin: reusing cached observation", commontypes.LogFields{
+			"seqNr": outctx.SeqNr,
+		})
+		return observation, nil
+	}
+
+	observation, err := rp.ReportingPluginV2.Observation(ctx, outctx, query)
+	if err != nil {
+		// errors are never cached
+		return nil, err
+	}
+	rp.insert(key, observationCacheEntry{outctx.SeqNr, time.Now(), observation})
+	return observation, nil
+}
+
+func (rp *ObservationCachingOCR3ReportingPlugin[RI]) lookup(key [sha256.Size]byte, seqNr uint64) (types.Observation, bool) {
+	rp.mutex.Lock()
+	defer rp.mutex.Unlock()
+
+	entry, ok := rp.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !rp.fresh(entry, seqNr, time.Now()) {
+		delete(rp.entries, key)
+		return nil, false
+	}
+	return entry.observation, true
+}
+
+func (rp *ObservationCachingOCR3ReportingPlugin[RI]) fresh(entry observationCacheEntry, seqNr uint64, now time.Time) bool {
+	if now.Sub(entry.time) >= rp.config.TTL {
+		return false
+	}
+	if seqNr < entry.seqNr {
+		return false
+	}
+	if rp.config.SeqNrWindow != 0 && seqNr-entry.seqNr > rp.config.SeqNrWindow {
+		return false
+	}
+	return true
+}
+
+func (rp *ObservationCachingOCR3ReportingPlugin[RI]) insert(key [sha256.Size]byte, entry observationCacheEntry) {
+	rp.mutex.Lock()
+	defer rp.mutex.Unlock()
+
+	if _, ok := rp.entries[key]; !ok && len(rp.entries) >= rp.config.MaxEntries {
+		// Make room by evicting expired entries, and failing that, the oldest
+		// entry. The cache is small, so a linear scan is fine.
+		var oldestKey [sha256.Size]byte
+		var oldestTime time.Time
+		for k, e := range rp.entries {
+			if entry.time.Sub(e.time) >= rp.config.TTL {
+				delete(rp.entries, k)
+				continue
+			}
+			if oldestTime.IsZero() || e.time.Before(oldestTime) {
+				oldestKey, oldestTime = k, e.time
+			}
+		}
+		if len(rp.entries) >= rp.config.MaxEntries {
+			delete(rp.entries, oldestKey)
+		}
+	}
+	rp.entries[key] = entry
+}
@@ -0,0 +1,158 @@
+package shim
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/pluginscheduler"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// SchedulingOCR3ReportingPlugin wraps another plugin and runs each of its
+// callbacks (other than Close) in a slot acquired from a
+// pluginscheduler.Instance.
+type SchedulingOCR3ReportingPlugin[RI any] struct {
+	Plugin   ocr3types.ReportingPluginV2[RI]
+	Instance *pluginscheduler.Instance
+}
+
+var _ ocr3types.ReportingPluginV2[struct{}] = SchedulingOCR3ReportingPlugin[struct{}]{}
+
+func (rp SchedulingOCR3ReportingPlugin[RI]) Query(ctx context.Context, outctx ocr3types.OutcomeContext) (types.Query, error) {
+	release, err := rp.Instance.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rp.Plugin.Query(ctx, outctx)
+}
+
+func (rp SchedulingOCR3ReportingPlugin[RI]) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
+	release, err := rp.Instance.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rp.Plugin.Observation(ctx, outctx, query)
+}
+
+func (rp SchedulingOCR3ReportingPlugin[RI]) ValidateObservation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, ao types.AttributedObservation) error {
+	release, err := rp.Instance.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return rp.Plugin.ValidateObservation(ctx, outctx, query, ao)
+}
+
+func (rp SchedulingOCR3ReportingPlugin[RI]) ObservationQuorum(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (ocr3types.Quorum, error) {
+	release, err := rp.Instance.Acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	return rp.Plugin.ObservationQuorum(ctx, outctx, query)
+}
+
+func (rp SchedulingOCR3ReportingPlugin[RI]) Outcome(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, aos []types.AttributedObservation) (ocr3types.Outcome, error) {
+	release, err := rp.Instance.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rp.Plugin.Outcome(ctx, outctx, query, aos)
+}
+
+func (rp SchedulingOCR3ReportingPlugin[RI]) Reports(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	release, err := rp.Instance.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rp.Plugin.Reports(ctx, seqNr, outcome)
+}
+
+func (rp SchedulingOCR3ReportingPlugin[RI]) ShouldAcceptAttestedReport(ctx context.Context, seqNr uint64, report ocr3types.ReportWithInfo[RI]) (bool, error) {
+	release, err := rp.Instance.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	return rp.Plugin.ShouldAcceptAttestedReport(ctx, seqNr, report)
+}
+
+func (rp SchedulingOCR3ReportingPlugin[RI]) ShouldTransmitAcceptedReport(ctx context.Context, seqNr uint64, report ocr3types.ReportWithInfo[RI]) (bool, error) {
+	release, err := rp.Instance.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	return rp.Plugin.ShouldTransmitAcceptedReport(ctx, seqNr, report)
+}
+
+func (rp SchedulingOCR3ReportingPlugin[RI]) Close() error {
+	return rp.Plugin.Close()
+}
+
+// SchedulingOCR3ReportBatcher is the analogue of SchedulingOCR3ReportingPlugin
+// for ocr3types.ReportBatcher.
+type SchedulingOCR3ReportBatcher[RI any] struct {
+	Batcher  ocr3types.ReportBatcher[RI]
+	Instance *pluginscheduler.Instance
+}
+
+var _ ocr3types.ReportBatcher[struct{}] = SchedulingOCR3ReportBatcher[struct{}]{}
+
+func (rb SchedulingOCR3ReportBatcher[RI]) ReportBatches(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([][]ocr3types.ReportWithInfo[RI], error) {
+	release, err := rb.Instance.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rb.Batcher.ReportBatches(ctx, seqNr, outcome)
+}
+
+// SchedulingOCR3OutcomeContextReporter is the analogue of
+// SchedulingOCR3ReportingPlugin for ocr3types.OutcomeContextReporter.
+type SchedulingOCR3OutcomeContextReporter[RI any] struct {
+	Reporter ocr3types.OutcomeContextReporter[RI]
+	Instance *pluginscheduler.Instance
+}
+
+var _ ocr3types.OutcomeContextReporter[struct{}] = SchedulingOCR3OutcomeContextReporter[struct{}]{}
+
+func (r SchedulingOCR3OutcomeContextReporter[RI]) ReportsWithOutcomeContext(ctx context.Context, outctx ocr3types.OutcomeContext, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	release, err := r.Instance.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return r.Reporter.ReportsWithOutcomeContext(ctx, outctx, outcome)
+}
+
+// SchedulingOCR3ReportDecisionBatcher is the analogue of
+// SchedulingOCR3ReportingPlugin for ocr3types.ReportDecisionBatcher.
+type SchedulingOCR3ReportDecisionBatcher[RI any] struct {
+	Batcher  ocr3types.ReportDecisionBatcher[RI]
+	Instance *pluginscheduler.Instance
+}
+
+var _ ocr3types.ReportDecisionBatcher[struct{}] = SchedulingOCR3ReportDecisionBatcher[struct{}]{}
+
+func (rb SchedulingOCR3ReportDecisionBatcher[RI]) ShouldAcceptAttestedReports(ctx context.Context, seqNr uint64, reports []ocr3types.ReportWithInfo[RI]) ([]bool, error) {
+	release, err := rb.Instance.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rb.Batcher.ShouldAcceptAttestedReports(ctx, seqNr, reports)
+}
+
+func (rb SchedulingOCR3ReportDecisionBatcher[RI]) ShouldTransmitAcceptedReports(ctx context.Context, seqNr uint64, reports []ocr3types.ReportWithInfo[RI]) ([]bool, error) {
+	release, err := rb.Instance.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rb.Batcher.ShouldTransmitAcceptedReports(ctx, seqNr, reports)
+}
@@ -0,0 +1,131 @@
+package shim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// OCR3ReportPostProcessingPipeline runs a sequence of
+// ocr3types.ReportPostProcessors and checks the size of their results.
+type OCR3ReportPostProcessingPipeline[RI any] struct {
+	postProcessors []ocr3types.ReportPostProcessor[RI]
+	// maxReportLengths[i] bounds the length of the reports returned by
+	// postProcessors[i]
+	maxReportLengths []int
+}
+
+// NewOCR3ReportPostProcessingPipeline returns an error if any post-processor
+// is nil or declares an invalid bound on the length of its results.
+// maxReportLength is the ReportingPlugin's MaxReportLength.
+func NewOCR3ReportPostProcessingPipeline[RI any](postProcessors []ocr3types.ReportPostProcessor[RI], maxReportLength int) (*OCR3ReportPostProcessingPipeline[RI], error) {
+	maxReportLengths := make([]int, 0, len(postProcessors))
+	for i, postProcessor := range postProcessors {
+		if postProcessor == nil {
+			return nil, fmt.Errorf("ReportPostProcessor %v is nil", i)
+		}
+		maxReportLength = postProcessor.MaxPostProcessedReportLength(maxReportLength)
+		if !(0 < maxReportLength && maxReportLength <= ocr3types.MaxMaxReportLength) {
+			return nil, fmt.Errorf("ReportPostProcessor %v declares MaxPostProcessedReportLength %v, but it must be positive and at most %v", i, maxReportLength, ocr3types.MaxMaxReportLength)
+		}
+		maxReportLengths = append(maxReportLengths, maxReportLength)
+	}
+	return &OCR3ReportPostProcessingPipeline[RI]{
+		append([]ocr3types.ReportPostProcessor[RI]{}, postProcessors...),
+		maxReportLengths,
+	}, nil
+}
+
+// PostProcess runs reportWithInfo through all post-processors, see
+// ocr3types.ReportPostProcessor.
+func (p *OCR3ReportPostProcessingPipeline[RI]) PostProcess(
+	ctx context.Context,
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	reportWithInfo ocr3types.ReportWithInfo[RI],
+) ([]ocr3types.ReportWithInfo[RI], error) {
+	reports := []ocr3types.ReportWithInfo[RI]{reportWithInfo}
+	for i, postProcessor := range p.postProcessors {
+		var processed []ocr3types.ReportWithInfo[RI]
+		for _, report := range reports {
+			results, err := postProcessor.PostProcess(ctx, configDigest, seqNr, report)
+			if err != nil {
+				return nil, fmt.Errorf("ReportPostProcessor %v failed: %w", i, err)
+			}
+			if len(results) == 0 {
+				return nil, fmt.Errorf("ReportPostProcessor %v returned no reports", i)
+			}
+			processed = append(processed, results...)
+			if len(processed) > ocr3types.MaxPostProcessedReportCount {
+				return nil, fmt.Errorf("ReportPostProcessor %v returned more than %v reports", i, ocr3types.MaxPostProcessedReportCount)
+			}
+		}
+		for j, report := range processed {
+			if len(report.Report) > p.maxReportLengths[i] {
+				return nil, fmt.Errorf("ReportPostProcessor %v returned report %v of length %v, exceeding its MaxPostProcessedReportLength %v", i, j, len(report.Report), p.maxReportLengths[i])
+			}
+		}
+		reports = processed
+	}
+	return reports, nil
+}
+
+// PostProcessingOCR3ContractTransmitter wraps another transmitter and
+// post-processes every report before transmitting it.
+type PostProcessingOCR3ContractTransmitter[RI any] struct {
+	ocr3types.ContractTransmitter[RI]
+	Pipeline *OCR3ReportPostProcessingPipeline[RI]
+}
+
+var _ ocr3types.ContractTransmitter[struct{}] = PostProcessingOCR3ContractTransmitter[struct{}]{}
+
+func (t PostProcessingOCR3ContractTransmitter[RI]) Transmit(
+	ctx context.Context,
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	reportWithInfo ocr3types.ReportWithInfo[RI],
+	aoss []types.AttributedOnchainSignature,
+) error {
+	reports, err := t.Pipeline.PostProcess(ctx, configDigest, seqNr, reportWithInfo)
+	if err != nil {
+		return fmt.Errorf("error while post-processing report: %w", err)
+	}
+	for i, report := range reports {
+		if err := t.ContractTransmitter.Transmit(ctx, configDigest, seqNr, report, aoss); err != nil {
+			return fmt.Errorf("error while transmitting post-processed report %v of %v: %w", i, len(reports), err)
+		}
+	}
+	return nil
+}
+
+// PostProcessingOCR3BatchContractTransmitter is the
+// ocr3types.BatchContractTransmitter counterpart of
+// PostProcessingOCR3ContractTransmitter.
+type PostProcessingOCR3BatchContractTransmitter[RI any] struct {
+	ocr3types.BatchContractTransmitter[RI]
+	Pipeline *OCR3ReportPostProcessingPipeline[RI]
+}
+
+var _ ocr3types.BatchContractTransmitter[struct{}] = PostProcessingOCR3BatchContractTransmitter[struct{}]{}
+
+func (t PostProcessingOCR3BatchContractTransmitter[RI]) TransmitBatch(
+	ctx context.Context,
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	reports []ocr3types.BatchedAttestedReport[RI],
+) error {
+	processed := make([]ocr3types.BatchedAttestedReport[RI], 0, len(reports))
+	for _, report := range reports {
+		reportsWithInfo, err := t.Pipeline.PostProcess(ctx, configDigest, seqNr, report.ReportWithInfo)
+		if err != nil {
+			return fmt.Errorf("error while post-processing report with index %v: %w", report.Index, err)
+		}
+		for _, reportWithInfo := range reportsWithInfo {
+			report.ReportWithInfo = reportWithInfo
+			processed = append(processed, report)
+		}
+	}
+	return t.BatchContractTransmitter.TransmitBatch(ctx, configDigest, seqNr, processed)
+}
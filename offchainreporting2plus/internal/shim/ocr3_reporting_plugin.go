@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
@@ -13,26 +14,40 @@ import (
 // possible.
 //
 // It does not check inputs since those are checked by the SerializingEndpoint.
+// It records the sizes of the outputs in Metrics, so that operators can see
+// how close the plugin runs to its limits.
 type LimitCheckOCR3ReportingPlugin[RI any] struct {
-	Plugin ocr3types.ReportingPlugin[RI]
-	Limits ocr3types.ReportingPluginLimits
+	Plugin                    ocr3types.ReportingPluginV2[RI]
+	Limits                    ocr3types.ReportingPluginLimits
+	MaxExactObservationQuorum int
+	Metrics                   *protocol.Metrics
 }
 
-var _ ocr3types.ReportingPlugin[struct{}] = LimitCheckOCR3ReportingPlugin[struct{}]{}
+var _ ocr3types.ReportingPluginV2[struct{}] = LimitCheckOCR3ReportingPlugin[struct{}]{}
 
 func (rp LimitCheckOCR3ReportingPlugin[RI]) Query(ctx context.Context, outctx ocr3types.OutcomeContext) (types.Query, error) {
 	query, err := rp.Plugin.Query(ctx, outctx)
 	if err != nil {
 		return nil, err
 	}
+	rp.Metrics.ObservePluginOutputSize(protocol.PluginOutputKindQuery, len(query), rp.Limits.MaxQueryLength)
 	if !(len(query) <= rp.Limits.MaxQueryLength) {
-		return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned oversize query (%v vs %v)", len(query), rp.Limits.MaxQueryLength)
+		return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned output exceeding limits: %w", &types.LimitExceededError{"query", -1, len(query), rp.Limits.MaxQueryLength})
 	}
 	return query, nil
 }
 
-func (rp LimitCheckOCR3ReportingPlugin[RI]) ObservationQuorum(outctx ocr3types.OutcomeContext, query types.Query) (ocr3types.Quorum, error) {
-	return rp.Plugin.ObservationQuorum(outctx, query)
+func (rp LimitCheckOCR3ReportingPlugin[RI]) ObservationQuorum(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (ocr3types.Quorum, error) {
+	quorum, err := rp.Plugin.ObservationQuorum(ctx, outctx, query)
+	if err != nil {
+		return 0, err
+	}
+	// zero means the plugin didn't declare a maximum, in which case exact
+	// counts are only checked against n-f by the protocol
+	if quorum.Exact() && rp.MaxExactObservationQuorum != 0 && !(int(quorum) <= rp.MaxExactObservationQuorum) {
+		return 0, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned exact observation quorum %v exceeding declared MaxExactObservationQuorum %v", int(quorum), rp.MaxExactObservationQuorum)
+	}
+	return quorum, nil
 }
 
 func (rp LimitCheckOCR3ReportingPlugin[RI]) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
@@ -40,41 +55,54 @@ func (rp LimitCheckOCR3ReportingPlugin[RI]) Observation(ctx context.Context, out
 	if err != nil {
 		return nil, err
 	}
+	rp.Metrics.ObservePluginOutputSize(protocol.PluginOutputKindObservation, len(observation), rp.Limits.MaxObservationLength)
 	if !(len(observation) <= rp.Limits.MaxObservationLength) {
-		return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned oversize observation (%v vs %v)", len(observation), rp.Limits.MaxObservationLength)
+		return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned output exceeding limits: %w", &types.LimitExceededError{"observation", -1, len(observation), rp.Limits.MaxObservationLength})
+	}
+	if err := ocr3types.CheckObservationProvenance(observation, rp.Limits); err != nil {
+		return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned observation with invalid provenance: %w", err)
 	}
 	return observation, nil
 }
 
-func (rp LimitCheckOCR3ReportingPlugin[RI]) ValidateObservation(outctx ocr3types.OutcomeContext, query types.Query, ao types.AttributedObservation) error {
-	return rp.Plugin.ValidateObservation(outctx, query, ao)
+func (rp LimitCheckOCR3ReportingPlugin[RI]) ValidateObservation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, ao types.AttributedObservation) error {
+	return rp.Plugin.ValidateObservation(ctx, outctx, query, ao)
 }
 
-func (rp LimitCheckOCR3ReportingPlugin[RI]) Outcome(outctx ocr3types.OutcomeContext, query types.Query, aos []types.AttributedObservation) (ocr3types.Outcome, error) {
-	outcome, err := rp.Plugin.Outcome(outctx, query, aos)
+func (rp LimitCheckOCR3ReportingPlugin[RI]) Outcome(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, aos []types.AttributedObservation) (ocr3types.Outcome, error) {
+	outcome, err := rp.Plugin.Outcome(ctx, outctx, query, aos)
 	if err != nil {
 		return nil, err
 	}
+	rp.Metrics.ObservePluginOutputSize(protocol.PluginOutputKindOutcome, len(outcome), rp.Limits.MaxOutcomeLength)
 	if !(len(outcome) <= rp.Limits.MaxOutcomeLength) {
-		return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned oversize outcome (%v vs %v)", len(outcome), rp.Limits.MaxOutcomeLength)
+		return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned output exceeding limits: %w", &types.LimitExceededError{"outcome", -1, len(outcome), rp.Limits.MaxOutcomeLength})
 	}
 	return outcome, nil
 }
 
-func (rp LimitCheckOCR3ReportingPlugin[RI]) Reports(seqNr uint64, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
-	reports, err := rp.Plugin.Reports(seqNr, outcome)
+func (rp LimitCheckOCR3ReportingPlugin[RI]) Reports(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	reports, err := rp.Plugin.Reports(ctx, seqNr, outcome)
 	if err != nil {
 		return nil, err
 	}
-	if !(len(reports) <= rp.Limits.MaxReportCount) {
-		return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned too many reports (%v vs %v)", len(reports), rp.Limits.MaxReportCount)
+	if err := checkReportsLimits(reports, rp.Limits, rp.Metrics); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func checkReportsLimits[RI any](reports []ocr3types.ReportWithInfo[RI], limits ocr3types.ReportingPluginLimits, metrics *protocol.Metrics) error {
+	if !(len(reports) <= limits.MaxReportCount) {
+		return fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned output exceeding limits: %w", &types.LimitExceededError{"reportCount", -1, len(reports), limits.MaxReportCount})
 	}
 	for i, reportWithInfo := range reports {
-		if !(len(reportWithInfo.Report) <= rp.Limits.MaxReportLength) {
-			return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned oversize report at index %v (%v vs %v)", i, len(reportWithInfo.Report), rp.Limits.MaxReportLength)
+		metrics.ObservePluginOutputSize(protocol.PluginOutputKindReport, len(reportWithInfo.Report), limits.MaxReportLength)
+		if !(len(reportWithInfo.Report) <= limits.MaxReportLength) {
+			return fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned output exceeding limits: %w", &types.LimitExceededError{"report", i, len(reportWithInfo.Report), limits.MaxReportLength})
 		}
 	}
-	return reports, nil
+	return nil
 }
 
 func (rp LimitCheckOCR3ReportingPlugin[RI]) ShouldAcceptAttestedReport(ctx context.Context, seqNr uint64, report ocr3types.ReportWithInfo[RI]) (bool, error) {
@@ -88,3 +116,269 @@ func (rp LimitCheckOCR3ReportingPlugin[RI]) ShouldTransmitAcceptedReport(ctx con
 func (rp LimitCheckOCR3ReportingPlugin[RI]) Close() error {
 	return rp.Plugin.Close()
 }
+
+// LimitCheckOCR3ReportBatcher is the analogue of LimitCheckOCR3ReportingPlugin
+// for ocr3types.ReportBatcher.
+type LimitCheckOCR3ReportBatcher[RI any] struct {
+	Batcher ocr3types.ReportBatcher[RI]
+	Limits  ocr3types.ReportingPluginLimits
+	Metrics *protocol.Metrics
+}
+
+var _ ocr3types.ReportBatcher[struct{}] = LimitCheckOCR3ReportBatcher[struct{}]{}
+
+func (rb LimitCheckOCR3ReportBatcher[RI]) ReportBatches(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([][]ocr3types.ReportWithInfo[RI], error) {
+	batches, err := rb.Batcher.ReportBatches(ctx, seqNr, outcome)
+	if err != nil {
+		return nil, err
+	}
+	if !(len(batches) <= rb.Limits.MaxReportCount) {
+		return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned output exceeding limits: %w", &types.LimitExceededError{"reportBatchCount", -1, len(batches), rb.Limits.MaxReportCount})
+	}
+	reportCount := 0
+	for _, batch := range batches {
+		if len(batch) == 0 {
+			return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned empty report batch")
+		}
+		for _, reportWithInfo := range batch {
+			rb.Metrics.ObservePluginOutputSize(protocol.PluginOutputKindReport, len(reportWithInfo.Report), rb.Limits.MaxReportLength)
+			if !(len(reportWithInfo.Report) <= rb.Limits.MaxReportLength) {
+				return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned output exceeding limits: %w", &types.LimitExceededError{"report", reportCount, len(reportWithInfo.Report), rb.Limits.MaxReportLength})
+			}
+			reportCount++
+		}
+	}
+	if !(reportCount <= rb.Limits.MaxReportCount) {
+		return nil, fmt.Errorf("LimitCheckOCR3Plugin: underlying plugin returned output exceeding limits: %w", &types.LimitExceededError{"reportCount", -1, reportCount, rb.Limits.MaxReportCount})
+	}
+	return batches, nil
+}
+
+// LimitCheckOCR3OutcomeContextReporter is the analogue of
+// LimitCheckOCR3ReportingPlugin for ocr3types.OutcomeContextReporter.
+type LimitCheckOCR3OutcomeContextReporter[RI any] struct {
+	Reporter ocr3types.OutcomeContextReporter[RI]
+	Limits   ocr3types.ReportingPluginLimits
+	Metrics  *protocol.Metrics
+}
+
+var _ ocr3types.OutcomeContextReporter[struct{}] = LimitCheckOCR3OutcomeContextReporter[struct{}]{}
+
+func (r LimitCheckOCR3OutcomeContextReporter[RI]) ReportsWithOutcomeContext(ctx context.Context, outctx ocr3types.OutcomeContext, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	reports, err := r.Reporter.ReportsWithOutcomeContext(ctx, outctx, outcome)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReportsLimits(reports, r.Limits, r.Metrics); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// OutcomeContextReportingOCR3ReportingPlugin attaches an
+// OutcomeContextReporter to a plugin, for the same reason as
+// ReportBatchingOCR3ReportingPlugin.
+type OutcomeContextReportingOCR3ReportingPlugin[RI any] struct {
+	ocr3types.ReportingPluginV2[RI]
+	ocr3types.OutcomeContextReporter[RI]
+}
+
+// AttachOCR3ReportDecisionBatcher attaches a ReportDecisionBatcher to a
+// plugin, for the same reason as ReportBatchingOCR3ReportingPlugin. Unlike
+// wrapping the plugin in another struct, this keeps a ReportBatcher or
+// OutcomeContextReporter attached to the plugin visible, so call it last.
+func AttachOCR3ReportDecisionBatcher[RI any](rp ocr3types.ReportingPluginV2[RI], rb ocr3types.ReportDecisionBatcher[RI]) ocr3types.ReportingPluginV2[RI] {
+	switch rp := rp.(type) {
+	case ReportBatchingOCR3ReportingPlugin[RI]:
+		return reportDecisionBatchingReportBatchingOCR3ReportingPlugin[RI]{rp, rb}
+	case OutcomeContextReportingOCR3ReportingPlugin[RI]:
+		return reportDecisionBatchingOutcomeContextReportingOCR3ReportingPlugin[RI]{rp, rb}
+	default:
+		return reportDecisionBatchingOCR3ReportingPlugin[RI]{rp, rb}
+	}
+}
+
+type reportDecisionBatchingOCR3ReportingPlugin[RI any] struct {
+	ocr3types.ReportingPluginV2[RI]
+	ocr3types.ReportDecisionBatcher[RI]
+}
+
+type reportDecisionBatchingReportBatchingOCR3ReportingPlugin[RI any] struct {
+	ReportBatchingOCR3ReportingPlugin[RI]
+	ocr3types.ReportDecisionBatcher[RI]
+}
+
+type reportDecisionBatchingOutcomeContextReportingOCR3ReportingPlugin[RI any] struct {
+	OutcomeContextReportingOCR3ReportingPlugin[RI]
+	ocr3types.ReportDecisionBatcher[RI]
+}
+
+// ReportBatchingOCR3ReportingPlugin attaches a ReportBatcher to a plugin. The
+// protocol detects batching plugins by their ReportBatches method, which
+// wrappers like LimitCheckOCR3ReportingPlugin don't forward, so wrap the
+// outermost plugin in this.
+type ReportBatchingOCR3ReportingPlugin[RI any] struct {
+	ocr3types.ReportingPluginV2[RI]
+	ocr3types.ReportBatcher[RI]
+}
+
+// BatchingOCR3ContractTransmitter attaches a BatchContractTransmitter to a
+// transmitter. Like ReportBatchingOCR3ReportingPlugin, this exists because
+// wrappers like ChaosOCR3ContractTransmitter don't forward TransmitBatch, by
+// which the protocol detects batching transmitters.
+type BatchingOCR3ContractTransmitter[RI any] struct {
+	ocr3types.ContractTransmitter[RI]
+	ocr3types.BatchContractTransmitter[RI]
+}
+
+// PreviousOutcomeHashOnlyOCR3ReportingPlugin omits PreviousOutcome from the
+// OutcomeContexts passed to the wrapped plugin, see
+// ocr3types.ReportingPluginInfo.PreviousOutcomeHashOnly.
+type PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI any] struct {
+	Plugin ocr3types.ReportingPluginV2[RI]
+}
+
+var _ ocr3types.ReportingPluginV2[struct{}] = PreviousOutcomeHashOnlyOCR3ReportingPlugin[struct{}]{}
+
+func hashOnly(outctx ocr3types.OutcomeContext) ocr3types.OutcomeContext {
+	outctx.PreviousOutcome = nil
+	return outctx
+}
+
+func (rp PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI]) Query(ctx context.Context, outctx ocr3types.OutcomeContext) (types.Query, error) {
+	return rp.Plugin.Query(ctx, hashOnly(outctx))
+}
+
+func (rp PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI]) ObservationQuorum(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (ocr3types.Quorum, error) {
+	return rp.Plugin.ObservationQuorum(ctx, hashOnly(outctx), query)
+}
+
+func (rp PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI]) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
+	return rp.Plugin.Observation(ctx, hashOnly(outctx), query)
+}
+
+func (rp PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI]) ValidateObservation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, ao types.AttributedObservation) error {
+	return rp.Plugin.ValidateObservation(ctx, hashOnly(outctx), query, ao)
+}
+
+func (rp PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI]) Outcome(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, aos []types.AttributedObservation) (ocr3types.Outcome, error) {
+	return rp.Plugin.Outcome(ctx, hashOnly(outctx), query, aos)
+}
+
+func (rp PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI]) Reports(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	return rp.Plugin.Reports(ctx, seqNr, outcome)
+}
+
+func (rp PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI]) ShouldAcceptAttestedReport(ctx context.Context, seqNr uint64, report ocr3types.ReportWithInfo[RI]) (bool, error) {
+	return rp.Plugin.ShouldAcceptAttestedReport(ctx, seqNr, report)
+}
+
+func (rp PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI]) ShouldTransmitAcceptedReport(ctx context.Context, seqNr uint64, report ocr3types.ReportWithInfo[RI]) (bool, error) {
+	return rp.Plugin.ShouldTransmitAcceptedReport(ctx, seqNr, report)
+}
+
+func (rp PreviousOutcomeHashOnlyOCR3ReportingPlugin[RI]) Close() error {
+	return rp.Plugin.Close()
+}
+
+// PreviousOutcomeHashOnlyOCR3OutcomeContextReporter is the
+// ocr3types.OutcomeContextReporter counterpart of
+// PreviousOutcomeHashOnlyOCR3ReportingPlugin.
+type PreviousOutcomeHashOnlyOCR3OutcomeContextReporter[RI any] struct {
+	Reporter ocr3types.OutcomeContextReporter[RI]
+}
+
+var _ ocr3types.OutcomeContextReporter[struct{}] = PreviousOutcomeHashOnlyOCR3OutcomeContextReporter[struct{}]{}
+
+func (r PreviousOutcomeHashOnlyOCR3OutcomeContextReporter[RI]) ReportsWithOutcomeContext(ctx context.Context, outctx ocr3types.OutcomeContext, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	return r.Reporter.ReportsWithOutcomeContext(ctx, hashOnly(outctx), outcome)
+}
+
+// BlobOCR3ReportingPlugin makes BlobBroadcastFetcher available to the wrapped
+// plugin through the contexts passed to it, see
+// ocr3types.BlobBroadcastFetcherFromContext.
+type BlobOCR3ReportingPlugin[RI any] struct {
+	Plugin               ocr3types.ReportingPluginV2[RI]
+	BlobBroadcastFetcher ocr3types.BlobBroadcastFetcher
+}
+
+var _ ocr3types.ReportingPluginV2[struct{}] = BlobOCR3ReportingPlugin[struct{}]{}
+
+func (rp BlobOCR3ReportingPlugin[RI]) Query(ctx context.Context, outctx ocr3types.OutcomeContext) (types.Query, error) {
+	return rp.Plugin.Query(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rp.BlobBroadcastFetcher), outctx)
+}
+
+func (rp BlobOCR3ReportingPlugin[RI]) ObservationQuorum(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (ocr3types.Quorum, error) {
+	return rp.Plugin.ObservationQuorum(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rp.BlobBroadcastFetcher), outctx, query)
+}
+
+func (rp BlobOCR3ReportingPlugin[RI]) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
+	return rp.Plugin.Observation(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rp.BlobBroadcastFetcher), outctx, query)
+}
+
+func (rp BlobOCR3ReportingPlugin[RI]) ValidateObservation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, ao types.AttributedObservation) error {
+	return rp.Plugin.ValidateObservation(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rp.BlobBroadcastFetcher), outctx, query, ao)
+}
+
+func (rp BlobOCR3ReportingPlugin[RI]) Outcome(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, aos []types.AttributedObservation) (ocr3types.Outcome, error) {
+	return rp.Plugin.Outcome(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rp.BlobBroadcastFetcher), outctx, query, aos)
+}
+
+func (rp BlobOCR3ReportingPlugin[RI]) Reports(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	return rp.Plugin.Reports(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rp.BlobBroadcastFetcher), seqNr, outcome)
+}
+
+func (rp BlobOCR3ReportingPlugin[RI]) ShouldAcceptAttestedReport(ctx context.Context, seqNr uint64, report ocr3types.ReportWithInfo[RI]) (bool, error) {
+	return rp.Plugin.ShouldAcceptAttestedReport(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rp.BlobBroadcastFetcher), seqNr, report)
+}
+
+func (rp BlobOCR3ReportingPlugin[RI]) ShouldTransmitAcceptedReport(ctx context.Context, seqNr uint64, report ocr3types.ReportWithInfo[RI]) (bool, error) {
+	return rp.Plugin.ShouldTransmitAcceptedReport(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rp.BlobBroadcastFetcher), seqNr, report)
+}
+
+func (rp BlobOCR3ReportingPlugin[RI]) Close() error {
+	return rp.Plugin.Close()
+}
+
+// BlobOCR3ReportBatcher is the ocr3types.ReportBatcher counterpart of
+// BlobOCR3ReportingPlugin.
+type BlobOCR3ReportBatcher[RI any] struct {
+	Batcher              ocr3types.ReportBatcher[RI]
+	BlobBroadcastFetcher ocr3types.BlobBroadcastFetcher
+}
+
+var _ ocr3types.ReportBatcher[struct{}] = BlobOCR3ReportBatcher[struct{}]{}
+
+func (rb BlobOCR3ReportBatcher[RI]) ReportBatches(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([][]ocr3types.ReportWithInfo[RI], error) {
+	return rb.Batcher.ReportBatches(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rb.BlobBroadcastFetcher), seqNr, outcome)
+}
+
+// BlobOCR3OutcomeContextReporter is the ocr3types.OutcomeContextReporter
+// counterpart of BlobOCR3ReportingPlugin.
+type BlobOCR3OutcomeContextReporter[RI any] struct {
+	Reporter             ocr3types.OutcomeContextReporter[RI]
+	BlobBroadcastFetcher ocr3types.BlobBroadcastFetcher
+}
+
+var _ ocr3types.OutcomeContextReporter[struct{}] = BlobOCR3OutcomeContextReporter[struct{}]{}
+
+func (r BlobOCR3OutcomeContextReporter[RI]) ReportsWithOutcomeContext(ctx context.Context, outctx ocr3types.OutcomeContext, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	return r.Reporter.ReportsWithOutcomeContext(ocr3types.ContextWithBlobBroadcastFetcher(ctx, r.BlobBroadcastFetcher), outctx, outcome)
+}
+
+// BlobOCR3ReportDecisionBatcher is the ocr3types.ReportDecisionBatcher
+// counterpart of BlobOCR3ReportingPlugin.
+type BlobOCR3ReportDecisionBatcher[RI any] struct {
+	Batcher              ocr3types.ReportDecisionBatcher[RI]
+	BlobBroadcastFetcher ocr3types.BlobBroadcastFetcher
+}
+
+var _ ocr3types.ReportDecisionBatcher[struct{}] = BlobOCR3ReportDecisionBatcher[struct{}]{}
+
+func (rb BlobOCR3ReportDecisionBatcher[RI]) ShouldAcceptAttestedReports(ctx context.Context, seqNr uint64, reports []ocr3types.ReportWithInfo[RI]) ([]bool, error) {
+	return rb.Batcher.ShouldAcceptAttestedReports(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rb.BlobBroadcastFetcher), seqNr, reports)
+}
+
+func (rb BlobOCR3ReportDecisionBatcher[RI]) ShouldTransmitAcceptedReports(ctx context.Context, seqNr uint64, reports []ocr3types.ReportWithInfo[RI]) ([]bool, error) {
+	return rb.Batcher.ShouldTransmitAcceptedReports(ocr3types.ContextWithBlobBroadcastFetcher(ctx, rb.BlobBroadcastFetcher), seqNr, reports)
+}
@@ -16,13 +16,22 @@ import (
 )
 
 type OCR3SerializingEndpoint[RI any] struct {
-	chTelemetry  chan<- *serialization.TelemetryWrapper
-	configDigest types.ConfigDigest
-	endpoint     commontypes.BinaryNetworkEndpoint
-	maxSigLen    int
-	logger       commontypes.Logger
-	pluginLimits ocr3types.ReportingPluginLimits
-	n, f         int
+	telemetryQueue *TelemetryQueue[*serialization.TelemetryWrapper]
+	configDigest   types.ConfigDigest
+	endpoint       commontypes.BinaryNetworkEndpoint
+	maxSigLen      int
+	logger         commontypes.Logger
+	metrics        *protocol.Metrics
+	pluginLimits   ocr3types.ReportingPluginLimits
+	n, f           int
+
+	chunkedMutex sync.Mutex
+	chunked      *chunkedTransfer // nil if chunked transfer is disabled
+
+	// only accessed by the receive loop and Close
+	inbound *OCR3InboundFilter
+
+	archive *messageArchive // nil if there is no MessageArchiver
 
 	mutex        sync.Mutex
 	subprocesses subprocesses.Subprocesses
@@ -37,23 +46,37 @@ type OCR3SerializingEndpoint[RI any] struct {
 var _ protocol.NetworkEndpoint[struct{}] = (*OCR3SerializingEndpoint[struct{}])(nil)
 
 func NewOCR3SerializingEndpoint[RI any](
-	chTelemetry chan<- *serialization.TelemetryWrapper,
+	telemetryQueue *TelemetryQueue[*serialization.TelemetryWrapper],
 	configDigest types.ConfigDigest,
 	endpoint commontypes.BinaryNetworkEndpoint,
 	maxSigLen int,
 	logger commontypes.Logger,
+	metrics *protocol.Metrics,
 	pluginLimits ocr3types.ReportingPluginLimits,
+	chunkedTransferConfig ocr3types.ChunkedTransferConfig,
+	deduplicationConfig types.MessageDeduplicationConfig,
+	messageArchiver types.MessageArchiver,
+	messageArchivingConfig types.MessageArchivingConfig,
+	maxMessageLength int,
 	n, f int,
 ) *OCR3SerializingEndpoint[RI] {
 	return &OCR3SerializingEndpoint[RI]{
-		chTelemetry,
+		telemetryQueue,
 		configDigest,
 		endpoint,
 		maxSigLen,
 		logger,
+		metrics,
 		pluginLimits,
 		n, f,
 
+		sync.Mutex{},
+		newChunkedTransfer(chunkedTransferConfig, maxMessageLength, n),
+
+		NewOCR3InboundFilter(logger, metrics, chunkedTransferConfig, deduplicationConfig, maxMessageLength, n),
+
+		newMessageArchive(messageArchiver, messageArchivingConfig, configDigest, logger, metrics),
+
 		sync.Mutex{},
 		subprocesses.Subprocesses{},
 		false,
@@ -66,14 +89,13 @@ func NewOCR3SerializingEndpoint[RI any](
 }
 
 func (n *OCR3SerializingEndpoint[RI]) sendTelemetry(t *serialization.TelemetryWrapper) {
-	select {
-	case n.chTelemetry <- t:
+	if n.telemetryQueue.TrySend(t) {
 		n.taper.Reset(func(oldCount uint64) {
 			n.logger.Info("OCR3SerializingEndpoint: stopped dropping telemetry", commontypes.LogFields{
 				"droppedCount": oldCount,
 			})
 		})
-	default:
+	} else {
 		n.taper.Trigger(func(newCount uint64) {
 			n.logger.Warn("OCR3SerializingEndpoint: dropping telemetry", commontypes.LogFields{
 				"droppedCount": newCount,
@@ -87,29 +109,32 @@ func (n *OCR3SerializingEndpoint[RI]) serialize(msg protocol.Message[RI]) ([]byt
 		n.logger.Error("OCR3SerializingEndpoint: Dropping outgoing message because it fails size check", commontypes.LogFields{
 			"limits": n.pluginLimits,
 		})
+		n.metrics.IncMessagesDropped(protocol.MessageType(msg), protocol.MessageDropReasonSize)
 		return nil, nil
 	}
-	sMsg, pbm, err := serialization.Serialize(msg)
+	sMsg, pbm, err := serialization.Serialize(msg, time.Now())
 	if err != nil {
 		n.logger.Error("OCR3SerializingEndpoint: Failed to serialize", commontypes.LogFields{
 			"message": msg,
 		})
+		n.metrics.IncMessagesDropped(protocol.MessageType(msg), protocol.MessageDropReasonSerialization)
 		return nil, nil
 	}
+	n.metrics.ObserveMessageSize(protocol.MessageType(msg), protocol.MessageDirectionSent, len(sMsg))
 	return sMsg, pbm
 }
 
-func (n *OCR3SerializingEndpoint[RI]) deserialize(raw []byte) (protocol.Message[RI], *serialization.MessageWrapper, error) {
-	m, pbm, err := serialization.Deserialize[RI](raw)
+func (n *OCR3SerializingEndpoint[RI]) deserialize(raw []byte) (protocol.Message[RI], *serialization.MessageWrapper, time.Time, error) {
+	m, pbm, sentTime, err := serialization.Deserialize[RI](raw)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, time.Time{}, err
 	}
 
 	if !m.CheckSize(n.n, n.f, n.pluginLimits, n.maxSigLen) {
-		return nil, nil, fmt.Errorf("message failed size check")
+		return nil, nil, time.Time{}, fmt.Errorf("message failed size check")
 	}
 
-	return m, pbm, nil
+	return m, pbm, sentTime, nil
 }
 
 // Start starts the SerializingEndpoint. It will also start the underlying endpoint.
@@ -126,6 +151,24 @@ func (n *OCR3SerializingEndpoint[RI]) Start() error {
 		return fmt.Errorf("error while starting OCR3SerializingEndpoint: %w", err)
 	}
 
+	if n.chunked != nil {
+		for i, sender := range n.chunked.senders {
+			oid := commontypes.OracleID(i)
+			sender := sender
+			n.subprocesses.Go(func() {
+				sender.run(n.chCancel, func(chunk []byte) {
+					n.endpoint.SendTo(chunk, oid)
+				})
+			})
+		}
+	}
+
+	if n.archive != nil {
+		n.subprocesses.Go(func() {
+			n.archive.run(n.chCancel)
+		})
+	}
+
 	n.subprocesses.Go(func() {
 		chRaw := n.endpoint.Receive()
 		for {
@@ -139,7 +182,16 @@ func (n *OCR3SerializingEndpoint[RI]) Start() error {
 					return
 				}
 
-				m, pbm, err := n.deserialize(raw.Msg)
+				serializedMsg, err := n.inbound.Filter(raw.Sender, raw.Msg)
+				if err != nil || serializedMsg == nil {
+					// dropped or incomplete, the filter has already logged
+					// and counted drops
+					break
+				}
+
+				n.archive.archiveInbound(serializedMsg, raw.Sender)
+
+				m, pbm, sentTime, err := n.deserialize(serializedMsg)
 				if err != nil {
 					n.logger.Error("OCR3SerializingEndpoint: Failed to deserialize", commontypes.LogFields{
 						"message": raw,
@@ -148,14 +200,17 @@ func (n *OCR3SerializingEndpoint[RI]) Start() error {
 						Wrapped: &serialization.TelemetryWrapper_AssertionViolation{&serialization.TelemetryAssertionViolation{
 							Violation: &serialization.TelemetryAssertionViolation_InvalidSerialization{&serialization.TelemetryAssertionViolationInvalidSerialization{
 								ConfigDigest:  n.configDigest[:],
-								SerializedMsg: raw.Msg,
+								SerializedMsg: serializedMsg,
 								Sender:        uint32(raw.Sender),
 							}},
 						}},
 						UnixTimeNanoseconds: time.Now().UnixNano(),
 					})
+					n.metrics.IncMessagesDropped(protocol.UnknownMessageType, protocol.MessageDropReasonSerialization)
 					break
 				}
+				n.metrics.IncMessagesReceived(protocol.MessageType(m))
+				n.metrics.ObserveMessageSize(protocol.MessageType(m), protocol.MessageDirectionReceived, len(serializedMsg))
 
 				n.sendTelemetry(&serialization.TelemetryWrapper{
 					Wrapped: &serialization.TelemetryWrapper_MessageReceived{&serialization.TelemetryMessageReceived{
@@ -167,7 +222,7 @@ func (n *OCR3SerializingEndpoint[RI]) Start() error {
 				})
 
 				select {
-				case n.chOut <- protocol.MessageWithSender[RI]{m, raw.Sender}:
+				case n.chOut <- protocol.MessageWithSender[RI]{m, raw.Sender, sentTime}:
 				case <-n.chCancel:
 					return
 				}
@@ -190,6 +245,8 @@ func (n *OCR3SerializingEndpoint[RI]) Close() error {
 		close(n.chCancel)
 		n.subprocesses.Wait()
 
+		n.inbound.Close()
+
 		if !n.closedChOut {
 			n.closedChOut = true
 			close(n.chOut)
@@ -201,10 +258,33 @@ func (n *OCR3SerializingEndpoint[RI]) Close() error {
 	return nil
 }
 
+// sendChunked returns true iff sMsg is sent in chunks, which is the case if
+// chunked transfer is enabled and sMsg is longer than a chunk.
+func (n *OCR3SerializingEndpoint[RI]) sendChunked(msg protocol.Message[RI], sMsg []byte, to []commontypes.OracleID) bool {
+	if n.chunked == nil || len(sMsg) <= n.chunked.config.ChunkSize {
+		return false
+	}
+	n.chunkedMutex.Lock()
+	ok := n.chunked.trySend(sMsg, to)
+	n.chunkedMutex.Unlock()
+	if !ok {
+		n.logger.Error("OCR3SerializingEndpoint: Dropping outgoing chunked message for some receivers because their backlog is full", commontypes.LogFields{
+			"messageLength": len(sMsg),
+			"receivers":     to,
+		})
+		n.metrics.IncMessagesDropped(protocol.MessageType(msg), protocol.MessageDropReasonBacklog)
+	}
+	return true
+}
+
 func (n *OCR3SerializingEndpoint[RI]) SendTo(msg protocol.Message[RI], to commontypes.OracleID) {
 	sMsg, pbm := n.serialize(msg)
 	if sMsg != nil {
-		n.endpoint.SendTo(sMsg, to)
+		if !n.sendChunked(msg, sMsg, []commontypes.OracleID{to}) {
+			n.endpoint.SendTo(sMsg, to)
+		}
+		n.archive.archiveOutbound(sMsg, []commontypes.OracleID{to})
+		n.metrics.IncMessagesSent(protocol.MessageType(msg))
 		n.sendTelemetry(&serialization.TelemetryWrapper{
 			Wrapped: &serialization.TelemetryWrapper_MessageSent{&serialization.TelemetryMessageSent{
 				ConfigDigest:  n.configDigest[:],
@@ -217,10 +297,48 @@ func (n *OCR3SerializingEndpoint[RI]) SendTo(msg protocol.Message[RI], to common
 	}
 }
 
+// Multicast serializes msg once and sends the same bytes to every oracle in
+// "to". Chunked messages are also split only once, see chunkedTransfer.trySend.
+func (n *OCR3SerializingEndpoint[RI]) Multicast(msg protocol.Message[RI], to []commontypes.OracleID) {
+	if len(to) == 0 {
+		return
+	}
+	sMsg, pbm := n.serialize(msg)
+	if sMsg != nil {
+		if !n.sendChunked(msg, sMsg, to) {
+			for _, oid := range to {
+				n.endpoint.SendTo(sMsg, oid)
+			}
+		}
+		n.archive.archiveOutbound(sMsg, append([]commontypes.OracleID(nil), to...))
+		now := time.Now().UnixNano()
+		for _, oid := range to {
+			n.metrics.IncMessagesSent(protocol.MessageType(msg))
+			n.sendTelemetry(&serialization.TelemetryWrapper{
+				Wrapped: &serialization.TelemetryWrapper_MessageSent{&serialization.TelemetryMessageSent{
+					ConfigDigest:  n.configDigest[:],
+					Msg:           pbm,
+					SerializedMsg: sMsg,
+					Receiver:      uint32(oid),
+				}},
+				UnixTimeNanoseconds: now,
+			})
+		}
+	}
+}
+
 func (n *OCR3SerializingEndpoint[RI]) Broadcast(msg protocol.Message[RI]) {
 	sMsg, pbm := n.serialize(msg)
 	if sMsg != nil {
-		n.endpoint.Broadcast(sMsg)
+		all := make([]commontypes.OracleID, 0, n.n)
+		for i := 0; i < n.n; i++ {
+			all = append(all, commontypes.OracleID(i))
+		}
+		if !n.sendChunked(msg, sMsg, all) {
+			n.endpoint.Broadcast(sMsg)
+		}
+		n.archive.archiveOutbound(sMsg, all)
+		n.metrics.IncMessagesSent(protocol.MessageType(msg))
 		n.sendTelemetry(&serialization.TelemetryWrapper{
 			Wrapped: &serialization.TelemetryWrapper_MessageBroadcast{&serialization.TelemetryMessageBroadcast{
 				ConfigDigest:  n.configDigest[:],
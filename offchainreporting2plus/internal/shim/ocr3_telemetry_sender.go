@@ -5,29 +5,29 @@ import (
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/serialization"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
 
 type OCR3TelemetrySender struct {
-	chTelemetry chan<- *serialization.TelemetryWrapper
-	logger      commontypes.Logger
-	taper       loghelper.LogarithmicTaper
+	telemetryQueue *TelemetryQueue[*serialization.TelemetryWrapper]
+	logger         commontypes.Logger
+	taper          loghelper.LogarithmicTaper
 }
 
-func MakeOCR3TelemetrySender(chTelemetry chan<- *serialization.TelemetryWrapper, logger commontypes.Logger) OCR3TelemetrySender {
-	return OCR3TelemetrySender{chTelemetry, logger, loghelper.LogarithmicTaper{}}
+func MakeOCR3TelemetrySender(telemetryQueue *TelemetryQueue[*serialization.TelemetryWrapper], logger commontypes.Logger) OCR3TelemetrySender {
+	return OCR3TelemetrySender{telemetryQueue, logger, loghelper.LogarithmicTaper{}}
 }
 
 func (ts OCR3TelemetrySender) send(t *serialization.TelemetryWrapper) {
-	select {
-	case ts.chTelemetry <- t:
+	if ts.telemetryQueue.TrySend(t) {
 		ts.taper.Reset(func(oldCount uint64) {
 			ts.logger.Info("OCR3TelemetrySender: stopped dropping telemetry", commontypes.LogFields{
 				"droppedCount": oldCount,
 			})
 		})
-	default:
+	} else {
 		ts.taper.Trigger(func(newCount uint64) {
 			ts.logger.Warn("OCR3TelemetrySender: dropping telemetry", commontypes.LogFields{
 				"droppedCount": newCount,
@@ -55,3 +55,151 @@ func (ts OCR3TelemetrySender) RoundStarted(
 		UnixTimeNanoseconds: time.Now().UnixNano(),
 	})
 }
+
+// Heartbeat sends a heartbeat created with heartbeat.Sign.
+func (ts OCR3TelemetrySender) Heartbeat(payload []byte, signature []byte) {
+	ts.send(&serialization.TelemetryWrapper{
+		Wrapped: &serialization.TelemetryWrapper_Heartbeat{&serialization.TelemetryHeartbeat{
+			Payload:   payload,
+			Signature: signature,
+		}},
+		UnixTimeNanoseconds: time.Now().UnixNano(),
+	})
+}
+
+func (ts OCR3TelemetrySender) ProtocolError(
+	configDigest types.ConfigDigest,
+	epoch uint64,
+	seqNr uint64,
+	code protocol.ProtocolErrorCode,
+) {
+	ts.send(&serialization.TelemetryWrapper{
+		Wrapped: &serialization.TelemetryWrapper_ProtocolError{&serialization.TelemetryProtocolError{
+			ConfigDigest: configDigest[:],
+			Epoch:        epoch,
+			SeqNr:        seqNr,
+			Code:         serialization.ProtocolErrorCode(code),
+		}},
+		UnixTimeNanoseconds: time.Now().UnixNano(),
+	})
+}
+
+func (ts OCR3TelemetrySender) OutcomeComputed(
+	configDigest types.ConfigDigest,
+	epoch uint64,
+	seqNr uint64,
+	round uint64,
+	leader commontypes.OracleID,
+	includedObservers []commontypes.OracleID,
+	excludedObservers []commontypes.OracleID,
+	outcomeDigest protocol.OutcomeDigest,
+) {
+	ts.send(&serialization.TelemetryWrapper{
+		Wrapped: &serialization.TelemetryWrapper_OutcomeComputed{&serialization.TelemetryOutcomeComputed{
+			ConfigDigest:      configDigest[:],
+			Epoch:             epoch,
+			Round:             round,
+			SeqNr:             seqNr,
+			Leader:            uint32(leader),
+			IncludedObservers: oracleIDsToUint32s(includedObservers),
+			ExcludedObservers: oracleIDsToUint32s(excludedObservers),
+			OutcomeDigest:     outcomeDigest[:],
+		}},
+		UnixTimeNanoseconds: time.Now().UnixNano(),
+	})
+}
+
+func (ts OCR3TelemetrySender) OutcomeCommitted(
+	configDigest types.ConfigDigest,
+	commitEpoch uint64,
+	seqNr uint64,
+	outcomeDigest protocol.OutcomeDigest,
+) {
+	ts.send(&serialization.TelemetryWrapper{
+		Wrapped: &serialization.TelemetryWrapper_OutcomeCommitted{&serialization.TelemetryOutcomeCommitted{
+			ConfigDigest:  configDigest[:],
+			Epoch:         commitEpoch,
+			SeqNr:         seqNr,
+			OutcomeDigest: outcomeDigest[:],
+		}},
+		UnixTimeNanoseconds: time.Now().UnixNano(),
+	})
+}
+
+func (ts OCR3TelemetrySender) ReportAttested(
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	index int,
+	signers []commontypes.OracleID,
+) {
+	ts.send(&serialization.TelemetryWrapper{
+		Wrapped: &serialization.TelemetryWrapper_ReportAttested{&serialization.TelemetryReportAttested{
+			ConfigDigest: configDigest[:],
+			SeqNr:        seqNr,
+			ReportIndex:  uint64(index),
+			Signers:      oracleIDsToUint32s(signers),
+		}},
+		UnixTimeNanoseconds: time.Now().UnixNano(),
+	})
+}
+
+func (ts OCR3TelemetrySender) TransmissionDecided(
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	index int,
+	decision protocol.TransmissionDecision,
+) {
+	ts.send(&serialization.TelemetryWrapper{
+		Wrapped: &serialization.TelemetryWrapper_TransmissionDecision{&serialization.TelemetryTransmissionDecision{
+			ConfigDigest: configDigest[:],
+			SeqNr:        seqNr,
+			ReportIndex:  uint64(index),
+			Decision:     serialization.TransmissionDecision(decision),
+		}},
+		UnixTimeNanoseconds: time.Now().UnixNano(),
+	})
+}
+
+func (ts OCR3TelemetrySender) DegradedModeChanged(
+	configDigest types.ConfigDigest,
+	degraded bool,
+	reachableOracles int,
+) {
+	ts.send(&serialization.TelemetryWrapper{
+		Wrapped: &serialization.TelemetryWrapper_DegradedModeChanged{&serialization.TelemetryDegradedModeChanged{
+			ConfigDigest:     configDigest[:],
+			Degraded:         degraded,
+			ReachableOracles: uint32(reachableOracles),
+		}},
+		UnixTimeNanoseconds: time.Now().UnixNano(),
+	})
+}
+
+func (ts OCR3TelemetrySender) CorruptedStateQuarantined(
+	configDigest types.ConfigDigest,
+	key string,
+) {
+	ts.send(&serialization.TelemetryWrapper{
+		Wrapped: &serialization.TelemetryWrapper_CorruptedStateQuarantined{&serialization.TelemetryCorruptedStateQuarantined{
+			ConfigDigest: configDigest[:],
+			Key:          key,
+		}},
+		UnixTimeNanoseconds: time.Now().UnixNano(),
+	})
+}
+
+func (ts OCR3TelemetrySender) TimerExpired(
+	types.ConfigDigest,
+	uint64,
+	uint64,
+	protocol.Timer,
+) {
+}
+
+func oracleIDsToUint32s(oracleIDs []commontypes.OracleID) []uint32 {
+	result := make([]uint32, 0, len(oracleIDs))
+	for _, oracleID := range oracleIDs {
+		result = append(result, uint32(oracleID))
+	}
+	return result
+}
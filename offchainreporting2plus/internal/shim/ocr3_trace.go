@@ -0,0 +1,493 @@
+package shim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/serialization"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3trace"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+func traceErrorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// RecordingOCR3ReportingPlugin records every call of the wrapped plugin with
+// its arguments and return values.
+type RecordingOCR3ReportingPlugin[RI any] struct {
+	Plugin   ocr3types.ReportingPluginV2[RI]
+	Recorder *ocr3trace.Recorder
+}
+
+var _ ocr3types.ReportingPluginV2[struct{}] = RecordingOCR3ReportingPlugin[struct{}]{}
+
+func (rp RecordingOCR3ReportingPlugin[RI]) Query(ctx context.Context, outctx ocr3types.OutcomeContext) (types.Query, error) {
+	query, err := rp.Plugin.Query(ctx, outctx)
+	rp.Recorder.Record(ocr3trace.Entry{
+		Kind:           ocr3trace.EntryKindQuery,
+		OutcomeContext: &outctx,
+		Query:          query,
+		Error:          traceErrorString(err),
+	})
+	return query, err
+}
+
+func (rp RecordingOCR3ReportingPlugin[RI]) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
+	observation, err := rp.Plugin.Observation(ctx, outctx, query)
+	rp.Recorder.Record(ocr3trace.Entry{
+		Kind:           ocr3trace.EntryKindObservation,
+		OutcomeContext: &outctx,
+		Query:          query,
+		Observation:    observation,
+		Error:          traceErrorString(err),
+	})
+	return observation, err
+}
+
+func (rp RecordingOCR3ReportingPlugin[RI]) ValidateObservation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, ao types.AttributedObservation) error {
+	err := rp.Plugin.ValidateObservation(ctx, outctx, query, ao)
+	rp.Recorder.Record(ocr3trace.Entry{
+		Kind:                  ocr3trace.EntryKindValidateObservation,
+		OutcomeContext:        &outctx,
+		Query:                 query,
+		AttributedObservation: &ao,
+		Error:                 traceErrorString(err),
+	})
+	return err
+}
+
+func (rp RecordingOCR3ReportingPlugin[RI]) ObservationQuorum(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (ocr3types.Quorum, error) {
+	quorum, err := rp.Plugin.ObservationQuorum(ctx, outctx, query)
+	entry := ocr3trace.Entry{
+		Kind:           ocr3trace.EntryKindObservationQuorum,
+		OutcomeContext: &outctx,
+		Query:          query,
+		Error:          traceErrorString(err),
+	}
+	if err == nil {
+		entry.Quorum = &quorum
+	}
+	rp.Recorder.Record(entry)
+	return quorum, err
+}
+
+func (rp RecordingOCR3ReportingPlugin[RI]) Outcome(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, aos []types.AttributedObservation) (ocr3types.Outcome, error) {
+	outcome, err := rp.Plugin.Outcome(ctx, outctx, query, aos)
+	rp.Recorder.Record(ocr3trace.Entry{
+		Kind:                   ocr3trace.EntryKindOutcome,
+		OutcomeContext:         &outctx,
+		Query:                  query,
+		AttributedObservations: aos,
+		Outcome:                outcome,
+		Error:                  traceErrorString(err),
+	})
+	return outcome, err
+}
+
+func (rp RecordingOCR3ReportingPlugin[RI]) Reports(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	reports, err := rp.Plugin.Reports(ctx, seqNr, outcome)
+	entry := ocr3trace.Entry{
+		Kind:    ocr3trace.EntryKindReports,
+		SeqNr:   seqNr,
+		Outcome: outcome,
+		Error:   traceErrorString(err),
+	}
+	for _, rwi := range reports {
+		entry.Reports = append(entry.Reports, ocr3trace.MakeReportEntry(rwi))
+	}
+	rp.Recorder.Record(entry)
+	return reports, err
+}
+
+func (rp RecordingOCR3ReportingPlugin[RI]) ShouldAcceptAttestedReport(ctx context.Context, seqNr uint64, rwi ocr3types.ReportWithInfo[RI]) (bool, error) {
+	accept, err := rp.Plugin.ShouldAcceptAttestedReport(ctx, seqNr, rwi)
+	report := ocr3trace.MakeReportEntry(rwi)
+	rp.Recorder.Record(ocr3trace.Entry{
+		Kind:   ocr3trace.EntryKindShouldAcceptAttestedReport,
+		SeqNr:  seqNr,
+		Report: &report,
+		Result: &accept,
+		Error:  traceErrorString(err),
+	})
+	return accept, err
+}
+
+func (rp RecordingOCR3ReportingPlugin[RI]) ShouldTransmitAcceptedReport(ctx context.Context, seqNr uint64, rwi ocr3types.ReportWithInfo[RI]) (bool, error) {
+	transmit, err := rp.Plugin.ShouldTransmitAcceptedReport(ctx, seqNr, rwi)
+	report := ocr3trace.MakeReportEntry(rwi)
+	rp.Recorder.Record(ocr3trace.Entry{
+		Kind:   ocr3trace.EntryKindShouldTransmitAcceptedReport,
+		SeqNr:  seqNr,
+		Report: &report,
+		Result: &transmit,
+		Error:  traceErrorString(err),
+	})
+	return transmit, err
+}
+
+func (rp RecordingOCR3ReportingPlugin[RI]) Close() error {
+	return rp.Plugin.Close()
+}
+
+// RecordingOCR3ReportBatcher records every ReportBatches call of the wrapped
+// ReportBatcher, like RecordingOCR3ReportingPlugin does for Reports.
+type RecordingOCR3ReportBatcher[RI any] struct {
+	ReportBatcher ocr3types.ReportBatcher[RI]
+	Recorder      *ocr3trace.Recorder
+}
+
+var _ ocr3types.ReportBatcher[struct{}] = RecordingOCR3ReportBatcher[struct{}]{}
+
+func (rb RecordingOCR3ReportBatcher[RI]) ReportBatches(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([][]ocr3types.ReportWithInfo[RI], error) {
+	batches, err := rb.ReportBatcher.ReportBatches(ctx, seqNr, outcome)
+	rb.Recorder.Record(ocr3trace.Entry{
+		Kind:          ocr3trace.EntryKindReportBatches,
+		SeqNr:         seqNr,
+		Outcome:       outcome,
+		ReportBatches: ocr3trace.MakeReportBatchEntries(batches),
+		Error:         traceErrorString(err),
+	})
+	return batches, err
+}
+
+// RecordingOCR3OutcomeContextReporter records every ReportsWithOutcomeContext
+// call of the wrapped OutcomeContextReporter, like
+// RecordingOCR3ReportingPlugin does for Reports.
+type RecordingOCR3OutcomeContextReporter[RI any] struct {
+	OutcomeContextReporter ocr3types.OutcomeContextReporter[RI]
+	Recorder               *ocr3trace.Recorder
+}
+
+var _ ocr3types.OutcomeContextReporter[struct{}] = RecordingOCR3OutcomeContextReporter[struct{}]{}
+
+func (ocr RecordingOCR3OutcomeContextReporter[RI]) ReportsWithOutcomeContext(ctx context.Context, outctx ocr3types.OutcomeContext, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	reports, err := ocr.OutcomeContextReporter.ReportsWithOutcomeContext(ctx, outctx, outcome)
+	entry := ocr3trace.Entry{
+		Kind:           ocr3trace.EntryKindReportsWithOutcomeContext,
+		OutcomeContext: &outctx,
+		Outcome:        outcome,
+		Error:          traceErrorString(err),
+	}
+	for _, rwi := range reports {
+		entry.Reports = append(entry.Reports, ocr3trace.MakeReportEntry(rwi))
+	}
+	ocr.Recorder.Record(entry)
+	return reports, err
+}
+
+// RecordingOCR3ReportDecisionBatcher records the decisions of the wrapped
+// ReportDecisionBatcher one report at a time, as if they had been made by
+// ShouldAcceptAttestedReport and ShouldTransmitAcceptedReport.
+type RecordingOCR3ReportDecisionBatcher[RI any] struct {
+	ReportDecisionBatcher ocr3types.ReportDecisionBatcher[RI]
+	Recorder              *ocr3trace.Recorder
+}
+
+var _ ocr3types.ReportDecisionBatcher[struct{}] = RecordingOCR3ReportDecisionBatcher[struct{}]{}
+
+func (rdb RecordingOCR3ReportDecisionBatcher[RI]) record(kind ocr3trace.EntryKind, seqNr uint64, reports []ocr3types.ReportWithInfo[RI], decisions []bool, err error) {
+	for i, rwi := range reports {
+		report := ocr3trace.MakeReportEntry(rwi)
+		entry := ocr3trace.Entry{
+			Kind:   kind,
+			SeqNr:  seqNr,
+			Report: &report,
+			Error:  traceErrorString(err),
+		}
+		if i < len(decisions) {
+			decision := decisions[i]
+			entry.Result = &decision
+		}
+		rdb.Recorder.Record(entry)
+	}
+}
+
+func (rdb RecordingOCR3ReportDecisionBatcher[RI]) ShouldAcceptAttestedReports(ctx context.Context, seqNr uint64, reports []ocr3types.ReportWithInfo[RI]) ([]bool, error) {
+	accepts, err := rdb.ReportDecisionBatcher.ShouldAcceptAttestedReports(ctx, seqNr, reports)
+	rdb.record(ocr3trace.EntryKindShouldAcceptAttestedReport, seqNr, reports, accepts, err)
+	return accepts, err
+}
+
+func (rdb RecordingOCR3ReportDecisionBatcher[RI]) ShouldTransmitAcceptedReports(ctx context.Context, seqNr uint64, reports []ocr3types.ReportWithInfo[RI]) ([]bool, error) {
+	transmits, err := rdb.ReportDecisionBatcher.ShouldTransmitAcceptedReports(ctx, seqNr, reports)
+	rdb.record(ocr3trace.EntryKindShouldTransmitAcceptedReport, seqNr, reports, transmits, err)
+	return transmits, err
+}
+
+// RecordingOCR3TelemetrySender passes all telemetry on to the wrapped
+// TelemetrySender and additionally records it as protocol events.
+type RecordingOCR3TelemetrySender struct {
+	protocol.TelemetrySender
+	Recorder *ocr3trace.Recorder
+}
+
+var _ protocol.TelemetrySender = RecordingOCR3TelemetrySender{}
+
+func (ts RecordingOCR3TelemetrySender) record(event string, fields commontypes.LogFields) {
+	ts.Recorder.Record(ocr3trace.Entry{
+		Kind:   ocr3trace.EntryKindEvent,
+		Event:  event,
+		Fields: fields,
+	})
+}
+
+func (ts RecordingOCR3TelemetrySender) RoundStarted(
+	configDigest types.ConfigDigest,
+	epoch uint64,
+	seqNr uint64,
+	round uint64,
+	leader commontypes.OracleID,
+) {
+	ts.record("RoundStarted", commontypes.LogFields{
+		"epoch":  epoch,
+		"seqNr":  seqNr,
+		"round":  round,
+		"leader": leader,
+	})
+	ts.TelemetrySender.RoundStarted(configDigest, epoch, seqNr, round, leader)
+}
+
+func (ts RecordingOCR3TelemetrySender) ProtocolError(
+	configDigest types.ConfigDigest,
+	epoch uint64,
+	seqNr uint64,
+	code protocol.ProtocolErrorCode,
+) {
+	ts.record("ProtocolError", commontypes.LogFields{
+		"epoch": epoch,
+		"seqNr": seqNr,
+		"code":  code.String(),
+	})
+	ts.TelemetrySender.ProtocolError(configDigest, epoch, seqNr, code)
+}
+
+func (ts RecordingOCR3TelemetrySender) OutcomeComputed(
+	configDigest types.ConfigDigest,
+	epoch uint64,
+	seqNr uint64,
+	round uint64,
+	leader commontypes.OracleID,
+	includedObservers []commontypes.OracleID,
+	excludedObservers []commontypes.OracleID,
+	outcomeDigest protocol.OutcomeDigest,
+) {
+	ts.record("OutcomeComputed", commontypes.LogFields{
+		"epoch":             epoch,
+		"seqNr":             seqNr,
+		"round":             round,
+		"leader":            leader,
+		"includedObservers": includedObservers,
+		"excludedObservers": excludedObservers,
+		"outcomeDigest":     fmt.Sprintf("%x", outcomeDigest),
+	})
+	ts.TelemetrySender.OutcomeComputed(configDigest, epoch, seqNr, round, leader, includedObservers, excludedObservers, outcomeDigest)
+}
+
+func (ts RecordingOCR3TelemetrySender) OutcomeCommitted(
+	configDigest types.ConfigDigest,
+	commitEpoch uint64,
+	seqNr uint64,
+	outcomeDigest protocol.OutcomeDigest,
+) {
+	ts.record("OutcomeCommitted", commontypes.LogFields{
+		"commitEpoch":   commitEpoch,
+		"seqNr":         seqNr,
+		"outcomeDigest": fmt.Sprintf("%x", outcomeDigest),
+	})
+	ts.TelemetrySender.OutcomeCommitted(configDigest, commitEpoch, seqNr, outcomeDigest)
+}
+
+func (ts RecordingOCR3TelemetrySender) ReportAttested(
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	index int,
+	signers []commontypes.OracleID,
+) {
+	ts.record("ReportAttested", commontypes.LogFields{
+		"seqNr":   seqNr,
+		"index":   index,
+		"signers": signers,
+	})
+	ts.TelemetrySender.ReportAttested(configDigest, seqNr, index, signers)
+}
+
+func (ts RecordingOCR3TelemetrySender) TransmissionDecided(
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	index int,
+	decision protocol.TransmissionDecision,
+) {
+	ts.record("TransmissionDecided", commontypes.LogFields{
+		"seqNr":    seqNr,
+		"index":    index,
+		"decision": decision.String(),
+	})
+	ts.TelemetrySender.TransmissionDecided(configDigest, seqNr, index, decision)
+}
+
+func (ts RecordingOCR3TelemetrySender) DegradedModeChanged(
+	configDigest types.ConfigDigest,
+	degraded bool,
+	reachableOracles int,
+) {
+	ts.record("DegradedModeChanged", commontypes.LogFields{
+		"degraded":         degraded,
+		"reachableOracles": reachableOracles,
+	})
+	ts.TelemetrySender.DegradedModeChanged(configDigest, degraded, reachableOracles)
+}
+
+func (ts RecordingOCR3TelemetrySender) CorruptedStateQuarantined(
+	configDigest types.ConfigDigest,
+	key string,
+) {
+	ts.record("CorruptedStateQuarantined", commontypes.LogFields{
+		"key": key,
+	})
+	ts.TelemetrySender.CorruptedStateQuarantined(configDigest, key)
+}
+
+func (ts RecordingOCR3TelemetrySender) TimerExpired(
+	configDigest types.ConfigDigest,
+	epoch uint64,
+	seqNr uint64,
+	timer protocol.Timer,
+) {
+	ts.record("TimerExpired", commontypes.LogFields{
+		"epoch": epoch,
+		"seqNr": seqNr,
+		"timer": string(timer),
+	})
+	ts.TelemetrySender.TimerExpired(configDigest, epoch, seqNr, timer)
+}
+
+// RecordingOCR3NetworkEndpoint records every message received through the
+// wrapped endpoint, serialized as on the wire, before passing it on.
+type RecordingOCR3NetworkEndpoint[RI any] struct {
+	endpoint protocol.NetworkEndpoint[RI]
+	recorder *ocr3trace.Recorder
+	logger   commontypes.Logger
+
+	mutex        sync.Mutex
+	subprocesses subprocesses.Subprocesses
+	started      bool
+	closed       bool
+	chCancel     chan struct{}
+	chOut        chan protocol.MessageWithSender[RI]
+	taper        loghelper.LogarithmicTaper
+}
+
+var _ protocol.NetworkEndpoint[struct{}] = (*RecordingOCR3NetworkEndpoint[struct{}])(nil)
+
+func NewRecordingOCR3NetworkEndpoint[RI any](endpoint protocol.NetworkEndpoint[RI], recorder *ocr3trace.Recorder, logger commontypes.Logger) *RecordingOCR3NetworkEndpoint[RI] {
+	return &RecordingOCR3NetworkEndpoint[RI]{
+		endpoint,
+		recorder,
+		logger,
+
+		sync.Mutex{},
+		subprocesses.Subprocesses{},
+		false,
+		false,
+		make(chan struct{}),
+		make(chan protocol.MessageWithSender[RI]),
+		loghelper.LogarithmicTaper{},
+	}
+}
+
+func (n *RecordingOCR3NetworkEndpoint[RI]) record(msg protocol.MessageWithSender[RI]) {
+	serializedMsg, _, err := serialization.Serialize(msg.Msg, msg.SentTime)
+	if err != nil {
+		n.taper.Trigger(func(newCount uint64) {
+			n.logger.Warn("RecordingOCR3NetworkEndpoint: failed to serialize message for trace", commontypes.LogFields{
+				"error":        err,
+				"failureCount": newCount,
+			})
+		})
+		return
+	}
+	sender := msg.Sender
+	n.recorder.Record(ocr3trace.Entry{
+		Kind:        ocr3trace.EntryKindMessage,
+		Sender:      &sender,
+		MessageType: protocol.MessageType(msg.Msg),
+		Message:     serializedMsg,
+	})
+}
+
+func (n *RecordingOCR3NetworkEndpoint[RI]) Start() error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.started {
+		return fmt.Errorf("cannot start already started RecordingOCR3NetworkEndpoint")
+	}
+	n.started = true
+
+	if err := n.endpoint.Start(); err != nil {
+		return fmt.Errorf("error while starting RecordingOCR3NetworkEndpoint: %w", err)
+	}
+
+	n.subprocesses.Go(func() {
+		defer close(n.chOut)
+		chIn := n.endpoint.Receive()
+		for {
+			select {
+			case msg, ok := <-chIn:
+				if !ok {
+					return
+				}
+				n.record(msg)
+				select {
+				case n.chOut <- msg:
+				case <-n.chCancel:
+					return
+				}
+			case <-n.chCancel:
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+func (n *RecordingOCR3NetworkEndpoint[RI]) Close() error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.started && !n.closed {
+		n.closed = true
+		close(n.chCancel)
+		n.subprocesses.Wait()
+		return n.endpoint.Close()
+	}
+
+	return nil
+}
+
+func (n *RecordingOCR3NetworkEndpoint[RI]) SendTo(msg protocol.Message[RI], to commontypes.OracleID) {
+	n.endpoint.SendTo(msg, to)
+}
+
+func (n *RecordingOCR3NetworkEndpoint[RI]) Broadcast(msg protocol.Message[RI]) {
+	n.endpoint.Broadcast(msg)
+}
+
+func (n *RecordingOCR3NetworkEndpoint[RI]) Multicast(msg protocol.Message[RI], to []commontypes.OracleID) {
+	n.endpoint.Multicast(msg, to)
+}
+
+func (n *RecordingOCR3NetworkEndpoint[RI]) Receive() <-chan protocol.MessageWithSender[RI] {
+	return n.chOut
+}
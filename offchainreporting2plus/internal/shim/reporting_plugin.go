@@ -25,7 +25,7 @@ func (rp LimitCheckReportingPlugin) Query(ctx context.Context, ts types.ReportTi
 		return nil, err
 	}
 	if !(len(query) <= rp.Limits.MaxQueryLength) {
-		return nil, fmt.Errorf("LimitCheckReportingPlugin: underlying ReportingPlugin returned oversize query (%v vs %v)", len(query), rp.Limits.MaxQueryLength)
+		return nil, fmt.Errorf("LimitCheckReportingPlugin: underlying ReportingPlugin returned output exceeding limits: %w", &types.LimitExceededError{"query", -1, len(query), rp.Limits.MaxQueryLength})
 	}
 	return query, nil
 }
@@ -36,7 +36,7 @@ func (rp LimitCheckReportingPlugin) Observation(ctx context.Context, ts types.Re
 		return nil, err
 	}
 	if !(len(observation) <= rp.Limits.MaxObservationLength) {
-		return nil, fmt.Errorf("LimitCheckReportingPlugin: underlying ReportingPlugin returned oversize observation (%v vs %v)", len(observation), rp.Limits.MaxObservationLength)
+		return nil, fmt.Errorf("LimitCheckReportingPlugin: underlying ReportingPlugin returned output exceeding limits: %w", &types.LimitExceededError{"observation", -1, len(observation), rp.Limits.MaxObservationLength})
 	}
 	return observation, nil
 }
@@ -47,7 +47,7 @@ func (rp LimitCheckReportingPlugin) Report(ctx context.Context, ts types.ReportT
 		return false, nil, err
 	}
 	if !(len(report) <= rp.Limits.MaxReportLength) {
-		return false, nil, fmt.Errorf("LimitCheckReportingPlugin: underlying ReportingPlugin returned oversize report (%v vs %v)", len(report), rp.Limits.MaxReportLength)
+		return false, nil, fmt.Errorf("LimitCheckReportingPlugin: underlying ReportingPlugin returned output exceeding limits: %w", &types.LimitExceededError{"report", -1, len(report), rp.Limits.MaxReportLength})
 	}
 	return shouldReport, report, nil
 }
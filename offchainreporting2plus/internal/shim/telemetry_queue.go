@@ -0,0 +1,147 @@
+package shim
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// TelemetryQueue buffers telemetry messages on their way to the
+// MonitoringEndpoint. Producers must never block on the MonitoringEndpoint, so
+// messages are dropped when the queue is full. Statistics about queued and
+// dropped messages are kept in a TelemetryQueueStats.
+type TelemetryQueue[M any] struct {
+	ch    chan M
+	stats *TelemetryQueueStats
+
+	// protected by stats.mutex. Enqueue times of all messages that are
+	// queued or currently being delivered, oldest first
+	enqueueTimes []time.Time
+	closed       bool
+}
+
+func NewTelemetryQueue[M any](capacity int, stats *TelemetryQueueStats) *TelemetryQueue[M] {
+	q := &TelemetryQueue[M]{make(chan M, capacity), stats, nil, false}
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+	stats.queues[q] = struct{}{}
+	return q
+}
+
+// TrySend enqueues m unless the queue is full or closed. It returns false if m
+// was dropped.
+func (q *TelemetryQueue[M]) TrySend(m M) bool {
+	// Holding the lock while sending ensures that the enqueue time is recorded
+	// before the consumer can call Delivered for m. The send never blocks.
+	q.stats.mutex.Lock()
+	defer q.stats.mutex.Unlock()
+	if q.closed {
+		return false
+	}
+	select {
+	case q.ch <- m:
+		q.enqueueTimes = append(q.enqueueTimes, time.Now())
+		return true
+	default:
+		q.stats.dropped++
+		return false
+	}
+}
+
+// Chan returns the channel the consumer reads messages from. The consumer must
+// call Delivered once it is done with each message.
+func (q *TelemetryQueue[M]) Chan() <-chan M {
+	return q.ch
+}
+
+// Delivered marks the oldest message received from Chan as no longer unsent.
+func (q *TelemetryQueue[M]) Delivered() {
+	q.stats.mutex.Lock()
+	defer q.stats.mutex.Unlock()
+	if len(q.enqueueTimes) != 0 {
+		q.enqueueTimes = q.enqueueTimes[1:]
+	}
+}
+
+// Close is called once the consumer has stopped reading from Chan. Messages
+// still in the queue will never be delivered, so they no longer count as
+// unsent, and later messages are dropped without being counted.
+func (q *TelemetryQueue[M]) Close() {
+	q.stats.mutex.Lock()
+	defer q.stats.mutex.Unlock()
+	q.closed = true
+	q.enqueueTimes = nil
+	delete(q.stats.queues, q)
+}
+
+type telemetryQueue interface {
+	unsent() (queued int, oldestEnqueueTime time.Time)
+}
+
+func (q *TelemetryQueue[M]) unsent() (int, time.Time) {
+	if len(q.enqueueTimes) == 0 {
+		return 0, time.Time{}
+	}
+	return len(q.enqueueTimes), q.enqueueTimes[0]
+}
+
+// TelemetryQueueStats aggregates the statistics of all open TelemetryQueues
+// created with it. It is safe for concurrent use.
+type TelemetryQueueStats struct {
+	mutex   sync.Mutex
+	queues  map[telemetryQueue]struct{}
+	dropped uint64
+}
+
+func NewTelemetryQueueStats() *TelemetryQueueStats {
+	return &TelemetryQueueStats{sync.Mutex{}, map[telemetryQueue]struct{}{}, 0}
+}
+
+func (s *TelemetryQueueStats) Status() types.TelemetryQueueStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var queued int
+	var oldestEnqueueTime time.Time
+	for q := range s.queues {
+		n, t := q.unsent()
+		queued += n
+		if n != 0 && (oldestEnqueueTime.IsZero() || t.Before(oldestEnqueueTime)) {
+			oldestEnqueueTime = t
+		}
+	}
+	var oldestUnsentAge time.Duration
+	if !oldestEnqueueTime.IsZero() {
+		oldestUnsentAge = time.Since(oldestEnqueueTime)
+	}
+	return types.TelemetryQueueStatus{
+		queued,
+		s.dropped,
+		oldestUnsentAge,
+	}
+}
+
+// Collectors returns prometheus metrics mirroring Status.
+func (s *TelemetryQueueStats) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "ocr_telemetry_queued_messages",
+			Help: "Number of telemetry messages that are queued or currently being delivered to the MonitoringEndpoint",
+		}, func() float64 {
+			return float64(s.Status().Queued)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "ocr_telemetry_dropped_messages_total",
+			Help: "Number of telemetry messages that were dropped because the queue to the MonitoringEndpoint was full",
+		}, func() float64 {
+			return float64(s.Status().Dropped)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "ocr_telemetry_oldest_unsent_age_seconds",
+			Help: "Age of the oldest telemetry message that is queued or currently being delivered to the MonitoringEndpoint, or zero if there is none",
+		}, func() float64 {
+			return s.Status().OldestUnsentAge.Seconds()
+		}),
+	}
+}
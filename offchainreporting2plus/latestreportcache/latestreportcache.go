@@ -0,0 +1,143 @@
+// Package latestreportcache keeps track of the latest report that has been
+// confirmed onchain, per ReportDestination and config digest, so that
+// ReportingPlugin.ShouldAcceptAttestedReport and
+// ReportingPlugin.ShouldTransmitAcceptedReport can answer "has this (or a
+// later) report already been reported?" synchronously, instead of performing
+// a slow RPC call on every invocation.
+//
+// The cache is fed by the ContractTransmitter: whenever it learns that a
+// transmission has been confirmed (e.g. because its transaction was included
+// in a block with sufficient depth, or because it observed a transmission
+// event of another oracle), it calls Confirm. The cache itself neither
+// talks to the destination nor knows what "confirmed" means for it, that's
+// the finality policy of the transmitter.
+//
+// An OCR3 oracle given a Cache via OCR3OracleArgs.LatestReportCache consults it
+// before transmitting: reports that have already been confirmed on all their
+// destinations aren't transmitted again. The oracle also makes the Cache
+// available to the ReportingPlugin's ShouldAcceptAttestedReport and
+// ShouldTransmitAcceptedReport and to the ContractTransmitter's Transmit
+// through their contexts, see FromContext, so that they can consult or feed
+// it without further plumbing.
+//
+// The cache is only ever a shortcut: a plugin consulting it must still be
+// correct if the cache lags behind the destination, e.g. right after a
+// restart, when it is empty. A cache miss should thus lead to the same
+// decision the plugin would have made without the cache.
+package latestreportcache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// DefaultDestination is the destination under which the oracle looks up
+// reports transmitted by a ContractTransmitter that isn't an
+// ocr3types.ContractTransmitterBundle.
+const DefaultDestination ocr3types.ReportDestination = ""
+
+// Destinations returns the destinations under which the oracle looks up the
+// reports transmitted by contractTransmitter: the Destinations of an
+// ocr3types.ContractTransmitterBundle, or DefaultDestination otherwise. The
+// oracle only skips transmitting the reports of a sequence number if they
+// have been confirmed on all of them.
+func Destinations[RI any](contractTransmitter ocr3types.ContractTransmitter[RI]) []ocr3types.ReportDestination {
+	if bundle, ok := contractTransmitter.(*ocr3types.ContractTransmitterBundle[RI]); ok {
+		return bundle.Destinations()
+	}
+	return []ocr3types.ReportDestination{DefaultDestination}
+}
+
+// Entry describes the latest confirmed report of a destination for a config
+// digest.
+type Entry struct {
+	SeqNr uint64
+	// Time at which Confirm was called
+	ConfirmedAt time.Time
+}
+
+type key struct {
+	destination  ocr3types.ReportDestination
+	configDigest types.ConfigDigest
+}
+
+// Cache is safe for concurrent use. The zero value is not usable, use New.
+type Cache struct {
+	mutex   sync.RWMutex
+	entries map[key]Entry
+}
+
+func New() *Cache {
+	return &Cache{
+		sync.RWMutex{},
+		map[key]Entry{},
+	}
+}
+
+// Confirm records that the reports with the given configDigest and seqNr
+// have been confirmed on destination. If a sequence number has several
+// reports for destination, only call Confirm once all of them have been
+// confirmed. Confirmations of reports that aren't later
+// than the cached one for the same destination and configDigest are ignored,
+// so confirmations may arrive out of order. Sequence numbers of different
+// protocol instances aren't comparable, so each configDigest has its own
+// entry, and confirmations for an old configDigest don't affect the entry of
+// a newer one.
+func (c *Cache) Confirm(destination ocr3types.ReportDestination, configDigest types.ConfigDigest, seqNr uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	k := key{destination, configDigest}
+	if entry, ok := c.entries[k]; ok && seqNr <= entry.SeqNr {
+		return
+	}
+	c.entries[k] = Entry{seqNr, time.Now()}
+}
+
+// Latest returns the latest confirmed report of destination for configDigest,
+// if any.
+func (c *Cache) Latest(destination ocr3types.ReportDestination, configDigest types.ConfigDigest) (Entry, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entry, ok := c.entries[key{destination, configDigest}]
+	return entry, ok
+}
+
+// Reported returns true iff a report with the given configDigest and a
+// sequence number of at least seqNr has been confirmed on destination. A false
+// result doesn't imply that no such report exists onchain: the report may
+// not have been confirmed yet, or not have been seen by this cache.
+func (c *Cache) Reported(destination ocr3types.ReportDestination, configDigest types.ConfigDigest, seqNr uint64) bool {
+	entry, ok := c.Latest(destination, configDigest)
+	return ok && seqNr <= entry.SeqNr
+}
+
+// Forget removes the cached entry of destination for configDigest, e.g. after
+// a reorg that invalidated a confirmation, or once configDigest has been
+// superseded onchain.
+func (c *Cache) Forget(destination ocr3types.ReportDestination, configDigest types.ConfigDigest) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, key{destination, configDigest})
+}
+
+type cacheContextKey struct{}
+
+// ContextWithCache returns a copy of ctx that carries cache. The oracle uses
+// it for the contexts passed to ShouldAcceptAttestedReport,
+// ShouldTransmitAcceptedReport, and Transmit.
+func ContextWithCache(ctx context.Context, cache *Cache) context.Context {
+	return context.WithValue(ctx, cacheContextKey{}, cache)
+}
+
+// FromContext returns the Cache carried by ctx, if any.
+func FromContext(ctx context.Context) (*Cache, bool) {
+	cache, ok := ctx.Value(cacheContextKey{}).(*Cache)
+	return cache, ok
+}
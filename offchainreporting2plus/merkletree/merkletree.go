@@ -0,0 +1,135 @@
+// Package merkletree implements the binary Merkle trees that OCR3 uses to
+// attest batches of reports with a single signature per oracle, see
+// ocr3types.ReportBatcher.
+//
+// Trees are built as specified in RFC 6962, section 2.1: leaves are hashed as
+// SHA2-256(0x00 || leaf) and interior nodes as SHA2-256(0x01 || left ||
+// right), and a tree with n leaves is split into a left subtree with the
+// largest power of two smaller than n leaves and a right subtree with the
+// rest. The domain separation between leaves and interior nodes prevents
+// second preimage attacks, so contracts verifying inclusion proofs needn't
+// know the number of leaves in advance.
+package merkletree
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+type Hash = [sha256.Size]byte
+
+const (
+	leafPrefix     = 0x00
+	interiorPrefix = 0x01
+)
+
+func hashLeaf(leaf []byte) Hash {
+	h := sha256.New()
+	_, _ = h.Write([]byte{leafPrefix})
+	_, _ = h.Write(leaf)
+	var result Hash
+	h.Sum(result[:0])
+	return result
+}
+
+func hashInterior(left Hash, right Hash) Hash {
+	h := sha256.New()
+	_, _ = h.Write([]byte{interiorPrefix})
+	_, _ = h.Write(left[:])
+	_, _ = h.Write(right[:])
+	var result Hash
+	h.Sum(result[:0])
+	return result
+}
+
+// split returns the largest power of two smaller than n, for n >= 2.
+func split(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+func root(leafHashes []Hash) Hash {
+	if len(leafHashes) == 1 {
+		return leafHashes[0]
+	}
+	k := split(len(leafHashes))
+	return hashInterior(root(leafHashes[:k]), root(leafHashes[k:]))
+}
+
+func hashLeaves(leaves [][]byte) []Hash {
+	leafHashes := make([]Hash, 0, len(leaves))
+	for _, leaf := range leaves {
+		leafHashes = append(leafHashes, hashLeaf(leaf))
+	}
+	return leafHashes
+}
+
+// Root returns the root of the tree with the given leaves. It returns an error
+// if there are no leaves.
+func Root(leaves [][]byte) (Hash, error) {
+	if len(leaves) == 0 {
+		return Hash{}, fmt.Errorf("cannot compute root of empty tree")
+	}
+	return root(hashLeaves(leaves)), nil
+}
+
+// Proofs returns the root and an inclusion proof for every leaf of the tree
+// with the given leaves. Each proof lists the sibling hashes on the path
+// from the leaf to the root, bottom up. It returns an error if there are no
+// leaves.
+func Proofs(leaves [][]byte) (Hash, [][]Hash, error) {
+	if len(leaves) == 0 {
+		return Hash{}, nil, fmt.Errorf("cannot compute proofs for empty tree")
+	}
+	leafHashes := hashLeaves(leaves)
+	proofs := make([][]Hash, len(leaves))
+	return proveAll(leafHashes, proofs), proofs, nil
+}
+
+// proveAll appends the sibling hashes for each leaf's path within the subtree
+// of leafHashes to proofs, and returns the subtree's root.
+func proveAll(leafHashes []Hash, proofs [][]Hash) Hash {
+	if len(leafHashes) == 1 {
+		return leafHashes[0]
+	}
+	k := split(len(leafHashes))
+	left := proveAll(leafHashes[:k], proofs[:k])
+	right := proveAll(leafHashes[k:], proofs[k:])
+	for i := range proofs[:k] {
+		proofs[i] = append(proofs[i], right)
+	}
+	for i := range proofs[k:] {
+		proofs[k+i] = append(proofs[k+i], left)
+	}
+	return hashInterior(left, right)
+}
+
+// Verify returns true iff proof shows that leaf is the index-th of count
+// leaves of the tree with the given root.
+func Verify(root Hash, leaf []byte, index int, count int, proof []Hash) bool {
+	if !(0 <= index && index < count) {
+		return false
+	}
+	computed, ok := computeRoot(hashLeaf(leaf), index, count, proof)
+	return ok && computed == root
+}
+
+func computeRoot(leafHash Hash, index int, count int, proof []Hash) (Hash, bool) {
+	if count == 1 {
+		return leafHash, len(proof) == 0
+	}
+	if len(proof) == 0 {
+		return Hash{}, false
+	}
+	sibling := proof[len(proof)-1]
+	k := split(count)
+	if index < k {
+		left, ok := computeRoot(leafHash, index, k, proof[:len(proof)-1])
+		return hashInterior(left, sibling), ok
+	}
+	right, ok := computeRoot(leafHash, index-k, count-k, proof[:len(proof)-1])
+	return hashInterior(sibling, right), ok
+}
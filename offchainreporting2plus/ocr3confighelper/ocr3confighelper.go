@@ -2,12 +2,14 @@ package ocr3confighelper
 
 import (
 	"crypto/rand"
+	"fmt"
 	"io"
 	"time"
 
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/confighelper"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
 
@@ -35,6 +37,21 @@ type PublicConfig struct {
 	F             int
 	OnchainConfig []byte
 	ConfigDigest  types.ConfigDigest
+
+	FeatureFlags ocr3types.FeatureFlags
+
+	DeltaGraceMin time.Duration
+	DeltaGraceMax time.Duration
+
+	LeaderWeights []int
+
+	DeltaRoundMin time.Duration
+	DeltaRoundMax time.Duration
+
+	// During a key rotation, NextOffchainPublicKeys has one entry per oracle,
+	// holding the offchain public key the oracle is being rotated to (or zero
+	// if it isn't). Empty if no oracle is being rotated.
+	NextOffchainPublicKeys []types.OffchainPublicKey
 }
 
 func (pc PublicConfig) N() int {
@@ -47,6 +64,8 @@ func PublicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 		return PublicConfig{}, err
 	}
 	identities := []confighelper.OracleIdentity{}
+	nextOffchainPublicKeys := []types.OffchainPublicKey{}
+	rotating := false
 	for _, internalIdentity := range internalPublicConfig.OracleIdentities {
 		identities = append(identities, confighelper.OracleIdentity{
 			internalIdentity.OffchainPublicKey,
@@ -54,6 +73,13 @@ func PublicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 			internalIdentity.PeerID,
 			internalIdentity.TransmitAccount,
 		})
+		nextOffchainPublicKeys = append(nextOffchainPublicKeys, internalIdentity.NextOffchainPublicKey)
+		if internalIdentity.NextOffchainPublicKey != (types.OffchainPublicKey{}) {
+			rotating = true
+		}
+	}
+	if !rotating {
+		nextOffchainPublicKeys = nil
 	}
 	return PublicConfig{
 		internalPublicConfig.DeltaProgress,
@@ -74,6 +100,13 @@ func PublicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 		internalPublicConfig.F,
 		internalPublicConfig.OnchainConfig,
 		internalPublicConfig.ConfigDigest,
+		internalPublicConfig.FeatureFlags,
+		internalPublicConfig.DeltaGraceMin,
+		internalPublicConfig.DeltaGraceMax,
+		internalPublicConfig.LeaderWeights,
+		internalPublicConfig.DeltaRoundMin,
+		internalPublicConfig.DeltaRoundMax,
+		nextOffchainPublicKeys,
 	}, nil
 }
 
@@ -153,14 +186,103 @@ func ContractSetConfigArgsForTests(
 	offchainConfig []byte,
 	err error,
 ) {
+	return ContractSetConfigArgsForTestsWithAuxiliaryArgs(
+		deltaProgress,
+		deltaResend,
+		deltaInitial,
+		deltaRound,
+		deltaGrace,
+		deltaCertifiedCommitRequest,
+		deltaStage,
+		rMax,
+		s,
+		oracles,
+		reportingPluginConfig,
+		maxDurationQuery,
+		maxDurationObservation,
+		maxDurationShouldAcceptAttestedReport,
+		maxDurationShouldTransmitAcceptedReport,
+		f,
+		onchainConfig,
+		AuxiliaryArgs{},
+	)
+}
+
+// AuxiliaryArgs provides keyword-style extra configuration for calls to
+// ContractSetConfigArgsForTestsWithAuxiliaryArgs. The zero value yields the
+// same config as ContractSetConfigArgsForTests.
+type AuxiliaryArgs struct {
+	FeatureFlags ocr3types.FeatureFlags
+
+	// If non-zero, enable auto-tuning of DeltaGrace within these bounds.
+	DeltaGraceMin time.Duration
+	DeltaGraceMax time.Duration
+
+	// If non-empty, weights leader selection.
+	LeaderWeights []int
+
+	// If non-zero, enable adaptive round pacing within these bounds.
+	DeltaRoundMin time.Duration
+	DeltaRoundMax time.Duration
+
+	// If non-empty, has one entry per oracle with the offchain public key the
+	// oracle is being rotated to, or zero if it isn't. See
+	// types.RotatingOffchainKeyring. Oracles running older versions of libocr
+	// ignore next keys and reject signatures under them.
+	NextOffchainPublicKeys []types.OffchainPublicKey
+}
+
+// ContractSetConfigArgsForTestsWithAuxiliaryArgs is like
+// ContractSetConfigArgsForTests, but additionally sets the config options in
+// auxiliaryArgs. Only use this for testing, *not* for production.
+func ContractSetConfigArgsForTestsWithAuxiliaryArgs(
+	deltaProgress time.Duration,
+	deltaResend time.Duration,
+	deltaInitial time.Duration,
+	deltaRound time.Duration,
+	deltaGrace time.Duration,
+	deltaCertifiedCommitRequest time.Duration,
+	deltaStage time.Duration,
+	rMax uint64,
+	s []int,
+	oracles []confighelper.OracleIdentityExtra,
+	reportingPluginConfig []byte,
+	maxDurationQuery time.Duration,
+	maxDurationObservation time.Duration,
+	maxDurationShouldAcceptAttestedReport time.Duration,
+	maxDurationShouldTransmitAcceptedReport time.Duration,
+	f int,
+	onchainConfig []byte,
+	auxiliaryArgs AuxiliaryArgs,
+) (
+	signers []types.OnchainPublicKey,
+	transmitters []types.Account,
+	f_ uint8,
+	onchainConfig_ []byte,
+	offchainConfigVersion uint64,
+	offchainConfig []byte,
+	err error,
+) {
+	if err := auxiliaryArgs.FeatureFlags.Validate(); err != nil {
+		return nil, nil, 0, nil, 0, nil, err
+	}
+	if len(auxiliaryArgs.NextOffchainPublicKeys) != 0 && len(auxiliaryArgs.NextOffchainPublicKeys) != len(oracles) {
+		return nil, nil, 0, nil, 0, nil, fmt.Errorf("NextOffchainPublicKeys must be empty or have one entry per oracle, got %v entries for %v oracles", len(auxiliaryArgs.NextOffchainPublicKeys), len(oracles))
+	}
+
 	identities := []config.OracleIdentity{}
 	configEncryptionPublicKeys := []types.ConfigEncryptionPublicKey{}
-	for _, oracle := range oracles {
+	for i, oracle := range oracles {
+		var nextOffchainPublicKey types.OffchainPublicKey
+		if len(auxiliaryArgs.NextOffchainPublicKeys) != 0 {
+			nextOffchainPublicKey = auxiliaryArgs.NextOffchainPublicKeys[i]
+		}
 		identities = append(identities, config.OracleIdentity{
 			oracle.OffchainPublicKey,
 			oracle.OnchainPublicKey,
 			oracle.PeerID,
 			oracle.TransmitAccount,
+			nextOffchainPublicKey,
 		})
 		configEncryptionPublicKeys = append(configEncryptionPublicKeys, oracle.ConfigEncryptionPublicKey)
 	}
@@ -187,6 +309,12 @@ func ContractSetConfigArgsForTests(
 			maxDurationObservation,
 			maxDurationShouldAcceptAttestedReport,
 			maxDurationShouldTransmitAcceptedReport,
+			auxiliaryArgs.FeatureFlags,
+			auxiliaryArgs.DeltaGraceMin,
+			auxiliaryArgs.DeltaGraceMax,
+			auxiliaryArgs.LeaderWeights,
+			auxiliaryArgs.DeltaRoundMin,
+			auxiliaryArgs.DeltaRoundMax,
 			f,
 			onchainConfig,
 			types.ConfigDigest{},
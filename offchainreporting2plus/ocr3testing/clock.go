@@ -0,0 +1,87 @@
+package ocr3testing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+)
+
+// Clock is an ocr3types.TimeSource that only moves when told to. Pass it in
+// Config.TimeSource to control the observation timestamps, and hence
+// OutcomeContext.ObservationsTimestamp, that a ReportingPlugin sees. Clock
+// also implements ocr3types.TimerSource, so it drives the protocol's timers,
+// e.g. DeltaProgress, DeltaRound and DeltaGrace, as well: they only fire once
+// the Clock has been moved past their deadline. All its functions are
+// thread-safe.
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []clockTimer
+}
+
+type clockTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+var _ ocr3types.TimeSource = (*Clock)(nil)
+var _ ocr3types.TimerSource = (*Clock)(nil)
+
+// NewClock returns a Clock that reads now until it is moved.
+func NewClock(now time.Time) *Clock {
+	return &Clock{sync.Mutex{}, now, nil}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the Clock's time once the Clock has
+// been moved forward by at least d.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.timers = append(c.timers, clockTimer{c.now.Add(d), ch})
+	return ch
+}
+
+// Set moves the Clock to now, which may lie in the past. Timers whose deadline
+// has been reached fire.
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+	c.fire()
+}
+
+// Advance moves the Clock forward by d and returns the new time. Timers whose
+// deadline has been reached fire.
+func (c *Clock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.fire()
+	return c.now
+}
+
+// fire must be called with c.mu held.
+func (c *Clock) fire() {
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.deadline.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		// never blocks, every channel is buffered and receives at most once
+		t.ch <- c.now
+	}
+	c.timers = pending
+}
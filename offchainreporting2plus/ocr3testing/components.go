@@ -0,0 +1,187 @@
+package ocr3testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// transmitter records every transmission of an oracle with its Cluster.
+type transmitter[RI any] struct {
+	cluster *Cluster[RI]
+	id      commontypes.OracleID
+}
+
+var _ ocr3types.ContractTransmitter[struct{}] = transmitter[struct{}]{}
+
+func (t transmitter[RI]) Transmit(ctx context.Context, configDigest types.ConfigDigest, seqNr uint64, rwi ocr3types.ReportWithInfo[RI], signatures []types.AttributedOnchainSignature) error {
+	t.cluster.recordTransmission(Transmission[RI]{
+		t.id,
+		configDigest,
+		seqNr,
+		rwi,
+		append([]types.AttributedOnchainSignature{}, signatures...),
+	})
+	return nil
+}
+
+func (t transmitter[RI]) FromAccount() (types.Account, error) {
+	return types.Account(fmt.Sprintf("account-%d", t.id)), nil
+}
+
+// criticalRecordingLogger passes all log messages on to the wrapped Logger and
+// additionally records Critical ones with its Cluster or Fuzzer.
+type criticalRecordingLogger struct {
+	commontypes.Logger
+	record func(error)
+	id     commontypes.OracleID
+}
+
+func (l criticalRecordingLogger) Critical(msg string, fields commontypes.LogFields) {
+	l.record(fmt.Errorf("oracle %v logged critical message %q: %v", l.id, msg, fields))
+	l.Logger.Critical(msg, fields)
+}
+
+// limitViolationRecordingPlugin records every error with which the wrapped
+// LimitCheckOCR3ReportingPlugin rejects an output of the plugin for exceeding
+// its limits with its Fuzzer. The protocol only logs such errors and carries
+// on, so a fuzz target would miss them otherwise.
+type limitViolationRecordingPlugin[RI any] struct {
+	ocr3types.ReportingPluginV2[RI]
+	record func(error)
+}
+
+func (rp limitViolationRecordingPlugin[RI]) Query(ctx context.Context, outctx ocr3types.OutcomeContext) (types.Query, error) {
+	query, err := rp.ReportingPluginV2.Query(ctx, outctx)
+	rp.check("Query", outctx.SeqNr, err)
+	return query, err
+}
+
+func (rp limitViolationRecordingPlugin[RI]) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
+	observation, err := rp.ReportingPluginV2.Observation(ctx, outctx, query)
+	rp.check("Observation", outctx.SeqNr, err)
+	return observation, err
+}
+
+func (rp limitViolationRecordingPlugin[RI]) Outcome(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, aos []types.AttributedObservation) (ocr3types.Outcome, error) {
+	outcome, err := rp.ReportingPluginV2.Outcome(ctx, outctx, query, aos)
+	rp.check("Outcome", outctx.SeqNr, err)
+	return outcome, err
+}
+
+func (rp limitViolationRecordingPlugin[RI]) Reports(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[RI], error) {
+	reports, err := rp.ReportingPluginV2.Reports(ctx, seqNr, outcome)
+	rp.check("Reports", seqNr, err)
+	return reports, err
+}
+
+func (rp limitViolationRecordingPlugin[RI]) check(name string, seqNr uint64, err error) {
+	var limitExceededError *types.LimitExceededError
+	if errors.As(err, &limitExceededError) {
+		rp.record(fmt.Errorf("%v for seqNr %v exceeded limits: %w", name, seqNr, err))
+	}
+}
+
+// discardingNetworkSender drops every message. The Fuzzer's peers are driven
+// by the fuzz target, so nobody listens to its oracle.
+type discardingNetworkSender[RI any] struct{}
+
+var _ protocol.NetworkSender[struct{}] = discardingNetworkSender[struct{}]{}
+
+func (discardingNetworkSender[RI]) SendTo(protocol.Message[RI], commontypes.OracleID)      {}
+func (discardingNetworkSender[RI]) Broadcast(protocol.Message[RI])                         {}
+func (discardingNetworkSender[RI]) Multicast(protocol.Message[RI], []commontypes.OracleID) {}
+
+type discardingLogger struct{}
+
+var _ commontypes.Logger = discardingLogger{}
+
+func (discardingLogger) Trace(msg string, fields commontypes.LogFields)    {}
+func (discardingLogger) Debug(msg string, fields commontypes.LogFields)    {}
+func (discardingLogger) Info(msg string, fields commontypes.LogFields)     {}
+func (discardingLogger) Warn(msg string, fields commontypes.LogFields)     {}
+func (discardingLogger) Error(msg string, fields commontypes.LogFields)    {}
+func (discardingLogger) Critical(msg string, fields commontypes.LogFields) {}
+
+type discardingTelemetrySender struct{}
+
+var _ protocol.TelemetrySender = discardingTelemetrySender{}
+
+func (discardingTelemetrySender) RoundStarted(
+	types.ConfigDigest,
+	uint64,
+	uint64,
+	uint64,
+	commontypes.OracleID,
+) {
+}
+
+func (discardingTelemetrySender) ProtocolError(
+	types.ConfigDigest,
+	uint64,
+	uint64,
+	protocol.ProtocolErrorCode,
+) {
+}
+
+func (discardingTelemetrySender) OutcomeComputed(
+	types.ConfigDigest,
+	uint64,
+	uint64,
+	uint64,
+	commontypes.OracleID,
+	[]commontypes.OracleID,
+	[]commontypes.OracleID,
+	protocol.OutcomeDigest,
+) {
+}
+
+func (discardingTelemetrySender) OutcomeCommitted(
+	types.ConfigDigest,
+	uint64,
+	uint64,
+	protocol.OutcomeDigest,
+) {
+}
+
+func (discardingTelemetrySender) ReportAttested(
+	types.ConfigDigest,
+	uint64,
+	int,
+	[]commontypes.OracleID,
+) {
+}
+
+func (discardingTelemetrySender) TransmissionDecided(
+	types.ConfigDigest,
+	uint64,
+	int,
+	protocol.TransmissionDecision,
+) {
+}
+
+func (discardingTelemetrySender) DegradedModeChanged(
+	types.ConfigDigest,
+	bool,
+	int,
+) {
+}
+
+func (discardingTelemetrySender) CorruptedStateQuarantined(
+	types.ConfigDigest,
+	string,
+) {
+}
+
+func (discardingTelemetrySender) TimerExpired(
+	types.ConfigDigest,
+	uint64,
+	uint64,
+	protocol.Timer,
+) {
+}
@@ -0,0 +1,430 @@
+package ocr3testing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/databases/memorydb"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/managed/limits"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/serialization"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/shim"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"go.uber.org/multierr"
+)
+
+// FuzzerOracleID is the id of the oracle whose plugin a Fuzzer exercises. All
+// other oracles are played by the fuzz target.
+const FuzzerOracleID commontypes.OracleID = 0
+
+// Fuzzer feeds messages from (possibly byzantine) peers to a single oracle
+// running a ReportingPlugin, and processes them with the same code as a
+// running oracle: chunked messages are reassembled, duplicates are dropped,
+// and messages are deserialized, size-checked against the plugin's limits, and
+// handed to outcome generation, which calls the plugin's Observation,
+// ValidateObservation, ObservationQuorum, and Outcome. Whenever the oracle
+// computes an outcome, the Fuzzer also generates reports from it the way
+// report attestation does, i.e. with ReportBatches or
+// ReportsWithOutcomeContext instead of Reports if the plugin implements
+// ocr3types.ReportBatcher or ocr3types.OutcomeContextReporter. The plugin is
+// wrapped like in a Cluster, so its limits are enforced.
+//
+// Unlike a Cluster, a Fuzzer runs no goroutines or timers; every function
+// returns once the oracle has processed its input. A Fuzzer isn't safe for
+// concurrent use. Create one per fuzz target and call StartRound at the start
+// of every iteration:
+//
+//	fuzzer, err := ocr3testing.NewFuzzer(ctx, ocr3testing.DefaultConfig(4, 1), factory)
+//	...
+//	defer fuzzer.Close()
+//	epoch, _ := fuzzer.EpochLedBy(1)
+//	f.Fuzz(func(t *testing.T, query []byte, observation []byte) {
+//		fuzzer.StartRound(ctx, epoch, 0, nil)
+//		_ = fuzzer.RoundStart(query)
+//		_ = fuzzer.Proposal([]types.AttributedObservation{{Observation: observation, Observer: 1}, ...}, nil)
+//		if err := fuzzer.Err(); err != nil {
+//			t.Fatal(err)
+//		}
+//	})
+//
+// Panics of the plugin aren't recovered, so that the fuzzing engine reports
+// them.
+type Fuzzer[RI any] struct {
+	sharedConfig     ocr3config.SharedConfig
+	oracles          []oracle[RI]
+	plugin           ocr3types.ReportingPluginV2[RI]
+	pluginLimits     ocr3types.ReportingPluginLimits
+	chunkedTransfer  ocr3types.ChunkedTransferConfig
+	dedup            types.MessageDeduplicationConfig
+	maxMessageLength int // of a message, before any chunking
+	maxRawLength     int // of what the network delivers, i.e. of a chunk if chunked transfer is enabled
+	logger           loghelper.LoggerWithContext
+	metrics          *protocol.Metrics
+	protocolPlugin   limitViolationRecordingPlugin[RI]
+	// at most one of them is non-nil, like in report attestation
+	reportBatcher          ocr3types.ReportBatcher[RI]
+	outcomeContextReporter ocr3types.OutcomeContextReporter[RI]
+	harness                *protocol.OutcomeGenerationHarness[RI]
+	nextMessageID          uint64
+
+	// reset by StartRound
+	ctx              context.Context
+	started          bool
+	committedOutcome ocr3types.Outcome
+	inbound          *shim.OCR3InboundFilter
+	reportedSeqNr    uint64
+	errs             []error
+}
+
+// NewFuzzer creates a plugin for oracle FuzzerOracleID of a cluster
+// configured by config, using factory. It fails if the factory fails or
+// returns an invalid ReportingPluginInfo. The protocol's timers aren't used,
+// so only the fields of config that affect what messages are valid matter,
+// e.g. N, F, FeatureFlags, and InboundMessageDeduplication.
+func NewFuzzer[RI any](ctx context.Context, config Config, factory ocr3types.ReportingPluginFactoryV2[RI]) (*Fuzzer[RI], error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	sharedConfig, oracles, err := makeSharedConfigAndOracles[RI](config)
+	if err != nil {
+		return nil, err
+	}
+
+	rootLogger := config.Logger
+	if rootLogger == nil {
+		rootLogger = discardingLogger{}
+	}
+
+	f := &Fuzzer[RI]{}
+	logger := loghelper.MakeRootLoggerWithContext(criticalRecordingLogger{rootLogger, f.recordError, FuzzerOracleID}).MakeChild(commontypes.LogFields{"oid": FuzzerOracleID})
+	oracles[FuzzerOracleID].logger = logger
+
+	plugin, info, err := newReportingPlugin(ctx, factory, sharedConfig, oracles, FuzzerOracleID)
+	if err != nil {
+		return nil, err
+	}
+	maxSigLen := oracles[FuzzerOracleID].onchainKeyring.MaxSignatureLength()
+	maxMessageLength, err := limits.OCR3MaxSerializedMessageLength(sharedConfig.PublicConfig, info.Limits, maxSigLen)
+	if err != nil {
+		_ = plugin.Close()
+		return nil, fmt.Errorf("invalid ReportingPluginInfo for oracle %v: %w", FuzzerOracleID, err)
+	}
+	networkLimits, err := limits.OCR3Limits(sharedConfig.PublicConfig, info.Limits, info.ChunkedTransfer, maxSigLen)
+	if err != nil {
+		_ = plugin.Close()
+		return nil, fmt.Errorf("invalid ReportingPluginInfo for oracle %v: %w", FuzzerOracleID, err)
+	}
+
+	metrics := protocol.NewMetrics(nil, logger)
+	wrappedPlugin := wrapReportingPlugin(sharedConfig, plugin, info, logger, metrics)
+	protocolPlugin := limitViolationRecordingPlugin[RI]{wrappedPlugin, f.recordError}
+	reportBatcher, _ := wrappedPlugin.(ocr3types.ReportBatcher[RI])
+	var outcomeContextReporter ocr3types.OutcomeContextReporter[RI]
+	if reportBatcher == nil {
+		outcomeContextReporter, _ = wrappedPlugin.(ocr3types.OutcomeContextReporter[RI])
+	}
+
+	*f = Fuzzer[RI]{
+		sharedConfig,
+		oracles,
+		plugin,
+		info.Limits,
+		info.ChunkedTransfer,
+		config.InboundMessageDeduplication,
+		maxMessageLength,
+		networkLimits.MaxMessageLength,
+		logger,
+		metrics,
+		protocolPlugin,
+		reportBatcher,
+		outcomeContextReporter,
+		protocol.NewOutcomeGenerationHarness[RI](
+			sharedConfig,
+			&shim.SerializingOCR3Database{memorydb.New(), nil},
+			types.LocalConfig{
+				DatabaseTimeout:                    time.Second,
+				ContractTransmitterTransmitTimeout: time.Second,
+			},
+			logger,
+			metrics,
+			discardingNetworkSender[RI]{},
+			oracles[FuzzerOracleID].offchainKeyring,
+			protocolPlugin,
+			discardingTelemetrySender{},
+			config.TimeSource,
+		),
+		0,
+
+		nil,
+		false,
+		nil,
+		nil,
+		0,
+		nil,
+	}
+	return f, nil
+}
+
+// ConfigDigest returns the config digest of the cluster, which is random.
+func (f *Fuzzer[RI]) ConfigDigest() types.ConfigDigest {
+	return f.sharedConfig.ConfigDigest
+}
+
+// maxEpochLedBySearch bounds the epochs EpochLedBy considers. With uniform
+// leader selection, each oracle leads one of them with overwhelming
+// probability.
+const maxEpochLedBySearch = 100 * types.MaxOracles
+
+// EpochLedBy returns the first epoch led by oracle id, and false if it can't
+// find one.
+func (f *Fuzzer[RI]) EpochLedBy(id commontypes.OracleID) (uint64, bool) {
+	for epoch := uint64(1); epoch <= maxEpochLedBySearch; epoch++ {
+		if protocol.Leader(epoch, f.sharedConfig.N(), f.sharedConfig.LeaderWeights, f.sharedConfig.LeaderSelectionKey()) == id {
+			return epoch, true
+		}
+	}
+	return 0, false
+}
+
+// StartRound discards all state from previous rounds and findings, and starts
+// the round following committedSeqNr in epoch, with committedOutcome as the
+// previous outcome. committedOutcome should be an outcome that the plugin
+// itself computed, since the protocol only ever passes those to the plugin.
+//
+// If FuzzerOracleID leads epoch, the plugin's Query is called right away, and
+// the round expects observations. Otherwise it expects a RoundStart from the
+// leader, unless the plugin's queries are empty, in which case the plugin
+// observes right away. Plugin calls are made with ctx.
+func (f *Fuzzer[RI]) StartRound(ctx context.Context, epoch uint64, committedSeqNr uint64, committedOutcome ocr3types.Outcome) {
+	f.ctx = ctx
+	f.started = true
+	f.committedOutcome = committedOutcome
+	if f.inbound != nil {
+		f.inbound.Close()
+	}
+	f.inbound = shim.NewOCR3InboundFilter(f.logger, f.metrics, f.chunkedTransfer, f.dedup, f.maxMessageLength, f.sharedConfig.N())
+	f.reportedSeqNr = 0
+	f.errs = nil
+	f.harness.StartRound(ctx, FuzzerOracleID, epoch, committedSeqNr, committedOutcome)
+}
+
+// Leader returns the leader of the current round's epoch.
+func (f *Fuzzer[RI]) Leader() commontypes.OracleID {
+	return f.harness.Leader()
+}
+
+// SeqNr returns the seqNr of the current round.
+func (f *Fuzzer[RI]) SeqNr() uint64 {
+	return f.harness.SeqNr()
+}
+
+// Outcome returns the outcome the oracle computed in the current round, if it
+// did.
+func (f *Fuzzer[RI]) Outcome() (ocr3types.Outcome, bool) {
+	return f.harness.Outcome()
+}
+
+// Message delivers data from sender as if it had been received from the
+// network. data takes the same path as in a running oracle: if the plugin
+// enables chunked transfer, data may be a chunk, and the message is only
+// processed once its last chunk is delivered. Messages that are too long, are
+// invalid chunks, duplicate an earlier message, can't be deserialized, or fail
+// the size check against the plugin's limits are rejected with an error, and
+// so never reach the plugin. Messages for outcome generation are then
+// processed by the oracle; all other messages are only deserialized and
+// size-checked.
+func (f *Fuzzer[RI]) Message(sender commontypes.OracleID, data []byte) error {
+	if !f.started {
+		return fmt.Errorf("Message called before StartRound")
+	}
+	if !(0 <= int(sender) && int(sender) < f.sharedConfig.N()) {
+		return fmt.Errorf("invalid sender %v", sender)
+	}
+	if len(data) > f.maxRawLength {
+		return fmt.Errorf("message of length %v exceeds maximum message length %v", len(data), f.maxRawLength)
+	}
+	serializedMsg, err := f.inbound.Filter(sender, data)
+	if err != nil {
+		return fmt.Errorf("message dropped: %w", err)
+	}
+	if serializedMsg == nil {
+		// incomplete chunked message
+		return nil
+	}
+	msg, _, _, err := serialization.Deserialize[RI](serializedMsg)
+	if err != nil {
+		return fmt.Errorf("could not deserialize message: %w", err)
+	}
+	if !msg.CheckSize(f.sharedConfig.N(), f.sharedConfig.F, f.pluginLimits, f.oracles[FuzzerOracleID].onchainKeyring.MaxSignatureLength()) {
+		return fmt.Errorf("%v failed size check", protocol.MessageType(msg))
+	}
+
+	msgOutgen, ok := msg.(protocol.MessageToOutcomeGeneration[RI])
+	if !ok {
+		return nil
+	}
+	f.harness.Deliver(msgOutgen, sender)
+
+	if outcome, ok := f.harness.Outcome(); ok && f.reportedSeqNr != f.harness.SeqNr() {
+		f.reportedSeqNr = f.harness.SeqNr()
+		f.reports(f.reportedSeqNr, outcome)
+	}
+	return nil
+}
+
+// reports generates the reports for outcome like
+// reportAttestationState.reports does.
+func (f *Fuzzer[RI]) reports(seqNr uint64, outcome ocr3types.Outcome) {
+	switch {
+	case f.outcomeContextReporter != nil:
+		outctx := ocr3types.OutcomeContext{
+			seqNr,
+			f.committedOutcome,
+			0,
+			0,
+			0,
+			false,
+			ocr3types.MakeOutcomeHash(f.committedOutcome),
+			time.Time{},
+		}
+		_, err := f.outcomeContextReporter.ReportsWithOutcomeContext(f.ctx, outctx, outcome)
+		f.protocolPlugin.check("ReportsWithOutcomeContext", seqNr, err)
+	case f.reportBatcher != nil:
+		batches, err := f.reportBatcher.ReportBatches(f.ctx, seqNr, outcome)
+		f.protocolPlugin.check("ReportBatches", seqNr, err)
+		for i, batch := range batches {
+			if _, err := ocr3types.MakeReportBatch(batch); err != nil {
+				f.recordError(fmt.Errorf("ReportBatches for seqNr %v returned invalid batch %v: %w", seqNr, i, err))
+			}
+		}
+	default:
+		_, _ = f.protocolPlugin.Reports(f.ctx, seqNr, outcome)
+	}
+}
+
+// RoundStart sends a MessageRoundStart with query from the leader of the
+// current round.
+func (f *Fuzzer[RI]) RoundStart(query types.Query) error {
+	if !f.started {
+		return fmt.Errorf("RoundStart called before StartRound")
+	}
+	return f.send(f.harness.Leader(), protocol.MessageRoundStart[RI]{
+		f.harness.Epoch(),
+		f.harness.SeqNr(),
+		query,
+	})
+}
+
+// Observation sends a MessageObservation with observation from sender, which
+// must not be FuzzerOracleID, to the leader of the current round. It is signed
+// by sender for the query of the round, so FuzzerOracleID must lead the round.
+// observedAt is only used if ocr3types.ProtocolFeatureFlagObservationTimestamps
+// is set.
+func (f *Fuzzer[RI]) Observation(sender commontypes.OracleID, observation types.Observation, observedAt time.Time) error {
+	if !f.started {
+		return fmt.Errorf("Observation called before StartRound")
+	}
+	if sender == FuzzerOracleID {
+		return fmt.Errorf("sender must not be oracle %v", FuzzerOracleID)
+	}
+	if f.harness.Leader() != FuzzerOracleID {
+		return fmt.Errorf("oracle %v doesn't lead the current round", FuzzerOracleID)
+	}
+	so, err := f.signObservation(sender, observation, observedAt)
+	if err != nil {
+		return err
+	}
+	return f.send(sender, protocol.MessageObservation[RI]{
+		f.harness.Epoch(),
+		f.harness.SeqNr(),
+		so,
+	})
+}
+
+// Proposal sends a MessageProposal with aos from the leader of the current
+// round. Every observation is signed by its observer for the query of the
+// round, so FuzzerOracleID must have received it already, see RoundStart.
+// Observations attributed to observers outside the cluster get an invalid
+// signature. timestamps holds the timestamps for each observer and is only
+// used if ocr3types.ProtocolFeatureFlagObservationTimestamps is set.
+func (f *Fuzzer[RI]) Proposal(aos []types.AttributedObservation, timestamps map[commontypes.OracleID]ocr3types.ObservationTimestamps) error {
+	if !f.started {
+		return fmt.Errorf("Proposal called before StartRound")
+	}
+	asos := make([]protocol.AttributedSignedObservation, 0, len(aos))
+	for _, ao := range aos {
+		ts := timestamps[ao.Observer]
+		var so protocol.SignedObservation
+		if 0 <= int(ao.Observer) && int(ao.Observer) < f.sharedConfig.N() {
+			var err error
+			so, err = f.signObservation(ao.Observer, ao.Observation, ts.ObservedAt)
+			if err != nil {
+				return err
+			}
+		} else {
+			so = protocol.SignedObservation{ao.Observation, make([]byte, ed25519.SignatureSize), ts.ObservedAt}
+		}
+		asos = append(asos, protocol.AttributedSignedObservation{so, ao.Observer, ts.ReceivedAt})
+	}
+	return f.send(f.harness.Leader(), protocol.MessageProposal[RI]{
+		f.harness.Epoch(),
+		f.harness.SeqNr(),
+		asos,
+	})
+}
+
+func (f *Fuzzer[RI]) signObservation(observer commontypes.OracleID, observation types.Observation, observedAt time.Time) (protocol.SignedObservation, error) {
+	query, ok := f.harness.Query()
+	if !ok {
+		return protocol.SignedObservation{}, fmt.Errorf("oracle %v has no query for the current round yet", FuzzerOracleID)
+	}
+	return protocol.MakeSignedObservation(
+		protocol.OutcomeGenerationID{f.sharedConfig.ConfigDigest, f.harness.Epoch()},
+		f.harness.SeqNr(),
+		query,
+		observation,
+		observedAt,
+		f.oracles[observer].offchainKeyring.OffchainSign,
+	)
+}
+
+func (f *Fuzzer[RI]) send(sender commontypes.OracleID, msg protocol.Message[RI]) error {
+	data, _, err := serialization.Serialize[RI](msg, time.Time{})
+	if err != nil {
+		return fmt.Errorf("could not serialize %v: %w", protocol.MessageType(msg), err)
+	}
+	messageID := f.nextMessageID
+	f.nextMessageID++
+	for _, chunk := range shim.SplitIntoOCR3Chunks(f.chunkedTransfer, data, messageID) {
+		if err := f.Message(sender, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Err returns the findings since the last call of StartRound, i.e. calls in
+// which the plugin exceeded its limits, invalid report batches, and critical
+// messages logged by the oracle, or nil if there are none.
+func (f *Fuzzer[RI]) Err() error {
+	return multierr.Combine(f.errs...)
+}
+
+// Close closes the plugin.
+func (f *Fuzzer[RI]) Close() error {
+	if f.inbound != nil {
+		f.inbound.Close()
+	}
+	return f.plugin.Close()
+}
+
+func (f *Fuzzer[RI]) recordError(err error) {
+	f.errs = append(f.errs, err)
+}
@@ -0,0 +1,606 @@
+// Package ocr3testing runs several OCR3 oracles in a single process, so that
+// ReportingPlugin authors can test their plugin end-to-end against the real
+// protocol without setting up contracts, databases, or a peer-to-peer network.
+//
+// A Cluster connects N oracles through the same in-memory network that
+// libocr's own model checker uses. Each oracle runs its own instance of the
+// plugin created by your ReportingPluginFactory. You can inject latency into
+// the network and partition it while the cluster is running. Every report that
+// an oracle would transmit is recorded together with its signatures, which are
+// checked as it is recorded.
+//
+//	cluster, err := ocr3testing.NewCluster(ocr3testing.DefaultConfig(4, 1), factory)
+//	...
+//	if err := cluster.Start(ctx); err != nil { ... }
+//	defer cluster.Close()
+//	if err := cluster.WaitForSeqNr(ctx, 10); err != nil { ... }
+//	if err := cluster.Err(); err != nil { ... }
+//
+// By default, the protocol's timers, e.g. DeltaProgress and DeltaRound, use the
+// wall clock, so tests run in real time; pick short durations in Config. To
+// control them, and the timestamps attached to observations, pass a Clock as
+// Config.TimeSource and move it forward explicitly. Network latencies and the
+// timeouts of ReportingPlugin calls always use the wall clock.
+//
+// A Cluster wraps the plugin with the same shims as a managed oracle, i.e. it
+// validates the ReportingPluginInfo, enforces its limits, and uses the optional
+// ocr3types interfaces the plugin implements, such as ReportBatcher. Plugin
+// scheduling and trace recording are not available, and plugins that exchange
+// blobs are rejected, since blobs need a network that serializes messages.
+//
+// A Fuzzer drives a single oracle synchronously instead, so that fuzz targets
+// can feed it the messages a byzantine peer could send and have the plugin
+// process them exactly as in production, with the same limits enforced.
+package ocr3testing
+
+import (
+	"context"
+	"crypto/ed25519"
+	cryptorand "crypto/rand"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/databases/memorydb"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/config/ocr3config"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/managed"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/managed/limits"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/simnet"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/shim"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+	"go.uber.org/multierr"
+)
+
+// Config configures a Cluster. The protocol parameters have the same meaning
+// as the fields of the same name in ocr3confighelper.PublicConfig.
+type Config struct {
+	// Number of oracles and upper bound on the number of faulty oracles
+	N int
+	F int
+
+	DeltaProgress               time.Duration
+	DeltaResend                 time.Duration
+	DeltaInitial                time.Duration
+	DeltaRound                  time.Duration
+	DeltaGrace                  time.Duration
+	DeltaCertifiedCommitRequest time.Duration
+	DeltaStage                  time.Duration
+	RMax                        uint64
+	S                           []int
+
+	ReportingPluginConfig []byte
+
+	MaxDurationQuery                        time.Duration
+	MaxDurationObservation                  time.Duration
+	MaxDurationShouldAcceptAttestedReport   time.Duration
+	MaxDurationShouldTransmitAcceptedReport time.Duration
+
+	FeatureFlags  ocr3types.FeatureFlags
+	OnchainConfig []byte
+
+	// Every message is delayed by a duration drawn uniformly at random from
+	// [MinLatency, MaxLatency). Can be changed later with Cluster.SetLatency.
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// Seed for the network's latencies
+	Seed int64
+
+	// Source of the timestamps attached to observations, and of the
+	// protocol's timers if it implements ocr3types.TimerSource. If nil, the
+	// oracles use the wall clock. See Clock for one you can control.
+	TimeSource ocr3types.TimeSource
+	// Receives the log messages of all oracles. If nil, they are discarded.
+	// Critical messages are reported by Cluster.Err regardless.
+	Logger commontypes.Logger
+
+	// Only used by a Fuzzer, which drops duplicate messages like an oracle
+	// whose LocalConfig.InboundMessageDeduplication is set to this. The
+	// in-memory network of a Cluster doesn't serialize messages.
+	InboundMessageDeduplication types.MessageDeduplicationConfig
+}
+
+// DefaultConfig returns a Config for n oracles tolerating f faulty ones, with
+// durations short enough for rounds to complete every few tens of
+// milliseconds. Every oracle transmits every report right away.
+func DefaultConfig(n int, f int) Config {
+	return Config{
+		n,
+		f,
+
+		2 * time.Second,        // DeltaProgress
+		200 * time.Millisecond, // DeltaResend
+		1 * time.Second,        // DeltaInitial
+		50 * time.Millisecond,  // DeltaRound
+		10 * time.Millisecond,  // DeltaGrace
+		100 * time.Millisecond, // DeltaCertifiedCommitRequest
+		50 * time.Millisecond,  // DeltaStage
+		100,                    // RMax
+		[]int{n},
+
+		nil, // ReportingPluginConfig
+
+		100 * time.Millisecond, // MaxDurationQuery
+		100 * time.Millisecond, // MaxDurationObservation
+		100 * time.Millisecond, // MaxDurationShouldAcceptAttestedReport
+		100 * time.Millisecond, // MaxDurationShouldTransmitAcceptedReport
+
+		0,   // FeatureFlags
+		nil, // OnchainConfig
+
+		0,                    // MinLatency
+		5 * time.Millisecond, // MaxLatency
+		0,                    // Seed
+
+		nil, // TimeSource
+		nil, // Logger
+
+		types.MessageDeduplicationConfig{}, // InboundMessageDeduplication
+	}
+}
+
+// Transmission is a report that an oracle passed to its ContractTransmitter.
+type Transmission[RI any] struct {
+	Transmitter  commontypes.OracleID
+	ConfigDigest types.ConfigDigest
+	SeqNr        uint64
+	Report       ocr3types.ReportWithInfo[RI]
+	Signatures   []types.AttributedOnchainSignature
+}
+
+type oracle[RI any] struct {
+	offchainKeyring simnet.OffchainKeyring
+	onchainKeyring  simnet.OnchainKeyring[RI]
+	logger          loghelper.LoggerWithContext
+}
+
+// Cluster is a set of oracles running a ReportingPlugin in-process. All its
+// functions are thread-safe.
+type Cluster[RI any] struct {
+	config       Config
+	sharedConfig ocr3config.SharedConfig
+	factory      ocr3types.ReportingPluginFactoryV2[RI]
+	oracles      []oracle[RI]
+	net          *simnet.Network[RI]
+
+	mu sync.Mutex
+	// protected by mu
+	started       bool
+	closed        bool
+	cancel        context.CancelFunc
+	plugins       []ocr3types.ReportingPluginV2[RI]
+	transmissions []Transmission[RI]
+	errs          []error
+	// closed and replaced whenever transmissions or errs change
+	chChanged chan struct{}
+
+	subs subprocesses.Subprocesses
+}
+
+// NewCluster returns a Cluster of config.N oracles that each run a plugin
+// created by factory. Wrap a v1 factory with
+// ocr3types.NewReportingPluginFactoryV2FromV1. The oracles only start running
+// once you call Start.
+func NewCluster[RI any](config Config, factory ocr3types.ReportingPluginFactoryV2[RI]) (*Cluster[RI], error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	sharedConfig, oracles, err := makeSharedConfigAndOracles[RI](config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cluster[RI]{
+		config,
+		sharedConfig,
+		factory,
+		oracles,
+		simnet.NewNetwork[RI](
+			config.N,
+			simnet.Params{config.MinLatency, config.MaxLatency, 0, 0},
+			rand.New(rand.NewSource(config.Seed)),
+		),
+
+		sync.Mutex{},
+		false,
+		false,
+		nil,
+		nil,
+		nil,
+		nil,
+		make(chan struct{}),
+
+		subprocesses.Subprocesses{},
+	}, nil
+}
+
+func validateConfig(config Config) error {
+	if !(0 < config.F && 3*config.F < config.N && config.N <= types.MaxOracles) {
+		return fmt.Errorf("invalid oracle set: n=%v f=%v, need 0 < f, 3f < n, and n <= %v", config.N, config.F, types.MaxOracles)
+	}
+	sumS := 0
+	for _, s := range config.S {
+		if s < 0 {
+			return fmt.Errorf("transmission schedule S contains negative entry %v", s)
+		}
+		sumS += s
+	}
+	if sumS > config.N {
+		return fmt.Errorf("transmission schedule S schedules %v oracles, but there are only %v", sumS, config.N)
+	}
+	return nil
+}
+
+func makeSharedConfigAndOracles[RI any](cfg Config) (ocr3config.SharedConfig, []oracle[RI], error) {
+	var sharedSecret [config.SharedSecretSize]byte
+	if _, err := cryptorand.Read(sharedSecret[:]); err != nil {
+		return ocr3config.SharedConfig{}, nil, err
+	}
+
+	var configDigest types.ConfigDigest
+	if _, err := cryptorand.Read(configDigest[:]); err != nil {
+		return ocr3config.SharedConfig{}, nil, err
+	}
+
+	identities := make([]config.OracleIdentity, 0, cfg.N)
+	oracles := make([]oracle[RI], 0, cfg.N)
+	for i := 0; i < cfg.N; i++ {
+		_, offchainPrivateKey, err := ed25519.GenerateKey(cryptorand.Reader)
+		if err != nil {
+			return ocr3config.SharedConfig{}, nil, err
+		}
+		_, onchainPrivateKey, err := ed25519.GenerateKey(cryptorand.Reader)
+		if err != nil {
+			return ocr3config.SharedConfig{}, nil, err
+		}
+
+		offchainKeyring := simnet.OffchainKeyring{offchainPrivateKey}
+		onchainKeyring := simnet.OnchainKeyring[RI]{onchainPrivateKey}
+
+		identities = append(identities, config.OracleIdentity{
+			offchainKeyring.OffchainPublicKey(),
+			onchainKeyring.PublicKey(),
+			fmt.Sprintf("oracle-%d", i),
+			types.Account(fmt.Sprintf("account-%d", i)),
+			types.OffchainPublicKey{},
+		})
+		oracles = append(oracles, oracle[RI]{offchainKeyring, onchainKeyring, nil})
+	}
+
+	return ocr3config.SharedConfig{
+		ocr3config.PublicConfig{
+			cfg.DeltaProgress,
+			cfg.DeltaResend,
+			cfg.DeltaInitial,
+			cfg.DeltaRound,
+			cfg.DeltaGrace,
+			cfg.DeltaCertifiedCommitRequest,
+			cfg.DeltaStage,
+			cfg.RMax,
+			cfg.S,
+			identities,
+			cfg.ReportingPluginConfig,
+			cfg.MaxDurationQuery,
+			cfg.MaxDurationObservation,
+			cfg.MaxDurationShouldAcceptAttestedReport,
+			cfg.MaxDurationShouldTransmitAcceptedReport,
+			cfg.FeatureFlags,
+			0,   // DeltaGraceMin
+			0,   // DeltaGraceMax
+			nil, // LeaderWeights
+			0,   // DeltaRoundMin
+			0,   // DeltaRoundMax
+			cfg.F,
+			cfg.OnchainConfig,
+			configDigest,
+		},
+		&sharedSecret,
+	}, oracles, nil
+}
+
+// ConfigDigest returns the config digest of the cluster, which is random.
+func (c *Cluster[RI]) ConfigDigest() types.ConfigDigest {
+	return c.sharedConfig.ConfigDigest
+}
+
+// OnchainPublicKeys returns the onchain public keys of all oracles, indexed by
+// oracle id.
+func (c *Cluster[RI]) OnchainPublicKeys() []types.OnchainPublicKey {
+	pks := make([]types.OnchainPublicKey, 0, len(c.oracles))
+	for _, o := range c.oracles {
+		pks = append(pks, o.onchainKeyring.PublicKey())
+	}
+	return pks
+}
+
+// Start creates a plugin for every oracle and starts the oracles. The oracles
+// run until ctx is done or Close is called. Start fails if the factory fails
+// or returns an invalid ReportingPluginInfo, in which case no oracle is
+// started.
+func (c *Cluster[RI]) Start(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.started || c.closed {
+		return fmt.Errorf("cluster has already been started or closed")
+	}
+
+	rootLogger := c.config.Logger
+	if rootLogger == nil {
+		rootLogger = discardingLogger{}
+	}
+
+	plugins := make([]ocr3types.ReportingPluginV2[RI], 0, len(c.oracles))
+	protocolReportingPlugins := make([]ocr3types.ReportingPluginV2[RI], 0, len(c.oracles))
+	metrics := make([]*protocol.Metrics, 0, len(c.oracles))
+	for i := range c.oracles {
+		id := commontypes.OracleID(i)
+		c.oracles[i].logger = loghelper.MakeRootLoggerWithContext(criticalRecordingLogger{rootLogger, c.recordError, id}).MakeChild(commontypes.LogFields{"oid": id})
+
+		plugin, info, err := newReportingPlugin(ctx, c.factory, c.sharedConfig, c.oracles, id)
+		if err != nil {
+			for _, plugin := range plugins {
+				_ = plugin.Close()
+			}
+			return err
+		}
+		plugins = append(plugins, plugin)
+
+		m := protocol.NewMetrics(nil, c.oracles[i].logger)
+		protocolReportingPlugins = append(protocolReportingPlugins, wrapReportingPlugin(c.sharedConfig, plugin, info, c.oracles[i].logger, m))
+		metrics = append(metrics, m)
+	}
+
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.plugins = plugins
+	c.started = true
+
+	localConfig := types.LocalConfig{
+		DatabaseTimeout:                    time.Second,
+		ContractTransmitterTransmitTimeout: time.Second,
+	}
+	for i, o := range c.oracles {
+		id := commontypes.OracleID(i)
+		o := o
+		protocolReportingPlugin := protocolReportingPlugins[i]
+		// nil if the plugin doesn't implement RoundAbandonmentListener
+		roundAbandonmentListener, _ := c.plugins[i].(ocr3types.RoundAbandonmentListener)
+		m := metrics[i]
+		c.subs.Go(func() {
+			protocol.RunOracle[RI](
+				ctx,
+				nil,
+				nil,
+				c.sharedConfig,
+				transmitter[RI]{c, id},
+				&shim.SerializingOCR3Database{memorydb.New(), nil},
+				id,
+				nil,
+				nil,
+				nil,
+				localConfig,
+				o.logger,
+				m,
+				c.net.Endpoint(id),
+				o.offchainKeyring,
+				o.onchainKeyring,
+				protocolReportingPlugin,
+				roundAbandonmentListener,
+				discardingTelemetrySender{},
+				c.config.TimeSource,
+				nil,
+				nil,
+			)
+		})
+	}
+	return nil
+}
+
+func newReportingPlugin[RI any](ctx context.Context, factory ocr3types.ReportingPluginFactoryV2[RI], sharedConfig ocr3config.SharedConfig, oracles []oracle[RI], id commontypes.OracleID) (ocr3types.ReportingPluginV2[RI], ocr3types.ReportingPluginInfo, error) {
+	plugin, info, err := factory.NewReportingPlugin(ctx, ocr3types.ReportingPluginConfig{
+		sharedConfig.ConfigDigest,
+		id,
+		sharedConfig.N(),
+		sharedConfig.F,
+		sharedConfig.OnchainConfig,
+		sharedConfig.ReportingPluginConfig,
+		sharedConfig.DeltaRound,
+		sharedConfig.MaxDurationQuery,
+		sharedConfig.MaxDurationObservation,
+		sharedConfig.MaxDurationShouldAcceptAttestedReport,
+		sharedConfig.MaxDurationShouldTransmitAcceptedReport,
+		sharedConfig.FeatureFlags,
+	})
+	if err != nil {
+		return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("error during NewReportingPlugin() for oracle %v: %w", id, err)
+	}
+	if err := multierr.Combine(
+		managed.ValidateOCR3ReportingPluginInfo(sharedConfig, plugin, info),
+		limitsError(sharedConfig.PublicConfig, info, oracles[id].onchainKeyring.MaxSignatureLength()),
+		blobsError(info),
+	); err != nil {
+		_ = plugin.Close()
+		return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("invalid ReportingPluginInfo for oracle %v: %w", id, err)
+	}
+	return plugin, info, nil
+}
+
+// wrapReportingPlugin wraps plugin the way a managed oracle does before
+// passing it to the protocol.
+func wrapReportingPlugin[RI any](sharedConfig ocr3config.SharedConfig, plugin ocr3types.ReportingPluginV2[RI], info ocr3types.ReportingPluginInfo, logger loghelper.LoggerWithContext, metrics *protocol.Metrics) ocr3types.ReportingPluginV2[RI] {
+	return managed.WrapOCR3ReportingPlugin[RI](sharedConfig, plugin, info, logger, metrics, nil, nil, nil, nil)
+}
+
+func blobsError(info ocr3types.ReportingPluginInfo) error {
+	if info.Limits.MaxBlobLength != 0 {
+		return fmt.Errorf("MaxBlobLength is %v, but ocr3testing doesn't support blobs", info.Limits.MaxBlobLength)
+	}
+	return nil
+}
+
+func limitsError(publicConfig ocr3config.PublicConfig, info ocr3types.ReportingPluginInfo, maxSigLen int) error {
+	_, err := limits.OCR3Limits(publicConfig, info.Limits, info.ChunkedTransfer, maxSigLen)
+	return err
+}
+
+// Close stops all oracles, then closes their plugins. It returns the errors
+// returned by the plugins' Close functions.
+func (c *Cluster[RI]) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	cancel, plugins := c.cancel, c.plugins
+	c.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	c.subs.Wait()
+	c.net.Close()
+
+	var err error
+	for i, plugin := range plugins {
+		if closeErr := plugin.Close(); closeErr != nil {
+			err = multierr.Append(err, fmt.Errorf("error during Close() of plugin of oracle %v: %w", i, closeErr))
+		}
+	}
+	return err
+}
+
+// SetLatency changes the latency of all messages sent from now on, see
+// Config.MinLatency.
+func (c *Cluster[RI]) SetLatency(minLatency time.Duration, maxLatency time.Duration) {
+	c.net.SetParams(simnet.Params{minLatency, maxLatency, 0, 0})
+}
+
+// Partition splits the network into groups, so that oracles can only reach
+// oracles in their own group. Every oracle not listed in any group is
+// isolated. Messages in flight across the new boundaries are lost. Partition
+// replaces any earlier partition.
+//
+// For example, with n=4 and f=1, Partition([]commontypes.OracleID{0, 1, 2})
+// isolates oracle 3, and the remaining oracles keep making progress without
+// it. Partition([]commontypes.OracleID{0, 1}, []commontypes.OracleID{2, 3})
+// stalls the protocol until Heal is called.
+func (c *Cluster[RI]) Partition(groups ...[]commontypes.OracleID) {
+	c.net.Partition(groups...)
+}
+
+// Heal undoes Partition.
+func (c *Cluster[RI]) Heal() {
+	c.net.Heal()
+}
+
+// Transmissions returns all transmissions recorded so far, in the order in
+// which they were recorded. Since S in Config usually includes several
+// oracles, the same report is typically transmitted several times.
+func (c *Cluster[RI]) Transmissions() []Transmission[RI] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Transmission[RI]{}, c.transmissions...)
+}
+
+// WaitFor blocks until condition, which is called with all transmissions
+// recorded so far whenever a transmission is recorded, returns true, or until
+// ctx is done.
+func (c *Cluster[RI]) WaitFor(ctx context.Context, condition func([]Transmission[RI]) bool) error {
+	for {
+		c.mu.Lock()
+		transmissions := append([]Transmission[RI]{}, c.transmissions...)
+		chChanged := c.chChanged
+		c.mu.Unlock()
+
+		if condition(transmissions) {
+			return nil
+		}
+
+		select {
+		case <-chChanged:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// WaitForSeqNr blocks until a report with sequence number seqNr or higher
+// has been transmitted, or until ctx is done.
+func (c *Cluster[RI]) WaitForSeqNr(ctx context.Context, seqNr uint64) error {
+	return c.WaitFor(ctx, func(transmissions []Transmission[RI]) bool {
+		for _, t := range transmissions {
+			if t.SeqNr >= seqNr {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Err returns all problems found so far: messages that oracles logged at
+// Critical level, which indicate a bug in the protocol or a violated
+// assumption, and transmissions whose signatures don't check out. It returns
+// nil if there are none.
+func (c *Cluster[RI]) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return multierr.Combine(c.errs...)
+}
+
+// VerifyTransmission checks that t carries valid signatures from at least F+1
+// distinct oracles over its report under the cluster's config digest. The
+// Cluster runs this check on every transmission it records and reports
+// failures through Err.
+func (c *Cluster[RI]) VerifyTransmission(t Transmission[RI]) error {
+	if t.ConfigDigest != c.sharedConfig.ConfigDigest {
+		return fmt.Errorf("transmission has config digest %v, expected %v", t.ConfigDigest, c.sharedConfig.ConfigDigest)
+	}
+	if len(t.Signatures) < c.sharedConfig.F+1 {
+		return fmt.Errorf("transmission of seqNr %v has %v signatures, need at least f+1 (%v)", t.SeqNr, len(t.Signatures), c.sharedConfig.F+1)
+	}
+	seen := make(map[commontypes.OracleID]bool, len(t.Signatures))
+	for _, as := range t.Signatures {
+		if int(as.Signer) >= len(c.oracles) {
+			return fmt.Errorf("transmission of seqNr %v has signature from unknown oracle %v", t.SeqNr, as.Signer)
+		}
+		if seen[as.Signer] {
+			return fmt.Errorf("transmission of seqNr %v has duplicate signature from oracle %v", t.SeqNr, as.Signer)
+		}
+		seen[as.Signer] = true
+		keyring := c.oracles[as.Signer].onchainKeyring
+		if !keyring.Verify(keyring.PublicKey(), t.ConfigDigest, t.SeqNr, t.Report, as.Signature) {
+			return fmt.Errorf("transmission of seqNr %v has invalid signature from oracle %v", t.SeqNr, as.Signer)
+		}
+	}
+	return nil
+}
+
+func (c *Cluster[RI]) recordLocked() {
+	close(c.chChanged)
+	c.chChanged = make(chan struct{})
+}
+
+func (c *Cluster[RI]) recordTransmission(t Transmission[RI]) {
+	err := c.VerifyTransmission(t)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.transmissions = append(c.transmissions, t)
+	if err != nil {
+		c.errs = append(c.errs, fmt.Errorf("oracle %v transmitted invalid report: %w", t.Transmitter, err))
+	}
+	c.recordLocked()
+}
+
+func (c *Cluster[RI]) recordError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+	c.recordLocked()
+}
@@ -0,0 +1,272 @@
+// Package ocr3trace records what an OCR3 oracle saw and what its
+// ReportingPlugin returned, and replays the recorded plugin calls offline, so
+// that incidents in which the outcomes of honest oracles diverge can be
+// debugged from the traces of the affected operators instead of by
+// reproducing them live.
+//
+// Recording is gated: an oracle only records a trace if a Recorder is passed
+// in OCR3OracleArgs.TraceRecorder. A trace is a sequence of JSON-encoded
+// Entries, one per line, and covers:
+//
+//   - the ReportingPluginConfig of every protocol instance the oracle runs,
+//   - every protocol message the oracle receives, serialized as on the wire,
+//   - protocol events, e.g. rounds starting, outcomes committing, and the
+//     protocol's timers expiring,
+//   - every call of a ReportingPlugin function with its arguments and
+//     return values, including the optional ocr3types.ReportBatcher,
+//     ocr3types.OutcomeContextReporter, and ocr3types.ReportDecisionBatcher
+//     functions.
+//
+// Replay creates a fresh plugin from the recorded config and calls the
+// functions that the protocol requires to be deterministic, i.e.
+// ValidateObservation, ObservationQuorum, Outcome, and Reports (or
+// ReportBatches or ReportsWithOutcomeContext), with the recorded arguments,
+// reporting every call whose result differs from the recording. It also
+// checks that the outcome digest of every OutcomeComputed event matches the
+// replayed outcome. Query, Observation, ShouldAcceptAttestedReport, and
+// ShouldTransmitAcceptedReport depend on the world outside the oracle and are
+// not replayed; their recorded results are only there for inspection.
+//
+// Replay doesn't re-execute the protocol's message handling. That would need
+// the oracle's private keys, which a trace deliberately doesn't contain.
+// Instead, the recorded messages, events, and timer expirations show the order
+// in which this oracle saw things happen.
+//
+// Traces contain everything the plugin sees, including observations and
+// reports, and grow by several entries per round, so only record them while
+// investigating an incident, and treat them as sensitively as the data the
+// plugin processes.
+package ocr3trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// EntryKind identifies what an Entry records.
+type EntryKind string
+
+const (
+	// A protocol instance started. Entry.Config is set.
+	EntryKindConfig EntryKind = "config"
+	// The oracle received a protocol message. Entry.Sender, MessageType and
+	// Message are set.
+	EntryKindMessage EntryKind = "message"
+	// Something happened in the protocol. Entry.Event and Fields are set.
+	EntryKindEvent EntryKind = "event"
+	// The Recorder fell behind and dropped entries. Entry.Dropped is set.
+	EntryKindDropped EntryKind = "dropped"
+
+	// The oracle called a plugin function. The fields holding its arguments
+	// and return values are set, as well as Error if it failed.
+	EntryKindQuery                        EntryKind = "Query"
+	EntryKindObservation                  EntryKind = "Observation"
+	EntryKindValidateObservation          EntryKind = "ValidateObservation"
+	EntryKindObservationQuorum            EntryKind = "ObservationQuorum"
+	EntryKindOutcome                      EntryKind = "Outcome"
+	EntryKindReports                      EntryKind = "Reports"
+	EntryKindReportBatches                EntryKind = "ReportBatches"
+	EntryKindReportsWithOutcomeContext    EntryKind = "ReportsWithOutcomeContext"
+	EntryKindShouldAcceptAttestedReport   EntryKind = "ShouldAcceptAttestedReport"
+	EntryKindShouldTransmitAcceptedReport EntryKind = "ShouldTransmitAcceptedReport"
+)
+
+// Entry is a single record of a trace. Which fields are set depends on Kind.
+type Entry struct {
+	Time time.Time
+	Kind EntryKind
+
+	Config *ocr3types.ReportingPluginConfig `json:",omitempty"`
+
+	Sender      *commontypes.OracleID `json:",omitempty"`
+	MessageType string                `json:",omitempty"`
+	Message     []byte                `json:",omitempty"`
+
+	Event  string                `json:",omitempty"`
+	Fields commontypes.LogFields `json:",omitempty"`
+
+	OutcomeContext         *ocr3types.OutcomeContext     `json:",omitempty"`
+	SeqNr                  uint64                        `json:",omitempty"`
+	Query                  types.Query                   `json:",omitempty"`
+	Observation            types.Observation             `json:",omitempty"`
+	AttributedObservation  *types.AttributedObservation  `json:",omitempty"`
+	AttributedObservations []types.AttributedObservation `json:",omitempty"`
+	Quorum                 *ocr3types.Quorum             `json:",omitempty"`
+	Outcome                ocr3types.Outcome             `json:",omitempty"`
+	Reports                []ReportEntry                 `json:",omitempty"`
+	ReportBatches          [][]ReportEntry               `json:",omitempty"`
+	Report                 *ReportEntry                  `json:",omitempty"`
+	Result                 *bool                         `json:",omitempty"`
+	Error                  string                        `json:",omitempty"`
+
+	Dropped uint64 `json:",omitempty"`
+}
+
+// ReportEntry is a report with its info, which is stored as JSON so that
+// traces don't depend on the plugin's report info type.
+type ReportEntry struct {
+	Report types.Report
+	Info   json.RawMessage `json:",omitempty"`
+}
+
+// MakeReportEntry encodes rwi as a ReportEntry. Info is omitted if it can't be
+// encoded as JSON.
+func MakeReportEntry[RI any](rwi ocr3types.ReportWithInfo[RI]) ReportEntry {
+	info, err := json.Marshal(rwi.Info)
+	if err != nil {
+		info = nil
+	}
+	return ReportEntry{rwi.Report, info}
+}
+
+// MakeReportBatchEntries encodes each report of batches with MakeReportEntry,
+// keeping the batch structure.
+func MakeReportBatchEntries[RI any](batches [][]ocr3types.ReportWithInfo[RI]) [][]ReportEntry {
+	var entries [][]ReportEntry
+	for _, batch := range batches {
+		batchEntries := make([]ReportEntry, 0, len(batch))
+		for _, rwi := range batch {
+			batchEntries = append(batchEntries, MakeReportEntry(rwi))
+		}
+		entries = append(entries, batchEntries)
+	}
+	return entries
+}
+
+// Number of entries a Recorder buffers before it starts dropping them
+const recorderBufferSize = 4096
+
+// Recorder writes Entries to an io.Writer. The oracle calls Record from the
+// goroutines that run the protocol, so Record only hands entries to a
+// goroutine that encodes and writes them, and never blocks: if the writer
+// falls behind by more than a few thousand entries, entries are dropped, and
+// the trace records how many (see EntryKindDropped). Close the Recorder after
+// closing the oracle to flush the remaining entries. All its functions are
+// thread-safe.
+type Recorder struct {
+	chEntries chan Entry
+	chDone    chan struct{}
+
+	mu      sync.Mutex
+	closed  bool
+	dropped uint64
+	err     error
+}
+
+// NewRecorder returns a Recorder that writes the trace to w, and starts the
+// goroutine doing so.
+func NewRecorder(w io.Writer) *Recorder {
+	r := &Recorder{
+		make(chan Entry, recorderBufferSize),
+		make(chan struct{}),
+
+		sync.Mutex{},
+		false,
+		0,
+		nil,
+	}
+	go r.write(json.NewEncoder(w))
+	return r
+}
+
+func (r *Recorder) write(encoder *json.Encoder) {
+	defer close(r.chDone)
+	for entry := range r.chEntries {
+		if err := encoder.Encode(entry); err != nil {
+			r.mu.Lock()
+			if r.err == nil {
+				r.err = err
+			}
+			r.mu.Unlock()
+		}
+	}
+}
+
+// Record appends entry to the trace, setting its Time if it is zero. Since
+// entry is encoded asynchronously, the caller must not modify the slices it
+// references afterwards. After the first write error, and after Close, Record
+// discards all entries, see Err.
+func (r *Recorder) Record(entry Entry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.err != nil {
+		return
+	}
+	if r.dropped != 0 {
+		select {
+		case r.chEntries <- Entry{Time: entry.Time, Kind: EntryKindDropped, Dropped: r.dropped}:
+			r.dropped = 0
+		default:
+			r.dropped++
+			return
+		}
+	}
+	select {
+	case r.chEntries <- entry:
+	default:
+		r.dropped++
+	}
+}
+
+// Close writes all entries recorded so far and stops the Recorder. It returns
+// the same error as Err. It doesn't close the underlying io.Writer.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	alreadyClosed := r.closed
+	r.closed = true
+	dropped := r.dropped
+	r.dropped = 0
+	r.mu.Unlock()
+
+	if !alreadyClosed {
+		// Record no longer sends once closed is set, so we can block here
+		if dropped != 0 {
+			r.chEntries <- Entry{Time: time.Now(), Kind: EntryKindDropped, Dropped: dropped}
+		}
+		close(r.chEntries)
+	}
+	<-r.chDone
+	return r.Err()
+}
+
+// Err returns the first error encountered while writing the trace, if any.
+// The trace is incomplete from that point on.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// maxEntryLength bounds the length of a single line of a trace when reading
+// it. Entries holding several observations or reports may be large.
+const maxEntryLength = 64 * 1024 * 1024
+
+// ReadTrace reads all Entries of a trace.
+func ReadTrace(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxEntryLength)
+	for line := 1; scanner.Scan(); line++ {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("line %v: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,242 @@
+package ocr3trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/ocr3/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"go.uber.org/multierr"
+)
+
+// Divergence is a plugin call whose replayed result differs from the
+// recorded one, or an OutcomeComputed event whose outcome digest differs from
+// that of the replayed Outcome call. Replayed only has the result fields and
+// Error set, or for events, the outcomeDigest field.
+type Divergence struct {
+	// Index of the call's Entry in the trace, starting at zero
+	Index    int
+	Recorded Entry
+	Replayed Entry
+}
+
+func (d Divergence) String() string {
+	return fmt.Sprintf("entry %v: %v for seqNr %v diverged from recording", d.Index, d.Recorded.Kind, entrySeqNr(d.Recorded))
+}
+
+func entrySeqNr(entry Entry) uint64 {
+	if entry.OutcomeContext != nil {
+		return entry.OutcomeContext.SeqNr
+	}
+	return entry.SeqNr
+}
+
+// Replay reads a trace from r and replays the deterministic plugin calls in it
+// against plugins created by factory, one per recorded config, see the package
+// documentation. Calls recorded before the first config are skipped. Replay
+// returns the calls whose results diverged, in trace order, and an error if
+// the trace can't be read or a plugin can't be created.
+//
+// Errors are compared by message, so the deterministic plugin functions must
+// also fail deterministically.
+func Replay[RI any](ctx context.Context, r io.Reader, factory ocr3types.ReportingPluginFactoryV2[RI]) (divergences []Divergence, err error) {
+	entries, err := ReadTrace(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not read trace: %w", err)
+	}
+
+	var plugin ocr3types.ReportingPluginV2[RI]
+	// latest replayed outcome for each seqNr under the current config
+	var outcomes map[uint64]ocr3types.Outcome
+	defer func() {
+		if plugin != nil {
+			err = multierr.Append(err, plugin.Close())
+		}
+	}()
+
+	for i, entry := range entries {
+		if entry.Kind == EntryKindConfig {
+			if entry.Config == nil {
+				return divergences, fmt.Errorf("entry %v: config entry without Config", i)
+			}
+			if plugin != nil {
+				if err := plugin.Close(); err != nil {
+					return divergences, fmt.Errorf("entry %v: error during Close(): %w", i, err)
+				}
+				plugin = nil
+			}
+			plugin, _, err = factory.NewReportingPlugin(ctx, *entry.Config)
+			if err != nil {
+				return divergences, fmt.Errorf("entry %v: error during NewReportingPlugin(): %w", i, err)
+			}
+			outcomes = map[uint64]ocr3types.Outcome{}
+			continue
+		}
+		if plugin == nil {
+			continue
+		}
+
+		if entry.Kind == EntryKindEvent {
+			if replayed, ok := checkOutcomeComputed(entry, outcomes); !ok {
+				divergences = append(divergences, Divergence{i, entry, replayed})
+			}
+			continue
+		}
+
+		replayed, ok, err := replayEntry(ctx, plugin, entry)
+		if err != nil {
+			return divergences, fmt.Errorf("entry %v: %w", i, err)
+		}
+		if ok && !sameResult(entry, replayed) {
+			divergences = append(divergences, Divergence{i, entry, replayed})
+		}
+		if ok && entry.Kind == EntryKindOutcome && replayed.Error == "" {
+			outcomes[entry.OutcomeContext.SeqNr] = replayed.Outcome
+		}
+	}
+	return divergences, nil
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// replayEntry returns false if entry isn't a call of a deterministic plugin
+// function.
+func replayEntry[RI any](ctx context.Context, plugin ocr3types.ReportingPluginV2[RI], entry Entry) (Entry, bool, error) {
+	switch entry.Kind {
+	case EntryKindValidateObservation:
+		if entry.OutcomeContext == nil || entry.AttributedObservation == nil {
+			return Entry{}, false, fmt.Errorf("incomplete %v entry", entry.Kind)
+		}
+		err := plugin.ValidateObservation(ctx, *entry.OutcomeContext, entry.Query, *entry.AttributedObservation)
+		return Entry{Kind: entry.Kind, Error: errorString(err)}, true, nil
+	case EntryKindObservationQuorum:
+		if entry.OutcomeContext == nil {
+			return Entry{}, false, fmt.Errorf("incomplete %v entry", entry.Kind)
+		}
+		quorum, err := plugin.ObservationQuorum(ctx, *entry.OutcomeContext, entry.Query)
+		replayed := Entry{Kind: entry.Kind, Error: errorString(err)}
+		if err == nil {
+			replayed.Quorum = &quorum
+		}
+		return replayed, true, nil
+	case EntryKindOutcome:
+		if entry.OutcomeContext == nil {
+			return Entry{}, false, fmt.Errorf("incomplete %v entry", entry.Kind)
+		}
+		outcome, err := plugin.Outcome(ctx, *entry.OutcomeContext, entry.Query, entry.AttributedObservations)
+		return Entry{Kind: entry.Kind, Outcome: outcome, Error: errorString(err)}, true, nil
+	case EntryKindReports:
+		reports, err := plugin.Reports(ctx, entry.SeqNr, entry.Outcome)
+		replayed := Entry{Kind: entry.Kind, Error: errorString(err)}
+		for _, rwi := range reports {
+			replayed.Reports = append(replayed.Reports, MakeReportEntry(rwi))
+		}
+		return replayed, true, nil
+	case EntryKindReportBatches:
+		reportBatcher, ok := plugin.(ocr3types.ReportBatcher[RI])
+		if !ok {
+			return Entry{}, false, fmt.Errorf("%v entry, but plugin doesn't implement ReportBatcher", entry.Kind)
+		}
+		batches, err := reportBatcher.ReportBatches(ctx, entry.SeqNr, entry.Outcome)
+		return Entry{Kind: entry.Kind, ReportBatches: MakeReportBatchEntries(batches), Error: errorString(err)}, true, nil
+	case EntryKindReportsWithOutcomeContext:
+		if entry.OutcomeContext == nil {
+			return Entry{}, false, fmt.Errorf("incomplete %v entry", entry.Kind)
+		}
+		outcomeContextReporter, ok := plugin.(ocr3types.OutcomeContextReporter[RI])
+		if !ok {
+			return Entry{}, false, fmt.Errorf("%v entry, but plugin doesn't implement OutcomeContextReporter", entry.Kind)
+		}
+		reports, err := outcomeContextReporter.ReportsWithOutcomeContext(ctx, *entry.OutcomeContext, entry.Outcome)
+		replayed := Entry{Kind: entry.Kind, Error: errorString(err)}
+		for _, rwi := range reports {
+			replayed.Reports = append(replayed.Reports, MakeReportEntry(rwi))
+		}
+		return replayed, true, nil
+	}
+	return Entry{}, false, nil
+}
+
+// checkOutcomeComputed re-derives the outcome digest of an OutcomeComputed
+// event from the replayed Outcome call that preceded it. Commits aren't
+// checked, since an oracle may commit outcomes it never computed itself, e.g.
+// via state sync.
+func checkOutcomeComputed(entry Entry, outcomes map[uint64]ocr3types.Outcome) (Entry, bool) {
+	if entry.Event != "OutcomeComputed" {
+		return Entry{}, true
+	}
+	// Fields were decoded from JSON, so numbers are float64s
+	seqNr, ok := entry.Fields["seqNr"].(float64)
+	if !ok {
+		return Entry{}, true
+	}
+	outcome, ok := outcomes[uint64(seqNr)]
+	if !ok {
+		return Entry{}, true
+	}
+	replayedDigest := fmt.Sprintf("%x", protocol.MakeOutcomeDigest(outcome))
+	if entry.Fields["outcomeDigest"] == replayedDigest {
+		return Entry{}, true
+	}
+	return Entry{Kind: entry.Kind, Event: entry.Event, Fields: commontypes.LogFields{"outcomeDigest": replayedDigest}}, false
+}
+
+func sameResult(recorded Entry, replayed Entry) bool {
+	if recorded.Error != replayed.Error {
+		return false
+	}
+	if recorded.Error != "" {
+		return true
+	}
+	switch recorded.Kind {
+	case EntryKindObservationQuorum:
+		return recorded.Quorum != nil && replayed.Quorum != nil && *recorded.Quorum == *replayed.Quorum
+	case EntryKindOutcome:
+		return bytes.Equal(recorded.Outcome, replayed.Outcome)
+	case EntryKindReports, EntryKindReportsWithOutcomeContext:
+		return sameReports(recorded.Reports, replayed.Reports)
+	case EntryKindReportBatches:
+		if len(recorded.ReportBatches) != len(replayed.ReportBatches) {
+			return false
+		}
+		for i := range recorded.ReportBatches {
+			if !sameReports(recorded.ReportBatches[i], replayed.ReportBatches[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func sameReports(a []ReportEntry, b []ReportEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameReport(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameReport(a ReportEntry, b ReportEntry) bool {
+	return bytes.Equal(a.Report, b.Report) && bytes.Equal(compactJSON(a.Info), compactJSON(b.Info))
+}
+
+func compactJSON(raw json.RawMessage) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return raw
+	}
+	return buf.Bytes()
+}
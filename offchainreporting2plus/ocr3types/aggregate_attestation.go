@@ -0,0 +1,54 @@
+package ocr3types
+
+import (
+	"math/bits"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+)
+
+// AggregatingOnchainKeyring must be implemented by the OnchainKeyring if the
+// config enables ProtocolFeatureFlagAggregateAttestation, which is only
+// sensible with signature schemes that support aggregation (e.g. BLS, see
+// package blsattestation).
+type AggregatingOnchainKeyring[RI any] interface {
+	OnchainKeyring[RI]
+
+	// AggregateSignatures combines signatures over the same report by distinct
+	// oracles into a single signature that the target contract can verify
+	// against the (aggregated) public keys of these oracles. Each of the
+	// signatures has passed Verify.
+	AggregateSignatures(signatures [][]byte) ([]byte, error)
+}
+
+// SignerBitmap identifies a set of oracles: bit i is set iff oracle i is in
+// the set. (32 bits suffice since types.MaxOracles is 31.)
+type SignerBitmap uint32
+
+func (b SignerBitmap) Has(oracleID commontypes.OracleID) bool {
+	return oracleID < 32 && b&(1<<oracleID) != 0
+}
+
+// Len returns the number of oracles in the set.
+func (b SignerBitmap) Len() int {
+	return bits.OnesCount32(uint32(b))
+}
+
+// OracleIDs returns the oracles in the set in ascending order.
+func (b SignerBitmap) OracleIDs() []commontypes.OracleID {
+	oracleIDs := make([]commontypes.OracleID, 0, b.Len())
+	for i := 0; i < 32; i++ {
+		if b.Has(commontypes.OracleID(i)) {
+			oracleIDs = append(oracleIDs, commontypes.OracleID(i))
+		}
+	}
+	return oracleIDs
+}
+
+// AggregateSignature is the attestation of a report in aggregate attestation
+// mode, see ProtocolFeatureFlagAggregateAttestation.
+type AggregateSignature struct {
+	// As returned by AggregatingOnchainKeyring.AggregateSignatures
+	Signature []byte
+	// The oracles whose signatures were aggregated
+	Signers SignerBitmap
+}
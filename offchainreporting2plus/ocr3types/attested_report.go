@@ -0,0 +1,57 @@
+package ocr3types
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// AttestedReport is a report together with the attestation that a
+// ContractTransmitter sends onchain.
+type AttestedReport[RI any] struct {
+	ConfigDigest         types.ConfigDigest
+	SeqNr                uint64
+	ReportWithInfo       ReportWithInfo[RI]
+	AttributedSignatures []types.AttributedOnchainSignature
+	// Set iff the config enables ProtocolFeatureFlagAggregateAttestation. The
+	// AttributedSignatures are those that were aggregated.
+	AggregateSignature *AggregateSignature
+}
+
+type attestedReportContextKey struct{}
+
+// ContextWithAttestedReport returns a copy of ctx that carries attestedReport.
+//
+// The protocol uses it for the contexts passed to
+// ReportingPlugin.ShouldAcceptAttestedReport,
+// ReportingPlugin.ShouldTransmitAcceptedReport, and
+// ContractTransmitter.Transmit, so that implementations that need the exact
+// payload that goes onchain (e.g. to simulate the transaction) can obtain it
+// with AttestedReportFromContext.
+func ContextWithAttestedReport[RI any](ctx context.Context, attestedReport AttestedReport[RI]) context.Context {
+	return context.WithValue(ctx, attestedReportContextKey{}, attestedReport)
+}
+
+// AttestedReportFromContext returns the AttestedReport carried by ctx, if any.
+// RI must match the type parameter of the ReportingPlugin or
+// ContractTransmitter whose method received ctx.
+func AttestedReportFromContext[RI any](ctx context.Context) (AttestedReport[RI], bool) {
+	attestedReport, ok := ctx.Value(attestedReportContextKey{}).(AttestedReport[RI])
+	return attestedReport, ok
+}
+
+type reportIndexContextKey struct{}
+
+// ContextWithReportIndex returns a copy of ctx that carries the index of a
+// report among the reports of its sequence number, i.e. among those returned
+// by ReportingPlugin.Reports. The protocol uses it for the same contexts as
+// ContextWithAttestedReport.
+func ContextWithReportIndex(ctx context.Context, index int) context.Context {
+	return context.WithValue(ctx, reportIndexContextKey{}, index)
+}
+
+// ReportIndexFromContext returns the report index carried by ctx, if any.
+func ReportIndexFromContext(ctx context.Context) (int, bool) {
+	index, ok := ctx.Value(reportIndexContextKey{}).(int)
+	return index, ok
+}
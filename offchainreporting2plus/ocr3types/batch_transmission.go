@@ -0,0 +1,48 @@
+package ocr3types
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// BatchContractTransmitter may optionally be implemented by a
+// ContractTransmitter whose contract accepts several reports in a single
+// transaction, to save gas when the ReportingPlugin produces many reports per
+// round.
+//
+// If the transmitter implements BatchContractTransmitter, the protocol calls
+// TransmitBatch instead of Transmit. It waits until all reports of a seqNr
+// have been attested and then hands those that were accepted by
+// ShouldAcceptAttestedReport and ShouldTransmitAcceptedReport to
+// TransmitBatch in one call, ordered by Index. The transmission schedule is
+// determined per seqNr (instead of per report), namely as that of the seqNr's
+// first report, so the same oracle transmits the entire batch.
+//
+// TransmitBatch is subject to the same considerations as Transmit. Unlike
+// Transmit, the context doesn't carry an AttestedReport.
+type BatchContractTransmitter[RI any] interface {
+	// TransmitBatch sends the (non-empty) batch of attested reports of seqNr
+	// to the onchain smart contract.
+	TransmitBatch(
+		ctx context.Context,
+		configDigest types.ConfigDigest,
+		seqNr uint64,
+		reports []BatchedAttestedReport[RI],
+	) error
+}
+
+// BatchedAttestedReport is an attested report within a batch passed to
+// BatchContractTransmitter.TransmitBatch.
+type BatchedAttestedReport[RI any] struct {
+	// Index of the report among the reports of its seqNr, i.e. in the list
+	// returned by Reports (or ReportBatches)
+	Index                int
+	ReportWithInfo       ReportWithInfo[RI]
+	AttributedSignatures []types.AttributedOnchainSignature
+	// Set iff ReportWithInfo is the root of a Merkle batch of reports, see
+	// ReportBatcher.
+	ReportBatch *ReportBatch[RI]
+	// Set iff the config enables ProtocolFeatureFlagAggregateAttestation.
+	AggregateSignature *AggregateSignature
+}
@@ -0,0 +1,71 @@
+package ocr3types
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// BlobDigest identifies a blob by its content, see MakeBlobDigest.
+type BlobDigest [32]byte
+
+func (d BlobDigest) String() string {
+	return hex.EncodeToString(d[:])
+}
+
+// MakeBlobDigest returns the SHA2-256 hash of payload.
+func MakeBlobDigest(payload []byte) BlobDigest {
+	return sha256.Sum256(payload)
+}
+
+// BlobBroadcastFetcher lets a plugin disseminate payloads that are too large
+// to be put into queries, observations, or outcomes directly. A plugin
+// broadcasts a payload, puts only the returned digest into e.g. its
+// observation, and other oracles fetch the payload by digest when they need
+// it.
+//
+// Blobs are enabled by setting ReportingPluginLimits.MaxBlobLength and
+// ReportingPluginLimits.MaxBlobsPerRound. The BlobBroadcastFetcher is then
+// carried by the contexts passed to all ReportingPluginV2 methods (and to
+// ReportBatcher.ReportBatches), see BlobBroadcastFetcherFromContext.
+//
+// Each oracle stores the most recent BlobRetentionRounds*MaxBlobsPerRound
+// blobs it received from every other oracle and evicts older ones. Plugins
+// should thus only refer to blobs from recent rounds.
+type BlobBroadcastFetcher interface {
+	// BroadcastBlob sends payload to all oracles and returns once a byzantine
+	// quorum of oracles (including this one) has stored it. Since at most f of
+	// these are faulty, the blob can then be fetched by any oracle from one of
+	// the at least f+1 correct oracles storing it. Broadcasts are rate
+	// limited to MaxBlobsPerRound per DeltaRound, BroadcastBlob blocks if the
+	// limit is exceeded.
+	BroadcastBlob(ctx context.Context, payload []byte) (BlobDigest, error)
+
+	// FetchBlob returns the payload with the given digest, fetching it from
+	// other oracles if it isn't stored locally. It retries until it succeeds
+	// or ctx is done. Note that a faulty oracle may put the digest of a blob
+	// that it never broadcast into its observation. Plugins must therefore
+	// bound the time they spend fetching and treat such observations as
+	// invalid.
+	FetchBlob(ctx context.Context, digest BlobDigest) ([]byte, error)
+}
+
+// BlobRetentionRounds determines how many blobs each oracle stores per
+// remote oracle, see BlobBroadcastFetcher.
+const BlobRetentionRounds = 10
+
+type blobBroadcastFetcherContextKey struct{}
+
+// ContextWithBlobBroadcastFetcher returns a copy of ctx that carries
+// blobBroadcastFetcher.
+func ContextWithBlobBroadcastFetcher(ctx context.Context, blobBroadcastFetcher BlobBroadcastFetcher) context.Context {
+	return context.WithValue(ctx, blobBroadcastFetcherContextKey{}, blobBroadcastFetcher)
+}
+
+// BlobBroadcastFetcherFromContext returns the BlobBroadcastFetcher carried by
+// ctx, if any. It is carried by the contexts passed to the plugin iff blobs
+// are enabled.
+func BlobBroadcastFetcherFromContext(ctx context.Context) (BlobBroadcastFetcher, bool) {
+	blobBroadcastFetcher, ok := ctx.Value(blobBroadcastFetcherContextKey{}).(BlobBroadcastFetcher)
+	return blobBroadcastFetcher, ok
+}
@@ -6,11 +6,34 @@ import (
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
 
+// Hosts that want to route config reads to a read replica can implement
+// types.ConfigDatabaseReader and DatabaseWriter separately and combine them
+// with NewSplitDatabase. Existing implementations of Database need no changes.
 type Database interface {
-	types.ConfigDatabase
+	types.ConfigDatabaseReader
+	DatabaseWriter
+}
+
+// DatabaseWriter contains all writes, as well as ReadProtocolState.
+// ReadProtocolState must observe the latest WriteProtocolState and must thus
+// be served by the primary: restoring stale protocol state could cause an
+// oracle to send messages that conflict with ones it sent before.
+type DatabaseWriter interface {
+	types.ConfigDatabaseWriter
 	ProtocolStateDatabase
 }
 
+type splitDatabase struct {
+	types.ConfigDatabaseReader
+	DatabaseWriter
+}
+
+// NewSplitDatabase returns a Database that serves ReadConfig from reader and
+// all other methods from writer.
+func NewSplitDatabase(reader types.ConfigDatabaseReader, writer DatabaseWriter) Database {
+	return splitDatabase{reader, writer}
+}
+
 // ProtocolStateDatabase persistently stores protocol state to survive process restarts.
 // Expect Write to be called far more frequently than Read.
 //
@@ -21,3 +44,45 @@ type ProtocolStateDatabase interface {
 	// Writing with a nil value is the same as deleting.
 	WriteProtocolState(ctx context.Context, configDigest types.ConfigDigest, key string, value []byte) error
 }
+
+// ProtocolStatePruner may optionally be implemented by a Database to let the
+// oracle garbage-collect the protocol state of superseded configs, which would
+// otherwise accumulate across reconfigurations. Config digests never repeat, so
+// the state of a superseded config is never read again.
+type ProtocolStatePruner interface {
+	// DeleteProtocolStateWithOtherConfigDigest deletes the protocol state of
+	// all config digests other than configDigest. It is called whenever the
+	// oracle starts running the protocol instance for configDigest.
+	DeleteProtocolStateWithOtherConfigDigest(ctx context.Context, configDigest types.ConfigDigest) error
+}
+
+// ProtocolStateQuarantiner may optionally be implemented by a Database to keep
+// corrupted protocol state for forensics, e.g. in a separate table. If
+// types.LocalConfig.QuarantineCorruptedProtocolState is set and the oracle
+// finds protocol state that fails its checksum, it quarantines the value,
+// deletes it with WriteProtocolState, and starts the protocol instance from a
+// fresh state instead of failing to start. A value that passes its checksum but
+// can't be decoded, e.g. after a downgrade to an incompatible version, isn't
+// considered corrupted and is never quarantined.
+//
+// Until it has moved past every epoch in which it may have signed messages
+// before, the restarted instance stays passive. If the pacemaker state is
+// intact, that is the epoch it stores. Otherwise, the instance stays passive
+// in every epoch up to the highest epoch that at least f+1 oracles wish for,
+// as reported by a Byzantine quorum of oracles counting itself: at least f+1 oracles wished
+// for any epoch the instance entered, so this bounds the epochs it entered
+// once enough of them have been heard from, while faulty oracles can't keep
+// it passive forever.
+//
+// If the Database doesn't implement ProtocolStateQuarantiner, the value is
+// quarantined with WriteProtocolState under the key QuarantineKeyPrefix+key.
+type ProtocolStateQuarantiner interface {
+	// QuarantineProtocolState stores the corrupted value that was found under
+	// key. It must not modify the value stored under key itself.
+	QuarantineProtocolState(ctx context.Context, configDigest types.ConfigDigest, key string, value []byte) error
+}
+
+// QuarantineKeyPrefix is prepended to the key of corrupted protocol state
+// quarantined in a Database that doesn't implement ProtocolStateQuarantiner.
+// The oracle never reads keys with this prefix.
+const QuarantineKeyPrefix = "quarantine/"
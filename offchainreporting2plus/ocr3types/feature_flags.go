@@ -0,0 +1,90 @@
+package ocr3types
+
+import "fmt"
+
+// FeatureFlags is a bitfield in the offchain config that switches optional
+// behaviors on or off. Since it is part of the config, all oracles of a DON
+// switch at the same time, namely when the config changes.
+//
+// The lower 32 bits are reserved for the protocol. Oracles reject configs that
+// set protocol bits unknown to them, so that a behavior can't end up enabled
+// on only the part of a DON that runs a library version supporting it. (Note
+// that library versions that predate FeatureFlags ignore them entirely.)
+//
+// The upper 32 bits are available to reporting plugins, which receive the
+// flags in ReportingPluginConfig and are responsible for validating them.
+type FeatureFlags uint64
+
+const (
+	ProtocolFeatureFlagsMask FeatureFlags = 0x00000000_ffffffff
+	PluginFeatureFlagsMask   FeatureFlags = 0xffffffff_00000000
+
+	// ProtocolFeatureFlagQueryLessRounds switches the protocol to query-less
+	// rounds: the leader doesn't call Query and doesn't broadcast a round
+	// start message, instead followers observe on their own as soon as the
+	// previous round has been committed (and DeltaRound has passed since their
+	// previous observation). This saves one message delay per round. Oracles
+	// refuse to run a config with this flag unless their ReportingPlugin
+	// declares ReportingPluginInfo.QueryLess.
+	ProtocolFeatureFlagQueryLessRounds FeatureFlags = 1 << 0
+
+	// ProtocolFeatureFlagObservationTimestamps makes the protocol attach
+	// timestamps to every observation passed to Outcome: the time at which the
+	// observer started making the observation (signed by the observer) and
+	// the time at which the leader received it. See ObservationTimestamps.
+	ProtocolFeatureFlagObservationTimestamps FeatureFlags = 1 << 1
+
+	// ProtocolFeatureFlagAggregateAttestation makes the protocol aggregate the
+	// f+1 signatures attesting a report into a single signature plus a
+	// SignerBitmap, which is cheaper to verify onchain for large oracle sets.
+	// The aggregate is available to the ContractTransmitter as
+	// AttestedReport.AggregateSignature (see AttestedReportFromContext) and
+	// BatchedAttestedReport.AggregateSignature. Oracles refuse to run a config
+	// with this flag unless their OnchainKeyring implements
+	// AggregatingOnchainKeyring.
+	ProtocolFeatureFlagAggregateAttestation FeatureFlags = 1 << 2
+
+	// ProtocolFeatureFlagObservationSampling makes the protocol collect the
+	// observations of each round from a pseudorandom sample of oracles only,
+	// which saves bandwidth and plugin work for large DONs. The sample for a
+	// round consists of min(N, quorum+F) oracles, where quorum is the
+	// round's ObservationQuorum, so that the sampled oracles can reach the
+	// quorum even if F of them are faulty. It is derived from the epoch and
+	// SeqNr of the round under a key shared by the oracles of the config, so
+	// that every oracle can verify that the leader only used observations
+	// from the sample, and a new epoch draws a new sample. Oracles outside
+	// the sample don't call Observation. Oracles refuse to run a config with
+	// this flag unless their ReportingPlugin declares
+	// ReportingPluginInfo.ObservationSampling.
+	ProtocolFeatureFlagObservationSampling FeatureFlags = 1 << 3
+
+	// KnownProtocolFeatureFlags is the set of protocol feature flags
+	// supported by this version of the library.
+	KnownProtocolFeatureFlags = ProtocolFeatureFlagQueryLessRounds | ProtocolFeatureFlagObservationTimestamps | ProtocolFeatureFlagAggregateAttestation | ProtocolFeatureFlagObservationSampling
+)
+
+// PluginFeatureFlag returns the i-th plugin feature flag, for i in [0, 32).
+func PluginFeatureFlag(i int) FeatureFlags {
+	if !(0 <= i && i < 32) {
+		panic(fmt.Sprintf("plugin feature flag index %v out of range", i))
+	}
+	return FeatureFlags(1) << (32 + i)
+}
+
+// Has returns true iff all flags in flag are set.
+func (f FeatureFlags) Has(flag FeatureFlags) bool {
+	return f&flag == flag
+}
+
+// Plugin returns the subset of f reserved for reporting plugins.
+func (f FeatureFlags) Plugin() FeatureFlags {
+	return f & PluginFeatureFlagsMask
+}
+
+// Validate checks that f doesn't contain unknown protocol feature flags.
+func (f FeatureFlags) Validate() error {
+	if unknown := f & ProtocolFeatureFlagsMask &^ KnownProtocolFeatureFlags; unknown != 0 {
+		return fmt.Errorf("unknown protocol feature flags 0x%x", uint64(unknown))
+	}
+	return nil
+}
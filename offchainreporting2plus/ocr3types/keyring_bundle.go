@@ -0,0 +1,273 @@
+package ocr3types
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"go.uber.org/multierr"
+)
+
+// ReportDestination identifies a system that reports are transmitted to, e.g.
+// a particular chain.
+type ReportDestination string
+
+const maxReportDestinationLength = math.MaxUint8
+
+// OnchainKeyringBundle is an OnchainKeyring that holds a separate
+// OnchainKeyring per ReportDestination. This allows a report that is fanned
+// out to several destinations to be attested under a different signature
+// scheme for each destination.
+//
+// A signature produced by the bundle contains one signature per destination,
+// so the report attestation protocol collects f+1 signatures for every
+// destination in the same round. Use a ContractTransmitterBundle with the same
+// destinations to pass each destination's signatures on to its transmitter.
+//
+// All oracles in a DON must use bundles with the same set of destinations. The
+// OnchainPublicKey of each oracle in the contract config must be the value
+// returned by the bundle's PublicKey method.
+type OnchainKeyringBundle[RI any] struct {
+	destinations []ReportDestination // sorted
+	keyrings     []OnchainKeyring[RI]
+}
+
+var _ OnchainKeyring[struct{}] = &OnchainKeyringBundle[struct{}]{}
+
+func NewOnchainKeyringBundle[RI any](keyrings map[ReportDestination]OnchainKeyring[RI]) (*OnchainKeyringBundle[RI], error) {
+	if len(keyrings) == 0 {
+		return nil, fmt.Errorf("OnchainKeyringBundle needs at least one keyring")
+	}
+	destinations := sortedDestinations(keyrings)
+	for _, destination := range destinations {
+		if keyrings[destination] == nil {
+			return nil, fmt.Errorf("OnchainKeyring for destination %q is nil", destination)
+		}
+		if len(destination) > maxReportDestinationLength {
+			return nil, fmt.Errorf("destination %q is longer than %v bytes", destination, maxReportDestinationLength)
+		}
+		if len(keyrings[destination].PublicKey()) > math.MaxUint16 {
+			return nil, fmt.Errorf("OnchainPublicKey for destination %q is longer than %v bytes", destination, math.MaxUint16)
+		}
+		if keyrings[destination].MaxSignatureLength() > math.MaxUint16 {
+			return nil, fmt.Errorf("MaxSignatureLength for destination %q is larger than %v", destination, math.MaxUint16)
+		}
+	}
+	bundle := &OnchainKeyringBundle[RI]{destinations, nil}
+	for _, destination := range destinations {
+		bundle.keyrings = append(bundle.keyrings, keyrings[destination])
+	}
+	return bundle, nil
+}
+
+// Destinations returns the destinations of the bundle in sorted order.
+func (b *OnchainKeyringBundle[RI]) Destinations() []ReportDestination {
+	return append([]ReportDestination{}, b.destinations...)
+}
+
+// PublicKey returns an encoding of the public keys of all keyrings in the
+// bundle. Use SplitOnchainPublicKeyBundle to recover the individual keys.
+func (b *OnchainKeyringBundle[RI]) PublicKey() types.OnchainPublicKey {
+	var buf []byte
+	for i, destination := range b.destinations {
+		pk := b.keyrings[i].PublicKey()
+		buf = append(buf, byte(len(destination)))
+		buf = append(buf, destination...)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(pk)))
+		buf = append(buf, pk...)
+	}
+	return buf
+}
+
+// Sign signs the report with every keyring in the bundle.
+func (b *OnchainKeyringBundle[RI]) Sign(configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ReportWithInfo[RI]) ([]byte, error) {
+	var buf []byte
+	for i, destination := range b.destinations {
+		sig, err := b.keyrings[i].Sign(configDigest, seqNr, reportWithInfo)
+		if err != nil {
+			return nil, fmt.Errorf("error while signing for destination %q: %w", destination, err)
+		}
+		if len(sig) > b.keyrings[i].MaxSignatureLength() {
+			return nil, fmt.Errorf("signature for destination %q is longer than MaxSignatureLength", destination)
+		}
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(sig)))
+		buf = append(buf, sig...)
+	}
+	return buf, nil
+}
+
+// Verify returns true iff the signature contains a valid signature for every
+// destination in the bundle.
+func (b *OnchainKeyringBundle[RI]) Verify(publicKey types.OnchainPublicKey, configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ReportWithInfo[RI], signature []byte) bool {
+	publicKeys, err := SplitOnchainPublicKeyBundle(publicKey)
+	if err != nil || len(publicKeys) != len(b.destinations) {
+		return false
+	}
+	sigs, err := splitSignatureBundle(signature, len(b.destinations))
+	if err != nil {
+		return false
+	}
+	for i, destination := range b.destinations {
+		pk, ok := publicKeys[destination]
+		if !ok {
+			return false
+		}
+		if len(sigs[i]) > b.keyrings[i].MaxSignatureLength() {
+			return false
+		}
+		if !b.keyrings[i].Verify(pk, configDigest, seqNr, reportWithInfo, sigs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *OnchainKeyringBundle[RI]) MaxSignatureLength() int {
+	length := 0
+	for _, keyring := range b.keyrings {
+		length += 2 + keyring.MaxSignatureLength()
+	}
+	return length
+}
+
+// SplitOnchainPublicKeyBundle recovers the per-destination public keys from a
+// public key returned by OnchainKeyringBundle.PublicKey.
+func SplitOnchainPublicKeyBundle(publicKey types.OnchainPublicKey) (map[ReportDestination]types.OnchainPublicKey, error) {
+	result := map[ReportDestination]types.OnchainPublicKey{}
+	var previous *ReportDestination
+	for len(publicKey) > 0 {
+		destinationLen := int(publicKey[0])
+		publicKey = publicKey[1:]
+		if len(publicKey) < destinationLen+2 {
+			return nil, fmt.Errorf("public key bundle is truncated")
+		}
+		destination := ReportDestination(publicKey[:destinationLen])
+		publicKey = publicKey[destinationLen:]
+		pkLen := int(binary.BigEndian.Uint16(publicKey))
+		publicKey = publicKey[2:]
+		if len(publicKey) < pkLen {
+			return nil, fmt.Errorf("public key for destination %q is truncated", destination)
+		}
+		if previous != nil && destination <= *previous {
+			return nil, fmt.Errorf("destinations are not strictly sorted")
+		}
+		previous = &destination
+		result[destination] = append(types.OnchainPublicKey{}, publicKey[:pkLen]...)
+		publicKey = publicKey[pkLen:]
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("public key bundle is empty")
+	}
+	return result, nil
+}
+
+// splitSignatureBundle splits a signature returned by
+// OnchainKeyringBundle.Sign into the per-destination signatures, in sorted
+// order of destinations.
+func splitSignatureBundle(signature []byte, count int) ([][]byte, error) {
+	sigs := make([][]byte, 0, count)
+	for len(signature) > 0 {
+		if len(sigs) == count {
+			return nil, fmt.Errorf("signature bundle contains more than %v signatures", count)
+		}
+		if len(signature) < 2 {
+			return nil, fmt.Errorf("signature bundle is truncated")
+		}
+		sigLen := int(binary.BigEndian.Uint16(signature))
+		signature = signature[2:]
+		if len(signature) < sigLen {
+			return nil, fmt.Errorf("signature bundle is truncated")
+		}
+		sigs = append(sigs, signature[:sigLen])
+		signature = signature[sigLen:]
+	}
+	if len(sigs) != count {
+		return nil, fmt.Errorf("signature bundle contains %v signatures, expected %v", len(sigs), count)
+	}
+	return sigs, nil
+}
+
+// ContractTransmitterBundle is a ContractTransmitter that holds a separate
+// ContractTransmitter per ReportDestination. It expects the attributed
+// signatures passed to Transmit to have been produced by an
+// OnchainKeyringBundle with the same destinations, and passes each
+// destination's signatures on to the corresponding transmitter.
+//
+// Destinations that require confidentiality can wrap their transmitter in an
+// EncryptingContractTransmitter with a per-destination key.
+type ContractTransmitterBundle[RI any] struct {
+	destinations []ReportDestination // sorted
+	transmitters []ContractTransmitter[RI]
+}
+
+var _ ContractTransmitter[struct{}] = &ContractTransmitterBundle[struct{}]{}
+
+func NewContractTransmitterBundle[RI any](transmitters map[ReportDestination]ContractTransmitter[RI]) (*ContractTransmitterBundle[RI], error) {
+	if len(transmitters) == 0 {
+		return nil, fmt.Errorf("ContractTransmitterBundle needs at least one transmitter")
+	}
+	destinations := sortedDestinations(transmitters)
+	bundle := &ContractTransmitterBundle[RI]{destinations, nil}
+	for _, destination := range destinations {
+		if transmitters[destination] == nil {
+			return nil, fmt.Errorf("ContractTransmitter for destination %q is nil", destination)
+		}
+		bundle.transmitters = append(bundle.transmitters, transmitters[destination])
+	}
+	return bundle, nil
+}
+
+// Destinations returns the destinations of the bundle in sorted order.
+func (b *ContractTransmitterBundle[RI]) Destinations() []ReportDestination {
+	return append([]ReportDestination{}, b.destinations...)
+}
+
+// Transmit invokes Transmit on the transmitter of every destination, even if
+// some of them fail. The returned error combines all errors encountered.
+func (b *ContractTransmitterBundle[RI]) Transmit(
+	ctx context.Context,
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	reportWithInfo ReportWithInfo[RI],
+	aoss []types.AttributedOnchainSignature,
+) error {
+	aossPerDestination := make([][]types.AttributedOnchainSignature, len(b.destinations))
+	for _, aos := range aoss {
+		sigs, err := splitSignatureBundle(aos.Signature, len(b.destinations))
+		if err != nil {
+			return fmt.Errorf("malformed signature from oracle %v: %w", aos.Signer, err)
+		}
+		for i := range b.destinations {
+			aossPerDestination[i] = append(aossPerDestination[i], types.AttributedOnchainSignature{
+				sigs[i],
+				aos.Signer,
+			})
+		}
+	}
+
+	var err error
+	for i, destination := range b.destinations {
+		if terr := b.transmitters[i].Transmit(ctx, configDigest, seqNr, reportWithInfo, aossPerDestination[i]); terr != nil {
+			err = multierr.Append(err, fmt.Errorf("error while transmitting to destination %q: %w", destination, terr))
+		}
+	}
+	return err
+}
+
+// FromAccount returns the account of the transmitter for the first destination
+// in sorted order.
+func (b *ContractTransmitterBundle[RI]) FromAccount() (types.Account, error) {
+	return b.transmitters[0].FromAccount()
+}
+
+func sortedDestinations[T any](m map[ReportDestination]T) []ReportDestination {
+	destinations := make([]ReportDestination, 0, len(m))
+	for destination := range m {
+		destinations = append(destinations, destination)
+	}
+	sort.Slice(destinations, func(i, j int) bool { return destinations[i] < destinations[j] })
+	return destinations
+}
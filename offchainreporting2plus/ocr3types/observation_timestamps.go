@@ -0,0 +1,53 @@
+package ocr3types
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+)
+
+// ObservationTimestamps are the timestamps that the protocol attaches to an
+// observation if the config sets ProtocolFeatureFlagObservationTimestamps.
+// All honest oracles see the same timestamps for the same observation, so
+// plugins may use them in Outcome, e.g. to weight or reject stale
+// observations. Note that they are taken from the clocks of different oracles.
+//
+// The timestamps are carried by the contexts passed to ValidateObservation and
+// Outcome, see ObservationTimestampsFromContext, so only ReportingPluginV2
+// receives them.
+type ObservationTimestamps struct {
+	// ObservedAt is the time at which the observer started making the
+	// observation, according to the observer's clock. It is signed by the
+	// observer, so a faulty leader cannot change it, but a faulty observer
+	// may lie about it.
+	ObservedAt time.Time
+	// ReceivedAt is the time at which the leader of the round received the
+	// observation, according to the leader's clock. A faulty leader may lie
+	// about it.
+	ReceivedAt time.Time
+}
+
+type observationTimestampsContextKey struct{}
+
+// ContextWithObservationTimestamps returns a copy of ctx that carries the
+// timestamps of the observations made by the oracles in timestamps. The
+// protocol uses it for the contexts passed to
+// ReportingPlugin.ValidateObservation and Outcome if the config sets
+// ProtocolFeatureFlagObservationTimestamps.
+func ContextWithObservationTimestamps(ctx context.Context, timestamps map[commontypes.OracleID]ObservationTimestamps) context.Context {
+	return context.WithValue(ctx, observationTimestampsContextKey{}, timestamps)
+}
+
+// ObservationTimestampsFromContext returns the timestamps of the observation
+// made by observer, if ctx carries them. In ValidateObservation and Outcome,
+// ok is true for every attributed observation passed iff the config sets
+// ProtocolFeatureFlagObservationTimestamps.
+func ObservationTimestampsFromContext(ctx context.Context, observer commontypes.OracleID) (ObservationTimestamps, bool) {
+	timestamps, ok := ctx.Value(observationTimestampsContextKey{}).(map[commontypes.OracleID]ObservationTimestamps)
+	if !ok {
+		return ObservationTimestamps{}, false
+	}
+	ts, ok := timestamps[observer]
+	return ts, ok
+}
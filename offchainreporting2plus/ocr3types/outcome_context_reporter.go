@@ -0,0 +1,33 @@
+package ocr3types
+
+import (
+	"context"
+)
+
+// OutcomeContextReporter may optionally be implemented by a ReportingPluginV2
+// whose reports depend on the previous outcome, e.g. to only report the values
+// that changed since the previous round. Without it, such a plugin would have
+// to encode the relevant parts of the previous outcome redundantly into every
+// outcome.
+//
+// If the plugin implements OutcomeContextReporter, the protocol calls
+// ReportsWithOutcomeContext instead of Reports. The OutcomeContext only has
+// SeqNr, PreviousOutcome and PreviousOutcomeHash set; all other fields are
+// zero, since they might differ between the oracles generating reports for the
+// same SeqNr. As for the other plugin functions, PreviousOutcome is nil if the
+// plugin declared ReportingPluginInfo.PreviousOutcomeHashOnly.
+//
+// An oracle only ever learns the previous outcome if it committed or received
+// the outcome with sequence number (SeqNr-1), which isn't the case e.g. right
+// after state synchronization has skipped ahead. Such an oracle doesn't
+// generate (and hence doesn't sign) reports for SeqNr and relies on the other
+// oracles to attest them instead.
+//
+// Oracles refuse to run a plugin that implements both OutcomeContextReporter
+// and ReportBatcher.
+type OutcomeContextReporter[RI any] interface {
+	// Generates a (possibly empty) list of reports from an outcome and the
+	// context it was generated in. The same considerations as for Reports
+	// apply; in particular, this function should be pure.
+	ReportsWithOutcomeContext(ctx context.Context, outctx OutcomeContext, outcome Outcome) ([]ReportWithInfo[RI], error)
+}
@@ -2,9 +2,12 @@ package ocr3types
 
 import (
 	"context"
+	"crypto/sha256"
+	"fmt"
 	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/byzquorum"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 )
 
@@ -14,6 +17,9 @@ type ReportingPluginFactory[RI any] interface {
 	// Creates a new reporting plugin instance. The instance may have
 	// associated goroutines or hold system resources, which should be
 	// released when its Close() function is called.
+	//
+	// NewReportingPlugin can't be cancelled. Factories that block on outside
+	// services should implement ReportingPluginFactoryV2 instead.
 	NewReportingPlugin(ReportingPluginConfig) (ReportingPlugin[RI], ReportingPluginInfo, error)
 }
 
@@ -51,6 +57,11 @@ type ReportingPluginConfig struct {
 	MaxDurationObservation                  time.Duration
 	MaxDurationShouldAcceptAttestedReport   time.Duration
 	MaxDurationShouldTransmitAcceptedReport time.Duration
+
+	// FeatureFlags from the offchain config. Only the flags reserved for
+	// plugins (see FeatureFlags.Plugin) are meant to be interpreted by the
+	// ReportingPlugin; validating them is up to the ReportingPlugin.
+	FeatureFlags FeatureFlags
 }
 
 type ReportWithInfo[RI any] struct {
@@ -76,8 +87,48 @@ type OutcomeContext struct {
 	// Deprecated: exposed for legacy compatibility, do not rely on this
 	// unless you have a really good reason.
 	Round uint64
+
+	// Leader of the epoch in which the round with SeqNr is being run, and
+	// whether that leader is the local oracle.
+	//
+	// This is intended for optimizations only, e.g. prefetching data needed
+	// for the Query. Leadership can change at any time and without warning:
+	// the epoch may already have ended by the time the plugin sees this value,
+	// and the same SeqNr may be rerun in another epoch with a different
+	// leader. Never make the output of any plugin function depend on these
+	// fields, since other oracles may see different values for the same SeqNr.
+	Leader   commontypes.OracleID
+	IsLeader bool
+
+	// Hash of PreviousOutcome, see MakeOutcomeHash. This is set even if
+	// PreviousOutcome is omitted because the plugin declared
+	// ReportingPluginInfo.PreviousOutcomeHashOnly.
+	PreviousOutcomeHash OutcomeHash
+
+	// ObservationsTimestamp is only set in calls to Outcome, and only if the
+	// config sets ProtocolFeatureFlagObservationTimestamps. Otherwise, it is
+	// zero. It is the median ObservationTimestamps.ObservedAt of the
+	// attributed observations passed to Outcome (the upper median if their
+	// number is even). All honest oracles compute the same
+	// ObservationsTimestamp for the same outcome, so plugins may embed it in
+	// the outcome to obtain deterministic time fields in reports, instead of
+	// reading their local clocks. If more than 2f observations are passed, the
+	// median lies between the ObservedAt of two honest oracles, so faulty
+	// observers can't move it arbitrarily.
+	ObservationsTimestamp time.Time
 }
 
+type OutcomeHash [32]byte
+
+// MakeOutcomeHash returns the SHA2-256 hash of outcome. For SeqNr 1, whose
+// PreviousOutcome is nil, PreviousOutcomeHash is MakeOutcomeHash(nil).
+func MakeOutcomeHash(outcome Outcome) OutcomeHash {
+	return sha256.Sum256(outcome)
+}
+
+// A Quorum is a minimum number of observations, either one of the symbolic
+// constants below, which scale with N and F, or an exact count, see
+// QuorumExact.
 type Quorum int
 
 const (
@@ -91,6 +142,52 @@ const (
 	QuorumNMinusF
 )
 
+// QuorumExact returns the Quorum requiring exactly count observations, e.g.
+// for aggregation plugins that need a specific sample size. count must be
+// between 1 and N-F: larger counts may never be reached if F oracles are
+// faulty, and the protocol treats them as errors. Unlike the symbolic
+// constants, an exact count doesn't scale with the config, so consider its
+// safety for every N and F the plugin may run with: with count <= F, all
+// observations may come from faulty oracles; with count <= 2F, faulty
+// oracles may contribute the majority of observations. Plugins declare the
+// largest count they use in ReportingPluginInfo.MaxExactObservationQuorum so
+// that it is validated at config time.
+func QuorumExact(count int) Quorum {
+	return Quorum(count)
+}
+
+// Exact returns whether q is an exact count, as opposed to one of the
+// symbolic constants.
+func (q Quorum) Exact() bool {
+	return q <= types.MaxOracles
+}
+
+// Count returns the number of observations q requires for n oracles of which
+// up to f are faulty. It returns an error if q requires fewer than one or
+// more than n-f observations.
+func (q Quorum) Count(n, f int) (int, error) {
+	var count int
+	switch q {
+	case QuorumFPlusOne:
+		count = f + 1
+	case QuorumTwoFPlusOne:
+		count = 2*f + 1
+	case QuorumByzQuorum:
+		count = byzquorum.Size(n, f)
+	case QuorumNMinusF:
+		count = n - f
+	default:
+		if !q.Exact() {
+			return 0, fmt.Errorf("unknown quorum %v", int(q))
+		}
+		count = int(q)
+	}
+	if !(0 < count && count <= n-f) {
+		return 0, fmt.Errorf("quorum of %v observations must be between 1 and n-f (%v)", count, n-f)
+	}
+	return count, nil
+}
+
 // A ReportingPlugin allows plugging custom logic into the OCR3 protocol. The
 // OCR protocol handles cryptography, networking, ensuring that a sufficient
 // number of nodes is in agreement about any report, transmitting the report to
@@ -164,6 +261,12 @@ type ReportingPlugin[RI any] interface {
 	// Observation gets an observation from the underlying data source. Returns
 	// a value or an error.
 	//
+	// If observations are expensive to canonicalize for Outcome, e.g. because
+	// they need sorting or deduplicating, return them in canonical form and
+	// have ValidateObservation reject observations that aren't, so that
+	// every oracle does this work for its own observation instead of Outcome
+	// doing it for all of them.
+	//
 	// You may assume that the outctx.SeqNr is increasing monotonically (though
 	// *not* strictly) across the lifetime of a protocol instance and that
 	// outctx.previousOutcome contains the consensus outcome with sequence
@@ -188,7 +291,8 @@ type ReportingPlugin[RI any] interface {
 	//
 	// This is an advanced feature. The "default" approach (what OCR1 & OCR2
 	// did) is to have an empty ValidateObservation function and return
-	// QuorumTwoFPlusOne from this function.
+	// QuorumTwoFPlusOne from this function. Plugins that need a specific
+	// number of observations can return QuorumExact(count) instead.
 	ObservationQuorum(outctx OutcomeContext, query types.Query) (Quorum, error)
 
 	// Generates an outcome for a seqNr, typically based on the previous
@@ -212,8 +316,11 @@ type ReportingPlugin[RI any] interface {
 	//
 	// This function should be pure. Don't do anything slow in here.
 	//
-	// This is likely to change in the future. It will likely be returning a
-	// list of report batches, where each batch goes into its own Merkle tree.
+	// Plugins producing many reports per round should consider returning
+	// report batches instead, where each batch goes into its own Merkle tree.
+	// See ReportBatcher. Plugins whose reports depend on the previous outcome
+	// can obtain it without duplicating it into every outcome, see
+	// OutcomeContextReporter.
 	//
 	// You may assume that the outctx.SeqNr is increasing monotonically (though
 	// *not* strictly) across the lifetime of a protocol instance and that
@@ -227,6 +334,15 @@ type ReportingPlugin[RI any] interface {
 	//
 	// Don't make assumptions about the seqNr order in which this function
 	// is called.
+	//
+	// The attestation is available via AttestedReportFromContext.
+	//
+	// To check whether a report has already been reported without an RPC
+	// call, consult a latestreportcache.Cache fed by the ContractTransmitter,
+	// e.g. the one carried by ctx, see latestreportcache.FromContext.
+	//
+	// Plugins for which this is expensive per call can decide on all reports
+	// of a round at once, see ReportDecisionBatcher.
 	ShouldAcceptAttestedReport(context.Context, uint64, ReportWithInfo[RI]) (bool, error)
 
 	// Decides whether the given report should actually be broadcast to the
@@ -243,6 +359,9 @@ type ReportingPlugin[RI any] interface {
 	// database upon oracle restart, this function  may be called with reports
 	// that no other function of this instance of this interface has ever
 	// been invoked on.
+	//
+	// The attestation that will be transmitted is available via
+	// AttestedReportFromContext.
 	ShouldTransmitAcceptedReport(context.Context, uint64, ReportWithInfo[RI]) (bool, error)
 
 	// If Close is called a second time, it may return an error but must not
@@ -263,6 +382,14 @@ const (
 	MaxMaxOutcomeLength     = 5 * mib
 	MaxMaxReportLength      = 5 * mib
 	MaxMaxReportCount       = 2000
+
+	MaxMaxObservationProvenanceLength = 1024
+
+	// Applies instead of MaxMaxObservationLength if chunked transfer is
+	// enabled, see ChunkedTransferConfig.
+	MaxMaxChunkedObservationLength = 32 * mib
+
+	MaxMaxBlobsPerRound = 100
 )
 
 type ReportingPluginLimits struct {
@@ -273,6 +400,19 @@ type ReportingPluginLimits struct {
 	MaxOutcomeLength     int
 	MaxReportLength      int
 	MaxReportCount       int
+
+	// Maximum length in bytes of the ObservationProvenance attached to each
+	// observation. Zero means that the plugin doesn't use provenance. If
+	// non-zero, all observations must be encoded using
+	// EncodeObservationWithProvenance.
+	MaxObservationProvenanceLength int
+
+	// Maximum length in bytes of a blob and maximum number of blobs each
+	// oracle may broadcast per DeltaRound, see BlobBroadcastFetcher. Either
+	// both or neither must be zero; zero disables blobs. MaxBlobLength is
+	// subject to the same bound as MaxObservationLength.
+	MaxBlobLength    int
+	MaxBlobsPerRound int
 }
 
 type ReportingPluginInfo struct {
@@ -280,4 +420,99 @@ type ReportingPluginInfo struct {
 	Name string
 
 	Limits ReportingPluginLimits
+
+	// Optional. Enables caching of observations, see ObservationCacheConfig.
+	ObservationCache ObservationCacheConfig
+
+	// Declares that the plugin never uses queries: its Query always returns
+	// an empty query and nothing else depends on the query. Such a plugin can
+	// be run with query-less rounds, which are enabled by setting
+	// ProtocolFeatureFlagQueryLessRounds in the offchain config. In a
+	// query-less round, Query isn't called and Observation,
+	// ValidateObservation, ObservationQuorum, and Outcome are passed an empty
+	// query.
+	QueryLess bool
+
+	// Optional. Enables chunked transfer of large messages, see
+	// ChunkedTransferConfig.
+	ChunkedTransfer ChunkedTransferConfig
+
+	// Declares that the plugin keeps track of outcomes itself, e.g. in
+	// external state, and only needs OutcomeContext.PreviousOutcomeHash to
+	// verify continuity. If set, OutcomeContext.PreviousOutcome is always
+	// nil, which saves copying outcomes into the plugin, e.g. across process
+	// boundaries.
+	PreviousOutcomeHashOnly bool
+
+	// Optional. The largest exact count (see QuorumExact) that
+	// ObservationQuorum may return. It is validated against N and F when the
+	// plugin is instantiated for a config, so that a config too small for the
+	// plugin is rejected right away rather than failing every round.
+	// ObservationQuorum returning an exact count above it is an error. Zero
+	// means undeclared: exact counts are then only checked against N-F in
+	// every round.
+	MaxExactObservationQuorum int
+
+	// Optional. Declares that the plugin's Outcome doesn't depend on
+	// observations of particular oracles, e.g. because it takes a median, so
+	// that the protocol may collect observations from a sample of oracles
+	// only. The guarantees of ObservationQuorum are unaffected: a quorum of
+	// sampled observations contains as many honest ones as any other quorum.
+	// Required for configs that set ProtocolFeatureFlagObservationSampling.
+	ObservationSampling bool
 }
+
+// ChunkedTransferConfig enables chunked transfer for plugins whose
+// observations exceed MaxMaxObservationLength. Outgoing messages longer than
+// ChunkSize are split into chunks that are sent one by one, at most
+// MaxChunksPerSecond to each oracle, and reassembled by the receiver. This
+// keeps individual messages on the underlying streams small, so that a large
+// message doesn't delay the small messages that the protocol relies on for
+// progress.
+//
+// With chunked transfer enabled, MaxObservationLength may be up to
+// MaxMaxChunkedObservationLength. Note that the leader's proposal contains up
+// to N observations: MaxChunksPerSecond must be large enough for a proposal
+// to be transferred well within DeltaProgress.
+//
+// All oracles must use the same ChunkedTransferConfig, since it determines
+// the network limits. The zero value disables chunked transfer.
+type ChunkedTransferConfig struct {
+	// Maximum length in bytes of the data carried by each chunk. Zero
+	// disables chunked transfer.
+	ChunkSize int
+	// Maximum number of chunks sent to each oracle per second
+	MaxChunksPerSecond float64
+}
+
+const (
+	MinChunkSize          = 64 * 1024
+	MaxMaxChunksPerSecond = 10000
+)
+
+// ObservationCacheConfig configures a cache of observation results, keyed by
+// the hash of the query. When a round has the same query as a recent round,
+// the cached observation is reused instead of calling
+// ReportingPlugin.Observation again. This reduces load on data sources for
+// plugins that observe identical queries across quick successive rounds, and
+// avoids observing twice when the leader of a new epoch retries a seqNr that
+// timed out with the same query.
+//
+// Only enable the cache if the plugin's observations depend on nothing but the
+// query (and on time, to an extent bounded by TTL). In particular, the
+// previous outcome is ignored for cache lookups. If observation timestamps are
+// enabled (see ProtocolFeatureFlagObservationTimestamps), a reused observation
+// is timestamped when it is reused, not when it was made.
+//
+// The zero value disables the cache.
+type ObservationCacheConfig struct {
+	// Maximum number of cached observations. Zero disables the cache.
+	MaxEntries int
+	// Maximum age of a cached observation.
+	TTL time.Duration
+	// A cached observation made in round seqNr is only reused in rounds
+	// seqNr through seqNr+SeqNrWindow. Zero means no limit besides TTL.
+	SeqNrWindow uint64
+}
+
+const MaxObservationCacheMaxEntries = 1000
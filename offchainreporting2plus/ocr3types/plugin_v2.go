@@ -0,0 +1,122 @@
+package ocr3types
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// ReportingPluginFactoryV2 is a variant of ReportingPluginFactory that creates
+// ReportingPluginV2 instances and receives a context.Context.
+type ReportingPluginFactoryV2[RI any] interface {
+	// Creates a new reporting plugin instance. The instance may have
+	// associated goroutines or hold system resources, which should be
+	// released when its Close() function is called.
+	//
+	// ctx is cancelled when the oracle shuts down or the config changes
+	// before the instance has been created. NewReportingPlugin should then
+	// stop any blocking interactions with outside services, release what it
+	// has acquired so far, and return an error.
+	NewReportingPlugin(context.Context, ReportingPluginConfig) (ReportingPluginV2[RI], ReportingPluginInfo, error)
+}
+
+// ReportingPluginV2 is a variant of ReportingPlugin in which every function
+// receives a context.Context, including the pure functions ValidateObservation,
+// ObservationQuorum, Outcome, and Reports. Apart from that, the semantics of
+// each function are exactly those documented on ReportingPlugin.
+//
+// The context passed to the pure functions carries the protocol's deadline
+// for the call, which is short since these functions should finish
+// "instantly". It is meant for tracing and for enforcing timeouts within the
+// plugin, e.g. when consulting an in-process cache. The functions must remain
+// pure: don't make their output depend on the context other than through the
+// values the protocol attaches to it, such as ObservationTimestamps, and by
+// returning an error once it is done.
+type ReportingPluginV2[RI any] interface {
+	Query(ctx context.Context, outctx OutcomeContext) (types.Query, error)
+
+	Observation(ctx context.Context, outctx OutcomeContext, query types.Query) (types.Observation, error)
+
+	ValidateObservation(ctx context.Context, outctx OutcomeContext, query types.Query, ao types.AttributedObservation) error
+
+	ObservationQuorum(ctx context.Context, outctx OutcomeContext, query types.Query) (Quorum, error)
+
+	Outcome(ctx context.Context, outctx OutcomeContext, query types.Query, aos []types.AttributedObservation) (Outcome, error)
+
+	Reports(ctx context.Context, seqNr uint64, outcome Outcome) ([]ReportWithInfo[RI], error)
+
+	ShouldAcceptAttestedReport(context.Context, uint64, ReportWithInfo[RI]) (bool, error)
+
+	ShouldTransmitAcceptedReport(context.Context, uint64, ReportWithInfo[RI]) (bool, error)
+
+	Close() error
+}
+
+// NewReportingPluginFactoryV2FromV1 adapts a ReportingPluginFactory to the
+// ReportingPluginFactoryV2 interface. The context passed to NewReportingPlugin
+// is ignored, as are the contexts passed to the created plugins' functions
+// that ReportingPlugin calls without one.
+func NewReportingPluginFactoryV2FromV1[RI any](factory ReportingPluginFactory[RI]) ReportingPluginFactoryV2[RI] {
+	return reportingPluginFactoryV1ToV2[RI]{factory}
+}
+
+type reportingPluginFactoryV1ToV2[RI any] struct {
+	factory ReportingPluginFactory[RI]
+}
+
+func (f reportingPluginFactoryV1ToV2[RI]) NewReportingPlugin(_ context.Context, config ReportingPluginConfig) (ReportingPluginV2[RI], ReportingPluginInfo, error) {
+	plugin, info, err := f.factory.NewReportingPlugin(config)
+	if err != nil {
+		return nil, info, err
+	}
+	return NewReportingPluginV2FromV1(plugin), info, nil
+}
+
+// NewReportingPluginV2FromV1 adapts a ReportingPlugin to the ReportingPluginV2
+// interface. Contexts passed to the functions that ReportingPlugin calls
+// without one are ignored.
+func NewReportingPluginV2FromV1[RI any](plugin ReportingPlugin[RI]) ReportingPluginV2[RI] {
+	return reportingPluginV1ToV2[RI]{plugin}
+}
+
+type reportingPluginV1ToV2[RI any] struct {
+	plugin ReportingPlugin[RI]
+}
+
+var _ ReportingPluginV2[struct{}] = reportingPluginV1ToV2[struct{}]{}
+
+func (rp reportingPluginV1ToV2[RI]) Query(ctx context.Context, outctx OutcomeContext) (types.Query, error) {
+	return rp.plugin.Query(ctx, outctx)
+}
+
+func (rp reportingPluginV1ToV2[RI]) Observation(ctx context.Context, outctx OutcomeContext, query types.Query) (types.Observation, error) {
+	return rp.plugin.Observation(ctx, outctx, query)
+}
+
+func (rp reportingPluginV1ToV2[RI]) ValidateObservation(_ context.Context, outctx OutcomeContext, query types.Query, ao types.AttributedObservation) error {
+	return rp.plugin.ValidateObservation(outctx, query, ao)
+}
+
+func (rp reportingPluginV1ToV2[RI]) ObservationQuorum(_ context.Context, outctx OutcomeContext, query types.Query) (Quorum, error) {
+	return rp.plugin.ObservationQuorum(outctx, query)
+}
+
+func (rp reportingPluginV1ToV2[RI]) Outcome(_ context.Context, outctx OutcomeContext, query types.Query, aos []types.AttributedObservation) (Outcome, error) {
+	return rp.plugin.Outcome(outctx, query, aos)
+}
+
+func (rp reportingPluginV1ToV2[RI]) Reports(_ context.Context, seqNr uint64, outcome Outcome) ([]ReportWithInfo[RI], error) {
+	return rp.plugin.Reports(seqNr, outcome)
+}
+
+func (rp reportingPluginV1ToV2[RI]) ShouldAcceptAttestedReport(ctx context.Context, seqNr uint64, reportWithInfo ReportWithInfo[RI]) (bool, error) {
+	return rp.plugin.ShouldAcceptAttestedReport(ctx, seqNr, reportWithInfo)
+}
+
+func (rp reportingPluginV1ToV2[RI]) ShouldTransmitAcceptedReport(ctx context.Context, seqNr uint64, reportWithInfo ReportWithInfo[RI]) (bool, error) {
+	return rp.plugin.ShouldTransmitAcceptedReport(ctx, seqNr, reportWithInfo)
+}
+
+func (rp reportingPluginV1ToV2[RI]) Close() error {
+	return rp.plugin.Close()
+}
@@ -0,0 +1,72 @@
+package ocr3types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// ObservationProvenance describes where the data in an observation came from,
+// e.g. the data providers an oracle queried. In DONs whose oracles pull from
+// heterogeneous providers, plugins may use it in Outcome to check that an
+// outcome is backed by a sufficiently diverse set of providers.
+//
+// Provenance is self-reported by the observer. It is covered by the
+// observer's signature over the observation, so it cannot be altered by the
+// leader, but a faulty observer can of course lie about it.
+type ObservationProvenance []byte
+
+const observationProvenanceLengthSize = 2
+
+// EncodeObservationWithProvenance wraps observation and provenance into a
+// single observation envelope. Plugins that set
+// ReportingPluginLimits.MaxObservationProvenanceLength must return observations
+// in this format from Observation, and the protocol will reject observations
+// from other oracles that aren't in this format or whose provenance exceeds
+// the limit.
+//
+// Note that the envelope as a whole counts towards
+// ReportingPluginLimits.MaxObservationLength.
+func EncodeObservationWithProvenance(observation types.Observation, provenance ObservationProvenance) (types.Observation, error) {
+	if len(provenance) > math.MaxUint16 {
+		return nil, fmt.Errorf("provenance is longer than %v bytes", math.MaxUint16)
+	}
+	envelope := make([]byte, 0, observationProvenanceLengthSize+len(provenance)+len(observation))
+	envelope = binary.BigEndian.AppendUint16(envelope, uint16(len(provenance)))
+	envelope = append(envelope, provenance...)
+	envelope = append(envelope, observation...)
+	return envelope, nil
+}
+
+// DecodeObservationWithProvenance reverses EncodeObservationWithProvenance. The
+// returned slices alias envelope.
+func DecodeObservationWithProvenance(envelope types.Observation) (types.Observation, ObservationProvenance, error) {
+	if len(envelope) < observationProvenanceLengthSize {
+		return nil, nil, fmt.Errorf("observation envelope is too short")
+	}
+	provenanceLength := int(binary.BigEndian.Uint16(envelope))
+	rest := envelope[observationProvenanceLengthSize:]
+	if len(rest) < provenanceLength {
+		return nil, nil, fmt.Errorf("observation envelope is truncated")
+	}
+	return rest[provenanceLength:], ObservationProvenance(rest[:provenanceLength]), nil
+}
+
+// CheckObservationProvenance checks that observation is a well-formed
+// envelope whose provenance respects the limits. It always succeeds if the
+// limits don't enable provenance.
+func CheckObservationProvenance(observation types.Observation, limits ReportingPluginLimits) error {
+	if limits.MaxObservationProvenanceLength == 0 {
+		return nil
+	}
+	_, provenance, err := DecodeObservationWithProvenance(observation)
+	if err != nil {
+		return err
+	}
+	if len(provenance) > limits.MaxObservationProvenanceLength {
+		return fmt.Errorf("provenance is too long (%v vs %v)", len(provenance), limits.MaxObservationProvenanceLength)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package ocr3types
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/merkletree"
+)
+
+// ReportBatcher may optionally be implemented by a ReportingPluginV2 that
+// produces large numbers of reports per round. Instead of having every report
+// signed individually, the reports are grouped into batches and each batch goes
+// into its own Merkle tree (see package merkletree), so that oracles only sign
+// one root per batch.
+//
+// If the plugin implements ReportBatcher, the protocol calls ReportBatches
+// instead of Reports. Each batch is then handled by the remaining components
+// as if it were a single report whose bytes are the 32-byte Merkle root of
+// the batch's reports and whose Info is that of the batch's first report:
+// this is what the OnchainKeyring signs and verifies, what
+// ShouldAcceptAttestedReport and ShouldTransmitAcceptedReport are called with,
+// and what the ContractTransmitter transmits. The batch's reports and their
+// inclusion proofs are available to the latter three via
+// ReportBatchFromContext.
+//
+// MaxReportLength in ReportingPluginLimits applies to the reports within
+// batches, and MaxReportCount bounds both the number of batches and their
+// total number of reports.
+type ReportBatcher[RI any] interface {
+	// Generates a (possibly empty) list of non-empty report batches from an
+	// outcome. The same considerations as for Reports apply; in particular,
+	// this function should be pure.
+	ReportBatches(ctx context.Context, seqNr uint64, outcome Outcome) ([][]ReportWithInfo[RI], error)
+}
+
+// ReportBatch is a batch of reports together with the Merkle root that is
+// attested in their stead and an inclusion proof for each report.
+type ReportBatch[RI any] struct {
+	Root            merkletree.Hash
+	ReportsWithInfo []ReportWithInfo[RI]
+	// Proofs[i] proves inclusion of ReportsWithInfo[i].Report, see
+	// merkletree.Verify.
+	Proofs [][]merkletree.Hash
+}
+
+// MakeReportBatch computes the Merkle root of reportsWithInfo and the inclusion
+// proofs of all reports. It returns an error if reportsWithInfo is empty.
+func MakeReportBatch[RI any](reportsWithInfo []ReportWithInfo[RI]) (ReportBatch[RI], error) {
+	leaves := make([][]byte, 0, len(reportsWithInfo))
+	for _, rwi := range reportsWithInfo {
+		leaves = append(leaves, rwi.Report)
+	}
+	root, proofs, err := merkletree.Proofs(leaves)
+	if err != nil {
+		return ReportBatch[RI]{}, err
+	}
+	return ReportBatch[RI]{root, reportsWithInfo, proofs}, nil
+}
+
+// RootReportWithInfo returns the report that is attested in place of the
+// batch.
+func (b ReportBatch[RI]) RootReportWithInfo() ReportWithInfo[RI] {
+	root := b.Root
+	return ReportWithInfo[RI]{root[:], b.ReportsWithInfo[0].Info}
+}
+
+type reportBatchContextKey struct{}
+
+// ContextWithReportBatch returns a copy of ctx that carries reportBatch. The
+// protocol uses it for the same contexts as ContextWithAttestedReport,
+// whenever the attested report is the root of a batch.
+func ContextWithReportBatch[RI any](ctx context.Context, reportBatch ReportBatch[RI]) context.Context {
+	return context.WithValue(ctx, reportBatchContextKey{}, reportBatch)
+}
+
+// ReportBatchFromContext returns the ReportBatch carried by ctx, if any. RI
+// must match the type parameter of the ReportingPlugin or ContractTransmitter
+// whose method received ctx.
+func ReportBatchFromContext[RI any](ctx context.Context) (ReportBatch[RI], bool) {
+	reportBatch, ok := ctx.Value(reportBatchContextKey{}).(ReportBatch[RI])
+	return reportBatch, ok
+}
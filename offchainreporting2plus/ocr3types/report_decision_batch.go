@@ -0,0 +1,37 @@
+package ocr3types
+
+import (
+	"context"
+)
+
+// ReportDecisionBatcher may optionally be implemented by a ReportingPluginV2
+// that produces many reports per round and whose ShouldAcceptAttestedReport
+// and ShouldTransmitAcceptedReport are expensive per call, e.g. because each
+// call does a database lookup. Its methods decide on all reports of a round at
+// once, so that the plugin can amortize such costs.
+//
+// If the plugin implements ReportDecisionBatcher, the protocol waits until all
+// reports of a round have been attested and then calls
+// ShouldAcceptAttestedReports once instead of calling
+// ShouldAcceptAttestedReport for each report. ShouldTransmitAcceptedReports is
+// only called instead of ShouldTransmitAcceptedReport if the
+// ContractTransmitter implements BatchContractTransmitter, since the reports
+// of a round are otherwise transmitted at different times.
+//
+// Each call is subject to the same MaxDuration as a single call of its
+// per-report counterpart. Unlike for the per-report functions, the contexts
+// passed to these functions don't carry an AttestedReport or ReportBatch.
+// If the plugin also implements ReportBatcher, the reports passed to these
+// functions are the roots of the batches, as for the per-report functions.
+type ReportDecisionBatcher[RI any] interface {
+	// Batched counterpart of ShouldAcceptAttestedReport. The reports are
+	// ordered by their index in the list returned by Reports. The result must
+	// contain one decision per report, in the same order.
+	ShouldAcceptAttestedReports(ctx context.Context, seqNr uint64, reports []ReportWithInfo[RI]) ([]bool, error)
+
+	// Batched counterpart of ShouldTransmitAcceptedReport. Only accepted
+	// reports are passed, ordered by their index in the list returned by
+	// Reports. The result must contain one decision per report, in the same
+	// order.
+	ShouldTransmitAcceptedReports(ctx context.Context, seqNr uint64, reports []ReportWithInfo[RI]) ([]bool, error)
+}
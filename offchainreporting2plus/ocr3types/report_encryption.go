@@ -0,0 +1,153 @@
+package ocr3types
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+const ReportEncryptionKeySize = 32
+
+const reportEncryptionDomainSeparator = "ocr3 report encryption"
+
+// ReportCipher encrypts and decrypts report payloads for a single destination
+// using AES-256-GCM. Ciphertexts are bound to the config digest, sequence
+// number, and index of the report, so they cannot be replayed under a
+// different report context, nor swapped between the reports of the same
+// sequence number.
+//
+// All its functions are thread-safe.
+type ReportCipher struct {
+	aead cipher.AEAD
+}
+
+func NewReportCipher(key [ReportEncryptionKeySize]byte) (*ReportCipher, error) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("could not create AES cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("could not create GCM: %w", err)
+	}
+	return &ReportCipher{aead}, nil
+}
+
+func reportEncryptionAdditionalData(configDigest types.ConfigDigest, seqNr uint64, index int) []byte {
+	ad := []byte(reportEncryptionDomainSeparator)
+	ad = append(ad, configDigest[:]...)
+	ad = binary.BigEndian.AppendUint64(ad, seqNr)
+	return binary.BigEndian.AppendUint64(ad, uint64(index))
+}
+
+// Encrypt returns nonce || ciphertext for the report with the given index
+// among the reports of seqNr.
+func (c *ReportCipher) Encrypt(configDigest types.ConfigDigest, seqNr uint64, index int, report types.Report) (types.Report, error) {
+	nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(report)+c.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %w", err)
+	}
+	return c.aead.Seal(nonce, nonce, report, reportEncryptionAdditionalData(configDigest, seqNr, index)), nil
+}
+
+// Decrypt reverses Encrypt. It returns an error if the ciphertext was
+// tampered with or was produced for a different config digest, sequence
+// number, or index.
+func (c *ReportCipher) Decrypt(configDigest types.ConfigDigest, seqNr uint64, index int, encryptedReport types.Report) (types.Report, error) {
+	if len(encryptedReport) < c.aead.NonceSize()+c.aead.Overhead() {
+		return nil, fmt.Errorf("encrypted report is too short")
+	}
+	nonce, ciphertext := encryptedReport[:c.aead.NonceSize()], encryptedReport[c.aead.NonceSize():]
+	report, err := c.aead.Open(nil, nonce, ciphertext, reportEncryptionAdditionalData(configDigest, seqNr, index))
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt report: %w", err)
+	}
+	return report, nil
+}
+
+// EncryptingContractTransmitter encrypts the report payload before passing it
+// on to the underlying ContractTransmitter. Use it to wrap transmitters that
+// persist reports, e.g. in a pending-transmissions database, so that payloads
+// are only ever stored in encrypted form. The component that eventually sends
+// the report should decrypt it using a DecryptingContractTransmitter with the
+// same key.
+//
+// Signatures are computed over the plaintext report and passed through
+// unchanged. The report index is taken from the context passed to Transmit,
+// see ReportIndexFromContext.
+type EncryptingContractTransmitter[RI any] struct {
+	transmitter ContractTransmitter[RI]
+	cipher      *ReportCipher
+}
+
+var _ ContractTransmitter[struct{}] = &EncryptingContractTransmitter[struct{}]{}
+
+func NewEncryptingContractTransmitter[RI any](transmitter ContractTransmitter[RI], cipher *ReportCipher) *EncryptingContractTransmitter[RI] {
+	return &EncryptingContractTransmitter[RI]{transmitter, cipher}
+}
+
+func (t *EncryptingContractTransmitter[RI]) Transmit(
+	ctx context.Context,
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	reportWithInfo ReportWithInfo[RI],
+	aoss []types.AttributedOnchainSignature,
+) error {
+	index, ok := ReportIndexFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("EncryptingContractTransmitter: context carries no report index")
+	}
+	encryptedReport, err := t.cipher.Encrypt(configDigest, seqNr, index, reportWithInfo.Report)
+	if err != nil {
+		return fmt.Errorf("EncryptingContractTransmitter: %w", err)
+	}
+	return t.transmitter.Transmit(ctx, configDigest, seqNr, ReportWithInfo[RI]{encryptedReport, reportWithInfo.Info}, aoss)
+}
+
+func (t *EncryptingContractTransmitter[RI]) FromAccount() (types.Account, error) {
+	return t.transmitter.FromAccount()
+}
+
+// DecryptingContractTransmitter decrypts report payloads produced by an
+// EncryptingContractTransmitter before passing them on to the underlying
+// ContractTransmitter, which typically sends them over a confidential channel
+// to their destination. Like EncryptingContractTransmitter, it takes the
+// report index from the context passed to Transmit. Callers other than the
+// protocol must thus pass the index using ContextWithReportIndex.
+type DecryptingContractTransmitter[RI any] struct {
+	transmitter ContractTransmitter[RI]
+	cipher      *ReportCipher
+}
+
+var _ ContractTransmitter[struct{}] = &DecryptingContractTransmitter[struct{}]{}
+
+func NewDecryptingContractTransmitter[RI any](transmitter ContractTransmitter[RI], cipher *ReportCipher) *DecryptingContractTransmitter[RI] {
+	return &DecryptingContractTransmitter[RI]{transmitter, cipher}
+}
+
+func (t *DecryptingContractTransmitter[RI]) Transmit(
+	ctx context.Context,
+	configDigest types.ConfigDigest,
+	seqNr uint64,
+	reportWithInfo ReportWithInfo[RI],
+	aoss []types.AttributedOnchainSignature,
+) error {
+	index, ok := ReportIndexFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("DecryptingContractTransmitter: context carries no report index")
+	}
+	report, err := t.cipher.Decrypt(configDigest, seqNr, index, reportWithInfo.Report)
+	if err != nil {
+		return fmt.Errorf("DecryptingContractTransmitter: %w", err)
+	}
+	return t.transmitter.Transmit(ctx, configDigest, seqNr, ReportWithInfo[RI]{report, reportWithInfo.Info}, aoss)
+}
+
+func (t *DecryptingContractTransmitter[RI]) FromAccount() (types.Account, error) {
+	return t.transmitter.FromAccount()
+}
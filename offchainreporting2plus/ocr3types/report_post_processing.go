@@ -0,0 +1,59 @@
+package ocr3types
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// ReportPostProcessor transforms attested reports between attestation and
+// transmission, e.g. to add a calldata prefix, to split a report into chunks
+// that fit into a chain's transaction size limit, or to append a proof. Pass
+// ReportPostProcessors in OCR3OracleArgs.ReportPostProcessors.
+//
+// Post-processing is local to the transmitting oracle: the signatures (and
+// aggregate signature, if any) still cover the report as produced by the
+// ReportingPlugin, so the contract must be able to recover it from the
+// post-processed reports.
+//
+// The post-processors form a pipeline: the first one receives the attested
+// report, each subsequent one receives every report returned by its
+// predecessor, in order, and the reports returned by the last one are
+// transmitted in order, each with the signatures of the original report. If
+// the transmitter implements BatchContractTransmitter, the post-processed
+// reports of a batch are passed to TransmitBatch in a single call, all with
+// the Index of their original report. An oracle post-processes the reports of
+// a protocol instance one at a time, in the order in which it transmits them,
+// and again on every retry of a failed transmission. Transmit must therefore
+// be idempotent for the reports preceding a failed one.
+//
+// After every step, the oracle checks that the number of reports doesn't
+// exceed MaxPostProcessedReportCount and that their lengths don't exceed the
+// bound given by MaxPostProcessedReportLength. If a step fails or a check
+// doesn't pass, the transmission fails (and may be retried, see package
+// transmissionretry).
+//
+// Implementations must be thread-safe if they are shared between protocol
+// instances.
+type ReportPostProcessor[RI any] interface {
+	// PostProcess transforms reportWithInfo into one or more reports. It
+	// must not return an empty slice; return an error instead.
+	PostProcess(
+		ctx context.Context,
+		configDigest types.ConfigDigest,
+		seqNr uint64,
+		reportWithInfo ReportWithInfo[RI],
+	) ([]ReportWithInfo[RI], error)
+
+	// MaxPostProcessedReportLength returns an upper bound on the length of
+	// the reports returned by PostProcess, given that its input reports are
+	// at most maxReportLength bytes long. It is called once per protocol
+	// instance, before any reports are post-processed. The bound of the first
+	// post-processor's input is the ReportingPlugin's MaxReportLength, and
+	// the bounds of all steps must not exceed MaxMaxReportLength.
+	MaxPostProcessedReportLength(maxReportLength int) int
+}
+
+// Maximum number of reports that a single attested report may be turned into
+// by the ReportPostProcessors
+const MaxPostProcessedReportCount = 64
@@ -0,0 +1,68 @@
+package ocr3types
+
+import (
+	"context"
+	"fmt"
+)
+
+// RoundAbandonmentListener may optionally be implemented by a ReportingPluginV2
+// to learn why a round it took part in ended without committing, e.g. to
+// release resources it holds for the round, or to stop prefetching data for an
+// epoch whose leader has repeatedly failed.
+//
+// When an epoch ends, the oracle checks whether it had reached the observation
+// phase of a round whose outcome it hasn't committed yet, and if so calls
+// RoundAbandoned with the round's seqNr and the phase in which the round got
+// stuck. It is called at most once per epoch.
+//
+// Abandonment is judged by this oracle alone. Other oracles may have gotten
+// further and may report a different reason. In particular, if enough oracles
+// had prepared the outcome, it is committed after all in the next epoch.
+// Either way, the next epoch continues with the seqNr following the highest
+// committed one, so the plugin may well be asked to observe for the same seqNr
+// again.
+//
+// RoundAbandoned is called from the protocol's main loop like the pure
+// ReportingPlugin functions, i.e. it should finish "instantly". Its ctx expires
+// after a short grace period, and the oracle logs an error if it takes longer
+// than that. An error returned by RoundAbandoned is logged and otherwise
+// ignored.
+type RoundAbandonmentListener interface {
+	RoundAbandoned(ctx context.Context, seqNr uint64, reason RoundAbandonmentReason) error
+}
+
+// RoundAbandonmentReason is the phase in which an abandoned round got stuck,
+// see RoundAbandonmentListener.
+type RoundAbandonmentReason int
+
+const (
+	_ RoundAbandonmentReason = iota
+	// This oracle led the round, but didn't receive a quorum of valid
+	// observations before the epoch ended.
+	RoundAbandonmentReasonInsufficientObservations
+	// This oracle observed, but didn't receive a valid proposal from the
+	// leader before the epoch ended, e.g. because the leader failed or didn't
+	// receive a quorum of observations itself.
+	RoundAbandonmentReasonNoProposal
+	// This oracle accepted the leader's proposal, but didn't receive a quorum
+	// of prepare signatures before the epoch ended.
+	RoundAbandonmentReasonInsufficientPrepares
+	// This oracle prepared the outcome, but didn't receive a quorum of commit
+	// signatures before the epoch ended. The outcome may still be committed
+	// in the next epoch.
+	RoundAbandonmentReasonInsufficientCommits
+)
+
+func (r RoundAbandonmentReason) String() string {
+	switch r {
+	case RoundAbandonmentReasonInsufficientObservations:
+		return "InsufficientObservations"
+	case RoundAbandonmentReasonNoProposal:
+		return "NoProposal"
+	case RoundAbandonmentReasonInsufficientPrepares:
+		return "InsufficientPrepares"
+	case RoundAbandonmentReasonInsufficientCommits:
+		return "InsufficientCommits"
+	}
+	return fmt.Sprintf("RoundAbandonmentReason(%d)", int(r))
+}
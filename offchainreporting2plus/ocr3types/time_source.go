@@ -0,0 +1,31 @@
+package ocr3types
+
+import "time"
+
+// TimeSource provides the timestamps that the protocol attaches to
+// observations if the config sets ProtocolFeatureFlagObservationTimestamps,
+// i.e. ObservationTimestamps.ObservedAt and ReceivedAt, and hence
+// OutcomeContext.ObservationsTimestamp. Hosts whose local clock may drift,
+// e.g. on virtualized infrastructure, can pass a more accurate TimeSource,
+// such as a GPS- or PTP-disciplined clock, to tighten agreement among oracles.
+// The protocol's own timers and timeouts use the local clock, unless the
+// TimeSource also implements TimerSource.
+//
+// Now must be thread-safe and return quickly.
+type TimeSource interface {
+	Now() time.Time
+}
+
+// TimerSource may optionally be implemented by a TimeSource to also drive the
+// protocol's timers, i.e. T_progress and T_resend of the pacemaker
+// (DeltaProgress, DeltaResend), and T_initial, T_round and T_grace of outcome
+// generation (DeltaInitial, DeltaRound, DeltaGrace). This lets tests control
+// the progress of the protocol, see e.g. ocr3testing.Clock. All other
+// timeouts, e.g. the MaxDuration limits of ReportingPlugin calls and
+// retransmission delays, always use the local clock.
+//
+// After must be thread-safe and, like time.After, return a channel that
+// receives the TimeSource's time once d has elapsed on it.
+type TimerSource interface {
+	After(d time.Duration) <-chan time.Time
+}
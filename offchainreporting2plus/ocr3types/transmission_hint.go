@@ -0,0 +1,41 @@
+package ocr3types
+
+import (
+	"time"
+)
+
+// TransmissionHinter may optionally be implemented by the Info of a
+// ReportWithInfo to adjust when the oracle transmits the report, e.g. so that
+// latency-critical and best-effort reports produced by the same instance
+// don't share one undifferentiated transmission schedule.
+//
+// Each oracle that is part of the transmission schedule for a report (see
+// ocr3confighelper.PublicConfig.S) normally waits i*DeltaStage before
+// transmitting it, where i is the oracle's stage for the report. With a
+// TransmissionHint, it waits Delay + i*DeltaStage instead, using the hint's
+// DeltaStage if it is longer. The stages themselves, and hence which oracles transmit,
+// are unaffected. Since Reports runs on every oracle, you should make the hint
+// a deterministic function of the report, so that all oracles follow the same
+// adjusted schedule.
+//
+// If the ContractTransmitter implements BatchContractTransmitter, the reports
+// of a round are transmitted together, and the batch is transmitted at the
+// earliest time permitted by the hints of its reports.
+type TransmissionHinter interface {
+	TransmissionHint() TransmissionHint
+}
+
+// TransmissionHint adjusts the transmission schedule of a single report, see
+// TransmissionHinter. The zero value leaves the schedule unchanged. Hints with
+// negative fields are ignored.
+type TransmissionHint struct {
+	// Delay before the first stage of the transmission schedule. Use it to
+	// let best-effort reports yield to latency-critical ones.
+	Delay time.Duration
+	// If longer than the DeltaStage of the config, replaces it for this
+	// report. A longer DeltaStage makes for fewer redundant transmissions, at
+	// the cost of later stages taking over later if earlier transmitters
+	// fail. Shorter values are ignored, so that hints can't make all stages
+	// transmit at once.
+	DeltaStage time.Duration
+}
@@ -18,6 +18,9 @@ type ContractTransmitter[RI any] interface {
 	// transmission in a queue/database/..., but perform the actual
 	// transmission (and potentially confirmation) of the transaction
 	// asynchronously.
+	//
+	// The context also carries all arguments bundled as an AttestedReport,
+	// see AttestedReportFromContext.
 	Transmit(
 		context.Context,
 		types.ConfigDigest,
@@ -53,3 +56,14 @@ type OnchainKeyring[RI any] interface {
 	// Maximum length of a signature
 	MaxSignatureLength() int
 }
+
+// RotatingOnchainKeyring may optionally be implemented by an OnchainKeyring to
+// support rotating its key without restarting the oracle, see
+// types.RotatingOnchainKeyring.
+type RotatingOnchainKeyring[RI any] interface {
+	OnchainKeyring[RI]
+
+	// NextOnchainKeyring returns the keyring that the OnchainKeyring is being
+	// rotated to, or nil if no rotation is in progress.
+	NextOnchainKeyring() OnchainKeyring[RI]
+}
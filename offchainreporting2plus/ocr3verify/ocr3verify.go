@@ -0,0 +1,140 @@
+// Package ocr3verify verifies attested OCR3 reports, for services that consume
+// reports outside of the protocol (e.g. risk engines, indexers) and need to
+// check that a report was really attested by an oracle config. It has no
+// networking or database dependencies; only import it together with the
+// package implementing the signature scheme of the config's OnchainKeyring.
+//
+// A report is attested iff it carries valid signatures by at least F+1
+// distinct oracles of the config under which it was generated, the same
+// threshold contracts enforce.
+package ocr3verify
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// SignatureVerifier verifies a single oracle's signature over a report. Every
+// ocr3types.OnchainKeyring is a SignatureVerifier, but since Verify doesn't
+// use the keyring's private key, implementations that only contain the
+// verification logic are fine too (e.g. a keyring constructed with a dummy
+// key). Must be thread-safe if the Verifier is used concurrently.
+type SignatureVerifier[RI any] interface {
+	// Same semantics as ocr3types.OnchainKeyring.Verify
+	Verify(publicKey types.OnchainPublicKey, configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ocr3types.ReportWithInfo[RI], signature []byte) bool
+}
+
+var _ SignatureVerifier[struct{}] = ocr3types.OnchainKeyring[struct{}](nil)
+
+// SignerSet is the part of an OCR3 config needed to verify its reports.
+type SignerSet struct {
+	ConfigDigest types.ConfigDigest
+	// OnchainPublicKeys of the config's oracles, indexed by OracleID
+	Signers []types.OnchainPublicKey
+	// Maximum number of faulty oracles
+	F int
+}
+
+// SignerSetFromContractConfig extracts the SignerSet from an OCR3 config as
+// returned by a ContractConfigTracker.
+func SignerSetFromContractConfig(contractConfig types.ContractConfig) SignerSet {
+	return SignerSet{
+		contractConfig.ConfigDigest,
+		contractConfig.Signers,
+		int(contractConfig.F),
+	}
+}
+
+func (s SignerSet) Validate() error {
+	if len(s.Signers) > types.MaxOracles {
+		return fmt.Errorf("SignerSet has %v signers, but at most %v are allowed", len(s.Signers), types.MaxOracles)
+	}
+	if !(0 <= s.F && 3*s.F < len(s.Signers)) {
+		return fmt.Errorf("SignerSet has F=%v, but it must be non-negative and less than a third of the %v signers", s.F, len(s.Signers))
+	}
+	for i, signer := range s.Signers {
+		if len(signer) == 0 {
+			return fmt.Errorf("signer %v has empty public key", i)
+		}
+	}
+	return nil
+}
+
+// Verifier verifies attested reports of a single config. It is thread-safe
+// if its SignatureVerifier is.
+type Verifier[RI any] struct {
+	signerSet         SignerSet
+	signatureVerifier SignatureVerifier[RI]
+}
+
+func NewVerifier[RI any](signerSet SignerSet, signatureVerifier SignatureVerifier[RI]) (*Verifier[RI], error) {
+	if err := signerSet.Validate(); err != nil {
+		return nil, err
+	}
+	if signatureVerifier == nil {
+		return nil, fmt.Errorf("SignatureVerifier is nil")
+	}
+	signers := make([]types.OnchainPublicKey, 0, len(signerSet.Signers))
+	for _, signer := range signerSet.Signers {
+		signers = append(signers, append(types.OnchainPublicKey{}, signer...))
+	}
+	return &Verifier[RI]{
+		SignerSet{signerSet.ConfigDigest, signers, signerSet.F},
+		signatureVerifier,
+	}, nil
+}
+
+// ConfigDigest returns the digest of the config whose reports v verifies.
+func (v *Verifier[RI]) ConfigDigest() types.ConfigDigest {
+	return v.signerSet.ConfigDigest
+}
+
+// Verify returns nil iff signatures attest reportWithInfo at seqNr under
+// configDigest, i.e. configDigest is v's config digest and at least F+1
+// distinct oracles of the config produced valid signatures. A report carrying
+// an invalid signature is rejected, even if the remaining signatures reach
+// the threshold.
+func (v *Verifier[RI]) Verify(configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ocr3types.ReportWithInfo[RI], signatures []types.AttributedOnchainSignature) error {
+	if configDigest != v.signerSet.ConfigDigest {
+		return fmt.Errorf("report has config digest %v, expected %v", configDigest, v.signerSet.ConfigDigest)
+	}
+	if seqNr == 0 {
+		return fmt.Errorf("report has seqNr 0, but sequence numbers start at 1")
+	}
+	if len(signatures) <= v.signerSet.F {
+		return fmt.Errorf("got %v signatures, expected at least %v", len(signatures), v.signerSet.F+1)
+	}
+	// keyed by public key rather than OracleID, in case a config lists the
+	// same key twice
+	seen := make(map[string]commontypes.OracleID, len(signatures))
+	for i, signature := range signatures {
+		if int(signature.Signer) >= len(v.signerSet.Signers) {
+			return fmt.Errorf("signature %v has signer %v out of range, there are only %v oracles", i, signature.Signer, len(v.signerSet.Signers))
+		}
+		publicKey := v.signerSet.Signers[signature.Signer]
+		if other, ok := seen[string(publicKey)]; ok {
+			return fmt.Errorf("signature %v has signer %v, whose key has already signed as signer %v", i, signature.Signer, other)
+		}
+		seen[string(publicKey)] = signature.Signer
+		if !v.signatureVerifier.Verify(publicKey, configDigest, seqNr, reportWithInfo, signature.Signature) {
+			return fmt.Errorf("signature %v by signer %v is invalid", i, signature.Signer)
+		}
+	}
+	return nil
+}
+
+// VerifyAttestedReport is like Verify for an ocr3types.AttestedReport. If the
+// report is aggregate-attested, only its AttributedSignatures are verified;
+// check the AggregateSignature with the function provided by the signature
+// scheme (e.g. blsattestation.VerifyAggregate) if you rely on it.
+func (v *Verifier[RI]) VerifyAttestedReport(attestedReport ocr3types.AttestedReport[RI]) error {
+	return v.Verify(
+		attestedReport.ConfigDigest,
+		attestedReport.SeqNr,
+		attestedReport.ReportWithInfo,
+		attestedReport.AttributedSignatures,
+	)
+}
@@ -0,0 +1,146 @@
+// Package onchainconfig provides canonical encoders and decoders for the
+// standard layouts of types.ContractConfig.OnchainConfig (and
+// ocr3types.ReportingPluginConfig.OnchainConfig), so that reporting plugins
+// don't need to re-implement them.
+//
+// Every standard layout starts with a version byte that determines how the
+// rest of the config is to be interpreted. New layouts get new versions, so
+// that contracts and plugins can be upgraded independently of each other.
+package onchainconfig
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/libocr/bigbigendian"
+)
+
+type Version uint8
+
+const (
+	// VersionNumericBounds identifies the NumericBounds layout. It is used by
+	// the EVM and Solana integrations of the median plugin.
+	VersionNumericBounds Version = 1
+)
+
+// UnknownVersionError is returned when decoding an onchain config whose
+// version isn't supported by this package.
+type UnknownVersionError struct {
+	Version Version
+}
+
+func (e UnknownVersionError) Error() string {
+	return fmt.Sprintf("unknown OnchainConfig version %v", e.Version)
+}
+
+// OnchainConfig is a decoded onchain config in one of the standard layouts.
+// Exactly the field corresponding to Version is set.
+type OnchainConfig struct {
+	Version       Version
+	NumericBounds *NumericBounds
+}
+
+// VersionOf returns the version of the encoded onchain config b, without
+// checking whether the version is known.
+func VersionOf(b []byte) (Version, error) {
+	if len(b) == 0 {
+		return 0, fmt.Errorf("OnchainConfig is empty")
+	}
+	return Version(b[0]), nil
+}
+
+// Decode decodes an onchain config in any of the standard layouts. If
+// the version is unknown, the error is an UnknownVersionError.
+func Decode(b []byte) (OnchainConfig, error) {
+	version, err := VersionOf(b)
+	if err != nil {
+		return OnchainConfig{}, err
+	}
+	switch version {
+	case VersionNumericBounds:
+		numericBounds, err := DecodeNumericBounds(b)
+		if err != nil {
+			return OnchainConfig{}, err
+		}
+		return OnchainConfig{version, &numericBounds}, nil
+	default:
+		return OnchainConfig{}, UnknownVersionError{version}
+	}
+}
+
+// Encode encodes c in the layout determined by c.Version.
+func Encode(c OnchainConfig) ([]byte, error) {
+	switch c.Version {
+	case VersionNumericBounds:
+		if c.NumericBounds == nil {
+			return nil, fmt.Errorf("NumericBounds must be set for version %v", c.Version)
+		}
+		return EncodeNumericBounds(*c.NumericBounds)
+	default:
+		return nil, UnknownVersionError{c.Version}
+	}
+}
+
+// NumericBounds is the onchain config of contracts that only accept reported
+// values in [Min, Max]. It's encoded as
+//
+//	<version><min><max>
+//
+// where version is VersionNumericBounds as a uint8, and min and max are int192
+// in 24-byte big endian two's complement representation.
+type NumericBounds struct {
+	Min *big.Int
+	Max *big.Int
+}
+
+// Bounds on an int192
+const int192ByteWidth = 24
+
+const numericBoundsEncodedLength = 1 + int192ByteWidth + int192ByteWidth
+
+func DecodeNumericBounds(b []byte) (NumericBounds, error) {
+	if len(b) != numericBoundsEncodedLength {
+		return NumericBounds{}, fmt.Errorf("unexpected length of OnchainConfig, expected %v, got %v", numericBoundsEncodedLength, len(b))
+	}
+
+	if Version(b[0]) != VersionNumericBounds {
+		return NumericBounds{}, fmt.Errorf("unexpected version of OnchainConfig, expected %v, got %v", VersionNumericBounds, b[0])
+	}
+
+	min, err := bigbigendian.DeserializeSigned(int192ByteWidth, b[1:1+int192ByteWidth])
+	if err != nil {
+		return NumericBounds{}, err
+	}
+	max, err := bigbigendian.DeserializeSigned(int192ByteWidth, b[1+int192ByteWidth:])
+	if err != nil {
+		return NumericBounds{}, err
+	}
+
+	if !(min.Cmp(max) <= 0) {
+		return NumericBounds{}, fmt.Errorf("OnchainConfig min (%v) should not be greater than max(%v)", min, max)
+	}
+
+	return NumericBounds{min, max}, nil
+}
+
+func EncodeNumericBounds(c NumericBounds) ([]byte, error) {
+	if c.Min == nil || c.Max == nil {
+		return nil, fmt.Errorf("OnchainConfig min and max must not be nil")
+	}
+	if !(c.Min.Cmp(c.Max) <= 0) {
+		return nil, fmt.Errorf("OnchainConfig min (%v) should not be greater than max(%v)", c.Min, c.Max)
+	}
+	minBytes, err := bigbigendian.SerializeSigned(int192ByteWidth, c.Min)
+	if err != nil {
+		return nil, err
+	}
+	maxBytes, err := bigbigendian.SerializeSigned(int192ByteWidth, c.Max)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]byte, 0, numericBoundsEncodedLength)
+	result = append(result, byte(VersionNumericBounds))
+	result = append(result, minBytes...)
+	result = append(result, maxBytes...)
+	return result, nil
+}
@@ -4,19 +4,33 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/admission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/atrest"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/chaos"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/denylist"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/heartbeat"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/managed"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/internal/shim"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/latestreportcache"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3trace"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/pluginscheduler"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/retransmission"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/transmissionretry"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 	"github.com/smartcontractkit/libocr/subprocesses"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type OracleArgs interface {
 	oracleArgsMarker()
 	localConfig() types.LocalConfig
-	runManaged(ctx context.Context)
+	runManaged(ctx context.Context, teardownCtx context.Context, telemetryQueueStats *shim.TelemetryQueueStats, instances *managed.Instances)
 }
 
 // OCR2OracleArgs contains the configuration and services a caller must provide, in
@@ -63,21 +77,37 @@ type OCR2OracleArgs struct {
 	// ReportingPluginFactory creates ReportingPlugins that determine the
 	// "application logic" used in a OCR2 protocol instance.
 	ReportingPluginFactory types.ReportingPluginFactory
+	// AdmissionController decides whether the node has the capacity to run a
+	// protocol instance before the oracle starts it. Optional, every instance
+	// is run if nil. See package admission for details.
+	AdmissionController *admission.Controller
+	// Denylist lists the config digests of protocol instances the oracle must
+	// never run. Optional, no instance is denied if nil. See package denylist
+	// for details.
+	Denylist *denylist.Controller
+	// AtRestKeyProvider enables envelope encryption of the data the oracle
+	// persists in the Database. Optional, data is stored in plaintext if nil.
+	// See package atrest for details.
+	AtRestKeyProvider atrest.KeyProvider
 }
 
 func (OCR2OracleArgs) oracleArgsMarker() {}
 
 func (args OCR2OracleArgs) localConfig() types.LocalConfig { return args.LocalConfig }
 
-func (args OCR2OracleArgs) runManaged(ctx context.Context) {
+func (args OCR2OracleArgs) runManaged(ctx context.Context, teardownCtx context.Context, telemetryQueueStats *shim.TelemetryQueueStats, instances *managed.Instances) {
 	logger := loghelper.MakeRootLoggerWithContext(args.Logger)
 
 	managed.RunManagedOCR2Oracle(
 		ctx,
+		teardownCtx,
 
 		args.V2Bootstrappers,
+		args.AdmissionController,
+		args.AtRestKeyProvider,
 		args.ContractConfigTracker,
 		args.ContractTransmitter,
+		args.Denylist,
 		args.Database,
 		args.LocalConfig,
 		logger,
@@ -87,6 +117,7 @@ func (args OCR2OracleArgs) runManaged(ctx context.Context) {
 		args.OffchainKeyring,
 		args.OnchainKeyring,
 		args.ReportingPluginFactory,
+		telemetryQueueStats,
 	)
 }
 
@@ -134,22 +165,45 @@ type MercuryOracleArgs struct {
 	// ReportingPluginFactory creates ReportingPlugins that determine the
 	// "application logic" used in an OCR protocol instance.
 	MercuryPluginFactory ocr3types.MercuryPluginFactory
+	// AdmissionController decides whether the node has the capacity to run a
+	// protocol instance before the oracle starts it. Optional, every instance
+	// is run if nil. See package admission for details.
+	AdmissionController *admission.Controller
+	// Denylist lists the config digests of protocol instances the oracle must
+	// never run. Optional, no instance is denied if nil. See package denylist
+	// for details.
+	Denylist *denylist.Controller
+	// AtRestKeyProvider enables envelope encryption of the data the oracle
+	// persists in the Database. Optional, data is stored in plaintext if nil.
+	// See package atrest for details.
+	AtRestKeyProvider atrest.KeyProvider
+
+	// HeartbeatConfig enables periodic signed heartbeats over the
+	// MonitoringEndpoint. Optional, no heartbeats are emitted if nil. See
+	// package heartbeat for details.
+	HeartbeatConfig *heartbeat.Config
 }
 
 func (MercuryOracleArgs) oracleArgsMarker() {}
 
 func (args MercuryOracleArgs) localConfig() types.LocalConfig { return args.LocalConfig }
 
-func (args MercuryOracleArgs) runManaged(ctx context.Context) {
+func (args MercuryOracleArgs) runManaged(ctx context.Context, teardownCtx context.Context, telemetryQueueStats *shim.TelemetryQueueStats, instances *managed.Instances) {
 	logger := loghelper.MakeRootLoggerWithContext(args.Logger)
 
 	managed.RunManagedMercuryOracle(
 		ctx,
+		teardownCtx,
 
 		args.V2Bootstrappers,
+		args.AdmissionController,
+		args.AtRestKeyProvider,
 		args.ContractConfigTracker,
 		args.ContractTransmitter,
+		args.Denylist,
 		args.Database,
+		args.HeartbeatConfig,
+		instances,
 		args.LocalConfig,
 		logger,
 		args.MonitoringEndpoint,
@@ -158,6 +212,7 @@ func (args MercuryOracleArgs) runManaged(ctx context.Context) {
 		args.OffchainKeyring,
 		args.OnchainKeyring,
 		args.MercuryPluginFactory,
+		telemetryQueueStats,
 	)
 }
 
@@ -173,7 +228,10 @@ type OCR3OracleArgs[RI any] struct {
 	// Tracks configuration changes.
 	ContractConfigTracker types.ContractConfigTracker
 
-	// Transmit reports to the targeted system (e.g. a blockchain)
+	// Transmit reports to the targeted system (e.g. a blockchain). Use an
+	// ocr3types.ContractTransmitterBundle to transmit to several destinations.
+	// If it also implements ocr3types.BatchContractTransmitter, all reports of
+	// a round are transmitted in a single call.
 	ContractTransmitter ocr3types.ContractTransmitter[RI]
 
 	// Database provides persistent storage.
@@ -186,6 +244,25 @@ type OCR3OracleArgs[RI any] struct {
 	// Logger logs stuff.
 	Logger commontypes.Logger
 
+	// MetricsRegisterer is used to register prometheus metrics about the
+	// protocol's internals, e.g. round durations, plugin call latencies, and
+	// message counts, as well as metrics about telemetry that hasn't been
+	// delivered to the MonitoringEndpoint yet (see OracleStatus.Telemetry).
+	// Optional, metrics aren't exported if nil. If several oracles share a
+	// registerer, wrap it with prometheus.WrapRegistererWith to give each
+	// oracle's metrics distinct labels.
+	MetricsRegisterer prometheus.Registerer
+
+	// TracerProvider is used to create OpenTelemetry spans for the phases of
+	// each round, e.g. calls to the ReportingPlugin, the leader's collection
+	// of observations, report attestation, and transmission. Plugin callbacks
+	// receive contexts carrying these spans. All oracles put their spans for
+	// a given (configDigest, seqNr) into the same trace, whose ID is derived
+	// from configDigest and seqNr, so traces can be correlated across oracles
+	// without propagating trace context over the network. Optional, no spans
+	// are recorded if nil.
+	TracerProvider trace.TracerProvider
+
 	// Used to send logs to a monitor.
 	MonitoringEndpoint commontypes.MonitoringEndpoint
 
@@ -197,36 +274,134 @@ type OCR3OracleArgs[RI any] struct {
 	OffchainKeyring types.OffchainKeyring
 
 	// OnchainKeyring is used to sign reports that can be validated
-	// offchain and by the target contract.
+	// offchain and by the target contract. Use an
+	// ocr3types.OnchainKeyringBundle if reports are fanned out to several
+	// destinations with different signature schemes.
 	OnchainKeyring ocr3types.OnchainKeyring[RI]
 
 	// PluginFactory creates Plugins that determine the "application logic" used
 	// in a protocol instance.
 	ReportingPluginFactory ocr3types.ReportingPluginFactory[RI]
+
+	// ReportingPluginFactoryV2 is an alternative to ReportingPluginFactory
+	// for plugins that want a context.Context passed to every function,
+	// including NewReportingPlugin. If set, ReportingPluginFactory is ignored.
+	ReportingPluginFactoryV2 ocr3types.ReportingPluginFactoryV2[RI]
+
+	// ChaosController enables fault injection for game-day exercises. Leave
+	// nil in normal operation. See package chaos for details.
+	ChaosController *chaos.Controller
+
+	// RetransmissionController lets the plugin or host application request
+	// that the most recent attested reports be transmitted again. May be nil,
+	// in which case retransmission is not available. See package
+	// retransmission for details.
+	RetransmissionController *retransmission.Controller
+	// AdmissionController decides whether the node has the capacity to run a
+	// protocol instance before the oracle starts it. Optional, every instance
+	// is run if nil. See package admission for details.
+	AdmissionController *admission.Controller
+	// Denylist lists the config digests of protocol instances the oracle must
+	// never run. Optional, no instance is denied if nil. See package denylist
+	// for details.
+	Denylist *denylist.Controller
+	// AtRestKeyProvider enables envelope encryption of the data the oracle
+	// persists in the Database. Optional, data is stored in plaintext if nil.
+	// See package atrest for details.
+	AtRestKeyProvider atrest.KeyProvider
+
+	// HeartbeatConfig enables periodic signed heartbeats over the
+	// MonitoringEndpoint. Optional, no heartbeats are emitted if nil. See
+	// package heartbeat for details.
+	HeartbeatConfig *heartbeat.Config
+
+	// TransmissionRetryPolicy decides whether and when transmissions that
+	// failed are retried. Optional, failed transmissions aren't retried if
+	// nil. See package transmissionretry for details.
+	TransmissionRetryPolicy transmissionretry.Policy
+
+	// ReportPostProcessors transform attested reports before they are passed
+	// to the ContractTransmitter, in the given order. Optional, reports are
+	// transmitted as produced by the ReportingPlugin if empty. See
+	// ocr3types.ReportPostProcessor for details.
+	ReportPostProcessors []ocr3types.ReportPostProcessor[RI]
+
+	// PluginScheduler shares the node's capacity for running ReportingPlugin
+	// callbacks fairly among protocol instances. Optional, callbacks are run
+	// as soon as the protocol demands them if nil. See package
+	// pluginscheduler for details.
+	PluginScheduler *pluginscheduler.Scheduler
+
+	// TimeSource provides the timestamps attached to observations if the
+	// config enables ocr3types.ProtocolFeatureFlagObservationTimestamps.
+	// Optional, the local clock is used if nil. See ocr3types.TimeSource for
+	// details.
+	TimeSource ocr3types.TimeSource
+
+	// MessageArchiver receives copies of the protocol messages the oracle
+	// sends and receives, sampled and truncated according to
+	// LocalConfig.MessageArchiving. Optional, no messages are archived if
+	// nil. See types.MessageArchiver for details.
+	MessageArchiver types.MessageArchiver
+
+	// TraceRecorder records a trace of the protocol messages, events, and
+	// ReportingPlugin calls of every protocol instance, for replaying the
+	// plugin calls offline while debugging an incident. Leave nil in normal
+	// operation. Close the Recorder after closing the oracle. See package
+	// ocr3trace for details.
+	TraceRecorder *ocr3trace.Recorder
+
+	// LatestReportCache holds the reports that the ContractTransmitter has
+	// confirmed onchain. Optional, the oracle transmits every report it is
+	// asked to if nil. See package latestreportcache for details.
+	LatestReportCache *latestreportcache.Cache
 }
 
 func (OCR3OracleArgs[RI]) oracleArgsMarker() {}
 
 func (args OCR3OracleArgs[RI]) localConfig() types.LocalConfig { return args.LocalConfig }
 
-func (args OCR3OracleArgs[RI]) runManaged(ctx context.Context) {
+func (args OCR3OracleArgs[RI]) runManaged(ctx context.Context, teardownCtx context.Context, telemetryQueueStats *shim.TelemetryQueueStats, instances *managed.Instances) {
 	logger := loghelper.MakeRootLoggerWithContext(args.Logger)
 
+	reportingPluginFactory := args.ReportingPluginFactoryV2
+	if reportingPluginFactory == nil {
+		reportingPluginFactory = ocr3types.NewReportingPluginFactoryV2FromV1(args.ReportingPluginFactory)
+	}
+
 	managed.RunManagedOCR3Oracle(
 		ctx,
+		teardownCtx,
 
 		args.V2Bootstrappers,
+		args.AdmissionController,
+		args.AtRestKeyProvider,
+		args.ChaosController,
 		args.ContractConfigTracker,
 		args.ContractTransmitter,
+		args.Denylist,
 		args.Database,
+		args.HeartbeatConfig,
+		instances,
+		args.LatestReportCache,
 		args.LocalConfig,
 		logger,
+		args.MessageArchiver,
+		args.MetricsRegisterer,
 		args.MonitoringEndpoint,
 		args.BinaryNetworkEndpointFactory,
 		args.OffchainConfigDigester,
 		args.OffchainKeyring,
 		args.OnchainKeyring,
-		args.ReportingPluginFactory,
+		args.PluginScheduler,
+		reportingPluginFactory,
+		args.ReportPostProcessors,
+		args.RetransmissionController,
+		telemetryQueueStats,
+		args.TimeSource,
+		args.TraceRecorder,
+		args.TracerProvider,
+		args.TransmissionRetryPolicy,
 	)
 }
 
@@ -240,7 +415,38 @@ const (
 
 type Oracle interface {
 	Start() error
+	// Close is equivalent to CloseCtx(context.Background()).
 	Close() error
+	// CloseCtx shuts down the oracle. If ctx is done before shutdown has
+	// completed, e.g. because a ReportingPlugin's Close blocks, CloseCtx
+	// abandons the components that haven't stopped yet, logs them, and
+	// returns an error. Abandoned components may keep running in the
+	// background.
+	CloseCtx(ctx context.Context) error
+}
+
+// StatusReporter is implemented by the Oracles returned by NewOracle, which
+// returns the Oracle interface for compatibility:
+//
+//	if statusReporter, ok := oracle.(StatusReporter); ok {
+//		status := statusReporter.Status()
+//		...
+//	}
+type StatusReporter interface {
+	// Status returns a snapshot of the oracle's internal state. Safe to call
+	// at any time, including concurrently with Start and Close. Once the
+	// oracle has been closed, nothing is reported as pending anymore.
+	Status() OracleStatus
+}
+
+type OracleStatus struct {
+	// Telemetry destined for the MonitoringEndpoint that hasn't been
+	// delivered yet.
+	Telemetry types.TelemetryQueueStatus
+	// The OCR3 protocol instances the oracle is currently running, ordered by
+	// config digest. An instance is degraded if it can't reach a quorum of
+	// oracles, see types.DegradedModeConfig. Always empty for OCR2 oracles.
+	Instances []types.ProtocolInstanceStatus
 }
 
 type oracle struct {
@@ -250,11 +456,18 @@ type oracle struct {
 
 	oracleArgs OracleArgs
 
+	telemetryQueueStats *shim.TelemetryQueueStats
+	instances           *managed.Instances
+
 	// subprocesses tracks completion of all go routines on Oracle.Close()
 	subprocesses subprocesses.Subprocesses
 
 	// cancel sends a cancel message to all subprocesses, via a context.Context
 	cancel context.CancelFunc
+
+	// teardownCancel tells subprocesses to stop waiting for components to
+	// close
+	teardownCancel context.CancelFunc
 }
 
 // NewOracle returns a newly initialized Oracle using the provided services
@@ -267,8 +480,11 @@ func NewOracle(args OracleArgs) (Oracle, error) {
 		sync.Mutex{},
 		oracleStateUnstarted,
 		args,
+		shim.NewTelemetryQueueStats(),
+		managed.NewInstances(),
 		subprocesses.Subprocesses{},
 		nil,
+		nil,
 	}, nil
 }
 
@@ -284,16 +500,23 @@ func (o *oracle) Start() error {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	o.cancel = cancel
+	teardownCtx, teardownCancel := context.WithCancel(context.Background())
+	o.teardownCancel = teardownCancel
 	o.subprocesses.Go(func() {
 		defer cancel()
+		defer teardownCancel()
 
-		o.oracleArgs.runManaged(ctx)
+		o.oracleArgs.runManaged(ctx, teardownCtx, o.telemetryQueueStats, o.instances)
 	})
 	return nil
 }
 
 // Close shuts down an oracle. Can safely be called multiple times.
 func (o *oracle) Close() error {
+	return o.CloseCtx(context.Background())
+}
+
+func (o *oracle) CloseCtx(ctx context.Context) error {
 	o.lock.Lock()
 	defer o.lock.Unlock()
 
@@ -305,8 +528,43 @@ func (o *oracle) Close() error {
 	if o.cancel != nil {
 		o.cancel()
 	}
+	// Once ctx is done, subprocesses stop waiting for components to close.
+	stop := context.AfterFunc(ctx, o.teardownCancel)
+	defer stop()
+
 	// Wait for all subprocesses to shut down, before shutting down other resources.
 	// (Wouldn't want anything to panic from attempting to use a closed resource.)
-	o.subprocesses.Wait()
-	return nil
+	chDone := make(chan struct{})
+	go func() {
+		o.subprocesses.Wait()
+		close(chDone)
+	}()
+	select {
+	case <-chDone:
+		return nil
+	case <-ctx.Done():
+	}
+
+	// Subprocesses abandon components that fail to close, so they should exit
+	// promptly now. If they don't, the protocol itself is stuck, e.g. in a
+	// ReportingPlugin call that ignores its context.
+	select {
+	case <-chDone:
+		return fmt.Errorf("oracle shutdown exceeded deadline, abandoned components that failed to close: %w", ctx.Err())
+	case <-time.After(abandonGracePeriod):
+		return fmt.Errorf("oracle shutdown exceeded deadline, abandoned oracle that failed to stop: %w", ctx.Err())
+	}
+}
+
+// How long CloseCtx keeps waiting after its deadline for subprocesses to
+// abandon components that failed to close.
+const abandonGracePeriod = 100 * time.Millisecond
+
+var _ StatusReporter = (*oracle)(nil)
+
+func (o *oracle) Status() OracleStatus {
+	return OracleStatus{
+		o.telemetryQueueStats.Status(),
+		o.instances.Status(),
+	}
 }
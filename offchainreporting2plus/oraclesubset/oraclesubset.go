@@ -0,0 +1,113 @@
+// Package oraclesubset helps OCR3 reporting plugins pick a pseudorandom subset
+// of oracles for each round, e.g. to have only a few oracles perform an
+// expensive observation.
+//
+// # Determinism
+//
+// The subset for a round is derived solely from values that all correct
+// oracles agree on: the ConfigDigest of the protocol instance, the SeqNr of the
+// round, and the PreviousOutcome (which the protocol guarantees to be the unique
+// committed outcome with sequence number SeqNr-1). Hence, all correct oracles
+// computing the subset for the same OutcomeContext obtain the same result
+// without any additional communication.
+//
+// # Fairness
+//
+// The seed for round SeqNr is the SHA2-256 hash of a domain separator, the
+// ConfigDigest, SeqNr, and PreviousOutcome. Modelling SHA2-256 as a random
+// oracle, seeds for distinct (ConfigDigest, SeqNr) pairs are independent and
+// uniformly distributed. The seed keys a cryptographically secure pseudorandom
+// permutation pi of [0, n) (see package permutation). The subset consists of
+// the oracles i with pi[i] < k. Since pi is (computationally
+// indistinguishable from) a uniformly random permutation, it follows that:
+//
+// - every oracle is selected with probability exactly k/n in every round,
+//
+// - every k-subset of oracles is selected with probability 1/(n choose k),
+//
+// - selections in different rounds are independent, so over R rounds each
+// oracle is expected to be selected R*k/n times, and by a Chernoff bound, the
+// probability that an oracle is selected fewer than (1-d)*R*k/n times is at
+// most exp(-d^2*R*k/(2n)).
+//
+// # Caveats
+//
+// Independence across rounds relies on PreviousOutcome not being chosen
+// adversarially *after* the adversary learns which subset a given outcome
+// results in. Outcomes are computed by the plugin's (pure) Outcome function
+// from a quorum of observations. If a plugin lets a small number of oracles
+// freely "grind" bytes into the outcome, a byzantine oracle may be able to
+// bias the selection for the next round. Plugins that are concerned about this
+// should ensure that their outcome is not malleable by individual oracles.
+//
+// A subset only ever selects oracles, it doesn't guarantee that the selected
+// oracles are correct or online. Plugins should choose k large enough that at
+// least one (or however many they require) correct oracle is in the subset
+// with high probability, e.g. k = f+1 guarantees at least one correct oracle.
+package oraclesubset
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/libocr/permutation"
+)
+
+const domainSeparator = "ocr3 oracle subset"
+
+// Seed returns the seed used to derive the subset for the round identified by
+// outctx. It is exposed so that callers can derive additional per-round
+// randomness that is consistent across all correct oracles.
+func Seed(configDigest types.ConfigDigest, outctx ocr3types.OutcomeContext) [16]byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(domainSeparator))
+	_, _ = h.Write(configDigest[:])
+	_ = binary.Write(h, binary.BigEndian, outctx.SeqNr)
+	_ = binary.Write(h, binary.BigEndian, uint64(len(outctx.PreviousOutcome)))
+	_, _ = h.Write(outctx.PreviousOutcome)
+
+	var seed [16]byte
+	copy(seed[:], h.Sum(nil))
+	return seed
+}
+
+// Subset returns a sorted list of k distinct oracles out of n. The result is a
+// deterministic function of its inputs. See the package documentation for a
+// discussion of the fairness properties.
+func Subset(configDigest types.ConfigDigest, outctx ocr3types.OutcomeContext, n int, k int) ([]commontypes.OracleID, error) {
+	if !(0 < n && n <= types.MaxOracles) {
+		return nil, fmt.Errorf("n (%v) must be between 1 and %v", n, types.MaxOracles)
+	}
+	if !(0 <= k && k <= n) {
+		return nil, fmt.Errorf("k (%v) must be between 0 and n (%v)", k, n)
+	}
+
+	pi := permutation.Permutation(n, Seed(configDigest, outctx))
+
+	subset := make([]commontypes.OracleID, 0, k)
+	for i, p := range pi {
+		if p < k {
+			subset = append(subset, commontypes.OracleID(i))
+		}
+	}
+	return subset, nil
+}
+
+// IsSelected is a convenience function that returns whether oracleID is
+// contained in the Subset with the same parameters.
+func IsSelected(configDigest types.ConfigDigest, outctx ocr3types.OutcomeContext, n int, k int, oracleID commontypes.OracleID) (bool, error) {
+	subset, err := Subset(configDigest, outctx, n, k)
+	if err != nil {
+		return false, err
+	}
+	for _, selected := range subset {
+		if selected == oracleID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
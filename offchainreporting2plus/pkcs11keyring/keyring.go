@@ -0,0 +1,63 @@
+package pkcs11keyring
+
+import (
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// OCR2Keyring is a types.OnchainKeyring whose key is held by an HSM. It is
+// thread-safe.
+type OCR2Keyring struct {
+	signer *Signer
+}
+
+var _ types.OnchainKeyring = &OCR2Keyring{}
+
+func NewOCR2Keyring(signer *Signer) *OCR2Keyring {
+	return &OCR2Keyring{signer}
+}
+
+func (k *OCR2Keyring) PublicKey() types.OnchainPublicKey {
+	return k.signer.OnchainPublicKey()
+}
+
+func (k *OCR2Keyring) Sign(repctx types.ReportContext, report types.Report) ([]byte, error) {
+	return k.signer.signDigest(k.signer.scheme.OCR2Digest(repctx, report))
+}
+
+func (k *OCR2Keyring) Verify(publicKey types.OnchainPublicKey, repctx types.ReportContext, report types.Report, signature []byte) bool {
+	return k.signer.scheme.Verify(publicKey, k.signer.scheme.OCR2Digest(repctx, report), signature)
+}
+
+func (k *OCR2Keyring) MaxSignatureLength() int {
+	return k.signer.scheme.MaxSignatureLength()
+}
+
+// OCR3Keyring is an ocr3types.OnchainKeyring whose key is held by an HSM. It
+// signs the report bytes only, the report info isn't signed. It is
+// thread-safe.
+type OCR3Keyring[RI any] struct {
+	signer *Signer
+}
+
+var _ ocr3types.OnchainKeyring[struct{}] = &OCR3Keyring[struct{}]{}
+
+func NewOCR3Keyring[RI any](signer *Signer) *OCR3Keyring[RI] {
+	return &OCR3Keyring[RI]{signer}
+}
+
+func (k *OCR3Keyring[RI]) PublicKey() types.OnchainPublicKey {
+	return k.signer.OnchainPublicKey()
+}
+
+func (k *OCR3Keyring[RI]) Sign(configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ocr3types.ReportWithInfo[RI]) ([]byte, error) {
+	return k.signer.signDigest(k.signer.scheme.OCR3Digest(configDigest, seqNr, reportWithInfo.Report))
+}
+
+func (k *OCR3Keyring[RI]) Verify(publicKey types.OnchainPublicKey, configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ocr3types.ReportWithInfo[RI], signature []byte) bool {
+	return k.signer.scheme.Verify(publicKey, k.signer.scheme.OCR3Digest(configDigest, seqNr, reportWithInfo.Report), signature)
+}
+
+func (k *OCR3Keyring[RI]) MaxSignatureLength() int {
+	return k.signer.scheme.MaxSignatureLength()
+}
@@ -0,0 +1,67 @@
+// Package pkcs11keyring implements onchain keyrings for OCR2
+// (types.OnchainKeyring) and OCR3 (ocr3types.OnchainKeyring) whose signing key
+// is held by a hardware security module (HSM) and accessed through PKCS#11,
+// so that it never leaves the HSM.
+//
+// A Signer owns the connection to the HSM. Since signing is on the hot path of
+// report attestation, it keeps a pool of logged-in sessions so that several
+// reports can be signed concurrently, retries operations that fail with
+// transient errors (e.g. because the HSM was briefly unreachable) on a fresh
+// session, and exports the latency of every signing operation as prometheus
+// metrics. How reports are hashed and how signatures are encoded for the
+// target chain is determined by a Scheme, e.g. EVMScheme.
+//
+// Loading PKCS#11 modules requires cgo. The package also builds with
+// CGO_ENABLED=0, but NewSigner then always returns an error.
+//
+// Example:
+//
+//	signer, err := pkcs11keyring.NewSigner(pkcs11keyring.Config{
+//		ModulePath: "/usr/lib/softhsm/libsofthsm2.so",
+//		TokenLabel: "ocr",
+//		PIN:        pin,
+//		KeyLabel:   "ocr-onchain-key",
+//		Logger:     logger,
+//	}, pkcs11keyring.EVMScheme{})
+//	...
+//	defer signer.Close()
+//	keyring := pkcs11keyring.NewOCR3Keyring[RI](signer)
+package pkcs11keyring
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartcontractkit/libocr/commontypes"
+)
+
+const (
+	DefaultSessionPoolSize = 4
+	DefaultMaxAttempts     = 3
+	DefaultRetryBackoff    = 50 * time.Millisecond
+)
+
+type Config struct {
+	// Path of the PKCS#11 module (shared library) provided by the HSM vendor
+	ModulePath string
+	// Label of the token that holds the key
+	TokenLabel string
+	// PIN of the token's user
+	PIN string
+	// CKA_LABEL of the private key and of the corresponding public key
+	KeyLabel string
+
+	// Number of sessions kept open. This bounds the number of concurrent
+	// signing operations. Zero means DefaultSessionPoolSize.
+	SessionPoolSize int
+	// Number of attempts made per signing operation if the HSM returns
+	// transient errors. Zero means DefaultMaxAttempts.
+	MaxAttempts int
+	// Delay before the first retry, doubled for every further one. Zero means
+	// DefaultRetryBackoff.
+	RetryBackoff time.Duration
+
+	Logger commontypes.Logger
+	// May be nil, in which case no metrics are exported
+	MetricsRegisterer prometheus.Registerer
+}
@@ -0,0 +1,120 @@
+package pkcs11keyring
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/miekg/pkcs11"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/chains/evmutil"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// Scheme determines what is signed for a report and how signatures are
+// encoded for a particular chain. Implementations must be thread-safe.
+type Scheme interface {
+	// PKCS#11 mechanism used for signing digests, e.g. pkcs11.CKM_ECDSA
+	Mechanism() uint
+	// OnchainPublicKey derives the OnchainPublicKey that identifies the
+	// oracle in the contract config from the public key point stored in
+	// CKA_EC_POINT (without DER wrapping).
+	OnchainPublicKey(ecPoint []byte) (types.OnchainPublicKey, error)
+
+	// OCR2Digest returns the digest signed for an OCR2 report.
+	OCR2Digest(types.ReportContext, types.Report) []byte
+	// OCR3Digest returns the digest signed for an OCR3 report.
+	OCR3Digest(types.ConfigDigest, uint64, types.Report) []byte
+
+	// Signature converts hsmSignature, the signature over digest as returned
+	// by the HSM, into the format expected onchain.
+	Signature(digest []byte, hsmSignature []byte, ecPoint []byte) ([]byte, error)
+	// Verify verifies a signature produced by Signature. It must gracefully
+	// handle malformed or adversarially crafted inputs.
+	Verify(publicKey types.OnchainPublicKey, digest []byte, signature []byte) bool
+	// Maximum length of a signature produced by Signature
+	MaxSignatureLength() int
+}
+
+// EVMScheme signs reports for the OCR2 and OCR3 EVM contracts with a
+// secp256k1 key. The OnchainPublicKey is the key's 20-byte address, and
+// signatures are 65 bytes long: r || s || v, with v ∈ {0, 1} and s in the lower
+// half of the curve order, as produced by go-ethereum's crypto.Sign.
+type EVMScheme struct{}
+
+var _ Scheme = EVMScheme{}
+
+const evmSignatureLength = 65
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+func (EVMScheme) Mechanism() uint {
+	return pkcs11.CKM_ECDSA
+}
+
+func (EVMScheme) OnchainPublicKey(ecPoint []byte) (types.OnchainPublicKey, error) {
+	publicKey, err := crypto.UnmarshalPubkey(ecPoint)
+	if err != nil {
+		return nil, fmt.Errorf("not an uncompressed secp256k1 public key: %w", err)
+	}
+	address := crypto.PubkeyToAddress(*publicKey)
+	return types.OnchainPublicKey(address.Bytes()), nil
+}
+
+func (EVMScheme) OCR2Digest(repctx types.ReportContext, report types.Report) []byte {
+	rawRepctx := evmutil.RawReportContext(repctx)
+	sigData := crypto.Keccak256(report)
+	sigData = append(sigData, rawRepctx[0][:]...)
+	sigData = append(sigData, rawRepctx[1][:]...)
+	sigData = append(sigData, rawRepctx[2][:]...)
+	return crypto.Keccak256(sigData)
+}
+
+func (EVMScheme) OCR3Digest(configDigest types.ConfigDigest, seqNr uint64, report types.Report) []byte {
+	return evmutil.OCR3ReportDigest(configDigest, seqNr, report)
+}
+
+func (EVMScheme) Signature(digest []byte, hsmSignature []byte, ecPoint []byte) ([]byte, error) {
+	// CKM_ECDSA returns r || s, each as long as the curve order
+	if len(hsmSignature) != 64 {
+		return nil, fmt.Errorf("HSM returned signature of length %v, expected 64", len(hsmSignature))
+	}
+	s := new(big.Int).SetBytes(hsmSignature[32:])
+	if s.Cmp(secp256k1HalfN) > 0 {
+		// (r, -s) is an equally valid signature, usually mandated onchain to
+		// prevent malleability
+		s.Sub(secp256k1N, s)
+	}
+
+	signature := make([]byte, evmSignatureLength)
+	copy(signature[:32], hsmSignature[:32])
+	s.FillBytes(signature[32:64])
+	// The HSM doesn't tell us the recovery id, so we find it by trial.
+	for v := byte(0); v <= 1; v++ {
+		signature[64] = v
+		recovered, err := crypto.Ecrecover(digest, signature)
+		if err == nil && bytes.Equal(recovered, ecPoint) {
+			return signature, nil
+		}
+	}
+	return nil, fmt.Errorf("HSM returned signature that doesn't verify under its public key")
+}
+
+func (EVMScheme) Verify(publicKey types.OnchainPublicKey, digest []byte, signature []byte) bool {
+	if len(signature) != evmSignatureLength || len(digest) != 32 {
+		return false
+	}
+	recovered, err := crypto.SigToPub(digest, signature)
+	if err != nil {
+		return false
+	}
+	address := crypto.PubkeyToAddress(*recovered)
+	return bytes.Equal(address.Bytes(), publicKey)
+}
+
+func (EVMScheme) MaxSignatureLength() int {
+	return evmSignatureLength
+}
@@ -0,0 +1,436 @@
+//go:build cgo
+
+package pkcs11keyring
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/miekg/pkcs11"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/internal/metricshelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// Signer signs digests with a key held by an HSM. All its functions are
+// thread-safe.
+type Signer struct {
+	config Config
+	scheme Scheme
+	logger loghelper.LoggerWithContext
+
+	module     *pkcs11.Ctx
+	slot       uint
+	privateKey pkcs11.ObjectHandle
+
+	// public key as stored in CKA_EC_POINT, without the DER wrapping
+	ecPoint          []byte
+	onchainPublicKey types.OnchainPublicKey
+
+	// holds SessionPoolSize sessions while no signing operation is ongoing
+	sessions chan *session
+	chClose  chan struct{}
+	closeErr error
+	close    sync.Once
+
+	metrics *metrics
+}
+
+type session struct {
+	handle pkcs11.SessionHandle
+	open   bool
+}
+
+// NewSigner loads the PKCS#11 module, logs into the token and looks up the
+// key. Call Close to release the module once the Signer is no longer used.
+func NewSigner(config Config, scheme Scheme) (*Signer, error) {
+	if config.ModulePath == "" || config.TokenLabel == "" || config.KeyLabel == "" {
+		return nil, fmt.Errorf("ModulePath, TokenLabel and KeyLabel must be set")
+	}
+	if config.Logger == nil {
+		return nil, fmt.Errorf("Logger must be set")
+	}
+	if config.SessionPoolSize < 0 || config.MaxAttempts < 0 || config.RetryBackoff < 0 {
+		return nil, fmt.Errorf("SessionPoolSize, MaxAttempts and RetryBackoff must not be negative")
+	}
+	if config.SessionPoolSize == 0 {
+		config.SessionPoolSize = DefaultSessionPoolSize
+	}
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.RetryBackoff == 0 {
+		config.RetryBackoff = DefaultRetryBackoff
+	}
+
+	module := pkcs11.New(config.ModulePath)
+	if module == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %q", config.ModulePath)
+	}
+	if err := module.Initialize(); err != nil {
+		module.Destroy()
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module: %w", err)
+	}
+
+	s := &Signer{
+		config,
+		scheme,
+		loghelper.MakeRootLoggerWithContext(config.Logger).MakeChild(commontypes.LogFields{
+			"proto":    "pkcs11keyring",
+			"keyLabel": config.KeyLabel,
+		}),
+
+		module,
+		0,
+		0,
+
+		nil,
+		nil,
+
+		make(chan *session, config.SessionPoolSize),
+		make(chan struct{}),
+		nil,
+		sync.Once{},
+
+		newMetrics(config.MetricsRegisterer, config.Logger),
+	}
+
+	if err := s.init(); err != nil {
+		s.metrics.close()
+		_ = module.Finalize()
+		module.Destroy()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Signer) init() error {
+	slot, err := s.findSlot()
+	if err != nil {
+		return err
+	}
+	s.slot = slot
+
+	first := &session{}
+	if err := s.openSession(first); err != nil {
+		return err
+	}
+	s.sessions <- first
+	for i := 1; i < s.config.SessionPoolSize; i++ {
+		// further sessions are opened lazily
+		s.sessions <- &session{}
+	}
+
+	privateKey, err := s.findObject(first.handle, pkcs11.CKO_PRIVATE_KEY)
+	if err != nil {
+		s.closeSessions()
+		return err
+	}
+	s.privateKey = privateKey
+
+	ecPoint, err := s.readECPoint(first.handle)
+	if err != nil {
+		s.closeSessions()
+		return err
+	}
+	onchainPublicKey, err := s.scheme.OnchainPublicKey(ecPoint)
+	if err != nil {
+		s.closeSessions()
+		return fmt.Errorf("public key of %q is unsuitable for the scheme: %w", s.config.KeyLabel, err)
+	}
+	s.ecPoint = ecPoint
+	s.onchainPublicKey = onchainPublicKey
+	return nil
+}
+
+func (s *Signer) findSlot() (uint, error) {
+	slots, err := s.module.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list PKCS#11 slots: %w", err)
+	}
+	for _, slot := range slots {
+		tokenInfo, err := s.module.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get info of token in slot %v: %w", slot, err)
+		}
+		if tokenInfo.Label == s.config.TokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("no token with label %q found", s.config.TokenLabel)
+}
+
+// openSession opens and logs into a session for sess, which must not be open.
+func (s *Signer) openSession(sess *session) error {
+	handle, err := s.module.OpenSession(s.slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("failed to open PKCS#11 session: %w", err)
+	}
+	// A login applies to all sessions of the application, so all but the
+	// first session are typically logged in already.
+	if err := s.module.Login(handle, pkcs11.CKU_USER, s.config.PIN); err != nil && !isError(err, pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		_ = s.module.CloseSession(handle)
+		return fmt.Errorf("failed to log into token %q: %w", s.config.TokenLabel, err)
+	}
+	sess.handle = handle
+	sess.open = true
+	return nil
+}
+
+func (s *Signer) closeSession(sess *session) {
+	if !sess.open {
+		return
+	}
+	if err := s.module.CloseSession(sess.handle); err != nil {
+		s.logger.Debug("pkcs11keyring: error while closing session", commontypes.LogFields{"error": err})
+	}
+	sess.open = false
+}
+
+func (s *Signer) findObject(handle pkcs11.SessionHandle, class uint) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, s.config.KeyLabel),
+	}
+	if err := s.module.FindObjectsInit(handle, template); err != nil {
+		return 0, fmt.Errorf("failed to search for key %q: %w", s.config.KeyLabel, err)
+	}
+	objects, _, err := s.module.FindObjects(handle, 2)
+	if finalErr := s.module.FindObjectsFinal(handle); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to search for key %q: %w", s.config.KeyLabel, err)
+	}
+	switch len(objects) {
+	case 0:
+		return 0, fmt.Errorf("no key with label %q and class %v found", s.config.KeyLabel, class)
+	case 1:
+		return objects[0], nil
+	}
+	return 0, fmt.Errorf("label %q is ambiguous, found several keys of class %v", s.config.KeyLabel, class)
+}
+
+func (s *Signer) readECPoint(handle pkcs11.SessionHandle) ([]byte, error) {
+	publicKey, err := s.findObject(handle, pkcs11.CKO_PUBLIC_KEY)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := s.module.GetAttributeValue(handle, publicKey, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key %q: %w", s.config.KeyLabel, err)
+	}
+	if len(attributes) != 1 {
+		return nil, fmt.Errorf("failed to read public key %q: got %v attributes", s.config.KeyLabel, len(attributes))
+	}
+	// CKA_EC_POINT is a DER-encoded OCTET STRING, though some modules omit
+	// the encoding.
+	var ecPoint []byte
+	if rest, err := asn1.Unmarshal(attributes[0].Value, &ecPoint); err == nil && len(rest) == 0 {
+		return ecPoint, nil
+	}
+	return attributes[0].Value, nil
+}
+
+// OnchainPublicKey returns the public key of the HSM key, as determined by
+// the Scheme.
+func (s *Signer) OnchainPublicKey() types.OnchainPublicKey {
+	return append(types.OnchainPublicKey{}, s.onchainPublicKey...)
+}
+
+// Sign signs digest with the HSM key and returns the signature in the format
+// produced by the HSM. Sign blocks until a session is available.
+func (s *Signer) Sign(digest []byte) ([]byte, error) {
+	start := time.Now()
+	signature, err := s.sign(digest)
+	s.metrics.observeSign(time.Since(start), err)
+	return signature, err
+}
+
+// signDigest signs digest and converts the result into the Scheme's onchain
+// signature format.
+func (s *Signer) signDigest(digest []byte) ([]byte, error) {
+	hsmSignature, err := s.Sign(digest)
+	if err != nil {
+		return nil, err
+	}
+	return s.scheme.Signature(digest, hsmSignature, s.ecPoint)
+}
+
+func (s *Signer) sign(digest []byte) ([]byte, error) {
+	waitStart := time.Now()
+	var sess *session
+	select {
+	case sess = <-s.sessions:
+	case <-s.chClose:
+		return nil, fmt.Errorf("pkcs11keyring: Signer is closed")
+	}
+	s.metrics.observeSessionWait(time.Since(waitStart))
+	defer func() { s.sessions <- sess }()
+
+	backoff := s.config.RetryBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var signature []byte
+		signature, err = s.trySign(sess, digest)
+		if err == nil {
+			return signature, nil
+		}
+		if !isTransient(err) {
+			break
+		}
+		// The session may be unusable, so we don't use it again.
+		s.closeSession(sess)
+		if attempt >= s.config.MaxAttempts {
+			break
+		}
+
+		s.logger.Warn("pkcs11keyring: signing failed with transient error, retrying on fresh session", commontypes.LogFields{
+			"attempt":     attempt,
+			"maxAttempts": s.config.MaxAttempts,
+			"backoff":     backoff.String(),
+			"error":       err,
+		})
+		s.metrics.incRetries()
+		select {
+		case <-time.After(backoff):
+		case <-s.chClose:
+			return nil, fmt.Errorf("pkcs11keyring: Signer was closed while retrying: %w", err)
+		}
+		backoff *= 2
+	}
+	return nil, fmt.Errorf("pkcs11keyring: signing failed: %w", err)
+}
+
+func (s *Signer) trySign(sess *session, digest []byte) ([]byte, error) {
+	if !sess.open {
+		if err := s.openSession(sess); err != nil {
+			return nil, err
+		}
+	}
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(s.scheme.Mechanism(), nil)}
+	if err := s.module.SignInit(sess.handle, mechanism, s.privateKey); err != nil {
+		return nil, fmt.Errorf("C_SignInit failed: %w", err)
+	}
+	signature, err := s.module.Sign(sess.handle, digest)
+	if err != nil {
+		return nil, fmt.Errorf("C_Sign failed: %w", err)
+	}
+	return signature, nil
+}
+
+// Close closes all sessions and releases the PKCS#11 module. It waits for
+// ongoing signing operations to finish. Signing fails after Close has been
+// called.
+func (s *Signer) Close() error {
+	s.close.Do(func() {
+		close(s.chClose)
+		s.closeSessions()
+		if err := s.module.Finalize(); err != nil {
+			s.closeErr = fmt.Errorf("failed to finalize PKCS#11 module: %w", err)
+		}
+		s.module.Destroy()
+		s.metrics.close()
+	})
+	return s.closeErr
+}
+
+// closeSessions waits until all sessions have been returned to the pool and
+// closes them.
+func (s *Signer) closeSessions() {
+	for i := 0; i < s.config.SessionPoolSize; i++ {
+		sess := <-s.sessions
+		s.closeSession(sess)
+	}
+}
+
+func isError(err error, code uint) bool {
+	var pkcs11Err pkcs11.Error
+	return errors.As(err, &pkcs11Err) && uint(pkcs11Err) == code
+}
+
+// isTransient returns true if err may go away when the operation is retried
+// on a fresh session.
+func isTransient(err error) bool {
+	var pkcs11Err pkcs11.Error
+	if !errors.As(err, &pkcs11Err) {
+		return false
+	}
+	switch uint(pkcs11Err) {
+	case pkcs11.CKR_SESSION_HANDLE_INVALID,
+		pkcs11.CKR_SESSION_CLOSED,
+		pkcs11.CKR_SESSION_COUNT,
+		pkcs11.CKR_USER_NOT_LOGGED_IN,
+		pkcs11.CKR_OPERATION_ACTIVE,
+		pkcs11.CKR_DEVICE_ERROR,
+		pkcs11.CKR_DEVICE_MEMORY,
+		pkcs11.CKR_DEVICE_REMOVED,
+		pkcs11.CKR_TOKEN_NOT_PRESENT,
+		pkcs11.CKR_FUNCTION_FAILED,
+		pkcs11.CKR_HOST_MEMORY:
+		return true
+	}
+	return false
+}
+
+type metrics struct {
+	registerer *metricshelper.Registerer
+
+	signDuration        *prometheus.HistogramVec
+	sessionWaitDuration prometheus.Histogram
+	retries             prometheus.Counter
+}
+
+func newMetrics(registerer prometheus.Registerer, logger commontypes.Logger) *metrics {
+	m := &metrics{
+		metricshelper.NewRegisterer(registerer, logger),
+
+		prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pkcs11keyring_sign_duration_seconds",
+			Help:    "Duration of signing operations, including waiting for a session and retries, by result",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, []string{"result"}),
+		prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "pkcs11keyring_session_wait_duration_seconds",
+			Help:    "Time signing operations spent waiting for a free session. Consistently high values suggest raising SessionPoolSize.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}),
+		prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "pkcs11keyring_sign_retries_total",
+			Help: "Number of times a signing operation was retried after a transient error",
+		}),
+	}
+	m.registerer.Register(
+		m.signDuration,
+		m.sessionWaitDuration,
+		m.retries,
+	)
+	return m
+}
+
+func (m *metrics) close() {
+	m.registerer.Close()
+}
+
+func (m *metrics) observeSign(duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.signDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+func (m *metrics) observeSessionWait(duration time.Duration) {
+	m.sessionWaitDuration.Observe(duration.Seconds())
+}
+
+func (m *metrics) incRetries() {
+	m.retries.Inc()
+}
@@ -0,0 +1,38 @@
+//go:build !cgo
+
+package pkcs11keyring
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// Signer signs digests with a key held by an HSM. Accessing PKCS#11 modules
+// requires cgo, so without cgo NewSigner always fails.
+type Signer struct {
+	scheme Scheme
+}
+
+var errCgoRequired = fmt.Errorf("pkcs11keyring requires cgo, but was built with CGO_ENABLED=0")
+
+// NewSigner fails, since PKCS#11 modules can't be loaded without cgo.
+func NewSigner(config Config, scheme Scheme) (*Signer, error) {
+	return nil, errCgoRequired
+}
+
+func (s *Signer) OnchainPublicKey() types.OnchainPublicKey {
+	return nil
+}
+
+func (s *Signer) Sign(digest []byte) ([]byte, error) {
+	return nil, errCgoRequired
+}
+
+func (s *Signer) signDigest(digest []byte) ([]byte, error) {
+	return nil, errCgoRequired
+}
+
+func (s *Signer) Close() error {
+	return nil
+}
@@ -0,0 +1,311 @@
+// Package pluginscheduler lets hosts share the CPU available for reporting
+// plugin callbacks fairly among the protocol instances running on a node.
+//
+// Without a Scheduler, every protocol instance calls its ReportingPlugin
+// whenever the protocol demands it. On a saturated node, a few heavyweight
+// plugins can then monopolize the CPU, and the callbacks of all other
+// instances miss their deadlines. A Scheduler instead bounds the number of
+// plugin callbacks running concurrently across all oracles it is passed to
+// (see OCR3OracleArgs.PluginScheduler). Once all slots are in use, further
+// callbacks wait for a slot, and whenever a slot frees up, it goes to the
+// waiting callback of the instance with the lowest virtual runtime, i.e. the
+// time its callbacks have spent running divided by its weight. Oracles weigh
+// their instances by the limits declared by the reporting plugin, such that
+// each instance's share is proportional to the load it may generate (see
+// admission.Resources.MessagesPerSecond). When the node is saturated, all
+// instances thus slow down together instead of some of them starving.
+//
+// Since Go can't preempt a running callback, the time slices handed out by a
+// Scheduler are entire callbacks: an instance whose callbacks run long is
+// charged for the time they took and waits correspondingly long for its next
+// slot. Time spent waiting for a slot counts against the deadline of a
+// callback's context.
+//
+// An instance without running or waiting callbacks doesn't accumulate credit:
+// when it becomes active again, its virtual runtime is raised to the lowest
+// one among the active instances, so that it can't monopolize the Scheduler
+// to make up for the time it was idle.
+//
+// Typically, a single Scheduler is shared among all oracles of a node.
+package pluginscheduler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/metricshelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// DefaultStarvationThreshold is used if Config.StarvationThreshold is zero.
+const DefaultStarvationThreshold = 100 * time.Millisecond
+
+type Config struct {
+	// Maximum number of plugin callbacks running concurrently, across all
+	// protocol instances. Typically the number of CPU cores available to
+	// plugins. Must be positive.
+	MaxConcurrentCallbacks int
+
+	// A callback that waits for a slot for longer than this is counted as
+	// starved in the ocr3_plugin_scheduler_starved_callbacks_total metric.
+	// Must not be negative. If zero, DefaultStarvationThreshold is used.
+	StarvationThreshold time.Duration
+}
+
+func (c Config) validate() error {
+	if c.MaxConcurrentCallbacks <= 0 {
+		return fmt.Errorf("MaxConcurrentCallbacks (%v) must be positive", c.MaxConcurrentCallbacks)
+	}
+	if c.StarvationThreshold < 0 {
+		return fmt.Errorf("StarvationThreshold (%v) must not be negative", c.StarvationThreshold)
+	}
+	return nil
+}
+
+// Scheduler schedules the plugin callbacks of the protocol instances
+// registered with it. All its functions are thread-safe.
+type Scheduler struct {
+	config  Config
+	metrics *metrics
+
+	mu        sync.Mutex
+	running   int
+	nextSeqNr uint64
+	// callbacks waiting for a slot, ordered by arrival
+	waiting   []*waiter
+	instances map[*Instance]struct{}
+}
+
+type waiter struct {
+	instance *Instance
+	// closed once the waiter has been granted a slot
+	chGranted chan struct{}
+}
+
+// NewScheduler returns a Scheduler enforcing config. Its metrics are
+// registered with registerer, which may be nil. Call Close to unregister
+// them.
+func NewScheduler(config Config, registerer prometheus.Registerer, logger commontypes.Logger) (*Scheduler, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid Config: %w", err)
+	}
+	if config.StarvationThreshold == 0 {
+		config.StarvationThreshold = DefaultStarvationThreshold
+	}
+	return &Scheduler{
+		config,
+		newMetrics(registerer, logger),
+
+		sync.Mutex{},
+		0,
+		0,
+		nil,
+		map[*Instance]struct{}{},
+	}, nil
+}
+
+// Close unregisters the Scheduler's metrics. The Scheduler keeps working
+// after Close.
+func (s *Scheduler) Close() {
+	s.metrics.close()
+}
+
+// Instance is a protocol instance registered with a Scheduler. All its
+// functions are thread-safe.
+type Instance struct {
+	scheduler    *Scheduler
+	configDigest types.ConfigDigest
+	weight       float64
+
+	// guarded by scheduler.mu
+	virtualRuntime float64
+	// number of running and waiting callbacks
+	active int
+}
+
+// Register registers the protocol instance with configDigest, which gets a
+// share of the slots proportional to weight. weight must be positive and
+// finite. Call Instance.Close once the instance has stopped.
+func (s *Scheduler) Register(configDigest types.ConfigDigest, weight float64) (*Instance, error) {
+	if !(0 < weight && weight < math.Inf(1)) {
+		return nil, fmt.Errorf("weight (%v) of protocol instance %v must be positive and finite", weight, configDigest)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	in := &Instance{
+		s,
+		configDigest,
+		weight,
+
+		s.minActiveVirtualRuntime(),
+		0,
+	}
+	s.instances[in] = struct{}{}
+	return in, nil
+}
+
+// Close unregisters the instance. Callbacks of the instance that are still
+// running or waiting are unaffected. Close may be called multiple times.
+func (in *Instance) Close() {
+	s := in.scheduler
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.instances, in)
+	s.metrics.deleteInstance(in.configDigest)
+}
+
+// Acquire waits for a slot to run a plugin callback of the instance. It
+// returns ctx.Err() if ctx is done before a slot is available. Otherwise,
+// release must be called exactly once, as soon as the callback has returned.
+func (in *Instance) Acquire(ctx context.Context) (release func(), err error) {
+	s := in.scheduler
+
+	s.mu.Lock()
+	if in.active == 0 {
+		// don't let idle time accumulate as credit
+		in.virtualRuntime = math.Max(in.virtualRuntime, s.minActiveVirtualRuntime())
+	}
+	in.active++
+	if len(s.waiting) == 0 && s.running < s.config.MaxConcurrentCallbacks {
+		s.running++
+		s.mu.Unlock()
+		s.metrics.waitDuration.Observe(0)
+		return in.makeRelease(), nil
+	}
+	w := &waiter{in, make(chan struct{})}
+	s.waiting = append(s.waiting, w)
+	s.metrics.waitingCallbacks.Inc()
+	s.mu.Unlock()
+
+	waitStart := time.Now()
+	select {
+	case <-w.chGranted:
+		in.observeWait(time.Since(waitStart))
+		return in.makeRelease(), nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	for i, other := range s.waiting {
+		if other == w {
+			s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+			s.metrics.waitingCallbacks.Dec()
+			in.active--
+			s.mu.Unlock()
+			in.observeWait(time.Since(waitStart))
+			return nil, ctx.Err()
+		}
+	}
+	s.mu.Unlock()
+
+	// We were granted a slot concurrently with ctx being done. Hand the slot
+	// on.
+	in.observeWait(time.Since(waitStart))
+	in.makeRelease()()
+	return nil, ctx.Err()
+}
+
+func (in *Instance) makeRelease() func() {
+	s := in.scheduler
+	start := time.Now()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			runtime := time.Since(start)
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			in.virtualRuntime += runtime.Seconds() / in.weight
+			in.active--
+			s.running--
+			s.dispatch()
+		})
+	}
+}
+
+func (in *Instance) observeWait(wait time.Duration) {
+	s := in.scheduler
+	s.metrics.waitDuration.Observe(wait.Seconds())
+	if wait > s.config.StarvationThreshold {
+		s.metrics.starvedCallbacks.WithLabelValues(in.configDigest.String()).Inc()
+	}
+}
+
+// dispatch hands free slots to the waiting callbacks of the instances with the
+// lowest virtual runtime, breaking ties by arrival. Must be called with mu
+// held.
+func (s *Scheduler) dispatch() {
+	for s.running < s.config.MaxConcurrentCallbacks && len(s.waiting) != 0 {
+		next := 0
+		for i, w := range s.waiting {
+			if w.instance.virtualRuntime < s.waiting[next].instance.virtualRuntime {
+				next = i
+			}
+		}
+		w := s.waiting[next]
+		s.waiting = append(s.waiting[:next], s.waiting[next+1:]...)
+		s.metrics.waitingCallbacks.Dec()
+		s.running++
+		close(w.chGranted)
+	}
+}
+
+// minActiveVirtualRuntime returns the lowest virtual runtime among the
+// registered instances with running or waiting callbacks, or zero if there are
+// none. Must be called with mu held.
+func (s *Scheduler) minActiveVirtualRuntime() float64 {
+	result := math.Inf(1)
+	for in := range s.instances {
+		if in.active != 0 {
+			result = math.Min(result, in.virtualRuntime)
+		}
+	}
+	if math.IsInf(result, 1) {
+		return 0
+	}
+	return result
+}
+
+type metrics struct {
+	registerer *metricshelper.Registerer
+
+	waitDuration     prometheus.Histogram
+	waitingCallbacks prometheus.Gauge
+	starvedCallbacks *prometheus.CounterVec
+}
+
+func newMetrics(registerer prometheus.Registerer, logger commontypes.Logger) *metrics {
+	m := &metrics{
+		metricshelper.NewRegisterer(registerer, logger),
+
+		prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "ocr3_plugin_scheduler_wait_duration_seconds",
+			Help:    "Time plugin callbacks waited for a slot",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15),
+		}),
+		prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ocr3_plugin_scheduler_waiting_callbacks",
+			Help: "Number of plugin callbacks currently waiting for a slot",
+		}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ocr3_plugin_scheduler_starved_callbacks_total",
+			Help: "Number of plugin callbacks that waited for a slot for longer than the starvation threshold, by config digest",
+		}, []string{"config_digest"}),
+	}
+	m.registerer.Register(m.waitDuration, m.waitingCallbacks, m.starvedCallbacks)
+	return m
+}
+
+func (m *metrics) deleteInstance(configDigest types.ConfigDigest) {
+	m.starvedCallbacks.DeleteLabelValues(configDigest.String())
+}
+
+func (m *metrics) close() {
+	m.registerer.Close()
+}
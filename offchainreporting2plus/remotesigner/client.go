@@ -0,0 +1,338 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/remotesigner/remotesignerpb"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"golang.org/x/crypto/curve25519"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type ClientConfig struct {
+	// Address of the RemoteSigner server, in the format accepted by
+	// grpc.Dial, e.g. "signer.internal:7000"
+	Address string
+	// Must contain a client certificate (for mutual authentication) and
+	// verify the server's certificate, e.g. against a private CA in RootCAs.
+	TLSConfig *tls.Config
+	// Upper bound on the duration of every remote call. Calls made while the
+	// connection is down wait for it to come back until the timeout expires.
+	// Zero means DefaultTimeout. See also TimeoutForDeltaGrace.
+	Timeout time.Duration
+
+	Logger commontypes.Logger
+}
+
+// Client is a connection to a RemoteSigner server from which keyrings can be
+// obtained. All its functions are thread-safe.
+type Client struct {
+	config ClientConfig
+	logger loghelper.LoggerWithContext
+	conn   *grpc.ClientConn
+	rpc    remotesignerpb.RemoteSignerClient
+	keys   *remotesignerpb.PublicKeysResponse
+}
+
+// Dial connects to the server and fetches its public keys. ctx bounds
+// establishing the connection and fetching the keys. Call Close once the
+// Client and the keyrings obtained from it are no longer used.
+func Dial(ctx context.Context, config ClientConfig) (*Client, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("Address must be set")
+	}
+	if err := validateClientTLSConfig(config.TLSConfig); err != nil {
+		return nil, err
+	}
+	if config.Logger == nil {
+		return nil, fmt.Errorf("Logger must be set")
+	}
+	if config.Timeout < 0 {
+		return nil, fmt.Errorf("Timeout must not be negative")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	conn, err := grpc.DialContext(ctx, config.Address, grpc.WithTransportCredentials(credentials.NewTLS(config.TLSConfig)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial remote signer: %w", err)
+	}
+	rpc := remotesignerpb.NewRemoteSignerClient(conn)
+
+	keys, err := rpc.PublicKeys(ctx, &remotesignerpb.PublicKeysRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to fetch public keys from remote signer: %w", err)
+	}
+	if err := validatePublicKeys(keys); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("remote signer returned invalid public keys: %w", err)
+	}
+
+	return &Client{
+		config,
+		loghelper.MakeRootLoggerWithContext(config.Logger).MakeChild(commontypes.LogFields{
+			"proto":   "remotesigner",
+			"address": config.Address,
+		}),
+		conn,
+		rpc,
+		keys,
+	}, nil
+}
+
+func validatePublicKeys(keys *remotesignerpb.PublicKeysResponse) error {
+	for name, key := range map[string]*remotesignerpb.OnchainKey{
+		"OCR2 onchain key": keys.Ocr2OnchainKey,
+		"OCR3 onchain key": keys.Ocr3OnchainKey,
+	} {
+		if key == nil {
+			continue
+		}
+		if len(key.PublicKey) == 0 {
+			return fmt.Errorf("%v is empty", name)
+		}
+		if key.MaxSignatureLength == 0 {
+			return fmt.Errorf("%v has zero MaxSignatureLength", name)
+		}
+	}
+	if keys.OffchainKeys != nil {
+		if len(keys.OffchainKeys.OffchainPublicKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("offchain public key has length %v, expected %v", len(keys.OffchainKeys.OffchainPublicKey), ed25519.PublicKeySize)
+		}
+		if len(keys.OffchainKeys.ConfigEncryptionPublicKey) != curve25519.PointSize {
+			return fmt.Errorf("config encryption public key has length %v, expected %v", len(keys.OffchainKeys.ConfigEncryptionPublicKey), curve25519.PointSize)
+		}
+	}
+	return nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) callContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.config.Timeout)
+}
+
+// checkSignature makes sure that we never hand a signature to the protocol
+// that our peers would reject.
+func (c *Client) checkSignature(rpcName string, signature []byte, maxSignatureLength int, valid func() bool) error {
+	if len(signature) > maxSignatureLength {
+		return fmt.Errorf("remote signer returned %v signature of length %v, exceeding MaxSignatureLength %v", rpcName, len(signature), maxSignatureLength)
+	}
+	if !valid() {
+		c.logger.Error("remote signer returned invalid signature, is it misconfigured or compromised?", commontypes.LogFields{
+			"rpc": rpcName,
+		})
+		return fmt.Errorf("remote signer returned invalid %v signature", rpcName)
+	}
+	return nil
+}
+
+// OCR2OnchainVerifier verifies OCR2 onchain signatures locally. Any
+// types.OnchainKeyring for the same chain is an OCR2OnchainVerifier, regardless
+// of its key.
+type OCR2OnchainVerifier interface {
+	Verify(_ types.OnchainPublicKey, _ types.ReportContext, _ types.Report, signature []byte) bool
+}
+
+// OCR3OnchainVerifier verifies OCR3 onchain signatures locally. Any
+// ocr3types.OnchainKeyring for the same chain is an OCR3OnchainVerifier,
+// regardless of its key.
+type OCR3OnchainVerifier[RI any] interface {
+	Verify(_ types.OnchainPublicKey, _ types.ConfigDigest, seqNr uint64, _ ocr3types.ReportWithInfo[RI], signature []byte) bool
+}
+
+type ocr2OnchainKeyring struct {
+	client   *Client
+	key      *remotesignerpb.OnchainKey
+	verifier OCR2OnchainVerifier
+}
+
+var _ types.OnchainKeyring = &ocr2OnchainKeyring{}
+
+// OCR2OnchainKeyring returns a keyring that signs with the server's OCR2
+// onchain key. verifier is used for Verify and for checking signatures
+// returned by the server.
+func (c *Client) OCR2OnchainKeyring(verifier OCR2OnchainVerifier) (types.OnchainKeyring, error) {
+	if c.keys.Ocr2OnchainKey == nil {
+		return nil, fmt.Errorf("remote signer doesn't hold an OCR2 onchain key")
+	}
+	if verifier == nil {
+		return nil, fmt.Errorf("verifier must not be nil")
+	}
+	return &ocr2OnchainKeyring{c, c.keys.Ocr2OnchainKey, verifier}, nil
+}
+
+func (k *ocr2OnchainKeyring) PublicKey() types.OnchainPublicKey {
+	return append(types.OnchainPublicKey{}, k.key.PublicKey...)
+}
+
+func (k *ocr2OnchainKeyring) Sign(repctx types.ReportContext, report types.Report) ([]byte, error) {
+	ctx, cancel := k.client.callContext()
+	defer cancel()
+	resp, err := k.client.rpc.OCR2Sign(ctx, &remotesignerpb.OCR2SignRequest{
+		ConfigDigest: repctx.ConfigDigest[:],
+		Epoch:        repctx.Epoch,
+		Round:        uint32(repctx.Round),
+		ExtraHash:    repctx.ExtraHash[:],
+		Report:       report,
+	}, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, fmt.Errorf("remote OCR2Sign failed: %w", err)
+	}
+	if err := k.client.checkSignature("OCR2Sign", resp.Signature, k.MaxSignatureLength(), func() bool {
+		return k.verifier.Verify(k.key.PublicKey, repctx, report, resp.Signature)
+	}); err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+func (k *ocr2OnchainKeyring) Verify(publicKey types.OnchainPublicKey, repctx types.ReportContext, report types.Report, signature []byte) bool {
+	return k.verifier.Verify(publicKey, repctx, report, signature)
+}
+
+func (k *ocr2OnchainKeyring) MaxSignatureLength() int {
+	return int(k.key.MaxSignatureLength)
+}
+
+type ocr3OnchainKeyring[RI any] struct {
+	client           *Client
+	key              *remotesignerpb.OnchainKey
+	verifier         OCR3OnchainVerifier[RI]
+	encodeReportInfo func(RI) ([]byte, error)
+}
+
+var _ ocr3types.OnchainKeyring[struct{}] = &ocr3OnchainKeyring[struct{}]{}
+
+// NewOCR3OnchainKeyring returns a keyring that signs with the server's OCR3
+// onchain key. verifier is used for Verify and for checking signatures
+// returned by the server. If encodeReportInfo is not nil, it is used to pass
+// the report info to the server, which receives it as
+// ReportWithInfo[[]byte].Info. Otherwise, the server receives an empty info.
+func NewOCR3OnchainKeyring[RI any](client *Client, verifier OCR3OnchainVerifier[RI], encodeReportInfo func(RI) ([]byte, error)) (ocr3types.OnchainKeyring[RI], error) {
+	if client.keys.Ocr3OnchainKey == nil {
+		return nil, fmt.Errorf("remote signer doesn't hold an OCR3 onchain key")
+	}
+	if verifier == nil {
+		return nil, fmt.Errorf("verifier must not be nil")
+	}
+	return &ocr3OnchainKeyring[RI]{client, client.keys.Ocr3OnchainKey, verifier, encodeReportInfo}, nil
+}
+
+func (k *ocr3OnchainKeyring[RI]) PublicKey() types.OnchainPublicKey {
+	return append(types.OnchainPublicKey{}, k.key.PublicKey...)
+}
+
+func (k *ocr3OnchainKeyring[RI]) Sign(configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ocr3types.ReportWithInfo[RI]) ([]byte, error) {
+	var reportInfo []byte
+	if k.encodeReportInfo != nil {
+		var err error
+		reportInfo, err = k.encodeReportInfo(reportWithInfo.Info)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode report info: %w", err)
+		}
+	}
+
+	ctx, cancel := k.client.callContext()
+	defer cancel()
+	resp, err := k.client.rpc.OCR3Sign(ctx, &remotesignerpb.OCR3SignRequest{
+		ConfigDigest: configDigest[:],
+		SeqNr:        seqNr,
+		Report:       reportWithInfo.Report,
+		ReportInfo:   reportInfo,
+	}, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, fmt.Errorf("remote OCR3Sign failed: %w", err)
+	}
+	if err := k.client.checkSignature("OCR3Sign", resp.Signature, k.MaxSignatureLength(), func() bool {
+		return k.verifier.Verify(k.key.PublicKey, configDigest, seqNr, reportWithInfo, resp.Signature)
+	}); err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+func (k *ocr3OnchainKeyring[RI]) Verify(publicKey types.OnchainPublicKey, configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ocr3types.ReportWithInfo[RI], signature []byte) bool {
+	return k.verifier.Verify(publicKey, configDigest, seqNr, reportWithInfo, signature)
+}
+
+func (k *ocr3OnchainKeyring[RI]) MaxSignatureLength() int {
+	return int(k.key.MaxSignatureLength)
+}
+
+type offchainKeyring struct {
+	client                    *Client
+	offchainPublicKey         types.OffchainPublicKey
+	configEncryptionPublicKey types.ConfigEncryptionPublicKey
+}
+
+var _ types.OffchainKeyring = &offchainKeyring{}
+
+// OffchainKeyring returns a keyring backed by the server's offchain keys.
+// Signatures returned by the server are checked with ed25519.Verify.
+func (c *Client) OffchainKeyring() (types.OffchainKeyring, error) {
+	if c.keys.OffchainKeys == nil {
+		return nil, fmt.Errorf("remote signer doesn't hold offchain keys")
+	}
+	k := &offchainKeyring{client: c}
+	copy(k.offchainPublicKey[:], c.keys.OffchainKeys.OffchainPublicKey)
+	copy(k.configEncryptionPublicKey[:], c.keys.OffchainKeys.ConfigEncryptionPublicKey)
+	return k, nil
+}
+
+func (k *offchainKeyring) OffchainSign(msg []byte) ([]byte, error) {
+	ctx, cancel := k.client.callContext()
+	defer cancel()
+	resp, err := k.client.rpc.OffchainSign(ctx, &remotesignerpb.OffchainSignRequest{
+		Msg: msg,
+	}, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, fmt.Errorf("remote OffchainSign failed: %w", err)
+	}
+	if err := k.client.checkSignature("OffchainSign", resp.Signature, ed25519.SignatureSize, func() bool {
+		return ed25519.Verify(ed25519.PublicKey(k.offchainPublicKey[:]), msg, resp.Signature)
+	}); err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+func (k *offchainKeyring) ConfigDiffieHellman(point [curve25519.PointSize]byte) ([curve25519.PointSize]byte, error) {
+	var sharedPoint [curve25519.PointSize]byte
+
+	ctx, cancel := k.client.callContext()
+	defer cancel()
+	resp, err := k.client.rpc.ConfigDiffieHellman(ctx, &remotesignerpb.ConfigDiffieHellmanRequest{
+		Point: point[:],
+	}, grpc.WaitForReady(true))
+	if err != nil {
+		return sharedPoint, fmt.Errorf("remote ConfigDiffieHellman failed: %w", err)
+	}
+	// Unlike signatures, the shared point can't be checked without the
+	// secret key.
+	if len(resp.SharedPoint) != curve25519.PointSize {
+		return sharedPoint, fmt.Errorf("remote signer returned shared point of length %v, expected %v", len(resp.SharedPoint), curve25519.PointSize)
+	}
+	copy(sharedPoint[:], resp.SharedPoint)
+	return sharedPoint, nil
+}
+
+func (k *offchainKeyring) OffchainPublicKey() types.OffchainPublicKey {
+	return k.offchainPublicKey
+}
+
+func (k *offchainKeyring) ConfigEncryptionPublicKey() types.ConfigEncryptionPublicKey {
+	return k.configEncryptionPublicKey
+}
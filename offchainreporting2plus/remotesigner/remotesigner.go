@@ -0,0 +1,77 @@
+// Package remotesigner lets an oracle use onchain and offchain keyrings whose
+// key material lives in a separate, hardened process or host.
+//
+// The process holding the keys serves the RemoteSigner gRPC service (see
+// remotesignerpb/remotesigner.proto), e.g. through NewGRPCServer. The oracle
+// connects to it with Dial and obtains keyrings from the resulting Client
+// that it then passes to the library like any local keyring.
+//
+// Both sides authenticate each other via mutual TLS. The client bounds every
+// remote call by ClientConfig.Timeout, because keyrings are called on the
+// critical path of the protocol and a signer that hangs must not stall the
+// oracle for longer than the protocol can tolerate (see TimeoutForDeltaGrace).
+// Since the signer might be compromised or misconfigured, the client also
+// checks each signature it receives with a local verifier before handing it
+// to the protocol, so that it never broadcasts an invalid signature.
+//
+// Example:
+//
+//	client, err := remotesigner.Dial(ctx, remotesigner.ClientConfig{
+//		Address:   "signer.internal:7000",
+//		TLSConfig: tlsConfig, // with client certificate and RootCAs
+//		Timeout:   remotesigner.TimeoutForDeltaGrace(deltaGrace),
+//		Logger:    logger,
+//	})
+//	...
+//	defer client.Close()
+//	offchainKeyring, err := client.OffchainKeyring()
+//	onchainKeyring, err := remotesigner.NewOCR3OnchainKeyring[RI](client, localVerifier, nil)
+package remotesigner
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultTimeout = time.Second
+	// Lower bound on the timeouts returned by TimeoutForDeltaGrace
+	MinTimeout = 50 * time.Millisecond
+)
+
+// TimeoutForDeltaGrace returns a call timeout suitable for a DON whose
+// offchain config specifies deltaGrace. Observations, prepares and commits are
+// signed on the critical path of every round, and a signature that takes
+// longer than DeltaGrace to arrive has usually missed its round anyway. We
+// therefore give up after half of DeltaGrace and leave the other half for
+// network latency between oracles.
+func TimeoutForDeltaGrace(deltaGrace time.Duration) time.Duration {
+	return max(deltaGrace/2, MinTimeout)
+}
+
+func validateClientTLSConfig(config *tls.Config) error {
+	if config == nil {
+		return fmt.Errorf("TLSConfig must be set")
+	}
+	if len(config.Certificates) == 0 && config.GetClientCertificate == nil {
+		return fmt.Errorf("TLSConfig must provide a client certificate for mutual authentication")
+	}
+	if config.InsecureSkipVerify && config.VerifyPeerCertificate == nil {
+		return fmt.Errorf("TLSConfig must verify the server certificate")
+	}
+	return nil
+}
+
+func validateServerTLSConfig(config *tls.Config) error {
+	if config == nil {
+		return fmt.Errorf("TLSConfig must be set")
+	}
+	if len(config.Certificates) == 0 && config.GetCertificate == nil && config.GetConfigForClient == nil {
+		return fmt.Errorf("TLSConfig must provide a server certificate")
+	}
+	if config.ClientAuth != tls.RequireAndVerifyClientCert {
+		return fmt.Errorf("TLSConfig.ClientAuth must be tls.RequireAndVerifyClientCert for mutual authentication")
+	}
+	return nil
+}
@@ -0,0 +1,866 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: remotesigner.proto
+
+package remotesignerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PublicKeysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PublicKeysRequest) Reset() {
+	*x = PublicKeysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remotesigner_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublicKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicKeysRequest) ProtoMessage() {}
+
+func (x *PublicKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remotesigner_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicKeysRequest.ProtoReflect.Descriptor instead.
+func (*PublicKeysRequest) Descriptor() ([]byte, []int) {
+	return file_remotesigner_proto_rawDescGZIP(), []int{0}
+}
+
+type PublicKeysResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Each field is unset iff the server doesn't hold the respective keyring.
+	Ocr2OnchainKey *OnchainKey   `protobuf:"bytes,1,opt,name=ocr2_onchain_key,json=ocr2OnchainKey,proto3" json:"ocr2_onchain_key,omitempty"`
+	Ocr3OnchainKey *OnchainKey   `protobuf:"bytes,2,opt,name=ocr3_onchain_key,json=ocr3OnchainKey,proto3" json:"ocr3_onchain_key,omitempty"`
+	OffchainKeys   *OffchainKeys `protobuf:"bytes,3,opt,name=offchain_keys,json=offchainKeys,proto3" json:"offchain_keys,omitempty"`
+}
+
+func (x *PublicKeysResponse) Reset() {
+	*x = PublicKeysResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remotesigner_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublicKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicKeysResponse) ProtoMessage() {}
+
+func (x *PublicKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remotesigner_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicKeysResponse.ProtoReflect.Descriptor instead.
+func (*PublicKeysResponse) Descriptor() ([]byte, []int) {
+	return file_remotesigner_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PublicKeysResponse) GetOcr2OnchainKey() *OnchainKey {
+	if x != nil {
+		return x.Ocr2OnchainKey
+	}
+	return nil
+}
+
+func (x *PublicKeysResponse) GetOcr3OnchainKey() *OnchainKey {
+	if x != nil {
+		return x.Ocr3OnchainKey
+	}
+	return nil
+}
+
+func (x *PublicKeysResponse) GetOffchainKeys() *OffchainKeys {
+	if x != nil {
+		return x.OffchainKeys
+	}
+	return nil
+}
+
+type OnchainKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey          []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	MaxSignatureLength uint32 `protobuf:"varint,2,opt,name=max_signature_length,json=maxSignatureLength,proto3" json:"max_signature_length,omitempty"`
+}
+
+func (x *OnchainKey) Reset() {
+	*x = OnchainKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remotesigner_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnchainKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnchainKey) ProtoMessage() {}
+
+func (x *OnchainKey) ProtoReflect() protoreflect.Message {
+	mi := &file_remotesigner_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnchainKey.ProtoReflect.Descriptor instead.
+func (*OnchainKey) Descriptor() ([]byte, []int) {
+	return file_remotesigner_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OnchainKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *OnchainKey) GetMaxSignatureLength() uint32 {
+	if x != nil {
+		return x.MaxSignatureLength
+	}
+	return 0
+}
+
+type OffchainKeys struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OffchainPublicKey         []byte `protobuf:"bytes,1,opt,name=offchain_public_key,json=offchainPublicKey,proto3" json:"offchain_public_key,omitempty"`
+	ConfigEncryptionPublicKey []byte `protobuf:"bytes,2,opt,name=config_encryption_public_key,json=configEncryptionPublicKey,proto3" json:"config_encryption_public_key,omitempty"`
+}
+
+func (x *OffchainKeys) Reset() {
+	*x = OffchainKeys{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remotesigner_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OffchainKeys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OffchainKeys) ProtoMessage() {}
+
+func (x *OffchainKeys) ProtoReflect() protoreflect.Message {
+	mi := &file_remotesigner_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OffchainKeys.ProtoReflect.Descriptor instead.
+func (*OffchainKeys) Descriptor() ([]byte, []int) {
+	return file_remotesigner_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OffchainKeys) GetOffchainPublicKey() []byte {
+	if x != nil {
+		return x.OffchainPublicKey
+	}
+	return nil
+}
+
+func (x *OffchainKeys) GetConfigEncryptionPublicKey() []byte {
+	if x != nil {
+		return x.ConfigEncryptionPublicKey
+	}
+	return nil
+}
+
+type OCR2SignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte `protobuf:"bytes,1,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+	Epoch        uint32 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round        uint32 `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	ExtraHash    []byte `protobuf:"bytes,4,opt,name=extra_hash,json=extraHash,proto3" json:"extra_hash,omitempty"`
+	Report       []byte `protobuf:"bytes,5,opt,name=report,proto3" json:"report,omitempty"`
+}
+
+func (x *OCR2SignRequest) Reset() {
+	*x = OCR2SignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remotesigner_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OCR2SignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCR2SignRequest) ProtoMessage() {}
+
+func (x *OCR2SignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remotesigner_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCR2SignRequest.ProtoReflect.Descriptor instead.
+func (*OCR2SignRequest) Descriptor() ([]byte, []int) {
+	return file_remotesigner_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OCR2SignRequest) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *OCR2SignRequest) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *OCR2SignRequest) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *OCR2SignRequest) GetExtraHash() []byte {
+	if x != nil {
+		return x.ExtraHash
+	}
+	return nil
+}
+
+func (x *OCR2SignRequest) GetReport() []byte {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type OCR3SignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfigDigest []byte `protobuf:"bytes,1,opt,name=config_digest,json=configDigest,proto3" json:"config_digest,omitempty"`
+	SeqNr        uint64 `protobuf:"varint,2,opt,name=seq_nr,json=seqNr,proto3" json:"seq_nr,omitempty"`
+	Report       []byte `protobuf:"bytes,3,opt,name=report,proto3" json:"report,omitempty"`
+	// Encoding of the report info, empty if the client doesn't encode it.
+	ReportInfo []byte `protobuf:"bytes,4,opt,name=report_info,json=reportInfo,proto3" json:"report_info,omitempty"`
+}
+
+func (x *OCR3SignRequest) Reset() {
+	*x = OCR3SignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remotesigner_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OCR3SignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCR3SignRequest) ProtoMessage() {}
+
+func (x *OCR3SignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remotesigner_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCR3SignRequest.ProtoReflect.Descriptor instead.
+func (*OCR3SignRequest) Descriptor() ([]byte, []int) {
+	return file_remotesigner_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OCR3SignRequest) GetConfigDigest() []byte {
+	if x != nil {
+		return x.ConfigDigest
+	}
+	return nil
+}
+
+func (x *OCR3SignRequest) GetSeqNr() uint64 {
+	if x != nil {
+		return x.SeqNr
+	}
+	return 0
+}
+
+func (x *OCR3SignRequest) GetReport() []byte {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+func (x *OCR3SignRequest) GetReportInfo() []byte {
+	if x != nil {
+		return x.ReportInfo
+	}
+	return nil
+}
+
+type OffchainSignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg []byte `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *OffchainSignRequest) Reset() {
+	*x = OffchainSignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remotesigner_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OffchainSignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OffchainSignRequest) ProtoMessage() {}
+
+func (x *OffchainSignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remotesigner_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OffchainSignRequest.ProtoReflect.Descriptor instead.
+func (*OffchainSignRequest) Descriptor() ([]byte, []int) {
+	return file_remotesigner_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OffchainSignRequest) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+type SignResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *SignResponse) Reset() {
+	*x = SignResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remotesigner_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignResponse) ProtoMessage() {}
+
+func (x *SignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remotesigner_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignResponse.ProtoReflect.Descriptor instead.
+func (*SignResponse) Descriptor() ([]byte, []int) {
+	return file_remotesigner_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SignResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type ConfigDiffieHellmanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Point []byte `protobuf:"bytes,1,opt,name=point,proto3" json:"point,omitempty"`
+}
+
+func (x *ConfigDiffieHellmanRequest) Reset() {
+	*x = ConfigDiffieHellmanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remotesigner_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigDiffieHellmanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigDiffieHellmanRequest) ProtoMessage() {}
+
+func (x *ConfigDiffieHellmanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remotesigner_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigDiffieHellmanRequest.ProtoReflect.Descriptor instead.
+func (*ConfigDiffieHellmanRequest) Descriptor() ([]byte, []int) {
+	return file_remotesigner_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ConfigDiffieHellmanRequest) GetPoint() []byte {
+	if x != nil {
+		return x.Point
+	}
+	return nil
+}
+
+type ConfigDiffieHellmanResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedPoint []byte `protobuf:"bytes,1,opt,name=shared_point,json=sharedPoint,proto3" json:"shared_point,omitempty"`
+}
+
+func (x *ConfigDiffieHellmanResponse) Reset() {
+	*x = ConfigDiffieHellmanResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remotesigner_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigDiffieHellmanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigDiffieHellmanResponse) ProtoMessage() {}
+
+func (x *ConfigDiffieHellmanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remotesigner_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigDiffieHellmanResponse.ProtoReflect.Descriptor instead.
+func (*ConfigDiffieHellmanResponse) Descriptor() ([]byte, []int) {
+	return file_remotesigner_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConfigDiffieHellmanResponse) GetSharedPoint() []byte {
+	if x != nil {
+		return x.SharedPoint
+	}
+	return nil
+}
+
+var File_remotesigner_proto protoreflect.FileDescriptor
+
+var file_remotesigner_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x22, 0x13, 0x0a, 0x11, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xdd, 0x01, 0x0a, 0x12, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42,
+	0x0a, 0x10, 0x6f, 0x63, 0x72, 0x32, 0x5f, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b,
+	0x65, 0x79, 0x52, 0x0e, 0x6f, 0x63, 0x72, 0x32, 0x4f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b,
+	0x65, 0x79, 0x12, 0x42, 0x0a, 0x10, 0x6f, 0x63, 0x72, 0x33, 0x5f, 0x6f, 0x6e, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x6e, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x4b, 0x65, 0x79, 0x52, 0x0e, 0x6f, 0x63, 0x72, 0x33, 0x4f, 0x6e, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x3f, 0x0a, 0x0d, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x66, 0x66,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x0c, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x5d, 0x0a, 0x0a, 0x4f, 0x6e, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x7f, 0x0a, 0x0c, 0x4f, 0x66, 0x66, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x11, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x3f, 0x0a, 0x1c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x19, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x22, 0x99, 0x01, 0x0a, 0x0f, 0x4f, 0x43, 0x52, 0x32,
+	0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x65, 0x78, 0x74, 0x72, 0x61, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x22, 0x86, 0x01, 0x0a, 0x0f, 0x4f, 0x43, 0x52, 0x33, 0x53, 0x69, 0x67, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06,
+	0x73, 0x65, 0x71, 0x5f, 0x6e, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65,
+	0x71, 0x4e, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x27, 0x0a, 0x13,
+	0x4f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x2c, 0x0a, 0x0c, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x22, 0x32, 0x0a, 0x1a, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x66,
+	0x66, 0x69, 0x65, 0x48, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x40, 0x0a, 0x1b, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x44, 0x69, 0x66, 0x66, 0x69, 0x65, 0x48, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x32, 0xa8, 0x03, 0x0a, 0x0c, 0x52, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x4f, 0x0a, 0x0a, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1f, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65,
+	0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x08, 0x4f,
+	0x43, 0x52, 0x32, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x43, 0x52, 0x32, 0x53, 0x69, 0x67, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x45, 0x0a, 0x08, 0x4f, 0x43, 0x52, 0x33, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x1d,
+	0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x43,
+	0x52, 0x33, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x4f, 0x66, 0x66,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x21, 0x2e, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x44, 0x69, 0x66, 0x66, 0x69, 0x65, 0x48, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x12,
+	0x28, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x66, 0x66, 0x69, 0x65, 0x48, 0x65, 0x6c, 0x6c, 0x6d,
+	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44,
+	0x69, 0x66, 0x66, 0x69, 0x65, 0x48, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x57, 0x5a, 0x55, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x6b, 0x69, 0x74, 0x2f, 0x6c, 0x69, 0x62, 0x6f, 0x63, 0x72, 0x2f, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x70, 0x6c, 0x75,
+	0x73, 0x2f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2f, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_remotesigner_proto_rawDescOnce sync.Once
+	file_remotesigner_proto_rawDescData = file_remotesigner_proto_rawDesc
+)
+
+func file_remotesigner_proto_rawDescGZIP() []byte {
+	file_remotesigner_proto_rawDescOnce.Do(func() {
+		file_remotesigner_proto_rawDescData = protoimpl.X.CompressGZIP(file_remotesigner_proto_rawDescData)
+	})
+	return file_remotesigner_proto_rawDescData
+}
+
+var file_remotesigner_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_remotesigner_proto_goTypes = []interface{}{
+	(*PublicKeysRequest)(nil),           // 0: remotesigner.PublicKeysRequest
+	(*PublicKeysResponse)(nil),          // 1: remotesigner.PublicKeysResponse
+	(*OnchainKey)(nil),                  // 2: remotesigner.OnchainKey
+	(*OffchainKeys)(nil),                // 3: remotesigner.OffchainKeys
+	(*OCR2SignRequest)(nil),             // 4: remotesigner.OCR2SignRequest
+	(*OCR3SignRequest)(nil),             // 5: remotesigner.OCR3SignRequest
+	(*OffchainSignRequest)(nil),         // 6: remotesigner.OffchainSignRequest
+	(*SignResponse)(nil),                // 7: remotesigner.SignResponse
+	(*ConfigDiffieHellmanRequest)(nil),  // 8: remotesigner.ConfigDiffieHellmanRequest
+	(*ConfigDiffieHellmanResponse)(nil), // 9: remotesigner.ConfigDiffieHellmanResponse
+}
+var file_remotesigner_proto_depIdxs = []int32{
+	2, // 0: remotesigner.PublicKeysResponse.ocr2_onchain_key:type_name -> remotesigner.OnchainKey
+	2, // 1: remotesigner.PublicKeysResponse.ocr3_onchain_key:type_name -> remotesigner.OnchainKey
+	3, // 2: remotesigner.PublicKeysResponse.offchain_keys:type_name -> remotesigner.OffchainKeys
+	0, // 3: remotesigner.RemoteSigner.PublicKeys:input_type -> remotesigner.PublicKeysRequest
+	4, // 4: remotesigner.RemoteSigner.OCR2Sign:input_type -> remotesigner.OCR2SignRequest
+	5, // 5: remotesigner.RemoteSigner.OCR3Sign:input_type -> remotesigner.OCR3SignRequest
+	6, // 6: remotesigner.RemoteSigner.OffchainSign:input_type -> remotesigner.OffchainSignRequest
+	8, // 7: remotesigner.RemoteSigner.ConfigDiffieHellman:input_type -> remotesigner.ConfigDiffieHellmanRequest
+	1, // 8: remotesigner.RemoteSigner.PublicKeys:output_type -> remotesigner.PublicKeysResponse
+	7, // 9: remotesigner.RemoteSigner.OCR2Sign:output_type -> remotesigner.SignResponse
+	7, // 10: remotesigner.RemoteSigner.OCR3Sign:output_type -> remotesigner.SignResponse
+	7, // 11: remotesigner.RemoteSigner.OffchainSign:output_type -> remotesigner.SignResponse
+	9, // 12: remotesigner.RemoteSigner.ConfigDiffieHellman:output_type -> remotesigner.ConfigDiffieHellmanResponse
+	8, // [8:13] is the sub-list for method output_type
+	3, // [3:8] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_remotesigner_proto_init() }
+func file_remotesigner_proto_init() {
+	if File_remotesigner_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_remotesigner_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicKeysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remotesigner_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicKeysResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remotesigner_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnchainKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remotesigner_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OffchainKeys); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remotesigner_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OCR2SignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remotesigner_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OCR3SignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remotesigner_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OffchainSignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remotesigner_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remotesigner_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigDiffieHellmanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remotesigner_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigDiffieHellmanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_remotesigner_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_remotesigner_proto_goTypes,
+		DependencyIndexes: file_remotesigner_proto_depIdxs,
+		MessageInfos:      file_remotesigner_proto_msgTypes,
+	}.Build()
+	File_remotesigner_proto = out.File
+	file_remotesigner_proto_rawDesc = nil
+	file_remotesigner_proto_goTypes = nil
+	file_remotesigner_proto_depIdxs = nil
+}
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: remotesigner.proto
+
+package remotesignerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RemoteSigner_PublicKeys_FullMethodName          = "/remotesigner.RemoteSigner/PublicKeys"
+	RemoteSigner_OCR2Sign_FullMethodName            = "/remotesigner.RemoteSigner/OCR2Sign"
+	RemoteSigner_OCR3Sign_FullMethodName            = "/remotesigner.RemoteSigner/OCR3Sign"
+	RemoteSigner_OffchainSign_FullMethodName        = "/remotesigner.RemoteSigner/OffchainSign"
+	RemoteSigner_ConfigDiffieHellman_FullMethodName = "/remotesigner.RemoteSigner/ConfigDiffieHellman"
+)
+
+// RemoteSignerClient is the client API for RemoteSigner service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RemoteSignerClient interface {
+	PublicKeys(ctx context.Context, in *PublicKeysRequest, opts ...grpc.CallOption) (*PublicKeysResponse, error)
+	OCR2Sign(ctx context.Context, in *OCR2SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	OCR3Sign(ctx context.Context, in *OCR3SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	OffchainSign(ctx context.Context, in *OffchainSignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	ConfigDiffieHellman(ctx context.Context, in *ConfigDiffieHellmanRequest, opts ...grpc.CallOption) (*ConfigDiffieHellmanResponse, error)
+}
+
+type remoteSignerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRemoteSignerClient(cc grpc.ClientConnInterface) RemoteSignerClient {
+	return &remoteSignerClient{cc}
+}
+
+func (c *remoteSignerClient) PublicKeys(ctx context.Context, in *PublicKeysRequest, opts ...grpc.CallOption) (*PublicKeysResponse, error) {
+	out := new(PublicKeysResponse)
+	err := c.cc.Invoke(ctx, RemoteSigner_PublicKeys_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) OCR2Sign(ctx context.Context, in *OCR2SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, RemoteSigner_OCR2Sign_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) OCR3Sign(ctx context.Context, in *OCR3SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, RemoteSigner_OCR3Sign_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) OffchainSign(ctx context.Context, in *OffchainSignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, RemoteSigner_OffchainSign_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) ConfigDiffieHellman(ctx context.Context, in *ConfigDiffieHellmanRequest, opts ...grpc.CallOption) (*ConfigDiffieHellmanResponse, error) {
+	out := new(ConfigDiffieHellmanResponse)
+	err := c.cc.Invoke(ctx, RemoteSigner_ConfigDiffieHellman_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RemoteSignerServer is the server API for RemoteSigner service.
+// All implementations must embed UnimplementedRemoteSignerServer
+// for forward compatibility
+type RemoteSignerServer interface {
+	PublicKeys(context.Context, *PublicKeysRequest) (*PublicKeysResponse, error)
+	OCR2Sign(context.Context, *OCR2SignRequest) (*SignResponse, error)
+	OCR3Sign(context.Context, *OCR3SignRequest) (*SignResponse, error)
+	OffchainSign(context.Context, *OffchainSignRequest) (*SignResponse, error)
+	ConfigDiffieHellman(context.Context, *ConfigDiffieHellmanRequest) (*ConfigDiffieHellmanResponse, error)
+	mustEmbedUnimplementedRemoteSignerServer()
+}
+
+// UnimplementedRemoteSignerServer must be embedded to have forward compatible implementations.
+type UnimplementedRemoteSignerServer struct {
+}
+
+func (UnimplementedRemoteSignerServer) PublicKeys(context.Context, *PublicKeysRequest) (*PublicKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublicKeys not implemented")
+}
+func (UnimplementedRemoteSignerServer) OCR2Sign(context.Context, *OCR2SignRequest) (*SignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OCR2Sign not implemented")
+}
+func (UnimplementedRemoteSignerServer) OCR3Sign(context.Context, *OCR3SignRequest) (*SignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OCR3Sign not implemented")
+}
+func (UnimplementedRemoteSignerServer) OffchainSign(context.Context, *OffchainSignRequest) (*SignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OffchainSign not implemented")
+}
+func (UnimplementedRemoteSignerServer) ConfigDiffieHellman(context.Context, *ConfigDiffieHellmanRequest) (*ConfigDiffieHellmanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigDiffieHellman not implemented")
+}
+func (UnimplementedRemoteSignerServer) mustEmbedUnimplementedRemoteSignerServer() {}
+
+// UnsafeRemoteSignerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RemoteSignerServer will
+// result in compilation errors.
+type UnsafeRemoteSignerServer interface {
+	mustEmbedUnimplementedRemoteSignerServer()
+}
+
+func RegisterRemoteSignerServer(s grpc.ServiceRegistrar, srv RemoteSignerServer) {
+	s.RegisterService(&RemoteSigner_ServiceDesc, srv)
+}
+
+func _RemoteSigner_PublicKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).PublicKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemoteSigner_PublicKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).PublicKeys(ctx, req.(*PublicKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_OCR2Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OCR2SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).OCR2Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemoteSigner_OCR2Sign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).OCR2Sign(ctx, req.(*OCR2SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_OCR3Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OCR3SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).OCR3Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemoteSigner_OCR3Sign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).OCR3Sign(ctx, req.(*OCR3SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_OffchainSign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OffchainSignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).OffchainSign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemoteSigner_OffchainSign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).OffchainSign(ctx, req.(*OffchainSignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_ConfigDiffieHellman_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigDiffieHellmanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).ConfigDiffieHellman(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemoteSigner_ConfigDiffieHellman_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).ConfigDiffieHellman(ctx, req.(*ConfigDiffieHellmanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RemoteSigner_ServiceDesc is the grpc.ServiceDesc for RemoteSigner service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RemoteSigner_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "remotesigner.RemoteSigner",
+	HandlerType: (*RemoteSignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PublicKeys",
+			Handler:    _RemoteSigner_PublicKeys_Handler,
+		},
+		{
+			MethodName: "OCR2Sign",
+			Handler:    _RemoteSigner_OCR2Sign_Handler,
+		},
+		{
+			MethodName: "OCR3Sign",
+			Handler:    _RemoteSigner_OCR3Sign_Handler,
+		},
+		{
+			MethodName: "OffchainSign",
+			Handler:    _RemoteSigner_OffchainSign_Handler,
+		},
+		{
+			MethodName: "ConfigDiffieHellman",
+			Handler:    _RemoteSigner_ConfigDiffieHellman_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "remotesigner.proto",
+}
@@ -0,0 +1,194 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/remotesigner/remotesignerpb"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"golang.org/x/crypto/curve25519"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// ServerKeyrings are the keyrings served by a RemoteSigner server. Any of them
+// may be nil, but not all.
+type ServerKeyrings struct {
+	OCR2OnchainKeyring types.OnchainKeyring
+	// Receives the report info as encoded by the client, see
+	// NewOCR3OnchainKeyring.
+	OCR3OnchainKeyring ocr3types.OnchainKeyring[[]byte]
+	OffchainKeyring    types.OffchainKeyring
+}
+
+// Server implements remotesignerpb.RemoteSignerServer on top of local
+// keyrings. Most users will want to use NewGRPCServer instead.
+type Server struct {
+	remotesignerpb.UnimplementedRemoteSignerServer
+
+	keyrings ServerKeyrings
+	logger   loghelper.LoggerWithContext
+}
+
+var _ remotesignerpb.RemoteSignerServer = &Server{}
+
+func NewServer(keyrings ServerKeyrings, logger commontypes.Logger) (*Server, error) {
+	if keyrings.OCR2OnchainKeyring == nil && keyrings.OCR3OnchainKeyring == nil && keyrings.OffchainKeyring == nil {
+		return nil, fmt.Errorf("at least one keyring must be set")
+	}
+	for name, keyring := range map[string]interface{ MaxSignatureLength() int }{
+		"OCR2OnchainKeyring": keyrings.OCR2OnchainKeyring,
+		"OCR3OnchainKeyring": keyrings.OCR3OnchainKeyring,
+	} {
+		if keyring == nil {
+			continue
+		}
+		if !(0 < keyring.MaxSignatureLength() && uint64(keyring.MaxSignatureLength()) <= math.MaxUint32) {
+			return nil, fmt.Errorf("%v has MaxSignatureLength %v out of range", name, keyring.MaxSignatureLength())
+		}
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger must be set")
+	}
+	return &Server{
+		remotesignerpb.UnimplementedRemoteSignerServer{},
+		keyrings,
+		loghelper.MakeRootLoggerWithContext(logger).MakeChild(commontypes.LogFields{
+			"proto": "remotesigner",
+		}),
+	}, nil
+}
+
+// NewGRPCServer returns a grpc.Server serving keyrings that only accepts
+// clients presenting a certificate verified by tlsConfig. Call Serve on it to
+// start serving.
+func NewGRPCServer(tlsConfig *tls.Config, keyrings ServerKeyrings, logger commontypes.Logger, opts ...grpc.ServerOption) (*grpc.Server, error) {
+	if err := validateServerTLSConfig(tlsConfig); err != nil {
+		return nil, err
+	}
+	server, err := NewServer(keyrings, logger)
+	if err != nil {
+		return nil, err
+	}
+	grpcServer := grpc.NewServer(append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))...)
+	remotesignerpb.RegisterRemoteSignerServer(grpcServer, server)
+	return grpcServer, nil
+}
+
+func (s *Server) PublicKeys(ctx context.Context, req *remotesignerpb.PublicKeysRequest) (*remotesignerpb.PublicKeysResponse, error) {
+	var resp remotesignerpb.PublicKeysResponse
+	if s.keyrings.OCR2OnchainKeyring != nil {
+		resp.Ocr2OnchainKey = &remotesignerpb.OnchainKey{
+			PublicKey:          s.keyrings.OCR2OnchainKeyring.PublicKey(),
+			MaxSignatureLength: uint32(s.keyrings.OCR2OnchainKeyring.MaxSignatureLength()),
+		}
+	}
+	if s.keyrings.OCR3OnchainKeyring != nil {
+		resp.Ocr3OnchainKey = &remotesignerpb.OnchainKey{
+			PublicKey:          s.keyrings.OCR3OnchainKeyring.PublicKey(),
+			MaxSignatureLength: uint32(s.keyrings.OCR3OnchainKeyring.MaxSignatureLength()),
+		}
+	}
+	if s.keyrings.OffchainKeyring != nil {
+		offchainPublicKey := s.keyrings.OffchainKeyring.OffchainPublicKey()
+		configEncryptionPublicKey := s.keyrings.OffchainKeyring.ConfigEncryptionPublicKey()
+		resp.OffchainKeys = &remotesignerpb.OffchainKeys{
+			OffchainPublicKey:         offchainPublicKey[:],
+			ConfigEncryptionPublicKey: configEncryptionPublicKey[:],
+		}
+	}
+	return &resp, nil
+}
+
+func (s *Server) OCR2Sign(ctx context.Context, req *remotesignerpb.OCR2SignRequest) (*remotesignerpb.SignResponse, error) {
+	if s.keyrings.OCR2OnchainKeyring == nil {
+		return nil, status.Error(codes.Unimplemented, "no OCR2 onchain keyring")
+	}
+	configDigest, err := types.BytesToConfigDigest(req.ConfigDigest)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid config digest: %v", err)
+	}
+	if req.Round > math.MaxUint8 {
+		return nil, status.Errorf(codes.InvalidArgument, "round %v out of range", req.Round)
+	}
+	if len(req.ExtraHash) != 32 {
+		return nil, status.Errorf(codes.InvalidArgument, "extra hash has length %v, expected 32", len(req.ExtraHash))
+	}
+	repctx := types.ReportContext{
+		types.ReportTimestamp{configDigest, req.Epoch, uint8(req.Round)},
+		[32]byte(req.ExtraHash),
+	}
+	return s.sign(ctx, "OCR2Sign", func() ([]byte, error) {
+		return s.keyrings.OCR2OnchainKeyring.Sign(repctx, req.Report)
+	})
+}
+
+func (s *Server) OCR3Sign(ctx context.Context, req *remotesignerpb.OCR3SignRequest) (*remotesignerpb.SignResponse, error) {
+	if s.keyrings.OCR3OnchainKeyring == nil {
+		return nil, status.Error(codes.Unimplemented, "no OCR3 onchain keyring")
+	}
+	configDigest, err := types.BytesToConfigDigest(req.ConfigDigest)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid config digest: %v", err)
+	}
+	return s.sign(ctx, "OCR3Sign", func() ([]byte, error) {
+		return s.keyrings.OCR3OnchainKeyring.Sign(configDigest, req.SeqNr, ocr3types.ReportWithInfo[[]byte]{
+			req.Report,
+			req.ReportInfo,
+		})
+	})
+}
+
+func (s *Server) OffchainSign(ctx context.Context, req *remotesignerpb.OffchainSignRequest) (*remotesignerpb.SignResponse, error) {
+	if s.keyrings.OffchainKeyring == nil {
+		return nil, status.Error(codes.Unimplemented, "no offchain keyring")
+	}
+	return s.sign(ctx, "OffchainSign", func() ([]byte, error) {
+		return s.keyrings.OffchainKeyring.OffchainSign(req.Msg)
+	})
+}
+
+func (s *Server) ConfigDiffieHellman(ctx context.Context, req *remotesignerpb.ConfigDiffieHellmanRequest) (*remotesignerpb.ConfigDiffieHellmanResponse, error) {
+	if s.keyrings.OffchainKeyring == nil {
+		return nil, status.Error(codes.Unimplemented, "no offchain keyring")
+	}
+	if len(req.Point) != curve25519.PointSize {
+		return nil, status.Errorf(codes.InvalidArgument, "point has length %v, expected %v", len(req.Point), curve25519.PointSize)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	sharedPoint, err := s.keyrings.OffchainKeyring.ConfigDiffieHellman([curve25519.PointSize]byte(req.Point))
+	if err != nil {
+		s.logger.Error("ConfigDiffieHellman failed", commontypes.LogFields{
+			"error": err,
+		})
+		return nil, status.Error(codes.Internal, "ConfigDiffieHellman failed")
+	}
+	return &remotesignerpb.ConfigDiffieHellmanResponse{SharedPoint: sharedPoint[:]}, nil
+}
+
+func (s *Server) sign(ctx context.Context, rpcName string, f func() ([]byte, error)) (*remotesignerpb.SignResponse, error) {
+	// Don't bother signing if the client has already given up on us.
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	signature, err := f()
+	if err != nil {
+		// The error might reveal secret report contents, so we don't return
+		// it to the client.
+		s.logger.Error("signing failed", commontypes.LogFields{
+			"rpc":   rpcName,
+			"error": err,
+		})
+		return nil, status.Errorf(codes.Internal, "%v failed", rpcName)
+	}
+	return &remotesignerpb.SignResponse{Signature: signature}, nil
+}
@@ -0,0 +1,114 @@
+// Package reportgate implements the common "report only on change" policy: a
+// new report is produced when the value deviates sufficiently from the last
+// reported value, or when the last report is older than a heartbeat interval.
+//
+// The gate is stateless, callers supply the last reported value and its
+// timestamp. This makes it usable both inside ReportingPlugin.Reports, which
+// must be a pure function of the outcome, and inside
+// ShouldAcceptAttestedReport/ShouldTransmitAcceptedReport, which would
+// typically compare against the latest value on the destination.
+//
+// When used inside Reports, now must be derived from the outcome (e.g. the
+// median of observed timestamps), never from the local clock, or oracles will
+// disagree about which reports exist.
+package reportgate
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+type Config struct {
+	// If DeviationDisabled is true, deviation never triggers a report.
+	DeviationDisabled bool
+	// Relative deviation between the last reported value and the new value,
+	// in parts per billion, that triggers a report.
+	DeviationThresholdPPB uint64
+	// Maximum age of the last report before a new one is triggered regardless
+	// of deviation. Zero disables heartbeats.
+	Heartbeat time.Duration
+}
+
+func (c Config) Validate() error {
+	if c.Heartbeat < 0 {
+		return fmt.Errorf("Heartbeat (%v) must be non-negative", c.Heartbeat)
+	}
+	if c.DeviationDisabled && c.Heartbeat == 0 {
+		return fmt.Errorf("at least one of deviation and heartbeat must be enabled")
+	}
+	return nil
+}
+
+// LastReport describes the most recently reported value.
+type LastReport struct {
+	Value     *big.Int
+	Timestamp time.Time
+}
+
+// Reason explains the decision of the gate.
+type Reason int
+
+const (
+	_ Reason = iota
+	// No report, since the value hasn't deviated and the heartbeat hasn't
+	// expired.
+	ReasonNone
+	// There is no previous report.
+	ReasonInitial
+	// The value deviates sufficiently from the last report.
+	ReasonDeviation
+	// The last report is older than the heartbeat interval.
+	ReasonHeartbeat
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonNone:
+		return "none"
+	case ReasonInitial:
+		return "initial"
+	case ReasonDeviation:
+		return "deviation"
+	case ReasonHeartbeat:
+		return "heartbeat"
+	}
+	return fmt.Sprintf("Reason(%d)", int(r))
+}
+
+// ShouldReport decides whether value should be reported at time now, given
+// the last report. Pass a nil last if nothing has been reported yet.
+func ShouldReport(config Config, last *LastReport, value *big.Int, now time.Time) (bool, Reason) {
+	if last == nil || last.Value == nil {
+		return true, ReasonInitial
+	}
+	if !config.DeviationDisabled && Deviates(config.DeviationThresholdPPB, last.Value, value) {
+		return true, ReasonDeviation
+	}
+	if config.Heartbeat != 0 && !now.Before(last.Timestamp.Add(config.Heartbeat)) {
+		return true, ReasonHeartbeat
+	}
+	return false, ReasonNone
+}
+
+// Deviates returns true iff new deviates from old by at least thresholdPPB
+// parts per billion, relative to old. Any change from zero is considered
+// a deviation.
+func Deviates(thresholdPPB uint64, old *big.Int, new *big.Int) bool {
+	if old.Sign() == 0 {
+		if new.Sign() == 0 {
+			return false // Both values are zero; no deviation
+		}
+		return true // Any deviation from 0 is significant
+	}
+	// ||new - old|| / ||old||, approximated by a float
+	change := &big.Rat{}
+	change.SetFrac((&big.Int{}).Sub(new, old), old)
+	change.Abs(change)
+	threshold := &big.Rat{}
+	threshold.SetFrac(
+		(&big.Int{}).SetUint64(thresholdPPB),
+		(&big.Int{}).SetUint64(1e9),
+	)
+	return change.Cmp(threshold) >= 0
+}
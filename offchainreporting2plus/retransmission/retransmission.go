@@ -0,0 +1,76 @@
+// Package retransmission lets a ReportingPlugin (or the host application)
+// request that an OCR3 oracle transmit its most recent attested reports
+// again, e.g. because the plugin learned out of band that the onchain state
+// is stale, perhaps because a transmission was dropped from the mempool.
+//
+// An oracle only serves requests if a Controller is passed in
+// OCR3OracleArgs.RetransmissionController. The host application typically
+// also hands the same Controller to its ReportingPluginFactory.
+//
+// Retransmitted reports go through the transmission pipeline just like fresh
+// ones: ShouldAcceptAttestedReport and ShouldTransmitAcceptedReport are
+// invoked again, and the oracle only transmits if it is part of the
+// transmission schedule for the report. Hence, retransmission should be
+// requested on (at least) as many oracles as are needed to reach a correct
+// transmitter, just like with fresh reports.
+package retransmission
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// Request is a request to retransmit the most recent attested reports of the
+// protocol instance with ConfigDigest. It is consumed by the oracle, which
+// must call Respond exactly once.
+type Request struct {
+	ConfigDigest types.ConfigDigest
+	chResult     chan<- error
+}
+
+// Respond reports the outcome of the request to the requester.
+func (r Request) Respond(err error) {
+	// chResult is buffered, so this never blocks
+	r.chResult <- err
+}
+
+// Controller passes retransmission requests to an oracle. All its functions
+// are thread-safe.
+type Controller struct {
+	chRequests chan Request
+}
+
+func NewController() *Controller {
+	return &Controller{make(chan Request)}
+}
+
+// RetransmitLatestReports re-enqueues the attested reports of the highest
+// sequence number that the oracle has attested for the protocol instance with
+// configDigest into the transmission pipeline. It returns once the reports
+// have been re-enqueued, not once they have been transmitted (which happens
+// asynchronously, subject to the transmission schedule). It returns an error
+// if the oracle isn't running the instance with configDigest, hasn't attested
+// any reports for it yet, or ctx expires before the oracle picks up the
+// request.
+func (c *Controller) RetransmitLatestReports(ctx context.Context, configDigest types.ConfigDigest) error {
+	chResult := make(chan error, 1)
+	select {
+	case c.chRequests <- Request{configDigest, chResult}:
+	case <-ctx.Done():
+		return fmt.Errorf("oracle didn't pick up retransmission request: %w", ctx.Err())
+	}
+	select {
+	case err := <-chResult:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Requests returns a channel that receives every request passed to
+// RetransmitLatestReports. It is consumed by the oracle.
+func (c *Controller) Requests() <-chan Request {
+	return c.chRequests
+}
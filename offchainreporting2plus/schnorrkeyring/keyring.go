@@ -0,0 +1,84 @@
+package schnorrkeyring
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+type signer struct {
+	privateKey *btcec.PrivateKey
+	publicKey  types.OnchainPublicKey
+}
+
+func newSigner(privateKey *btcec.PrivateKey) signer {
+	return signer{privateKey, schnorr.SerializePubKey(privateKey.PubKey())}
+}
+
+func (s signer) sign(digest []byte) ([]byte, error) {
+	signature, err := schnorr.Sign(s.privateKey, digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign report digest: %w", err)
+	}
+	return signature.Serialize(), nil
+}
+
+// OCR2Keyring is a types.OnchainKeyring that signs reports with BIP-340
+// Schnorr signatures. It is thread-safe.
+type OCR2Keyring struct {
+	signer signer
+}
+
+var _ types.OnchainKeyring = &OCR2Keyring{}
+
+func NewOCR2Keyring(privateKey *btcec.PrivateKey) *OCR2Keyring {
+	return &OCR2Keyring{newSigner(privateKey)}
+}
+
+func (k *OCR2Keyring) PublicKey() types.OnchainPublicKey {
+	return append(types.OnchainPublicKey{}, k.signer.publicKey...)
+}
+
+func (k *OCR2Keyring) Sign(repctx types.ReportContext, report types.Report) ([]byte, error) {
+	return k.signer.sign(OCR2ReportDigest(repctx, report))
+}
+
+func (k *OCR2Keyring) Verify(publicKey types.OnchainPublicKey, repctx types.ReportContext, report types.Report, signature []byte) bool {
+	return Verify(publicKey, OCR2ReportDigest(repctx, report), signature)
+}
+
+func (k *OCR2Keyring) MaxSignatureLength() int {
+	return SignatureLength
+}
+
+// OCR3Keyring is an ocr3types.OnchainKeyring that signs reports with BIP-340
+// Schnorr signatures. It signs the report bytes only, the report info isn't
+// signed. It is thread-safe.
+type OCR3Keyring[RI any] struct {
+	signer signer
+}
+
+var _ ocr3types.OnchainKeyring[struct{}] = &OCR3Keyring[struct{}]{}
+
+func NewOCR3Keyring[RI any](privateKey *btcec.PrivateKey) *OCR3Keyring[RI] {
+	return &OCR3Keyring[RI]{newSigner(privateKey)}
+}
+
+func (k *OCR3Keyring[RI]) PublicKey() types.OnchainPublicKey {
+	return append(types.OnchainPublicKey{}, k.signer.publicKey...)
+}
+
+func (k *OCR3Keyring[RI]) Sign(configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ocr3types.ReportWithInfo[RI]) ([]byte, error) {
+	return k.signer.sign(OCR3ReportDigest(configDigest, seqNr, reportWithInfo.Report))
+}
+
+func (k *OCR3Keyring[RI]) Verify(publicKey types.OnchainPublicKey, configDigest types.ConfigDigest, seqNr uint64, reportWithInfo ocr3types.ReportWithInfo[RI], signature []byte) bool {
+	return Verify(publicKey, OCR3ReportDigest(configDigest, seqNr, reportWithInfo.Report), signature)
+}
+
+func (k *OCR3Keyring[RI]) MaxSignatureLength() int {
+	return SignatureLength
+}
@@ -0,0 +1,126 @@
+// Package schnorrkeyring implements OCR2 and OCR3 OnchainKeyrings that sign
+// reports with BIP-340 Schnorr signatures over secp256k1, for chains that
+// verify such signatures natively, together with helpers for verifying
+// attested reports, e.g. in a ContractTransmitter before submitting them.
+//
+// The OnchainPublicKey of an oracle is its 32-byte x-only public key and
+// signatures are 64 bytes long, both as specified by BIP-340. Oracles sign the
+// 32-byte digest returned by OCR2ReportDigest or OCR3ReportDigest, which use
+// BIP-340 tagged hashes so that report signatures can't be confused with
+// signatures over other messages (e.g. transactions) made with the same key.
+package schnorrkeyring
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+const (
+	// Length of an OnchainPublicKey, an x-only public key
+	PublicKeyLength = schnorr.PubKeyBytesLen
+	// Length of a signature
+	SignatureLength = schnorr.SignatureSize
+)
+
+// Tags of the BIP-340 tagged hashes used for report digests
+var (
+	ocr2ReportTag = []byte("libocr/ocr2/report")
+	ocr3ReportTag = []byte("libocr/ocr3/report")
+)
+
+// OCR2ReportDigest returns the digest that oracles sign for an OCR2 report,
+// namely
+//
+//	taggedHash("libocr/ocr2/report", sha256(report) || configDigest ||
+//	           uint32(epoch) || uint8(round) || extraHash)
+//
+// with integers in big-endian encoding.
+func OCR2ReportDigest(repctx types.ReportContext, report types.Report) []byte {
+	reportHash := chainhash.HashB(report)
+	var rawEpochRound [5]byte
+	binary.BigEndian.PutUint32(rawEpochRound[:4], repctx.Epoch)
+	rawEpochRound[4] = repctx.Round
+	return chainhash.TaggedHash(
+		ocr2ReportTag,
+		reportHash,
+		repctx.ConfigDigest[:],
+		rawEpochRound[:],
+		repctx.ExtraHash[:],
+	)[:]
+}
+
+// OCR3ReportDigest returns the digest that oracles sign for an OCR3 report,
+// namely
+//
+//	taggedHash("libocr/ocr3/report", sha256(report) || configDigest ||
+//	           uint64(seqNr))
+//
+// with integers in big-endian encoding.
+func OCR3ReportDigest(configDigest types.ConfigDigest, seqNr uint64, report types.Report) []byte {
+	reportHash := chainhash.HashB(report)
+	var rawSeqNr [8]byte
+	binary.BigEndian.PutUint64(rawSeqNr[:], seqNr)
+	return chainhash.TaggedHash(
+		ocr3ReportTag,
+		reportHash,
+		configDigest[:],
+		rawSeqNr[:],
+	)[:]
+}
+
+// Verify verifies a BIP-340 signature over digest. It returns false for
+// malformed public keys and signatures.
+func Verify(publicKey types.OnchainPublicKey, digest []byte, signature []byte) bool {
+	if len(publicKey) != PublicKeyLength || len(signature) != SignatureLength {
+		return false
+	}
+	pubKey, err := schnorr.ParsePubKey(publicKey)
+	if err != nil {
+		return false
+	}
+	sig, err := schnorr.ParseSignature(signature)
+	if err != nil {
+		return false
+	}
+	return sig.Verify(digest, pubKey)
+}
+
+// VerifyOCR2AttestedReport checks that signatures attest report with repctx,
+// i.e. that at least f+1 distinct oracles of the config signed it. publicKeys
+// are the OnchainPublicKeys of all oracles in the config, indexed by OracleID.
+func VerifyOCR2AttestedReport(publicKeys []types.OnchainPublicKey, f int, repctx types.ReportContext, report types.Report, signatures []types.AttributedOnchainSignature) error {
+	return verifyAttestation(publicKeys, f, OCR2ReportDigest(repctx, report), signatures)
+}
+
+// VerifyOCR3AttestedReport is like VerifyOCR2AttestedReport, for OCR3
+// reports.
+func VerifyOCR3AttestedReport(publicKeys []types.OnchainPublicKey, f int, configDigest types.ConfigDigest, seqNr uint64, report types.Report, signatures []types.AttributedOnchainSignature) error {
+	return verifyAttestation(publicKeys, f, OCR3ReportDigest(configDigest, seqNr, report), signatures)
+}
+
+func verifyAttestation(publicKeys []types.OnchainPublicKey, f int, digest []byte, signatures []types.AttributedOnchainSignature) error {
+	if len(signatures) <= f {
+		return fmt.Errorf("got %v signatures, expected at least %v", len(signatures), f+1)
+	}
+	// keyed by public key rather than OracleID, in case a config lists the
+	// same key twice
+	seen := make(map[string]bool, len(signatures))
+	for i, signature := range signatures {
+		if int(signature.Signer) >= len(publicKeys) {
+			return fmt.Errorf("signature %v has signer %v out of range, there are only %v oracles", i, signature.Signer, len(publicKeys))
+		}
+		publicKey := publicKeys[signature.Signer]
+		if seen[string(publicKey)] {
+			return fmt.Errorf("signature %v has duplicate signer %v", i, signature.Signer)
+		}
+		seen[string(publicKey)] = true
+		if !Verify(publicKey, digest, signature.Signature) {
+			return fmt.Errorf("signature %v by signer %v is invalid", i, signature.Signer)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Package seqnrclock estimates the relation between OCR3 sequence numbers and
+// wall-clock time, e.g. to answer "when will seqNr X roughly happen" or "what
+// seqNr corresponds to this timestamp" in host tooling and dashboards.
+//
+// The estimate is based on the observed round cadence: callers feed the
+// Estimator (seqNr, time) samples, such as the seqNrs passed to
+// ContractTransmitter.Transmit together with the time of the call. Rounds
+// aren't spaced perfectly regularly (epoch changes in particular cause
+// gaps), so estimates far from observed samples are correspondingly rough.
+//
+// Note that there is no analogous estimate for epochs: epochs only change upon
+// failures, so their cadence carries no predictive information.
+package seqnrclock
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type sample struct {
+	seqNr uint64
+	time  time.Time
+}
+
+// Estimator is safe for concurrent use.
+type Estimator struct {
+	mutex      sync.Mutex
+	maxSamples int
+	// samples are strictly increasing in seqNr and non-decreasing in time
+	samples []sample
+}
+
+// NewEstimator returns an Estimator that bases its estimates on the most
+// recent maxSamples samples. maxSamples must be at least 2.
+func NewEstimator(maxSamples int) (*Estimator, error) {
+	if maxSamples < 2 {
+		return nil, fmt.Errorf("maxSamples (%v) must be at least 2", maxSamples)
+	}
+	return &Estimator{sync.Mutex{}, maxSamples, nil}, nil
+}
+
+// Observe records that seqNr happened at time t. Samples that don't advance
+// the seqNr (e.g. duplicates or stale reports) are ignored, as are samples
+// that would go back in time.
+func (e *Estimator) Observe(seqNr uint64, t time.Time) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if len(e.samples) != 0 {
+		last := e.samples[len(e.samples)-1]
+		if seqNr <= last.seqNr || t.Before(last.time) {
+			return
+		}
+	}
+	if len(e.samples) == e.maxSamples {
+		copy(e.samples, e.samples[1:])
+		e.samples = e.samples[:len(e.samples)-1]
+	}
+	e.samples = append(e.samples, sample{seqNr, t})
+}
+
+// Cadence returns the average duration per seqNr across the retained
+// samples. ok is false if fewer than two samples have been observed.
+func (e *Estimator) Cadence() (cadence time.Duration, ok bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.cadence()
+}
+
+func (e *Estimator) cadence() (time.Duration, bool) {
+	if len(e.samples) < 2 {
+		return 0, false
+	}
+	first, last := e.samples[0], e.samples[len(e.samples)-1]
+	return last.time.Sub(first.time) / time.Duration(last.seqNr-first.seqNr), true
+}
+
+// TimeOf estimates the time at which seqNr happened or will happen. Between
+// samples, it interpolates linearly; outside, it extrapolates from the
+// nearest sample at the average cadence. ok is false if fewer than two
+// samples have been observed.
+func (e *Estimator) TimeOf(seqNr uint64) (t time.Time, ok bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	cadence, ok := e.cadence()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	first, last := e.samples[0], e.samples[len(e.samples)-1]
+	switch {
+	case seqNr <= first.seqNr:
+		return first.time.Add(-time.Duration(first.seqNr-seqNr) * cadence), true
+	case last.seqNr <= seqNr:
+		return last.time.Add(time.Duration(seqNr-last.seqNr) * cadence), true
+	}
+
+	// first.seqNr < seqNr < last.seqNr, so 0 < i < len(e.samples)
+	i := sort.Search(len(e.samples), func(i int) bool { return seqNr <= e.samples[i].seqNr })
+	lo, hi := e.samples[i-1], e.samples[i]
+	fraction := float64(seqNr-lo.seqNr) / float64(hi.seqNr-lo.seqNr)
+	return lo.time.Add(time.Duration(fraction * float64(hi.time.Sub(lo.time)))), true
+}
+
+// SeqNrAt estimates the seqNr that happened or will happen at time t. It is
+// the inverse of TimeOf, rounded down. Estimates before seqNr 1 are clamped
+// to 1. ok is false if fewer than two samples have been observed, or if all
+// samples have the same time.
+func (e *Estimator) SeqNrAt(t time.Time) (seqNr uint64, ok bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	cadence, ok := e.cadence()
+	if !ok || cadence <= 0 {
+		return 0, false
+	}
+
+	first, last := e.samples[0], e.samples[len(e.samples)-1]
+	switch {
+	case !t.After(first.time):
+		back := uint64(first.time.Sub(t) / cadence)
+		if first.seqNr <= back {
+			return 1, true
+		}
+		return first.seqNr - back, true
+	case !t.Before(last.time):
+		return last.seqNr + uint64(t.Sub(last.time)/cadence), true
+	}
+
+	// first.time < t < last.time, so 0 < i < len(e.samples)
+	i := sort.Search(len(e.samples), func(i int) bool { return !e.samples[i].time.Before(t) })
+	lo, hi := e.samples[i-1], e.samples[i]
+	fraction := float64(t.Sub(lo.time)) / float64(hi.time.Sub(lo.time))
+	return lo.seqNr + uint64(fraction*float64(hi.seqNr-lo.seqNr)), true
+}
@@ -0,0 +1,220 @@
+// Package syntheticplugin contains an OCR3 ReportingPlugin that does no useful
+// work but produces outputs of configurable size and fails at configurable
+// rates. Use it for capacity planning, soak tests, and leak detection with the
+// real protocol stack: running it shows what the protocol itself costs for a
+// given load, independently of any application logic.
+//
+// Outputs are padded with zeros to the configured sizes. (Real plugins'
+// outputs are typically less compressible, but nothing in the protocol
+// compresses.)
+package syntheticplugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"go.uber.org/multierr"
+)
+
+const (
+	// observations start with the seqNr and the observer
+	MinObservationLength = 8 + 1
+	// outcomes start with the seqNr and a digest of the observations
+	MinOutcomeLength = 8 + sha256.Size
+	// reports start with the seqNr and the index of the report
+	MinReportLength = 8 + 4
+)
+
+type Config struct {
+	ObservationLength int
+	OutcomeLength     int
+	// Number of reports generated from each outcome, and length of each
+	ReportCount  int
+	ReportLength int
+
+	// Probabilities that a call to the respective function returns an error.
+	// Only functions that needn't be pure can fail: a pure function that
+	// failed at random would make oracles disagree.
+	QueryFailureProbability                        float64
+	ObservationFailureProbability                  float64
+	ShouldAcceptAttestedReportFailureProbability   float64
+	ShouldTransmitAcceptedReportFailureProbability float64
+}
+
+// DefaultConfig returns a Config with small outputs, a single report per
+// round, and no failures.
+func DefaultConfig() Config {
+	return Config{
+		64,
+		256,
+		1,
+		128,
+		0,
+		0,
+		0,
+		0,
+	}
+}
+
+func (c Config) Validate() error {
+	var err error
+	if !(MinObservationLength <= c.ObservationLength && c.ObservationLength <= ocr3types.MaxMaxObservationLength) {
+		err = multierr.Append(err, fmt.Errorf("ObservationLength (%v) out of range. Should be between %v and %v", c.ObservationLength, MinObservationLength, ocr3types.MaxMaxObservationLength))
+	}
+	if !(MinOutcomeLength <= c.OutcomeLength && c.OutcomeLength <= ocr3types.MaxMaxOutcomeLength) {
+		err = multierr.Append(err, fmt.Errorf("OutcomeLength (%v) out of range. Should be between %v and %v", c.OutcomeLength, MinOutcomeLength, ocr3types.MaxMaxOutcomeLength))
+	}
+	if !(0 <= c.ReportCount && c.ReportCount <= ocr3types.MaxMaxReportCount) {
+		err = multierr.Append(err, fmt.Errorf("ReportCount (%v) out of range. Should be between 0 and %v", c.ReportCount, ocr3types.MaxMaxReportCount))
+	}
+	if !(MinReportLength <= c.ReportLength && c.ReportLength <= ocr3types.MaxMaxReportLength) {
+		err = multierr.Append(err, fmt.Errorf("ReportLength (%v) out of range. Should be between %v and %v", c.ReportLength, MinReportLength, ocr3types.MaxMaxReportLength))
+	}
+	for _, p := range []struct {
+		name  string
+		value float64
+	}{
+		{"QueryFailureProbability", c.QueryFailureProbability},
+		{"ObservationFailureProbability", c.ObservationFailureProbability},
+		{"ShouldAcceptAttestedReportFailureProbability", c.ShouldAcceptAttestedReportFailureProbability},
+		{"ShouldTransmitAcceptedReportFailureProbability", c.ShouldTransmitAcceptedReportFailureProbability},
+	} {
+		if !(0 <= p.value && p.value <= 1) {
+			err = multierr.Append(err, fmt.Errorf("%v (%v) out of range. Should be between 0 and 1", p.name, p.value))
+		}
+	}
+	return err
+}
+
+// Factory creates synthetic plugins. Its Config is taken as is, the offchain
+// config's ReportingPluginConfig is ignored.
+type Factory struct {
+	Config Config
+}
+
+var _ ocr3types.ReportingPluginFactory[struct{}] = Factory{}
+
+func (f Factory) NewReportingPlugin(config ocr3types.ReportingPluginConfig) (ocr3types.ReportingPlugin[struct{}], ocr3types.ReportingPluginInfo, error) {
+	if err := f.Config.Validate(); err != nil {
+		return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("invalid synthetic plugin config: %w", err)
+	}
+	return &plugin{f.Config, config.OracleID}, ocr3types.ReportingPluginInfo{
+		"SyntheticPlugin",
+		ocr3types.ReportingPluginLimits{
+			8,
+			f.Config.ObservationLength,
+			f.Config.OutcomeLength,
+			f.Config.ReportLength,
+			f.Config.ReportCount,
+			0,
+			0,
+			0,
+		},
+		ocr3types.ObservationCacheConfig{},
+		false,
+		ocr3types.ChunkedTransferConfig{},
+		false,
+		0,
+		false,
+	}, nil
+}
+
+type plugin struct {
+	config Config
+	id     commontypes.OracleID
+}
+
+var _ ocr3types.ReportingPlugin[struct{}] = (*plugin)(nil)
+
+func maybeFail(probability float64, name string, seqNr uint64) error {
+	if probability > 0 && rand.Float64() < probability {
+		return fmt.Errorf("synthetic failure in %v for seqNr %v", name, seqNr)
+	}
+	return nil
+}
+
+func (p *plugin) Query(ctx context.Context, outctx ocr3types.OutcomeContext) (types.Query, error) {
+	if err := maybeFail(p.config.QueryFailureProbability, "Query", outctx.SeqNr); err != nil {
+		return nil, err
+	}
+	return binary.BigEndian.AppendUint64(nil, outctx.SeqNr), nil
+}
+
+func (p *plugin) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
+	if err := maybeFail(p.config.ObservationFailureProbability, "Observation", outctx.SeqNr); err != nil {
+		return nil, err
+	}
+	observation := make([]byte, p.config.ObservationLength)
+	binary.BigEndian.PutUint64(observation, outctx.SeqNr)
+	observation[8] = byte(p.id)
+	return observation, nil
+}
+
+func (p *plugin) ValidateObservation(outctx ocr3types.OutcomeContext, query types.Query, ao types.AttributedObservation) error {
+	if len(ao.Observation) != p.config.ObservationLength {
+		return fmt.Errorf("observation has wrong length %v, expected %v", len(ao.Observation), p.config.ObservationLength)
+	}
+	if binary.BigEndian.Uint64(ao.Observation) != outctx.SeqNr {
+		return fmt.Errorf("observation has wrong seqNr")
+	}
+	if commontypes.OracleID(ao.Observation[8]) != ao.Observer {
+		return fmt.Errorf("observation has wrong observer")
+	}
+	return nil
+}
+
+func (p *plugin) ObservationQuorum(outctx ocr3types.OutcomeContext, query types.Query) (ocr3types.Quorum, error) {
+	return ocr3types.QuorumTwoFPlusOne, nil
+}
+
+func (p *plugin) Outcome(outctx ocr3types.OutcomeContext, query types.Query, aos []types.AttributedObservation) (ocr3types.Outcome, error) {
+	sorted := append([]types.AttributedObservation{}, aos...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Observer < sorted[j].Observer })
+
+	h := sha256.New()
+	_, _ = h.Write(outctx.PreviousOutcome)
+	for _, ao := range sorted {
+		_, _ = h.Write(ao.Observation)
+	}
+
+	outcome := make([]byte, p.config.OutcomeLength)
+	binary.BigEndian.PutUint64(outcome, outctx.SeqNr)
+	copy(outcome[8:], h.Sum(nil))
+	return outcome, nil
+}
+
+func (p *plugin) Reports(seqNr uint64, outcome ocr3types.Outcome) ([]ocr3types.ReportWithInfo[struct{}], error) {
+	reports := make([]ocr3types.ReportWithInfo[struct{}], 0, p.config.ReportCount)
+	for i := 0; i < p.config.ReportCount; i++ {
+		report := make([]byte, p.config.ReportLength)
+		binary.BigEndian.PutUint64(report, seqNr)
+		binary.BigEndian.PutUint32(report[8:], uint32(i))
+		reports = append(reports, ocr3types.ReportWithInfo[struct{}]{report, struct{}{}})
+	}
+	return reports, nil
+}
+
+func (p *plugin) ShouldAcceptAttestedReport(ctx context.Context, seqNr uint64, _ ocr3types.ReportWithInfo[struct{}]) (bool, error) {
+	if err := maybeFail(p.config.ShouldAcceptAttestedReportFailureProbability, "ShouldAcceptAttestedReport", seqNr); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *plugin) ShouldTransmitAcceptedReport(ctx context.Context, seqNr uint64, _ ocr3types.ReportWithInfo[struct{}]) (bool, error) {
+	if err := maybeFail(p.config.ShouldTransmitAcceptedReportFailureProbability, "ShouldTransmitAcceptedReport", seqNr); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *plugin) Close() error {
+	return nil
+}
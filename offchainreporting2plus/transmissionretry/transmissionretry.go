@@ -0,0 +1,112 @@
+// Package transmissionretry lets operators control whether and how an OCR3
+// oracle retries transmissions that failed, i.e. calls to
+// ContractTransmitter.Transmit (or BatchContractTransmitter.TransmitBatch)
+// that returned an error. This is mostly useful on congested chains, where
+// transmissions may fail transiently.
+//
+// An oracle only retries if a Policy is passed in
+// OCR3OracleArgs.TransmissionRetryPolicy. Without one, a report whose
+// transmission failed is dropped, as before.
+//
+// A retry goes through the same steps as the first attempt, except for
+// ShouldAcceptAttestedReport: ShouldTransmitAcceptedReport is invoked again
+// right before every retry, so plugins that check onchain state there don't
+// need a Policy to avoid transmitting reports that have become obsolete.
+package transmissionretry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/libocr/backoff"
+	"go.uber.org/multierr"
+)
+
+// Status describes a report (or batch of reports, if the oracle transmits in
+// batches) whose transmission has failed at least once.
+type Status struct {
+	SeqNr uint64
+	// Number of attempts that have failed so far, at least 1
+	FailedAttempts int
+	// Time since the oracle's transmission stage received the attested
+	// report. Retransmission requests (see package retransmission) restart
+	// the clock.
+	Age time.Duration
+	// Highest sequence number for which the oracle has received an attested
+	// report. The report is superseded iff this is greater than SeqNr.
+	HighestAttestedSeqNr uint64
+	// Error returned by the most recent attempt
+	LastErr error
+}
+
+// Policy decides whether and when failed transmissions are retried. A Policy
+// must be thread-safe if it is shared between oracles.
+type Policy interface {
+	// ShouldRetry is called after every failed attempt and again right before
+	// the retry is made, with Age and HighestAttestedSeqNr updated. If it
+	// returns false, the report is dropped.
+	ShouldRetry(status Status) bool
+	// RetryDelay returns how long to wait before the next attempt. It is only
+	// called after a failed attempt for which ShouldRetry returned true.
+	RetryDelay(status Status) time.Duration
+}
+
+// Config configures the Policy returned by NewPolicy.
+type Config struct {
+	// Maximum number of attempts per report, including the first one. Must be
+	// at least 1. A value of 1 disables retries.
+	MaxAttempts int
+	// Delays between attempts. Backoff.Initial is the delay between the first
+	// and the second attempt.
+	Backoff backoff.Schedule
+	// Reports older than this aren't retried. Zero means no limit.
+	MaxReportAge time.Duration
+	// If true, reports aren't retried once a report with a higher sequence
+	// number has been attested.
+	DropSuperseded bool
+}
+
+func (c Config) Validate() error {
+	var err error
+	if !(1 <= c.MaxAttempts) {
+		err = multierr.Append(err, fmt.Errorf("MaxAttempts (%v) must be at least 1", c.MaxAttempts))
+	}
+	if backoffErr := c.Backoff.Validate(); backoffErr != nil {
+		err = multierr.Append(err, fmt.Errorf("invalid Backoff: %w", backoffErr))
+	}
+	if !(0 <= c.MaxReportAge) {
+		err = multierr.Append(err, fmt.Errorf("MaxReportAge (%v) must not be negative", c.MaxReportAge))
+	}
+	return err
+}
+
+// NewPolicy returns a Policy that retries up to config.MaxAttempts times,
+// backing off according to config.Backoff, and gives up early on reports that
+// are too old or superseded if config says so.
+func NewPolicy(config Config) (Policy, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return configPolicy{config}, nil
+}
+
+type configPolicy struct {
+	config Config
+}
+
+func (p configPolicy) ShouldRetry(status Status) bool {
+	if status.FailedAttempts >= p.config.MaxAttempts {
+		return false
+	}
+	if p.config.MaxReportAge != 0 && status.Age > p.config.MaxReportAge {
+		return false
+	}
+	if p.config.DropSuperseded && status.HighestAttestedSeqNr > status.SeqNr {
+		return false
+	}
+	return true
+}
+
+func (p configPolicy) RetryDelay(status Status) time.Duration {
+	return p.config.Backoff.Delay(status.FailedAttempts - 1)
+}
@@ -0,0 +1,215 @@
+// Package transmissionsim simulates the OCR3 transmission schedule, so that
+// deployers can tune S and DeltaStage (see ocr3confighelper.PublicConfig) with
+// data instead of intuition.
+//
+// For every report, the oracles are ordered by a pseudorandom permutation. The
+// first S[0] oracles in that order transmit right away, the next S[1] oracles
+// transmit after DeltaStage, the next S[2] after 2*DeltaStage, and so on. An
+// oracle in a later stage only transmits if no transmission from an earlier
+// stage has landed, so the time to the first successful transmission is the
+// stage of the earliest oracle whose transmission succeeds, times DeltaStage.
+//
+// Simulate models each oracle's transmission as failing independently with a
+// given probability, e.g. because the oracle is offline or its transmissions
+// tend to revert, and draws many permutations to estimate the distribution of
+// the time to the first successful transmission. Since all randomness derives
+// from Config.Seed, results are reproducible. WorstCaseDelay complements the
+// simulation with the bound that holds regardless of probabilities, if at most
+// F oracles are faulty.
+//
+// The model ignores the time transmissions take to land onchain, which adds
+// to every delay reported here.
+package transmissionsim
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// MaxTrials bounds Config.Trials.
+const MaxTrials = 10_000_000
+
+type Config struct {
+	// Transmission schedule, see ocr3confighelper.PublicConfig.S. Entries
+	// must be non-negative and sum to at most N.
+	S []int
+	// Delay between stages of the transmission schedule. Must not be
+	// negative.
+	DeltaStage time.Duration
+	// Maximum number of faulty oracles, used for WorstCaseDelay. Must be
+	// less than N.
+	F int
+	// FailureProbabilities[i] is the probability that a transmission by
+	// oracle i fails. Entries must be between 0 and 1. The number of entries
+	// determines N.
+	FailureProbabilities []float64
+	// Number of simulated reports. Must be between 1 and MaxTrials.
+	Trials int
+	// Seed of the simulation's pseudorandom number generator.
+	Seed int64
+}
+
+// N is the number of oracles.
+func (c Config) N() int {
+	return len(c.FailureProbabilities)
+}
+
+func (c Config) validate() error {
+	if c.N() == 0 {
+		return fmt.Errorf("FailureProbabilities must not be empty")
+	}
+	sum := 0
+	for i, s := range c.S {
+		if s < 0 {
+			return fmt.Errorf("S[%v] (%v) must not be negative", i, s)
+		}
+		sum += s
+	}
+	if sum > c.N() {
+		return fmt.Errorf("sum(S) (%v) must not exceed N (%v)", sum, c.N())
+	}
+	if c.DeltaStage < 0 {
+		return fmt.Errorf("DeltaStage (%v) must not be negative", c.DeltaStage)
+	}
+	if !(0 <= c.F && c.F < c.N()) {
+		return fmt.Errorf("F (%v) must be between 0 and N-1 (%v)", c.F, c.N()-1)
+	}
+	for i, p := range c.FailureProbabilities {
+		if !(0 <= p && p <= 1) {
+			return fmt.Errorf("FailureProbabilities[%v] (%v) must be between 0 and 1", i, p)
+		}
+	}
+	if !(1 <= c.Trials && c.Trials <= MaxTrials) {
+		return fmt.Errorf("Trials (%v) must be between 1 and %v", c.Trials, MaxTrials)
+	}
+	return nil
+}
+
+// Result summarizes a simulation.
+type Result struct {
+	Trials int
+	// Number of trials in which no transmission succeeded.
+	Failures int
+	// StageSuccesses[i] is the number of trials whose first successful
+	// transmission happened in stage i.
+	StageSuccesses []int
+	// WorstCaseDelay and WorstCaseOK as returned by the package-level
+	// WorstCaseDelay for the simulated Config.
+	WorstCaseDelay time.Duration
+	WorstCaseOK    bool
+
+	deltaStage time.Duration
+}
+
+// SuccessProbability returns the fraction of trials in which some
+// transmission succeeded.
+func (r Result) SuccessProbability() float64 {
+	return float64(r.Trials-r.Failures) / float64(r.Trials)
+}
+
+// Quantile returns the q-quantile of the time to the first successful
+// transmission, counting trials without successful transmission as infinitely
+// delayed. It returns false if the quantile is infinite. q must be between 0
+// and 1.
+func (r Result) Quantile(q float64) (time.Duration, bool) {
+	rank := int(math.Ceil(q * float64(r.Trials)))
+	if rank < 1 {
+		rank = 1
+	}
+	for stage, count := range r.StageSuccesses {
+		rank -= count
+		if rank <= 0 {
+			return time.Duration(stage) * r.deltaStage, true
+		}
+	}
+	return 0, false
+}
+
+// MeanDelay returns the mean time to the first successful transmission among
+// the trials in which some transmission succeeded, or zero if there are none.
+func (r Result) MeanDelay() time.Duration {
+	successes := r.Trials - r.Failures
+	if successes == 0 {
+		return 0
+	}
+	var sum float64
+	for stage, count := range r.StageSuccesses {
+		sum += float64(stage) * float64(count)
+	}
+	return time.Duration(sum / float64(successes) * float64(r.deltaStage))
+}
+
+// Simulate runs config.Trials trials of the transmission schedule.
+func Simulate(config Config) (Result, error) {
+	if err := config.validate(); err != nil {
+		return Result{}, fmt.Errorf("invalid Config: %w", err)
+	}
+
+	// stageOfPosition[p] is the stage of the oracle at position p of the
+	// permutation, or -1 if it isn't part of the schedule
+	stageOfPosition := make([]int, config.N())
+	for p := range stageOfPosition {
+		stageOfPosition[p] = -1
+	}
+	p := 0
+	for stage, s := range config.S {
+		for j := 0; j < s; j++ {
+			stageOfPosition[p] = stage
+			p++
+		}
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed))
+	failures := 0
+	stageSuccesses := make([]int, len(config.S))
+	for trial := 0; trial < config.Trials; trial++ {
+		pi := rng.Perm(config.N())
+		firstStage := -1
+		for oracle, position := range pi {
+			stage := stageOfPosition[position]
+			if stage < 0 {
+				continue
+			}
+			// draw for every scheduled oracle, so that the random stream
+			// doesn't depend on outcomes of earlier draws
+			succeeded := rng.Float64() >= config.FailureProbabilities[oracle]
+			if succeeded && (firstStage < 0 || stage < firstStage) {
+				firstStage = stage
+			}
+		}
+		if firstStage < 0 {
+			failures++
+		} else {
+			stageSuccesses[firstStage]++
+		}
+	}
+
+	worstCaseDelay, worstCaseOK := WorstCaseDelay(config.S, config.DeltaStage, config.F)
+	return Result{
+		config.Trials,
+		failures,
+		stageSuccesses,
+		worstCaseDelay,
+		worstCaseOK,
+
+		config.DeltaStage,
+	}, nil
+}
+
+// WorstCaseDelay returns the latest time at which some correct oracle
+// transmits if at most f oracles are faulty, regardless of the order of
+// transmitters: the delay of the first stage by which more than f oracles
+// have been scheduled. It returns false if the schedule contains at most f
+// oracles, in which case faulty oracles can prevent transmission altogether.
+func WorstCaseDelay(s []int, deltaStage time.Duration, f int) (time.Duration, bool) {
+	sum := 0
+	for stage, count := range s {
+		sum += count
+		if sum > f {
+			return time.Duration(stage) * deltaStage, true
+		}
+	}
+	return 0, false
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 )
 
@@ -12,12 +13,16 @@ import (
 type ConfigDigestPrefix uint16
 
 // This acts as the canonical "registry" of ConfigDigestPrefixes. Pick an unused
-// prefix and add it to this list before you build an OffchainConfigDigester for
-// whatever chain you're targeting.
+// prefix and add it to this list (and to configDigestPrefixNames) before you
+// build an OffchainConfigDigester for whatever chain you're targeting. Rather
+// than copying the EVM digester, consider computing the digest with
+// package chains/configdigester, which implements hashing conventions meant to
+// be shared by non-EVM chains. Add test vectors for your digester to package
+// chains/configdigestvectors.
 const (
 	_                                        ConfigDigestPrefix = 0 // reserved to prevent errors where a zero-default creeps through somewhere
 	ConfigDigestPrefixEVM                    ConfigDigestPrefix = 1 // TODO: rename to ConfigDigestPrefixEVMSimple in the future
-	ConfigDigestPrefixTerra                  ConfigDigestPrefix = 2
+	ConfigDigestPrefixTerra                  ConfigDigestPrefix = 2 // Terra and other Cosmos-SDK chains running the cosmwasm contracts, see chains/cosmosutil
 	ConfigDigestPrefixSolana                 ConfigDigestPrefix = 3
 	ConfigDigestPrefixStarknet               ConfigDigestPrefix = 4
 	_                                                           = 5 // reserved, not sure for what
@@ -30,6 +35,32 @@ const (
 	_                      ConfigDigestPrefix = 0xFFFF // reserved for future use
 )
 
+var configDigestPrefixNames = map[ConfigDigestPrefix]string{
+	ConfigDigestPrefixEVM:                    "EVM",
+	ConfigDigestPrefixTerra:                  "Terra",
+	ConfigDigestPrefixSolana:                 "Solana",
+	ConfigDigestPrefixStarknet:               "Starknet",
+	ConfigDigestPrefixMercuryV02:             "MercuryV02",
+	ConfigDigestPrefixEVMThresholdDecryption: "EVMThresholdDecryption",
+	ConfigDigestPrefixEVMS4:                  "EVMS4",
+	ConfigDigestPrefixLLO:                    "LLO",
+	ConfigDigestPrefixOCR1:                   "OCR1",
+}
+
+// Name returns the name of a registered prefix, e.g. "EVM" for
+// ConfigDigestPrefixEVM. ok is false if the prefix isn't registered.
+func (prefix ConfigDigestPrefix) Name() (name string, ok bool) {
+	name, ok = configDigestPrefixNames[prefix]
+	return name, ok
+}
+
+// IsRegistered returns whether the prefix is one of the
+// ConfigDigestPrefixes listed above.
+func (prefix ConfigDigestPrefix) IsRegistered() bool {
+	_, ok := configDigestPrefixNames[prefix]
+	return ok
+}
+
 func ConfigDigestPrefixFromConfigDigest(configDigest ConfigDigest) ConfigDigestPrefix {
 	return ConfigDigestPrefix(binary.BigEndian.Uint16(configDigest[:2]))
 }
@@ -88,6 +119,22 @@ func (c ConfigDigest) MarshalText() (text []byte, err error) {
 	return []byte(s), nil
 }
 
+var _ encoding.TextUnmarshaler = (*ConfigDigest)(nil)
+
+// UnmarshalText parses the hex encoding produced by MarshalText.
+func (c *ConfigDigest) UnmarshalText(text []byte) error {
+	b, err := hex.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("cannot parse ConfigDigest: %w", err)
+	}
+	configDigest, err := BytesToConfigDigest(b)
+	if err != nil {
+		return err
+	}
+	*c = configDigest
+	return nil
+}
+
 // An OffchainConfigDigester computes a ConfigDigest the same way as the
 // contract, but *offchain*. This is used to ensure that the ConfigDigest
 // returned from the contract was computed correctly and to prevent a malicious
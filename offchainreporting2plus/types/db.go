@@ -13,24 +13,82 @@ import (
 //
 // All its functions should be thread-safe.
 type ConfigDatabase interface {
+	ConfigDatabaseReader
+	ConfigDatabaseWriter
+}
+
+// ConfigDatabaseReader is the read facet of ConfigDatabase. Reads may be
+// served from a (slightly stale) read replica.
+type ConfigDatabaseReader interface {
 	ReadConfig(ctx context.Context) (*ContractConfig, error)
+}
+
+// ConfigDatabaseWriter is the write facet of ConfigDatabase.
+type ConfigDatabaseWriter interface {
+	// WriteConfig overwrites the stored config. Once a config has been
+	// removed from the contract (see
+	// LocalConfig.ContractConfigRemovalGracePeriod), it is overwritten with
+	// a zero ContractConfig, which ReadConfig may return like any other.
 	WriteConfig(ctx context.Context, config ContractConfig) error
 }
 
 // Database persistently stores information on-disk.
 // All its functions should be thread-safe.
+//
+// Hosts that want to route heavy reads to a read replica can implement
+// DatabaseReader and DatabaseWriter separately and combine them with
+// NewSplitDatabase. Existing implementations of Database need no changes.
 type Database interface {
-	ConfigDatabase
+	DatabaseReader
+	DatabaseWriter
+}
+
+// DatabaseReader contains the reads that tolerate slightly stale data and may
+// therefore be served from a read replica.
+type DatabaseReader interface {
+	ConfigDatabaseReader
+
+	PendingTransmissionsWithConfigDigest(context.Context, ConfigDigest) (map[ReportTimestamp]PendingTransmission, error)
+}
+
+// DatabaseWriter contains all writes, as well as ReadState. ReadState must
+// observe the latest WriteState and must thus be served by the primary:
+// restoring stale protocol state could cause an oracle to send messages that
+// conflict with ones it sent before.
+type DatabaseWriter interface {
+	ConfigDatabaseWriter
 
 	ReadState(ctx context.Context, configDigest ConfigDigest) (*PersistentState, error)
 	WriteState(ctx context.Context, configDigest ConfigDigest, state PersistentState) error
 
 	StorePendingTransmission(context.Context, ReportTimestamp, PendingTransmission) error
-	PendingTransmissionsWithConfigDigest(context.Context, ConfigDigest) (map[ReportTimestamp]PendingTransmission, error)
 	DeletePendingTransmission(context.Context, ReportTimestamp) error
 	DeletePendingTransmissionsOlderThan(context.Context, time.Time) error
 }
 
+type splitDatabase struct {
+	DatabaseReader
+	DatabaseWriter
+}
+
+// NewSplitDatabase returns a Database that serves the methods of
+// DatabaseReader from reader and all other methods from writer.
+func NewSplitDatabase(reader DatabaseReader, writer DatabaseWriter) Database {
+	return splitDatabase{reader, writer}
+}
+
+// PendingTransmissionPruner may optionally be implemented by a Database to let
+// the oracle delete pending transmissions of superseded configs. Pending
+// transmissions of a config can never succeed once the contract has moved on
+// to another config, so they are pure garbage.
+type PendingTransmissionPruner interface {
+	// DeletePendingTransmissionsWithOtherConfigDigest deletes all pending
+	// transmissions whose config digest differs from configDigest. It is
+	// called whenever the oracle starts running the protocol instance for
+	// configDigest.
+	DeletePendingTransmissionsWithOtherConfigDigest(ctx context.Context, configDigest ConfigDigest) error
+}
+
 type PendingTransmission struct {
 	Time                 time.Time
 	ExtraHash            [32]byte
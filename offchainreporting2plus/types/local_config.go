@@ -1,9 +1,18 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 const EnableDangerousDevelopmentMode = "enable dangerous development mode"
 
+const DefaultPendingTransmissionRetention = 24 * time.Hour
+
+// DefaultMessageArchivingQueueCapacity is used if
+// MessageArchivingConfig.QueueCapacity is zero.
+const DefaultMessageArchivingQueueCapacity = 1000
+
 // LocalConfig contains oracle-specific configuration details which are not
 // mandated by the on-chain configuration specification via OCR2Aggregator.SetConfig
 type LocalConfig struct {
@@ -54,6 +63,25 @@ type LocalConfig struct {
 	// fifteen seconds and two minutes.
 	ContractConfigTrackerPollInterval time.Duration
 
+	// If non-zero, a running protocol instance is torn down once
+	// ContractConfigTracker has continuously reported that the contract has no
+	// config (i.e. a zero ConfigDigest) for at least this long. The grace
+	// period guards against tearing down instances because of transient RPC
+	// glitches. The removed config is also overwritten in the
+	// ConfigDatabase, so that it isn't restored on the next boot. If the
+	// ContractConfigTracker implements ContractConfigRemovalSubscriber, it is
+	// notified of the teardown.
+	//
+	// Zero disables this check, in which case instances keep running against
+	// their stale config until a new config is set.
+	ContractConfigRemovalGracePeriod time.Duration
+
+	// Pending transmissions older than this are deleted from the Database
+	// periodically. Zero means DefaultPendingTransmissionRetention. OCR2
+	// only; pending transmissions of superseded configs are deleted as well if
+	// the Database implements PendingTransmissionPruner.
+	PendingTransmissionRetention time.Duration
+
 	// Timeout for ContractTransmitter.Transmit calls.
 	ContractTransmitterTransmitTimeout time.Duration
 
@@ -68,7 +96,167 @@ type LocalConfig struct {
 	// validation will be done on this value.
 	MinOCR2MaxDurationQuery time.Duration
 
+	// InboundMessageDeduplication configures how OCR3 oracles recognize
+	// byte-identical copies of messages they have already received, which
+	// are dropped before being deserialized and verified. The zero value
+	// disables deduplication.
+	InboundMessageDeduplication MessageDeduplicationConfig
+
+	// MessageArchiving configures which protocol messages an OCR3 oracle
+	// passes to its MessageArchiver, if it has one (see
+	// OCR3OracleArgs.MessageArchiver).
+	MessageArchiving MessageArchivingConfig
+
+	// DegradedMode configures how an OCR3 oracle detects and responds to
+	// network partitions. The zero value disables detection.
+	DegradedMode DegradedModeConfig
+
+	// HotConfigSwap configures how an OCR3 oracle switches protocol
+	// instances when the contract config changes. The zero value winds down
+	// the old instance completely before starting the new one.
+	HotConfigSwap HotConfigSwapConfig
+
+	// StaleMessageDropping configures whether an OCR3 oracle drops messages
+	// that are too old to be useful before processing them. The zero value
+	// disables dropping.
+	StaleMessageDropping StaleMessageDroppingConfig
+
+	// If set, an OCR3 oracle that finds its persisted protocol state
+	// corrupted, i.e. failing its checksum, moves it out of the way and
+	// starts the protocol instance from a fresh state. Since it has forgotten
+	// what it signed, the oracle then stays passive, i.e. it doesn't sign
+	// prepares, commits, or epoch start requests and doesn't lead, until it
+	// has moved past every epoch it may have signed in before, see
+	// ocr3types.ProtocolStateQuarantiner. Use this only if an oracle failing to
+	// start is worse than the oracle being unable to contribute for a while.
+	//
+	// If unset, the oracle keeps retrying to read its state and doesn't start
+	// the instance, so that an operator can restore the state from a backup.
+	QuarantineCorruptedProtocolState bool
+
 	// DANGER, this turns off all kinds of sanity checks. May be useful for testing.
 	// Set this to EnableDangerousDevelopmentMode to turn on dev mode.
 	DevelopmentMode string
 }
+
+// MessageDeduplicationConfig bounds the memory used for deduplicating
+// inbound messages. A message is recognized as a duplicate if a copy from the
+// same sender was received at most Window ago. If a sender sends more than
+// MaxEntries messages per Window, the oldest entries are evicted early and
+// duplicates of the corresponding messages are no longer recognized. Such
+// evictions are counted in the ocr3_dedup_cache_evictions_total metric with
+// reason "capacity", which signals that MaxEntries should be raised.
+//
+// Messages are compared without the time at which they were sent, so that
+// retransmissions of the same message are recognized, too. The protocol
+// tolerates duplicates regardless, deduplication merely saves the work of
+// processing them.
+type MessageDeduplicationConfig struct {
+	// Maximum number of remembered messages per sender.
+	MaxEntries int
+	// Duration for which a received message is remembered.
+	Window time.Duration
+}
+
+// DegradedModeBehavior determines what an OCR3 oracle does while in degraded
+// mode, see DegradedModeConfig.
+type DegradedModeBehavior int
+
+const (
+	// Keep dialing unreachable oracles at the regular rate, so that the
+	// protocol recovers as quickly as possible once the partition heals.
+	DegradedModeBehaviorKeepTrying DegradedModeBehavior = iota
+	// Dial unreachable oracles less and less often, to reduce the load a
+	// long partition puts on the network and on the other oracles. Recovery
+	// may be delayed accordingly. Only takes effect if the oracle's network
+	// endpoints implement DialBackoffEndpoint.
+	DegradedModeBehaviorBackOff
+)
+
+func (b DegradedModeBehavior) String() string {
+	switch b {
+	case DegradedModeBehaviorKeepTrying:
+		return "KeepTrying"
+	case DegradedModeBehaviorBackOff:
+		return "BackOff"
+	}
+	return fmt.Sprintf("DegradedModeBehavior(%d)", int(b))
+}
+
+// DegradedModeConfig configures degraded mode: an OCR3 protocol instance is in
+// degraded mode while it hasn't received any message from enough oracles to
+// form a Byzantine quorum (counting itself) for Threshold. Without such a
+// quorum, e.g. during a network partition, the protocol can't make progress,
+// so degraded mode distinguishes a partitioned instance from a merely slow
+// one. Degraded mode is reported in heartbeats, telemetry, logs and the
+// ocr3_degraded metric, so that operators and automation can respond. The
+// instance leaves degraded mode as soon as a quorum is reachable again.
+type DegradedModeConfig struct {
+	// Duration without messages from a quorum after which an instance
+	// enters degraded mode. Zero disables degraded mode. Oracles that can
+	// reach each other exchange messages at least every DeltaRound or
+	// DeltaResend, so Threshold should comfortably exceed both.
+	Threshold time.Duration
+	// What to do while in degraded mode
+	Behavior DegradedModeBehavior
+}
+
+// HotConfigSwapConfig configures hot config swaps: when the contract config
+// changes, an OCR3 oracle starts the protocol instance for the new config right
+// away, while the instance for the old config winds down alongside it. The old
+// instance stops taking part in the protocol immediately, but
+//
+//   - keeps its network endpoint open until the new instance's endpoint has
+//     started, so that connections to peers that are part of both configs are
+//     kept instead of being torn down and redialed, and
+//   - finishes transmitting the reports it has already scheduled, which is
+//     useful if reports remain valid after the config changes.
+//
+// This shortens the gap in reporting after a config change, which is
+// otherwise dominated by redialing peers. The old instance's resources are
+// only released to the AdmissionController once it has fully stopped. If the
+// AdmissionController lacks the capacity for running both instances at once,
+// the new instance waits for the old one to stop before it is admitted, which
+// forgoes the benefits of the hot config swap.
+type HotConfigSwapConfig struct {
+	// Maximum duration for which the old instance keeps its network endpoint
+	// open and transmits reports after the config changes. Zero disables hot
+	// config swaps.
+	GracePeriod time.Duration
+}
+
+// StaleMessageDroppingConfig configures dropping of stale messages: an OCR3
+// oracle drops a message at ingress if it was sent longer ago than its type's
+// freshness window, which is derived from the config's delta parameters, plus
+// MaxClockSkew. This saves the work of processing the useless messages that
+// peers flush once a network partition heals. Dropped messages are counted by
+// type and logged.
+//
+// A message's age is measured against the time at which the sender claims to
+// have sent it, according to the sender's own clock. The protocol doesn't
+// otherwise require synchronized clocks, so only enable this if oracles'
+// clocks are known to be synchronized to within MaxClockSkew: an oracle whose
+// clock is off by more would drop, or have dropped, honest messages and could
+// keep the protocol from making progress.
+type StaleMessageDroppingConfig struct {
+	// Maximum tolerated difference between the clocks of a message's sender
+	// and its receiver. Zero disables dropping stale messages.
+	MaxClockSkew time.Duration
+}
+
+// MessageArchivingConfig controls the volume of protocol messages passed to a
+// MessageArchiver. The zero value archives every message in full.
+type MessageArchivingConfig struct {
+	// Fraction of messages that are archived, each message being sampled
+	// independently at random. Must be between 0 and 1. Zero means all
+	// messages are archived.
+	SamplingRate float64
+	// Serialized messages longer than this are truncated before being
+	// archived. Zero means messages are never truncated.
+	MaxMessageLength int
+	// Maximum number of messages waiting to be archived, per protocol
+	// instance. Once the queue is full, further messages are dropped from the
+	// archive and counted in the ocr3_message_archive_dropped_total metric.
+	// Zero means DefaultMessageArchivingQueueCapacity.
+	QueueCapacity int
+}
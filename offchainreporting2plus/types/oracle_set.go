@@ -0,0 +1,48 @@
+package types
+
+import "fmt"
+
+// OracleSetViolation identifies the constraint that a config's oracle set
+// violates, see InvalidOracleSetError.
+type OracleSetViolation string
+
+const (
+	// F is negative, doesn't fit into 8 bits, or isn't less than N/3.
+	OracleSetViolationFaultTolerance OracleSetViolation = "faultTolerance"
+	// N exceeds MaxOracles.
+	OracleSetViolationTooManyOracles OracleSetViolation = "tooManyOracles"
+	// The lists of signers, transmitters, peer IDs, offchain public keys, and
+	// shared secret encryptions don't all have length N.
+	OracleSetViolationListLengthMismatch OracleSetViolation = "listLengthMismatch"
+	// Two oracles share a signer, transmitter, peer ID, or offchain public
+	// key.
+	OracleSetViolationDuplicateIdentity OracleSetViolation = "duplicateIdentity"
+	// An oracle lacks its signer, transmitter, or peer ID.
+	OracleSetViolationMissingIdentity OracleSetViolation = "missingIdentity"
+	// The transmission schedule S can't be satisfied by the oracle set, e.g.
+	// because it doesn't include a single correct oracle.
+	OracleSetViolationTransmissionSchedule OracleSetViolation = "transmissionSchedule"
+)
+
+// InvalidOracleSetError is returned when a config's number of oracles N, its
+// number of potentially faulty oracles F, and its oracle identities don't form
+// a valid combination. Use errors.As to detect it.
+//
+// N, F, and the oracle identities are fixed for the lifetime of a protocol
+// instance, which is identified by its config digest. There is no way to
+// change them mid-instance: every setConfig starts a fresh instance, and
+// oracles refuse to run an instance whose oracle set is invalid. A faulty
+// setConfig thus halts the DON until it's superseded by a valid one. Deploy
+// tooling should check proposed configs before submitting them, see
+// confighelper.ValidateOracleSet.
+type InvalidOracleSetError struct {
+	Violation OracleSetViolation
+	N         int
+	F         int
+	// Human-readable description of the violation
+	Detail string
+}
+
+func (e *InvalidOracleSetError) Error() string {
+	return fmt.Sprintf("invalid oracle set (N = %v, F = %v): %s", e.N, e.F, e.Detail)
+}
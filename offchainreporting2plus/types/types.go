@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/ed25519"
+	"fmt"
 	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
@@ -33,6 +34,16 @@ type BinaryNetworkEndpointFactory interface {
 	PeerID() string
 }
 
+// DialBackoffEndpoint may optionally be implemented by the
+// commontypes.BinaryNetworkEndpoints returned by a
+// BinaryNetworkEndpointFactory, to let OCR3 oracles in degraded mode dial
+// unreachable oracles less often, see DegradedModeBehaviorBackOff.
+type DialBackoffEndpoint interface {
+	// SetDialBackoff sets whether the endpoint may dial disconnected oracles
+	// less often than usual. Must be thread-safe.
+	SetDialBackoff(enabled bool)
+}
+
 // BootstrapperFactory creates permissioned Bootstrappers.
 //
 // All its functions should be thread-safe.
@@ -283,6 +294,35 @@ type ReportingPluginLimits struct {
 	MaxReportLength      int
 }
 
+// LimitExceededError is returned when a ReportingPlugin produces an output
+// that exceeds the limits it declared. Use errors.As to detect it and
+// LogFields to obtain structured details about the offending output.
+type LimitExceededError struct {
+	// Which output exceeded its limit, e.g. "observation" or "reportCount".
+	Output string
+	// Index of the offending item if the output is a list, otherwise -1.
+	Index int
+	// Size of the output in bytes, or the number of items for counts.
+	Size  int
+	Limit int
+}
+
+func (e *LimitExceededError) Error() string {
+	if e.Index >= 0 {
+		return fmt.Sprintf("%s at index %v exceeds limit (%v vs %v)", e.Output, e.Index, e.Size, e.Limit)
+	}
+	return fmt.Sprintf("%s exceeds limit (%v vs %v)", e.Output, e.Size, e.Limit)
+}
+
+func (e *LimitExceededError) LogFields() commontypes.LogFields {
+	return commontypes.LogFields{
+		"limitExceededOutput": e.Output,
+		"limitExceededIndex":  e.Index,
+		"limitExceededSize":   e.Size,
+		"limitExceededLimit":  e.Limit,
+	}
+}
+
 type ReportingPluginInfo struct {
 	// Used for debugging purposes.
 	Name string
@@ -330,6 +370,19 @@ type ContractTransmitter interface {
 	FromAccount() (Account, error)
 }
 
+// ConfigDigestEpochContractTransmitter may optionally be implemented by a
+// ContractTransmitter that can look up the latest transmitted epoch for a
+// given config digest, even if another config digest is logically latest,
+// e.g. because it transmits to several contracts that may lag behind each
+// other. If implemented, the protocol calls LatestEpochForConfigDigest
+// instead of LatestConfigDigestAndEpoch.
+type ConfigDigestEpochContractTransmitter interface {
+	// LatestEpochForConfigDigest returns the logically latest epoch for which
+	// a report with configDigest was successfully transmitted, or zero if
+	// there is none.
+	LatestEpochForConfigDigest(ctx context.Context, configDigest ConfigDigest) (epoch uint32, err error)
+}
+
 // ContractConfigTracker tracks configuration changes of the OCR contract
 // (on-chain).
 //
@@ -354,6 +407,16 @@ type ContractConfigTracker interface {
 	LatestBlockHeight(ctx context.Context) (blockHeight uint64, err error)
 }
 
+// ContractConfigRemovalSubscriber may optionally be implemented by a
+// ContractConfigTracker to be notified when a protocol instance is torn down
+// because its config was removed from the contract. See
+// LocalConfig.ContractConfigRemovalGracePeriod.
+type ContractConfigRemovalSubscriber interface {
+	// ConfigRemoved is called after the instance running with configDigest has
+	// been torn down. It should return quickly.
+	ConfigRemoved(configDigest ConfigDigest)
+}
+
 type ContractConfig struct {
 	ConfigDigest          ConfigDigest
 	ConfigCount           uint64
@@ -437,3 +500,123 @@ type OnchainKeyring interface {
 	// Maximum length of a signature
 	MaxSignatureLength() int
 }
+
+// RotatingOffchainKeyring may optionally be implemented by an OffchainKeyring
+// to support rotating its keys without restarting the oracle.
+//
+// To rotate, the operator makes NextOffchainKeyring return a keyring holding
+// the new keys and then gets a contract config listing the new public keys
+// set. Whenever the oracle starts running a contract config, it uses whichever
+// of the two keyrings the config lists, so the new keys take effect as soon as
+// the new config does, without coordinated restarts. Until then, the oracle
+// keeps using the current keys. Once the new config is active, the
+// implementation may make the new keys current and return nil from
+// NextOffchainKeyring again.
+//
+// OCR3 configs additionally support a transition window: a config may list
+// an oracle's new OffchainPublicKey as its next key alongside the current one
+// (see ocr3confighelper.AuxiliaryArgs.NextOffchainPublicKeys). While such a
+// config is active, the other oracles accept the oracle's offchain signatures
+// under either key, so each operator can switch their oracle to the new key
+// whenever it suits them, e.g. at the oracle's next restart, and a later
+// config listing only the new key ends the window. The oracle must still be
+// able to decrypt the config's shared secret, so the keyring it runs with
+// should keep the ConfigEncryptionPublicKey that the config was created for.
+type RotatingOffchainKeyring interface {
+	OffchainKeyring
+
+	// NextOffchainKeyring returns the keyring that the OffchainKeyring is
+	// being rotated to, or nil if no rotation is in progress.
+	NextOffchainKeyring() OffchainKeyring
+}
+
+// RotatingOnchainKeyring may optionally be implemented by an OnchainKeyring to
+// support rotating its key without restarting the oracle. The oracle matches
+// the config's OnchainPublicKey against both keyrings, otherwise it works like
+// RotatingOffchainKeyring. The offchain and onchain keys may be rotated
+// independently of each other. Unlike offchain keys, onchain keys have no
+// transition window in which both are accepted, since the contract verifies
+// reports against exactly one signer per oracle.
+type RotatingOnchainKeyring interface {
+	OnchainKeyring
+
+	// NextOnchainKeyring returns the keyring that the OnchainKeyring is being
+	// rotated to, or nil if no rotation is in progress.
+	NextOnchainKeyring() OnchainKeyring
+}
+
+// TelemetryQueueStatus describes the backlog of telemetry that has not yet
+// been delivered to the MonitoringEndpoint. A steadily growing OldestUnsentAge
+// or Dropped count indicates that the MonitoringEndpoint can't keep up.
+type TelemetryQueueStatus struct {
+	// Number of messages that are queued or currently being delivered.
+	Queued int
+	// Total number of messages that were dropped because the queue was full.
+	Dropped uint64
+	// Age of the oldest message that is queued or currently being delivered,
+	// or zero if there is no such message.
+	OldestUnsentAge time.Duration
+}
+
+// ProtocolInstanceStatus is the status of an OCR3 protocol instance that an
+// oracle is running.
+type ProtocolInstanceStatus struct {
+	ConfigDigest ConfigDigest
+	// Highest sequence number committed by the instance, zero if none.
+	HighestCommittedSeqNr uint64
+	// Whether the instance is in degraded mode, see DegradedModeConfig.
+	Degraded bool
+	// Whether the instance found its persisted state corrupted when it
+	// started, and started from a fresh state instead, see
+	// LocalConfig.QuarantineCorruptedProtocolState.
+	RecoveredFromCorruptedState bool
+}
+
+// MessageArchiver receives copies of the protocol messages an OCR3 oracle
+// sends and receives, e.g. to retain protocol traffic as regulators require.
+// The oracle hands messages to the MessageArchiver through a bounded queue
+// (see LocalConfig.MessageArchiving) drained by a separate goroutine, so a
+// slow MessageArchiver causes messages to be missing from the archive, but
+// never slows down the protocol.
+type MessageArchiver interface {
+	// ArchiveMessage is called for one message at a time, per protocol
+	// instance. ctx is canceled once the protocol instance stops. msg must
+	// not be modified.
+	ArchiveMessage(ctx context.Context, msg ArchivedMessage) error
+}
+
+type MessageDirection int
+
+const (
+	_ MessageDirection = iota
+	MessageDirectionInbound
+	MessageDirectionOutbound
+)
+
+func (d MessageDirection) String() string {
+	switch d {
+	case MessageDirectionInbound:
+		return "inbound"
+	case MessageDirectionOutbound:
+		return "outbound"
+	}
+	return fmt.Sprintf("MessageDirection(%d)", int(d))
+}
+
+// ArchivedMessage is a protocol message as passed to a MessageArchiver.
+type ArchivedMessage struct {
+	ConfigDigest ConfigDigest
+	Direction    MessageDirection
+	// The sender of an inbound message.
+	Sender commontypes.OracleID
+	// The receivers of an outbound message. A broadcast lists all oracles.
+	Receivers []commontypes.OracleID
+	// When the message was sent or received.
+	Time time.Time
+	// The serialized message as exchanged by the protocol, i.e. after
+	// reassembly of chunks. Truncated to
+	// LocalConfig.MessageArchiving.MaxMessageLength.
+	SerializedMessage []byte
+	// Length of the serialized message before truncation.
+	Length int
+}
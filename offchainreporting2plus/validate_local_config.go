@@ -51,6 +51,92 @@ func SanityCheckLocalConfig(c types.LocalConfig) (err error) {
 			100*time.Millisecond, 10*time.Second,
 		))
 
+	if c.ContractConfigRemovalGracePeriod != 0 {
+		err = multierr.Append(err,
+			boundTimeDuration(
+				c.ContractConfigRemovalGracePeriod,
+				"contract config removal grace period",
+				c.ContractConfigTrackerPollInterval, 24*time.Hour,
+			))
+	}
+
+	if c.PendingTransmissionRetention != 0 {
+		err = multierr.Append(err,
+			boundTimeDuration(
+				c.PendingTransmissionRetention,
+				"pending transmission retention",
+				10*time.Minute, 30*24*time.Hour,
+			))
+	}
+
+	if c.InboundMessageDeduplication != (types.MessageDeduplicationConfig{}) {
+		const maxDeduplicationMaxEntries = 1_000_000
+		if !(0 < c.InboundMessageDeduplication.MaxEntries && c.InboundMessageDeduplication.MaxEntries <= maxDeduplicationMaxEntries) {
+			err = multierr.Append(err, errors.Errorf(
+				"inbound message deduplication max entries must be between 1 and %v, but is currently %v",
+				maxDeduplicationMaxEntries,
+				c.InboundMessageDeduplication.MaxEntries))
+		}
+		err = multierr.Append(err,
+			boundTimeDuration(
+				c.InboundMessageDeduplication.Window,
+				"inbound message deduplication window",
+				1*time.Second, 1*time.Hour,
+			))
+	}
+
+	if !(0 <= c.MessageArchiving.SamplingRate && c.MessageArchiving.SamplingRate <= 1) {
+		err = multierr.Append(err, errors.Errorf(
+			"message archiving sampling rate must be between 0 and 1, but is currently %v",
+			c.MessageArchiving.SamplingRate))
+	}
+	if c.MessageArchiving.MaxMessageLength < 0 {
+		err = multierr.Append(err, errors.Errorf(
+			"message archiving max message length must not be negative, but is currently %v",
+			c.MessageArchiving.MaxMessageLength))
+	}
+	const maxMessageArchivingQueueCapacity = 100_000
+	if !(0 <= c.MessageArchiving.QueueCapacity && c.MessageArchiving.QueueCapacity <= maxMessageArchivingQueueCapacity) {
+		err = multierr.Append(err, errors.Errorf(
+			"message archiving queue capacity must be between 0 and %v, but is currently %v",
+			maxMessageArchivingQueueCapacity,
+			c.MessageArchiving.QueueCapacity))
+	}
+
+	if c.DegradedMode.Threshold != 0 {
+		err = multierr.Append(err,
+			boundTimeDuration(
+				c.DegradedMode.Threshold,
+				"degraded mode threshold",
+				1*time.Second, 1*time.Hour,
+			))
+	}
+	switch c.DegradedMode.Behavior {
+	case types.DegradedModeBehaviorKeepTrying, types.DegradedModeBehaviorBackOff:
+	default:
+		err = multierr.Append(err, errors.Errorf(
+			"degraded mode behavior must be KeepTrying or BackOff, but is currently %v",
+			c.DegradedMode.Behavior))
+	}
+
+	if c.HotConfigSwap.GracePeriod != 0 {
+		err = multierr.Append(err,
+			boundTimeDuration(
+				c.HotConfigSwap.GracePeriod,
+				"hot config swap grace period",
+				100*time.Millisecond, 5*time.Minute,
+			))
+	}
+
+	if c.StaleMessageDropping.MaxClockSkew != 0 {
+		err = multierr.Append(err,
+			boundTimeDuration(
+				c.StaleMessageDropping.MaxClockSkew,
+				"stale message dropping max clock skew",
+				100*time.Millisecond, 1*time.Minute,
+			))
+	}
+
 	const minContractConfigConfirmations = 1
 	const maxContractConfigConfirmations = 100
 	if !(minContractConfigConfirmations <= c.ContractConfigConfirmations && c.ContractConfigConfirmations <= maxContractConfigConfirmations) {
@@ -1,5 +1,34 @@
 // Package permutation generates cryptographically secure
 // pseudorandom permutations
+//
+// The protocol uses these permutations to decide in which order oracles
+// transmit reports, and reporting plugins may use them in the same way, e.g.
+// for fair task assignment among oracles. (See package oraclesubset for
+// picking subsets of oracles per OCR3 round.)
+//
+// # Guarantees
+//
+// Permutation is a deterministic function of n and key: every oracle
+// computing Permutation(n, key) obtains the same result, whatever its
+// platform. The output for a given (n, key) will not change across library
+// versions, since oracles running different versions must agree on
+// transmission order.
+//
+// The permutation is obtained by a Fisher-Yates shuffle (math/rand's
+// Shuffle) driven by AES-128 in CTR mode under key. Modelling AES as a
+// pseudorandom function, the output is computationally indistinguishable
+// from a uniformly random permutation of [0, n) to anyone who doesn't know
+// key, and permutations for distinct keys are independent. If key is public
+// (e.g. derived from a ConfigDigest and a sequence number), the permutation
+// is still uniformly distributed as long as nobody chooses key after seeing
+// the resulting permutation.
+//
+// Keys should be derived by hashing all inputs that the permutation is meant
+// to depend on together with a domain separator, so that permutations used
+// for different purposes are independent.
+//
+// Package permutationvectors pins the output of Permutation with test
+// vectors.
 package permutation
 
 import (
@@ -10,7 +39,7 @@ import (
 )
 
 // Permutation generates a cryptographically secure, keyed
-// permutation on [0, ..., n-1]
+// permutation on [0, ..., n-1]. It panics if n is negative.
 func Permutation(n int, key [16]byte) []int {
 	var result []int
 	for i := 0; i < n; i++ {
@@ -0,0 +1,119 @@
+// Package permutationvectors provides canonical test vectors for package
+// permutation.
+//
+// Oracles running different library versions must agree on the permutations
+// they compute, e.g. for transmission order. Vectors pins Permutation's output
+// for a range of n and keys. The expected permutations were computed by an
+// implementation independent of libocr: AES-128-CTR keystream from OpenSSL,
+// fed through a transcription of math/rand's Int63, Uint32, int31n and
+// Shuffle. Downstream code that reimplements Permutation (e.g. in a contract
+// or another language) can check itself against Vectors too.
+//
+// The vectors never change once published.
+package permutationvectors
+
+import (
+	"fmt"
+
+	"go.uber.org/multierr"
+)
+
+// Vector is an input to Permutation together with the expected output.
+type Vector struct {
+	Name        string
+	N           int
+	Key         [16]byte
+	Permutation []int
+}
+
+// Vectors returns the canonical test vectors. The result is freshly allocated
+// on every call, so callers may modify it.
+func Vectors() []Vector {
+	return []Vector{
+		{
+			"empty",
+			0,
+			[16]byte{},
+			[]int{},
+		},
+		{
+			"1 oracle",
+			1,
+			[16]byte{},
+			[]int{
+				0,
+			},
+		},
+		{
+			"4 oracles, zero key",
+			4,
+			[16]byte{},
+			[]int{
+				0, 2, 3, 1,
+			},
+		},
+		{
+			"7 oracles",
+			7,
+			[16]byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f},
+			[]int{
+				4, 2, 3, 6, 1, 5, 0,
+			},
+		},
+		{
+			"31 oracles",
+			31,
+			[16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			[]int{
+				8, 13, 30, 6, 20, 17, 23, 2, 27, 16, 9, 7, 28, 11, 19, 12, 21,
+				4, 3, 24, 22, 26, 15, 29, 5, 1, 25, 0, 14, 10, 18,
+			},
+		},
+		{
+			"100 oracles",
+			100,
+			[16]byte{0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f},
+			[]int{
+				44, 23, 70, 3, 22, 48, 18, 7, 92, 57, 25, 19, 89, 50, 65, 82,
+				58, 55, 53, 39, 14, 69, 76, 27, 83, 60, 0, 5, 4, 52, 47, 21, 12,
+				35, 24, 33, 11, 49, 34, 96, 31, 98, 54, 64, 15, 20, 66, 32, 6,
+				88, 17, 26, 84, 38, 29, 74, 56, 59, 28, 99, 42, 2, 46, 75, 80,
+				81, 1, 87, 78, 13, 94, 37, 97, 62, 73, 43, 67, 72, 45, 77, 61,
+				40, 91, 30, 71, 10, 90, 9, 79, 51, 8, 36, 63, 16, 86, 93, 95,
+				41, 68, 85,
+			},
+		},
+	}
+}
+
+// Check runs permute on every vector and returns an error listing all vectors
+// for which permute's output isn't a permutation of [0, n) or differs from
+// the expected one. Use permutation.Permutation to check libocr itself.
+func Check(vectors []Vector, permute func(n int, key [16]byte) []int) error {
+	var err error
+	for _, v := range vectors {
+		if checkErr := checkVector(v, permute(v.N, v.Key)); checkErr != nil {
+			err = multierr.Append(err, fmt.Errorf("vector %q: %w", v.Name, checkErr))
+		}
+	}
+	return err
+}
+
+func checkVector(v Vector, pi []int) error {
+	if len(pi) != v.N {
+		return fmt.Errorf("got %v elements, expected %v", len(pi), v.N)
+	}
+	seen := make([]bool, v.N)
+	for _, p := range pi {
+		if !(0 <= p && p < v.N) || seen[p] {
+			return fmt.Errorf("%v is not a permutation of [0, %v)", pi, v.N)
+		}
+		seen[p] = true
+	}
+	for i := range pi {
+		if pi[i] != v.Permutation[i] {
+			return fmt.Errorf("got %v, expected %v", pi, v.Permutation)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package ragep2p
+
+import (
+	"sync"
+
+	"github.com/smartcontractkit/libocr/ragep2p/types"
+)
+
+// AddressOverrides holds static addresses for peers, supplied by the host
+// application, that take precedence over whatever the Discoverer has learned
+// from announcements. This is useful when discovery learns addresses that are
+// wrong from our vantage point, e.g. because of NAT hairpinning.
+//
+// AddressOverrides is safe for concurrent use. Overrides can be replaced at
+// any time with Set and take effect on the next dial.
+type AddressOverrides struct {
+	mutex     sync.RWMutex
+	addresses map[types.PeerID][]types.Address
+}
+
+func NewAddressOverrides(overrides map[types.PeerID][]types.Address) *AddressOverrides {
+	o := &AddressOverrides{}
+	o.Set(overrides)
+	return o
+}
+
+// Set replaces all overrides. Peers without an entry (or with an empty one)
+// are dialed at the addresses known to the Discoverer.
+func (o *AddressOverrides) Set(overrides map[types.PeerID][]types.Address) {
+	addresses := make(map[types.PeerID][]types.Address, len(overrides))
+	for id, addrs := range overrides {
+		if len(addrs) == 0 {
+			continue
+		}
+		addresses[id] = append([]types.Address(nil), addrs...)
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.addresses = addresses
+}
+
+// Get returns the override for peer, if any.
+func (o *AddressOverrides) Get(peer types.PeerID) ([]types.Address, bool) {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	addresses, ok := o.addresses[peer]
+	return append([]types.Address(nil), addresses...), ok
+}
+
+type overridingDiscoverer struct {
+	Discoverer
+	overrides *AddressOverrides
+}
+
+// NewOverridingDiscoverer wraps discoverer such that FindPeer returns the
+// addresses in overrides for peers that have an override.
+func NewOverridingDiscoverer(discoverer Discoverer, overrides *AddressOverrides) Discoverer {
+	return overridingDiscoverer{discoverer, overrides}
+}
+
+func (d overridingDiscoverer) FindPeer(peer types.PeerID) ([]types.Address, error) {
+	if addresses, ok := d.overrides.Get(peer); ok {
+		return addresses, nil
+	}
+	return d.Discoverer.FindPeer(peer)
+}
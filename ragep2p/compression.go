@@ -0,0 +1,219 @@
+package ragep2p
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+)
+
+// Maximum length of a CompressionConfig's Dictionary
+const MaxCompressionDictionaryLength = 1024 * 1024 // 1 MiB
+
+// CompressionConfig enables zstd compression of the messages sent over a
+// Host's connections.
+//
+// Compression is negotiated per connection: a Host only sends compressed
+// messages to a peer that has also enabled compression, and peers that predate
+// compression simply never negotiate it, so enabling it doesn't affect
+// compatibility. Compression is further opt-in per Stream, see
+// Stream.SetCompression. Messages are only sent compressed if that makes them
+// shorter.
+//
+// Compression reduces the bandwidth used by highly compressible messages, but
+// not the resources a receiver spends on them: the maximum message length and
+// the bytes rate limit of a Stream both apply to the decompressed message. A
+// receiver checks the decompressed length declared by the sender against the
+// limits, and charges it to the rate limit, before allocating any memory for
+// the message, and rejects messages whose actual decompressed length differs
+// from the declared one.
+type CompressionConfig struct {
+	// MinMessageLength is the minimum length of a message for it to be
+	// compressed. Shorter messages are always sent uncompressed, since they
+	// rarely compress well. May be zero.
+	MinMessageLength int
+
+	// DictionaryID identifies Dictionary. The dictionary is only used on a
+	// connection if both peers advertise the same DictionaryID, so all peers
+	// using a dictionary must agree on the DictionaryID of its content. Must
+	// be zero if and only if Dictionary is empty.
+	DictionaryID uint32
+
+	// Dictionary is raw zstd dictionary content, e.g. typical messages or
+	// common substrings thereof. Dictionaries greatly improve the compression
+	// of short messages. May be empty, in which case no dictionary is used.
+	Dictionary []byte
+}
+
+func (c *CompressionConfig) validate() error {
+	if c.MinMessageLength < 0 {
+		return fmt.Errorf("MinMessageLength (%v) must not be negative", c.MinMessageLength)
+	}
+	if (c.DictionaryID == 0) != (len(c.Dictionary) == 0) {
+		return fmt.Errorf("DictionaryID (%v) must be zero if and only if Dictionary is empty (length %v)", c.DictionaryID, len(c.Dictionary))
+	}
+	if len(c.Dictionary) > MaxCompressionDictionaryLength {
+		return fmt.Errorf("Dictionary is longer (%v) than MaxCompressionDictionaryLength (%v)", len(c.Dictionary), MaxCompressionDictionaryLength)
+	}
+	return nil
+}
+
+// The compression capabilities of a Host are negotiated with TLS ALPN
+// (RFC 7301) during the handshake: a Host that enables compression offers the
+// application protocols below, most preferred first, and the server of the
+// connection picks the first of its own protocols that the client offers, so
+// both sides arrive at the same choice. Hosts that don't enable compression,
+// including those that predate it, offer no protocols and ignore those offered
+// to them, so no protocol is negotiated and compression isn't used. Since both
+// sides of a connection that enable compression offer
+// compressionALPNProtocol, the handshake never fails for lack of a common
+// protocol.
+const compressionALPNProtocol = "ragep2p+zstd"
+
+func compressionWithDictionaryALPNProtocol(dictionaryID uint32) string {
+	return fmt.Sprintf("%s+dict=%d", compressionALPNProtocol, dictionaryID)
+}
+
+// hostCompression holds the zstd encoders and decoder shared by all
+// connections of a Host. zstd.Encoder.EncodeAll and zstd.Decoder.DecodeAll are
+// safe for concurrent use.
+type hostCompression struct {
+	config CompressionConfig
+
+	encoder *zstd.Encoder
+	// nil if config has no dictionary
+	dictionaryEncoder *zstd.Encoder
+	// knows config's dictionary, if any, and can thus decode messages
+	// compressed with or without it
+	decoder *zstd.Decoder
+}
+
+func newHostCompression(config CompressionConfig) (*hostCompression, error) {
+	encoderOptions := []zstd.EOption{
+		// TLS already protects message integrity
+		zstd.WithEncoderCRC(false),
+		zstd.WithEncoderLevel(zstd.SpeedDefault),
+	}
+	encoder, err := zstd.NewWriter(nil, encoderOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+
+	var dictionaryEncoder *zstd.Encoder
+	decoderOptions := []zstd.DOption{
+		// Output is limited to the capacity of the buffer passed to DecodeAll,
+		// which we size according to the declared length of the message.
+		zstd.WithDecodeAllCapLimit(true),
+		zstd.WithDecoderMaxMemory(MaxMessageLength),
+	}
+	if len(config.Dictionary) != 0 {
+		dictionaryEncoder, err = zstd.NewWriter(nil, append(encoderOptions, zstd.WithEncoderDictRaw(config.DictionaryID, config.Dictionary))...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd encoder with dictionary: %w", err)
+		}
+		decoderOptions = append(decoderOptions, zstd.WithDecoderDictRaw(config.DictionaryID, config.Dictionary))
+	}
+
+	decoder, err := zstd.NewReader(nil, decoderOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+
+	return &hostCompression{
+		config,
+		encoder,
+		dictionaryEncoder,
+		decoder,
+	}, nil
+}
+
+func (hc *hostCompression) close() {
+	hc.decoder.Close()
+}
+
+// nextProtos returns the ALPN protocols to offer in TLS handshakes, see
+// compressionALPNProtocol. The result is nil if compression is disabled.
+func (hc *hostCompression) nextProtos() []string {
+	if hc == nil {
+		return nil
+	}
+	if hc.dictionaryEncoder != nil {
+		return []string{compressionWithDictionaryALPNProtocol(hc.config.DictionaryID), compressionALPNProtocol}
+	}
+	return []string{compressionALPNProtocol}
+}
+
+// negotiate returns the compression to use on a connection whose TLS handshake
+// negotiated the ALPN protocol negotiatedProtocol. The result is nil if
+// compression isn't used on the connection.
+func (hc *hostCompression) negotiate(negotiatedProtocol string, logger loghelper.LoggerWithContext) *connCompression {
+	if hc == nil {
+		return nil
+	}
+	var useDictionary bool
+	switch {
+	case hc.dictionaryEncoder != nil && negotiatedProtocol == compressionWithDictionaryALPNProtocol(hc.config.DictionaryID):
+		useDictionary = true
+	case negotiatedProtocol == compressionALPNProtocol:
+		useDictionary = false
+	default:
+		logger.Debug("Peer did not negotiate compression, not using compression", commontypes.LogFields{
+			"negotiatedProtocol": negotiatedProtocol,
+		})
+		return nil
+	}
+	encoder := hc.encoder
+	if useDictionary {
+		encoder = hc.dictionaryEncoder
+	}
+	logger.Debug("Negotiated compression", commontypes.LogFields{
+		"useDictionary":     useDictionary,
+		"localDictionaryID": hc.config.DictionaryID,
+	})
+	return &connCompression{
+		hc.config.MinMessageLength,
+		encoder,
+		hc.decoder,
+	}
+}
+
+// connCompression is the compression negotiated on a connection
+type connCompression struct {
+	minMessageLength int
+	encoder          *zstd.Encoder
+	decoder          *zstd.Decoder
+}
+
+// The payload of a frameTypeCompressedData frame consists of the decompressed
+// length of the message (big-endian uint32), followed by a zstd frame.
+const compressedPayloadPrefixSize = 4
+
+// compress returns the payload of a frameTypeCompressedData frame for data.
+// ok is false if data should be sent uncompressed instead.
+func (cc *connCompression) compress(data []byte) (payload []byte, ok bool) {
+	if len(data) < cc.minMessageLength {
+		return nil, false
+	}
+	payload = make([]byte, compressedPayloadPrefixSize, compressedPayloadPrefixSize+len(data))
+	binary.BigEndian.PutUint32(payload, uint32(len(data)))
+	payload = cc.encoder.EncodeAll(data, payload)
+	if len(payload) >= len(data) {
+		return nil, false
+	}
+	return payload, true
+}
+
+// decompress decompresses compressed, which must decompress to exactly
+// decompressedLength bytes.
+func (cc *connCompression) decompress(compressed []byte, decompressedLength int) ([]byte, error) {
+	data, err := cc.decoder.DecodeAll(compressed, make([]byte, 0, decompressedLength))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != decompressedLength {
+		return nil, fmt.Errorf("message decompressed to %v bytes, but sender declared %v bytes", len(data), decompressedLength)
+	}
+	return data, nil
+}
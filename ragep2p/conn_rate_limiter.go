@@ -50,6 +50,18 @@ func (crl *connRateLimiter) RemoveStream(messagesLimit TokenBucketParams, bytesL
 	crl.addRemoveStream(false, messagesLimit, bytesLimit)
 }
 
+// Saturation returns the fraction of the connection-level token bucket
+// that is currently used up.
+func (crl *connRateLimiter) Saturation() float64 {
+	crl.mutex.Lock()
+	defer crl.mutex.Unlock()
+
+	if crl.infiniteRate {
+		return 0
+	}
+	return saturation(crl.limiter)
+}
+
 func (crl *connRateLimiter) AddTokens(n uint32) {
 	crl.mutex.Lock()
 	defer crl.mutex.Unlock()
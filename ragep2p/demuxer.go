@@ -34,6 +34,19 @@ type demuxerStream struct {
 	maxMessageSize  int
 	messagesLimiter ratelimit.TokenBucket
 	bytesLimiter    ratelimit.TokenBucket
+	stats           demuxerStreamStats
+}
+
+type demuxerStreamStats struct {
+	received           uint64
+	bytesReceived      uint64
+	droppedRateLimited uint64
+	droppedOverflow    uint64
+
+	// only populated by Stats
+	messagesLimiterSaturation float64
+	bytesLimiterSaturation    float64
+	queued                    int
 }
 
 type demuxer struct {
@@ -50,12 +63,30 @@ func newDemuxer() *demuxer {
 
 func makeRateLimiter(params TokenBucketParams) ratelimit.TokenBucket {
 	tb := ratelimit.TokenBucket{}
-	tb.SetRate(ratelimit.MillitokensPerSecond(math.Ceil(params.Rate * 1000)))
-	tb.SetCapacity(params.Capacity)
+	setRateLimiter(&tb, params)
 	tb.AddTokens(params.Capacity)
 	return tb
 }
 
+// saturation returns the fraction of tb's capacity that is currently used up,
+// i.e. 0 for a full bucket and 1 for an empty one.
+func saturation(tb *ratelimit.TokenBucket) float64 {
+	capacity := tb.Capacity()
+	if capacity == 0 {
+		return 0
+	}
+	tokens := tb.Tokens()
+	if tokens >= capacity {
+		return 0
+	}
+	return 1 - float64(tokens)/float64(capacity)
+}
+
+func setRateLimiter(tb *ratelimit.TokenBucket, params TokenBucketParams) {
+	tb.SetRate(ratelimit.MillitokensPerSecond(math.Ceil(params.Rate * 1000)))
+	tb.SetCapacity(params.Capacity)
+}
+
 func (d *demuxer) AddStream(
 	sid streamID,
 	incomingBufferSize int,
@@ -76,10 +107,27 @@ func (d *demuxer) AddStream(
 		maxMessageSize,
 		makeRateLimiter(messagesLimit),
 		makeRateLimiter(bytesLimit),
+		demuxerStreamStats{},
 	}
 	return true
 }
 
+// SetRateLimits replaces the rate limiters of the stream, retaining as many of
+// its current tokens as fit into the new capacities.
+func (d *demuxer) SetRateLimits(sid streamID, messagesLimit TokenBucketParams, bytesLimit TokenBucketParams) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	s, ok := d.streams[sid]
+	if !ok {
+		return false
+	}
+
+	setRateLimiter(&s.messagesLimiter, messagesLimit)
+	setRateLimiter(&s.bytesLimiter, bytesLimit)
+	return true
+}
+
 func (d *demuxer) RemoveStream(sid streamID) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
@@ -87,6 +135,10 @@ func (d *demuxer) RemoveStream(sid streamID) {
 	delete(d.streams, sid)
 }
 
+// ShouldPush checks whether a message of the given length may be pushed to the
+// stream. For compressed messages, size is the decompressed length, so that
+// the limits bound what the receiver has to allocate rather than what went
+// over the wire.
 func (d *demuxer) ShouldPush(sid streamID, size int) shouldPushResult {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
@@ -104,10 +156,12 @@ func (d *demuxer) ShouldPush(sid streamID, size int) shouldPushResult {
 	bytesLimiterAllow := s.bytesLimiter.RemoveTokens(uint32(size))
 
 	if !messagesLimiterAllow {
+		s.stats.droppedRateLimited++
 		return shouldPushResultMessagesLimitExceeded
 	}
 
 	if !bytesLimiterAllow {
+		s.stats.droppedRateLimited++
 		return shouldPushResultBytesLimitExceeded
 	}
 
@@ -124,9 +178,12 @@ func (d *demuxer) PushMessage(sid streamID, msg []byte) pushResult {
 	}
 
 	var result pushResult
+	s.stats.received++
+	s.stats.bytesReceived += uint64(len(msg))
 	if s.buffer.Push(msg) == nil {
 		result = pushResultSuccess
 	} else {
+		s.stats.droppedOverflow++
 		result = pushResultDropped
 	}
 
@@ -170,3 +227,19 @@ func (d *demuxer) SignalPending(sid streamID) <-chan struct{} {
 
 	return s.chSignal
 }
+
+func (d *demuxer) Stats(sid streamID) (demuxerStreamStats, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	s, ok := d.streams[sid]
+	if !ok {
+		return demuxerStreamStats{}, false
+	}
+
+	stats := s.stats
+	stats.messagesLimiterSaturation = saturation(&s.messagesLimiter)
+	stats.bytesLimiterSaturation = saturation(&s.bytesLimiter)
+	stats.queued = s.buffer.Len()
+	return stats, true
+}
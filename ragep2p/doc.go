@@ -46,7 +46,9 @@
 // We allocate a buffer for each message received. In principle, this could allo
 // an adversary to force a recipient to run out of memory. To defend against
 // this, we put limits on the length of messages and rate limit messages,
-// thereby also limiting adversarially-controlled allocations.
+// thereby also limiting adversarially-controlled allocations. For compressed
+// messages (see CompressionConfig), the limits apply to the decompressed length
+// declared by the sender, which we check before decompressing.
 //
 // # Security
 //
@@ -19,6 +19,9 @@ const (
 	frameTypeOpen
 	frameTypeClose
 	frameTypeData
+	// only sent on connections that have negotiated compression, see
+	// CompressionConfig
+	frameTypeCompressedData
 )
 
 type frameHeader struct {
@@ -46,6 +49,7 @@ func decodeFrameHeader(encoded []byte) (frameHeader, error) {
 	case frameTypeOpen:
 	case frameTypeClose:
 	case frameTypeData:
+	case frameTypeCompressedData:
 	default:
 		return frameHeader{}, errUnknownFrameType
 	}
@@ -25,6 +25,11 @@ func (rb *MessageBuffer) Peek() []byte {
 	}
 }
 
+// Len returns the number of items in the buffer
+func (rb *MessageBuffer) Len() int {
+	return rb.length
+}
+
 // Pop front item
 func (rb *MessageBuffer) Pop() []byte {
 	result := rb.Peek()
@@ -6,15 +6,17 @@ import (
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"math/big"
 )
 
 // Generates a minimal certificate (that wouldn't be considered valid outside this telemetry networking protocol)
-// from an Ed25519 private key.
-func NewMinimalX509CertFromPrivateKey(sk ed25519.PrivateKey) tls.Certificate {
+// from an Ed25519 private key. commonName becomes the subject's common name and may be empty.
+func NewMinimalX509CertFromPrivateKey(sk ed25519.PrivateKey, commonName string) tls.Certificate {
 	template := x509.Certificate{
 		SerialNumber: big.NewInt(0), // serial number must be set, so we set it to 0
+		Subject:      pkix.Name{CommonName: commonName},
 	}
 
 	encodedCert, err := x509.CreateCertificate(rand.Reader, &template, &template, sk.Public(), sk)
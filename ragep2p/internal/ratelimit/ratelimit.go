@@ -113,3 +113,13 @@ func (tb *TokenBucket) SetCapacity(capacity uint32) {
 func (tb *TokenBucket) Capacity() uint32 {
 	return tb.capacity
 }
+
+// Returns the number of whole tokens currently in the bucket.
+func (tb *TokenBucket) Tokens() uint32 {
+	tb.update(time.Now())
+	tokens := tb.nanotokens / 1_000_000_000
+	if tokens > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(tokens)
+}
@@ -0,0 +1,52 @@
+package ragep2p
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/metricshelper"
+)
+
+// hostMetrics holds the prometheus metrics of a Host. They are registered
+// when the Host is started and unregistered when it is closed.
+type hostMetrics struct {
+	registerer *metricshelper.Registerer
+
+	connectedPeers         prometheus.Gauge
+	connectionsEstablished *prometheus.CounterVec
+}
+
+func newHostMetrics(registerer prometheus.Registerer, logger commontypes.Logger) *hostMetrics {
+	return &hostMetrics{
+		metricshelper.NewRegisterer(registerer, logger),
+
+		prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ragep2p_connected_peers",
+			Help: "Number of peers with which the host has an authenticated connection",
+		}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ragep2p_connections_established_total",
+			Help: "Number of authenticated connections established, by direction (in or out)",
+		}, []string{"direction"}),
+	}
+}
+
+func (m *hostMetrics) register() {
+	m.registerer.Register(m.connectedPeers, m.connectionsEstablished)
+}
+
+func (m *hostMetrics) close() {
+	m.registerer.Close()
+}
+
+func (m *hostMetrics) connectionEstablished(incoming bool) {
+	direction := "out"
+	if incoming {
+		direction = "in"
+	}
+	m.connectionsEstablished.WithLabelValues(direction).Inc()
+	m.connectedPeers.Inc()
+}
+
+func (m *hostMetrics) connectionTerminated() {
+	m.connectedPeers.Dec()
+}
@@ -0,0 +1,313 @@
+package ragep2p
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/ragep2p/internal/mtls"
+	"github.com/smartcontractkit/libocr/ragep2p/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+// Transport identifies how a Host connects to another peer.
+type Transport int
+
+const (
+	TransportTCP Transport = iota
+	TransportQUIC
+)
+
+func (t Transport) String() string {
+	switch t {
+	case TransportTCP:
+		return "tcp"
+	case TransportQUIC:
+		return "quic"
+	}
+	return fmt.Sprintf("Transport(%d)", int(t))
+}
+
+// QUICConfig enables the QUIC transport as an alternative to TCP. A Host with
+// a QUICConfig additionally listens for QUIC connections on the UDP ports
+// corresponding to its (TCP) listen addresses, i.e. a peer's announced
+// addresses are used for both transports.
+//
+// A QUIC connection carries a single QUIC stream, over which the Host runs
+// exactly the same knock and mutual TLS 1.3 handshake as over a TCP
+// connection. Hence, QUIC connections have the same authentication guarantees
+// as TCP connections. However, a QUIC listener completes the (outer) QUIC
+// handshake with anyone, so unlike with TCP, a port scan can tell that a
+// QUIC-enabled Host is running some QUIC service.
+//
+// QUIC's loss recovery doesn't suffer from head-of-line blocking on packet
+// loss in the way TCP does and reconnections use 0-RTT, which both reduce
+// latency on long-haul and lossy links. QUIC also sidesteps middleboxes that
+// interfere with long-lived TCP connections.
+type QUICConfig struct {
+	// Transports selects the transport used to dial each peer. Peers without
+	// an entry are dialed over TCP. Incoming connections are accepted over
+	// both transports regardless.
+	Transports map[types.PeerID]Transport
+
+	// If DisableTCPFallback is false, a peer that cannot be dialed over QUIC,
+	// e.g. because it hasn't enabled QUIC or UDP traffic is blocked on the
+	// path, is dialed over TCP right after. The transport is thus negotiated
+	// anew on every dial.
+	DisableTCPFallback bool
+
+	// KeepAlivePeriod is the interval at which keep-alive packets are sent on
+	// otherwise idle connections, which prevents NATs and firewalls from
+	// dropping the connection's UDP mapping. Zero means DefaultQUICKeepAlivePeriod.
+	KeepAlivePeriod time.Duration
+}
+
+const DefaultQUICKeepAlivePeriod = 15 * time.Second
+
+// ragep2p runs its own protocol over the single stream of every QUIC
+// connection. The version of that protocol is advertised in the inner TLS
+// handshake, see versionCommonNamePrefix.
+const quicNextProto = "ragep2p"
+
+func (c *QUICConfig) validate() error {
+	for peerID, transport := range c.Transports {
+		if !(transport == TransportTCP || transport == TransportQUIC) {
+			return fmt.Errorf("invalid transport %v for peer %v", transport, peerID)
+		}
+	}
+	if c.KeepAlivePeriod < 0 {
+		return fmt.Errorf("KeepAlivePeriod (%v) must be non-negative", c.KeepAlivePeriod)
+	}
+	return nil
+}
+
+func (c *QUICConfig) transport(other types.PeerID) Transport {
+	if transport, ok := c.Transports[other]; ok {
+		return transport
+	}
+	return TransportTCP
+}
+
+func (c *QUICConfig) quicConfig() *quic.Config {
+	keepAlivePeriod := c.KeepAlivePeriod
+	if keepAlivePeriod == 0 {
+		keepAlivePeriod = DefaultQUICKeepAlivePeriod
+	}
+	return &quic.Config{
+		HandshakeIdleTimeout: netTimeout,
+		KeepAlivePeriod:      keepAlivePeriod,
+		// The dialer opens a single stream, see quicDialer.dial
+		MaxIncomingStreams:    1,
+		MaxIncomingUniStreams: -1,
+		Allow0RTT:             true,
+	}
+}
+
+// quicTransports holds the UDP sockets of a Host. Outgoing connections are
+// dialed from the first socket, so that they originate from an announced
+// port, which plays nicer with NATs.
+type quicTransports struct {
+	config       *QUICConfig
+	tlsCert      tls.Certificate
+	sessionCache tls.ClientSessionCache
+
+	mutex      sync.Mutex
+	transports []*quic.Transport
+}
+
+func newQUICTransports(config *QUICConfig, tlsCert tls.Certificate) *quicTransports {
+	return &quicTransports{
+		config,
+		tlsCert,
+		tls.NewLRUClientSessionCache(0),
+
+		sync.Mutex{},
+		nil,
+	}
+}
+
+// listen starts listening for QUIC connections on the UDP port of address.
+func (qt *quicTransports) listen(address string, logger loghelper.LoggerWithContext) (net.Listener, error) {
+	udpConn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	transport := &quic.Transport{Conn: udpConn}
+
+	tlsConfig := newTLSConfig(qt.tlsCert, nil)
+	tlsConfig.NextProtos = []string{quicNextProto}
+	ln, err := transport.ListenEarly(tlsConfig, qt.config.quicConfig())
+	if err != nil {
+		_ = transport.Close()
+		_ = udpConn.Close()
+		return nil, err
+	}
+
+	qt.mutex.Lock()
+	qt.transports = append(qt.transports, transport)
+	qt.mutex.Unlock()
+
+	return newQUICListener(ln, transport, udpConn, logger), nil
+}
+
+// dial dials other at address and opens the connection's single stream.
+func (qt *quicTransports) dial(ctx context.Context, other types.PeerID, address string) (net.Conn, error) {
+	qt.mutex.Lock()
+	var transport *quic.Transport
+	if len(qt.transports) > 0 {
+		transport = qt.transports[0]
+	}
+	qt.mutex.Unlock()
+	if transport == nil {
+		return nil, fmt.Errorf("not listening for QUIC connections")
+	}
+
+	udpAddr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	// The inner TLS handshake authenticates other, we check the outer one
+	// only to fail early when talking to an impostor.
+	tlsConfig := newTLSConfig(qt.tlsCert, mtls.VerifyCertMatchesPubKey(other))
+	tlsConfig.NextProtos = []string{quicNextProto}
+	tlsConfig.ClientSessionCache = qt.sessionCache
+
+	conn, err := transport.DialEarly(ctx, udpAddr, tlsConfig, qt.config.quicConfig())
+	if err != nil {
+		return nil, err
+	}
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		_ = conn.CloseWithError(0, "")
+		return nil, err
+	}
+	return &quicStreamConn{stream, conn}, nil
+}
+
+// quicStreamConn exposes the single stream of a QUIC connection as a
+// net.Conn. Closing it closes the entire QUIC connection.
+type quicStreamConn struct {
+	quic.Stream
+	conn quic.Connection
+}
+
+var _ net.Conn = &quicStreamConn{}
+
+func (c *quicStreamConn) Close() error {
+	c.Stream.CancelRead(0)
+	return c.conn.CloseWithError(0, "")
+}
+
+func (c *quicStreamConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *quicStreamConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// quicListener is a net.Listener whose Accept returns the single stream of
+// each incoming QUIC connection.
+type quicListener struct {
+	ln        *quic.EarlyListener
+	transport *quic.Transport
+	udpConn   net.PacketConn
+	logger    loghelper.LoggerWithContext
+
+	subprocesses subprocesses.Subprocesses
+	ctx          context.Context
+	cancel       context.CancelFunc
+	closeOnce    sync.Once
+	chConns      chan net.Conn
+}
+
+var _ net.Listener = &quicListener{}
+
+func newQUICListener(ln *quic.EarlyListener, transport *quic.Transport, udpConn net.PacketConn, logger loghelper.LoggerWithContext) *quicListener {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &quicListener{
+		ln,
+		transport,
+		udpConn,
+		logger,
+
+		subprocesses.Subprocesses{},
+		ctx,
+		cancel,
+		sync.Once{},
+		make(chan net.Conn),
+	}
+	l.subprocesses.Go(l.acceptLoop)
+	return l
+}
+
+func (l *quicListener) acceptLoop() {
+	for {
+		conn, err := l.ln.Accept(l.ctx)
+		if err != nil {
+			if l.ctx.Err() == nil {
+				l.logger.Info("quicListener: exiting acceptLoop due to error while Accepting", commontypes.LogFields{"error": err})
+			}
+			return
+		}
+		l.subprocesses.Go(func() {
+			l.acceptStream(conn)
+		})
+	}
+}
+
+func (l *quicListener) acceptStream(conn quic.EarlyConnection) {
+	ctx, cancel := context.WithTimeout(l.ctx, netTimeout)
+	defer cancel()
+	stream, err := conn.AcceptStream(ctx)
+	if err != nil {
+		l.logger.Debug("quicListener: failed to accept stream, closing connection", commontypes.LogFields{
+			"error":      err,
+			"remoteAddr": conn.RemoteAddr(),
+		})
+		_ = conn.CloseWithError(0, "")
+		return
+	}
+
+	select {
+	case l.chConns <- &quicStreamConn{stream, conn}:
+	case <-l.ctx.Done():
+		_ = conn.CloseWithError(0, "")
+	}
+}
+
+func (l *quicListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.chConns:
+		return conn, nil
+	case <-l.ctx.Done():
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *quicListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		l.cancel()
+		err = l.ln.Close()
+		l.subprocesses.Wait()
+		if errTransport := l.transport.Close(); err == nil {
+			err = errTransport
+		}
+		if errUDPConn := l.udpConn.Close(); err == nil {
+			err = errUDPConn
+		}
+	})
+	return err
+}
+
+func (l *quicListener) Addr() net.Addr {
+	return l.ln.Addr()
+}
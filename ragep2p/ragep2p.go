@@ -4,16 +4,19 @@ import (
 	"context"
 	"crypto/ed25519"
 	"crypto/tls"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"io"
-	"math/rand"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/smartcontractkit/libocr/backoff"
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/ragep2p/internal/msgbuf"
 
@@ -85,6 +88,16 @@ type peerStreamCloseResponse struct {
 	err              error
 }
 
+type peerStreamUpdateRequest struct {
+	streamID      streamID
+	messagesLimit TokenBucketParams
+	bytesLimit    TokenBucketParams
+}
+
+type peerStreamUpdateResponse struct {
+	err error
+}
+
 type newConnNotification struct {
 	chConnTerminated <-chan struct{}
 }
@@ -98,6 +111,8 @@ type streamStateNotification struct {
 type streamIDAndData struct {
 	StreamID streamID
 	Data     []byte
+	// whether Data may be compressed, see Stream.SetCompression
+	Compress bool
 }
 
 type peerConnLifeCycle struct {
@@ -133,12 +148,74 @@ type peer struct {
 
 	chStreamCloseRequest  chan<- peerStreamCloseRequest
 	chStreamCloseResponse <-chan peerStreamCloseResponse
+
+	chStreamUpdateRequest  chan<- peerStreamUpdateRequest
+	chStreamUpdateResponse <-chan peerStreamUpdateResponse
+
+	// version advertised on the most recently established connection, nil
+	// if none was advertised
+	version atomic.Pointer[PeerVersion]
+
+	versionMismatchTaperMu sync.Mutex
+	versionMismatchTaper   loghelper.LogarithmicTaper
+
+	// whether there currently is an authenticated connection with other
+	connected atomic.Bool
+
+	// open streams with other, guarded by Host.peersMu
+	streams map[streamID]*Stream
 }
 
 type HostConfig struct {
 	// DurationBetweenDials is the minimum duration between two dials. It is
 	// not the exact duration because of jitter.
 	DurationBetweenDials time.Duration
+
+	// Dialer is used to establish outgoing connections to other peers. May be
+	// nil, in which case a net.Dialer with a timeout of DurationBetweenDials
+	// is used.
+	Dialer Dialer
+
+	// ListenConfig is used to listen for incoming connections from other
+	// peers. May be nil, in which case a zero net.ListenConfig is used.
+	ListenConfig ListenConfig
+
+	// MetricsRegisterer is used to register prometheus metrics about peer
+	// connectivity while the Host is open. May be nil, in which case metrics
+	// aren't exported.
+	MetricsRegisterer prometheus.Registerer
+
+	// QUIC enables the QUIC transport, see QUICConfig. May be nil, in which
+	// case only TCP is used. QUIC connections don't use Dialer and
+	// ListenConfig.
+	QUIC *QUICConfig
+
+	// Compression enables zstd compression of messages, see
+	// CompressionConfig. May be nil, in which case messages are never
+	// compressed.
+	Compression *CompressionConfig
+}
+
+// Dialer establishes outgoing network connections. *net.Dialer implements
+// this interface. Hosts can supply their own implementation, e.g. to wrap
+// connections with instrumentation or to tunnel them through some other
+// network.
+//
+// The Host applies its own timeout to ctx, implementations needn't enforce a
+// timeout of their own. The Host always authenticates the remote peer over the
+// returned connection, so implementations needn't do so either.
+//
+// All its functions should be thread-safe.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// ListenConfig creates network listeners for incoming connections.
+// *net.ListenConfig implements this interface.
+//
+// All its functions should be thread-safe.
+type ListenConfig interface {
+	Listen(ctx context.Context, network, address string) (net.Listener, error)
 }
 
 // A Host allows users to establish Streams with other peers identified by their
@@ -153,6 +230,12 @@ type Host struct {
 	discoverer      Discoverer
 	logger          loghelper.LoggerWithContext
 
+	metrics *hostMetrics
+	// nil if QUIC is disabled
+	quic *quicTransports
+	// nil if compression is disabled
+	compression *hostCompression
+
 	// Derived from secretKey
 	id      types.PeerID
 	tlsCert tls.Certificate
@@ -185,22 +268,54 @@ func NewHost(
 		return nil, fmt.Errorf("no listen addresses provided")
 	}
 
+	if config.QUIC != nil {
+		if err := config.QUIC.validate(); err != nil {
+			return nil, fmt.Errorf("invalid QUICConfig: %w", err)
+		}
+	}
+
+	if config.Compression != nil {
+		if err := config.Compression.validate(); err != nil {
+			return nil, fmt.Errorf("invalid CompressionConfig: %w", err)
+		}
+	}
+
 	id, err := mtls.StaticallySizedEd25519PublicKey(secretKey.Public())
 	if err != nil {
 		return nil, err
 	}
 
+	var compression *hostCompression
+	if config.Compression != nil {
+		compression, err = newHostCompression(*config.Compression)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tlsCert := mtls.NewMinimalX509CertFromPrivateKey(secretKey, versionCommonName())
+	var quicTransports *quicTransports
+	if config.QUIC != nil {
+		quicTransports = newQUICTransports(config.QUIC, tlsCert)
+	}
+
+	// peerID might already be set to the same value if we are managed, but we don't take any chances
+	hostLogger := loghelper.MakeRootLoggerWithContext(logger).MakeChild(commontypes.LogFields{"id": "ragep2p", "peerID": types.PeerID(id)})
+
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Host{
 		config,
 		secretKey,
 		listenAddresses,
 		discoverer,
-		// peerID might already be set to the same value if we are managed, but we don't take any chances
-		loghelper.MakeRootLoggerWithContext(logger).MakeChild(commontypes.LogFields{"id": "ragep2p", "peerID": types.PeerID(id)}),
+		hostLogger,
+
+		newHostMetrics(config.MetricsRegisterer, hostLogger),
+		quicTransports,
+		compression,
 
 		id,
-		mtls.NewMinimalX509CertFromPrivateKey(secretKey),
+		tlsCert,
 
 		sync.Mutex{},
 		hostStatePending,
@@ -230,19 +345,37 @@ func (ho *Host) Start() error {
 		return fmt.Errorf("cannot Start() host that has already been started")
 	}
 	ho.state = hostStateOpen
+	ho.metrics.register()
 
-	ho.subprocesses.Go(func() {
-		ho.dialLoop()
-	})
+	var listenConfig ListenConfig = &net.ListenConfig{}
+	if ho.config.ListenConfig != nil {
+		listenConfig = ho.config.ListenConfig
+	}
 	for _, addr := range ho.listenAddresses {
-		ln, err := net.Listen("tcp", addr)
+		ln, err := listenConfig.Listen(ho.ctx, "tcp", addr)
 		if err != nil {
-			return fmt.Errorf("net.Listen(%q) failed: %w", addr, err)
+			return fmt.Errorf("Listen(%q) failed: %w", addr, err)
 		}
 		ho.subprocesses.Go(func() {
 			ho.listenLoop(ln)
 		})
 	}
+	if ho.quic != nil {
+		for _, addr := range ho.listenAddresses {
+			ln, err := ho.quic.listen(addr, ho.logger)
+			if err != nil {
+				return fmt.Errorf("failed to listen for QUIC connections on %q: %w", addr, err)
+			}
+			ho.subprocesses.Go(func() {
+				ho.listenLoop(ln)
+			})
+		}
+	}
+	// Start dialing only once we're listening, since QUIC connections are
+	// dialed from a listening socket.
+	ho.subprocesses.Go(func() {
+		ho.dialLoop()
+	})
 
 	err := ho.discoverer.Start(ho, ho.secretKey, ho.logger)
 	if err != nil {
@@ -283,6 +416,9 @@ func (ho *Host) findOrCreatePeer(other types.PeerID) *peer {
 		chStreamCloseRequest := make(chan peerStreamCloseRequest)
 		chStreamCloseResponse := make(chan peerStreamCloseResponse)
 
+		chStreamUpdateRequest := make(chan peerStreamUpdateRequest)
+		chStreamUpdateResponse := make(chan peerStreamUpdateResponse)
+
 		incomingConnsLimiter := ratelimit.NewTokenBucket(incomingConnsRateLimit(ho.config.DurationBetweenDials), 4, true)
 
 		connRateLimiter := newConnRateLimiter(logger)
@@ -319,6 +455,17 @@ func (ho *Host) findOrCreatePeer(other types.PeerID) *peer {
 
 			chStreamCloseRequest,
 			chStreamCloseResponse,
+
+			chStreamUpdateRequest,
+			chStreamUpdateResponse,
+
+			atomic.Pointer[PeerVersion]{},
+
+			sync.Mutex{},
+			loghelper.LogarithmicTaper{},
+			atomic.Bool{},
+
+			map[streamID]*Stream{},
 		}
 		ho.peers[other] = &p
 
@@ -335,6 +482,8 @@ func (ho *Host) findOrCreatePeer(other types.PeerID) *peer {
 				chStreamOpenResponse,
 				chStreamCloseRequest,
 				chStreamCloseResponse,
+				chStreamUpdateRequest,
+				chStreamUpdateResponse,
 				logger,
 			)
 		})
@@ -354,6 +503,8 @@ func peerLoop(
 	chStreamOpenResponse chan<- peerStreamOpenResponse,
 	chStreamCloseRequest <-chan peerStreamCloseRequest,
 	chStreamCloseResponse chan<- peerStreamCloseResponse,
+	chStreamUpdateRequest <-chan peerStreamUpdateRequest,
+	chStreamUpdateResponse chan<- peerStreamUpdateResponse,
 	logger loghelper.LoggerWithContext,
 ) {
 	defer close(chDone)
@@ -520,6 +671,19 @@ func peerLoop(
 				}
 			}
 
+		case req := <-chStreamUpdateRequest:
+			if s, ok := streams[req.streamID]; ok {
+				connRateLimiter.RemoveStream(s.messagesLimit, s.bytesLimit)
+				connRateLimiter.AddStream(req.messagesLimit, req.bytesLimit)
+				demux.SetRateLimits(req.streamID, req.messagesLimit, req.bytesLimit)
+				s.messagesLimit = req.messagesLimit
+				s.bytesLimit = req.bytesLimit
+				streams[req.streamID] = s
+				chStreamUpdateResponse <- peerStreamUpdateResponse{nil}
+			} else {
+				chStreamUpdateResponse <- peerStreamUpdateResponse{fmt.Errorf("stream not found")}
+			}
+
 		case <-ctx.Done():
 			return
 		}
@@ -541,6 +705,10 @@ func (ho *Host) Close() error {
 	ho.state = hostStateClosed
 	ho.cancel()
 	ho.subprocesses.Wait()
+	ho.metrics.close()
+	if ho.compression != nil {
+		ho.compression.close()
+	}
 	ho.logger.Info("Host exiting", nil)
 	if err != nil {
 		return fmt.Errorf("failed to close discoverer: %w", err)
@@ -552,19 +720,42 @@ func (ho *Host) ID() types.PeerID {
 	return ho.id
 }
 
+// Maximum factor by which dial back-off (see Stream.SetDialBackoff) stretches
+// the time between dials.
+const maxDialBackoffFactor = 32
+
 func (ho *Host) dialLoop() {
 	type dialState struct {
 		next uint
+		// remaining dial loop iterations to skip due to dial back-off
+		skip uint
+		// factor by which dial back-off stretches the time until the next
+		// dial, one if there is no back-off
+		backoffFactor uint
 	}
 	dialStates := make(map[types.PeerID]*dialState)
+	// We're not retrying a failed operation here, dials happen periodically
+	// regardless of their outcome. Hence the constant schedule.
+	dialSchedule := backoff.Constant(ho.config.DurationBetweenDials, 1)
+	ho.logger.Debug("Host.dialLoop starting", commontypes.LogFields{
+		"dialSchedule": dialSchedule.String(),
+	})
 	for {
 		var dialProcesses subprocesses.Subprocesses
 		ho.peersMu.Lock()
 		peers := make([]*peer, 0, len(ho.peers))
+		backoffs := make(map[types.PeerID]bool, len(ho.peers))
 		for pid, p := range ho.peers {
 			peers = append(peers, p)
 			if dialStates[pid] == nil {
-				dialStates[pid] = &dialState{0}
+				dialStates[pid] = &dialState{0, 0, 1}
+			}
+			backoffs[pid] = len(p.streams) > 0
+			for _, st := range p.streams {
+				if !st.dialBackoff.Load() {
+					backoffs[pid] = false
+					break
+				}
 			}
 		}
 		// Some peers may have been discarded, garbage collect dial states
@@ -577,17 +768,32 @@ func (ho *Host) dialLoop() {
 		for _, p := range peers {
 			p := p // copy for goroutine
 			ds := dialStates[p.other]
+			backoff := backoffs[p.other]
 			dialProcesses.Go(func() {
 				p.connLifeCycleMu.Lock()
 				chConnTerminated := p.connLifeCycle.chConnTerminated
 				p.connLifeCycleMu.Unlock()
 				select {
 				case <-chConnTerminated:
-					p.logger.Debug("Dialing", nil)
 				default:
 					p.logger.Trace("Dial skip", nil)
+					ds.skip, ds.backoffFactor = 0, 1
+					return
+				}
+
+				if !backoff {
+					ds.skip, ds.backoffFactor = 0, 1
+				} else if ds.skip > 0 {
+					ds.skip--
+					p.logger.Trace("Dial skip due to back-off", nil)
 					return
+				} else {
+					ds.skip = ds.backoffFactor - 1
+					if ds.backoffFactor < maxDialBackoffFactor {
+						ds.backoffFactor *= 2
+					}
 				}
+				p.logger.Debug("Dialing", commontypes.LogFields{"backoff": backoff})
 
 				addresses, err := ho.discoverer.FindPeer(p.other)
 				if err != nil {
@@ -609,16 +815,13 @@ func (ho *Host) dialLoop() {
 
 				logger := p.logger.MakeChild(commontypes.LogFields{"direction": "out", "remoteAddr": address})
 
-				dialer := net.Dialer{
-					Timeout: ho.config.DurationBetweenDials,
-				}
-				conn, err := dialer.DialContext(ho.ctx, "tcp", address)
+				conn, transport, err := ho.dial(p.other, address, logger)
 				if err != nil {
 					logger.Warn("Dial error", commontypes.LogFields{"error": err})
 					return
 				}
 
-				logger.Trace("Dial succeeded", nil)
+				logger.Trace("Dial succeeded", commontypes.LogFields{"transport": transport})
 				ho.subprocesses.Go(func() {
 					ho.handleOutgoingConnection(conn, p.other, logger)
 				})
@@ -629,7 +832,7 @@ func (ho *Host) dialLoop() {
 
 		select {
 		//case <-time.After(5 * time.Second): // good for testing simultaneous dials, real version is on next line
-		case <-time.After(ho.config.DurationBetweenDials + time.Duration(rand.Float32()*float32(ho.config.DurationBetweenDials))):
+		case <-time.After(dialSchedule.Delay(0)):
 		case <-ho.ctx.Done():
 			ho.logger.Trace("Host.dialLoop exiting", nil)
 			return
@@ -637,6 +840,40 @@ func (ho *Host) dialLoop() {
 	}
 }
 
+// dial dials other at address over the transport selected by the QUICConfig,
+// falling back to TCP if allowed.
+func (ho *Host) dial(other types.PeerID, address string, logger loghelper.LoggerWithContext) (net.Conn, Transport, error) {
+	if ho.quic != nil && ho.quic.config.transport(other) == TransportQUIC {
+		ctx, cancel := context.WithTimeout(ho.ctx, ho.config.DurationBetweenDials)
+		defer cancel()
+		conn, err := ho.quic.dial(ctx, other, address)
+		if err == nil {
+			return conn, TransportQUIC, nil
+		}
+		if ho.quic.config.DisableTCPFallback {
+			return nil, TransportQUIC, err
+		}
+		logger.Info("QUIC dial failed, falling back to TCP", commontypes.LogFields{"error": err})
+	}
+	conn, err := ho.dialTCP(address)
+	return conn, TransportTCP, err
+}
+
+func (ho *Host) dialTCP(address string) (net.Conn, error) {
+	if ho.config.Dialer == nil {
+		dialer := net.Dialer{
+			Timeout: ho.config.DurationBetweenDials,
+		}
+		return dialer.DialContext(ho.ctx, "tcp", address)
+	}
+
+	// Use the context to enforce the timeout, since we have no control over
+	// the host-supplied dialer.
+	ctx, cancel := context.WithTimeout(ho.ctx, ho.config.DurationBetweenDials)
+	defer cancel()
+	return ho.config.Dialer.DialContext(ctx, "tcp", address)
+}
+
 func (ho *Host) listenLoop(ln net.Listener) {
 	ho.subprocesses.Go(func() {
 		<-ho.ctx.Done()
@@ -694,6 +931,7 @@ func (ho *Host) handleOutgoingConnection(conn net.Conn, other types.PeerID, logg
 		ho.tlsCert,
 		mtls.VerifyCertMatchesPubKey(other),
 	)
+	tlsConfig.NextProtos = ho.compression.nextProtos()
 	tlsConn := tls.Client(rlConn, tlsConfig)
 	ho.handleConnection(false, rlConn, tlsConn, peer, logger)
 }
@@ -752,6 +990,7 @@ func (ho *Host) handleIncomingConnection(conn net.Conn) {
 		ho.tlsCert,
 		mtls.VerifyCertMatchesPubKey(*other),
 	)
+	tlsConfig.NextProtos = ho.compression.nextProtos()
 	tlsConn := tls.Server(rlConn, tlsConfig)
 	ho.handleConnection(true, rlConn, tlsConn, peer, logger)
 }
@@ -783,7 +1022,8 @@ func (ho *Host) handleConnection(incoming bool, rlConn *ratelimitedconn.RateLimi
 	}
 
 	// get public key
-	pubKey, err := mtls.PubKeyFromCert(tlsConn.ConnectionState().PeerCertificates[0])
+	cert := tlsConn.ConnectionState().PeerCertificates[0]
+	pubKey, err := mtls.PubKeyFromCert(cert)
 	if err != nil {
 		logger.Warn("Closing connection, error getting public key", commontypes.LogFields{"error": err})
 		return
@@ -806,9 +1046,17 @@ func (ho *Host) handleConnection(incoming bool, rlConn *ratelimitedconn.RateLimi
 		}
 	}
 
+	if version, ok := checkPeerVersion(cert, peer, logger); ok {
+		peer.version.Store(&version)
+	} else {
+		peer.version.Store(nil)
+	}
+
+	compression := ho.compression.negotiate(tlsConn.ConnectionState().NegotiatedProtocol, logger)
+
 	rlConn.EnableRateLimiting()
 
-	logger.Info("Connection established", nil)
+	logger.Info("Connection established", commontypes.LogFields{"compression": compression != nil})
 
 	// the lock here ensures there is at most one active connection at any time.
 	// it also prevents races on connLifeCycle.connSubs.
@@ -821,6 +1069,10 @@ func (ho *Host) handleConnection(incoming bool, rlConn *ratelimitedconn.RateLimi
 	peer.connLifeCycle.chConnTerminated = chConnTerminated
 	peer.connLifeCycle.connSubs.Go(func() {
 		defer connCancel()
+		peer.connected.Store(true)
+		defer peer.connected.Store(false)
+		ho.metrics.connectionEstablished(incoming)
+		defer ho.metrics.connectionTerminated()
 		authenticatedConnectionLoop(
 			connCtx,
 			tlsConn,
@@ -828,6 +1080,7 @@ func (ho *Host) handleConnection(incoming bool, rlConn *ratelimitedconn.RateLimi
 			peer.chSelfStreamStateNotification,
 			peer.demuxer,
 			peer.chStreamToConn,
+			compression,
 			chConnTerminated,
 			logger,
 		)
@@ -930,8 +1183,18 @@ func (ho *Host) NewStream(
 
 		p.chStreamCloseRequest,
 		p.chStreamCloseResponse,
+
+		p.chStreamUpdateRequest,
+		p.chStreamUpdateResponse,
+
+		streamStats{},
+		atomic.Bool{},
+
+		atomic.Bool{},
 	}
 
+	p.streams[streamID] = &s
+
 	s.subprocesses.Go(func() {
 		s.receiveLoop()
 	})
@@ -978,6 +1241,83 @@ type Stream struct {
 
 	chStreamCloseRequest  chan<- peerStreamCloseRequest
 	chStreamCloseResponse <-chan peerStreamCloseResponse
+
+	chStreamUpdateRequest  chan<- peerStreamUpdateRequest
+	chStreamUpdateResponse <-chan peerStreamUpdateResponse
+
+	stats streamStats
+
+	compress atomic.Bool
+
+	dialBackoff atomic.Bool
+}
+
+type streamStats struct {
+	sent                atomic.Uint64
+	bytesSent           atomic.Uint64
+	droppedDisconnected atomic.Uint64
+	droppedBackpressure atomic.Uint64
+	queued              atomic.Int64
+}
+
+// StreamStats counts messages on a stream, for estimating message loss on the
+// link to the stream's counterparty. Since the underlying connections are
+// reliable and ordered, messages are only lost at the points counted here, or
+// when a connection breaks while messages are in flight.
+type StreamStats struct {
+	// Outgoing messages handed to the connection for writing.
+	MessagesSent uint64
+	// Outgoing messages evicted from the outgoing buffer while there was no
+	// connection to the counterparty. Indicates network problems or that the
+	// counterparty is down.
+	MessagesDroppedDisconnected uint64
+	// Outgoing messages evicted from the outgoing buffer while there was a
+	// connection to the counterparty, but it didn't keep up. Indicates a slow
+	// counterparty or a congested link.
+	MessagesDroppedBackpressure uint64
+	// Incoming messages read from the connection, including ones later
+	// counted in MessagesDroppedOverflow.
+	MessagesReceived uint64
+	// Incoming messages dropped because the counterparty exceeded the
+	// stream's rate limits.
+	MessagesDroppedRateLimited uint64
+	// Incoming messages evicted from the incoming buffer because the local
+	// consumer didn't keep up.
+	MessagesDroppedOverflow uint64
+	// Payload bytes of MessagesSent.
+	BytesSent uint64
+	// Payload bytes of MessagesReceived.
+	BytesReceived uint64
+	// Fraction of the capacity of the stream's incoming messages and bytes
+	// token buckets that is currently used up. Values close to 1 mean that
+	// the counterparty is about to exceed the stream's rate limits.
+	MessagesLimiterSaturation float64
+	BytesLimiterSaturation    float64
+	// Outgoing messages currently waiting in the outgoing buffer.
+	OutgoingQueueDepth int
+	// Incoming messages currently waiting in the incoming buffer for the
+	// local consumer.
+	IncomingQueueDepth int
+}
+
+// OutgoingLossRate returns the fraction of outgoing messages that were dropped.
+func (s StreamStats) OutgoingLossRate() float64 {
+	dropped := s.MessagesDroppedDisconnected + s.MessagesDroppedBackpressure
+	return lossRate(dropped, s.MessagesSent+dropped)
+}
+
+// IncomingLossRate returns the fraction of incoming messages that were
+// dropped.
+func (s StreamStats) IncomingLossRate() float64 {
+	dropped := s.MessagesDroppedRateLimited + s.MessagesDroppedOverflow
+	return lossRate(dropped, s.MessagesReceived+s.MessagesDroppedRateLimited)
+}
+
+func lossRate(dropped uint64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(dropped) / float64(total)
 }
 
 // Other returns the peer ID of the stream counterparty.
@@ -985,6 +1325,22 @@ func (st *Stream) Other() types.PeerID {
 	return st.other
 }
 
+// PeerVersion returns the version the stream counterparty advertised on the
+// most recently established connection. ok is false if no connection has been
+// established yet or the counterparty didn't advertise a version.
+func (st *Stream) PeerVersion() (version PeerVersion, ok bool) {
+	st.host.peersMu.Lock()
+	p, exists := st.host.peers[st.other]
+	st.host.peersMu.Unlock()
+	if !exists {
+		return PeerVersion{}, false
+	}
+	if v := p.version.Load(); v != nil {
+		return *v, true
+	}
+	return PeerVersion{}, false
+}
+
 // Name returns the name of the stream.
 func (st *Stream) Name() string {
 	return st.name
@@ -998,6 +1354,46 @@ func (st *Stream) SendMessage(data []byte) {
 	}
 }
 
+// SetCompression sets whether messages sent on the stream may be compressed.
+// Compression is disabled by default. It only takes effect if the Host has a
+// CompressionConfig and the stream counterparty has enabled compression, too.
+// Affects messages that haven't been handed to the connection yet.
+func (st *Stream) SetCompression(enabled bool) {
+	st.compress.Store(enabled)
+}
+
+// SetDialBackoff sets whether the Host may dial the stream counterparty less
+// often while there is no connection to it, e.g. because the stream's owner
+// has determined that the network is partitioned. The Host only backs off if
+// all open streams with the counterparty have enabled back-off, in which case
+// the time between dials doubles with every dial, up to
+// maxDialBackoffFactor*DurationBetweenDials. Back-off is disabled by default.
+func (st *Stream) SetDialBackoff(enabled bool) {
+	st.dialBackoff.Store(enabled)
+}
+
+// Stats returns a snapshot of the stream's message and byte counters and of the
+// saturation of its rate limiters.
+func (st *Stream) Stats() StreamStats {
+	// The demuxer forgets about the stream once it's closed, in which case we
+	// report zero incoming counters.
+	demuxStats, _ := st.demux.Stats(st.streamID)
+	return StreamStats{
+		st.stats.sent.Load(),
+		st.stats.droppedDisconnected.Load(),
+		st.stats.droppedBackpressure.Load(),
+		demuxStats.received,
+		demuxStats.droppedRateLimited,
+		demuxStats.droppedOverflow,
+		st.stats.bytesSent.Load(),
+		demuxStats.bytesReceived,
+		demuxStats.messagesLimiterSaturation,
+		demuxStats.bytesLimiterSaturation,
+		int(st.stats.queued.Load()),
+		demuxStats.queued,
+	}
+}
+
 // Best effort receiving of messages. The returned channel will be closed when
 // the stream is closed. Note that this function may return the same channel
 // across invocations.
@@ -1005,6 +1401,35 @@ func (st *Stream) ReceiveMessages() <-chan []byte {
 	return st.chReceive
 }
 
+// SetRateLimits replaces the limits on incoming messages that were passed to
+// NewStream, without affecting the connection to the other peer or any other
+// stream. Tokens that are currently in the buckets are retained, up to the new
+// capacities. The connection-level rate limit is adjusted accordingly.
+func (st *Stream) SetRateLimits(messagesLimit TokenBucketParams, bytesLimit TokenBucketParams) error {
+	st.closedMu.Lock()
+	defer st.closedMu.Unlock()
+
+	if st.closed {
+		return fmt.Errorf("cannot set rate limits of closed stream")
+	}
+
+	select {
+	case st.chStreamUpdateRequest <- peerStreamUpdateRequest{st.streamID, messagesLimit, bytesLimit}:
+		resp := <-st.chStreamUpdateResponse
+		if resp.err != nil {
+			return resp.err
+		}
+	case <-st.ctx.Done():
+		return fmt.Errorf("host shut down")
+	}
+
+	st.logger.Info("Stream rate limits updated", commontypes.LogFields{
+		"messagesLimit": messagesLimit,
+		"bytesLimit":    bytesLimit,
+	})
+	return nil
+}
+
 // Close the stream. This closes any channel returned by ReceiveMessages earlier.
 // After close the stream cannot be reopened. If the stream is needed in the
 // future it should be created again through NewStream.
@@ -1035,9 +1460,10 @@ func (st *Stream) Close() error {
 				})
 				return resp.err
 			}
+			peer := host.peers[st.other]
+			delete(peer.streams, st.streamID)
 			if resp.peerHasNoStreams {
 				st.logger.Trace("Garbage collecting peer", nil)
-				peer := host.peers[st.other]
 				host.subprocesses.Go(func() {
 					peer.connLifeCycleMu.Lock()
 					defer peer.connLifeCycleMu.Unlock()
@@ -1105,8 +1531,17 @@ func (st *Stream) sendLoop() {
 			}
 
 		case msg := <-st.chSend:
-			if ringBuffer.Push(msg) != nil || !pendingFilled {
-				pending = streamIDAndData{st.streamID, ringBuffer.Peek()}
+			evicted := ringBuffer.Push(msg) != nil
+			if evicted {
+				if onOff {
+					st.stats.droppedBackpressure.Add(1)
+				} else {
+					st.stats.droppedDisconnected.Add(1)
+				}
+			}
+			st.stats.queued.Store(int64(ringBuffer.Len()))
+			if evicted || !pendingFilled {
+				pending = streamIDAndData{st.streamID, ringBuffer.Peek(), st.compress.Load()}
 				pendingFilled = true
 				if onOff {
 					chStreamToPeerOrNil = st.chStreamToConn
@@ -1114,9 +1549,12 @@ func (st *Stream) sendLoop() {
 			}
 
 		case chStreamToPeerOrNil <- pending:
+			st.stats.sent.Add(1)
+			st.stats.bytesSent.Add(uint64(len(pending.Data)))
 			ringBuffer.Pop()
+			st.stats.queued.Store(int64(ringBuffer.Len()))
 			if p := ringBuffer.Peek(); p != nil {
-				pending = streamIDAndData{st.streamID, p}
+				pending = streamIDAndData{st.streamID, p, st.compress.Load()}
 			} else {
 				pendingFilled = false
 				chStreamToPeerOrNil = nil
@@ -1139,6 +1577,7 @@ func authenticatedConnectionLoop(
 	chSelfStreamStateNotification <-chan streamStateNotification,
 	demux *demuxer,
 	chWriteData <-chan streamIDAndData,
+	compression *connCompression,
 	chTerminated chan<- struct{},
 	logger loghelper.LoggerWithContext,
 ) {
@@ -1166,6 +1605,7 @@ func authenticatedConnectionLoop(
 			conn,
 			chOtherStreamStateNotification,
 			demux,
+			compression,
 			chReadTerminated,
 			logger,
 		)
@@ -1178,6 +1618,7 @@ func authenticatedConnectionLoop(
 			conn,
 			chSelfStreamStateNotification,
 			chWriteData,
+			compression,
 			chWriteTerminated,
 			logger,
 		)
@@ -1197,6 +1638,7 @@ func authenticatedConnectionReadLoop(
 	conn net.Conn,
 	chOtherStreamStateNotification chan<- streamStateNotification,
 	demux *demuxer,
+	compression *connCompression,
 	chReadTerminated chan<- struct{},
 	logger loghelper.LoggerWithContext,
 ) {
@@ -1292,7 +1734,7 @@ func authenticatedConnectionReadLoop(
 			case <-ctx.Done():
 				return
 			}
-		case frameTypeData:
+		case frameTypeData, frameTypeCompressedData:
 			if MaxMessageLength < header.PayloadLength {
 				logWithHeader(header).Warn("authenticatedConnectionReadLoop: message exceeds ragep2p message length limit, closing connection", commontypes.LogFields{
 					"payloadLength":           header.PayloadLength,
@@ -1300,11 +1742,40 @@ func authenticatedConnectionReadLoop(
 				})
 				return
 			}
-			// Cast to int is safe since header.PayloadLength <= MaxMessageLength <= INT_MAX
-			switch demux.ShouldPush(header.StreamID, int(header.PayloadLength)) {
+			// length of the message, and of the remaining payload, which is
+			// shorter if the message is compressed
+			messageLength, remainingLength := header.PayloadLength, header.PayloadLength
+			compressed := header.Type == frameTypeCompressedData
+			if compressed {
+				if compression == nil {
+					logWithHeader(header).Warn("authenticatedConnectionReadLoop: received compressed message, but compression wasn't negotiated, closing connection", nil)
+					return
+				}
+				if header.PayloadLength < compressedPayloadPrefixSize {
+					logWithHeader(header).Warn("authenticatedConnectionReadLoop: compressed message is too short, closing connection", nil)
+					return
+				}
+				prefix := make([]byte, compressedPayloadPrefixSize)
+				if !readInternal(prefix) {
+					return
+				}
+				messageLength = binary.BigEndian.Uint32(prefix)
+				remainingLength = header.PayloadLength - compressedPayloadPrefixSize
+				// We only ever send compressed messages if that makes them
+				// shorter
+				if MaxMessageLength < messageLength || messageLength <= remainingLength {
+					logWithHeader(header).Warn("authenticatedConnectionReadLoop: compressed message declares invalid decompressed length, closing connection", commontypes.LogFields{
+						"decompressedLength":      messageLength,
+						"ragep2pMaxMessageLength": MaxMessageLength,
+					})
+					return
+				}
+			}
+			// Cast to int is safe since messageLength <= MaxMessageLength <= INT_MAX
+			switch demux.ShouldPush(header.StreamID, int(messageLength)) {
 			case shouldPushResultMessageTooBig:
 				logWithHeader(header).Warn("authenticatedConnectionReadLoop: message too big, closing connection", commontypes.LogFields{
-					"payloadLength": header.PayloadLength,
+					"messageLength": messageLength,
 				})
 				return
 			case shouldPushResultMessagesLimitExceeded:
@@ -1313,7 +1784,7 @@ func authenticatedConnectionReadLoop(
 						"limitsExceededDroppedCount": count,
 					})
 				})
-				if !skipInternal(header.PayloadLength) {
+				if !skipInternal(remainingLength) {
 					return
 				}
 			case shouldPushResultBytesLimitExceeded:
@@ -1322,7 +1793,7 @@ func authenticatedConnectionReadLoop(
 						"limitsExceededDroppedCount": count,
 					})
 				})
-				if !skipInternal(header.PayloadLength) {
+				if !skipInternal(remainingLength) {
 					return
 				}
 			case shouldPushResultUnknownStream:
@@ -1331,7 +1802,7 @@ func authenticatedConnectionReadLoop(
 						"unknownStreamIDDroppedCount": count,
 					})
 				})
-				if !skipInternal(header.PayloadLength) {
+				if !skipInternal(remainingLength) {
 					return
 				}
 			case shouldPushResultYes:
@@ -1340,10 +1811,25 @@ func authenticatedConnectionReadLoop(
 						"droppedCount": oldCount,
 					})
 				})
-				data := make([]byte, header.PayloadLength)
+				data := make([]byte, remainingLength)
 				if !readInternal(data) {
 					return
 				}
+				if compressed {
+					var err error
+					// Only now that the demuxer has checked messageLength
+					// against the stream's maximum message length and
+					// charged it to the stream's rate limit do we allocate
+					// memory for the decompressed message.
+					data, err = compression.decompress(data, int(messageLength))
+					if err != nil {
+						logWithHeader(header).Warn("authenticatedConnectionReadLoop: failed to decompress message, closing connection", commontypes.LogFields{
+							"decompressedLength": messageLength,
+							"error":              err,
+						})
+						return
+					}
+				}
 				switch demux.PushMessage(header.StreamID, data) {
 				case pushResultSuccess:
 				case pushResultDropped:
@@ -1373,6 +1859,7 @@ func authenticatedConnectionWriteLoop(
 	conn net.Conn,
 	chSelfStreamStateNotification <-chan streamStateNotification,
 	chWriteData <-chan streamIDAndData,
+	compression *connCompression,
 	chWriteTerminated chan<- struct{},
 	logger loghelper.LoggerWithContext,
 ) {
@@ -1393,19 +1880,25 @@ func authenticatedConnectionWriteLoop(
 	for {
 		select {
 		case data := <-chWriteData:
+			typ, payload := frameTypeData, data.Data
+			if compression != nil && data.Compress {
+				if compressed, ok := compression.compress(data.Data); ok {
+					typ, payload = frameTypeCompressedData, compressed
+				}
+			}
 			if err := conn.SetWriteDeadline(time.Now().Add(netTimeout)); err != nil {
 				logger.Warn("Closing connection, error during SetWriteDeadline", commontypes.LogFields{"error": err})
 				return
 			}
 			header := frameHeader{
-				frameTypeData,
+				typ,
 				data.StreamID,
-				uint32(len(data.Data)),
+				uint32(len(payload)),
 			}
 			if !writeInternal(header.Encode()) {
 				return
 			}
-			if !writeInternal(data.Data) {
+			if !writeInternal(payload) {
 				return
 			}
 		case notification := <-chSelfStreamStateNotification:
@@ -0,0 +1,87 @@
+package ragep2p
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/smartcontractkit/libocr/ragep2p/types"
+)
+
+// HostSnapshot captures the parts of a Host's state that are worth carrying
+// over to a standby Host with the same identity in an active/passive HA setup.
+//
+// Connections themselves can't be carried over since they are bound to TLS
+// sessions of the active Host. But knowing the addresses of all peers lets the
+// standby dial them as soon as it takes over, instead of having to wait for
+// discovery to converge first.
+//
+// HostSnapshot can be serialized with encoding/json.
+type HostSnapshot struct {
+	// Time at which the snapshot was taken.
+	Time  time.Time
+	Peers []PeerSnapshot
+}
+
+type PeerSnapshot struct {
+	ID        types.PeerID
+	Addresses []types.Address
+}
+
+// Snapshot returns the addresses of all peers the Host currently has streams
+// with, as known to its Discoverer. Peers without known addresses are
+// omitted.
+func (ho *Host) Snapshot() HostSnapshot {
+	ho.peersMu.Lock()
+	ids := make([]types.PeerID, 0, len(ho.peers))
+	for id := range ho.peers {
+		ids = append(ids, id)
+	}
+	ho.peersMu.Unlock()
+
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+
+	peers := make([]PeerSnapshot, 0, len(ids))
+	for _, id := range ids {
+		addresses, err := ho.discoverer.FindPeer(id)
+		if err != nil || len(addresses) == 0 {
+			continue
+		}
+		peers = append(peers, PeerSnapshot{id, addresses})
+	}
+	return HostSnapshot{time.Now(), peers}
+}
+
+type prewarmedDiscoverer struct {
+	Discoverer
+	addresses map[types.PeerID][]types.Address
+}
+
+// NewPrewarmedDiscoverer wraps discoverer such that FindPeer falls back to the
+// addresses in snapshot for peers whose addresses discoverer doesn't know
+// (yet). Pass the result to NewHost when a standby takes over from an active
+// Host.
+//
+// Don't start the standby Host before the active Host has shut down: since
+// both share the same identity, they would keep displacing each other's
+// connections.
+func NewPrewarmedDiscoverer(discoverer Discoverer, snapshot HostSnapshot) Discoverer {
+	addresses := make(map[types.PeerID][]types.Address, len(snapshot.Peers))
+	for _, p := range snapshot.Peers {
+		addresses[p.ID] = p.Addresses
+	}
+	return prewarmedDiscoverer{discoverer, addresses}
+}
+
+func (d prewarmedDiscoverer) FindPeer(peer types.PeerID) ([]types.Address, error) {
+	addresses, err := d.Discoverer.FindPeer(peer)
+	if err == nil && len(addresses) != 0 {
+		return addresses, nil
+	}
+	if snapshotAddresses, ok := d.addresses[peer]; ok {
+		return snapshotAddresses, nil
+	}
+	return addresses, err
+}
@@ -0,0 +1,57 @@
+package ragep2p
+
+import (
+	"github.com/smartcontractkit/libocr/ragep2p/types"
+)
+
+// PeerStats describes the traffic between a Host and one remote peer, for
+// diagnosing which streams (and thus which protocol instances) saturate the
+// link to that peer.
+type PeerStats struct {
+	// Stats of each open stream with the peer, by stream name.
+	Streams map[string]StreamStats
+	// Fraction of the capacity of the connection-level token bucket that is
+	// currently used up. The connection-level limit is derived from the limits
+	// of all streams with the peer, see NewStream.
+	ConnectionLimiterSaturation float64
+	// Whether there currently is an authenticated connection with the peer.
+	Connected bool
+}
+
+// Totals returns the sum of the counters and queue depths of all streams. The limiter
+// saturations of the result are the maxima across all streams.
+func (ps PeerStats) Totals() StreamStats {
+	var total StreamStats
+	for _, s := range ps.Streams {
+		total.MessagesSent += s.MessagesSent
+		total.MessagesDroppedDisconnected += s.MessagesDroppedDisconnected
+		total.MessagesDroppedBackpressure += s.MessagesDroppedBackpressure
+		total.MessagesReceived += s.MessagesReceived
+		total.MessagesDroppedRateLimited += s.MessagesDroppedRateLimited
+		total.MessagesDroppedOverflow += s.MessagesDroppedOverflow
+		total.BytesSent += s.BytesSent
+		total.BytesReceived += s.BytesReceived
+		total.MessagesLimiterSaturation = max(total.MessagesLimiterSaturation, s.MessagesLimiterSaturation)
+		total.BytesLimiterSaturation = max(total.BytesLimiterSaturation, s.BytesLimiterSaturation)
+		total.OutgoingQueueDepth += s.OutgoingQueueDepth
+		total.IncomingQueueDepth += s.IncomingQueueDepth
+	}
+	return total
+}
+
+// PeerStats returns the stats of all remote peers the Host currently has open
+// streams with. Counters of closed streams are not included.
+func (ho *Host) PeerStats() map[types.PeerID]PeerStats {
+	ho.peersMu.Lock()
+	defer ho.peersMu.Unlock()
+
+	result := make(map[types.PeerID]PeerStats, len(ho.peers))
+	for id, p := range ho.peers {
+		streams := make(map[string]StreamStats, len(p.streams))
+		for _, st := range p.streams {
+			streams[st.name] = st.Stats()
+		}
+		result[id] = PeerStats{streams, p.connRateLimiter.Saturation(), p.connected.Load()}
+	}
+	return result
+}
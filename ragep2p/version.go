@@ -0,0 +1,105 @@
+package ragep2p
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+)
+
+// ProtocolVersion is the version of the ragep2p wire protocol spoken by this
+// library. It must be incremented whenever a change makes ragep2p incompatible
+// with peers running the previous version.
+const ProtocolVersion = 1
+
+// PeerVersion is the version information a peer advertises when establishing
+// a connection.
+type PeerVersion struct {
+	ProtocolVersion int
+}
+
+func (v PeerVersion) String() string {
+	return versionCommonNamePrefix + strconv.Itoa(v.ProtocolVersion)
+}
+
+// The version is advertised as the subject common name of the self-signed
+// certificate used for the TLS handshake, e.g. "ragep2p/1". Peers that predate
+// the version exchange leave the common name empty and ignore it, so
+// advertising our version doesn't affect compatibility with them. Since the
+// certificate is covered by the handshake, the advertised version is
+// authenticated.
+//
+// The certificate is sent before the other side has authenticated itself, so
+// it carries only the protocol version, which reveals nothing beyond what the
+// peer's behavior on the wire would reveal anyway.
+const versionCommonNamePrefix = "ragep2p/"
+
+func versionCommonName() string {
+	return PeerVersion{ProtocolVersion}.String()
+}
+
+// versionFromCert returns the version advertised in cert. ok is false if cert
+// doesn't contain a version, e.g. because the peer predates the version
+// exchange.
+func versionFromCert(cert *x509.Certificate) (version PeerVersion, ok bool, err error) {
+	commonName := cert.Subject.CommonName
+	if commonName == "" {
+		return PeerVersion{}, false, nil
+	}
+	s, found := strings.CutPrefix(commonName, versionCommonNamePrefix)
+	if !found {
+		return PeerVersion{}, false, fmt.Errorf("common name %q lacks prefix %q", commonName, versionCommonNamePrefix)
+	}
+	protocolVersion, err := strconv.Atoi(s)
+	if err != nil {
+		return PeerVersion{}, false, fmt.Errorf("failed to parse protocol version of common name %q: %w", commonName, err)
+	}
+	return PeerVersion{protocolVersion}, true, nil
+}
+
+// checkPeerVersion logs a warning if the version advertised by the peer on
+// the other end of a connection suggests that it's incompatible with or
+// outdated relative to us. Such mismatches typically cause subtle failures
+// higher up the stack, so we want them to be easy to spot in the logs. The
+// connection is kept regardless, since ragep2p itself may well still work.
+//
+// A peer typically reconnects many times until it's upgraded, so warnings are
+// tapered per peer.
+func checkPeerVersion(cert *x509.Certificate, peer *peer, logger loghelper.LoggerWithContext) (PeerVersion, bool) {
+	remote, ok, err := versionFromCert(cert)
+	fields := commontypes.LogFields{"localVersion": versionCommonName()}
+	var warning string
+	switch {
+	case err != nil:
+		warning = "Peer advertised malformed version"
+		fields["error"] = err
+	case !ok:
+		warning = "Peer did not advertise a version, it likely runs an outdated version of libocr"
+	case remote.ProtocolVersion != ProtocolVersion:
+		warning = "Peer advertised incompatible ragep2p protocol version"
+		fields["remoteVersion"] = remote.String()
+	}
+
+	peer.versionMismatchTaperMu.Lock()
+	defer peer.versionMismatchTaperMu.Unlock()
+	if warning == "" {
+		peer.versionMismatchTaper.Reset(func(oldCount uint64) {
+			logger.Info("Peer advertised compatible version again", commontypes.LogFields{
+				"localVersion":          versionCommonName(),
+				"mismatchedConnections": oldCount,
+			})
+		})
+	} else {
+		peer.versionMismatchTaper.Trigger(func(newCount uint64) {
+			fields["mismatchedConnections"] = newCount
+			logger.Warn(warning, fields)
+		})
+	}
+	if err != nil || !ok {
+		return PeerVersion{}, false
+	}
+	return remote, true
+}